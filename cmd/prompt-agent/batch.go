@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/images"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// batchItem is one line of a -batch input file. Protocol defaults to the
+// -protocol flag; tools requests use the -tools-file definitions.
+type batchItem struct {
+	ID       string         `json:"id,omitempty"`
+	Prompt   string         `json:"prompt"`
+	Protocol string         `json:"protocol,omitempty"`
+	Images   []string       `json:"images,omitempty"`
+	Options  map[string]any `json:"options,omitempty"`
+}
+
+// batchResult is one line of the batch output. Results are written in input
+// order regardless of concurrency.
+type batchResult struct {
+	ID        string               `json:"id,omitempty"`
+	Line      int                  `json:"line"`
+	Protocol  string               `json:"protocol"`
+	Content   string               `json:"content,omitempty"`
+	ToolCalls []response.ToolCall  `json:"tool_calls,omitempty"`
+	Embedding []float64            `json:"embedding,omitempty"`
+	Usage     *response.TokenUsage `json:"usage,omitempty"`
+	LatencyMS int64                `json:"latency_ms"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// batchConfig holds the batch flags and shared request settings.
+type batchConfig struct {
+	input       string
+	output      string
+	concurrency int
+	protocol    string
+	timeout     time.Duration
+	tools       []agent.Tool
+	imageMaxDim int
+	options     func(protocol string) map[string]any
+}
+
+// readBatch parses a JSONL batch file, skipping blank lines.
+func readBatch(path string) ([]batchItem, []int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var (
+		items []batchItem
+		lines []int
+	)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var item batchItem
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if item.Prompt == "" {
+			return nil, nil, fmt.Errorf("line %d: prompt is required", n)
+		}
+		items = append(items, item)
+		lines = append(lines, n)
+	}
+	return items, lines, scanner.Err()
+}
+
+// runBatch executes every batch item with bounded concurrency and writes one
+// JSON result per item. Item failures are recorded in their result; the
+// returned count reports how many failed.
+func runBatch(a agent.Agent, cfg batchConfig) (int, error) {
+	items, lines, err := readBatch(cfg.input)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read batch file: %w", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if cfg.output != "" && cfg.output != "-" {
+		f, err := os.Create(cfg.output)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create batch output: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	results := make([]chan batchResult, len(items))
+	for i := range results {
+		results[i] = make(chan batchResult, 1)
+	}
+
+	sem := make(chan struct{}, max(cfg.concurrency, 1))
+	var wg sync.WaitGroup
+
+	go func() {
+		for i, item := range items {
+			sem <- struct{}{}
+			wg.Go(func() {
+				defer func() { <-sem }()
+				result := runBatchItem(a, item, cfg)
+				result.Line = lines[i]
+				results[i] <- result
+			})
+		}
+	}()
+
+	enc := json.NewEncoder(out)
+	failed := 0
+	for i := range items {
+		result := <-results[i]
+		if result.Error != "" {
+			failed++
+		}
+		if err := enc.Encode(result); err != nil {
+			return failed, fmt.Errorf("failed to write batch result: %w", err)
+		}
+	}
+
+	wg.Wait()
+	return failed, nil
+}
+
+// runBatchItem executes a single batch item with its own timeout.
+func runBatchItem(a agent.Agent, item batchItem, cfg batchConfig) batchResult {
+	protocol := item.Protocol
+	if protocol == "" {
+		protocol = cfg.protocol
+	}
+	result := batchResult{ID: item.ID, Protocol: protocol}
+
+	// Item options take precedence over command-line overrides
+	opts := cfg.options(protocol)
+	maps.Copy(opts, item.Options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := func() error {
+		if len(item.Images) > 0 {
+			prepared, err := images.Prepare(ctx, item.Images, images.WithMaxDimension(cfg.imageMaxDim))
+			if err != nil {
+				return fmt.Errorf("failed to prepare images: %w", err)
+			}
+			item.Images = prepared
+		}
+
+		switch protocol {
+		case "chat":
+			resp, err := a.Chat(ctx, item.Prompt, opts)
+			if err != nil {
+				return err
+			}
+			result.Content, result.Usage = resp.Content(), resp.Usage
+		case "vision":
+			resp, err := a.Vision(ctx, item.Prompt, item.Images, opts)
+			if err != nil {
+				return err
+			}
+			result.Content, result.Usage = resp.Content(), resp.Usage
+		case "tools":
+			var (
+				resp *response.ToolsResponse
+				err  error
+			)
+			if len(item.Images) > 0 {
+				resp, err = a.VisionTools(ctx, item.Prompt, item.Images, cfg.tools, opts)
+			} else {
+				resp, err = a.Tools(ctx, item.Prompt, cfg.tools, opts)
+			}
+			if err != nil {
+				return err
+			}
+			if len(resp.Choices) > 0 {
+				result.Content = resp.Choices[0].Message.Content
+			}
+			result.ToolCalls, result.Usage = resp.ToolCalls(), resp.Usage
+		case "embeddings":
+			resp, err := a.Embed(ctx, item.Prompt, opts)
+			if err != nil {
+				return err
+			}
+			if len(resp.Data) > 0 {
+				result.Embedding = resp.Data[0].Embedding
+			}
+			result.Usage = resp.Usage
+		default:
+			return fmt.Errorf("unknown protocol: %s", protocol)
+		}
+		return nil
+	}()
+	result.LatencyMS = time.Since(start).Milliseconds()
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
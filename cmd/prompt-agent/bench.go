@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// countingTransport counts HTTP attempts so retries can be derived from the
+// difference between attempts and requests.
+type countingTransport struct {
+	next     http.RoundTripper
+	attempts atomic.Int64
+}
+
+// poolTransport builds a transport from the client's connection pool
+// settings, matching the client's own, for wrapping by the CLI transports.
+func poolTransport(cfg *config.ClientConfig) http.RoundTripper {
+	return &http.Transport{
+		MaxIdleConns:        cfg.ConnectionPoolSize,
+		MaxIdleConnsPerHost: cfg.ConnectionPoolSize,
+		IdleConnTimeout:     cfg.ConnectionTimeout.ToDuration(),
+	}
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempts.Add(1)
+	return t.next.RoundTrip(req)
+}
+
+// benchConfig holds the benchmark flags and request settings.
+type benchConfig struct {
+	requests    int
+	concurrency int
+	protocol    string
+	prompt      string
+	images      []string
+	tools       []agent.Tool
+	stream      bool
+	timeout     time.Duration
+	options     map[string]any
+	transport   *countingTransport
+}
+
+// benchSample records the outcome of a single benchmark request.
+// TTFT and tokens are only set for successful streaming requests.
+type benchSample struct {
+	latency time.Duration
+	ttft    time.Duration
+	tokens  int
+	err     error
+}
+
+// runBench fires the configured number of requests with bounded concurrency
+// and prints latency percentiles, throughput, error rate, and retry counts.
+func runBench(a agent.Agent, cfg benchConfig) {
+	samples := make([]benchSample, cfg.requests)
+	sem := make(chan struct{}, max(cfg.concurrency, 1))
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for i := range cfg.requests {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			samples[i] = benchOnce(a, cfg)
+		})
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	printBenchReport(cfg, samples, elapsed)
+}
+
+// benchOnce executes a single request with its own timeout.
+func benchOnce(a agent.Agent, cfg benchConfig) benchSample {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+
+	start := time.Now()
+	var sample benchSample
+
+	switch {
+	case cfg.stream && (cfg.protocol == "chat" || cfg.protocol == "vision"):
+		var (
+			stream <-chan *response.StreamingChunk
+			err    error
+		)
+		if cfg.protocol == "vision" {
+			stream, err = a.VisionStream(ctx, cfg.prompt, cfg.images, cfg.options)
+		} else {
+			stream, err = a.ChatStream(ctx, cfg.prompt, cfg.options)
+		}
+		if err != nil {
+			sample.err = err
+			break
+		}
+
+		// Usage is reported on the final chunk when the provider includes it;
+		// otherwise content chunks approximate the token count.
+		chunks := 0
+		for chunk := range stream {
+			if chunk.Error != nil {
+				sample.err = chunk.Error
+				continue
+			}
+			if chunk.Content() != "" {
+				if chunks == 0 {
+					sample.ttft = time.Since(start)
+				}
+				chunks++
+			}
+			if chunk.Usage != nil {
+				sample.tokens = chunk.Usage.CompletionTokens
+			}
+		}
+		if sample.tokens == 0 {
+			sample.tokens = chunks
+		}
+	case cfg.protocol == "chat":
+		_, sample.err = a.Chat(ctx, cfg.prompt, cfg.options)
+	case cfg.protocol == "vision":
+		_, sample.err = a.Vision(ctx, cfg.prompt, cfg.images, cfg.options)
+	case cfg.protocol == "tools" && len(cfg.images) > 0:
+		_, sample.err = a.VisionTools(ctx, cfg.prompt, cfg.images, cfg.tools, cfg.options)
+	case cfg.protocol == "tools":
+		_, sample.err = a.Tools(ctx, cfg.prompt, cfg.tools, cfg.options)
+	case cfg.protocol == "embeddings":
+		_, sample.err = a.Embed(ctx, cfg.prompt, cfg.options)
+	default:
+		sample.err = fmt.Errorf("unknown protocol: %s", cfg.protocol)
+	}
+
+	sample.latency = time.Since(start)
+	return sample
+}
+
+// printBenchReport summarizes benchmark samples. Latency percentiles cover
+// successful requests only.
+func printBenchReport(cfg benchConfig, samples []benchSample, elapsed time.Duration) {
+	var (
+		latencies, ttfts []time.Duration
+		tokens           int
+		generation       time.Duration
+		errs             = make(map[string]int)
+	)
+	for _, s := range samples {
+		if s.err != nil {
+			errs[s.err.Error()]++
+			continue
+		}
+		latencies = append(latencies, s.latency)
+		if s.ttft > 0 {
+			ttfts = append(ttfts, s.ttft)
+			tokens += s.tokens
+			generation += s.latency - s.ttft
+		}
+	}
+
+	failed := len(samples) - len(latencies)
+	fmt.Printf("Requests:    %d (concurrency %d, protocol %s, stream %t)\n", len(samples), max(cfg.concurrency, 1), cfg.protocol, cfg.stream)
+	fmt.Printf("Duration:    %s\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("Throughput:  %.2f req/s\n", float64(len(samples))/elapsed.Seconds())
+	fmt.Printf("Errors:      %d (%.1f%%)\n", failed, 100*float64(failed)/float64(len(samples)))
+
+	attempts := int(cfg.transport.attempts.Load())
+	fmt.Printf("Retries:     %d (%d HTTP attempts)\n", max(attempts-len(samples), 0), attempts)
+
+	if len(latencies) > 0 {
+		fmt.Println()
+		printPercentiles("Latency", latencies)
+	}
+
+	if len(ttfts) > 0 {
+		printPercentiles("TTFT", ttfts)
+		if generation > 0 {
+			fmt.Printf("\nStreaming:   %.1f tokens/s per request (%d tokens)\n", float64(tokens)/generation.Seconds(), tokens)
+		}
+	}
+
+	if len(errs) > 0 {
+		fmt.Println("\nError breakdown:")
+		for _, msg := range slices.Sorted(maps.Keys(errs)) {
+			fmt.Printf("  %4d  %s\n", errs[msg], msg)
+		}
+	}
+}
+
+// printPercentiles prints min, mean, p50/p90/p95/p99, and max of durations
+// using the nearest-rank method.
+func printPercentiles(label string, durations []time.Duration) {
+	slices.Sort(durations)
+
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+
+	percentile := func(p float64) time.Duration {
+		rank := int(math.Ceil(p/100*float64(len(durations)))) - 1
+		return durations[min(max(rank, 0), len(durations)-1)]
+	}
+
+	round := func(d time.Duration) time.Duration { return d.Round(time.Millisecond / 10) }
+	fmt.Printf("%-12s min %s  mean %s  p50 %s  p90 %s  p95 %s  p99 %s  max %s\n",
+		label+":",
+		round(durations[0]),
+		round(sum/time.Duration(len(durations))),
+		round(percentile(50)),
+		round(percentile(90)),
+		round(percentile(95)),
+		round(percentile(99)),
+		round(durations[len(durations)-1]),
+	)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+// debugTransport prints every HTTP attempt to w: the request URL, headers,
+// and body, then the response status, headers, and body, redacted by the
+// default redaction policy.
+// Attempts are numbered so retries of the same request are visible.
+// Streaming response bodies are printed line by line as they are read.
+type debugTransport struct {
+	next     http.RoundTripper
+	w        io.Writer
+	mu       sync.Mutex
+	attempts atomic.Int64
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempt := t.attempts.Add(1)
+
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	t.printf("--> #%d %s %s\n%s\n%s\n\n", attempt, req.Method, request.RedactURL(req.URL), formatHeaders(request.RedactHeaders(req.Header)), redact.Default().Body(body))
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.printf("<-- #%d error after %s: %v\n\n", attempt, time.Since(start).Round(time.Millisecond), err)
+		return nil, err
+	}
+
+	t.printf("<-- #%d %s (%s)\n%s\n", attempt, resp.Status, time.Since(start).Round(time.Millisecond), formatHeaders(request.RedactHeaders(resp.Header)))
+	resp.Body = &debugBody{ReadCloser: resp.Body, t: t, attempt: attempt}
+	return resp, nil
+}
+
+func (t *debugTransport) printf(format string, args ...any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.w, format, args...)
+}
+
+// debugBody echoes a response body to the debug output as it is read.
+// Output is redacted a line at a time, holding back text after the last
+// newline, so a secret split across reads is still matched whole.
+type debugBody struct {
+	io.ReadCloser
+	t       *debugTransport
+	attempt int64
+	mu      sync.Mutex
+	pending []byte
+	done    sync.Once
+}
+
+func (b *debugBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.echo(p[:n])
+	}
+	if err != nil {
+		b.finish()
+	}
+	return n, err
+}
+
+// echo prints the complete lines read so far and keeps the rest pending.
+func (b *debugBody) echo(data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = append(b.pending, data...)
+	if i := bytes.LastIndexByte(b.pending, '\n'); i >= 0 {
+		b.t.printf("%s", redact.Default().String(string(b.pending[:i+1])))
+		b.pending = slices.Clone(b.pending[i+1:])
+	}
+}
+
+func (b *debugBody) Close() error {
+	b.finish()
+	return b.ReadCloser.Close()
+}
+
+func (b *debugBody) finish() {
+	b.done.Do(func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.t.printf("%s\n<-- #%d end of body\n\n", redact.Default().String(string(b.pending)), b.attempt)
+		b.pending = nil
+	})
+}
+
+// formatHeaders renders headers one per line in sorted order.
+func formatHeaders(headers map[string]string) string {
+	var b bytes.Buffer
+	for _, key := range slices.Sorted(maps.Keys(headers)) {
+		fmt.Fprintf(&b, "    %s: %s\n", key, headers[key])
+	}
+	return b.String()
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// readInputs reads one embeddings input per non-blank line.
+func readInputs(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var inputs []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			inputs = append(inputs, line)
+		}
+	}
+	return inputs, scanner.Err()
+}
+
+// embedInputs embeds inputs in batches of batchSize, sending each batch as a
+// single multi-input embeddings request with its own timeout. Vectors are
+// returned in input order.
+func embedInputs(a agent.Agent, inputs []string, batchSize int, timeout time.Duration, opts map[string]any) ([][]float64, error) {
+	batchSize = max(batchSize, 1)
+	options := maps.Clone(a.Model().Options[protocol.Embeddings])
+	if options == nil {
+		options = make(map[string]any)
+	}
+	maps.Copy(options, opts)
+
+	vectors := make([][]float64, 0, len(inputs))
+	for start := 0; start < len(inputs); start += batchSize {
+		batch := inputs[start:min(start+batchSize, len(inputs))]
+
+		req := request.NewEmbeddings(a.Provider(), a.Model(), batch, maps.Clone(options))
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		result, err := a.Client().Execute(ctx, req)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("inputs %d-%d: %w", start+1, start+len(batch), err)
+		}
+
+		resp, ok := result.(*response.EmbeddingsResponse)
+		if !ok {
+			return nil, fmt.Errorf("unexpected response type: %T", result)
+		}
+		if len(resp.Data) != len(batch) {
+			return nil, fmt.Errorf("inputs %d-%d: got %d embeddings, want %d", start+1, start+len(batch), len(resp.Data), len(batch))
+		}
+
+		ordered := make([][]float64, len(batch))
+		for i, data := range resp.Data {
+			index := data.Index
+			if index < 0 || index >= len(batch) || ordered[index] != nil {
+				index = i
+			}
+			ordered[index] = data.Embedding
+		}
+		vectors = append(vectors, ordered...)
+	}
+
+	return vectors, nil
+}
+
+// writeVectors writes vectors to path in the format selected by its
+// extension: .jsonl, .csv, or .npy.
+func writeVectors(path string, inputs []string, vectors [][]float64) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".jsonl":
+		err = writeJSONL(w, inputs, vectors)
+	case ".csv":
+		err = writeCSV(w, inputs, vectors)
+	case ".npy":
+		err = writeNPY(w, vectors)
+	default:
+		err = fmt.Errorf("unsupported output format %q (use .jsonl, .csv, or .npy)", ext)
+	}
+
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// writeJSONL writes one {"index", "input", "embedding"} object per line.
+func writeJSONL(w io.Writer, inputs []string, vectors [][]float64) error {
+	enc := json.NewEncoder(w)
+	for i, vector := range vectors {
+		record := struct {
+			Index     int       `json:"index"`
+			Input     string    `json:"input"`
+			Embedding []float64 `json:"embedding"`
+		}{i, inputs[i], vector}
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV writes a header row followed by one row per input: the input text
+// and one column per dimension.
+func writeCSV(w io.Writer, inputs []string, vectors [][]float64) error {
+	cw := csv.NewWriter(w)
+
+	dims := 0
+	for _, vector := range vectors {
+		dims = max(dims, len(vector))
+	}
+
+	header := make([]string, 0, dims+1)
+	header = append(header, "input")
+	for i := range dims {
+		header = append(header, "d"+strconv.Itoa(i))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i, vector := range vectors {
+		row := make([]string, 0, len(vector)+1)
+		row = append(row, inputs[i])
+		for _, value := range vector {
+			row = append(row, strconv.FormatFloat(value, 'g', -1, 64))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeNPY writes vectors as a little-endian float64 NumPy array of shape
+// (inputs, dimensions) in .npy format version 1.0. All vectors must have the
+// same dimension.
+func writeNPY(w io.Writer, vectors [][]float64) error {
+	dims := 0
+	if len(vectors) > 0 {
+		dims = len(vectors[0])
+	}
+	for i, vector := range vectors {
+		if len(vector) != dims {
+			return fmt.Errorf("embedding %d has %d dimensions, want %d", i, len(vector), dims)
+		}
+	}
+
+	// The header is padded with spaces and terminated by a newline so the
+	// data starts on a 64-byte boundary.
+	header := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': (%d, %d), }", len(vectors), dims)
+	const preamble = 10
+	padding := 64 - (preamble+len(header)+1)%64
+	if padding == 64 {
+		padding = 0
+	}
+	header += strings.Repeat(" ", padding) + "\n"
+	if len(header) > math.MaxUint16 {
+		return errors.New("npy header too large")
+	}
+
+	if _, err := io.WriteString(w, "\x93NUMPY\x01\x00"); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint16(len(header))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	for _, vector := range vectors {
+		if err := binary.Write(w, binary.LittleEndian, vector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+// starterConfigs are the commented configs written by the init subcommand,
+// keyed by provider name.
+var starterConfigs = map[string]string{
+	"ollama": `{
+  // Agent name, used in logs and audit records
+  "name": "ollama-agent",
+
+  // Optional system prompt sent with chat, vision, and tools requests
+  "system_prompt": "You are a helpful assistant.",
+
+  "client": {
+    // Time allowed for each request, including retries
+    "timeout": "2m",
+    "retry": {
+      "max_retries": 3,
+      "initial_backoff": "1s",
+      "max_backoff": "30s",
+      "backoff_multiplier": 2.0,
+      "jitter": true
+    },
+    "connection_pool_size": 10,
+    "connection_timeout": "30s"
+  },
+
+  "provider": {
+    "name": "ollama",
+    // Local Ollama server; /v1 is appended automatically
+    "base_url": "http://localhost:11434",
+    "options": {
+      // Only needed behind an authenticating proxy:
+      // "auth_type": "bearer",
+      // "token_file": "/path/to/token"
+    }
+  },
+
+  "model": {
+    // Any model pulled with "ollama pull"
+    "name": "llama3.2:3b",
+    // Default request options per protocol (chat, vision, tools, embeddings)
+    "capabilities": {
+      "chat": {
+        "temperature": 0.7,
+        "max_tokens": 4096
+      }
+    }
+  }
+}
+`,
+	"azure": `{
+  // Agent name, used in logs and audit records
+  "name": "azure-agent",
+
+  // Optional system prompt sent with chat, vision, and tools requests
+  "system_prompt": "You are a helpful assistant.",
+
+  "client": {
+    // Time allowed for each request, including retries
+    "timeout": "2m",
+    "retry": {
+      "max_retries": 3,
+      "initial_backoff": "1s",
+      "max_backoff": "30s",
+      "backoff_multiplier": 2.0,
+      "jitter": true
+    },
+    "connection_pool_size": 10,
+    "connection_timeout": "30s"
+  },
+
+  "provider": {
+    "name": "azure",
+    // Your resource endpoint, ending in /openai
+    "base_url": "https://YOUR-RESOURCE.openai.azure.com/openai",
+    "options": {
+      // Deployment name from Azure AI Foundry (not necessarily the model name)
+      "deployment": "gpt-4o",
+      "api_version": "2024-10-21",
+      // "api_key" sends the api-key header; "bearer" sends an Entra ID token
+      "auth_type": "api_key"
+      // Credential: pass -token or set TAU_PROVIDER_OPTIONS_TOKEN rather than
+      // storing it here, or read it from a file or command:
+      // "token_file": "/path/to/azure-openai-key"
+      // "token_command": "az account get-access-token --resource https://cognitiveservices.azure.com --query accessToken -o tsv"
+    }
+  },
+
+  "model": {
+    "name": "gpt-4o",
+    // Default request options per protocol (chat, vision, tools, embeddings)
+    "capabilities": {
+      "chat": {
+        "temperature": 0.7,
+        "max_tokens": 4096
+      }
+    }
+  }
+}
+`,
+}
+
+// runInit writes a commented starter config for a provider.
+func runInit(args []string) error {
+	flags := flag.NewFlagSet("init", flag.ExitOnError)
+	provider := flags.String("provider", "ollama", "Provider to configure ("+strings.Join(slices.Sorted(maps.Keys(starterConfigs)), ", ")+")")
+	output := flags.String("output", "config.json", "File to write the config to (- writes to stdout)")
+	force := flags.Bool("force", false, "Overwrite an existing file")
+	flags.Parse(args)
+
+	starter, ok := starterConfigs[*provider]
+	if !ok {
+		return fmt.Errorf("no starter config for provider %q (available: %s)", *provider, strings.Join(slices.Sorted(maps.Keys(starterConfigs)), ", "))
+	}
+
+	if *output == "-" {
+		fmt.Print(starter)
+		return nil
+	}
+
+	if !*force {
+		if _, err := os.Stat(*output); err == nil {
+			return fmt.Errorf("%s already exists (use -force to overwrite)", *output)
+		}
+	}
+
+	// Owner-only permissions since the file may later hold a token
+	if err := os.WriteFile(*output, []byte(starter), 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %s config to %s\n", *provider, *output)
+	fmt.Printf("Edit it, then check it with: prompt-agent validate -config %s\n", *output)
+	return nil
+}
+
+// runValidate loads configs the same way a request would (files, then TAU_*
+// environment variables), validates the result, and checks that the provider
+// can be created from it. It reports every problem found and returns an error
+// if there were any.
+func runValidate(args []string) error {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	var configs config.Files
+	flags.Var(&configs, "config", "Configuration file to validate; repeat or comma-separate to overlay files in order (default config.json)")
+	flags.Parse(args)
+
+	name := configs.String()
+	if name == "" {
+		name = config.DefaultConfigFile
+		if _, err := os.Stat(name); errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%s not found (create one with: prompt-agent init)", name)
+		}
+	}
+
+	cfg, err := config.Load("TAU", configs...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	var problems []string
+	if err := cfg.Validate(); err != nil {
+		for _, e := range unwrapJoined(err) {
+			problems = append(problems, e.Error())
+		}
+	}
+
+	// Provider-specific options (deployment, credentials) are checked when
+	// the provider is created
+	if cfg.Provider != nil && cfg.Provider.Name != "" {
+		if _, err := providers.Create(cfg.Provider); err != nil {
+			problems = append(problems, fmt.Sprintf("provider: %v", err))
+		}
+	}
+
+	for _, warning := range cfg.Deprecations() {
+		fmt.Printf("%s: warning: %s\n", name, warning)
+	}
+
+	if len(problems) > 0 {
+		fmt.Printf("%s: %d problem(s):\n", name, len(problems))
+		for _, problem := range problems {
+			fmt.Printf("  - %s\n", problem)
+		}
+		return fmt.Errorf("%s is invalid", name)
+	}
+
+	fmt.Printf("%s: OK (provider %s at %s, model %s)\n", name, cfg.Provider.Name, cfg.Provider.BaseURL, cfg.Model.Name)
+	return nil
+}
+
+// unwrapJoined splits an errors.Join result into its errors.
+func unwrapJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
@@ -2,39 +2,144 @@ package main
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
+	"maps"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/agent"
 	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/images"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/stream"
 )
 
+// optionFlags collects repeated -option key=value overrides. Values are
+// parsed as JSON when possible (numbers, booleans, arrays, objects) and kept
+// as strings otherwise.
+type optionFlags map[string]any
+
+func (o optionFlags) String() string {
+	pairs := make([]string, 0, len(o))
+	for _, key := range slices.Sorted(maps.Keys(o)) {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, o[key]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (o optionFlags) Set(value string) error {
+	key, raw, ok := strings.Cut(value, "=")
+	if key = strings.TrimSpace(key); !ok || key == "" {
+		return fmt.Errorf("option %q must be key=value", value)
+	}
+
+	var parsed any
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		parsed = raw
+	}
+	o[key] = parsed
+	return nil
+}
+
+// requestOptions returns the runtime option overrides for a protocol.
+// Generation overrides (-temperature, -max-tokens) do not apply to embeddings.
+func requestOptions(protocol string, generation, options map[string]any) map[string]any {
+	merged := make(map[string]any)
+	if protocol != "embeddings" {
+		maps.Copy(merged, generation)
+	}
+	maps.Copy(merged, options)
+	return merged
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "init":
+			if err := runInit(os.Args[2:]); err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			return
+		case "validate":
+			if err := runValidate(os.Args[2:]); err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			return
+		}
+	}
+
+	var configs config.Files
+	flag.Var(&configs, "config", "Configuration file to use; repeat or comma-separate to overlay files in order (default config.json)")
+
+	options := make(optionFlags)
+	flag.Var(options, "option", "Request option override as key=value (JSON values are parsed); repeatable")
+
 	var (
-		configFile   = flag.String("config", "config.json", "Configuration file to use")
 		protocol     = flag.String("protocol", "chat", "Protocol to use (chat, vision, tools, embeddings)")
-		prompt       = flag.String("prompt", "", "Prompt to send to the agent")
+		prompt       = flag.String("prompt", "", "Prompt to send to the agent (- reads from stdin)")
+		promptFile   = flag.String("prompt-file", "", "File containing the prompt (- reads from stdin)")
 		systemPrompt = flag.String("system-prompt", "", "System prompt (overrides config)")
 		token        = flag.String("token", "", "Authentication token (overrides config)")
 		stream       = flag.Bool("stream", false, "Enable streaming responses")
-
-		images    = flag.String("images", "", "Comma-separated image URLs/paths (for vision)")
-		toolsFile = flag.String("tools-file", "", "JSON file containing tool definitions (for tools)")
+		showConfig   = flag.Bool("show-config", false, "Print the effective configuration with secrets redacted and exit")
+		sessionFile  = flag.String("session", "", "Chat session file: resume the conversation it holds and save it after the reply")
+		debug        = flag.Bool("debug", false, "Print each HTTP attempt (URL, redacted headers, request and raw response bodies) to stderr")
+
+		modelName   = flag.String("model", "", "Model name (overrides config)")
+		temperature = flag.Float64("temperature", 0, "Sampling temperature (overrides config)")
+		maxTokens   = flag.Int("max-tokens", 0, "Maximum tokens to generate (overrides config)")
+
+		imageSources = flag.String("images", "", "Comma-separated image URLs/paths (for vision, optional for tools)")
+		imageMaxDim  = flag.Int("image-max-dim", 0, "Downscale images whose width or height exceeds this many pixels (for vision; 0 disables)")
+		toolsFile    = flag.String("tools-file", "", "JSON file containing tool definitions (for tools)")
+
+		inputsFile     = flag.String("inputs", "", "File with one embeddings input per line (for embeddings)")
+		outFile        = flag.String("out", "", "Write embeddings to a .jsonl, .csv, or .npy file (for embeddings)")
+		embedBatchSize = flag.Int("embed-batch-size", 64, "Number of -inputs lines sent per embeddings request")
+
+		batchFile   = flag.String("batch", "", "JSONL file with one request per line ({\"id\", \"prompt\", \"protocol\", \"images\", \"options\"})")
+		batchOutput = flag.String("batch-output", "", "File to write batch results to as JSONL (default stdout)")
+		concurrency = flag.Int("concurrency", 4, "Number of batch or benchmark requests to run concurrently")
+		bench       = flag.Int("bench", 0, "Benchmark mode: send the prompt this many times and report latency, throughput, errors, and retries")
 	)
 	flag.Parse()
 
-	if *prompt == "" {
-		log.Fatal("Error: -prompt flag is required")
+	generation := make(map[string]any)
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "temperature":
+			generation["temperature"] = *temperature
+		case "max-tokens":
+			generation["max_tokens"] = *maxTokens
+		}
+	})
+
+	input, err := readPrompt(*prompt, *promptFile)
+	if err != nil {
+		log.Fatalf("Failed to read prompt: %v", err)
+	}
+	*prompt = input
+
+	if *prompt == "" && *batchFile == "" && *inputsFile == "" && !*showConfig {
+		log.Fatal("Error: -prompt or -prompt-file flag is required")
+	}
+
+	if *inputsFile != "" && *protocol != "embeddings" {
+		log.Fatal("Error: -inputs requires -protocol embeddings")
+	}
+
+	if *sessionFile != "" && (*protocol != "chat" || *batchFile != "" || *bench > 0) {
+		log.Fatal("Error: -session is only supported for single chat prompts")
 	}
 
-	cfg, err := config.LoadAgentConfig(*configFile)
+	cfg, err := config.Load("TAU", configs...)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
@@ -50,50 +155,198 @@ func main() {
 		cfg.SystemPrompt = *systemPrompt
 	}
 
+	if *modelName != "" {
+		cfg.Model.Name = *modelName
+		if err := cfg.ResolveDeprecations(); err != nil {
+			log.Fatalf("Invalid model: %v", err)
+		}
+	}
+
+	for _, warning := range cfg.Deprecations() {
+		log.Printf("Warning: %s", warning)
+	}
+
+	// Benchmarks count HTTP attempts to report retries; debug mode prints them
+	var counter *countingTransport
+	if *bench > 0 || *debug {
+		transport := poolTransport(cfg.Client)
+		if *bench > 0 {
+			counter = &countingTransport{next: transport}
+			transport = counter
+		}
+		if *debug {
+			transport = &debugTransport{next: transport, w: os.Stderr}
+		}
+		cfg.Client.Transport = transport
+	}
+
+	if *showConfig {
+		data, err := json.MarshalIndent(cfg.Redacted(), "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to marshal config: %v", err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
 	a, err := agent.New(cfg)
 	if err != nil {
 		log.Fatalf("Failed to create agent: %v", err)
 	}
 
+	if *batchFile != "" {
+		var toolList []agent.Tool
+		if *toolsFile != "" {
+			toolList = loadTools(*toolsFile)
+		}
+
+		failed, err := runBatch(a, batchConfig{
+			input:       *batchFile,
+			output:      *batchOutput,
+			concurrency: *concurrency,
+			protocol:    *protocol,
+			timeout:     cfg.Client.Timeout.ToDuration(),
+			tools:       toolList,
+			imageMaxDim: *imageMaxDim,
+			options: func(protocol string) map[string]any {
+				return requestOptions(protocol, generation, options)
+			},
+		})
+		if err != nil {
+			log.Fatalf("Batch failed: %v", err)
+		}
+		if failed > 0 {
+			log.Fatalf("Batch completed with %d failed request(s)", failed)
+		}
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Client.Timeout.ToDuration())
 	defer cancel()
 
+	opts := requestOptions(*protocol, generation, options)
+
+	if *bench > 0 {
+		var toolList []agent.Tool
+		if *toolsFile != "" {
+			toolList = loadTools(*toolsFile)
+		}
+
+		var preparedImages []string
+		if *imageSources != "" {
+			imgCtx, imgCancel := context.WithTimeout(context.Background(), cfg.Client.Timeout.ToDuration())
+			preparedImages = prepareImages(imgCtx, *imageSources, *imageMaxDim)
+			imgCancel()
+		}
+
+		runBench(a, benchConfig{
+			requests:    *bench,
+			concurrency: *concurrency,
+			protocol:    *protocol,
+			prompt:      *prompt,
+			images:      preparedImages,
+			tools:       toolList,
+			stream:      *stream,
+			timeout:     cfg.Client.Timeout.ToDuration(),
+			options:     opts,
+			transport:   counter,
+		})
+		return
+	}
+
 	switch *protocol {
 	case "chat":
-		if *stream {
-			executeChatStream(ctx, a, *prompt)
+		if *sessionFile != "" {
+			s, err := loadSession(*sessionFile)
+			if err != nil {
+				log.Fatalf("Failed to load session: %v", err)
+			}
+			// New sessions take the configured system prompt; resumed
+			// sessions keep theirs unless -system-prompt is given
+			if *systemPrompt != "" || s.Turns == 0 {
+				s.SystemPrompt = cfg.SystemPrompt
+			}
+			executeSessionChat(ctx, a, s, *sessionFile, *prompt, *stream, opts)
+		} else if *stream {
+			executeChatStream(ctx, a, *prompt, opts)
 		} else {
-			executeChat(ctx, a, *prompt)
+			executeChat(ctx, a, *prompt, opts)
 		}
 	case "vision":
-		if *images == "" {
+		if *imageSources == "" {
 			log.Fatal("Error: -images flag is required for vision protocol")
 		}
-		imageList := strings.Split(*images, ",")
-		for i, img := range imageList {
-			imageList[i] = strings.TrimSpace(img)
-		}
-		preparedImages := prepareImages(imageList)
+		preparedImages := prepareImages(ctx, *imageSources, *imageMaxDim)
 		if *stream {
-			executeVisionStream(ctx, a, *prompt, preparedImages)
+			executeVisionStream(ctx, a, *prompt, preparedImages, opts)
 		} else {
-			executeVision(ctx, a, *prompt, preparedImages)
+			executeVision(ctx, a, *prompt, preparedImages, opts)
 		}
 	case "tools":
 		if *toolsFile == "" {
 			log.Fatal("Error: -tools-file flag is required for tools protocol")
 		}
 		toolList := loadTools(*toolsFile)
-		executeTools(ctx, a, *prompt, toolList)
+		// Images are optional; when given the model analyzes them before calling tools
+		var preparedImages []string
+		if *imageSources != "" {
+			preparedImages = prepareImages(ctx, *imageSources, *imageMaxDim)
+		}
+		executeTools(ctx, a, *prompt, preparedImages, toolList, opts)
 	case "embeddings":
-		executeEmbeddings(ctx, a, *prompt)
+		if *inputsFile != "" || *outFile != "" {
+			exportEmbeddings(a, *prompt, *inputsFile, *outFile, *embedBatchSize, cfg.Client.Timeout.ToDuration(), opts)
+		} else {
+			executeEmbeddings(ctx, a, *prompt, opts)
+		}
 	default:
 		log.Fatalf("Unknown protocol: %s", *protocol)
 	}
 }
 
-func executeChat(ctx context.Context, agent agent.Agent, prompt string) {
-	response, err := agent.Chat(ctx, prompt)
+// readPrompt resolves the prompt from -prompt or -prompt-file. A value of "-"
+// reads from stdin, so multi-line or piped prompts need no shell escaping.
+// Trailing newlines from files and stdin are trimmed.
+func readPrompt(prompt, promptFile string) (string, error) {
+	if prompt != "" && promptFile != "" {
+		return "", errors.New("-prompt and -prompt-file are mutually exclusive")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	switch {
+	case prompt == "-", promptFile == "-":
+		data, err = io.ReadAll(os.Stdin)
+	case promptFile != "":
+		data, err = os.ReadFile(promptFile)
+	default:
+		return prompt, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// prepareImages loads comma-separated image sources, inlining remote images
+// as data URIs since some providers only support base64.
+func prepareImages(ctx context.Context, sources string, maxDim int) []string {
+	imageList := strings.Split(sources, ",")
+	for i, img := range imageList {
+		imageList[i] = strings.TrimSpace(img)
+	}
+	prepared, err := images.Prepare(ctx, imageList, images.WithMaxDimension(maxDim))
+	if err != nil {
+		log.Fatalf("Failed to prepare images: %v", err)
+	}
+	return prepared
+}
+
+func executeChat(ctx context.Context, agent agent.Agent, prompt string, opts map[string]any) {
+	response, err := agent.Chat(ctx, prompt, opts)
 	if err != nil {
 		log.Fatalf("Chat failed: %v", err)
 	}
@@ -108,23 +361,25 @@ func executeChat(ctx context.Context, agent agent.Agent, prompt string) {
 	}
 }
 
-func executeChatStream(ctx context.Context, agent agent.Agent, prompt string) {
-	stream, err := agent.ChatStream(ctx, prompt)
+func executeChatStream(ctx context.Context, agent agent.Agent, prompt string, opts map[string]any) {
+	chunks, err := agent.ChatStream(ctx, prompt, opts)
 	if err != nil {
 		log.Fatalf("ChatStream failed: %v", err)
 	}
 
-	for chunk := range stream {
+	out := stream.NewWriter(os.Stdout)
+	for chunk := range chunks {
 		if chunk.Error != nil {
 			log.Fatalf("Stream error: %v", chunk.Error)
 		}
-		fmt.Print(chunk.Content())
+		out.WriteString(chunk.Content())
 	}
+	out.Close()
 	fmt.Println()
 }
 
-func executeVision(ctx context.Context, agent agent.Agent, prompt string, images []string) {
-	response, err := agent.Vision(ctx, prompt, images)
+func executeVision(ctx context.Context, agent agent.Agent, prompt string, images []string, opts map[string]any) {
+	response, err := agent.Vision(ctx, prompt, images, opts)
 	if err != nil {
 		log.Fatalf("Vision failed: %v", err)
 	}
@@ -139,25 +394,35 @@ func executeVision(ctx context.Context, agent agent.Agent, prompt string, images
 	}
 }
 
-func executeVisionStream(ctx context.Context, agent agent.Agent, prompt string, images []string) {
-	stream, err := agent.VisionStream(ctx, prompt, images)
+func executeVisionStream(ctx context.Context, agent agent.Agent, prompt string, images []string, opts map[string]any) {
+	chunks, err := agent.VisionStream(ctx, prompt, images, opts)
 	if err != nil {
 		log.Fatalf("VisionStream failed: %v", err)
 	}
 
-	for chunk := range stream {
+	out := stream.NewWriter(os.Stdout)
+	for chunk := range chunks {
 		if chunk.Error != nil {
 			log.Fatalf("Stream error: %v", chunk.Error)
 		}
 
-		fmt.Print(chunk.Content())
+		out.WriteString(chunk.Content())
 	}
+	out.Close()
 
 	fmt.Println()
 }
 
-func executeTools(ctx context.Context, agent agent.Agent, prompt string, tools []agent.Tool) {
-	response, err := agent.Tools(ctx, prompt, tools)
+func executeTools(ctx context.Context, agent agent.Agent, prompt string, images []string, tools []agent.Tool, opts map[string]any) {
+	var (
+		response *response.ToolsResponse
+		err      error
+	)
+	if len(images) > 0 {
+		response, err = agent.VisionTools(ctx, prompt, images, tools, opts)
+	} else {
+		response, err = agent.Tools(ctx, prompt, tools, opts)
+	}
 	if err != nil {
 		log.Fatalf("Tools failed: %v", err)
 	}
@@ -186,8 +451,8 @@ func executeTools(ctx context.Context, agent agent.Agent, prompt string, tools [
 	}
 }
 
-func executeEmbeddings(ctx context.Context, agent agent.Agent, input string) {
-	response, err := agent.Embed(ctx, input)
+func executeEmbeddings(ctx context.Context, agent agent.Agent, input string, opts map[string]any) {
+	response, err := agent.Embed(ctx, input, opts)
 	if err != nil {
 		log.Fatalf("Embeddings failed: %v", err)
 	}
@@ -263,88 +528,48 @@ func executeEmbeddings(ctx context.Context, agent agent.Agent, input string) {
 	}
 }
 
-func loadTools(filename string) []agent.Tool {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		log.Fatalf("Failed to read tools file: %v", err)
+// exportEmbeddings embeds the -inputs lines (or the prompt) and writes the
+// vectors to the -out file, or as JSONL to stdout when -out is not set.
+func exportEmbeddings(a agent.Agent, prompt, inputsFile, outFile string, batchSize int, timeout time.Duration, opts map[string]any) {
+	inputs := []string{prompt}
+	if inputsFile != "" {
+		var err error
+		if inputs, err = readInputs(inputsFile); err != nil {
+			log.Fatalf("Failed to read inputs: %v", err)
+		}
+		if len(inputs) == 0 {
+			log.Fatal("Error: -inputs file contains no inputs")
+		}
 	}
 
-	var tools []agent.Tool
-	if err := json.Unmarshal(data, &tools); err != nil {
-		log.Fatalf("Failed to parse tools file: %v", err)
+	vectors, err := embedInputs(a, inputs, batchSize, timeout, opts)
+	if err != nil {
+		log.Fatalf("Embeddings failed: %v", err)
 	}
 
-	return tools
-}
-
-func prepareImages(imageList []string) []string {
-	prepared := make([]string, len(imageList))
-	for i, img := range imageList {
-		if strings.HasPrefix(img, "http://") || strings.HasPrefix(img, "https://") {
-			// Download and encode remote images (some providers only support base64)
-			data, err := downloadImage(img)
-			if err != nil {
-				log.Fatalf("Failed to download image %s: %v", img, err)
-			}
-
-			// Detect MIME type from downloaded content
-			mimeType := http.DetectContentType(data)
-
-			// Validate it's an image
-			if !strings.HasPrefix(mimeType, "image/") {
-				log.Fatalf("URL %s is not an image (detected type: %s)", img, mimeType)
-			}
-
-			// Create data URL with detected MIME type
-			encoded := base64.StdEncoding.EncodeToString(data)
-			prepared[i] = fmt.Sprintf("data:%s;base64,%s", mimeType, encoded)
-		} else {
-			// Expand home directory if needed
-			if strings.HasPrefix(img, "~/") {
-				home, err := os.UserHomeDir()
-				if err != nil {
-					log.Fatalf("Failed to get home directory: %v", err)
-				}
-				img = strings.Replace(img, "~", home, 1)
-			}
-
-			// Local file, read and encode
-			data, err := os.ReadFile(img)
-			if err != nil {
-				log.Fatalf("Failed to read image %s: %v", img, err)
-			}
-
-			// Detect MIME type from content
-			mimeType := http.DetectContentType(data)
-
-			// Validate it's an image
-			if !strings.HasPrefix(mimeType, "image/") {
-				log.Fatalf("File %s is not an image (detected type: %s)", img, mimeType)
-			}
-
-			// Create data URL with detected MIME type
-			encoded := base64.StdEncoding.EncodeToString(data)
-			prepared[i] = fmt.Sprintf("data:%s;base64,%s", mimeType, encoded)
+	if outFile == "" {
+		if err := writeJSONL(os.Stdout, inputs, vectors); err != nil {
+			log.Fatalf("Failed to write embeddings: %v", err)
 		}
+		return
 	}
-	return prepared
-}
 
-func downloadImage(url string) ([]byte, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to download: %w", err)
+	if err := writeVectors(outFile, inputs, vectors); err != nil {
+		log.Fatalf("Failed to write embeddings: %v", err)
 	}
-	defer resp.Body.Close()
+	fmt.Printf("Wrote %d embedding(s) with %d dimensions to %s\n", len(vectors), len(vectors[0]), outFile)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("download failed with status %d: %s", resp.StatusCode, resp.Status)
+func loadTools(filename string) []agent.Tool {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		log.Fatalf("Failed to read tools file: %v", err)
 	}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	var tools []agent.Tool
+	if err := json.Unmarshal(data, &tools); err != nil {
+		log.Fatalf("Failed to parse tools file: %v", err)
 	}
 
-	return data, nil
+	return tools
 }
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/stream"
+)
+
+// session is a chat conversation persisted between invocations by -session.
+// The system prompt is stored separately from the user and assistant turns
+// so it can be changed on resume with -system-prompt.
+type session struct {
+	SystemPrompt string              `json:"system_prompt,omitempty"`
+	Model        string              `json:"model,omitempty"`
+	Messages     []protocol.Message  `json:"messages"`
+	Usage        response.TokenUsage `json:"usage"`
+	Turns        int                 `json:"turns"`
+	Created      time.Time           `json:"created"`
+	Updated      time.Time           `json:"updated"`
+}
+
+// loadSession reads a session file, returning an empty session if the file
+// does not exist yet.
+func loadSession(path string) (*session, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &session{Created: time.Now()}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// save writes the session atomically, replacing the file only once the new
+// contents are fully written. Sessions are owner-only since conversations may
+// contain sensitive content.
+func (s *session) save(path string) error {
+	s.Updated = time.Now()
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// messages returns the request messages for a new turn: the system prompt,
+// the conversation so far, and the new user prompt.
+func (s *session) messages(prompt string) []protocol.Message {
+	messages := make([]protocol.Message, 0, len(s.Messages)+2)
+	if s.SystemPrompt != "" {
+		messages = append(messages, protocol.NewMessage("system", s.SystemPrompt))
+	}
+	messages = append(messages, s.Messages...)
+	return append(messages, protocol.NewMessage("user", prompt))
+}
+
+// record appends a completed turn and accumulates its usage.
+func (s *session) record(prompt, reply string, usage *response.TokenUsage) {
+	s.Messages = append(s.Messages,
+		protocol.NewMessage("user", prompt),
+		protocol.NewMessage("assistant", reply),
+	)
+	s.Turns++
+	if usage != nil {
+		s.Usage.PromptTokens += usage.PromptTokens
+		s.Usage.CompletionTokens += usage.CompletionTokens
+		s.Usage.TotalTokens += usage.TotalTokens
+	}
+}
+
+// executeSessionChat sends the prompt with the session's history, prints the
+// reply, and saves the updated session. The agent's single-turn methods only
+// send the latest prompt, so the request is built here from the full history
+// with the model's chat options.
+func executeSessionChat(ctx context.Context, a agent.Agent, s *session, path, prompt string, streaming bool, opts map[string]any) {
+	options := maps.Clone(a.Model().Options[protocol.Chat])
+	if options == nil {
+		options = make(map[string]any)
+	}
+	maps.Copy(options, opts)
+
+	var (
+		reply string
+		usage *response.TokenUsage
+	)
+	if streaming {
+		options["stream"] = true
+		chunks, err := a.Client().ExecuteStream(ctx, request.NewChat(a.Provider(), a.Model(), s.messages(prompt), options))
+		if err != nil {
+			log.Fatalf("ChatStream failed: %v", err)
+		}
+
+		var b strings.Builder
+		out := stream.NewWriter(os.Stdout)
+		for chunk := range chunks {
+			if chunk.Error != nil {
+				log.Fatalf("Stream error: %v", chunk.Error)
+			}
+			out.WriteString(chunk.Content())
+			b.WriteString(chunk.Content())
+			if chunk.Usage != nil {
+				usage = chunk.Usage
+			}
+		}
+		out.Close()
+		fmt.Println()
+		reply = b.String()
+	} else {
+		result, err := a.Client().Execute(ctx, request.NewChat(a.Provider(), a.Model(), s.messages(prompt), options))
+		if err != nil {
+			log.Fatalf("Chat failed: %v", err)
+		}
+		resp, ok := result.(*response.ChatResponse)
+		if !ok {
+			log.Fatalf("Chat failed: unexpected response type: %T", result)
+		}
+		reply, usage = resp.Content(), resp.Usage
+		fmt.Printf("Response: %s\n", reply)
+	}
+
+	s.Model = a.Model().Name
+	s.record(prompt, reply, usage)
+	if err := s.save(path); err != nil {
+		log.Fatalf("Failed to save session: %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Session %s: %d turn(s), %d total tokens\n", path, s.Turns, s.Usage.TotalTokens)
+}
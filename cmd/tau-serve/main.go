@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+func main() {
+	var configs config.Files
+	flag.Var(&configs, "config", "Configuration file to use; repeat or comma-separate to overlay files in order (default config.json)")
+
+	var (
+		addr   = flag.String("addr", ":8080", "Address to listen on")
+		apiKey = flag.String("api-key", os.Getenv("TAU_SERVE_API_KEY"), "Bearer token clients must send (default $TAU_SERVE_API_KEY; empty disables authentication)")
+	)
+	flag.Parse()
+
+	cfg, err := config.Load("TAU", configs...)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	for _, warning := range cfg.Deprecations() {
+		log.Printf("Warning: %s", warning)
+	}
+
+	s, err := newServer(cfg, *apiKey)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving %s (%s) on %s", cfg.Name, cfg.Model.Name, *addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Server failed: %v", err)
+	}
+}
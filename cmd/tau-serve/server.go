@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// server exposes configured agents through an OpenAI-compatible HTTP API.
+// Requests select an agent by the "model" field, matching a configured alias
+// or model name; unknown or empty names use the protocol's route or the
+// primary agent.
+type server struct {
+	primary agent.Agent
+	agents  map[string]agent.Agent
+	routes  map[protocol.Protocol]agent.Agent
+	apiKey  string
+}
+
+// newServer creates the primary agent and one agent per configured alias.
+// Aliases without a provider share the primary provider configuration.
+func newServer(cfg *config.AgentConfig, apiKey string) (*server, error) {
+	primary, err := agent.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &server{
+		primary: primary,
+		agents:  map[string]agent.Agent{primary.Model().Name: primary},
+		routes:  make(map[protocol.Protocol]agent.Agent),
+		apiKey:  apiKey,
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.Aliases)) {
+		alias := cfg.Aliases[name]
+		if alias == nil || alias.Model == nil {
+			return nil, fmt.Errorf("model alias %q has no model", name)
+		}
+
+		aliasCfg := *cfg
+		aliasCfg.Model = alias.Model
+		aliasCfg.Aliases = nil
+		aliasCfg.Routes = nil
+		if alias.Provider != nil {
+			aliasCfg.Provider = alias.Provider
+		}
+
+		a, err := agent.New(&aliasCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create agent for model alias %q: %w", name, err)
+		}
+
+		s.agents[name] = a
+		if _, exists := s.agents[alias.Model.Name]; !exists {
+			s.agents[alias.Model.Name] = a
+		}
+	}
+
+	for name, alias := range cfg.Routes {
+		a, ok := s.agents[alias]
+		if !ok {
+			return nil, fmt.Errorf("route for %s references unknown model alias %q", name, alias)
+		}
+		s.routes[protocol.Protocol(name)] = a
+	}
+
+	return s, nil
+}
+
+// handler returns the HTTP handler serving the OpenAI-compatible API.
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/chat/completions", s.handleChatCompletions)
+	mux.HandleFunc("POST /v1/embeddings", s.handleEmbeddings)
+	mux.HandleFunc("GET /v1/models", s.handleModels)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return s.authenticate(mux)
+}
+
+// authenticate requires a matching bearer token when an API key is configured.
+func (s *server) authenticate(next http.Handler) http.Handler {
+	if s.apiKey == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.apiKey)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid_api_key", "invalid or missing API key")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// lookup selects the agent for a requested model name and protocol.
+func (s *server) lookup(name string, proto protocol.Protocol) agent.Agent {
+	if a, ok := s.agents[name]; ok && name != "" {
+		return a
+	}
+	if a, ok := s.routes[proto]; ok {
+		return a
+	}
+	return s.primary
+}
+
+// chatCompletionRequest holds the fields of an OpenAI chat completion request
+// that select the protocol. Remaining fields are forwarded as options.
+type chatCompletionRequest struct {
+	Model    string             `json:"model"`
+	Messages []protocol.Message `json:"messages"`
+	Stream   bool               `json:"stream"`
+	Tools    []struct {
+		Type     string                   `json:"type"`
+		Function providers.ToolDefinition `json:"function"`
+	} `json:"tools"`
+}
+
+func (s *server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	var body chatCompletionRequest
+	options, err := decodeRequest(r, &body, "model", "messages", "stream", "stream_options", "tools")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	if len(body.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "messages must not be empty")
+		return
+	}
+
+	proto := protocol.Chat
+	if len(body.Tools) > 0 {
+		proto = protocol.Tools
+	}
+
+	a := s.lookup(body.Model, proto)
+	options = mergeOptions(a.Model().Options[proto], options)
+	if body.Stream {
+		options["stream"] = true
+	}
+
+	var req request.Request
+	if proto == protocol.Tools {
+		tools := make([]providers.ToolDefinition, len(body.Tools))
+		for i, tool := range body.Tools {
+			tools[i] = tool.Function
+		}
+		req = request.NewTools(a.Provider(), a.Model(), body.Messages, tools, options)
+	} else {
+		req = request.NewChat(a.Provider(), a.Model(), body.Messages, options)
+	}
+
+	if body.Stream {
+		s.streamChat(r.Context(), w, a.Client(), req)
+		return
+	}
+
+	result, err := a.Client().Execute(r.Context(), req)
+	if err != nil {
+		writeExecuteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// streamChat relays streaming chunks as OpenAI server-sent events.
+// Errors after the stream starts are sent as an error event by
+// response.StreamSSE since the status code has already been written.
+func (s *server) streamChat(ctx context.Context, w http.ResponseWriter, c client.Client, req request.Request) {
+	chunks, err := c.ExecuteStream(ctx, req)
+	if err != nil {
+		writeExecuteError(w, err)
+		return
+	}
+
+	response.StreamSSE(w, chunks)
+}
+
+// embeddingsRequest holds the fields of an OpenAI embeddings request.
+// Remaining fields are forwarded as options.
+type embeddingsRequest struct {
+	Model string `json:"model"`
+	Input any    `json:"input"`
+}
+
+func (s *server) handleEmbeddings(w http.ResponseWriter, r *http.Request) {
+	var body embeddingsRequest
+	options, err := decodeRequest(r, &body, "model", "input")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	input, err := embeddingsInput(body.Input)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	a := s.lookup(body.Model, protocol.Embeddings)
+	options = mergeOptions(a.Model().Options[protocol.Embeddings], options)
+
+	req := request.NewEmbeddings(a.Provider(), a.Model(), input, options)
+	result, err := a.Client().Execute(r.Context(), req)
+	if err != nil {
+		writeExecuteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// embeddingsInput converts a decoded input field to a string or []string.
+func embeddingsInput(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []any:
+		inputs := make([]string, len(v))
+		for i, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("input item %d must be a string", i)
+			}
+			inputs[i] = text
+		}
+		return inputs, nil
+	default:
+		return nil, errors.New("input must be a string or an array of strings")
+	}
+}
+
+func (s *server) handleModels(w http.ResponseWriter, r *http.Request) {
+	type modelEntry struct {
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		OwnedBy string `json:"owned_by"`
+	}
+
+	list := struct {
+		Object string       `json:"object"`
+		Data   []modelEntry `json:"data"`
+	}{Object: "list"}
+
+	for _, name := range slices.Sorted(maps.Keys(s.agents)) {
+		if name == "" {
+			continue
+		}
+		list.Data = append(list.Data, modelEntry{
+			ID:      name,
+			Object:  "model",
+			OwnedBy: s.agents[name].Provider().Name(),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// decodeRequest decodes a JSON body into target and returns the fields not
+// named in known as request options.
+func decodeRequest(r *http.Request, target any, known ...string) (map[string]any, error) {
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %w", err)
+	}
+
+	data, _ := json.Marshal(raw)
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	options := make(map[string]any)
+	for key, value := range raw {
+		if slices.Contains(known, key) {
+			continue
+		}
+		var decoded any
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		options[key] = decoded
+	}
+	return options, nil
+}
+
+// mergeOptions merges request options over the model's configured defaults.
+func mergeOptions(defaults, options map[string]any) map[string]any {
+	merged := maps.Clone(defaults)
+	if merged == nil {
+		merged = make(map[string]any)
+	}
+	maps.Copy(merged, options)
+	return merged
+}
+
+// writeExecuteError maps an execution error to an HTTP status.
+// Provider status codes are passed through, validation failures are
+// client errors, and other failures are reported as a bad gateway.
+func writeExecuteError(w http.ResponseWriter, err error) {
+	var statusErr *client.HTTPStatusError
+	switch {
+	case errors.As(err, &statusErr):
+		writeError(w, statusErr.StatusCode, "upstream_error", err.Error())
+	case errors.Is(err, request.ErrInvalidRequest), errors.Is(err, model.ErrInvalidOption):
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, "timeout", err.Error())
+	default:
+		writeError(w, http.StatusBadGateway, "upstream_error", err.Error())
+	}
+}
+
+// errorBody builds an OpenAI-style error payload.
+func errorBody(errType, message string) map[string]any {
+	return map[string]any{
+		"error": map[string]any{
+			"message": message,
+			"type":    errType,
+		},
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, errType, message string) {
+	writeJSON(w, status, errorBody(errType, message))
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+func TestWriteExecuteError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "provider status", err: fmt.Errorf("failed: %w", &client.HTTPStatusError{StatusCode: http.StatusTooManyRequests}), want: http.StatusTooManyRequests},
+		{name: "invalid request", err: fmt.Errorf("%w: prompt is too long", request.ErrInvalidRequest), want: http.StatusBadRequest},
+		{name: "invalid option", err: fmt.Errorf("invalid options: %w", model.ErrInvalidOption), want: http.StatusBadRequest},
+		{name: "deadline", err: fmt.Errorf("request failed: %w", context.DeadlineExceeded), want: http.StatusGatewayTimeout},
+		{name: "other", err: errors.New("connection refused"), want: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeExecuteError(rec, tt.err)
+
+			if rec.Code != tt.want {
+				t.Errorf("got status %d, want %d", rec.Code, tt.want)
+			}
+
+			var body struct {
+				Error struct {
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || body.Error.Message != tt.err.Error() {
+				t.Errorf("got body %+v, err %v, want the error message", body, err)
+			}
+		})
+	}
+}
+
+// upstream records the model named by each request an OpenAI-compatible
+// server receives, answering chat and embeddings requests.
+type upstream struct {
+	*httptest.Server
+	mu     sync.Mutex
+	models []string
+}
+
+func newUpstream(t *testing.T) *upstream {
+	u := &upstream{}
+	u.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		u.mu.Lock()
+		u.models = append(u.models, body.Model)
+		u.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/embeddings") {
+			fmt.Fprintf(w, `{"object":"list","model":%q,"data":[{"object":"embedding","index":0,"embedding":[0.1]}]}`, body.Model)
+			return
+		}
+		fmt.Fprintf(w, `{"model":%q,"choices":[{"index":0,"message":{"role":"assistant","content":"ok"}}]}`, body.Model)
+	}))
+	t.Cleanup(u.Close)
+	return u
+}
+
+func (u *upstream) received() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]string(nil), u.models...)
+}
+
+func TestServer_RoutesByAlias(t *testing.T) {
+	primary := newUpstream(t)
+	remote := newUpstream(t)
+
+	cfg := config.DefaultAgentConfig()
+	cfg.Provider = &config.ProviderConfig{Name: "ollama", BaseURL: primary.URL}
+	cfg.Model = &config.ModelConfig{Name: "llama3"}
+	cfg.Aliases = map[string]*config.AliasConfig{
+		"fast":  {Model: &config.ModelConfig{Name: "llama3.2:3b"}},
+		"embed": {Provider: &config.ProviderConfig{Name: "ollama", BaseURL: remote.URL}, Model: &config.ModelConfig{Name: "nomic-embed-text"}},
+	}
+	cfg.Routes = map[string]string{"embeddings": "embed"}
+
+	s, err := newServer(&cfg, "")
+	if err != nil {
+		t.Fatalf("newServer failed: %v", err)
+	}
+	server := httptest.NewServer(s.handler())
+	defer server.Close()
+
+	post := func(path, body string) {
+		resp, err := http.Post(server.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST %s failed: %v", path, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("POST %s: got status %d, want 200", path, resp.StatusCode)
+		}
+	}
+
+	post("/v1/chat/completions", `{"messages":[{"role":"user","content":"Hi"}]}`)
+	post("/v1/chat/completions", `{"model":"fast","messages":[{"role":"user","content":"Hi"}]}`)
+	post("/v1/chat/completions", `{"model":"llama3.2:3b","messages":[{"role":"user","content":"Hi"}]}`)
+	post("/v1/embeddings", `{"input":"hello"}`)
+	post("/v1/embeddings", `{"model":"llama3","input":"hello"}`)
+
+	if got, want := primary.received(), []string{"llama3", "llama3.2:3b", "llama3.2:3b", "llama3"}; !slices.Equal(got, want) {
+		t.Errorf("primary provider got models %v, want %v", got, want)
+	}
+	if got, want := remote.received(), []string{"nomic-embed-text"}; !slices.Equal(got, want) {
+		t.Errorf("aliased provider got models %v, want %v", got, want)
+	}
+}
@@ -3,7 +3,9 @@ package agent
 import (
 	"context"
 	"fmt"
+	"io"
 	"maps"
+	"sync"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/client"
 	"github.com/tailored-agentic-units/tau-core/pkg/config"
@@ -38,33 +40,57 @@ type Agent interface {
 	// Model returns the model instance.
 	Model() *model.Model
 
-	// Chat executes a chat protocol request with optional system prompt injection.
-	// Returns the parsed chat response or an error.
-	Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error)
-
-	// ChatStream executes a streaming chat protocol request.
-	// Automatically sets stream: true in options.
-	// Returns a channel of streaming chunks or an error.
-	ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error)
-
-	// Vision executes a vision protocol request with images.
-	// Images can be URLs or base64-encoded data URIs.
-	// Returns the parsed chat response or an error.
-	Vision(ctx context.Context, prompt string, images []string, opts ...map[string]any) (*response.ChatResponse, error)
-
-	// VisionStream executes a streaming vision protocol request with images.
-	// Returns a channel of streaming chunks or an error.
-	VisionStream(ctx context.Context, prompt string, images []string, opts ...map[string]any) (<-chan *response.StreamingChunk, error)
-
-	// Tools executes a tools protocol request with function definitions.
-	// Returns the parsed tools response with tool calls or an error.
-	Tools(ctx context.Context, prompt string, tools []Tool, opts ...map[string]any) (*response.ToolsResponse, error)
-
-	// Embed executes an embeddings protocol request.
-	// Returns the parsed embeddings response or an error.
-	Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error)
+	// Supports reports whether the agent can serve a protocol: the provider
+	// the protocol is routed to has an endpoint for it, and the model does not
+	// disable it (config.FeatureVision for vision, config.FeatureTools for
+	// tools). Orchestration code can check a capability before using it.
+	Supports(p protocol.Protocol) bool
+
+	Chatter
+	Visioner
+	Tooler
+	Embedder
+
+	// UploadFile uploads content to the provider's files API for the given purpose.
+	// Returns the stored file, whose ID can be referenced by later requests.
+	// Returns an error wrapping providers.ErrFilesNotSupported if the provider has no files API.
+	UploadFile(ctx context.Context, filename string, content io.Reader, purpose response.FilePurpose) (*response.File, error)
+
+	// ListFiles returns the files stored with the provider, filtered by purpose when not empty.
+	ListFiles(ctx context.Context, purpose response.FilePurpose) ([]response.File, error)
+
+	// DeleteFile deletes a file from the provider's files API.
+	DeleteFile(ctx context.Context, id string) error
+
+	// Prewarm loads the agent's models into memory ahead of traffic, on
+	// providers that load models on demand (see client.Prewarm). Each model
+	// stays loaded for its keep_alive option when one is configured.
+	// Returns an error wrapping providers.ErrPrewarmNotSupported if no
+	// provider of the agent can prewarm.
+	Prewarm(ctx context.Context) error
+
+	// SetSystemPrompt replaces the system prompt sent with later chat, vision,
+	// and tools requests. An empty prompt sends none.
+	// Safe to call while requests are in flight; they keep the prompt they started with.
+	SetSystemPrompt(prompt string)
+
+	// SetDefaultOptions replaces the configured options for a protocol with opts
+	// on every route, so later requests merge runtime options over opts instead
+	// of the model's configured options. Passing nil restores the configured options.
+	// Safe to call while requests are in flight.
+	SetDefaultOptions(proto protocol.Protocol, opts map[string]any)
+
+	// DefaultOptions returns a copy of the options requests for a protocol
+	// start from before runtime options are merged: those set with
+	// SetDefaultOptions, or the configured options of the protocol's model.
+	DefaultOptions(proto protocol.Protocol) map[string]any
 }
 
+// ModelOption is the runtime option key that selects a configured model alias
+// for a single request, e.g. map[string]any{"model": "fast"}.
+// The key is consumed by the agent and never sent to the provider.
+const ModelOption = "model"
+
 // agent implements the Agent interface.
 type agent struct {
 	id           string
@@ -72,6 +98,19 @@ type agent struct {
 	provider     providers.Provider
 	model        *model.Model
 	systemPrompt string
+	aliases      map[string]*route
+	routes       map[protocol.Protocol]*route
+	includeUsage bool
+
+	// mu guards systemPrompt and defaults, which can change at runtime
+	mu       sync.RWMutex
+	defaults map[protocol.Protocol]map[string]any
+}
+
+// route is a resolved provider and model pair that requests are sent to.
+type route struct {
+	provider providers.Provider
+	model    *model.Model
 }
 
 // New creates a new Agent from configuration.
@@ -87,13 +126,45 @@ func New(cfg *config.AgentConfig) (Agent, error) {
 	m := model.New(cfg.Model)
 	c := client.New(cfg.Client)
 
-	return &agent{
+	a := &agent{
 		id:           uuid.Must(uuid.NewV7()).String(),
 		client:       c,
 		provider:     p,
 		model:        m,
 		systemPrompt: cfg.SystemPrompt,
-	}, nil
+		aliases:      make(map[string]*route),
+		routes:       make(map[protocol.Protocol]*route),
+		includeUsage: cfg.Client.Streaming.IncludeUsageEnabled(),
+	}
+
+	for name, alias := range cfg.Aliases {
+		if alias == nil || alias.Model == nil {
+			return nil, fmt.Errorf("model alias %q has no model", name)
+		}
+
+		aliasProvider := p
+		if alias.Provider != nil {
+			aliasProvider, err = providers.Create(alias.Provider)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create provider for model alias %q: %w", name, err)
+			}
+		}
+
+		a.aliases[name] = &route{provider: aliasProvider, model: model.New(alias.Model)}
+	}
+
+	for name, alias := range cfg.Routes {
+		if !protocol.IsValid(name) {
+			return nil, fmt.Errorf("invalid route protocol: %s", name)
+		}
+		r, ok := a.aliases[alias]
+		if !ok {
+			return nil, fmt.Errorf("route for %s references unknown model alias %q", name, alias)
+		}
+		a.routes[protocol.Protocol(name)] = r
+	}
+
+	return a, nil
 }
 
 func (a *agent) ID() string {
@@ -121,9 +192,12 @@ func (a *agent) Model() *model.Model {
 // Returns parsed ChatResponse or error.
 func (a *agent) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
 	messages := a.initMessages(prompt)
-	options := a.mergeOptions(protocol.Chat, opts...)
+	r, options, err := a.resolve(protocol.Chat, opts...)
+	if err != nil {
+		return nil, err
+	}
 
-	req := request.NewChat(a.provider, a.model, messages, options)
+	req := request.NewChat(r.provider, r.model, messages, options)
 
 	result, err := a.client.Execute(ctx, req)
 	if err != nil {
@@ -140,18 +214,32 @@ func (a *agent) Chat(ctx context.Context, prompt string, opts ...map[string]any)
 
 // ChatStream executes a streaming chat protocol request.
 // Merges model's configured chat options with runtime opts.
-// Automatically sets stream: true in options.
+// Automatically sets stream: true in options, and asks for usage when the
+// streaming config includes it.
 // Returns a channel of StreamingChunk or error.
 func (a *agent) ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
 	messages := a.initMessages(prompt)
-	options := a.mergeOptions(protocol.Chat, opts...)
-	options["stream"] = true
+	r, options, err := a.resolve(protocol.Chat, opts...)
+	if err != nil {
+		return nil, err
+	}
+	a.streamOptions(options)
 
-	req := request.NewChat(a.provider, a.model, messages, options)
+	req := request.NewChat(r.provider, r.model, messages, options)
 
 	return a.client.ExecuteStream(ctx, req)
 }
 
+// streamOptions marks options for streaming. When the client's streaming
+// config includes usage, a final usage chunk is requested unless options set
+// stream_options themselves.
+func (a *agent) streamOptions(options map[string]any) {
+	options["stream"] = true
+	if _, ok := options["stream_options"]; !ok && a.includeUsage {
+		options["stream_options"] = map[string]any{"include_usage": true}
+	}
+}
+
 // Vision executes a vision protocol request with images.
 // Images can be URLs or base64-encoded data URIs.
 // Merges model's configured vision options with runtime opts.
@@ -159,18 +247,13 @@ func (a *agent) ChatStream(ctx context.Context, prompt string, opts ...map[strin
 // Returns parsed ChatResponse or error.
 func (a *agent) Vision(ctx context.Context, prompt string, images []string, opts ...map[string]any) (*response.ChatResponse, error) {
 	messages := a.initMessages(prompt)
-	options := a.mergeOptions(protocol.Vision, opts...)
-
-	// Extract vision_options
-	var visionOptions map[string]any
-	if vOpts, exists := options["vision_options"]; exists {
-		if vOptsMap, ok := vOpts.(map[string]any); ok {
-			visionOptions = vOptsMap
-			delete(options, "vision_options")
-		}
+	r, options, err := a.resolve(protocol.Vision, opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	req := request.NewVision(a.provider, a.model, messages, images, visionOptions, options)
+	visionOptions := extractVisionOptions(options)
+	req := request.NewVision(r.provider, r.model, messages, images, visionOptions, options)
 
 	result, err := a.client.Execute(ctx, req)
 	if err != nil {
@@ -188,23 +271,19 @@ func (a *agent) Vision(ctx context.Context, prompt string, images []string, opts
 // VisionStream executes a streaming vision protocol request with images.
 // Merges model's configured vision options with runtime opts.
 // Extracts vision_options from opts if present, separating them from model options.
-// Automatically sets stream: true in options.
+// Automatically sets stream: true in options, and asks for usage when the
+// streaming config includes it.
 // Returns a channel of StreamingChunk or error.
 func (a *agent) VisionStream(ctx context.Context, prompt string, images []string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
 	messages := a.initMessages(prompt)
-	options := a.mergeOptions(protocol.Vision, opts...)
-	options["stream"] = true
-
-	// Extract vision_options
-	var visionOptions map[string]any
-	if vOpts, exists := options["vision_options"]; exists {
-		if vOptsMap, ok := vOpts.(map[string]any); ok {
-			visionOptions = vOptsMap
-			delete(options, "vision_options")
-		}
+	r, options, err := a.resolve(protocol.Vision, opts...)
+	if err != nil {
+		return nil, err
 	}
+	a.streamOptions(options)
 
-	req := request.NewVision(a.provider, a.model, messages, images, visionOptions, options)
+	visionOptions := extractVisionOptions(options)
+	req := request.NewVision(r.provider, r.model, messages, images, visionOptions, options)
 
 	return a.client.ExecuteStream(ctx, req)
 }
@@ -215,20 +294,36 @@ func (a *agent) VisionStream(ctx context.Context, prompt string, images []string
 // Returns parsed ToolsResponse with tool calls or error.
 func (a *agent) Tools(ctx context.Context, prompt string, tools []Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
 	messages := a.initMessages(prompt)
-	options := a.mergeOptions(protocol.Tools, opts...)
+	r, options, err := a.resolve(protocol.Tools, opts...)
+	if err != nil {
+		return nil, err
+	}
 
-	// Convert agent.Tool to providers.ToolDefinition
-	toolDefs := make([]providers.ToolDefinition, len(tools))
-	for i, tool := range tools {
-		toolDefs[i] = providers.ToolDefinition{
-			Name:        tool.Name,
-			Description: tool.Description,
-			Parameters:  tool.Parameters,
-		}
+	req := request.NewTools(r.provider, r.model, messages, toolDefinitions(tools), options)
+
+	return a.executeTools(ctx, req)
+}
+
+// VisionTools executes a tools protocol request with images and function definitions.
+// Images can be URLs or base64-encoded data URIs.
+// Merges model's configured tools options with runtime opts.
+// Extracts vision_options from opts if present, separating them from model options.
+// Returns parsed ToolsResponse with tool calls or error.
+func (a *agent) VisionTools(ctx context.Context, prompt string, images []string, tools []Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	messages := a.initMessages(prompt)
+	r, options, err := a.resolve(protocol.Tools, opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	req := request.NewTools(a.provider, a.model, messages, toolDefs, options)
+	visionOptions := extractVisionOptions(options)
+	req := request.NewVisionTools(r.provider, r.model, messages, images, visionOptions, toolDefinitions(tools), options)
 
+	return a.executeTools(ctx, req)
+}
+
+// executeTools executes a tools request and returns its ToolsResponse.
+func (a *agent) executeTools(ctx context.Context, req request.Request) (*response.ToolsResponse, error) {
 	result, err := a.client.Execute(ctx, req)
 	if err != nil {
 		return nil, err
@@ -242,13 +337,40 @@ func (a *agent) Tools(ctx context.Context, prompt string, tools []Tool, opts ...
 	return resp, nil
 }
 
+// extractVisionOptions removes vision_options from options and returns them,
+// or nil when options do not set them as a map.
+func extractVisionOptions(options map[string]any) map[string]any {
+	visionOptions, ok := options["vision_options"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	delete(options, "vision_options")
+	return visionOptions
+}
+
+// toolDefinitions converts agent tools to provider tool definitions.
+func toolDefinitions(tools []Tool) []providers.ToolDefinition {
+	defs := make([]providers.ToolDefinition, len(tools))
+	for i, tool := range tools {
+		defs[i] = providers.ToolDefinition{
+			Name:        tool.Name,
+			Description: tool.Description,
+			Parameters:  tool.Parameters,
+		}
+	}
+	return defs
+}
+
 // Embed executes an embeddings protocol request.
 // Merges model's configured embeddings options with runtime opts.
 // Returns parsed EmbeddingsResponse or error.
 func (a *agent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
-	options := a.mergeOptions(protocol.Embeddings, opts...)
+	r, options, err := a.resolve(protocol.Embeddings, opts...)
+	if err != nil {
+		return nil, err
+	}
 
-	req := request.NewEmbeddings(a.provider, a.model, input, options)
+	req := request.NewEmbeddings(r.provider, r.model, input, options)
 
 	result, err := a.client.Execute(ctx, req)
 	if err != nil {
@@ -263,16 +385,103 @@ func (a *agent) Embed(ctx context.Context, input string, opts ...map[string]any)
 	return resp, nil
 }
 
-// mergeOptions creates options by merging model defaults with runtime options.
-func (a *agent) mergeOptions(proto protocol.Protocol, opts ...map[string]any) map[string]any {
+// SetSystemPrompt replaces the system prompt for later requests.
+func (a *agent) SetSystemPrompt(prompt string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.systemPrompt = prompt
+}
+
+// SetDefaultOptions replaces the default options for a protocol, or restores
+// the model's configured options when opts is nil. Opts are copied.
+func (a *agent) SetDefaultOptions(proto protocol.Protocol, opts map[string]any) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if opts == nil {
+		delete(a.defaults, proto)
+		return
+	}
+	if a.defaults == nil {
+		a.defaults = make(map[protocol.Protocol]map[string]any)
+	}
+	a.defaults[proto] = maps.Clone(opts)
+}
+
+// DefaultOptions returns a copy of the default options for a protocol on its
+// configured route.
+func (a *agent) DefaultOptions(proto protocol.Protocol) map[string]any {
+	return a.defaultOptions(proto, a.route(proto))
+}
+
+// defaultOptions returns a copy of the defaults set with SetDefaultOptions
+// for a protocol, or of the route model's configured options.
+func (a *agent) defaultOptions(proto protocol.Protocol, r *route) map[string]any {
 	options := make(map[string]any)
-	if modelOpts := a.model.Options[proto]; modelOpts != nil {
+	a.mu.RLock()
+	defaults, overridden := a.defaults[proto]
+	a.mu.RUnlock()
+	if overridden {
+		maps.Copy(options, defaults)
+	} else if modelOpts := r.model.Options[proto]; modelOpts != nil {
 		maps.Copy(options, modelOpts)
 	}
+	return options
+}
+
+// resolve selects the route for a request and merges its model defaults, or
+// the defaults set with SetDefaultOptions, with runtime options. A
+// ModelOption in the runtime options selects that alias; otherwise the
+// protocol's configured route is used, falling back to the agent's provider
+// and model.
+// Returns an error if the requested alias is not configured.
+func (a *agent) resolve(proto protocol.Protocol, opts ...map[string]any) (*route, map[string]any, error) {
+	r := a.route(proto)
+
+	var runtime map[string]any
 	if len(opts) > 0 && opts[0] != nil {
-		maps.Copy(options, opts[0])
+		runtime = maps.Clone(opts[0])
 	}
-	return options
+
+	if value, ok := runtime[ModelOption]; ok {
+		name, _ := value.(string)
+		aliased, ok := a.aliases[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown model alias: %v", value)
+		}
+		r = aliased
+		delete(runtime, ModelOption)
+	}
+
+	options := a.defaultOptions(proto, r)
+	maps.Copy(options, runtime)
+	return r, options, nil
+}
+
+// Supports reports whether the agent can serve a protocol.
+func (a *agent) Supports(p protocol.Protocol) bool {
+	r := a.route(p)
+
+	if _, err := r.provider.Endpoint(p); err != nil {
+		return false
+	}
+
+	switch p {
+	case protocol.Vision:
+		return r.model.Supports(config.FeatureVision)
+	case protocol.Tools:
+		return r.model.Supports(config.FeatureTools)
+	}
+	return true
+}
+
+// route returns the protocol's configured route, or the agent's provider
+// and model.
+func (a *agent) route(proto protocol.Protocol) *route {
+	if routed, ok := a.routes[proto]; ok {
+		return routed
+	}
+	return &route{provider: a.provider, model: a.model}
 }
 
 // initMessages creates the initial message list with optional system prompt.
@@ -281,8 +490,12 @@ func (a *agent) mergeOptions(proto protocol.Protocol, opts ...map[string]any) ma
 func (a *agent) initMessages(prompt string) []protocol.Message {
 	messages := make([]protocol.Message, 0)
 
-	if a.systemPrompt != "" {
-		messages = append(messages, protocol.NewMessage("system", a.systemPrompt))
+	a.mu.RLock()
+	systemPrompt := a.systemPrompt
+	a.mu.RUnlock()
+
+	if systemPrompt != "" {
+		messages = append(messages, protocol.NewMessage("system", systemPrompt))
 	}
 
 	messages = append(messages, protocol.NewMessage("user", prompt))
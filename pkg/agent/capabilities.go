@@ -0,0 +1,52 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Chatter is the chat capability of an agent. Orchestration code that only
+// sends prompts can depend on Chatter instead of Agent, so test doubles need
+// only these methods.
+type Chatter interface {
+	// Chat executes a chat protocol request with optional system prompt injection.
+	// Returns the parsed chat response or an error.
+	Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error)
+
+	// ChatStream executes a streaming chat protocol request.
+	// Automatically sets stream: true in options.
+	// Returns a channel of streaming chunks or an error.
+	ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error)
+}
+
+// Visioner is the vision capability of an agent.
+type Visioner interface {
+	// Vision executes a vision protocol request with images.
+	// Images can be URLs or base64-encoded data URIs.
+	// Returns the parsed chat response or an error.
+	Vision(ctx context.Context, prompt string, images []string, opts ...map[string]any) (*response.ChatResponse, error)
+
+	// VisionStream executes a streaming vision protocol request with images.
+	// Returns a channel of streaming chunks or an error.
+	VisionStream(ctx context.Context, prompt string, images []string, opts ...map[string]any) (<-chan *response.StreamingChunk, error)
+}
+
+// Tooler is the tools (function calling) capability of an agent.
+type Tooler interface {
+	// Tools executes a tools protocol request with function definitions.
+	// Returns the parsed tools response with tool calls or an error.
+	Tools(ctx context.Context, prompt string, tools []Tool, opts ...map[string]any) (*response.ToolsResponse, error)
+
+	// VisionTools executes a tools protocol request that also includes images,
+	// letting the model analyze images and respond with tool calls.
+	// Returns the parsed tools response with tool calls or an error.
+	VisionTools(ctx context.Context, prompt string, images []string, tools []Tool, opts ...map[string]any) (*response.ToolsResponse, error)
+}
+
+// Embedder is the embeddings capability of an agent.
+type Embedder interface {
+	// Embed executes an embeddings protocol request.
+	// Returns the parsed embeddings response or an error.
+	Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error)
+}
@@ -23,6 +23,22 @@
 //	    Embed(ctx context.Context, input string, opts ...map[string]any) (*types.EmbeddingsResponse, error)
 //	}
 //
+// # Capability Interfaces
+//
+// The protocol methods are grouped into the smaller Chatter, Visioner,
+// Tooler, and Embedder interfaces, which Agent embeds. Code that needs only
+// one capability can accept the small interface, so its test doubles
+// implement only those methods:
+//
+//	func Summarize(ctx context.Context, c agent.Chatter, text string) (string, error)
+//
+// Supports reports whether an agent can serve a protocol, checking the
+// routed provider's endpoints and the model's vision and tools features:
+//
+//	if a.Supports(protocol.Vision) {
+//	    resp, err = a.Vision(ctx, prompt, images)
+//	}
+//
 // # Creating an Agent
 //
 // Agents are created from configuration that includes transport and optional system prompt:
@@ -172,6 +188,21 @@
 //	}
 //	response, err := agent.Embed(ctx, "text to embed", options)
 //
+// # Bulk Embeddings
+//
+// EmbedAll turns a channel of inputs into a channel of results, batching
+// inputs into multi-input embeddings requests with bounded concurrency and
+// retrying failed batches:
+//
+//	results := agent.EmbedAll(ctx, a, inputs, agent.WithBatchSize(64), agent.WithConcurrency(8))
+//	for r := range results {
+//	    if r.Err != nil {
+//	        log.Printf("input %d: %v", r.Index, r.Err)
+//	        continue
+//	    }
+//	    index.Add(r.Index, r.Vector)
+//	}
+//
 // # System Prompt Injection
 //
 // When an agent is created with a system prompt, it's automatically prepended
@@ -210,6 +241,30 @@
 //
 // Options are merged with model defaults, with request options taking precedence.
 //
+// # Prewarming
+//
+// Local model servers such as Ollama load models on first use. Prewarm loads
+// each routed model ahead of traffic spikes, keeping it resident for its
+// keep_alive option (see options.KeepAlive):
+//
+//	if err := a.Prewarm(ctx); err != nil && !errors.Is(err, providers.ErrPrewarmNotSupported) {
+//	    log.Printf("prewarm failed: %v", err)
+//	}
+//
+// # Model Aliases and Routing
+//
+// Configured model aliases can be selected per request with ModelOption.
+// An alias may use its own provider; otherwise it shares the agent's provider:
+//
+//	response, err := agent.Chat(ctx, "Summarize this", map[string]any{"model": "fast"})
+//
+// Config routes send a protocol to an alias by default, so embeddings can use
+// a different model than chat within one agent:
+//
+//	"routes": {"embeddings": "embed"}
+//
+// Agent.Provider and Agent.Model return the default route.
+//
 // # Tool Definitions
 //
 // Tools follow the OpenAI function calling schema:
@@ -233,6 +288,20 @@
 //	    "required": []string{"param_name"},
 //	}
 //
+// # Structured Output
+//
+// ChatStructured constrains a chat request to a schema.Schema and validates
+// the output. Invalid output is re-prompted with the validation errors
+// appended, up to DefaultStructuredRetries times unless the
+// StructuredRetriesOption runtime option says otherwise. Each attempt is
+// reported to hooks registered with WithStructuredHook:
+//
+//	ctx = agent.WithStructuredHook(ctx, func(a agent.StructuredAttempt) {
+//	    log.Printf("attempt %d: %v", a.Attempt, a.Err)
+//	})
+//	out, err := agent.ChatStructured(ctx, a, "Describe Oslo", citySchema,
+//	    map[string]any{agent.StructuredRetriesOption: 3})
+//
 // # Error Handling
 //
 // All methods return standard Go errors:
@@ -319,6 +388,16 @@
 // Agents are safe for concurrent use. Multiple goroutines can call protocol methods
 // simultaneously on the same agent instance.
 //
+// Long-lived agents can be re-tuned at runtime without being recreated, for
+// example from an admin endpoint:
+//
+//	a.SetSystemPrompt("You are a concise assistant.")
+//	a.SetDefaultOptions(protocol.Chat, map[string]any{"temperature": 0.2})
+//
+// SetDefaultOptions replaces the model's configured options for the protocol;
+// runtime options still take precedence, and nil restores the configured
+// options. Requests already in flight are unaffected.
+//
 // # Complete Example
 //
 // Comprehensive agent usage:
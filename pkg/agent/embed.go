@@ -0,0 +1,232 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// EmbedResult is the outcome of embedding one EmbedAll input.
+// Index is the input's position in the input channel, counting from zero.
+type EmbedResult struct {
+	Index  int
+	Input  string
+	Vector []float64
+	Err    error
+}
+
+// EmbedAllOption configures EmbedAll.
+type EmbedAllOption func(*embedAllConfig)
+
+type embedAllConfig struct {
+	batchSize   int
+	concurrency int
+	retries     int
+	backoff     time.Duration
+	options     map[string]any
+}
+
+// WithBatchSize sets how many inputs are sent per embeddings request
+// (default 32).
+func WithBatchSize(n int) EmbedAllOption {
+	return func(c *embedAllConfig) {
+		c.batchSize = max(n, 1)
+	}
+}
+
+// WithConcurrency limits how many batches are in flight at once (default 4).
+func WithConcurrency(n int) EmbedAllOption {
+	return func(c *embedAllConfig) {
+		c.concurrency = max(n, 1)
+	}
+}
+
+// WithBatchRetries sets how many times a failed batch is retried after the
+// client's own retries are exhausted (default 2). The delay before each retry
+// starts at backoff and doubles.
+func WithBatchRetries(n int, backoff time.Duration) EmbedAllOption {
+	return func(c *embedAllConfig) {
+		c.retries = max(n, 0)
+		c.backoff = backoff
+	}
+}
+
+// WithEmbedOptions sets runtime embeddings options, merged over the model's
+// configured embeddings options as for Embed.
+func WithEmbedOptions(options map[string]any) EmbedAllOption {
+	return func(c *embedAllConfig) {
+		c.options = options
+	}
+}
+
+// embedBatch is a run of consecutive inputs starting at index start.
+type embedBatch struct {
+	start  int
+	inputs []string
+}
+
+// EmbedAll embeds every input received from inputs, batching them into
+// multi-input embeddings requests sent with bounded concurrency. One result
+// per input is sent on the returned channel as its batch completes, so
+// results arrive grouped by batch rather than in input order.
+//
+// A partial batch is sent once inputs is closed. A batch that still fails
+// after its retries reports the error in the result of each of its inputs.
+// The returned channel is closed after every input has a result, or once ctx
+// is cancelled; inputs are not drained after cancellation, so producers
+// should also watch ctx.
+func EmbedAll(ctx context.Context, a Agent, inputs <-chan string, opts ...EmbedAllOption) <-chan EmbedResult {
+	cfg := embedAllConfig{
+		batchSize:   32,
+		concurrency: 4,
+		retries:     2,
+		backoff:     500 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	results := make(chan EmbedResult, cfg.batchSize)
+	batches := make(chan embedBatch)
+
+	go func() {
+		defer close(batches)
+
+		batch := embedBatch{}
+		next := 0
+		send := func() bool {
+			if len(batch.inputs) == 0 {
+				return true
+			}
+			select {
+			case batches <- batch:
+				batch = embedBatch{start: next}
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case input, ok := <-inputs:
+				if !ok {
+					send()
+					return
+				}
+				batch.inputs = append(batch.inputs, input)
+				next++
+				if len(batch.inputs) == cfg.batchSize && !send() {
+					return
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range cfg.concurrency {
+		wg.Go(func() {
+			for batch := range batches {
+				vectors, err := embedWithRetry(ctx, a, batch.inputs, cfg)
+				for i, input := range batch.inputs {
+					result := EmbedResult{Index: batch.start + i, Input: input, Err: err}
+					if err == nil {
+						result.Vector = vectors[i]
+					}
+					select {
+					case results <- result:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// embedWithRetry embeds a batch, retrying failures with doubling backoff.
+func embedWithRetry(ctx context.Context, a Agent, inputs []string, cfg embedAllConfig) ([][]float64, error) {
+	backoff := cfg.backoff
+	for attempt := 0; ; attempt++ {
+		vectors, err := embedBatchOnce(ctx, a, inputs, cfg.options)
+		if err == nil || attempt >= cfg.retries || ctx.Err() != nil {
+			return vectors, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+// embedBatchOnce sends one multi-input embeddings request on the agent's
+// embeddings route and returns the vectors in input order.
+func embedBatchOnce(ctx context.Context, a Agent, inputs []string, runtime map[string]any) ([][]float64, error) {
+	req, err := embedRequest(a, inputs, runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.Client().Execute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, ok := result.(*response.EmbeddingsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type: %T", result)
+	}
+	if len(resp.Data) != len(inputs) {
+		return nil, fmt.Errorf("got %d embeddings for %d inputs", len(resp.Data), len(inputs))
+	}
+
+	vectors := make([][]float64, len(inputs))
+	for i, data := range resp.Data {
+		index := data.Index
+		if index < 0 || index >= len(inputs) || vectors[index] != nil {
+			index = i
+		}
+		vectors[index] = data.Embedding
+	}
+	return vectors, nil
+}
+
+// embedRequest builds a multi-input embeddings request. Agents created by New
+// use their embeddings route and model aliases; other implementations use
+// their provider and model.
+func embedRequest(a Agent, inputs []string, runtime map[string]any) (request.Request, error) {
+	if concrete, ok := a.(*agent); ok {
+		r, options, err := concrete.resolve(protocol.Embeddings, runtime)
+		if err != nil {
+			return nil, err
+		}
+		return request.NewEmbeddings(r.provider, r.model, inputs, options), nil
+	}
+
+	options := a.DefaultOptions(protocol.Embeddings)
+	if options == nil {
+		options = make(map[string]any)
+	}
+	maps.Copy(options, runtime)
+	return request.NewEmbeddings(a.Provider(), a.Model(), inputs, options), nil
+}
@@ -0,0 +1,24 @@
+package agent
+
+import (
+	"context"
+	"io"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// UploadFile uploads content to the agent provider's files API.
+func (a *agent) UploadFile(ctx context.Context, filename string, content io.Reader, purpose response.FilePurpose) (*response.File, error) {
+	return client.UploadFile(ctx, a.client, a.provider, filename, content, purpose)
+}
+
+// ListFiles returns the files stored with the agent's provider.
+func (a *agent) ListFiles(ctx context.Context, purpose response.FilePurpose) ([]response.File, error) {
+	return client.ListFiles(ctx, a.client, a.provider, purpose)
+}
+
+// DeleteFile deletes a file from the agent provider's files API.
+func (a *agent) DeleteFile(ctx context.Context, id string) error {
+	return client.DeleteFile(ctx, a.client, a.provider, id)
+}
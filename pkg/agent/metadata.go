@@ -0,0 +1,28 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+// WithMetadata returns a context carrying per-request metadata such as tenant
+// or user IDs for multi-tenant attribution. Pass the context to any agent
+// method: providers forward configured keys as the OpenAI "user" field or
+// custom headers (see providers.MetadataForwarder), and audit records include
+// the metadata. Metadata already in ctx is kept, with md values overriding
+// matching keys.
+//
+// Example:
+//
+//	ctx = agent.WithMetadata(ctx, map[string]string{"tenant": "acme", "user": "u-42"})
+//	resp, err := a.Chat(ctx, prompt)
+func WithMetadata(ctx context.Context, md map[string]string) context.Context {
+	return providers.WithMetadata(ctx, md)
+}
+
+// Metadata returns a copy of the request metadata carried by ctx,
+// or nil if there is none.
+func Metadata(ctx context.Context) map[string]string {
+	return providers.Metadata(ctx)
+}
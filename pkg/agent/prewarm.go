@@ -0,0 +1,52 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+// Prewarm loads the model of every protocol route into memory, once per
+// provider and model, with the keep_alive option of the first protocol
+// routed to it. Routes whose provider cannot prewarm are skipped.
+func (a *agent) Prewarm(ctx context.Context) error {
+	type target struct {
+		provider providers.Provider
+		model    string
+	}
+
+	var (
+		seen      = make(map[target]bool)
+		errs      []error
+		prewarmed bool
+	)
+	for _, proto := range protocol.ValidProtocols() {
+		r, options, err := a.resolve(proto)
+		if err != nil {
+			return err
+		}
+
+		t := target{provider: r.provider, model: r.model.Name}
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+
+		if _, ok := r.provider.(providers.Prewarmer); !ok {
+			continue
+		}
+		prewarmed = true
+		if err := client.Prewarm(ctx, a.client, r.provider, r.model.Name, options["keep_alive"]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if !prewarmed {
+		return fmt.Errorf("%w: %s", providers.ErrPrewarmNotSupported, a.provider.Name())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ErrAgentExists is returned when registering an agent whose ID is already registered.
+var ErrAgentExists = errors.New("agent already registered")
+
+// Registry tracks agents by ID for orchestration scenarios such as message
+// routing and lifecycle tracking. The zero value is not usable; create
+// registries with NewRegistry.
+// Thread-safe for concurrent registration and lookup.
+type Registry struct {
+	agents map[string]Agent
+	mu     sync.RWMutex
+}
+
+// NewRegistry creates an empty agent registry.
+func NewRegistry() *Registry {
+	return &Registry{agents: make(map[string]Agent)}
+}
+
+// Register adds an agent under its ID.
+// Returns ErrAgentExists if an agent with the same ID is already registered.
+func (r *Registry) Register(a Agent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.agents[a.ID()]; exists {
+		return fmt.Errorf("%w: %s", ErrAgentExists, a.ID())
+	}
+	r.agents[a.ID()] = a
+	return nil
+}
+
+// Unregister removes the agent with the given ID. Unknown IDs are ignored.
+func (r *Registry) Unregister(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.agents, id)
+}
+
+// Get returns the agent registered under an ID.
+func (r *Registry) Get(id string) (Agent, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, ok := r.agents[id]
+	return a, ok
+}
+
+// List returns all registered agents sorted by ID.
+func (r *Registry) List() []Agent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	agents := make([]Agent, 0, len(r.agents))
+	for _, id := range slices.Sorted(maps.Keys(r.agents)) {
+		agents = append(agents, r.agents[id])
+	}
+	return agents
+}
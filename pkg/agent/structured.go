@@ -0,0 +1,131 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/schema"
+)
+
+// StructuredRetriesOption is the runtime option key that sets how many times
+// ChatStructured re-prompts after invalid output, e.g.
+// map[string]any{"structured_retries": 3}. The key is consumed by
+// ChatStructured and never sent to the provider.
+const StructuredRetriesOption = "structured_retries"
+
+// DefaultStructuredRetries is the number of re-prompts ChatStructured makes
+// when StructuredRetriesOption is not set.
+const DefaultStructuredRetries = 2
+
+// StructuredAttempt describes one ChatStructured request and its outcome.
+type StructuredAttempt struct {
+	Schema  string
+	Attempt int // 1 for the first request
+	Prompt  string
+	Output  string
+	Usage   *response.TokenUsage
+	Err     error // *schema.ValidationError or *schema.SyntaxError; nil when valid
+}
+
+// StructuredHook receives a StructuredAttempt after each ChatStructured
+// response is validated. Hooks run synchronously and should return quickly.
+type StructuredHook func(StructuredAttempt)
+
+type structuredHooksKey struct{}
+
+// WithStructuredHook returns a context whose ChatStructured calls report every
+// attempt to hook. Hooks accumulate.
+//
+//	ctx = agent.WithStructuredHook(ctx, func(a agent.StructuredAttempt) {
+//	    if a.Err != nil {
+//	        log.Printf("attempt %d invalid: %v", a.Attempt, a.Err)
+//	    }
+//	})
+func WithStructuredHook(ctx context.Context, hook StructuredHook) context.Context {
+	hooks, _ := ctx.Value(structuredHooksKey{}).([]StructuredHook)
+	hooks = append(hooks[:len(hooks):len(hooks)], hook)
+	return context.WithValue(ctx, structuredHooksKey{}, hooks)
+}
+
+// ChatStructured sends a chat request constrained to s and validates the
+// output. When the output is not valid JSON or does not match s, the prompt
+// is sent again with the errors appended (see schema.Feedback), up to
+// StructuredRetriesOption more times.
+// Returns the valid JSON with any code fence removed. Request errors are
+// returned immediately; if every attempt is invalid, the returned error wraps
+// the last *schema.ValidationError or *schema.SyntaxError.
+func ChatStructured(ctx context.Context, a Agent, prompt string, s *schema.Schema, opts ...map[string]any) (json.RawMessage, error) {
+	options := make(map[string]any)
+	if len(opts) > 0 && opts[0] != nil {
+		options = maps.Clone(opts[0])
+	}
+
+	retries, err := structuredRetries(options)
+	if err != nil {
+		return nil, err
+	}
+	delete(options, StructuredRetriesOption)
+	s.Option()(options)
+
+	hooks, _ := ctx.Value(structuredHooksKey{}).([]StructuredHook)
+
+	attemptPrompt := prompt
+	for attempt := 1; ; attempt++ {
+		resp, err := a.Chat(ctx, attemptPrompt, maps.Clone(options))
+		if err != nil {
+			return nil, err
+		}
+
+		output := resp.Content()
+		invalid := s.ValidateJSON([]byte(output))
+
+		for _, hook := range hooks {
+			hook(StructuredAttempt{
+				Schema:  s.Name(),
+				Attempt: attempt,
+				Prompt:  attemptPrompt,
+				Output:  output,
+				Usage:   resp.Usage,
+				Err:     invalid,
+			})
+		}
+
+		if invalid == nil {
+			return json.RawMessage(schema.StripFence([]byte(output))), nil
+		}
+		if attempt > retries {
+			return nil, fmt.Errorf("structured output invalid after %d attempts: %w", attempt, invalid)
+		}
+		attemptPrompt = prompt + schema.Feedback(invalid)
+	}
+}
+
+// structuredRetries reads StructuredRetriesOption, accepting an int or a
+// whole float64 as decoded from JSON.
+func structuredRetries(options map[string]any) (int, error) {
+	value, ok := options[StructuredRetriesOption]
+	if !ok {
+		return DefaultStructuredRetries, nil
+	}
+
+	var retries int
+	switch v := value.(type) {
+	case int:
+		retries = v
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("invalid %s: %v is not a whole number", StructuredRetriesOption, v)
+		}
+		retries = int(v)
+	default:
+		return 0, fmt.Errorf("invalid %s: expected a number, got %T", StructuredRetriesOption, value)
+	}
+
+	if retries < 0 {
+		return 0, fmt.Errorf("invalid %s: must not be negative", StructuredRetriesOption)
+	}
+	return retries, nil
+}
@@ -0,0 +1,292 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Record is an audit entry for a single agent call.
+// Records are passed to sinks by value and share no state with the agent,
+// so sinks may retain them.
+//
+// Prompt holds the full prompt text only under PromptFull, redacted by the
+// redaction policy; PromptHash holds its SHA-256 digest under PromptHash and
+// PromptFull. Error is the call's error message, redacted by the policy. ResponseDigest is the
+// SHA-256 digest of the response text (tool calls and embedding vectors are
+// digested as JSON), identical for streamed and non-streamed calls.
+// Metadata holds the request metadata set with agent.WithMetadata.
+type Record struct {
+	Timestamp      time.Time            `json:"timestamp"`
+	AgentID        string               `json:"agent_id"`
+	Protocol       protocol.Protocol    `json:"protocol"`
+	Provider       string               `json:"provider"`
+	Model          string               `json:"model"`
+	Stream         bool                 `json:"stream,omitempty"`
+	Prompt         string               `json:"prompt,omitempty"`
+	PromptHash     string               `json:"prompt_hash,omitempty"`
+	Images         int                  `json:"images,omitempty"`
+	ResponseDigest string               `json:"response_digest,omitempty"`
+	Usage          *response.TokenUsage `json:"usage,omitempty"`
+	Latency        time.Duration        `json:"latency_ns"`
+	Error          string               `json:"error,omitempty"`
+	Metadata       map[string]string    `json:"metadata,omitempty"`
+}
+
+// PromptPolicy controls how prompts are recorded.
+type PromptPolicy string
+
+const (
+	// PromptHash records only the SHA-256 digest of the prompt (default).
+	PromptHash PromptPolicy = "hash"
+
+	// PromptFull records the prompt text and its digest.
+	PromptFull PromptPolicy = "full"
+
+	// PromptOmit records neither the prompt nor its digest.
+	PromptOmit PromptPolicy = "omit"
+)
+
+// Option configures an audited agent.
+type Option func(*auditAgent)
+
+// WithPromptPolicy sets how prompts are recorded. Without it, the redaction
+// policy's prompt mode applies, and PromptHash when that sets none.
+func WithPromptPolicy(policy PromptPolicy) Option {
+	return func(a *auditAgent) {
+		a.policy = policy
+	}
+}
+
+// WithRedaction sets the redaction policy applied to recorded prompts and
+// error messages (default redact.Default at the time of each call).
+func WithRedaction(policy *redact.Policy) Option {
+	return func(a *auditAgent) {
+		a.redaction = policy
+	}
+}
+
+// WithErrorHandler receives errors returned by the sink.
+// Sink errors never fail the agent call; without a handler they are discarded.
+func WithErrorHandler(handler func(error)) Option {
+	return func(a *auditAgent) {
+		a.onError = handler
+	}
+}
+
+// auditAgent decorates an Agent, writing a Record to the sink for every call.
+type auditAgent struct {
+	agent.Agent
+	sink      Sink
+	policy    PromptPolicy
+	redaction *redact.Policy
+	onError   func(error)
+}
+
+// Wrap returns an Agent that records every Chat, ChatStream, Vision,
+// VisionStream, Tools, and Embed call to the sink, including failed calls.
+// Streaming calls are recorded when the stream ends.
+func Wrap(a agent.Agent, sink Sink, opts ...Option) agent.Agent {
+	audited := &auditAgent{Agent: a, sink: sink}
+	for _, opt := range opts {
+		opt(audited)
+	}
+	return audited
+}
+
+func (a *auditAgent) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
+	rec := a.begin(ctx, protocol.Chat, prompt)
+	resp, err := a.Agent.Chat(ctx, prompt, opts...)
+	if err == nil {
+		a.finish(rec, resp.Model, resp.Content(), resp.Usage, nil)
+	} else {
+		a.finish(rec, "", "", nil, err)
+	}
+	return resp, err
+}
+
+func (a *auditAgent) ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	rec := a.begin(ctx, protocol.Chat, prompt)
+	chunks, err := a.Agent.ChatStream(ctx, prompt, opts...)
+	if err != nil {
+		a.finish(rec, "", "", nil, err)
+		return nil, err
+	}
+	return a.stream(ctx, rec, chunks), nil
+}
+
+func (a *auditAgent) Vision(ctx context.Context, prompt string, images []string, opts ...map[string]any) (*response.ChatResponse, error) {
+	rec := a.begin(ctx, protocol.Vision, prompt)
+	rec.Images = len(images)
+	resp, err := a.Agent.Vision(ctx, prompt, images, opts...)
+	if err == nil {
+		a.finish(rec, resp.Model, resp.Content(), resp.Usage, nil)
+	} else {
+		a.finish(rec, "", "", nil, err)
+	}
+	return resp, err
+}
+
+func (a *auditAgent) VisionStream(ctx context.Context, prompt string, images []string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	rec := a.begin(ctx, protocol.Vision, prompt)
+	rec.Images = len(images)
+	chunks, err := a.Agent.VisionStream(ctx, prompt, images, opts...)
+	if err != nil {
+		a.finish(rec, "", "", nil, err)
+		return nil, err
+	}
+	return a.stream(ctx, rec, chunks), nil
+}
+
+func (a *auditAgent) Tools(ctx context.Context, prompt string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	rec := a.begin(ctx, protocol.Tools, prompt)
+	resp, err := a.Agent.Tools(ctx, prompt, tools, opts...)
+	a.finishTools(rec, resp, err)
+	return resp, err
+}
+
+func (a *auditAgent) VisionTools(ctx context.Context, prompt string, images []string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	rec := a.begin(ctx, protocol.Tools, prompt)
+	rec.Images = len(images)
+	resp, err := a.Agent.VisionTools(ctx, prompt, images, tools, opts...)
+	a.finishTools(rec, resp, err)
+	return resp, err
+}
+
+// finishTools completes a tools record, digesting the text and tool calls.
+func (a *auditAgent) finishTools(rec Record, resp *response.ToolsResponse, err error) {
+	if err != nil {
+		a.finish(rec, "", "", nil, err)
+		return
+	}
+
+	text := ""
+	if len(resp.Choices) > 0 {
+		text = resp.Choices[0].Message.Content
+	}
+	if calls := resp.ToolCalls(); len(calls) > 0 {
+		data, _ := json.Marshal(calls)
+		text += string(data)
+	}
+	a.finish(rec, resp.Model, text, resp.Usage, nil)
+}
+
+func (a *auditAgent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
+	rec := a.begin(ctx, protocol.Embeddings, input)
+	resp, err := a.Agent.Embed(ctx, input, opts...)
+	if err != nil {
+		a.finish(rec, "", "", nil, err)
+		return resp, err
+	}
+
+	data, _ := json.Marshal(resp.Data)
+	a.finish(rec, resp.Model, string(data), resp.Usage, nil)
+	return resp, nil
+}
+
+// begin starts a record for a call, applying the prompt policy.
+func (a *auditAgent) begin(ctx context.Context, proto protocol.Protocol, prompt string) Record {
+	rec := Record{
+		Timestamp: time.Now(),
+		AgentID:   a.ID(),
+		Protocol:  proto,
+		Provider:  a.Provider().Name(),
+		Model:     a.Model().Name,
+		Metadata:  agent.Metadata(ctx),
+	}
+
+	policy := a.policy
+	if policy == "" {
+		policy = PromptPolicy(a.redaction.Prompts())
+	}
+
+	switch policy {
+	case PromptFull:
+		rec.Prompt = a.redaction.String(prompt)
+		rec.PromptHash = digest(prompt)
+	case PromptOmit:
+	default:
+		rec.PromptHash = digest(prompt)
+	}
+
+	return rec
+}
+
+// finish completes a record with the call outcome and writes it to the sink.
+// The served model overrides the configured model when reported.
+func (a *auditAgent) finish(rec Record, served, text string, usage *response.TokenUsage, err error) {
+	rec.Latency = time.Since(rec.Timestamp)
+	if served != "" {
+		rec.Model = served
+	}
+	if usage != nil {
+		copied := *usage
+		rec.Usage = &copied
+	}
+	if err != nil {
+		rec.Error = a.redaction.String(err.Error())
+	} else {
+		rec.ResponseDigest = digest(text)
+	}
+
+	if writeErr := a.sink.Write(rec); writeErr != nil && a.onError != nil {
+		a.onError(writeErr)
+	}
+}
+
+// stream relays chunks to the caller and records the call when the stream ends.
+// If ctx is cancelled while the caller is not reading, the remaining chunks
+// are drained so the producer is not blocked.
+func (a *auditAgent) stream(ctx context.Context, rec Record, chunks <-chan *response.StreamingChunk) <-chan *response.StreamingChunk {
+	rec.Stream = true
+	out := make(chan *response.StreamingChunk)
+
+	go func() {
+		defer close(out)
+
+		var text strings.Builder
+		var served string
+		var usage *response.TokenUsage
+		var streamErr error
+
+	relay:
+		for chunk := range chunks {
+			if chunk != nil {
+				text.WriteString(chunk.Content())
+				if chunk.Model != "" {
+					served = chunk.Model
+				}
+				if chunk.Usage != nil {
+					usage = chunk.Usage
+				}
+				if chunk.Error != nil {
+					streamErr = chunk.Error
+				}
+			}
+
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				streamErr = ctx.Err()
+				for range chunks {
+				}
+				break relay
+			}
+		}
+
+		a.finish(rec, served, text.String(), usage, streamErr)
+	}()
+
+	return out
+}
+
+// digest returns the hex-encoded SHA-256 digest of text, prefixed with "sha256:".
+func digest(text string) string {
+	return redact.Digest(text)
+}
@@ -0,0 +1,24 @@
+// Package audit records LLM interactions for compliance and attribution.
+// Wrap decorates an agent.Agent so every call produces an immutable Record
+// (timestamp, agent ID, protocol, prompt hash or text, response digest, usage,
+// latency) delivered to a Sink. Auditing is opt-in: unwrapped agents record nothing.
+//
+// Append records to a JSON Lines file:
+//
+//	sink, err := audit.NewFileSink("audit.jsonl")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer sink.Close()
+//
+//	a = audit.Wrap(a, sink)
+//	resp, err := a.Chat(ctx, "Summarize the contract")
+//
+// Prompts are recorded as SHA-256 digests by default so audit logs do not
+// retain user content; WithPromptPolicy(audit.PromptFull) records full text
+// and audit.PromptOmit records neither. Full prompts and error messages are
+// redacted by the default redaction policy (see package redact), or by the
+// policy set with WithRedaction, whose prompt mode applies when
+// WithPromptPolicy is not given. Custom sinks (databases, queues)
+// implement Sink or use SinkFunc.
+package audit
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Sink receives audit records.
+// Implementations must be safe for concurrent use.
+type Sink interface {
+	Write(rec Record) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(rec Record) error
+
+// Write calls f(rec).
+func (f SinkFunc) Write(rec Record) error {
+	return f(rec)
+}
+
+// WriterSink writes records to an io.Writer as JSON Lines.
+// Thread-safe for concurrent use.
+type WriterSink struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewWriterSink creates a sink that writes one JSON object per line to w.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Write encodes the record as a single JSON line.
+func (s *WriterSink) Write(rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.Write(data); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+// FileSink appends records to a JSON Lines file.
+// Thread-safe for concurrent use.
+type FileSink struct {
+	*WriterSink
+	file *os.File
+}
+
+// NewFileSink opens path for appending, creating it with owner-only
+// permissions if it does not exist.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &FileSink{WriterSink: NewWriterSink(file), file: file}, nil
+}
+
+// Close closes the underlying file.
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
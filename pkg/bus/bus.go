@@ -0,0 +1,198 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+)
+
+var (
+	// ErrUnknownAgent indicates a message addressed to an agent that is not registered.
+	ErrUnknownAgent = errors.New("unknown agent")
+
+	// ErrNoHandler indicates a registered agent has no message handler.
+	ErrNoHandler = errors.New("agent has no message handler")
+
+	// ErrPayloadType indicates a payload does not have the requested type.
+	ErrPayloadType = errors.New("unexpected payload type")
+)
+
+// Message is an envelope exchanged between agents.
+// To addresses a single agent by ID; Topic addresses every topic subscriber.
+// Type names the payload kind so handlers can dispatch on it, and
+// CorrelationID links a reply to its request.
+type Message struct {
+	ID            string
+	From          string
+	To            string
+	Topic         string
+	Type          string
+	Payload       any
+	CorrelationID string
+	Timestamp     time.Time
+}
+
+// Handler processes a delivered message. The returned value is sent back as
+// the reply payload for Request and is ignored for Send and Publish.
+type Handler func(ctx context.Context, msg Message) (any, error)
+
+// Bus is a lightweight in-process message bus for agents registered in an
+// agent.Registry. Direct messages are delivered to the handler of the
+// addressed agent; topic messages are broadcast to every topic subscriber.
+//
+// Handlers run on the sender's goroutine and receive the sender's context,
+// so cancellation and deadlines propagate. Handlers that do long-running work
+// should hand it off to their own goroutine.
+// Thread-safe for concurrent use.
+type Bus struct {
+	registry *agent.Registry
+	handlers map[string]Handler
+	topics   map[string]map[uint64]Handler
+	nextSub  uint64
+	mu       sync.RWMutex
+}
+
+// New creates a Bus that routes direct messages to agents in the registry.
+func New(registry *agent.Registry) *Bus {
+	return &Bus{
+		registry: registry,
+		handlers: make(map[string]Handler),
+		topics:   make(map[string]map[uint64]Handler),
+	}
+}
+
+// Handle sets the handler for messages addressed to an agent, replacing any
+// existing handler. Returns ErrUnknownAgent if the agent is not registered.
+func (b *Bus) Handle(agentID string, handler Handler) error {
+	if _, ok := b.registry.Get(agentID); !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAgent, agentID)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[agentID] = handler
+	return nil
+}
+
+// Subscribe adds a handler for messages published to a topic.
+// Returns a function that removes the subscription.
+func (b *Bus) Subscribe(topic string, handler Handler) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextSub++
+	id := b.nextSub
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[uint64]Handler)
+	}
+	b.topics[topic][id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.topics[topic], id)
+		if len(b.topics[topic]) == 0 {
+			delete(b.topics, topic)
+		}
+	}
+}
+
+// Send delivers a message to the agent named by msg.To and waits for its
+// handler to return. Returns ErrUnknownAgent if the recipient is not
+// registered, ErrNoHandler if it has no handler, or the handler's error.
+func (b *Bus) Send(ctx context.Context, msg Message) error {
+	_, err := b.deliver(ctx, stamp(msg))
+	return err
+}
+
+// Request delivers a message to the agent named by msg.To and returns the
+// handler's result as a reply message correlated to the request.
+func (b *Bus) Request(ctx context.Context, msg Message) (Message, error) {
+	msg = stamp(msg)
+
+	payload, err := b.deliver(ctx, msg)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return stamp(Message{
+		From:          msg.To,
+		To:            msg.From,
+		Type:          msg.Type,
+		Payload:       payload,
+		CorrelationID: msg.ID,
+	}), nil
+}
+
+// Publish broadcasts a message to every subscriber of msg.Topic and returns
+// the number of subscribers it was delivered to. Every subscriber is called
+// even if some fail; their errors are joined.
+func (b *Bus) Publish(ctx context.Context, msg Message) (int, error) {
+	msg = stamp(msg)
+
+	b.mu.RLock()
+	subscribers := make([]Handler, 0, len(b.topics[msg.Topic]))
+	for _, handler := range b.topics[msg.Topic] {
+		subscribers = append(subscribers, handler)
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range subscribers {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if _, err := handler(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(subscribers), errors.Join(errs...)
+}
+
+// deliver invokes the recipient's handler for a direct message.
+func (b *Bus) deliver(ctx context.Context, msg Message) (any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, ok := b.registry.Get(msg.To); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAgent, msg.To)
+	}
+
+	b.mu.RLock()
+	handler, ok := b.handlers[msg.To]
+	b.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoHandler, msg.To)
+	}
+
+	return handler(ctx, msg)
+}
+
+// stamp assigns a message ID and timestamp when they are not set.
+func stamp(msg Message) Message {
+	if msg.ID == "" {
+		msg.ID = uuid.Must(uuid.NewV7()).String()
+	}
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+	return msg
+}
+
+// Payload returns the message payload as type T.
+// Returns ErrPayloadType if the payload has a different type.
+func Payload[T any](msg Message) (T, error) {
+	value, ok := msg.Payload.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w: got %T, want %T", ErrPayloadType, msg.Payload, zero)
+	}
+	return value, nil
+}
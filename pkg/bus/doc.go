@@ -0,0 +1,39 @@
+// Package bus provides a lightweight in-process message bus for multi-agent
+// coordination. Agents registered in an agent.Registry exchange Message
+// envelopes addressed by agent ID or topic.
+//
+// Register agents and their handlers:
+//
+//	registry := agent.NewRegistry()
+//	registry.Register(planner)
+//	registry.Register(researcher)
+//
+//	b := bus.New(registry)
+//	b.Handle(researcher.ID(), func(ctx context.Context, msg bus.Message) (any, error) {
+//	    question, err := bus.Payload[string](msg)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    resp, err := researcher.Chat(ctx, question)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return resp.Content(), nil
+//	})
+//
+// Request/response between agents:
+//
+//	reply, err := b.Request(ctx, bus.Message{From: planner.ID(), To: researcher.ID(), Payload: "What is RAG?"})
+//	answer, err := bus.Payload[string](reply)
+//
+// Broadcast to topic subscribers:
+//
+//	unsubscribe := b.Subscribe("status", func(ctx context.Context, msg bus.Message) (any, error) {
+//	    log.Printf("%s: %v", msg.From, msg.Payload)
+//	    return nil, nil
+//	})
+//	defer unsubscribe()
+//	b.Publish(ctx, bus.Message{From: planner.ID(), Topic: "status", Payload: "plan ready"})
+//
+// Handlers run synchronously on the sender's goroutine with the sender's context.
+package bus
@@ -0,0 +1,43 @@
+// Package cassette records real provider HTTP exchanges into fixture files and
+// replays them deterministically, enabling hermetic integration tests against
+// real wire formats.
+//
+// A Recorder is an http.RoundTripper. Install it through the client
+// configuration's Transport field so agents and clients use it transparently:
+//
+//	rec, err := cassette.New("testdata/chat.json", cassette.ModeAuto)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer rec.Save()
+//
+//	cfg.Client.Transport = rec
+//	a, err := agent.New(cfg)
+//
+// ModeRecord sends requests to the real provider and captures each exchange;
+// Save writes them to the cassette. ModeReplay serves recorded responses
+// without network access and returns an error wrapping ErrNoInteraction when
+// a request has no unused match. ModeAuto replays when the cassette exists and
+// records otherwise, so deleting a fixture re-records it on the next run.
+//
+// # Matching
+//
+// Replayed requests match by method, URL, and body, and each interaction is
+// served at most once in recorded order, so repeated identical requests replay
+// their responses in sequence. JSON bodies are compared structurally.
+//
+// # Sanitization
+//
+// Credentials are replaced with Redacted before they reach the fixture.
+// Request headers and query parameters the redaction policy treats as secret
+// (Authorization, api-key, key-style parameters, and configured keys; see
+// redact.Policy.Secret) and Set-Cookie response headers are redacted by
+// default; set the policy with WithPolicy and add more with
+// WithRedactedHeaders, WithRedactedParams, and WithRedactedFields (JSON
+// request body fields).
+// Requests are sanitized the same way before matching, so replay does not
+// depend on the credentials in use.
+//
+// Streaming responses are captured verbatim as they are read, so server-sent
+// events replay chunk for chunk.
+package cassette
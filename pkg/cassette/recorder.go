@@ -0,0 +1,381 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+)
+
+// ErrNoInteraction indicates a replayed request has no unused matching
+// interaction in the cassette.
+var ErrNoInteraction = errors.New("no matching cassette interaction")
+
+// Redacted replaces sanitized header, query parameter, and body field values.
+const Redacted = "REDACTED"
+
+// Mode selects whether a Recorder captures real exchanges or serves recorded ones.
+type Mode string
+
+const (
+	// ModeRecord sends requests to the real transport and records each exchange.
+	ModeRecord Mode = "record"
+
+	// ModeReplay serves responses from the cassette without network access.
+	ModeReplay Mode = "replay"
+
+	// ModeAuto replays when the cassette file exists and records otherwise.
+	ModeAuto Mode = "auto"
+)
+
+// defaultResponseHeaders are the response headers redacted by default.
+// Request headers and query parameters are redacted by the redaction policy
+// instead, which would also match response headers such as
+// x-ratelimit-remaining-tokens that replay must preserve.
+var defaultResponseHeaders = []string{"Set-Cookie"}
+
+// Recorder is an http.RoundTripper that records provider exchanges to a
+// cassette file or replays them deterministically.
+// Thread-safe for concurrent requests.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	policy    *redact.Policy
+
+	headers map[string]bool
+	params  map[string]bool
+	fields  map[string]bool
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to reach the real provider in
+// record mode. Defaults to http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// WithPolicy sets the redaction policy that selects the request headers and
+// query parameters to redact. Defaults to redact.Default.
+func WithPolicy(p *redact.Policy) Option {
+	return func(r *Recorder) {
+		r.policy = p
+	}
+}
+
+// WithRedactedHeaders adds request and response headers to redact.
+func WithRedactedHeaders(names ...string) Option {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.headers[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+}
+
+// WithRedactedParams adds URL query parameters to redact.
+func WithRedactedParams(names ...string) Option {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.params[strings.ToLower(name)] = true
+		}
+	}
+}
+
+// WithRedactedFields adds JSON request body fields to redact at any depth.
+func WithRedactedFields(names ...string) Option {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.fields[name] = true
+		}
+	}
+}
+
+// New creates a Recorder for the cassette at path.
+// ModeReplay loads the cassette and fails if it does not exist; ModeAuto
+// resolves to replay when the file exists and to record otherwise.
+// Recorded exchanges are written by Save.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		headers:   make(map[string]bool),
+		params:    make(map[string]bool),
+		fields:    make(map[string]bool),
+		cassette:  &Cassette{},
+	}
+
+	WithRedactedHeaders(defaultResponseHeaders...)(r)
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeAuto {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+
+	switch r.mode {
+	case ModeRecord:
+	case ModeReplay:
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	default:
+		return nil, fmt.Errorf("invalid cassette mode: %s", mode)
+	}
+
+	return r, nil
+}
+
+// Mode returns the resolved mode, which is never ModeAuto.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Save writes recorded interactions to the cassette file.
+// It is a no-op in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		body = data
+	}
+
+	recorded := r.sanitizeRequest(req, body)
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, body, recorded)
+}
+
+// record forwards the request and captures the response body as it is read,
+// so streaming responses reach the caller incrementally. The interaction is
+// added once the body is fully read or closed.
+func (r *Recorder) record(req *http.Request, body []byte, recorded Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if req.Body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    r.sanitizeHeaders(resp.Header, nil),
+		},
+	}
+
+	resp.Body = &recordingBody{
+		ReadCloser: resp.Body,
+		done: func(data []byte) {
+			interaction.Response.Body = string(data)
+			r.mu.Lock()
+			r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+			r.mu.Unlock()
+		},
+	}
+	return resp, nil
+}
+
+// replay serves the first unused interaction matching the request.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		res := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode)),
+			StatusCode:    res.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        res.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(res.Body)),
+			ContentLength: int64(len(res.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, recorded.Method, recorded.URL)
+}
+
+// matches reports whether a recorded request matches a sanitized live request
+// by method, URL, and body. JSON bodies are compared structurally.
+func matches(recorded, live Request) bool {
+	if recorded.Method != live.Method || recorded.URL != live.URL {
+		return false
+	}
+	if recorded.Body == live.Body {
+		return true
+	}
+
+	var a, b any
+	if json.Unmarshal([]byte(recorded.Body), &a) != nil || json.Unmarshal([]byte(live.Body), &b) != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// sanitizeRequest builds the recorded form of a request with secrets redacted.
+func (r *Recorder) sanitizeRequest(req *http.Request, body []byte) Request {
+	u := *req.URL
+	u.User = nil
+	if query := u.Query(); len(query) > 0 {
+		redacted := false
+		for name := range query {
+			if r.params[strings.ToLower(name)] || r.policy.Secret(name) {
+				query.Set(name, Redacted)
+				redacted = true
+			}
+		}
+		if redacted {
+			u.RawQuery = query.Encode()
+		}
+	}
+
+	return Request{
+		Method:  req.Method,
+		URL:     u.String(),
+		Headers: r.sanitizeHeaders(req.Header, r.policy.Secret),
+		Body:    r.sanitizeBody(body),
+	}
+}
+
+// sanitizeHeaders redacts configured headers and, when secret is set, the
+// headers it reports.
+func (r *Recorder) sanitizeHeaders(header http.Header, secret func(string) bool) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	sanitized := header.Clone()
+	for name := range sanitized {
+		if r.headers[http.CanonicalHeaderKey(name)] || (secret != nil && secret(name)) {
+			sanitized[name] = []string{Redacted}
+		}
+	}
+	return sanitized
+}
+
+// sanitizeBody redacts configured fields from JSON bodies.
+// Non-JSON bodies are recorded unchanged.
+func (r *Recorder) sanitizeBody(body []byte) string {
+	if len(r.fields) == 0 {
+		return string(body)
+	}
+
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return string(body)
+	}
+
+	if !r.redactFields(decoded) {
+		return string(body)
+	}
+
+	data, err := json.Marshal(decoded)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+// redactFields replaces configured fields in place and reports whether any
+// field was redacted.
+func (r *Recorder) redactFields(value any) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if r.fields[key] {
+				v[key] = Redacted
+				redacted = true
+				continue
+			}
+			redacted = r.redactFields(item) || redacted
+		}
+	case []any:
+		for _, item := range v {
+			redacted = r.redactFields(item) || redacted
+		}
+	}
+	return redacted
+}
+
+// recordingBody captures a response body as it is read and reports it once
+// on EOF, a read error, or Close.
+type recordingBody struct {
+	io.ReadCloser
+	buf  bytes.Buffer
+	done func([]byte)
+	once sync.Once
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	if err != nil {
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	b.finish()
+	return b.ReadCloser.Close()
+}
+
+func (b *recordingBody) finish() {
+	b.once.Do(func() {
+		b.done(b.buf.Bytes())
+	})
+}
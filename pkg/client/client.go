@@ -3,13 +3,17 @@ package client
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
 	"github.com/tailored-agentic-units/tau-core/pkg/request"
 	"github.com/tailored-agentic-units/tau-core/pkg/response"
 )
@@ -25,50 +29,79 @@ type Client interface {
 	// Execute executes a protocol request and returns the parsed response.
 	// Provider and model are obtained from the request.
 	// Automatically retries on transient failures (HTTP 429/502/503/504, network errors).
-	// Returns an error if request fails.
+	// Options are validated against the protocol schema before sending
+	// when option validation is configured.
+	// Responses are parsed in the configured parse mode.
+	// Requests are assigned a request ID unless ctx already carries one
+	// (see providers.CallInfo); retries reuse it.
+	// Returns an error if options are invalid or the request fails.
 	Execute(ctx context.Context, req request.Request) (any, error)
 
 	// ExecuteStream executes a streaming protocol request and returns a channel of chunks.
 	// Provider and model are obtained from the request.
+	// Chunks are parsed in the configured streaming parse mode and buffered
+	// according to the streaming config (see config.StreamingConfig).
 	// The channel is closed when streaming completes or context is cancelled.
-	// Returns an error if protocol doesn't support streaming or request fails.
+	// Returns an error if protocol doesn't support streaming, options are invalid,
+	// or the request fails.
 	ExecuteStream(ctx context.Context, req request.Request) (<-chan *response.StreamingChunk, error)
 
 	// IsHealthy returns the current health status of the client.
 	// Set to false after request failures, true after successful requests.
 	// Thread-safe for concurrent access.
 	IsHealthy() bool
+
+	// Shutdown stops accepting new Execute and ExecuteStream calls, and the
+	// package functions that send through the client (such as ListFiles),
+	// which then fail with ErrClientClosed, and waits for in-flight requests
+	// and open streams to finish before closing idle connections.
+	// Returns the context's error if it is done first; in-flight requests
+	// are not cancelled. Safe to call more than once.
+	Shutdown(ctx context.Context) error
 }
 
 // client implements the Client interface with HTTP orchestration.
 type client struct {
 	config *config.ClientConfig
+	pool   *http.Transport
 
 	mutex      sync.RWMutex
 	healthy    bool
 	lastHealth time.Time
+
+	drain drainState
 }
 
 // New creates a new Client from configuration.
 // Initializes HTTP settings and health tracking.
 func New(cfg *config.ClientConfig) Client {
 	return &client{
-		config:     cfg,
+		config: cfg,
+		pool: &http.Transport{
+			MaxIdleConns:        cfg.ConnectionPoolSize,
+			MaxIdleConnsPerHost: cfg.ConnectionPoolSize,
+			IdleConnTimeout:     cfg.ConnectionTimeout.ToDuration(),
+		},
 		healthy:    true,
 		lastHealth: time.Now(),
 	}
 }
 
 // HTTPClient creates and returns a configured HTTP client.
-// Each call creates a new client with timeout and connection pool settings from configuration.
+// Each call creates a new client with the configured timeout; clients share
+// the client's connection pool so connections are reused across requests.
+// A configured Transport replaces the pooled transport.
 func (c *client) HTTPClient() *http.Client {
+	if c.config.Transport != nil {
+		return &http.Client{
+			Timeout:   c.config.Timeout.ToDuration(),
+			Transport: c.config.Transport,
+		}
+	}
+
 	return &http.Client{
-		Timeout: c.config.Timeout.ToDuration(),
-		Transport: &http.Transport{
-			MaxIdleConns:        c.config.ConnectionPoolSize,
-			MaxIdleConnsPerHost: c.config.ConnectionPoolSize,
-			IdleConnTimeout:     c.config.ConnectionTimeout.ToDuration(),
-		},
+		Timeout:   c.config.Timeout.ToDuration(),
+		Transport: c.pool,
 	}
 }
 
@@ -76,14 +109,41 @@ func (c *client) HTTPClient() *http.Client {
 // Provider and model are obtained from the request.
 // Executes with retry on transient failures.
 func (c *client) Execute(ctx context.Context, req request.Request) (any, error) {
-	return doWithRetry(ctx, c.config.Retry, func(ctx context.Context) (any, error) {
-		return c.execute(ctx, req)
+	if err := c.drain.acquire(); err != nil {
+		return nil, err
+	}
+	defer c.drain.release()
+
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	ctx, err := withParseMode(ctx, c.config.ParseMode)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withFormat(withRequestID(ctx), req)
+
+	event := RetryEvent{
+		Provider: req.Provider().Name(),
+		Protocol: string(req.Protocol()),
+		Model:    req.Model().Name,
+	}
+
+	attempt := 0
+	return doWithRetry(ctx, c.config.Retry, event, func(ctx context.Context) (any, error) {
+		attempt++
+		return c.execute(ctx, req, attempt)
 	})
 }
 
 // execute performs a single HTTP request attempt without retry logic.
 // Returns HTTPStatusError for bad status codes, which retry logic evaluates.
-func (c *client) execute(ctx context.Context, req request.Request) (any, error) {
+// The attempt is traced when ctx carries timing hooks.
+func (c *client) execute(ctx context.Context, req request.Request, attempt int) (result any, err error) {
+	trace, ctx := startTrace(ctx, req, attempt, false)
+	defer func() { trace.finish(err) }()
+
 	provider := req.Provider()
 	proto := req.Protocol()
 
@@ -94,7 +154,7 @@ func (c *client) execute(ctx context.Context, req request.Request) (any, error)
 	}
 
 	// Prepare provider request
-	providerRequest, err := provider.PrepareRequest(ctx, proto, body, req.Headers())
+	providerRequest, err := providers.Prepare(ctx, provider, proto, body, req.Headers(), false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare request: %w", err)
 	}
@@ -115,6 +175,9 @@ func (c *client) execute(ctx context.Context, req request.Request) (any, error)
 		httpReq.Header.Set(key, value)
 	}
 	provider.SetHeaders(httpReq)
+	if err := providers.Sign(provider, httpReq, providerRequest.Body); err != nil {
+		return nil, err
+	}
 
 	// Execute HTTP request
 	httpClient := c.HTTPClient()
@@ -124,22 +187,24 @@ func (c *client) execute(ctx context.Context, req request.Request) (any, error)
 		return nil, err // Network error - retry logic will evaluate
 	}
 	defer resp.Body.Close()
+	trace.status(resp.StatusCode)
+	notifyRateLimits(ctx, req, resp)
 
 	// Check for non-OK status - return HTTPStatusError for retry evaluation
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		c.setHealthy(false)
-		return nil, &HTTPStatusError{
-			StatusCode: resp.StatusCode,
-			Status:     resp.Status,
-			Body:       bodyBytes,
-		}
+		return nil, newHTTPStatusError(resp, bodyBytes)
 	}
 
 	// Process response through provider
-	result, err := provider.ProcessResponse(ctx, resp, proto)
+	result, err = providers.Process(ctx, provider, resp, proto)
 	if err != nil {
-		c.setHealthy(false)
+		// A deferred request was accepted; the provider is healthy
+		var deferred *providers.DeferredCompletion
+		if !errors.As(err, &deferred) {
+			c.setHealthy(false)
+		}
 		return nil, err
 	}
 
@@ -150,7 +215,13 @@ func (c *client) execute(ctx context.Context, req request.Request) (any, error)
 // ExecuteStream executes a streaming protocol request.
 // Provider and model are obtained from the request.
 // Verifies protocol supports streaming and executes streaming flow.
-func (c *client) ExecuteStream(ctx context.Context, req request.Request) (<-chan *response.StreamingChunk, error) {
+func (c *client) ExecuteStream(ctx context.Context, req request.Request) (chunks <-chan *response.StreamingChunk, err error) {
+	defer func() {
+		if err != nil {
+			streamEndFrom(ctx).finish(err)
+		}
+	}()
+
 	proto := req.Protocol()
 
 	// Verify protocol supports streaming
@@ -158,12 +229,160 @@ func (c *client) ExecuteStream(ctx context.Context, req request.Request) (<-chan
 		return nil, fmt.Errorf("protocol %s does not support streaming", proto)
 	}
 
-	return c.executeStream(ctx, req)
+	if err := c.drain.acquire(); err != nil {
+		return nil, err
+	}
+
+	if err := c.validateRequest(req); err != nil {
+		c.drain.release()
+		return nil, err
+	}
+
+	parseCtx, err := withParseMode(ctx, c.config.Streaming.ParseMode)
+	if err != nil {
+		c.drain.release()
+		return nil, err
+	}
+	ctx = parseCtx
+
+	stream, err := c.executeStream(withFormat(withRequestID(ctx), req), req)
+	if err != nil {
+		c.drain.release()
+		return nil, err
+	}
+	return stream, nil
 }
 
 // executeStream performs the streaming HTTP request.
-// Streaming requests are not retried - they fail immediately on error.
+// Streaming requests fail immediately on error unless stream retries are
+// enabled, in which case failures before the first chunk are retried like
+// Execute's. With a first-byte timeout configured, the first chunk is
+// awaited before returning, so slow starts fail (and can be retried) here
+// rather than mid-stream.
+// The stream is monitored for idle periods when a stream idle timeout or
+// heartbeat is configured.
 func (c *client) executeStream(ctx context.Context, req request.Request) (<-chan *response.StreamingChunk, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	attempt := 0
+	open := func(ctx context.Context) (*openedStream, error) {
+		attempt++
+		return c.openAttempt(ctx, req, attempt)
+	}
+
+	var (
+		stream *openedStream
+		err    error
+	)
+	if c.config.StreamRetryEnabled() {
+		event := RetryEvent{
+			Provider: req.Provider().Name(),
+			Protocol: string(req.Protocol()),
+			Model:    req.Model().Name,
+		}
+		stream, err = doWithRetry(ctx, c.config.Retry, event, open)
+	} else {
+		stream, err = open(ctx)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Convert provider stream to typed chunk stream
+	output := make(chan *response.StreamingChunk, c.config.Streaming.BufferSize)
+	end := streamEndFrom(ctx)
+	go func() {
+		var ended error
+		defer func() { end.finish(ended) }()
+		defer c.drain.release()
+		defer close(output)
+		defer cancel()
+		if stream.cancel != nil {
+			defer stream.cancel()
+		}
+
+		completed, err := c.monitor(ctx, stream, output)
+		if completed {
+			c.setHealthy(true)
+		}
+		ended = err
+
+		// Stop the provider goroutine and wait for it to exit
+		cancel()
+		for range stream.chunks {
+		}
+
+		if !errors.Is(err, ErrStreamAbandoned) {
+			err = nil
+		}
+		stream.trace.finish(err)
+	}()
+
+	return output, nil
+}
+
+// openAttempt opens one streaming attempt. With a first-byte timeout
+// configured, the attempt runs under a context that is cancelled if no chunk
+// arrives in time, and the first chunk is awaited before returning.
+func (c *client) openAttempt(ctx context.Context, req request.Request, attempt int) (*openedStream, error) {
+	timeout := c.config.StreamFirstByteTimeout.ToDuration()
+	if timeout <= 0 {
+		return c.openStream(ctx, req, attempt)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	timer := time.AfterFunc(timeout, func() { cancel(ErrStreamFirstByteTimeout) })
+
+	stream, err := c.openStream(ctx, req, attempt)
+	if err == nil {
+		if err = awaitFirst(ctx, stream); err != nil {
+			stream.body.Close()
+			stream.trace.finish(err)
+		}
+	}
+	timer.Stop()
+
+	if err != nil {
+		cancel(nil)
+		if errors.Is(context.Cause(ctx), ErrStreamFirstByteTimeout) {
+			c.setHealthy(false)
+			return nil, fmt.Errorf("%w: no chunk within %s", ErrStreamFirstByteTimeout, timeout)
+		}
+		return nil, err
+	}
+
+	// The attempt context stays live for the rest of the stream
+	stream.cancel = func() { cancel(nil) }
+	return stream, nil
+}
+
+// openedStream is a provider stream, the response body it reads from, and
+// the trace to finish when the stream ends. first holds a chunk already
+// received while awaiting the first-byte timeout, and cancel, when set,
+// releases the attempt's context.
+type openedStream struct {
+	chunks <-chan any
+	body   *activityBody
+	trace  *tracer
+	first  any
+	cancel func()
+}
+
+// openStream sends the streaming request and hands the response to the
+// provider. The response body records when data was last received.
+// The request is traced when ctx carries timing hooks; the trace is finished
+// here on failure and by the caller once the stream ends.
+// Non-OK responses are returned as HTTPStatusError so retries can evaluate
+// them.
+func (c *client) openStream(ctx context.Context, req request.Request, attempt int) (opened *openedStream, err error) {
+	trace, ctx := startTrace(ctx, req, attempt, true)
+	defer func() {
+		if err != nil {
+			trace.finish(err)
+		}
+	}()
+
 	provider := req.Provider()
 	proto := req.Protocol()
 
@@ -174,7 +393,7 @@ func (c *client) executeStream(ctx context.Context, req request.Request) (<-chan
 	}
 
 	// Prepare streaming request
-	providerRequest, err := provider.PrepareStreamRequest(ctx, proto, body, req.Headers())
+	providerRequest, err := providers.Prepare(ctx, provider, proto, body, req.Headers(), true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare streaming request: %w", err)
 	}
@@ -195,6 +414,9 @@ func (c *client) executeStream(ctx context.Context, req request.Request) (<-chan
 		httpReq.Header.Set(key, value)
 	}
 	provider.SetHeaders(httpReq)
+	if err := providers.Sign(provider, httpReq, providerRequest.Body); err != nil {
+		return nil, err
+	}
 
 	// Execute HTTP request
 	httpClient := c.HTTPClient()
@@ -203,42 +425,90 @@ func (c *client) executeStream(ctx context.Context, req request.Request) (<-chan
 		c.setHealthy(false)
 		return nil, fmt.Errorf("streaming request failed: %w", err)
 	}
+	trace.status(resp.StatusCode)
+	notifyRateLimits(ctx, req, resp)
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
 		c.setHealthy(false)
-		return nil, fmt.Errorf("streaming request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("streaming request failed: %w", newHTTPStatusError(resp, bodyBytes))
 	}
 
+	activity := newActivityBody(resp.Body)
+	resp.Body = activity
+
 	// Process stream through provider
-	stream, err := provider.ProcessStreamResponse(ctx, resp, proto)
+	stream, err := providers.ProcessStream(ctx, provider, resp, proto)
 	if err != nil {
 		c.setHealthy(false)
 		resp.Body.Close()
 		return nil, err
 	}
 
-	// Convert provider stream to typed chunk stream
-	output := make(chan *response.StreamingChunk)
-	go func() {
-		defer close(output)
-		defer resp.Body.Close()
-
-		for data := range stream {
-			if chunk, ok := data.(*response.StreamingChunk); ok {
-				select {
-				case output <- chunk:
-				case <-ctx.Done():
-					return
-				}
-			}
+	return &openedStream{chunks: stream, body: activity, trace: trace}, nil
+}
+
+// withRequestID returns ctx with a generated request ID in its call info
+// unless the caller assigned one, so every attempt of a request, including
+// retries, shares the same ID.
+func withRequestID(ctx context.Context) context.Context {
+	if providers.CallInfoFrom(ctx).RequestID != "" {
+		return ctx
+	}
+	return providers.WithRequestID(ctx, uuid.Must(uuid.NewV7()).String())
+}
+
+// withFormat returns ctx selecting the wire format of the request model's
+// capability for the protocol, if it sets one, so the response is parsed in
+// the format the request was marshaled in.
+func withFormat(ctx context.Context, req request.Request) context.Context {
+	if format := req.Model().Format(req.Protocol()); format != "" {
+		return providers.WithFormat(ctx, format)
+	}
+	return ctx
+}
+
+// validateRequest checks request options against the protocol option schema
+// and the request against the model's declared limits and features, in the
+// configured validation mode. Validation is off unless configured, and both
+// checks are skipped when it is off. Unknown modes are rejected.
+// Requests that do not expose their options skip the schema check.
+func (c *client) validateRequest(req request.Request) error {
+	mode := model.OptionValidation(c.config.OptionValidation)
+	if mode == "" {
+		mode = model.OptionValidationOff
+	}
+	if !mode.IsValid() {
+		return fmt.Errorf("invalid options: unknown option validation mode %q", mode)
+	}
+
+	if r, ok := req.(interface{ Options() map[string]any }); ok {
+		if err := model.ValidateOptions(req.Protocol(), r.Options(), mode); err != nil {
+			return fmt.Errorf("invalid options: %w", err)
 		}
-		c.setHealthy(true)
-	}()
+	}
 
-	return output, nil
+	if mode == model.OptionValidationOff {
+		return nil
+	}
+
+	return request.CheckLimits(req)
+}
+
+// withParseMode returns ctx carrying a configured response parse mode, or ctx
+// unchanged when mode is empty.
+// Returns an error if mode is not "lenient" or "strict".
+func withParseMode(ctx context.Context, mode string) (context.Context, error) {
+	if mode == "" {
+		return ctx, nil
+	}
+	parseMode := response.ParseMode(mode)
+	if !parseMode.IsValid() {
+		return nil, fmt.Errorf("invalid client config: unknown parse mode %q", mode)
+	}
+	return response.WithParseMode(ctx, parseMode), nil
 }
 
 // IsHealthy returns the current health status.
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+// ErrDeferredPending indicates a deferred completion that is not ready yet.
+var ErrDeferredPending = errors.New("deferred completion pending")
+
+// FetchDeferred fetches the completion of a request sent with
+// options.Deferred, using the request ID from the *providers.DeferredCompletion
+// returned by Execute. The result is the same type Execute returns for proto.
+// Returns ErrDeferredPending while the provider is still generating the
+// completion, an error if the provider does not implement
+// providers.DeferredFetcher, ErrClientClosed after the client is shut down,
+// or an *HTTPStatusError if the provider rejects the request.
+func FetchDeferred(ctx context.Context, c Client, p providers.Provider, proto protocol.Protocol, requestID string) (any, error) {
+	fetcher, ok := p.(providers.DeferredFetcher)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support deferred completions", p.Name())
+	}
+
+	release, err := track(c)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	prepared, err := fetcher.DeferredCompletionRequest(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare deferred completion request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(withRequestID(ctx), http.MethodGet, prepared.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	for key, value := range prepared.Headers {
+		req.Header.Set(key, value)
+	}
+	p.SetHeaders(req)
+	if err := providers.Sign(p, req, nil); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch deferred completion %s: %w", requestID, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusAccepted:
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("%w: %s", ErrDeferredPending, requestID)
+	case resp.StatusCode != http.StatusOK:
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch deferred completion %s: %w", requestID, newHTTPStatusError(resp, bodyBytes))
+	}
+
+	return providers.Process(ctx, p, resp, proto)
+}
@@ -173,7 +173,8 @@
 //
 // Health status is updated:
 //   - Set to healthy on successful request completion
-//   - Set to unhealthy on HTTP errors or response processing failures
+//   - Set to unhealthy on HTTP errors or response processing failures, except
+//     a deferred completion (see Deferred Completions)
 //   - Thread-safe for concurrent health checks
 //
 // # Error Handling
@@ -211,6 +212,106 @@
 //	    }
 //	}
 //
+// Non-OK responses return an *HTTPStatusError. Its Body, and so its message,
+// is the response body truncated and redacted by the default redaction
+// policy, since providers may echo prompts and request metadata; RawBody
+// returns the original:
+//
+//	var statusErr *client.HTTPStatusError
+//	if errors.As(err, &statusErr) {
+//	    debugStore.Save(statusErr.StatusCode, statusErr.RawBody())
+//	}
+//
+// # Retry Events
+//
+// Execute retries transient failures with exponential backoff, honoring a
+// server's Retry-After delay when it is within MaxBackoff. Attach hooks to a
+// context to observe each retry as a RetryEvent (attempt, error class, status,
+// backoff chosen, and whether Retry-After was honored):
+//
+//	ctx = client.WithRetryHook(ctx, client.LogRetries(slog.Default()))
+//	ctx = client.WithRetryHook(ctx, func(e client.RetryEvent) {
+//	    retries.WithLabelValues(e.Provider, string(e.Class)).Inc()
+//	})
+//
+// # Request Timing
+//
+// Attach timing hooks to a context to trace each HTTP attempt with
+// net/http/httptrace. Hooks receive a Timing with DNS, connect, TLS, wait,
+// TTFB, and total durations once the attempt completes; for streams, Total
+// covers the whole stream. Tracing is skipped when no hook is attached:
+//
+//	ctx = client.WithTimingHook(ctx, client.LogTimings(slog.Default()))
+//
+// # Stall Detection
+//
+// Streams are not monitored by default. With client.streaming.idle_timeout
+// set, a stream that receives no data for that long is aborted and its final
+// chunk carries an error wrapping ErrStreamStalled. With
+// client.streaming.heartbeat set, chunks with Heartbeat true (and the idle
+// time in Idle) are emitted at that interval while the stream is idle; they
+// carry no choices. The streaming block also sets the stream's channel buffer
+// and parse mode (see config.StreamingConfig).
+//
+// With client.stream_first_byte_timeout set, ExecuteStream waits for the
+// first chunk and fails with ErrStreamFirstByteTimeout if it does not arrive
+// in time. Streams are not retried unless client.stream_retry is set; then
+// failures before the first chunk (connection errors, retryable statuses,
+// and first-byte timeouts) are retried with the client's retry settings.
+// Once a chunk has been delivered, a stream is never retried.
+//
+// # Graceful Shutdown
+//
+// Shutdown drains the client for clean rollouts: new calls, including the
+// package functions that take the client, fail with ErrClientClosed,
+// in-flight requests and open streams run to completion, and idle pooled
+// connections are closed:
+//
+//	<-sigterm
+//	ctx, cancel := context.WithTimeout(context.Background(), 25*time.Second)
+//	defer cancel()
+//	if err := a.Client().Shutdown(ctx); err != nil {
+//	    log.Printf("shutdown: %v", err) // deadline reached with requests in flight
+//	}
+//
+// # Rate Limits
+//
+// Providers that report rate limits in response headers (Together and
+// Fireworks) implement providers.RateLimitReporter, which maps their header
+// formats to providers.RateLimits. WithRateLimitHook receives them after
+// every attempt, including rejected ones:
+//
+//	ctx = client.WithRateLimitHook(ctx, func(e client.RateLimitEvent) {
+//	    if e.Limits.RemainingRequests == 0 {
+//	        limiter.Pause(e.Provider, e.Limits.ResetRequests)
+//	    }
+//	})
+//
+// # Raw Requests
+//
+// ExecuteRaw reaches provider endpoints without a protocol, such as model
+// listings or vendor-specific APIs. The path is resolved against the
+// provider's base URL, the provider authenticates the request, and the status,
+// headers, and body are returned unparsed:
+//
+//	resp, err := client.ExecuteRaw(ctx, c, provider, http.MethodGet, "/models", nil, nil)
+//	if err == nil && resp.StatusCode == http.StatusOK {
+//	    fmt.Println(string(resp.Body))
+//	}
+//
+// # Deferred Completions
+//
+// Requests sent with options.Deferred to a provider that queues them (xAI)
+// fail with a *providers.DeferredCompletion carrying the request ID. The
+// completion is fetched with FetchDeferred, which returns ErrDeferredPending
+// until it is ready:
+//
+//	_, err := c.Execute(ctx, req)
+//	var deferred *providers.DeferredCompletion
+//	if errors.As(err, &deferred) {
+//	    result, err = client.FetchDeferred(ctx, c, provider, protocol.Chat, deferred.RequestID)
+//	}
+//
 // # Context Cancellation
 //
 // Both execution methods respect context cancellation:
@@ -245,6 +346,20 @@
 //	    fmt.Print(chunk.Content())
 //	}
 //
+// Cancelling a stream's context closes its response body, stops the provider
+// goroutine reading it, and closes the chunk channel without waiting for the
+// provider to send more data. A stream ended this way sends no final error
+// chunk; attach a StreamEnd with WithStreamEnd to learn why a stream ended
+// and when its resources have been released:
+//
+//	ctx, end := client.WithStreamEnd(ctx)
+//	chunks, err := client.ExecuteStream(ctx, req)
+//	...
+//	<-end.Done()
+//	if errors.Is(end.Err(), context.Canceled) {
+//	    log.Println("stream cancelled")
+//	}
+//
 // # Thread Safety
 //
 // Clients are safe for concurrent use:
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// StreamEnd reports why a stream ended. Attach one to a context with
+// WithStreamEnd before calling ExecuteStream; once Done is closed, the
+// stream's chunk channel is closed, its response body is closed, and the
+// provider goroutine reading it has exited.
+//
+// A StreamEnd tracks the first stream started with its context; later
+// streams sharing the context do not report to it.
+type StreamEnd struct {
+	done chan struct{}
+	once sync.Once
+	err  error
+}
+
+type streamEndKey struct{}
+
+// WithStreamEnd returns a context that reports how a stream started with it
+// ends, and the StreamEnd to observe it through.
+//
+//	ctx, end := client.WithStreamEnd(ctx)
+//	chunks, err := a.ChatStream(ctx, "Tell me a story")
+//	if err != nil {
+//	    return err
+//	}
+//	for chunk := range chunks {
+//	    fmt.Print(chunk.Content())
+//	}
+//	<-end.Done()
+//	if err := end.Err(); err != nil {
+//	    log.Printf("stream ended early: %v", err)
+//	}
+func WithStreamEnd(ctx context.Context) (context.Context, *StreamEnd) {
+	end := &StreamEnd{done: make(chan struct{})}
+	return context.WithValue(ctx, streamEndKey{}, end), end
+}
+
+// Done returns a channel that is closed when the stream has ended and its
+// resources have been released.
+func (e *StreamEnd) Done() <-chan struct{} {
+	return e.done
+}
+
+// Err returns why the stream ended, or nil before Done is closed.
+// Returns nil if the provider stream completed; the error of the last
+// chunk carrying one; an error wrapping ErrStreamStalled or
+// ErrStreamAbandoned if the client aborted the stream; the context's cause
+// if it was cancelled; or ExecuteStream's error if the stream never started.
+func (e *StreamEnd) Err() error {
+	select {
+	case <-e.done:
+		return e.err
+	default:
+		return nil
+	}
+}
+
+// finish records err and closes Done. Only the first call has any effect,
+// and a nil StreamEnd records nothing.
+func (e *StreamEnd) finish(err error) {
+	if e == nil {
+		return
+	}
+	e.once.Do(func() {
+		e.err = err
+		close(e.done)
+	})
+}
+
+// streamEndFrom returns the StreamEnd attached to ctx, or nil.
+func streamEndFrom(ctx context.Context) *StreamEnd {
+	end, _ := ctx.Value(streamEndKey{}).(*StreamEnd)
+	return end
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+)
+
+// ErrorClass categorizes the transient failure that triggered a retry.
+type ErrorClass string
+
+const (
+	// ErrorClassRateLimit is an HTTP 429 response.
+	ErrorClassRateLimit ErrorClass = "rate_limit"
+
+	// ErrorClassServer is an HTTP 502, 503, or 504 response.
+	ErrorClassServer ErrorClass = "server"
+
+	// ErrorClassNetwork is a connection-level failure (refused, reset, timeout).
+	ErrorClassNetwork ErrorClass = "network"
+
+	// ErrorClassDNS is a temporary DNS resolution failure.
+	ErrorClassDNS ErrorClass = "dns"
+
+	// ErrorClassFirstByte is a stream that produced no chunk within the
+	// stream first-byte timeout.
+	ErrorClassFirstByte ErrorClass = "first_byte"
+)
+
+// RetryEvent describes a failed attempt that is about to be retried.
+// Attempt is the 1-based number of the attempt that failed; the retry is
+// attempt Attempt+1 of at most MaxRetries+1. Backoff is the delay chosen
+// before the retry. RetryAfter is the delay the server requested with a
+// Retry-After header (zero if none), and RetryAfterHonored reports whether
+// Backoff was taken from it.
+type RetryEvent struct {
+	Provider          string
+	Protocol          string
+	Model             string
+	Attempt           int
+	MaxRetries        int
+	Class             ErrorClass
+	StatusCode        int
+	Err               error
+	Backoff           time.Duration
+	RetryAfter        time.Duration
+	RetryAfterHonored bool
+}
+
+// RetryHook receives a RetryEvent before each retry's backoff.
+// Hooks run synchronously on the request goroutine and should return quickly.
+type RetryHook func(RetryEvent)
+
+type retryHooksKey struct{}
+
+// WithRetryHook returns a context whose requests report every retry to hook.
+// Hooks accumulate, so a context may carry a logger and a metrics hook.
+//
+//	ctx = client.WithRetryHook(ctx, func(e client.RetryEvent) {
+//	    retries.WithLabelValues(e.Provider, string(e.Class)).Inc()
+//	})
+func WithRetryHook(ctx context.Context, hook RetryHook) context.Context {
+	hooks, _ := ctx.Value(retryHooksKey{}).([]RetryHook)
+	hooks = append(hooks[:len(hooks):len(hooks)], hook)
+	return context.WithValue(ctx, retryHooksKey{}, hooks)
+}
+
+// LogRetries returns a RetryHook that logs each retry as a warning.
+// Errors are redacted by the default redaction policy.
+func LogRetries(logger *slog.Logger) RetryHook {
+	return func(e RetryEvent) {
+		attrs := []any{
+			"provider", e.Provider,
+			"protocol", e.Protocol,
+			"model", e.Model,
+			"attempt", e.Attempt,
+			"max_retries", e.MaxRetries,
+			"class", string(e.Class),
+			"backoff", e.Backoff,
+			"error", redact.Default().Error(e.Err),
+		}
+		if e.StatusCode != 0 {
+			attrs = append(attrs, "status", e.StatusCode)
+		}
+		if e.RetryAfter > 0 {
+			attrs = append(attrs, "retry_after", e.RetryAfter, "retry_after_honored", e.RetryAfterHonored)
+		}
+		logger.Warn("retrying request", attrs...)
+	}
+}
+
+// notifyRetry delivers an event to the hooks carried by ctx.
+func notifyRetry(ctx context.Context, event RetryEvent) {
+	hooks, _ := ctx.Value(retryHooksKey{}).([]RetryHook)
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
+
+// classifyError returns the class and HTTP status of a retryable error.
+func classifyError(err error) (ErrorClass, int) {
+	var httpErr *HTTPStatusError
+	if errors.As(err, &httpErr) {
+		if httpErr.StatusCode == http.StatusTooManyRequests {
+			return ErrorClassRateLimit, httpErr.StatusCode
+		}
+		return ErrorClassServer, httpErr.StatusCode
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrorClassDNS, 0
+	}
+
+	if errors.Is(err, ErrStreamFirstByteTimeout) {
+		return ErrorClassFirstByte, 0
+	}
+
+	return ErrorClassNetwork, 0
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date. Returns zero if the header is absent, invalid, or in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// UploadFile uploads content to the provider's files API as a multipart form
+// and returns the stored file. Content is streamed, not buffered, unless the
+// provider signs requests.
+// Returns an error wrapping providers.ErrFilesNotSupported if the provider
+// has no files API, or an *HTTPStatusError if the upload is rejected.
+func UploadFile(ctx context.Context, c Client, p providers.Provider, filename string, content io.Reader, purpose response.FilePurpose) (*response.File, error) {
+	endpoint, err := filesEndpoint(p, "")
+	if err != nil {
+		return nil, err
+	}
+
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+
+	go func() {
+		err := form.WriteField("purpose", string(purpose))
+		if err == nil {
+			var part io.Writer
+			if part, err = form.CreateFormFile("file", filename); err == nil {
+				if _, err = io.Copy(part, content); err == nil {
+					err = form.Close()
+				}
+			}
+		}
+		writer.CloseWithError(err)
+	}()
+
+	var file response.File
+	if err := doFiles(ctx, c, p, http.MethodPost, endpoint, body, form.FormDataContentType(), &file); err != nil {
+		body.CloseWithError(err)
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	return &file, nil
+}
+
+// ListFiles returns the files stored with the provider, filtered by purpose
+// when it is not empty.
+// Returns an error wrapping providers.ErrFilesNotSupported if the provider
+// has no files API.
+func ListFiles(ctx context.Context, c Client, p providers.Provider, purpose response.FilePurpose) ([]response.File, error) {
+	endpoint, err := filesEndpoint(p, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if purpose != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid files endpoint: %w", err)
+		}
+		query := u.Query()
+		query.Set("purpose", string(purpose))
+		u.RawQuery = query.Encode()
+		endpoint = u.String()
+	}
+
+	var list response.FileList
+	if err := doFiles(ctx, c, p, http.MethodGet, endpoint, nil, "", &list); err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	return list.Data, nil
+}
+
+// DeleteFile deletes a file from the provider's files API.
+// Returns an error wrapping providers.ErrFilesNotSupported if the provider
+// has no files API, or an *HTTPStatusError if the file does not exist.
+func DeleteFile(ctx context.Context, c Client, p providers.Provider, id string) error {
+	if id == "" {
+		return fmt.Errorf("file ID is required")
+	}
+
+	endpoint, err := filesEndpoint(p, id)
+	if err != nil {
+		return err
+	}
+
+	if err := doFiles(ctx, c, p, http.MethodDelete, endpoint, nil, "", nil); err != nil {
+		return fmt.Errorf("failed to delete file %s: %w", id, err)
+	}
+	return nil
+}
+
+// filesEndpoint resolves a files URL from a provider implementing FileProvider.
+func filesEndpoint(p providers.Provider, id string) (string, error) {
+	fp, ok := p.(providers.FileProvider)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", providers.ErrFilesNotSupported, p.Name())
+	}
+	return fp.FilesEndpoint(id)
+}
+
+// doFiles sends a files API request with provider authentication and decodes
+// a successful JSON response into target when it is not nil.
+// A provider that signs requests needs the whole body, so uploads to it are
+// buffered rather than streamed.
+func doFiles(ctx context.Context, c Client, p providers.Provider, method, endpoint string, body io.Reader, contentType string, target any) error {
+	release, err := track(c)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var payload []byte
+	if signing, ok := p.(providers.Signing); ok && signing.Signer() != nil && body != nil {
+		if payload, err = io.ReadAll(body); err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(withRequestID(ctx), method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	p.SetHeaders(req)
+	if err := providers.Sign(p, req, payload); err != nil {
+		return err
+	}
+
+	resp, err := c.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return newHTTPStatusError(resp, bodyBytes)
+	}
+
+	if target == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+// Prewarm loads a model into the provider's memory ahead of traffic so the
+// first request does not wait for it to load. keepAlive, when not nil, is the
+// residency hint for how long the model stays loaded (see options.KeepAlive).
+// Returns an error wrapping providers.ErrPrewarmNotSupported if the provider
+// does not implement providers.Prewarmer, ErrClientClosed after the client is
+// shut down, or an *HTTPStatusError if the provider rejects the request.
+func Prewarm(ctx context.Context, c Client, p providers.Provider, model string, keepAlive any) error {
+	prewarmer, ok := p.(providers.Prewarmer)
+	if !ok {
+		return fmt.Errorf("%w: %s", providers.ErrPrewarmNotSupported, p.Name())
+	}
+
+	release, err := track(c)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	prepared, err := prewarmer.PrewarmRequest(model, keepAlive)
+	if err != nil {
+		return fmt.Errorf("failed to prepare prewarm request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(withRequestID(ctx), http.MethodPost, prepared.URL, bytes.NewReader(prepared.Body))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	for key, value := range prepared.Headers {
+		req.Header.Set(key, value)
+	}
+	p.SetHeaders(req)
+	if err := providers.Sign(p, req, prepared.Body); err != nil {
+		return err
+	}
+
+	resp, err := c.HTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to prewarm %s: %w", model, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to prewarm %s: %w", model, newHTTPStatusError(resp, bodyBytes))
+	}
+
+	// Drain the body so the connection can be reused
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+// RateLimitEvent carries the rate limits a provider reported in the response
+// to one HTTP attempt, including rejected (429) attempts.
+type RateLimitEvent struct {
+	Provider   string
+	Protocol   string
+	Model      string
+	StatusCode int
+	Limits     providers.RateLimits
+}
+
+// RateLimitHook receives a RateLimitEvent for each response that reports rate
+// limits. Only providers implementing providers.RateLimitReporter report them.
+// Hooks run synchronously on the request goroutine and should return quickly.
+type RateLimitHook func(RateLimitEvent)
+
+type rateLimitHooksKey struct{}
+
+// WithRateLimitHook returns a context whose requests report provider rate
+// limits to hook, such as to throttle before the limit is reached. Hooks
+// accumulate.
+//
+//	ctx = client.WithRateLimitHook(ctx, func(e client.RateLimitEvent) {
+//	    remaining.WithLabelValues(e.Provider).Set(float64(e.Limits.RemainingTokens))
+//	})
+func WithRateLimitHook(ctx context.Context, hook RateLimitHook) context.Context {
+	hooks, _ := ctx.Value(rateLimitHooksKey{}).([]RateLimitHook)
+	hooks = append(hooks[:len(hooks):len(hooks)], hook)
+	return context.WithValue(ctx, rateLimitHooksKey{}, hooks)
+}
+
+// notifyRateLimits delivers the rate limits in resp to the hooks carried by
+// ctx when the request's provider reports them.
+func notifyRateLimits(ctx context.Context, req request.Request, resp *http.Response) {
+	hooks, _ := ctx.Value(rateLimitHooksKey{}).([]RateLimitHook)
+	if len(hooks) == 0 {
+		return
+	}
+
+	reporter, ok := req.Provider().(providers.RateLimitReporter)
+	if !ok {
+		return
+	}
+	limits, ok := reporter.RateLimits(resp.Header)
+	if !ok {
+		return
+	}
+
+	event := RateLimitEvent{
+		Provider:   req.Provider().Name(),
+		Protocol:   string(req.Protocol()),
+		Model:      req.Model().Name,
+		StatusCode: resp.StatusCode,
+		Limits:     limits,
+	}
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+// RawResponse is the unparsed result of ExecuteRaw.
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// ExecuteRaw sends a request to a provider endpoint tau-core does not model
+// and returns the response as received. path is resolved against the
+// provider's base URL unless it is an absolute URL, and the request carries
+// the provider's authentication headers followed by headers, and is signed
+// by the provider's Signer if it has one. body may be nil.
+// Non-2xx responses are returned, not treated as errors, and requests are not
+// retried or validated.
+// Raw requests count as in flight for Shutdown like Execute does.
+// Returns ErrClientClosed after the client is shut down, or an error if the
+// request cannot be sent or its body read.
+//
+//	resp, err := client.ExecuteRaw(ctx, a.Client(), a.Provider(), http.MethodGet, "/models", nil, nil)
+func ExecuteRaw(ctx context.Context, c Client, p providers.Provider, method, path string, body []byte, headers map[string]string) (*RawResponse, error) {
+	release, err := track(c)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(withRequestID(ctx), method, rawURL(p.BaseURL(), path), reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	p.SetHeaders(req)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if err := providers.Sign(p, req, body); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("raw request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &RawResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       data,
+	}, nil
+}
+
+// rawURL joins path to baseURL, keeping absolute URLs as they are.
+func rawURL(baseURL, path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+}
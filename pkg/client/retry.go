@@ -6,18 +6,50 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
 )
 
 // HTTPStatusError represents an HTTP error with status code and response body.
 // Used to distinguish HTTP errors from other types of errors for retry logic.
+// RetryAfter holds the delay requested by a Retry-After header, if any.
+//
+// Response bodies can echo prompts and request metadata, so the client sets
+// Body, which Error includes, to the body truncated and redacted by the
+// default redaction policy (see redact.Policy.ErrorBody). RawBody returns the
+// original body when it is explicitly needed.
 type HTTPStatusError struct {
 	StatusCode int
 	Status     string
 	Body       []byte
+	RetryAfter time.Duration
+
+	raw []byte
+}
+
+// newHTTPStatusError returns the error for a non-OK response with the given
+// body, which the caller has read.
+func newHTTPStatusError(resp *http.Response, body []byte) *HTTPStatusError {
+	return &HTTPStatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       redact.Default().ErrorBody(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		raw:        body,
+	}
+}
+
+// RawBody returns the full, unredacted response body. It may contain
+// sensitive content and should not be logged.
+func (e *HTTPStatusError) RawBody() []byte {
+	if e.raw != nil {
+		return e.raw
+	}
+	return e.Body
 }
 
 func (e *HTTPStatusError) Error() string {
@@ -32,6 +64,7 @@ func (e *HTTPStatusError) Error() string {
 // - HTTP 429 (rate limit), 502 (bad gateway), 503 (service unavailable), 504 (gateway timeout)
 // - Network operation errors (connection failures, timeouts)
 // - Temporary DNS errors
+// - Streams that produced no chunk within the first-byte timeout
 //
 // Returns false for:
 // - Context cancellation/deadline errors (user-initiated or timeout)
@@ -47,6 +80,11 @@ func isRetryableError(err error) bool {
 		return false
 	}
 
+	// The stream never started, so nothing has been delivered
+	if errors.Is(err, ErrStreamFirstByteTimeout) {
+		return true
+	}
+
 	// Check for HTTP status errors - retry on transient server issues
 	var httpErr *HTTPStatusError
 	if errors.As(err, &httpErr) {
@@ -90,7 +128,7 @@ func calculateBackoff(attempt int, cfg config.RetryConfig) time.Duration {
 	delay := time.Duration(cfg.InitialBackoff) * time.Duration(1<<uint(maxAttempt))
 
 	// Apply jitter (±25% randomization) if enabled
-	if cfg.Jitter {
+	if cfg.JitterEnabled() {
 		jitterRange := delay / 4
 		jitter := time.Duration(rand.Int63n(int64(jitterRange)*2)) - jitterRange
 		delay += jitter
@@ -100,15 +138,32 @@ func calculateBackoff(attempt int, cfg config.RetryConfig) time.Duration {
 	return min(delay, time.Duration(cfg.MaxBackoff))
 }
 
+// retryDelay returns the backoff before retrying after err. A Retry-After
+// delay requested by the server is honored when it does not exceed
+// MaxBackoff; otherwise exponential backoff applies.
+func retryDelay(attempt int, cfg config.RetryConfig, err error) (delay, retryAfter time.Duration, honored bool) {
+	var httpErr *HTTPStatusError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
+		retryAfter = httpErr.RetryAfter
+		if cfg.MaxBackoff <= 0 || retryAfter <= time.Duration(cfg.MaxBackoff) {
+			return retryAfter, retryAfter, true
+		}
+	}
+	return calculateBackoff(attempt, cfg), retryAfter, false
+}
+
 // doWithRetry executes an operation with retry logic.
 // Retries only on transient failures (determined by isRetryableError).
-// Uses exponential backoff with optional jitter between retries.
+// Uses exponential backoff with optional jitter between retries, or the
+// server's Retry-After delay when it is within MaxBackoff.
+// Before each backoff a RetryEvent, based on event, is sent to the hooks in ctx.
 // Respects context cancellation during operation and backoff.
 //
 // Returns the successful result or the last error encountered.
 func doWithRetry[T any](
 	ctx context.Context,
 	cfg config.RetryConfig,
+	event RetryEvent,
 	operation func(context.Context) (T, error),
 ) (T, error) {
 	var result T
@@ -133,7 +188,16 @@ func doWithRetry[T any](
 
 		// Don't sleep after last attempt
 		if attempt < cfg.MaxRetries {
-			delay := calculateBackoff(attempt, cfg)
+			delay, retryAfter, honored := retryDelay(attempt, cfg, lastErr)
+
+			event.Attempt = attempt + 1
+			event.MaxRetries = cfg.MaxRetries
+			event.Class, event.StatusCode = classifyError(lastErr)
+			event.Err = lastErr
+			event.Backoff = delay
+			event.RetryAfter = retryAfter
+			event.RetryAfterHonored = honored
+			notifyRetry(ctx, event)
 
 			select {
 			case <-time.After(delay):
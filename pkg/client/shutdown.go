@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClientClosed is returned by Execute, ExecuteStream, and the package
+// functions that send requests through a client after Shutdown.
+var ErrClientClosed = errors.New("client is shut down")
+
+// drainState tracks in-flight requests and streams for Shutdown.
+type drainState struct {
+	mu     sync.Mutex
+	closed bool
+	active int
+	idle   chan struct{}
+}
+
+// acquire registers an in-flight request, or returns ErrClientClosed once
+// shutdown has begun.
+func (d *drainState) acquire() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return ErrClientClosed
+	}
+	d.active++
+	return nil
+}
+
+// release marks an in-flight request finished, waking Shutdown when it was
+// the last.
+func (d *drainState) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.active--
+	if d.active == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// close stops new requests and returns a channel closed once no requests
+// are in flight.
+func (d *drainState) close() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.closed = true
+	if d.active == 0 {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+	if d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	return d.idle
+}
+
+// track registers a request sent through c outside Execute and
+// ExecuteStream, so Shutdown rejects it once begun and waits for it
+// otherwise. Returns the function that releases the request, or
+// ErrClientClosed after Shutdown. Clients not created by New are not tracked.
+func track(c Client) (func(), error) {
+	impl, ok := c.(*client)
+	if !ok {
+		return func() {}, nil
+	}
+	if err := impl.drain.acquire(); err != nil {
+		return nil, err
+	}
+	return impl.drain.release, nil
+}
+
+// Shutdown stops accepting requests, waits for in-flight requests and streams
+// to finish or ctx to be done, then closes idle connections.
+func (c *client) Shutdown(ctx context.Context) error {
+	drained := c.drain.close()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	c.HTTPClient().CloseIdleConnections()
+	return err
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// ErrStreamStalled is the chunk error for streams that received no data for
+// longer than the configured stream idle timeout. The stream is aborted and
+// its connection closed.
+var ErrStreamStalled = errors.New("stream stalled")
+
+// ErrStreamFirstByteTimeout is returned by ExecuteStream when a stream
+// produces no chunk within the configured stream first-byte timeout. It is
+// retryable when stream retries are enabled.
+var ErrStreamFirstByteTimeout = errors.New("stream first-byte timeout")
+
+// ErrStreamAbandoned is reported to timing hooks for streams whose consumer
+// stopped receiving chunks for longer than the configured stream send
+// timeout. The stream is aborted, its connection closed, and its channel
+// closed.
+var ErrStreamAbandoned = errors.New("stream abandoned")
+
+// activityBody records when a response body last returned data, so idle
+// time covers all bytes received, including SSE keep-alive comments that
+// never become chunks.
+type activityBody struct {
+	io.ReadCloser
+	last atomic.Int64
+}
+
+func newActivityBody(body io.ReadCloser) *activityBody {
+	b := &activityBody{ReadCloser: body}
+	b.last.Store(time.Now().UnixNano())
+	return b
+}
+
+func (b *activityBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.last.Store(time.Now().UnixNano())
+	}
+	return n, err
+}
+
+// idle returns the time since data was last received.
+func (b *activityBody) idle() time.Duration {
+	return time.Since(time.Unix(0, b.last.Load()))
+}
+
+// awaitFirst waits for the stream's first chunk, or for ctx to end when the
+// attempt's first-byte timer fires. The chunk is held in the stream until
+// monitor delivers it.
+func awaitFirst(ctx context.Context, stream *openedStream) error {
+	select {
+	case data, ok := <-stream.chunks:
+		if ok {
+			stream.first = data
+		}
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// monitor forwards provider chunks to output, emitting heartbeat chunks while
+// the stream is idle and aborting it with ErrStreamStalled once it has been
+// idle for the stream idle timeout. A first chunk already received by
+// awaitFirst is delivered before the rest. Returns whether the provider
+// stream completed, and the error that ended it: the last chunk error from
+// the provider, ErrStreamStalled or ErrStreamAbandoned if it was aborted, or
+// the context's cause if it ended first.
+func (c *client) monitor(ctx context.Context, opened *openedStream, output chan<- *response.StreamingChunk) (bool, error) {
+	stream, body := opened.chunks, opened.body
+	defer body.Close()
+
+	var failed error
+	if chunk, ok := opened.first.(*response.StreamingChunk); ok {
+		if chunk.Error != nil {
+			failed = chunk.Error
+		}
+		if err := c.send(ctx, output, chunk); err != nil {
+			return false, err
+		}
+	}
+
+	streaming := c.config.ResolvedStreaming()
+	stallAfter := streaming.IdleTimeout.ToDuration()
+	heartbeat := streaming.Heartbeat.ToDuration()
+
+	// Idle checks run at the heartbeat interval, or often enough to detect
+	// a stall within a quarter of the timeout
+	var ticks <-chan time.Time
+	if interval := checkInterval(stallAfter, heartbeat); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	var lastHeartbeat time.Time
+	for {
+		select {
+		case data, ok := <-stream:
+			if !ok {
+				return true, failed
+			}
+			if chunk, ok := data.(*response.StreamingChunk); ok {
+				if chunk.Error != nil {
+					failed = chunk.Error
+				}
+				if err := c.send(ctx, output, chunk); err != nil {
+					return false, err
+				}
+			}
+		case <-ticks:
+			idle := body.idle()
+			if stallAfter > 0 && idle >= stallAfter {
+				c.setHealthy(false)
+				err := fmt.Errorf("%w: no data received for %s", ErrStreamStalled, idle.Round(time.Millisecond))
+				c.send(ctx, output, &response.StreamingChunk{Error: err})
+				return false, err
+			}
+			if heartbeat > 0 && idle >= heartbeat && time.Since(lastHeartbeat) >= heartbeat {
+				lastHeartbeat = time.Now()
+				if err := c.send(ctx, output, &response.StreamingChunk{Heartbeat: true, Idle: idle}); err != nil {
+					return false, err
+				}
+			}
+		case <-ctx.Done():
+			return false, context.Cause(ctx)
+		}
+	}
+}
+
+// send delivers a chunk to output. With a stream send timeout configured, a
+// consumer that does not receive the chunk in time is treated as gone.
+// Returns ErrStreamAbandoned in that case, or the context's cause if it ends
+// first.
+func (c *client) send(ctx context.Context, output chan<- *response.StreamingChunk, chunk *response.StreamingChunk) error {
+	timeout := c.config.StreamSendTimeout.ToDuration()
+	if timeout <= 0 {
+		select {
+		case output <- chunk:
+			return nil
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case output <- chunk:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-timer.C:
+		return fmt.Errorf("%w: chunk not received within %s", ErrStreamAbandoned, timeout)
+	}
+}
+
+// checkInterval returns how often to check an idle stream, or zero when
+// neither a stall timeout nor heartbeats are configured.
+func checkInterval(stallAfter, heartbeat time.Duration) time.Duration {
+	switch {
+	case stallAfter > 0 && heartbeat > 0:
+		return min(stallAfter/4, heartbeat)
+	case stallAfter > 0:
+		return stallAfter / 4
+	default:
+		return heartbeat
+	}
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+// Timing breaks down the latency of one HTTP attempt by phase, so network
+// slowness can be told apart from model inference.
+//
+// DNS, Connect, and TLS are zero when a pooled connection is reused.
+// Wait is the time from the request being written to the first response
+// byte, which is dominated by the provider's processing (queueing and, for
+// non-streaming requests, the whole generation). TTFB is measured from the
+// start of the attempt. Total runs until the response is fully processed;
+// for streams it covers the whole stream.
+type Timing struct {
+	Provider   string
+	Protocol   string
+	Model      string
+	Attempt    int
+	Stream     bool
+	ReusedConn bool
+	DNS        time.Duration
+	Connect    time.Duration
+	TLS        time.Duration
+	Wait       time.Duration
+	TTFB       time.Duration
+	Total      time.Duration
+	StatusCode int
+	Err        error
+}
+
+// TimingHook receives the Timing of each HTTP attempt once it completes.
+// Hooks run synchronously on the request goroutine and should return quickly.
+type TimingHook func(Timing)
+
+type timingHooksKey struct{}
+
+// WithTimingHook returns a context whose requests are traced with
+// net/http/httptrace and report per-phase latency to hook. Tracing is off
+// unless a hook is attached. Hooks accumulate.
+//
+//	ctx = client.WithTimingHook(ctx, func(t client.Timing) {
+//	    ttfb.WithLabelValues(t.Provider, t.Model).Observe(t.TTFB.Seconds())
+//	})
+func WithTimingHook(ctx context.Context, hook TimingHook) context.Context {
+	hooks, _ := ctx.Value(timingHooksKey{}).([]TimingHook)
+	hooks = append(hooks[:len(hooks):len(hooks)], hook)
+	return context.WithValue(ctx, timingHooksKey{}, hooks)
+}
+
+// LogTimings returns a TimingHook that logs each attempt's timing at debug
+// level. Errors are redacted by the default redaction policy.
+func LogTimings(logger *slog.Logger) TimingHook {
+	return func(t Timing) {
+		attrs := []any{
+			"provider", t.Provider,
+			"protocol", t.Protocol,
+			"model", t.Model,
+			"attempt", t.Attempt,
+			"stream", t.Stream,
+			"reused_conn", t.ReusedConn,
+			"dns", t.DNS,
+			"connect", t.Connect,
+			"tls", t.TLS,
+			"wait", t.Wait,
+			"ttfb", t.TTFB,
+			"total", t.Total,
+			"status", t.StatusCode,
+		}
+		if t.Err != nil {
+			attrs = append(attrs, "error", redact.Default().Error(t.Err))
+		}
+		logger.Debug("request timing", attrs...)
+	}
+}
+
+// tracer collects httptrace events for one attempt. A nil tracer is valid
+// and records nothing, so callers need not check whether hooks are attached.
+type tracer struct {
+	hooks []TimingHook
+
+	mu           sync.Mutex
+	timing       Timing
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wrote        time.Time
+	finished     bool
+}
+
+// startTrace begins tracing an attempt if ctx carries timing hooks, returning
+// the tracer and a context that installs its httptrace callbacks.
+func startTrace(ctx context.Context, req request.Request, attempt int, stream bool) (*tracer, context.Context) {
+	hooks, _ := ctx.Value(timingHooksKey{}).([]TimingHook)
+	if len(hooks) == 0 {
+		return nil, ctx
+	}
+
+	t := &tracer{
+		hooks: hooks,
+		start: time.Now(),
+		timing: Timing{
+			Provider: req.Provider().Name(),
+			Protocol: string(req.Protocol()),
+			Model:    req.Model().Name,
+			Attempt:  attempt,
+			Stream:   stream,
+		},
+	}
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.since(&t.dnsStart, &t.timing.DNS)
+		},
+		ConnectStart: func(string, string) { t.mark(&t.connectStart) },
+		ConnectDone: func(string, string, error) {
+			t.since(&t.connectStart, &t.timing.Connect)
+		},
+		TLSHandshakeStart: func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.since(&t.tlsStart, &t.timing.TLS)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			t.timing.ReusedConn = info.Reused
+			t.mu.Unlock()
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) { t.mark(&t.wrote) },
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			now := time.Now()
+			t.timing.TTFB = now.Sub(t.start)
+			if !t.wrote.IsZero() {
+				t.timing.Wait = now.Sub(t.wrote)
+			}
+		},
+	}
+
+	return t, httptrace.WithClientTrace(ctx, trace)
+}
+
+// mark records the current time in field.
+func (t *tracer) mark(field *time.Time) {
+	t.mu.Lock()
+	*field = time.Now()
+	t.mu.Unlock()
+}
+
+// since records the time elapsed since start in d.
+func (t *tracer) since(start *time.Time, d *time.Duration) {
+	t.mu.Lock()
+	if !start.IsZero() {
+		*d = time.Since(*start)
+	}
+	t.mu.Unlock()
+}
+
+// status records the response status code.
+func (t *tracer) status(code int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.timing.StatusCode = code
+	t.mu.Unlock()
+}
+
+// finish completes the attempt and delivers its timing to the hooks.
+// Only the first call has any effect.
+func (t *tracer) finish(err error) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	if t.finished {
+		t.mu.Unlock()
+		return
+	}
+	t.finished = true
+	t.timing.Total = time.Since(t.start)
+	t.timing.Err = err
+	timing := t.timing
+	t.mu.Unlock()
+
+	for _, hook := range t.hooks {
+		hook(timing)
+	}
+}
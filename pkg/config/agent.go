@@ -3,18 +3,36 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
 )
 
 // AgentConfig defines the complete configuration for an agent.
 // It includes the agent name, optional system prompt, optional client settings,
 // provider configuration, and model configuration.
+// Extends names a base config file, relative to the extending file, that is
+// loaded first and overridden by this config. Quota optionally limits requests
+// and tokens per time window. Aliases name alternate model targets that requests
+// can select, and Routes map protocol names to the alias used by default for
+// that protocol (e.g., a dedicated embeddings model). DeprecatedModels sets how
+// deprecated models are handled when the config is loaded (default "warn").
 type AgentConfig struct {
-	Name         string          `json:"name"`
-	SystemPrompt string          `json:"system_prompt,omitempty"`
-	Client       *ClientConfig   `json:"client,omitempty"`
-	Provider     *ProviderConfig `json:"provider"`
-	Model        *ModelConfig    `json:"model"`
+	Extends          string                  `json:"extends,omitempty"`
+	Name             string                  `json:"name"`
+	SystemPrompt     string                  `json:"system_prompt,omitempty"`
+	Client           *ClientConfig           `json:"client,omitempty"`
+	Provider         *ProviderConfig         `json:"provider"`
+	Model            *ModelConfig            `json:"model"`
+	Quota            *QuotaConfig            `json:"quota,omitempty"`
+	Aliases          map[string]*AliasConfig `json:"aliases,omitempty"`
+	Routes           map[string]string       `json:"routes,omitempty"`
+	DeprecatedModels DeprecationPolicy       `json:"deprecated_models,omitempty"`
+
+	deprecations []DeprecationWarning
 }
 
 // DefaultAgentConfig creates an AgentConfig with default values.
@@ -62,24 +80,197 @@ func (c *AgentConfig) Merge(source *AgentConfig) {
 			c.Model.Merge(source.Model)
 		}
 	}
+
+	if source.Quota != nil {
+		if c.Quota == nil {
+			c.Quota = &QuotaConfig{}
+		}
+		c.Quota.Merge(source.Quota)
+	}
+
+	for name, alias := range source.Aliases {
+		if c.Aliases == nil {
+			c.Aliases = make(map[string]*AliasConfig)
+		}
+		if existing, ok := c.Aliases[name]; ok && existing != nil && alias != nil {
+			existing.Merge(alias)
+		} else if alias != nil {
+			// Store a copy so later merges into this alias leave source untouched
+			c.Aliases[name] = alias.clone()
+		} else {
+			c.Aliases[name] = nil
+		}
+	}
+
+	if source.Routes != nil {
+		if c.Routes == nil {
+			c.Routes = make(map[string]string)
+		}
+		maps.Copy(c.Routes, source.Routes)
+	}
+
+	if source.DeprecatedModels != "" {
+		c.DeprecatedModels = source.DeprecatedModels
+	}
 }
 
 // LoadAgentConfig loads an AgentConfig from a JSON file and merges it with defaults.
-// Returns an error if the file cannot be read or the JSON is invalid.
-func LoadAgentConfig(filename string) (*AgentConfig, error) {
+// Overlay files are merged in order on top of the first file, enabling
+// environment-specific overrides (e.g., prod base URLs and tokens).
+// Each file's extends chain is resolved before it is merged, deprecated models
+// are handled according to DeprecatedModels, and the registered preset for the
+// provider and model is applied beneath the result.
+// Files may contain // and /* */ comments.
+// Returns an error if a file cannot be read, the JSON is invalid,
+// an extends chain is circular, or a deprecated model is rejected.
+func LoadAgentConfig(filename string, overlays ...string) (*AgentConfig, error) {
 	config := DefaultAgentConfig()
 
-	data, err := os.ReadFile(filename)
+	for _, name := range append([]string{filename}, overlays...) {
+		loaded, err := loadAgentConfigFile(name, make(map[string]bool))
+		if err != nil {
+			return nil, err
+		}
+		config.Merge(loaded)
+	}
+
+	if err := config.ResolveDeprecations(); err != nil {
+		return nil, err
+	}
+
+	config.ApplyPreset()
+
+	return &config, nil
+}
+
+// loadAgentConfigFile reads a config file and resolves its extends chain.
+// Seen tracks visited files to detect cycles.
+func loadAgentConfigFile(filename string, seen map[string]bool) (*AgentConfig, error) {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config path: %w", err)
+	}
+
+	if seen[path] {
+		return nil, fmt.Errorf("circular config extends: %s", filename)
+	}
+	seen[path] = true
+
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	var loaded AgentConfig
-	if err := json.Unmarshal(data, &loaded); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	if err := json.Unmarshal(stripComments(data), &loaded); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", filename, err)
+	}
+
+	if loaded.Extends == "" {
+		return &loaded, nil
 	}
 
-	config.Merge(&loaded)
+	base := loaded.Extends
+	if !filepath.IsAbs(base) {
+		base = filepath.Join(filepath.Dir(path), base)
+	}
 
-	return &config, nil
+	parent, err := loadAgentConfigFile(base, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded.Extends = ""
+	parent.Merge(&loaded)
+	return parent, nil
+}
+
+// SaveAgentConfig writes an AgentConfig to a JSON file with indentation.
+// The file is created with owner-only permissions since it may contain secrets;
+// use Redacted to save a shareable copy.
+// Returns an error if the config cannot be marshaled or the file cannot be written.
+func SaveAgentConfig(filename string, cfg *AgentConfig) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(filename, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// RedactedValue replaces secret values in a redacted config.
+const RedactedValue = "[REDACTED]"
+
+// Redacted returns a copy of the config with secret provider options masked,
+// suitable for diagnostics and "show effective config" output.
+// The receiver is not modified.
+func (c *AgentConfig) Redacted() *AgentConfig {
+	redacted := *c
+
+	if c.Client != nil {
+		client := *c.Client
+		redacted.Client = &client
+	}
+
+	if c.Quota != nil {
+		quota := *c.Quota
+		redacted.Quota = &quota
+	}
+
+	if c.Provider != nil {
+		redacted.Provider = redactProvider(c.Provider)
+	}
+
+	if c.Model != nil {
+		redacted.Model = c.Model.clone()
+	}
+
+	if c.Aliases != nil {
+		redacted.Aliases = make(map[string]*AliasConfig, len(c.Aliases))
+		for name, alias := range c.Aliases {
+			if alias == nil {
+				continue
+			}
+			clone := alias.clone()
+			if clone.Provider != nil {
+				clone.Provider = redactProvider(clone.Provider)
+			}
+			redacted.Aliases[name] = clone
+		}
+	}
+
+	redacted.Routes = maps.Clone(c.Routes)
+
+	return &redacted
+}
+
+// redactProvider returns a copy of a provider config with secret options masked.
+func redactProvider(c *ProviderConfig) *ProviderConfig {
+	provider := *c
+	provider.Options = maps.Clone(c.Options)
+	for key, value := range provider.Options {
+		if isSecretOption(key) && value != nil && value != "" {
+			provider.Options[key] = RedactedValue
+		}
+	}
+	return &provider
+}
+
+// isSecretOption reports whether a provider option key holds a credential:
+// keys the default redaction policy treats as secret (see redact.Policy.Secret)
+// and credential options. Paths and settings such as token_file and
+// token_refresh are not secret, but token_command is masked since commands
+// may embed credentials.
+func isSecretOption(key string) bool {
+	key = strings.ToLower(key)
+	switch key {
+	case "token_file", "token_refresh", "auth_type", "auth_header":
+		return false
+	}
+
+	return redact.Default().Secret(key) || strings.Contains(key, "credential")
 }
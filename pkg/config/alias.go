@@ -0,0 +1,55 @@
+package config
+
+import "maps"
+
+// AliasConfig defines a named model target that requests can select by alias.
+// Provider is optional; when omitted the alias uses the agent's provider.
+//
+// Example JSON:
+//
+//	"aliases": {
+//	  "fast": {"model": {"name": "llama3.2:3b"}},
+//	  "smart": {
+//	    "provider": {"name": "azure", "base_url": "https://example.openai.azure.com/openai", "options": {...}},
+//	    "model": {"name": "gpt-4o"}
+//	  }
+//	},
+//	"routes": {
+//	  "embeddings": "embed"
+//	}
+type AliasConfig struct {
+	Provider *ProviderConfig `json:"provider,omitempty"`
+	Model    *ModelConfig    `json:"model"`
+}
+
+// Merge combines the source AliasConfig into this AliasConfig.
+// Provider and model settings are merged field by field.
+func (c *AliasConfig) Merge(source *AliasConfig) {
+	if source.Provider != nil {
+		if c.Provider == nil {
+			c.Provider = &ProviderConfig{}
+		}
+		c.Provider.Merge(source.Provider)
+	}
+
+	if source.Model != nil {
+		if c.Model == nil {
+			c.Model = &ModelConfig{}
+		}
+		c.Model.Merge(source.Model)
+	}
+}
+
+// clone returns a copy of the AliasConfig with independent nested configs.
+func (c *AliasConfig) clone() *AliasConfig {
+	clone := &AliasConfig{}
+	if c.Provider != nil {
+		provider := *c.Provider
+		provider.Options = maps.Clone(c.Provider.Options)
+		clone.Provider = &provider
+	}
+	if c.Model != nil {
+		clone.Model = c.Model.clone()
+	}
+	return clone
+}
@@ -1,14 +1,51 @@
 package config
 
-import "time"
+import (
+	"net/http"
+	"time"
+)
 
 // ClientConfig defines the configuration for the HTTP client layer.
 // It includes timeout settings, retry behavior, and connection pooling parameters.
+// ParseMode selects parsing strictness for non-streaming responses
+// ("lenient" or "strict"); when empty, the response package default applies.
+// Streams use Streaming.ParseMode.
+// OptionValidation selects request option checking ("strict", "lenient", or "off");
+// when empty, validation is off.
+// StreamFirstByteTimeout fails a streaming attempt that produces no chunk
+// within that long of being sent (disabled when zero). StreamRetry retries
+// streaming requests that fail before their first chunk, using the Retry
+// settings (see StreamRetryEnabled); streams are never retried once a chunk
+// has been delivered.
+// StreamSendTimeout aborts a stream whose consumer does not receive a chunk
+// within that long, closing its connection and channel so an abandoned
+// stream does not hold a goroutine (disabled when zero).
+// Streaming groups streaming behavior (see StreamingConfig).
+// Transport optionally replaces the pooled HTTP transport, for example with a
+// recording or replaying round tripper in tests; it is not serialized.
 type ClientConfig struct {
 	Timeout            Duration    `json:"timeout"`
 	Retry              RetryConfig `json:"retry"`
 	ConnectionPoolSize int         `json:"connection_pool_size"`
 	ConnectionTimeout  Duration    `json:"connection_timeout"`
+	ParseMode          string      `json:"parse_mode,omitempty"`
+	OptionValidation   string      `json:"option_validation,omitempty"`
+
+	// Deprecated: use Streaming.IdleTimeout. Merge moves it into the
+	// streaming block.
+	StreamIdleTimeout Duration `json:"stream_idle_timeout,omitempty"`
+
+	// Deprecated: use Streaming.Heartbeat. Merge moves it into the
+	// streaming block.
+	StreamHeartbeat Duration `json:"stream_heartbeat,omitempty"`
+
+	StreamFirstByteTimeout Duration `json:"stream_first_byte_timeout,omitempty"`
+	StreamRetry            *bool    `json:"stream_retry,omitempty"`
+	StreamSendTimeout      Duration `json:"stream_send_timeout,omitempty"`
+
+	Streaming StreamingConfig `json:"streaming,omitzero"`
+
+	Transport http.RoundTripper `json:"-"`
 }
 
 // RetryConfig configures retry behavior for failed requests.
@@ -18,7 +55,19 @@ type RetryConfig struct {
 	InitialBackoff    Duration `json:"initial_backoff"`
 	MaxBackoff        Duration `json:"max_backoff"`
 	BackoffMultiplier float64  `json:"backoff_multiplier"`
-	Jitter            bool     `json:"jitter"`
+	Jitter            *bool    `json:"jitter,omitempty"`
+}
+
+// JitterEnabled reports whether backoff delays are randomized.
+// A nil Jitter disables jitter.
+func (r RetryConfig) JitterEnabled() bool {
+	return r.Jitter != nil && *r.Jitter
+}
+
+// StreamRetryEnabled reports whether streams that fail before their first
+// chunk are retried. A nil StreamRetry disables stream retries.
+func (c *ClientConfig) StreamRetryEnabled() bool {
+	return c.StreamRetry != nil && *c.StreamRetry
 }
 
 // DefaultClientConfig creates a ClientConfig with default values.
@@ -34,17 +83,20 @@ func DefaultClientConfig() *ClientConfig {
 // DefaultRetryConfig creates a RetryConfig with default values.
 // Retries up to 3 times with exponential backoff starting at 1s, capped at 30s.
 func DefaultRetryConfig() RetryConfig {
+	jitter := true
 	return RetryConfig{
 		MaxRetries:        3,
 		InitialBackoff:    Duration(time.Second),
 		MaxBackoff:        Duration(30 * time.Second),
 		BackoffMultiplier: 2.0,
-		Jitter:            true,
+		Jitter:            &jitter,
 	}
 }
 
 // Merge combines the source ClientConfig into this ClientConfig.
 // Positive values from source override the current values. Zero values are ignored.
+// The deprecated StreamIdleTimeout and StreamHeartbeat are merged into the
+// streaming block unless source's streaming block sets them.
 func (c *ClientConfig) Merge(source *ClientConfig) {
 	if source.Timeout > 0 {
 		c.Timeout = source.Timeout
@@ -66,8 +118,11 @@ func (c *ClientConfig) Merge(source *ClientConfig) {
 		c.Retry.BackoffMultiplier = source.Retry.BackoffMultiplier
 	}
 
-	// Jitter is boolean, always take source value if explicitly set
-	c.Retry.Jitter = source.Retry.Jitter
+	// Jitter is optional: take the source value whenever it is set, including false
+	if source.Retry.Jitter != nil {
+		jitter := *source.Retry.Jitter
+		c.Retry.Jitter = &jitter
+	}
 
 	if source.ConnectionPoolSize > 0 {
 		c.ConnectionPoolSize = source.ConnectionPoolSize
@@ -76,4 +131,40 @@ func (c *ClientConfig) Merge(source *ClientConfig) {
 	if source.ConnectionTimeout > 0 {
 		c.ConnectionTimeout = source.ConnectionTimeout
 	}
+
+	if source.ParseMode != "" {
+		c.ParseMode = source.ParseMode
+	}
+
+	if source.OptionValidation != "" {
+		c.OptionValidation = source.OptionValidation
+	}
+
+	if source.StreamIdleTimeout > 0 && source.Streaming.IdleTimeout == 0 {
+		c.Streaming.IdleTimeout = source.StreamIdleTimeout
+	}
+
+	if source.StreamHeartbeat > 0 && source.Streaming.Heartbeat == 0 {
+		c.Streaming.Heartbeat = source.StreamHeartbeat
+	}
+
+	if source.StreamFirstByteTimeout > 0 {
+		c.StreamFirstByteTimeout = source.StreamFirstByteTimeout
+	}
+
+	// StreamRetry is optional: take the source value whenever it is set, including false
+	if source.StreamRetry != nil {
+		streamRetry := *source.StreamRetry
+		c.StreamRetry = &streamRetry
+	}
+
+	if source.StreamSendTimeout > 0 {
+		c.StreamSendTimeout = source.StreamSendTimeout
+	}
+
+	c.Streaming.Merge(&source.Streaming)
+
+	if source.Transport != nil {
+		c.Transport = source.Transport
+	}
 }
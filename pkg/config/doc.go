@@ -26,4 +26,21 @@
 //
 // Duration values support human-readable strings ("24s", "1m", "2h") or
 // numeric nanoseconds for programmatic configuration.
+//
+// A config file may set "extends" to a base file that it overrides, and
+// LoadAgentConfig accepts overlay files merged in order:
+//
+//	cfg, err := config.LoadAgentConfig("config.json", "config.prod.json")
+//
+// Load does the same and merges environment variables over the files (see
+// FromEnv), falling back to config.json or defaults when no file is given;
+// Files collects the file names from a repeatable flag:
+//
+//	var files config.Files
+//	flag.Var(&files, "config", "Configuration file; repeat to overlay")
+//	flag.Parse()
+//	cfg, err := config.Load("TAU", files...)
+//
+// Config files may contain // and /* */ comments. Validate reports missing
+// required fields and out-of-range settings, one error per field.
 package config
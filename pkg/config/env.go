@@ -0,0 +1,230 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromEnv builds an AgentConfig from environment variables named with prefix.
+// Only variables that are set populate the config, so the result can be merged
+// over file configuration or defaults:
+//
+//	cfg := config.DefaultAgentConfig()
+//	env, err := config.FromEnv("TAU")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	cfg.Merge(env)
+//
+// Recognized variables (shown with prefix "TAU"):
+//
+//	TAU_NAME, TAU_SYSTEM_PROMPT
+//	TAU_PROVIDER_NAME, TAU_PROVIDER_BASE_URL
+//	TAU_PROVIDER_OPTIONS_<KEY>          e.g. TAU_PROVIDER_OPTIONS_TOKEN
+//	TAU_MODEL_NAME
+//	TAU_MODEL_CAPABILITIES_<PROTOCOL>   JSON object, e.g. TAU_MODEL_CAPABILITIES_CHAT='{"temperature":0.7}'
+//	TAU_CLIENT_TIMEOUT, TAU_CLIENT_CONNECTION_TIMEOUT, TAU_CLIENT_CONNECTION_POOL_SIZE
+//	TAU_CLIENT_PARSE_MODE, TAU_CLIENT_OPTION_VALIDATION
+//	TAU_CLIENT_STREAM_SEND_TIMEOUT, TAU_CLIENT_STREAM_RETRY
+//	TAU_CLIENT_STREAMING_BUFFER_SIZE, TAU_CLIENT_STREAMING_IDLE_TIMEOUT, TAU_CLIENT_STREAMING_HEARTBEAT
+//	TAU_CLIENT_STREAMING_INCLUDE_USAGE, TAU_CLIENT_STREAMING_PARSE_MODE
+//	TAU_CLIENT_RETRY_MAX_RETRIES, TAU_CLIENT_RETRY_INITIAL_BACKOFF, TAU_CLIENT_RETRY_MAX_BACKOFF
+//	TAU_CLIENT_RETRY_BACKOFF_MULTIPLIER, TAU_CLIENT_RETRY_JITTER
+//
+// The deprecated TAU_CLIENT_STREAM_IDLE_TIMEOUT and TAU_CLIENT_STREAM_HEARTBEAT
+// set the streaming block's idle timeout and heartbeat unless the
+// TAU_CLIENT_STREAMING_ variables do.
+// Option keys and protocols are lowercased. Provider option values that are
+// JSON objects or arrays are decoded; all other values are kept as strings.
+// Returns an error listing every variable that cannot be parsed.
+func FromEnv(prefix string) (*AgentConfig, error) {
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+	env := &envReader{prefix: prefix}
+
+	cfg := &AgentConfig{}
+	env.str("NAME", &cfg.Name)
+	env.str("SYSTEM_PROMPT", &cfg.SystemPrompt)
+
+	provider := &ProviderConfig{}
+	providerSet := env.str("PROVIDER_NAME", &provider.Name)
+	providerSet = env.str("PROVIDER_BASE_URL", &provider.BaseURL) || providerSet
+	for key, value := range env.group("PROVIDER_OPTIONS_") {
+		if provider.Options == nil {
+			provider.Options = make(map[string]any)
+		}
+		provider.Options[key] = env.option(value)
+		providerSet = true
+	}
+	if providerSet {
+		cfg.Provider = provider
+	}
+
+	model := &ModelConfig{}
+	modelSet := env.str("MODEL_NAME", &model.Name)
+	for protocol, value := range env.group("MODEL_CAPABILITIES_") {
+		var options map[string]any
+		if err := json.Unmarshal([]byte(value), &options); err != nil {
+			env.fail("MODEL_CAPABILITIES_"+strings.ToUpper(protocol), err)
+			continue
+		}
+		if model.Capabilities == nil {
+			model.Capabilities = make(map[string]map[string]any)
+		}
+		model.Capabilities[protocol] = options
+		modelSet = true
+	}
+	if modelSet {
+		cfg.Model = model
+	}
+
+	client := &ClientConfig{}
+	clientSet := env.duration("CLIENT_TIMEOUT", &client.Timeout)
+	clientSet = env.duration("CLIENT_CONNECTION_TIMEOUT", &client.ConnectionTimeout) || clientSet
+	clientSet = env.integer("CLIENT_CONNECTION_POOL_SIZE", &client.ConnectionPoolSize) || clientSet
+	clientSet = env.str("CLIENT_PARSE_MODE", &client.ParseMode) || clientSet
+	clientSet = env.str("CLIENT_OPTION_VALIDATION", &client.OptionValidation) || clientSet
+	clientSet = env.duration("CLIENT_STREAM_IDLE_TIMEOUT", &client.Streaming.IdleTimeout) || clientSet
+	clientSet = env.duration("CLIENT_STREAM_HEARTBEAT", &client.Streaming.Heartbeat) || clientSet
+	clientSet = env.duration("CLIENT_STREAM_SEND_TIMEOUT", &client.StreamSendTimeout) || clientSet
+	var streamRetry bool
+	if env.boolean("CLIENT_STREAM_RETRY", &streamRetry) {
+		client.StreamRetry = &streamRetry
+		clientSet = true
+	}
+	clientSet = env.integer("CLIENT_STREAMING_BUFFER_SIZE", &client.Streaming.BufferSize) || clientSet
+	clientSet = env.duration("CLIENT_STREAMING_IDLE_TIMEOUT", &client.Streaming.IdleTimeout) || clientSet
+	clientSet = env.duration("CLIENT_STREAMING_HEARTBEAT", &client.Streaming.Heartbeat) || clientSet
+	var includeUsage bool
+	if env.boolean("CLIENT_STREAMING_INCLUDE_USAGE", &includeUsage) {
+		client.Streaming.IncludeUsage = &includeUsage
+		clientSet = true
+	}
+	clientSet = env.str("CLIENT_STREAMING_PARSE_MODE", &client.Streaming.ParseMode) || clientSet
+	clientSet = env.integer("CLIENT_RETRY_MAX_RETRIES", &client.Retry.MaxRetries) || clientSet
+	clientSet = env.duration("CLIENT_RETRY_INITIAL_BACKOFF", &client.Retry.InitialBackoff) || clientSet
+	clientSet = env.duration("CLIENT_RETRY_MAX_BACKOFF", &client.Retry.MaxBackoff) || clientSet
+	clientSet = env.float("CLIENT_RETRY_BACKOFF_MULTIPLIER", &client.Retry.BackoffMultiplier) || clientSet
+	var jitter bool
+	if env.boolean("CLIENT_RETRY_JITTER", &jitter) {
+		client.Retry.Jitter = &jitter
+		clientSet = true
+	}
+	if clientSet {
+		cfg.Client = client
+	}
+
+	if len(env.errs) > 0 {
+		return nil, fmt.Errorf("invalid environment configuration: %w", errors.Join(env.errs...))
+	}
+
+	return cfg, nil
+}
+
+// envReader reads prefixed environment variables, collecting parse errors.
+type envReader struct {
+	prefix string
+	errs   []error
+}
+
+func (e *envReader) lookup(name string) (string, bool) {
+	return os.LookupEnv(e.prefix + name)
+}
+
+func (e *envReader) fail(name string, err error) {
+	e.errs = append(e.errs, fmt.Errorf("%s%s: %w", e.prefix, name, err))
+}
+
+func (e *envReader) str(name string, target *string) bool {
+	value, ok := e.lookup(name)
+	if ok {
+		*target = value
+	}
+	return ok
+}
+
+func (e *envReader) duration(name string, target *Duration) bool {
+	value, ok := e.lookup(name)
+	if !ok {
+		return false
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.fail(name, err)
+		return false
+	}
+	*target = Duration(parsed)
+	return true
+}
+
+func (e *envReader) integer(name string, target *int) bool {
+	value, ok := e.lookup(name)
+	if !ok {
+		return false
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.fail(name, err)
+		return false
+	}
+	*target = parsed
+	return true
+}
+
+func (e *envReader) float(name string, target *float64) bool {
+	value, ok := e.lookup(name)
+	if !ok {
+		return false
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.fail(name, err)
+		return false
+	}
+	*target = parsed
+	return true
+}
+
+func (e *envReader) boolean(name string, target *bool) bool {
+	value, ok := e.lookup(name)
+	if !ok {
+		return false
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.fail(name, err)
+		return false
+	}
+	*target = parsed
+	return true
+}
+
+// group returns variables sharing a name prefix, keyed by the lowercased remainder.
+func (e *envReader) group(name string) map[string]string {
+	group := make(map[string]string)
+	for _, entry := range os.Environ() {
+		key, value, _ := strings.Cut(entry, "=")
+		if suffix, ok := strings.CutPrefix(key, e.prefix+name); ok && suffix != "" {
+			group[strings.ToLower(suffix)] = value
+		}
+	}
+	return group
+}
+
+// option decodes JSON object and array values, keeping other values as strings.
+func (e *envReader) option(value string) any {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var decoded any
+		if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+			return decoded
+		}
+	}
+	return value
+}
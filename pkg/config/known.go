@@ -0,0 +1,258 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrDeprecatedModel indicates a deprecated model is configured under the
+// DeprecationError policy.
+var ErrDeprecatedModel = errors.New("deprecated model")
+
+// ModelInfo describes a known model and its lifecycle status.
+// Replacement names the recommended successor of a deprecated model, and
+// Shutdown optionally records the date (YYYY-MM-DD) the model stops serving.
+type ModelInfo struct {
+	Name        string
+	Owner       string
+	Deprecated  bool
+	Replacement string
+	Shutdown    string
+}
+
+// knownModels maintains the global known-model registry keyed by lowercase name.
+// It is thread-safe for concurrent registration and lookup.
+var knownModels = struct {
+	entries map[string]ModelInfo
+	mu      sync.RWMutex
+}{
+	entries: make(map[string]ModelInfo),
+}
+
+// RegisterModel adds a model to the known-model registry.
+// Registering an existing name replaces its entry.
+// Thread-safe for concurrent registration.
+func RegisterModel(info ModelInfo) {
+	knownModels.mu.Lock()
+	defer knownModels.mu.Unlock()
+	knownModels.entries[strings.ToLower(info.Name)] = info
+}
+
+// LookupModel returns the registry entry for a model name.
+// Names are matched case-insensitively; Ollama-style tags (e.g., "llama2:7b")
+// fall back to the untagged name.
+// Thread-safe for concurrent access.
+func LookupModel(name string) (ModelInfo, bool) {
+	knownModels.mu.RLock()
+	defer knownModels.mu.RUnlock()
+
+	name = strings.ToLower(name)
+	if info, ok := knownModels.entries[name]; ok {
+		return info, true
+	}
+
+	if base, _, ok := strings.Cut(name, ":"); ok {
+		info, ok := knownModels.entries[base]
+		return info, ok
+	}
+
+	return ModelInfo{}, false
+}
+
+// KnownModels returns all registered models sorted by name.
+// Thread-safe for concurrent access.
+func KnownModels() []ModelInfo {
+	knownModels.mu.RLock()
+	defer knownModels.mu.RUnlock()
+
+	models := make([]ModelInfo, 0, len(knownModels.entries))
+	for _, key := range slices.Sorted(maps.Keys(knownModels.entries)) {
+		models = append(models, knownModels.entries[key])
+	}
+	return models
+}
+
+// DeprecationPolicy controls how LoadAgentConfig handles deprecated models.
+type DeprecationPolicy string
+
+const (
+	// DeprecationWarn records a warning and keeps the configured model.
+	DeprecationWarn DeprecationPolicy = "warn"
+
+	// DeprecationRewrite replaces deprecated models with their replacement.
+	// Models without a replacement are kept and recorded as warnings.
+	DeprecationRewrite DeprecationPolicy = "rewrite"
+
+	// DeprecationError rejects configs that use deprecated models.
+	DeprecationError DeprecationPolicy = "error"
+
+	// DeprecationIgnore disables deprecation checks.
+	DeprecationIgnore DeprecationPolicy = "ignore"
+)
+
+// IsValid returns true if the policy is a recognized value.
+func (p DeprecationPolicy) IsValid() bool {
+	switch p {
+	case DeprecationWarn, DeprecationRewrite, DeprecationError, DeprecationIgnore:
+		return true
+	default:
+		return false
+	}
+}
+
+// DeprecationWarning reports a deprecated model found in a config.
+// Alias is empty for the agent's primary model. Rewritten is true when the
+// model was replaced under the DeprecationRewrite policy.
+type DeprecationWarning struct {
+	Alias       string
+	Model       string
+	Replacement string
+	Shutdown    string
+	Rewritten   bool
+}
+
+// String describes the deprecation and the recommended action.
+func (w DeprecationWarning) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "model %q", w.Model)
+	if w.Alias != "" {
+		fmt.Fprintf(&b, " (alias %q)", w.Alias)
+	}
+	b.WriteString(" is deprecated")
+	if w.Shutdown != "" {
+		fmt.Fprintf(&b, " (shutdown %s)", w.Shutdown)
+	}
+	switch {
+	case w.Rewritten:
+		fmt.Fprintf(&b, "; rewritten to %q", w.Replacement)
+	case w.Replacement != "":
+		fmt.Fprintf(&b, "; use %q", w.Replacement)
+	}
+	return b.String()
+}
+
+// ResolveDeprecations checks the primary and alias models against the
+// known-model registry and applies the configured DeprecationPolicy.
+// Warnings are retained and available from Deprecations. It can be called
+// again after the models change (e.g., by overrides); warnings for models an
+// earlier call rewrote are kept while the model still names the replacement.
+// Returns an error wrapping ErrDeprecatedModel under DeprecationError, or if
+// the policy is not recognized.
+func (c *AgentConfig) ResolveDeprecations() error {
+	policy := c.DeprecatedModels
+	if policy == "" {
+		policy = DeprecationWarn
+	}
+	if !policy.IsValid() {
+		return fmt.Errorf("invalid deprecated_models policy: %s", policy)
+	}
+
+	var kept []DeprecationWarning
+	for _, w := range c.deprecations {
+		if model := c.aliasModel(w.Alias); w.Rewritten && model != nil && model.Name == w.Replacement {
+			kept = append(kept, w)
+		}
+	}
+	c.deprecations = kept
+	if policy == DeprecationIgnore {
+		return nil
+	}
+
+	check := func(alias string, model *ModelConfig) error {
+		if model == nil {
+			return nil
+		}
+		info, ok := LookupModel(model.Name)
+		if !ok || !info.Deprecated {
+			return nil
+		}
+
+		warning := DeprecationWarning{
+			Alias:       alias,
+			Model:       model.Name,
+			Replacement: info.Replacement,
+			Shutdown:    info.Shutdown,
+		}
+
+		switch policy {
+		case DeprecationError:
+			return fmt.Errorf("%w: %s", ErrDeprecatedModel, warning)
+		case DeprecationRewrite:
+			if info.Replacement != "" {
+				model.Name = info.Replacement
+				warning.Rewritten = true
+			}
+		}
+
+		c.deprecations = append(c.deprecations, warning)
+		return nil
+	}
+
+	if err := check("", c.Model); err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.Aliases)) {
+		if alias := c.Aliases[name]; alias != nil {
+			if err := check(name, alias.Model); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Deprecations returns the warnings recorded by ResolveDeprecations.
+func (c *AgentConfig) Deprecations() []DeprecationWarning {
+	return c.deprecations
+}
+
+// aliasModel returns the model of the named alias, or the primary model for
+// an empty name.
+func (c *AgentConfig) aliasModel(name string) *ModelConfig {
+	if name == "" {
+		return c.Model
+	}
+	if alias := c.Aliases[name]; alias != nil {
+		return alias.Model
+	}
+	return nil
+}
+
+func init() {
+	for _, name := range []string{
+		"gpt-4o", "gpt-4o-mini", "gpt-4.1", "gpt-4.1-mini", "gpt-4.1-nano",
+		"o3", "o3-mini", "o4-mini", "gpt-5", "gpt-5-mini",
+		"text-embedding-3-small", "text-embedding-3-large", "text-embedding-ada-002",
+	} {
+		RegisterModel(ModelInfo{Name: name, Owner: "openai"})
+	}
+
+	for _, name := range []string{"llama3.1", "llama3.2", "llama3.2-vision", "llava", "gemma3", "qwen2.5", "mistral", "nomic-embed-text", "mxbai-embed-large"} {
+		RegisterModel(ModelInfo{Name: name, Owner: "ollama"})
+	}
+
+	deprecated := []ModelInfo{
+		{Name: "gpt-4-vision-preview", Replacement: "gpt-4o", Shutdown: "2024-12-06"},
+		{Name: "gpt-4-1106-vision-preview", Replacement: "gpt-4o", Shutdown: "2024-12-06"},
+		{Name: "gpt-4-32k", Replacement: "gpt-4o"},
+		{Name: "gpt-4.5-preview", Replacement: "gpt-4.1", Shutdown: "2025-07-14"},
+		{Name: "gpt-3.5-turbo-0613", Replacement: "gpt-4o-mini"},
+		{Name: "gpt-3.5-turbo-16k", Replacement: "gpt-4o-mini"},
+		{Name: "text-davinci-003", Replacement: "gpt-4o-mini", Shutdown: "2024-01-04"},
+		{Name: "o1-preview", Replacement: "o3"},
+		{Name: "o1-mini", Replacement: "o4-mini"},
+	}
+	for _, info := range deprecated {
+		info.Owner = "openai"
+		info.Deprecated = true
+		RegisterModel(info)
+	}
+
+	RegisterModel(ModelInfo{Name: "llama2", Owner: "ollama", Deprecated: true, Replacement: "llama3.2"})
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// DefaultConfigFile is the config file Load reads when no file is given.
+const DefaultConfigFile = "config.json"
+
+// Files collects config file names from repeated or comma-separated flag
+// values (it implements flag.Value). Files are merged in order, so later
+// files override earlier ones.
+type Files []string
+
+// String returns the file names joined by commas.
+func (f *Files) String() string {
+	return strings.Join(*f, ",")
+}
+
+// Set appends the comma-separated file names in value.
+func (f *Files) Set(value string) error {
+	for file := range strings.SplitSeq(value, ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			*f = append(*f, file)
+		}
+	}
+	return nil
+}
+
+// Load loads the config files in order (see LoadAgentConfig) and merges
+// environment variables named with prefix over them (see FromEnv). Without
+// files, DefaultConfigFile is loaded when it exists; otherwise defaults are
+// used, so an agent can be configured from the environment alone.
+// Deprecated models are resolved again after the environment is merged (see
+// ResolveDeprecations), keeping the warnings for models the files rewrote.
+// Returns an error if a file cannot be loaded, a variable cannot be parsed,
+// or a deprecated model is rejected.
+func Load(prefix string, files ...string) (*AgentConfig, error) {
+	if len(files) == 0 {
+		if _, err := os.Stat(DefaultConfigFile); err == nil {
+			files = []string{DefaultConfigFile}
+		}
+	}
+
+	var cfg *AgentConfig
+	if len(files) > 0 {
+		loaded, err := LoadAgentConfig(files[0], files[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		cfg = loaded
+	} else {
+		defaults := DefaultAgentConfig()
+		cfg = &defaults
+	}
+
+	env, err := FromEnv(prefix)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Merge(env)
+
+	if err := cfg.ResolveDeprecations(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -1,10 +1,19 @@
 package config
 
-import "maps"
+import (
+	"maps"
+	"slices"
+)
 
 // ModelConfig defines the configuration for an LLM model.
 // Name is the model identifier (e.g., "gpt-4o", "claude-3-opus", "llama3.1:8b").
 // Capabilities maps protocol names to their default options.
+// Pricing optionally sets per-1K token costs for cost tracking.
+// ContextWindow and MaxOutputTokens bound request sizes, and Supports flags
+// features (vision, tools, json_mode, json_schema); features not listed are
+// assumed supported. Advisory names the limits and features filled in from a
+// preset rather than configured; they describe the model but are not enforced
+// against requests.
 //
 // Example JSON:
 //
@@ -19,13 +28,36 @@ import "maps"
 //	      "temperature": 0.5,
 //	      "max_tokens": 2048
 //	    }
-//	  }
+//	  },
+//	  "context_window": 128000,
+//	  "max_output_tokens": 16384,
+//	  "supports": {"vision": true, "tools": true, "json_mode": true}
 //	}
 type ModelConfig struct {
-	Name         string                      `json:"name,omitempty"`
-	Capabilities map[string]map[string]any   `json:"capabilities,omitempty"`
+	Name            string                    `json:"name,omitempty"`
+	Capabilities    map[string]map[string]any `json:"capabilities,omitempty"`
+	Pricing         *PricingConfig            `json:"pricing,omitempty"`
+	ContextWindow   int                       `json:"context_window,omitempty"`
+	MaxOutputTokens int                       `json:"max_output_tokens,omitempty"`
+	Supports        map[string]bool           `json:"supports,omitempty"`
+	Advisory        []string                  `json:"-"`
 }
 
+// Limit names used in ModelConfig.Advisory alongside feature names.
+const (
+	LimitContextWindow   = "context_window"
+	LimitMaxOutputTokens = "max_output_tokens"
+)
+
+// Feature names used as keys in ModelConfig.Supports.
+const (
+	FeatureVision     = "vision"
+	FeatureTools      = "tools"
+	FeatureJSONMode   = "json_mode"
+	FeatureJSONSchema = "json_schema"
+	FeatureWebSearch  = "web_search"
+)
+
 // DefaultModelConfig creates a ModelConfig with initialized empty capabilities.
 func DefaultModelConfig() *ModelConfig {
 	return &ModelConfig{
@@ -35,7 +67,9 @@ func DefaultModelConfig() *ModelConfig {
 
 // Merge combines the source ModelConfig into this ModelConfig.
 // Non-empty name from source overrides the current value.
-// Capabilities are merged at the protocol level.
+// Capabilities are merged at the protocol level, positive limits override,
+// and supports flags are merged by feature. A limit or feature taken from
+// source is advisory only if source marks it advisory.
 func (c *ModelConfig) Merge(source *ModelConfig) {
 	if source.Name != "" {
 		c.Name = source.Name
@@ -57,4 +91,57 @@ func (c *ModelConfig) Merge(source *ModelConfig) {
 			}
 		}
 	}
+
+	if source.Pricing != nil {
+		if c.Pricing == nil {
+			c.Pricing = &PricingConfig{}
+		}
+		c.Pricing.Merge(source.Pricing)
+	}
+
+	if source.ContextWindow > 0 {
+		c.ContextWindow = source.ContextWindow
+		c.takeAdvisory(source, LimitContextWindow)
+	}
+
+	if source.MaxOutputTokens > 0 {
+		c.MaxOutputTokens = source.MaxOutputTokens
+		c.takeAdvisory(source, LimitMaxOutputTokens)
+	}
+
+	if source.Supports != nil {
+		if c.Supports == nil {
+			c.Supports = make(map[string]bool)
+		}
+		for _, feature := range slices.Sorted(maps.Keys(source.Supports)) {
+			c.Supports[feature] = source.Supports[feature]
+			c.takeAdvisory(source, feature)
+		}
+	}
+}
+
+// takeAdvisory marks name advisory when source marks it, and configured otherwise.
+func (c *ModelConfig) takeAdvisory(source *ModelConfig, name string) {
+	c.Advisory = slices.DeleteFunc(c.Advisory, func(n string) bool { return n == name })
+	if slices.Contains(source.Advisory, name) {
+		c.Advisory = append(c.Advisory, name)
+	}
+}
+
+// clone returns a copy of the ModelConfig with independent capability maps.
+func (c *ModelConfig) clone() *ModelConfig {
+	clone := *c
+	if c.Capabilities != nil {
+		clone.Capabilities = make(map[string]map[string]any, len(c.Capabilities))
+		for protocol, options := range c.Capabilities {
+			clone.Capabilities[protocol] = maps.Clone(options)
+		}
+	}
+	if c.Pricing != nil {
+		pricing := *c.Pricing
+		clone.Pricing = &pricing
+	}
+	clone.Supports = maps.Clone(c.Supports)
+	clone.Advisory = slices.Clone(c.Advisory)
+	return &clone
 }
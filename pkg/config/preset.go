@@ -0,0 +1,186 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// AnyProvider registers a preset that applies to a model on every provider.
+const AnyProvider = "*"
+
+// presetKey identifies a preset by provider name and model name prefix.
+type presetKey struct {
+	provider string
+	prefix   string
+}
+
+// presets maintains the global model preset registry.
+// It is thread-safe for concurrent registration and lookup.
+var presets = struct {
+	entries map[presetKey]*ModelConfig
+	mu      sync.RWMutex
+}{
+	entries: make(map[presetKey]*ModelConfig),
+}
+
+// RegisterPreset registers default model configuration for models whose name
+// starts with modelPrefix on the named provider. Use AnyProvider to match the
+// model on every provider. Registering an existing key replaces the preset.
+// Thread-safe for concurrent registration.
+func RegisterPreset(provider, modelPrefix string, preset *ModelConfig) {
+	presets.mu.Lock()
+	defer presets.mu.Unlock()
+	presets.entries[presetKey{provider, strings.ToLower(modelPrefix)}] = preset.clone()
+}
+
+// LookupPreset returns a copy of the preset for a provider and model name.
+// Provider-specific presets take precedence over AnyProvider presets, and
+// longer model prefixes take precedence over shorter ones. Model names are
+// matched case-insensitively.
+// Thread-safe for concurrent access.
+func LookupPreset(provider, model string) (*ModelConfig, bool) {
+	presets.mu.RLock()
+	defer presets.mu.RUnlock()
+
+	name := strings.ToLower(model)
+	var best *ModelConfig
+	bestKey := presetKey{}
+
+	for key, preset := range presets.entries {
+		if key.provider != provider && key.provider != AnyProvider {
+			continue
+		}
+		if !strings.HasPrefix(name, key.prefix) {
+			continue
+		}
+
+		specific := key.provider != AnyProvider
+		bestSpecific := best != nil && bestKey.provider != AnyProvider
+		if best == nil || (specific && !bestSpecific) ||
+			(specific == bestSpecific && len(key.prefix) > len(bestKey.prefix)) {
+			best, bestKey = preset, key
+		}
+	}
+
+	if best == nil {
+		return nil, false
+	}
+	return best.clone(), true
+}
+
+// ApplyPreset fills in defaults from the registered preset for the configured
+// provider and model, and for each alias model. Only settings the config leaves
+// unset are filled in (capability formats, limits, and supports flags); the
+// config's own model settings always win. Preset limits and features are
+// advisory: they describe the model, but requests are only checked against
+// configured values. Aliases without a provider use the agent's provider.
+// Does nothing for models with no matching preset.
+func (c *AgentConfig) ApplyPreset() {
+	if c.Provider != nil {
+		c.Model = applyPreset(c.Provider.Name, c.Model)
+	}
+
+	for _, alias := range c.Aliases {
+		if alias == nil {
+			continue
+		}
+		provider := c.Provider
+		if alias.Provider != nil && alias.Provider.Name != "" {
+			provider = alias.Provider
+		}
+		if provider != nil {
+			alias.Model = applyPreset(provider.Name, alias.Model)
+		}
+	}
+}
+
+// applyPreset returns the preset for a provider and model merged with the
+// model's own settings, or the model unchanged when no preset matches.
+// Limits and features the model does not configure are marked advisory.
+func applyPreset(provider string, model *ModelConfig) *ModelConfig {
+	if model == nil || model.Name == "" {
+		return model
+	}
+
+	preset, ok := LookupPreset(provider, model.Name)
+	if !ok {
+		return model
+	}
+
+	if preset.ContextWindow > 0 {
+		preset.Advisory = append(preset.Advisory, LimitContextWindow)
+	}
+	if preset.MaxOutputTokens > 0 {
+		preset.Advisory = append(preset.Advisory, LimitMaxOutputTokens)
+	}
+	for _, feature := range slices.Sorted(maps.Keys(preset.Supports)) {
+		preset.Advisory = append(preset.Advisory, feature)
+	}
+
+	preset.Merge(model)
+	return preset
+}
+
+func init() {
+	// formats selects the OpenAI-compatible wire format for each protocol.
+	formats := func(protocols ...string) map[string]map[string]any {
+		capabilities := make(map[string]map[string]any, len(protocols))
+		for _, protocol := range protocols {
+			capabilities[protocol] = map[string]any{"format": "openai"}
+		}
+		return capabilities
+	}
+
+	// OpenAI models served by OpenAI-compatible providers and Azure.
+	openai := func(contextWindow, maxOutput int) *ModelConfig {
+		return &ModelConfig{
+			Capabilities:    formats("chat", "vision", "tools"),
+			ContextWindow:   contextWindow,
+			MaxOutputTokens: maxOutput,
+			Supports: map[string]bool{
+				FeatureVision:   true,
+				FeatureTools:    true,
+				FeatureJSONMode: true,
+			},
+		}
+	}
+	RegisterPreset(AnyProvider, "gpt-4o", openai(128000, 16384))
+	RegisterPreset(AnyProvider, "gpt-4.1", openai(1047576, 32768))
+	for _, prefix := range []string{"o1", "o3", "o4"} {
+		RegisterPreset(AnyProvider, prefix, openai(200000, 100000))
+	}
+	RegisterPreset(AnyProvider, "gpt-5", openai(400000, 128000))
+	for _, prefix := range []string{"text-embedding-3", "text-embedding-ada-002"} {
+		RegisterPreset(AnyProvider, prefix, &ModelConfig{
+			Capabilities:  formats("embeddings"),
+			ContextWindow: 8191,
+			Supports: map[string]bool{
+				FeatureVision:   false,
+				FeatureTools:    false,
+				FeatureJSONMode: false,
+			},
+		})
+	}
+
+	// Common Ollama models.
+	for _, prefix := range []string{"llama3", "qwen", "mistral"} {
+		RegisterPreset("ollama", prefix, &ModelConfig{
+			Capabilities: formats("chat", "tools"),
+			Supports:     map[string]bool{FeatureVision: false},
+		})
+	}
+	for _, prefix := range []string{"llava", "gemma3", "llama3.2-vision"} {
+		RegisterPreset("ollama", prefix, &ModelConfig{
+			Capabilities: formats("chat", "vision"),
+			Supports:     map[string]bool{FeatureTools: false},
+		})
+	}
+	for _, prefix := range []string{"nomic-embed-text", "mxbai-embed-large", "all-minilm"} {
+		RegisterPreset("ollama", prefix, &ModelConfig{
+			Capabilities: formats("embeddings"),
+			Supports:     map[string]bool{FeatureVision: false, FeatureTools: false},
+		})
+	}
+}
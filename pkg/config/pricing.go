@@ -0,0 +1,71 @@
+package config
+
+// PricingConfig defines token pricing for a model, used for cost tracking.
+// Prices are per 1,000 tokens in the given currency (default "USD").
+//
+// Example JSON:
+//
+//	"pricing": {
+//	  "prompt_per_1k": 0.0025,
+//	  "completion_per_1k": 0.01,
+//	  "currency": "USD"
+//	}
+type PricingConfig struct {
+	PromptPer1K     float64 `json:"prompt_per_1k"`
+	CompletionPer1K float64 `json:"completion_per_1k"`
+	Currency        string  `json:"currency,omitempty"`
+}
+
+// Cost returns the price of the given prompt and completion token counts.
+func (c *PricingConfig) Cost(promptTokens, completionTokens int) float64 {
+	return float64(promptTokens)/1000*c.PromptPer1K +
+		float64(completionTokens)/1000*c.CompletionPer1K
+}
+
+// Merge combines the source PricingConfig into this PricingConfig.
+// Positive prices and a non-empty currency from source override the current values.
+func (c *PricingConfig) Merge(source *PricingConfig) {
+	if source.PromptPer1K > 0 {
+		c.PromptPer1K = source.PromptPer1K
+	}
+
+	if source.CompletionPer1K > 0 {
+		c.CompletionPer1K = source.CompletionPer1K
+	}
+
+	if source.Currency != "" {
+		c.Currency = source.Currency
+	}
+}
+
+// QuotaConfig defines usage limits for an agent, used for rate limiting.
+// Limits apply per Window; zero limits are not enforced.
+//
+// Example JSON:
+//
+//	"quota": {
+//	  "window": "1m",
+//	  "max_requests": 60,
+//	  "max_tokens": 90000
+//	}
+type QuotaConfig struct {
+	Window      Duration `json:"window"`
+	MaxRequests int      `json:"max_requests,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+}
+
+// Merge combines the source QuotaConfig into this QuotaConfig.
+// Positive values from source override the current values.
+func (c *QuotaConfig) Merge(source *QuotaConfig) {
+	if source.Window > 0 {
+		c.Window = source.Window
+	}
+
+	if source.MaxRequests > 0 {
+		c.MaxRequests = source.MaxRequests
+	}
+
+	if source.MaxTokens > 0 {
+		c.MaxTokens = source.MaxTokens
+	}
+}
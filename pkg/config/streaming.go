@@ -0,0 +1,70 @@
+package config
+
+// StreamingConfig groups the client's streaming behavior under the client's
+// "streaming" block:
+//
+//   - BufferSize: chunks the stream channel buffers ahead of the consumer
+//     (default 0, unbuffered)
+//   - IdleTimeout: abort streams that receive no data for that long
+//   - Heartbeat: emit heartbeat chunks at that interval while a stream is idle
+//   - IncludeUsage: ask for a final usage chunk (stream_options.include_usage)
+//     unless a request sets stream_options itself (see IncludeUsageEnabled)
+//   - ParseMode: parse strictness for streams ("lenient" or "strict"); strict
+//     surfaces malformed chunks as error chunks. Empty uses the response
+//     package default; the client's parse_mode applies to non-streaming
+//     responses only.
+type StreamingConfig struct {
+	BufferSize   int      `json:"buffer_size,omitempty"`
+	IdleTimeout  Duration `json:"idle_timeout,omitempty"`
+	Heartbeat    Duration `json:"heartbeat,omitempty"`
+	IncludeUsage *bool    `json:"include_usage,omitempty"`
+	ParseMode    string   `json:"parse_mode,omitempty"`
+}
+
+// IncludeUsageEnabled reports whether streams ask for a final usage chunk.
+// A nil IncludeUsage disables it.
+func (s StreamingConfig) IncludeUsageEnabled() bool {
+	return s.IncludeUsage != nil && *s.IncludeUsage
+}
+
+// Merge combines the source StreamingConfig into this StreamingConfig.
+// Positive and non-empty values from source override the current values;
+// IncludeUsage is taken whenever source sets it, including false.
+func (s *StreamingConfig) Merge(source *StreamingConfig) {
+	if source.BufferSize > 0 {
+		s.BufferSize = source.BufferSize
+	}
+
+	if source.IdleTimeout > 0 {
+		s.IdleTimeout = source.IdleTimeout
+	}
+
+	if source.Heartbeat > 0 {
+		s.Heartbeat = source.Heartbeat
+	}
+
+	if source.IncludeUsage != nil {
+		includeUsage := *source.IncludeUsage
+		s.IncludeUsage = &includeUsage
+	}
+
+	if source.ParseMode != "" {
+		s.ParseMode = source.ParseMode
+	}
+}
+
+// ResolvedStreaming returns the streaming settings in effect: the streaming
+// block, with an unset idle timeout and heartbeat taken from the deprecated
+// StreamIdleTimeout and StreamHeartbeat. Loaded configs already hold them in
+// the block (see ClientConfig.Merge); the fallback covers configs built in
+// code.
+func (c *ClientConfig) ResolvedStreaming() StreamingConfig {
+	s := c.Streaming
+	if s.IdleTimeout == 0 {
+		s.IdleTimeout = c.StreamIdleTimeout
+	}
+	if s.Heartbeat == 0 {
+		s.Heartbeat = c.StreamHeartbeat
+	}
+	return s
+}
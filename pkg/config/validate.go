@@ -0,0 +1,217 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// ValidationError describes a single invalid config field.
+// Field is the JSON path of the field (e.g., "provider.base_url").
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Validate checks the config for missing required fields, malformed URLs,
+// out-of-range client and quota settings, unknown protocols, and routes to
+// undefined aliases. Provider-specific options are checked when the provider
+// is created, not here.
+// Returns nil if the config is valid, otherwise an error joining one
+// *ValidationError per problem in field order.
+func (c *AgentConfig) Validate() error {
+	v := &validator{}
+
+	if c.Provider == nil {
+		v.fail("provider", "is required")
+	} else {
+		v.provider("provider", c.Provider, true)
+	}
+
+	if c.Model == nil {
+		v.fail("model", "is required")
+	} else {
+		v.model("model", c.Model, true)
+	}
+
+	if c.Client != nil {
+		v.client(c.Client)
+	}
+
+	if c.Quota != nil {
+		if c.Quota.Window < 0 {
+			v.fail("quota.window", "must not be negative")
+		}
+		if c.Quota.MaxRequests < 0 {
+			v.fail("quota.max_requests", "must not be negative")
+		}
+		if c.Quota.MaxTokens < 0 {
+			v.fail("quota.max_tokens", "must not be negative")
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.Aliases)) {
+		alias := c.Aliases[name]
+		field := "aliases." + name
+		if alias == nil {
+			v.fail(field, "must not be null")
+			continue
+		}
+		if alias.Provider != nil {
+			v.provider(field+".provider", alias.Provider, false)
+		}
+		if alias.Model == nil {
+			v.fail(field+".model", "is required")
+		} else {
+			v.model(field+".model", alias.Model, true)
+		}
+	}
+
+	for _, proto := range slices.Sorted(maps.Keys(c.Routes)) {
+		field := "routes." + proto
+		if !protocol.IsValid(proto) {
+			v.fail(field, fmt.Sprintf("unknown protocol %q (expected one of %v)", proto, protocol.ValidProtocols()))
+		}
+		if _, ok := c.Aliases[c.Routes[proto]]; !ok {
+			v.fail(field, fmt.Sprintf("alias %q is not defined", c.Routes[proto]))
+		}
+	}
+
+	if c.DeprecatedModels != "" && !c.DeprecatedModels.IsValid() {
+		v.fail("deprecated_models", fmt.Sprintf("unknown policy %q (expected warn, rewrite, error, or ignore)", c.DeprecatedModels))
+	}
+
+	return errors.Join(v.errs...)
+}
+
+// validator collects validation errors.
+type validator struct {
+	errs []error
+}
+
+func (v *validator) fail(field, message string) {
+	v.errs = append(v.errs, &ValidationError{Field: field, Message: message})
+}
+
+// provider checks a provider config. Alias providers inherit unset fields
+// from the agent provider, so their name and base URL are optional.
+func (v *validator) provider(field string, c *ProviderConfig, required bool) {
+	if c.Name == "" && required {
+		v.fail(field+".name", "is required")
+	}
+
+	if c.BaseURL == "" {
+		if required {
+			v.fail(field+".base_url", "is required")
+		}
+		return
+	}
+
+	u, err := url.Parse(c.BaseURL)
+	switch {
+	case err != nil:
+		v.fail(field+".base_url", fmt.Sprintf("is not a valid URL: %v", err))
+	case u.Scheme != "http" && u.Scheme != "https":
+		v.fail(field+".base_url", fmt.Sprintf("must use http or https, got %q", c.BaseURL))
+	case u.Host == "":
+		v.fail(field+".base_url", fmt.Sprintf("must include a host, got %q", c.BaseURL))
+	}
+}
+
+// model checks a model config's name, capability protocols, and limits.
+func (v *validator) model(field string, c *ModelConfig, required bool) {
+	if c.Name == "" && required {
+		v.fail(field+".name", "is required")
+	}
+
+	for _, proto := range slices.Sorted(maps.Keys(c.Capabilities)) {
+		if !protocol.IsValid(proto) {
+			v.fail(field+".capabilities."+proto, fmt.Sprintf("unknown protocol (expected one of %v)", protocol.ValidProtocols()))
+		}
+	}
+
+	if c.ContextWindow < 0 {
+		v.fail(field+".context_window", "must not be negative")
+	}
+	if c.MaxOutputTokens < 0 {
+		v.fail(field+".max_output_tokens", "must not be negative")
+	}
+	if c.ContextWindow > 0 && c.MaxOutputTokens > c.ContextWindow && !slices.Contains(c.Advisory, LimitContextWindow) {
+		v.fail(field+".max_output_tokens", fmt.Sprintf("%d exceeds context_window %d", c.MaxOutputTokens, c.ContextWindow))
+	}
+
+	if c.Pricing != nil && (c.Pricing.PromptPer1K < 0 || c.Pricing.CompletionPer1K < 0) {
+		v.fail(field+".pricing", "costs must not be negative")
+	}
+}
+
+// client checks timeouts, pool size, retry settings, and mode names.
+func (v *validator) client(c *ClientConfig) {
+	if c.Timeout <= 0 {
+		v.fail("client.timeout", "must be positive")
+	}
+	if c.ConnectionTimeout < 0 {
+		v.fail("client.connection_timeout", "must not be negative")
+	}
+	if c.StreamIdleTimeout < 0 {
+		v.fail("client.stream_idle_timeout", "must not be negative")
+	}
+	if c.StreamHeartbeat < 0 {
+		v.fail("client.stream_heartbeat", "must not be negative")
+	}
+	if c.StreamFirstByteTimeout < 0 {
+		v.fail("client.stream_first_byte_timeout", "must not be negative")
+	}
+	if c.StreamSendTimeout < 0 {
+		v.fail("client.stream_send_timeout", "must not be negative")
+	}
+	if c.ConnectionPoolSize < 0 {
+		v.fail("client.connection_pool_size", "must not be negative")
+	}
+
+	retry := c.Retry
+	if retry.MaxRetries < 0 {
+		v.fail("client.retry.max_retries", "must not be negative")
+	}
+	if retry.MaxRetries > 0 {
+		if retry.InitialBackoff < 0 {
+			v.fail("client.retry.initial_backoff", "must not be negative")
+		}
+		if retry.MaxBackoff > 0 && retry.InitialBackoff > retry.MaxBackoff {
+			v.fail("client.retry.initial_backoff", "must not exceed max_backoff")
+		}
+		if retry.BackoffMultiplier != 0 && retry.BackoffMultiplier < 1 {
+			v.fail("client.retry.backoff_multiplier", "must be at least 1")
+		}
+	}
+
+	if !slices.Contains([]string{"", "lenient", "strict"}, c.ParseMode) {
+		v.fail("client.parse_mode", fmt.Sprintf("unknown mode %q (expected lenient or strict)", c.ParseMode))
+	}
+
+	streaming := c.Streaming
+	if streaming.BufferSize < 0 {
+		v.fail("client.streaming.buffer_size", "must not be negative")
+	}
+	if streaming.IdleTimeout < 0 {
+		v.fail("client.streaming.idle_timeout", "must not be negative")
+	}
+	if streaming.Heartbeat < 0 {
+		v.fail("client.streaming.heartbeat", "must not be negative")
+	}
+	if !slices.Contains([]string{"", "lenient", "strict"}, streaming.ParseMode) {
+		v.fail("client.streaming.parse_mode", fmt.Sprintf("unknown mode %q (expected lenient or strict)", streaming.ParseMode))
+	}
+
+	if !slices.Contains([]string{"", "strict", "lenient", "off"}, c.OptionValidation) {
+		v.fail("client.option_validation", fmt.Sprintf("unknown mode %q (expected strict, lenient, or off)", c.OptionValidation))
+	}
+}
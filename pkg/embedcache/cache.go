@@ -0,0 +1,141 @@
+package embedcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Store holds embedding vectors by cache key.
+// Memory and Disk are the built-in implementations; external key-value stores
+// (e.g., bbolt, Badger, Redis) implement this interface to back a cache.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the vector stored under key and whether it was found.
+	Get(ctx context.Context, key string) ([]float64, bool, error)
+
+	// Put stores a vector under key, replacing any existing vector.
+	Put(ctx context.Context, key string, vector []float64) error
+}
+
+// Key returns the content-addressed cache key for an input embedded by model
+// with the given request options: the hex SHA-256 digest of all three.
+// Options that change the vector (e.g., "dimensions") therefore produce
+// distinct keys.
+func Key(model, input string, options map[string]any) string {
+	h := sha256.New()
+	h.Write([]byte(model))
+	h.Write([]byte{0})
+	if len(options) > 0 {
+		// Map keys are sorted when marshaled, so equal options hash equally
+		data, _ := json.Marshal(options)
+		h.Write(data)
+	}
+	h.Write([]byte{0})
+	h.Write([]byte(input))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Option configures a caching agent.
+type Option func(*cacheAgent)
+
+// WithModelName sets the model name used in cache keys (default the agent's
+// model name). Set it when embeddings are routed to a model alias, so keys
+// follow the model that actually produces the vectors.
+func WithModelName(name string) Option {
+	return func(a *cacheAgent) {
+		a.modelName = name
+	}
+}
+
+// WithErrorHandler sets a function called with store errors. Store errors
+// never fail an Embed call: a failed lookup is treated as a miss and a failed
+// write leaves the input uncached. By default they are ignored.
+func WithErrorHandler(handler func(error)) Option {
+	return func(a *cacheAgent) {
+		a.onError = handler
+	}
+}
+
+// cacheAgent decorates an Agent, serving Embed calls from a Store.
+type cacheAgent struct {
+	agent.Agent
+	store     Store
+	modelName string
+	onError   func(error)
+}
+
+// Wrap returns an Agent whose Embed calls are served from store when the
+// same input was embedded before by the same model with the same options.
+// Hits skip the provider entirely and return a response without usage;
+// misses call the wrapped agent and store the resulting vector. All other
+// methods pass through unchanged.
+func Wrap(a agent.Agent, store Store, opts ...Option) agent.Agent {
+	cached := &cacheAgent{Agent: a, store: store}
+	for _, opt := range opts {
+		opt(cached)
+	}
+	return cached
+}
+
+func (a *cacheAgent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
+	model := a.model()
+
+	// Keys cover the options actually sent: the agent's default embeddings
+	// options overridden by runtime options
+	options := a.DefaultOptions(protocol.Embeddings)
+	if len(opts) > 0 && opts[0] != nil {
+		if options == nil {
+			options = make(map[string]any)
+		}
+		maps.Copy(options, opts[0])
+	}
+	key := Key(model, input, options)
+
+	vector, ok, err := a.store.Get(ctx, key)
+	if err != nil {
+		a.fail(err)
+	}
+	if err == nil && ok {
+		return &response.EmbeddingsResponse{
+			Object: "list",
+			Model:  model,
+			Data: []response.EmbeddingData{{
+				Embedding: vector,
+				Index:     0,
+				Object:    "embedding",
+			}},
+		}, nil
+	}
+
+	resp, err := a.Agent.Embed(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data) > 0 && len(resp.Data[0].Embedding) > 0 {
+		if err := a.store.Put(ctx, key, resp.Data[0].Embedding); err != nil {
+			a.fail(err)
+		}
+	}
+	return resp, nil
+}
+
+func (a *cacheAgent) model() string {
+	if a.modelName != "" {
+		return a.modelName
+	}
+	return a.Model().Name
+}
+
+func (a *cacheAgent) fail(err error) {
+	if a.onError != nil {
+		a.onError(err)
+	}
+}
@@ -0,0 +1,94 @@
+package embedcache
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// Disk is a Store that keeps one file per vector under a directory, so a
+// cache survives restarts and can be shared by processes on the same host.
+// Files are sharded into subdirectories by the first two characters of the
+// key and hold the vector as little-endian float64 values. Writes are atomic,
+// so concurrent readers never see a partial vector.
+type Disk struct {
+	dir string
+}
+
+// NewDisk creates a disk store rooted at dir, creating it if needed.
+func NewDisk(dir string) (*Disk, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &Disk{dir: dir}, nil
+}
+
+// Get reads the vector stored under key.
+func (d *Disk) Get(ctx context.Context, key string) ([]float64, bool, error) {
+	path, err := d.path(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if len(data)%8 != 0 {
+		return nil, false, fmt.Errorf("corrupt cache entry %s: %d bytes", key, len(data))
+	}
+
+	vector := make([]float64, len(data)/8)
+	for i := range vector {
+		vector[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return vector, true, nil
+}
+
+// Put writes the vector under key, replacing any existing file atomically.
+func (d *Disk) Put(ctx context.Context, key string, vector []float64) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data := make([]byte, 0, len(vector)*8)
+	for _, value := range vector {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(value))
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// path returns the file for a key. Keys must be file-name safe and at least
+// two characters, which holds for keys produced by Key.
+func (d *Disk) path(key string) (string, error) {
+	if len(key) < 2 || !filepath.IsLocal(key) || filepath.Base(key) != key {
+		return "", fmt.Errorf("invalid cache key %q", key)
+	}
+	return filepath.Join(d.dir, key[:2], key), nil
+}
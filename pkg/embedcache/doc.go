@@ -0,0 +1,21 @@
+// Package embedcache caches embedding vectors by content, so re-indexing
+// unchanged documents skips provider calls entirely. Vectors are keyed by
+// the SHA-256 digest of the model name, the embeddings options, and the input
+// text; Wrap decorates an agent.Agent so Embed calls are served from a Store
+// when the key is present.
+//
+// Cache embeddings on disk across runs:
+//
+//	store, err := embedcache.NewDisk(".cache/embeddings")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	a = embedcache.Wrap(a, store)
+//
+//	// Only new or changed documents reach the provider
+//	err = vectorstore.Index(ctx, a, vs, docs)
+//
+// Memory is an in-memory LRU store bounded by entry count. Other key-value
+// stores (bbolt, Badger, Redis) implement Store. When embeddings are routed
+// to a model alias, set WithModelName so keys follow the embedding model.
+package embedcache
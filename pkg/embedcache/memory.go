@@ -0,0 +1,76 @@
+package embedcache
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+)
+
+// Memory is an in-memory Store that evicts the least recently used vector
+// once it holds capacity entries. Thread-safe for concurrent use.
+type Memory struct {
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	mu       sync.Mutex
+}
+
+// memoryEntry is a cached vector in the LRU list.
+type memoryEntry struct {
+	key    string
+	vector []float64
+}
+
+// NewMemory creates an in-memory LRU store holding up to capacity vectors.
+// A capacity of zero or less disables eviction.
+func NewMemory(capacity int) *Memory {
+	return &Memory{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns a copy of the vector stored under key and marks it as
+// recently used.
+func (m *Memory) Get(ctx context.Context, key string) ([]float64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	m.order.MoveToFront(elem)
+	return slices.Clone(elem.Value.(*memoryEntry).vector), true, nil
+}
+
+// Put stores a copy of vector under key, evicting the least recently used
+// vector if the store is full.
+func (m *Memory) Put(ctx context.Context, key string, vector []float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memoryEntry).vector = slices.Clone(vector)
+		m.order.MoveToFront(elem)
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, vector: slices.Clone(vector)})
+
+	if m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of cached vectors.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
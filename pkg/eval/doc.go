@@ -0,0 +1,25 @@
+// Package eval provides an evaluation harness for comparing agents across
+// providers and models. Run sends a suite of prompts to each agent and records
+// responses, latency, token usage, and optional scores; reports are written
+// as JSON or CSV.
+//
+// Compare two agents with exact-match scoring:
+//
+//	cases := []eval.Case{
+//	    {Name: "capital", Prompt: "What is the capital of France? Answer in one word.", Expected: "Paris"},
+//	}
+//
+//	report, err := eval.Run(ctx, cases, []agent.Agent{ollama, azure}, eval.WithScorer(eval.Contains()))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	report.WriteCSV(os.Stdout)
+//
+// Score open-ended answers with an LLM judge:
+//
+//	eval.WithScorer(eval.Judge(judge, "Penalize answers longer than three sentences."))
+//
+// WithDryRun substitutes stand-in agents, such as mocks that return each
+// case's expected answer, validating a suite and its reporting before
+// spending provider quota.
+package eval
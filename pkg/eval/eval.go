@@ -0,0 +1,163 @@
+package eval
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Case is a single prompt in an evaluation suite.
+// Expected is the reference answer used by scorers; Options are passed to
+// each agent's Chat call.
+type Case struct {
+	Name     string         `json:"name"`
+	Prompt   string         `json:"prompt"`
+	Expected string         `json:"expected,omitempty"`
+	Options  map[string]any `json:"options,omitempty"`
+}
+
+// Result records one agent's response to one case.
+// Score is nil when no scorer is configured or the case failed.
+type Result struct {
+	Case     string               `json:"case"`
+	Agent    string               `json:"agent"`
+	Provider string               `json:"provider"`
+	Model    string               `json:"model"`
+	Output   string               `json:"output"`
+	Latency  time.Duration        `json:"latency_ns"`
+	Usage    *response.TokenUsage `json:"usage,omitempty"`
+	Score    *float64             `json:"score,omitempty"`
+	Error    string               `json:"error,omitempty"`
+}
+
+// Option configures a Run.
+type Option func(*runConfig)
+
+type runConfig struct {
+	scorer      Scorer
+	concurrency int
+	standIn     func(agent.Agent, Case) agent.Agent
+}
+
+// WithScorer scores each successful response.
+func WithScorer(scorer Scorer) Option {
+	return func(c *runConfig) {
+		c.scorer = scorer
+	}
+}
+
+// WithConcurrency sets how many case/agent pairs run at once (default 1).
+// Sequential runs give the most comparable latencies.
+func WithConcurrency(n int) Option {
+	return func(c *runConfig) {
+		c.concurrency = n
+	}
+}
+
+// WithDryRun replaces each agent with the agent standIn returns for a case,
+// exercising suites, scorers, and reports without calling providers. Results
+// keep the replaced agent's ID, provider, and model. Scorers still run, so
+// use a mock judge for a fully offline run. For example, with pkg/mock:
+//
+//	eval.WithDryRun(func(a agent.Agent, c eval.Case) agent.Agent {
+//	    return mock.NewSimpleChatAgent(a.ID(), c.Expected)
+//	})
+func WithDryRun(standIn func(a agent.Agent, c Case) agent.Agent) Option {
+	return func(c *runConfig) {
+		c.standIn = standIn
+	}
+}
+
+// Run executes every case against every agent and returns the report.
+// Case failures are recorded in their Result rather than stopping the run.
+// Results are ordered by case, then by agent, regardless of concurrency.
+// If ctx is cancelled, the report holds only the results that completed and
+// is returned with ctx.Err().
+func Run(ctx context.Context, cases []Case, agents []agent.Agent, opts ...Option) (*Report, error) {
+	cfg := runConfig{concurrency: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.concurrency = max(cfg.concurrency, 1)
+
+	report := &Report{
+		Started: time.Now(),
+		Results: make([]Result, len(cases)*len(agents)),
+	}
+
+	done := make([]bool, len(report.Results))
+	sem := make(chan struct{}, cfg.concurrency)
+	var wg sync.WaitGroup
+
+schedule:
+	for i, c := range cases {
+		for j, a := range agents {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				break schedule
+			}
+
+			wg.Go(func() {
+				defer func() { <-sem }()
+				k := i*len(agents) + j
+				report.Results[k] = run(ctx, c, a, cfg)
+				done[k] = true
+			})
+		}
+	}
+
+	wg.Wait()
+	report.Duration = time.Since(report.Started)
+
+	if err := ctx.Err(); err != nil {
+		completed := report.Results[:0]
+		for k, result := range report.Results {
+			if done[k] {
+				completed = append(completed, result)
+			}
+		}
+		report.Results = completed
+		return report, err
+	}
+	return report, nil
+}
+
+// run executes and scores a single case against a single agent.
+func run(ctx context.Context, c Case, a agent.Agent, cfg runConfig) Result {
+	result := Result{
+		Case:     c.Name,
+		Agent:    a.ID(),
+		Provider: a.Provider().Name(),
+		Model:    a.Model().Name,
+	}
+
+	if cfg.standIn != nil {
+		a = cfg.standIn(a, c)
+	}
+
+	start := time.Now()
+	resp, err := a.Chat(ctx, c.Prompt, c.Options)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Output = resp.Content()
+	result.Usage = resp.Usage
+
+	if cfg.scorer != nil {
+		score, err := cfg.scorer(ctx, c, result.Output)
+		if err != nil {
+			result.Error = "scoring failed: " + err.Error()
+			return result
+		}
+		result.Score = &score
+	}
+
+	return result
+}
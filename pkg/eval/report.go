@@ -0,0 +1,136 @@
+package eval
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Report holds the results of a Run.
+type Report struct {
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration_ns"`
+	Results  []Result      `json:"results"`
+}
+
+// Summary aggregates an agent's results across all cases.
+// MeanScore averages only scored results.
+type Summary struct {
+	Agent       string        `json:"agent"`
+	Provider    string        `json:"provider"`
+	Model       string        `json:"model"`
+	Cases       int           `json:"cases"`
+	Errors      int           `json:"errors"`
+	MeanLatency time.Duration `json:"mean_latency_ns"`
+	TotalTokens int           `json:"total_tokens"`
+	Scored      int           `json:"scored"`
+	MeanScore   float64       `json:"mean_score"`
+}
+
+// Summaries returns one summary per agent in the order agents were given to Run.
+func (r *Report) Summaries() []Summary {
+	var summaries []Summary
+	var latencies []time.Duration
+	var scores []float64
+	index := make(map[string]int)
+
+	for _, result := range r.Results {
+		i, ok := index[result.Agent]
+		if !ok {
+			i = len(summaries)
+			index[result.Agent] = i
+			summaries = append(summaries, Summary{
+				Agent:    result.Agent,
+				Provider: result.Provider,
+				Model:    result.Model,
+			})
+			latencies = append(latencies, 0)
+			scores = append(scores, 0)
+		}
+
+		s := &summaries[i]
+		s.Cases++
+		latencies[i] += result.Latency
+		if result.Error != "" {
+			s.Errors++
+		}
+		if result.Usage != nil {
+			s.TotalTokens += result.Usage.TotalTokens
+		}
+		if result.Score != nil {
+			s.Scored++
+			scores[i] += *result.Score
+		}
+	}
+
+	for i := range summaries {
+		s := &summaries[i]
+		s.MeanLatency = latencies[i] / time.Duration(s.Cases)
+		if s.Scored > 0 {
+			s.MeanScore = scores[i] / float64(s.Scored)
+		}
+	}
+
+	return summaries
+}
+
+// WriteJSON writes the results and per-agent summaries as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	out := struct {
+		*Report
+		Summaries []Summary `json:"summaries"`
+	}{r, r.Summaries()}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+// csvHeader lists the WriteCSV columns.
+var csvHeader = []string{
+	"case", "agent", "provider", "model", "latency_ms",
+	"prompt_tokens", "completion_tokens", "total_tokens",
+	"score", "error", "output",
+}
+
+// WriteCSV writes one row per result with a header row.
+// Token and score columns are empty when not reported.
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write(csvHeader)
+
+	for _, result := range r.Results {
+		row := []string{
+			result.Case,
+			result.Agent,
+			result.Provider,
+			result.Model,
+			strconv.FormatInt(result.Latency.Milliseconds(), 10),
+			"", "", "",
+			"",
+			result.Error,
+			result.Output,
+		}
+		if result.Usage != nil {
+			row[5] = strconv.Itoa(result.Usage.PromptTokens)
+			row[6] = strconv.Itoa(result.Usage.CompletionTokens)
+			row[7] = strconv.Itoa(result.Usage.TotalTokens)
+		}
+		if result.Score != nil {
+			row[8] = strconv.FormatFloat(*result.Score, 'f', -1, 64)
+		}
+		writer.Write(row)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
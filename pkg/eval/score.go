@@ -0,0 +1,76 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+)
+
+// Scorer rates an output for a case between 0 (worst) and 1 (best).
+type Scorer func(ctx context.Context, c Case, output string) (float64, error)
+
+// ExactMatch scores 1 when the output equals the expected answer after
+// trimming surrounding whitespace, and 0 otherwise.
+func ExactMatch() Scorer {
+	return func(ctx context.Context, c Case, output string) (float64, error) {
+		if strings.TrimSpace(output) == strings.TrimSpace(c.Expected) {
+			return 1, nil
+		}
+		return 0, nil
+	}
+}
+
+// Contains scores 1 when the output contains the expected answer,
+// ignoring case, and 0 otherwise.
+func Contains() Scorer {
+	return func(ctx context.Context, c Case, output string) (float64, error) {
+		if strings.Contains(strings.ToLower(output), strings.ToLower(strings.TrimSpace(c.Expected))) {
+			return 1, nil
+		}
+		return 0, nil
+	}
+}
+
+// judgePrompt asks the judge model for a 0-10 rating.
+const judgePrompt = `You are grading a model's answer to a prompt.
+
+Prompt:
+%s
+
+Reference answer:
+%s
+
+Model answer:
+%s
+
+%s
+Rate the model answer from 0 (wrong or unhelpful) to 10 (fully correct and complete). Respond with only the number.`
+
+var judgeScore = regexp.MustCompile(`\d+(\.\d+)?`)
+
+// Judge scores outputs with an LLM-as-judge: the judge agent rates each
+// answer from 0 to 10 against the prompt and reference answer, and the rating
+// is normalized to 0-1. Rubric adds grading instructions and may be empty.
+// Returns an error if the judge's reply contains no rating.
+func Judge(judge agent.Chatter, rubric string) Scorer {
+	return func(ctx context.Context, c Case, output string) (float64, error) {
+		prompt := fmt.Sprintf(judgePrompt, c.Prompt, c.Expected, output, rubric)
+
+		resp, err := judge.Chat(ctx, prompt)
+		if err != nil {
+			return 0, fmt.Errorf("judge request failed: %w", err)
+		}
+
+		match := judgeScore.FindString(resp.Content())
+		if match == "" {
+			return 0, fmt.Errorf("judge reply contains no rating: %q", resp.Content())
+		}
+
+		rating, _ := strconv.ParseFloat(match, 64)
+		return min(max(rating, 0), 10) / 10, nil
+	}
+}
@@ -0,0 +1,20 @@
+// Package images prepares images for vision requests. It loads images from
+// URLs, data URIs, or files, detects and validates their MIME type, enforces
+// size limits, optionally downscales and converts them, and encodes them as
+// data URIs.
+//
+// Prepare images for agent.Vision:
+//
+//	uris, err := images.Prepare(ctx, []string{"https://example.com/photo.jpg", "~/diagram.png"},
+//	    images.WithMaxDimension(2048),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := a.Vision(ctx, "Describe these images", uris)
+//
+// Downscaling reduces upload size and token cost for providers that bill by
+// image resolution. JPEG, PNG, and GIF can be resized or converted; other
+// image types (e.g., WebP) are passed through unchanged unless processing is
+// requested, which fails with ErrUnsupportedFormat.
+package images
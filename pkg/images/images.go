@@ -0,0 +1,203 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrNotImage indicates content whose detected MIME type is not an image.
+	ErrNotImage = errors.New("not an image")
+
+	// ErrTooLarge indicates a source larger than the configured maximum size.
+	ErrTooLarge = errors.New("image too large")
+
+	// ErrUnsupportedFormat indicates an image that cannot be decoded for
+	// resizing or conversion (e.g., WebP).
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+)
+
+// Image is encoded image data with its MIME type.
+type Image struct {
+	Data     []byte
+	MIMEType string
+}
+
+// DataURI returns the image as a base64 data URI
+// (data:<mime>;base64,<data>), the form accepted by agent.Vision.
+func (img *Image) DataURI() string {
+	return fmt.Sprintf("data:%s;base64,%s", img.MIMEType, base64.StdEncoding.EncodeToString(img.Data))
+}
+
+// ParseDataURI decodes a base64 data URI into an Image.
+// Returns an error if the URI is malformed or not base64-encoded.
+func ParseDataURI(uri string) (*Image, error) {
+	rest, ok := strings.CutPrefix(uri, "data:")
+	if !ok {
+		return nil, errors.New("invalid data URI: missing data: prefix")
+	}
+
+	meta, encoded, ok := strings.Cut(rest, ",")
+	if !ok {
+		return nil, errors.New("invalid data URI: missing data")
+	}
+
+	mimeType, ok := strings.CutSuffix(meta, ";base64")
+	if !ok {
+		return nil, errors.New("invalid data URI: only base64 encoding is supported")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data URI: %w", err)
+	}
+
+	return &Image{Data: data, MIMEType: mimeType}, nil
+}
+
+// IsURL returns true for http and https URLs.
+func IsURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// Load reads an image from a URL, data URI, or file path ("~/" expands to
+// the home directory), validates it, and applies any processing options.
+func Load(ctx context.Context, source string, opts ...Option) (*Image, error) {
+	cfg := newConfig(opts)
+
+	var data []byte
+	var err error
+	switch {
+	case IsURL(source):
+		data, err = download(ctx, source, cfg)
+	case strings.HasPrefix(source, "data:"):
+		var img *Image
+		if img, err = ParseDataURI(source); err == nil {
+			data = img.Data
+			if cfg.maxBytes > 0 && int64(len(data)) > cfg.maxBytes {
+				err = fmt.Errorf("%w: %d bytes exceeds %d", ErrTooLarge, len(data), cfg.maxBytes)
+			}
+		}
+	default:
+		data, err = readFile(source, cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return process(data, cfg)
+}
+
+// FromBytes validates raw image data, detects its MIME type, and applies any
+// processing options.
+func FromBytes(data []byte, opts ...Option) (*Image, error) {
+	cfg := newConfig(opts)
+	if cfg.maxBytes > 0 && int64(len(data)) > cfg.maxBytes {
+		return nil, fmt.Errorf("%w: %d bytes exceeds %d", ErrTooLarge, len(data), cfg.maxBytes)
+	}
+	return process(data, cfg)
+}
+
+// Prepare loads each source and returns data URIs ready for agent.Vision.
+// Remote images are downloaded and inlined because some providers only
+// accept base64 data.
+func Prepare(ctx context.Context, sources []string, opts ...Option) ([]string, error) {
+	prepared := make([]string, len(sources))
+	for i, source := range sources {
+		img, err := Load(ctx, source, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("image %s: %w", source, err)
+		}
+		prepared[i] = img.DataURI()
+	}
+	return prepared, nil
+}
+
+// download fetches a URL, reading at most the configured maximum size.
+func download(ctx context.Context, url string, cfg *imageConfig) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := cfg.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed with status %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	return readLimited(resp.Body, cfg.maxBytes)
+}
+
+// readFile reads a local file, expanding a leading "~/" to the home directory.
+func readFile(path string, cfg *imageConfig) ([]byte, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = home + "/" + rest
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	defer f.Close()
+
+	return readLimited(f, cfg.maxBytes)
+}
+
+// readLimited reads r, failing with ErrTooLarge past limit bytes.
+// A limit of zero or less reads everything.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", ErrTooLarge, limit)
+	}
+	return data, nil
+}
+
+// detect returns the MIME type of image data.
+// Returns ErrNotImage if the content is not an image.
+func detect(data []byte) (string, error) {
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return "", fmt.Errorf("%w: detected type %s", ErrNotImage, mimeType)
+	}
+	return mimeType, nil
+}
+
+// process detects the MIME type and applies resizing and format conversion.
+// Images are re-encoded only when they are resized or converted.
+func process(data []byte, cfg *imageConfig) (*Image, error) {
+	mimeType, err := detect(data)
+	if err != nil {
+		return nil, err
+	}
+
+	img := &Image{Data: data, MIMEType: mimeType}
+	if cfg.maxDimension <= 0 && cfg.format == "" {
+		return img, nil
+	}
+
+	return transform(bytes.NewReader(data), img, cfg)
+}
@@ -0,0 +1,77 @@
+package images
+
+import "net/http"
+
+// DefaultMaxBytes is the default maximum source size (20 MB), the largest
+// image most hosted vision APIs accept.
+const DefaultMaxBytes = 20 << 20
+
+// Format is an output image encoding.
+type Format string
+
+const (
+	FormatJPEG Format = "jpeg"
+	FormatPNG  Format = "png"
+)
+
+// Option configures image loading and processing.
+type Option func(*imageConfig)
+
+type imageConfig struct {
+	maxBytes     int64
+	maxDimension int
+	format       Format
+	quality      int
+	httpClient   *http.Client
+}
+
+func newConfig(opts []Option) *imageConfig {
+	cfg := &imageConfig{
+		maxBytes:   DefaultMaxBytes,
+		quality:    85,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithMaxBytes limits the size of source data (default DefaultMaxBytes).
+// Larger sources fail with ErrTooLarge. Zero or less disables the limit.
+func WithMaxBytes(n int64) Option {
+	return func(c *imageConfig) {
+		c.maxBytes = n
+	}
+}
+
+// WithMaxDimension downscales images whose width or height exceeds px,
+// preserving the aspect ratio. Smaller images are left unchanged.
+func WithMaxDimension(px int) Option {
+	return func(c *imageConfig) {
+		c.maxDimension = px
+	}
+}
+
+// WithFormat re-encodes images in the given format.
+// Transparent areas are flattened onto white when converting to JPEG.
+func WithFormat(format Format) Option {
+	return func(c *imageConfig) {
+		c.format = format
+	}
+}
+
+// WithJPEGQuality sets the JPEG encoding quality from 1 to 100 (default 85).
+func WithJPEGQuality(quality int) Option {
+	return func(c *imageConfig) {
+		c.quality = quality
+	}
+}
+
+// WithHTTPClient sets the client used to download remote images
+// (default http.DefaultClient).
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *imageConfig) {
+		c.httpClient = client
+	}
+}
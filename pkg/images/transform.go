@@ -0,0 +1,110 @@
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	// Register the GIF decoder for image.Decode.
+	_ "image/gif"
+)
+
+// transform decodes an image, downscales it to the configured maximum
+// dimension, and re-encodes it. JPEG sources stay JPEG and other formats
+// become PNG unless a format is configured.
+func transform(r io.Reader, img *Image, cfg *imageConfig) (*Image, error) {
+	decoded, source, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, img.MIMEType)
+	}
+
+	bounds := decoded.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if longest := max(width, height); cfg.maxDimension > 0 && longest > cfg.maxDimension {
+		width = max(width*cfg.maxDimension/longest, 1)
+		height = max(height*cfg.maxDimension/longest, 1)
+		decoded = downscale(decoded, width, height)
+	} else if cfg.format == "" || cfg.format == Format(source) {
+		return img, nil
+	}
+
+	format := cfg.format
+	if format == "" {
+		format = FormatPNG
+		if source == "jpeg" {
+			format = FormatJPEG
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, flatten(decoded), &jpeg.Options{Quality: cfg.quality})
+	case FormatPNG:
+		err = png.Encode(&buf, decoded)
+	default:
+		return nil, fmt.Errorf("%w: cannot encode %s", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", format, err)
+	}
+
+	return &Image{Data: buf.Bytes(), MIMEType: "image/" + string(format)}, nil
+}
+
+// downscale resizes src to width x height by averaging the source pixels
+// covered by each destination pixel (a box filter), which avoids the
+// aliasing of nearest-neighbor sampling when shrinking.
+func downscale(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	sw, sh := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for dy := range height {
+		y0 := dy * sh / height
+		y1 := max((dy+1)*sh/height, y0+1)
+		for dx := range width {
+			x0 := dx * sw / width
+			x1 := max((dx+1)*sw/width, x0+1)
+
+			var r, g, b, a, n int
+			for y := y0; y < y1; y++ {
+				row := rgba.Pix[y*rgba.Stride:]
+				for x := x0; x < x1; x++ {
+					p := row[x*4 : x*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			o := dy*dst.Stride + dx*4
+			dst.Pix[o] = uint8(r / n)
+			dst.Pix[o+1] = uint8(g / n)
+			dst.Pix[o+2] = uint8(b / n)
+			dst.Pix[o+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// flatten composites an image onto a white background for encoders
+// without alpha support.
+func flatten(src image.Image) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(bounds)
+	draw.Draw(dst, bounds, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, bounds, src, bounds.Min, draw.Over)
+	return dst
+}
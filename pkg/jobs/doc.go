@@ -0,0 +1,28 @@
+// Package jobs provides an asynchronous job queue for long-running requests.
+// Submit returns a job ID immediately while a bounded pool of workers executes
+// requests against a client.Client; callers poll Status and Result, block on
+// Wait, or receive completion callbacks.
+//
+// Offload a slow vision call from a web handler:
+//
+//	q := jobs.New(c, jobs.WithWorkers(4), jobs.WithTimeout(2*time.Minute))
+//	defer q.Close()
+//
+//	id, err := q.Submit(r.Context(), visionReq)
+//	if errors.Is(err, jobs.ErrQueueFull) {
+//	    http.Error(w, "busy", http.StatusServiceUnavailable)
+//	    return
+//	}
+//
+// Poll from a later request:
+//
+//	status, err := q.Status(id)
+//	if status.State == jobs.StateSucceeded {
+//	    result, _ := q.Result(id)
+//	    resp := result.(*response.ChatResponse)
+//	}
+//
+// Jobs are detached from the submitting context's cancellation so they outlive
+// the HTTP request that created them; use Cancel to stop a job. Finished jobs
+// are kept until Remove or until WithRetention expires them.
+package jobs
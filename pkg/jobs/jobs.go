@@ -0,0 +1,437 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+var (
+	// ErrUnknownJob indicates a job ID that was never submitted or has been removed.
+	ErrUnknownJob = errors.New("unknown job")
+
+	// ErrNotFinished indicates a job result was requested before the job finished.
+	ErrNotFinished = errors.New("job not finished")
+
+	// ErrQueueFull indicates the pending job queue is at capacity.
+	ErrQueueFull = errors.New("job queue full")
+
+	// ErrClosed indicates a job was submitted after the queue was closed.
+	ErrClosed = errors.New("job queue closed")
+
+	// ErrCancelled is the error of a job cancelled before it finished.
+	ErrCancelled = errors.New("job cancelled")
+)
+
+// State is the lifecycle state of a job.
+type State string
+
+const (
+	StatePending   State = "pending"
+	StateRunning   State = "running"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed"
+	StateCancelled State = "cancelled"
+)
+
+// Done returns true for terminal states.
+func (s State) Done() bool {
+	return s == StateSucceeded || s == StateFailed || s == StateCancelled
+}
+
+// Status is a snapshot of a job's progress.
+// Err is set for failed and cancelled jobs.
+type Status struct {
+	ID        string
+	State     State
+	Submitted time.Time
+	Started   time.Time
+	Finished  time.Time
+	Err       error
+}
+
+// Callback is invoked once when a job reaches a terminal state, with its final
+// status and result. Callbacks run on the worker goroutine, or on the
+// goroutine calling Cancel or Remove for a job cancelled before it finished.
+type Callback func(status Status, result any)
+
+// job is the internal record of a submitted request.
+type job struct {
+	status    Status
+	req       request.Request
+	ctx       context.Context
+	cancel    context.CancelFunc
+	result    any
+	callbacks []Callback
+	done      chan struct{}
+}
+
+// Queue executes requests asynchronously on a fixed pool of workers.
+// Submitted requests wait in a bounded queue until a worker is free; jobs
+// cancelled while waiting leave the queue at once.
+// Thread-safe for concurrent use.
+type Queue struct {
+	client    client.Client
+	pending   []*job
+	queueSize int
+	idle      int
+	ready     *sync.Cond
+	jobs      map[string]*job
+	timeout   time.Duration
+	retention time.Duration
+	callbacks []Callback
+	closed    bool
+	mu        sync.Mutex
+	wg        sync.WaitGroup
+}
+
+// Option configures a Queue.
+type Option func(*queueConfig)
+
+type queueConfig struct {
+	workers   int
+	queueSize int
+	timeout   time.Duration
+	retention time.Duration
+	callbacks []Callback
+}
+
+// WithWorkers sets how many jobs execute concurrently (default 4).
+func WithWorkers(n int) Option {
+	return func(c *queueConfig) {
+		c.workers = n
+	}
+}
+
+// WithQueueSize sets how many jobs may wait for a worker before Submit
+// returns ErrQueueFull (default 100).
+func WithQueueSize(n int) Option {
+	return func(c *queueConfig) {
+		c.queueSize = n
+	}
+}
+
+// WithTimeout bounds the execution time of each job. Zero means no limit
+// beyond the client's own timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *queueConfig) {
+		c.timeout = d
+	}
+}
+
+// WithRetention removes finished jobs older than d when new jobs are submitted,
+// bounding memory for long-running services. Zero keeps jobs until Remove.
+func WithRetention(d time.Duration) Option {
+	return func(c *queueConfig) {
+		c.retention = d
+	}
+}
+
+// WithCallback adds a callback invoked for every job that finishes.
+func WithCallback(cb Callback) Option {
+	return func(c *queueConfig) {
+		c.callbacks = append(c.callbacks, cb)
+	}
+}
+
+// New creates a Queue that executes requests with the client and starts its workers.
+// Call Close to stop the workers.
+func New(c client.Client, opts ...Option) *Queue {
+	cfg := queueConfig{workers: 4, queueSize: 100}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.workers = max(cfg.workers, 1)
+	cfg.queueSize = max(cfg.queueSize, 0)
+
+	q := &Queue{
+		client:    c,
+		queueSize: cfg.queueSize,
+		jobs:      make(map[string]*job),
+		timeout:   cfg.timeout,
+		retention: cfg.retention,
+		callbacks: cfg.callbacks,
+	}
+	q.ready = sync.NewCond(&q.mu)
+
+	for range cfg.workers {
+		q.wg.Go(q.work)
+	}
+
+	return q
+}
+
+// Submit queues a request and returns its job ID immediately.
+// The job keeps the values of ctx (e.g., trace IDs) but not its cancellation,
+// so it outlives the caller's request; use Cancel to stop it.
+// Callbacks are invoked when this job finishes, after the queue's callbacks.
+// Returns ErrQueueFull if no queue capacity remains or ErrClosed after Close.
+func (q *Queue) Submit(ctx context.Context, req request.Request, callbacks ...Callback) (string, error) {
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	j := &job{
+		status: Status{
+			ID:        uuid.Must(uuid.NewV7()).String(),
+			State:     StatePending,
+			Submitted: time.Now(),
+		},
+		req:       req,
+		ctx:       jobCtx,
+		cancel:    cancel,
+		callbacks: callbacks,
+		done:      make(chan struct{}),
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		cancel()
+		return "", ErrClosed
+	}
+
+	q.purge()
+
+	// Idle workers take a job each, so they add to the waiting capacity
+	if len(q.pending) >= q.queueSize+q.idle {
+		cancel()
+		return "", ErrQueueFull
+	}
+
+	q.pending = append(q.pending, j)
+	q.jobs[j.status.ID] = j
+	q.ready.Signal()
+	return j.status.ID, nil
+}
+
+// Status returns a snapshot of a job's progress.
+func (q *Queue) Status(id string) (Status, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	j, ok := q.jobs[id]
+	if !ok {
+		return Status{}, fmt.Errorf("%w: %s", ErrUnknownJob, id)
+	}
+	return j.status, nil
+}
+
+// Result returns the response of a finished job, which has the same type
+// client.Execute returns for the request's protocol.
+// Returns ErrNotFinished while the job is pending or running, and the job's
+// error if it failed or was cancelled.
+func (q *Queue) Result(id string) (any, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	j, ok := q.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJob, id)
+	}
+	if !j.status.State.Done() {
+		return nil, fmt.Errorf("%w: %s", ErrNotFinished, id)
+	}
+	return j.result, j.status.Err
+}
+
+// Wait blocks until a job finishes or ctx is done, then returns its result.
+// A job removed while Wait is blocked still reports its outcome.
+func (q *Queue) Wait(ctx context.Context, id string) (any, error) {
+	q.mu.Lock()
+	j, ok := q.jobs[id]
+	q.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJob, id)
+	}
+
+	select {
+	case <-j.done:
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return j.result, j.status.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Cancel stops a pending or running job. The job is marked cancelled at
+// once, so Status, Result, and Wait report ErrCancelled without waiting for a
+// worker; a running request is aborted through its context.
+// Cancelling a finished job has no effect.
+func (q *Queue) Cancel(id string) error {
+	return q.stop(id, false)
+}
+
+// Remove forgets a job. Unfinished jobs are cancelled first, as by Cancel,
+// and removed immediately.
+func (q *Queue) Remove(id string) error {
+	return q.stop(id, true)
+}
+
+// stop cancels a job if it has not finished and optionally removes it.
+// A pending job is taken out of the queue, freeing its capacity.
+// Callbacks of a job cancelled here run on the calling goroutine.
+func (q *Queue) stop(id string, remove bool) error {
+	q.mu.Lock()
+	j, ok := q.jobs[id]
+	if !ok {
+		q.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrUnknownJob, id)
+	}
+
+	if j.status.State == StatePending {
+		if i := slices.Index(q.pending, j); i >= 0 {
+			q.pending = slices.Delete(q.pending, i, i+1)
+		}
+	}
+
+	finished := q.finish(j, nil, ErrCancelled)
+	status := j.status
+	if remove {
+		delete(q.jobs, id)
+	}
+	q.mu.Unlock()
+
+	j.cancel()
+	if finished {
+		q.notify(j, status, nil)
+	}
+	return nil
+}
+
+// Close stops accepting jobs and waits for queued and running jobs to finish.
+// Cancel outstanding jobs first to stop them early.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		q.ready.Broadcast()
+	}
+	q.mu.Unlock()
+
+	q.wg.Wait()
+}
+
+// work executes queued jobs until the queue is closed and drained.
+func (q *Queue) work() {
+	for {
+		j, ok := q.next()
+		if !ok {
+			return
+		}
+		q.run(j)
+	}
+}
+
+// next waits for a queued job and takes it off the queue.
+// Returns false once the queue is closed and empty.
+func (q *Queue) next() (*job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.pending) == 0 && !q.closed {
+		q.idle++
+		q.ready.Wait()
+		q.idle--
+	}
+	if len(q.pending) == 0 {
+		return nil, false
+	}
+
+	j := q.pending[0]
+	q.pending[0] = nil
+	q.pending = q.pending[1:]
+	return j, true
+}
+
+// run executes a single job and records its outcome.
+// Jobs cancelled after leaving the queue are skipped.
+func (q *Queue) run(j *job) {
+	ctx := j.ctx
+	if q.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.timeout)
+		defer cancel()
+	}
+
+	q.mu.Lock()
+	if j.status.State.Done() {
+		q.mu.Unlock()
+		return
+	}
+	j.status.State = StateRunning
+	j.status.Started = time.Now()
+	q.mu.Unlock()
+
+	result, err := q.client.Execute(ctx, j.req)
+	if err != nil && errors.Is(j.ctx.Err(), context.Canceled) {
+		err = fmt.Errorf("%w: %w", ErrCancelled, err)
+	}
+
+	q.mu.Lock()
+	finished := q.finish(j, result, err)
+	status := j.status
+	q.mu.Unlock()
+
+	j.cancel()
+	if finished {
+		q.notify(j, status, result)
+	}
+}
+
+// finish records the outcome of a job and releases its waiters.
+// Returns false without changes if the job already finished.
+// Must be called with q.mu held.
+func (q *Queue) finish(j *job, result any, err error) bool {
+	if j.status.State.Done() {
+		return false
+	}
+
+	j.status.Finished = time.Now()
+	switch {
+	case err == nil:
+		j.status.State = StateSucceeded
+		j.result = result
+	case errors.Is(err, ErrCancelled):
+		j.status.State = StateCancelled
+		j.status.Err = err
+	default:
+		j.status.State = StateFailed
+		j.status.Err = err
+	}
+
+	close(j.done)
+	return true
+}
+
+// notify invokes the queue's callbacks and then the job's callbacks.
+func (q *Queue) notify(j *job, status Status, result any) {
+	for _, cb := range q.callbacks {
+		cb(status, result)
+	}
+	for _, cb := range j.callbacks {
+		cb(status, result)
+	}
+}
+
+// purge removes finished jobs older than the retention period.
+// Must be called with q.mu held.
+func (q *Queue) purge() {
+	if q.retention <= 0 {
+		return
+	}
+
+	cutoff := time.Now().Add(-q.retention)
+	for id, j := range q.jobs {
+		if j.status.State.Done() && j.status.Finished.Before(cutoff) {
+			delete(q.jobs, id)
+		}
+	}
+}
@@ -2,9 +2,14 @@ package mock
 
 import (
 	"context"
+	"io"
+	"maps"
+	"slices"
+	"sync"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/agent"
 	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
 	"github.com/tailored-agentic-units/tau-core/pkg/model"
 	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
 	"github.com/tailored-agentic-units/tau-core/pkg/providers"
@@ -26,14 +31,43 @@ type MockAgent struct {
 	embeddingsResponse *response.EmbeddingsResponse
 	embeddingsError    error
 
+	// Scripted protocol responses, returned in call order when set
+	chatSequence       *sequence[*response.ChatResponse]
+	visionSequence     *sequence[*response.ChatResponse]
+	toolsSequence      *sequence[*response.ToolsResponse]
+	embeddingsSequence *sequence[*response.EmbeddingsResponse]
+
 	// Streaming responses
 	streamChunks []response.StreamingChunk
 	streamError  error
+	streamFault  *StreamFault
+
+	// File responses
+	files     []response.File
+	fileError error
+
+	// Prewarm response
+	prewarmError error
+
+	// Protocols reported unsupported by Supports
+	unsupported []protocol.Protocol
+
+	// Failure injection for protocol methods
+	faults *faults
+
+	// Simulated usage and model pricing
+	usage   UsageFunc
+	pricing *config.PricingConfig
 
 	// Dependencies
 	mockClient   client.Client
 	mockProvider providers.Provider
 	mockModel    *model.Model
+
+	// Runtime tuning recorded by SetSystemPrompt and SetDefaultOptions
+	tuning         sync.Mutex
+	systemPrompt   string
+	defaultOptions map[protocol.Protocol]map[string]any
 }
 
 // NewMockAgent creates a new MockAgent with default configuration.
@@ -54,6 +88,12 @@ func NewMockAgent(opts ...MockAgentOption) *MockAgent {
 		opt(m)
 	}
 
+	if m.pricing != nil {
+		priced := *m.mockModel
+		priced.Pricing = m.pricing
+		m.mockModel = &priced
+	}
+
 	return m
 }
 
@@ -107,6 +147,48 @@ func WithStreamChunks(chunks []response.StreamingChunk, err error) MockAgentOpti
 	}
 }
 
+// WithPrewarmError sets the error returned by Prewarm.
+func WithPrewarmError(err error) MockAgentOption {
+	return func(m *MockAgent) {
+		m.prewarmError = err
+	}
+}
+
+// WithUnsupported makes Supports report the protocols as unsupported, to
+// test code that checks capabilities before using them. Protocol methods
+// still return their configured responses.
+func WithUnsupported(protos ...protocol.Protocol) MockAgentOption {
+	return func(m *MockAgent) {
+		m.unsupported = protos
+	}
+}
+
+// WithFiles sets the files returned by ListFiles and the error returned by
+// all file operations.
+func WithFiles(files []response.File, err error) MockAgentOption {
+	return func(m *MockAgent) {
+		m.files = files
+		m.fileError = err
+	}
+}
+
+// WithFault injects failures into the protocol methods (Chat, ChatStream,
+// Vision, VisionStream, Tools, VisionTools, and Embed). Calls are counted
+// across all of them; calls the fault lets through return the configured
+// responses.
+//
+// Example:
+//
+//	agent := mock.NewMockAgent(
+//	    mock.WithChatResponse(resp, nil),
+//	    mock.WithFault(mock.FailFirst(2, mock.HTTPError(503))),
+//	)
+func WithFault(f Fault) MockAgentOption {
+	return func(m *MockAgent) {
+		m.faults = &faults{fault: f}
+	}
+}
+
 // WithClient sets a custom client.
 func WithClient(c client.Client) MockAgentOption {
 	return func(m *MockAgent) {
@@ -148,54 +230,174 @@ func (m *MockAgent) Model() *model.Model {
 	return m.mockModel
 }
 
-// Chat returns the predetermined chat response.
+// Chat returns the next scripted chat result, or the predetermined chat response.
 func (m *MockAgent) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
-	return m.chatResponse, m.chatError
+	if err := m.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := m.chatResponse, m.chatError
+	if m.chatSequence != nil {
+		resp, err = m.chatSequence.pop()
+	}
+	return m.chatUsage(prompt, resp), err
 }
 
 // ChatStream returns a channel with predetermined streaming chunks.
+// A StreamFault, if configured, interrupts or paces the stream.
 func (m *MockAgent) ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	if err := m.faults.inject(); err != nil {
+		return nil, err
+	}
 	if m.streamError != nil {
 		return nil, m.streamError
 	}
 
-	ch := make(chan *response.StreamingChunk, len(m.streamChunks))
+	chunks := make([]*response.StreamingChunk, len(m.streamChunks))
 	for i := range m.streamChunks {
-		ch <- &m.streamChunks[i]
+		chunks[i] = &m.streamChunks[i]
 	}
-	close(ch)
 
-	return ch, nil
+	return streamChunks(ctx, m.streamUsage(prompt, chunks), m.streamFault), nil
 }
 
-// Vision returns the predetermined vision response.
+// Vision returns the next scripted vision result, or the predetermined vision response.
 func (m *MockAgent) Vision(ctx context.Context, prompt string, images []string, opts ...map[string]any) (*response.ChatResponse, error) {
-	return m.visionResponse, m.visionError
+	if err := m.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := m.visionResponse, m.visionError
+	if m.visionSequence != nil {
+		resp, err = m.visionSequence.pop()
+	}
+	return m.chatUsage(prompt, resp), err
 }
 
 // VisionStream returns a channel with predetermined streaming chunks.
+// A StreamFault, if configured, interrupts or paces the stream.
 func (m *MockAgent) VisionStream(ctx context.Context, prompt string, images []string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	if err := m.faults.inject(); err != nil {
+		return nil, err
+	}
 	if m.streamError != nil {
 		return nil, m.streamError
 	}
 
-	ch := make(chan *response.StreamingChunk, len(m.streamChunks))
+	chunks := make([]*response.StreamingChunk, len(m.streamChunks))
 	for i := range m.streamChunks {
-		ch <- &m.streamChunks[i]
+		chunks[i] = &m.streamChunks[i]
 	}
-	close(ch)
 
-	return ch, nil
+	return streamChunks(ctx, m.streamUsage(prompt, chunks), m.streamFault), nil
 }
 
-// Tools returns the predetermined tools response.
+// Tools returns the next scripted tools result, or the predetermined tools response.
 func (m *MockAgent) Tools(ctx context.Context, prompt string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
-	return m.toolsResponse, m.toolsError
+	if err := m.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := m.toolsResponse, m.toolsError
+	if m.toolsSequence != nil {
+		resp, err = m.toolsSequence.pop()
+	}
+	return m.toolsUsage(prompt, resp), err
+}
+
+// VisionTools returns the next scripted tools result, or the predetermined tools response.
+func (m *MockAgent) VisionTools(ctx context.Context, prompt string, images []string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	if err := m.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := m.toolsResponse, m.toolsError
+	if m.toolsSequence != nil {
+		resp, err = m.toolsSequence.pop()
+	}
+	return m.toolsUsage(prompt, resp), err
 }
 
-// Embed returns the predetermined embeddings response.
+// Embed returns the next scripted embeddings result, or the predetermined embeddings response.
 func (m *MockAgent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
-	return m.embeddingsResponse, m.embeddingsError
+	if err := m.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := m.embeddingsResponse, m.embeddingsError
+	if m.embeddingsSequence != nil {
+		resp, err = m.embeddingsSequence.pop()
+	}
+	return m.embeddingsUsage(input, resp), err
+}
+
+// Supports reports every valid protocol as supported unless it was marked
+// unsupported with WithUnsupported.
+func (m *MockAgent) Supports(p protocol.Protocol) bool {
+	return protocol.IsValid(string(p)) && !slices.Contains(m.unsupported, p)
+}
+
+// UploadFile returns a file describing the upload, or the predetermined file error.
+// The content is not read.
+func (m *MockAgent) UploadFile(ctx context.Context, filename string, content io.Reader, purpose response.FilePurpose) (*response.File, error) {
+	if m.fileError != nil {
+		return nil, m.fileError
+	}
+	return &response.File{ID: "file-mock", Object: "file", Filename: filename, Purpose: purpose}, nil
+}
+
+// ListFiles returns the predetermined files and error.
+func (m *MockAgent) ListFiles(ctx context.Context, purpose response.FilePurpose) ([]response.File, error) {
+	return m.files, m.fileError
+}
+
+// DeleteFile returns the predetermined file error.
+func (m *MockAgent) DeleteFile(ctx context.Context, id string) error {
+	return m.fileError
+}
+
+// Prewarm returns the predetermined prewarm error.
+func (m *MockAgent) Prewarm(ctx context.Context) error {
+	return m.prewarmError
+}
+
+// SetSystemPrompt records the system prompt. Mock responses are unaffected.
+func (m *MockAgent) SetSystemPrompt(prompt string) {
+	m.tuning.Lock()
+	defer m.tuning.Unlock()
+	m.systemPrompt = prompt
+}
+
+// SystemPrompt returns the prompt last set with SetSystemPrompt.
+func (m *MockAgent) SystemPrompt() string {
+	m.tuning.Lock()
+	defer m.tuning.Unlock()
+	return m.systemPrompt
+}
+
+// SetDefaultOptions records the default options for a protocol, or clears
+// them when opts is nil. Mock responses are unaffected.
+func (m *MockAgent) SetDefaultOptions(proto protocol.Protocol, opts map[string]any) {
+	m.tuning.Lock()
+	defer m.tuning.Unlock()
+	if opts == nil {
+		delete(m.defaultOptions, proto)
+		return
+	}
+	if m.defaultOptions == nil {
+		m.defaultOptions = make(map[protocol.Protocol]map[string]any)
+	}
+	m.defaultOptions[proto] = maps.Clone(opts)
+}
+
+// DefaultOptions returns a copy of the options last set for a protocol with
+// SetDefaultOptions, or of the mock model's configured options.
+func (m *MockAgent) DefaultOptions(proto protocol.Protocol) map[string]any {
+	m.tuning.Lock()
+	defaults, overridden := m.defaultOptions[proto]
+	m.tuning.Unlock()
+	if overridden {
+		return maps.Clone(defaults)
+	}
+	if mdl := m.Model(); mdl != nil {
+		return maps.Clone(mdl.Options[proto])
+	}
+	return nil
 }
 
 // Verify MockAgent implements agent.Agent interface.
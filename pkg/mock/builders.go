@@ -0,0 +1,128 @@
+package mock
+
+import (
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// ChatResponseWith builds a single-choice chat response with the given
+// assistant content and finish reason "stop". Usage may be nil.
+func ChatResponseWith(content string, usage *response.TokenUsage) *response.ChatResponse {
+	return &response.ChatResponse{
+		Object: "chat.completion",
+		Model:  "mock-model",
+		Choices: []response.ChatChoice{{
+			Message:      protocol.NewMessage("assistant", content),
+			FinishReason: response.FinishReasonStop,
+		}},
+		Usage: usage,
+	}
+}
+
+// ToolCall builds a function tool call with arguments given as a JSON string.
+// The ID is derived from the function name; set it explicitly when a response
+// calls the same function more than once.
+func ToolCall(name, argsJSON string) response.ToolCall {
+	return response.ToolCall{
+		ID:   "call_" + name,
+		Type: "function",
+		Function: response.ToolCallFunction{
+			Name:      name,
+			Arguments: argsJSON,
+		},
+	}
+}
+
+// ToolsResponseWith builds a single-choice tools response requesting the
+// given tool calls, with finish reason "tool_calls" (or "stop" when calls is
+// empty).
+func ToolsResponseWith(calls ...response.ToolCall) *response.ToolsResponse {
+	finish := response.FinishReasonToolCalls
+	if len(calls) == 0 {
+		finish = response.FinishReasonStop
+	}
+
+	return &response.ToolsResponse{
+		Object: "chat.completion",
+		Model:  "mock-model",
+		Choices: []response.ToolsChoice{{
+			Message: response.ToolsMessage{
+				Role:      "assistant",
+				ToolCalls: calls,
+			},
+			FinishReason: finish,
+		}},
+	}
+}
+
+// EmbeddingsResponseWith builds an embeddings response with one entry per
+// vector, indexed in order.
+func EmbeddingsResponseWith(vectors ...[]float64) *response.EmbeddingsResponse {
+	resp := &response.EmbeddingsResponse{Object: "list", Model: "mock-model"}
+	for i, vector := range vectors {
+		resp.Data = append(resp.Data, response.EmbeddingData{
+			Embedding: vector,
+			Index:     i,
+			Object:    "embedding",
+		})
+	}
+	return resp
+}
+
+// ContentChunk builds a streaming chunk carrying a content delta.
+func ContentChunk(content string) response.StreamingChunk {
+	return response.StreamingChunk{
+		Object: "chat.completion.chunk",
+		Model:  "mock-model",
+		Choices: []response.StreamingChoice{{
+			Delta: response.StreamingDelta{Content: content},
+		}},
+	}
+}
+
+// ContentChunks builds one content chunk per string, with the finish reason
+// "stop" set on the last.
+func ContentChunks(contents ...string) []response.StreamingChunk {
+	chunks := make([]response.StreamingChunk, len(contents))
+	for i, content := range contents {
+		chunks[i] = ContentChunk(content)
+	}
+	if len(chunks) > 0 {
+		finish := response.FinishReasonStop
+		chunks[len(chunks)-1].Choices[0].FinishReason = &finish
+	}
+	return chunks
+}
+
+// ToolCallChunk builds a streaming chunk carrying a tool call fragment at
+// index. The first fragment for an index carries the ID and name; later
+// fragments carry argument text only.
+func ToolCallChunk(index int, call response.ToolCall) response.StreamingChunk {
+	return response.StreamingChunk{
+		Object: "chat.completion.chunk",
+		Model:  "mock-model",
+		Choices: []response.StreamingChoice{{
+			Delta: response.StreamingDelta{
+				ToolCalls: []response.ToolCallDelta{{
+					Index:    index,
+					ID:       call.ID,
+					Type:     call.Type,
+					Function: call.Function,
+				}},
+			},
+		}},
+	}
+}
+
+// FinishChunk builds a final streaming chunk with the given finish reason and
+// optional usage.
+func FinishChunk(reason response.FinishReason, usage *response.TokenUsage) response.StreamingChunk {
+	return response.StreamingChunk{
+		Object: "chat.completion.chunk",
+		Model:  "mock-model",
+		Choices: []response.StreamingChoice{{
+			FinishReason: &reason,
+		}},
+		Usage: usage,
+	}
+}
@@ -3,6 +3,7 @@ package mock
 import (
 	"context"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/client"
@@ -20,6 +21,9 @@ type MockClient struct {
 	streamChunks    []*response.StreamingChunk
 	streamError     error
 	httpClient      *http.Client
+	faults          *faults
+	streamFault     *StreamFault
+	closed          atomic.Bool
 }
 
 // NewMockClient creates a new MockClient with default configuration.
@@ -57,6 +61,15 @@ func WithStreamResponse(chunks []*response.StreamingChunk, err error) MockClient
 	}
 }
 
+// WithExecuteFault injects failures into Execute and ExecuteStream.
+// Calls are counted across both; calls the fault lets through return the
+// configured responses.
+func WithExecuteFault(f Fault) MockClientOption {
+	return func(m *MockClient) {
+		m.faults = &faults{fault: f}
+	}
+}
+
 // WithHealthy sets the health status.
 func WithHealthy(healthy bool) MockClientOption {
 	return func(m *MockClient) {
@@ -78,22 +91,29 @@ func (m *MockClient) HTTPClient() *http.Client {
 
 // Execute returns the predetermined response.
 func (m *MockClient) Execute(ctx context.Context, req request.Request) (any, error) {
+	if m.closed.Load() {
+		return nil, client.ErrClientClosed
+	}
+	if err := m.faults.inject(); err != nil {
+		return nil, err
+	}
 	return m.executeResponse, m.executeError
 }
 
 // ExecuteStream returns a channel with predetermined chunks.
+// A StreamFault, if configured, interrupts or paces the stream.
 func (m *MockClient) ExecuteStream(ctx context.Context, req request.Request) (<-chan *response.StreamingChunk, error) {
+	if m.closed.Load() {
+		return nil, client.ErrClientClosed
+	}
+	if err := m.faults.inject(); err != nil {
+		return nil, err
+	}
 	if m.streamError != nil {
 		return nil, m.streamError
 	}
 
-	ch := make(chan *response.StreamingChunk, len(m.streamChunks))
-	for _, chunk := range m.streamChunks {
-		ch <- chunk
-	}
-	close(ch)
-
-	return ch, nil
+	return streamChunks(ctx, m.streamChunks, m.streamFault), nil
 }
 
 // IsHealthy returns the mock health status.
@@ -101,5 +121,12 @@ func (m *MockClient) IsHealthy() bool {
 	return m.healthy
 }
 
+// Shutdown marks the client closed; later Execute and ExecuteStream calls
+// return client.ErrClientClosed. There is nothing in flight to wait for.
+func (m *MockClient) Shutdown(ctx context.Context) error {
+	m.closed.Store(true)
+	return nil
+}
+
 // Verify MockClient implements client.Client interface.
 var _ client.Client = (*MockClient)(nil)
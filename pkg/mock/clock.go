@@ -0,0 +1,128 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock is a deterministic fake clock. Time moves only when Advance is
+// called, so tests control exactly when timers fire instead of sleeping.
+// Pass Now to code that accepts a time source (e.g., quota.WithClock) and
+// set it on StreamFault to pace mock streams.
+// Thread-safe for concurrent use.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*clockTimer
+	changed chan struct{}
+}
+
+// clockTimer is a pending After or Sleep call.
+type clockTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewClock creates a Clock reading start. A zero start uses
+// 2025-01-01T00:00:00Z so results do not depend on the real time.
+func NewClock(start time.Time) *Clock {
+	if start.IsZero() {
+		start = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return &Clock{now: start, changed: make(chan struct{})}
+}
+
+// Now returns the clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since returns the clock time elapsed since t.
+func (c *Clock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After returns a channel that receives the clock time once the clock has
+// advanced by d. A non-positive d fires immediately.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &clockTimer{at: c.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		timer.ch <- c.now
+		return timer.ch
+	}
+
+	c.timers = append(c.timers, timer)
+	close(c.changed)
+	c.changed = make(chan struct{})
+	return timer.ch
+}
+
+// Sleep blocks until the clock has advanced by d or ctx ends.
+// Returns the context's error if it ends first.
+func (c *Clock) Sleep(ctx context.Context, d time.Duration) error {
+	ch := c.After(d)
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		c.timers = slices.DeleteFunc(c.timers, func(t *clockTimer) bool { return t.ch == ch })
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Advance moves the clock forward by d, firing every timer that falls due in
+// deadline order.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due []*clockTimer
+	c.timers = slices.DeleteFunc(c.timers, func(t *clockTimer) bool {
+		if t.at.After(c.now) {
+			return false
+		}
+		due = append(due, t)
+		return true
+	})
+	c.mu.Unlock()
+
+	slices.SortStableFunc(due, func(a, b *clockTimer) int { return a.at.Compare(b.at) })
+	for _, t := range due {
+		t.ch <- t.at
+	}
+}
+
+// Waiters returns the number of pending After and Sleep calls.
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntil waits until at least n After or Sleep calls are pending, so a
+// test can advance the clock once the code under test is waiting on it.
+// Returns the context's error if it ends first.
+func (c *Clock) BlockUntil(ctx context.Context, n int) error {
+	for {
+		c.mu.Lock()
+		pending, changed := len(c.timers), c.changed
+		c.mu.Unlock()
+
+		if pending >= n {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
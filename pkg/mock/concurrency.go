@@ -0,0 +1,147 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// DefaultStreamTimeout bounds ConsumeStream when no timeout is given.
+const DefaultStreamTimeout = 5 * time.Second
+
+// RunConcurrent calls fn n times, with i from 0 to n-1, across workers
+// goroutines and returns each call's error by index. Workers are released
+// together so calls overlap as much as possible, which gives the race
+// detector the most interleavings to check. A non-positive workers runs
+// every call on its own goroutine. Once ctx ends, remaining calls are not
+// made and report the context's error.
+//
+//	errs := mock.RunConcurrent(ctx, 100, 10, func(ctx context.Context, i int) error {
+//	    _, err := orchestrator.Run(ctx, fmt.Sprintf("task %d", i))
+//	    return err
+//	})
+//	if err := errors.Join(errs...); err != nil {
+//	    t.Fatal(err)
+//	}
+func RunConcurrent(ctx context.Context, n, workers int, fn func(ctx context.Context, i int) error) []error {
+	if workers <= 0 || workers > n {
+		workers = n
+	}
+
+	calls := make(chan int, n)
+	for i := range n {
+		calls <- i
+	}
+	close(calls)
+
+	errs := make([]error, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			<-start
+			for i := range calls {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				errs[i] = fn(ctx, i)
+			}
+		})
+	}
+	close(start)
+	wg.Wait()
+
+	return errs
+}
+
+// StreamResult is what ConsumeStream read from a stream. Chunks excludes
+// heartbeats, which are counted separately; Content concatenates the first
+// choice's content; Err is the error of the stream's error chunk.
+type StreamResult struct {
+	Chunks       []*response.StreamingChunk
+	Content      string
+	Heartbeats   int
+	Usage        *response.TokenUsage
+	FinishReason response.FinishReason
+	Err          error
+}
+
+// ConsumeStream reads a stream until it closes and checks the invariants
+// every stream must hold: no nil chunks, no chunks after an error chunk, and
+// a close within timeout (DefaultStreamTimeout when not positive).
+// Violations are reported with t.Errorf, never t.Fatal, so ConsumeStream may
+// be called from any goroutine, such as the workers of RunConcurrent.
+func ConsumeStream(t testing.TB, chunks <-chan *response.StreamingChunk, timeout time.Duration) *StreamResult {
+	t.Helper()
+
+	if timeout <= 0 {
+		timeout = DefaultStreamTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	result := &StreamResult{}
+	var content strings.Builder
+	for {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				result.Content = content.String()
+				return result
+			}
+			switch {
+			case chunk == nil:
+				t.Errorf("stream sent a nil chunk")
+				continue
+			case result.Err != nil:
+				t.Errorf("stream sent a chunk after error chunk %v", result.Err)
+			}
+			if chunk.Heartbeat {
+				result.Heartbeats++
+				continue
+			}
+
+			result.Chunks = append(result.Chunks, chunk)
+			content.WriteString(chunk.Content())
+			if chunk.Usage != nil {
+				result.Usage = chunk.Usage
+			}
+			if reason := chunk.FinishReason(); reason != "" {
+				result.FinishReason = reason
+			}
+			if chunk.Error != nil {
+				result.Err = chunk.Error
+			}
+		case <-timer.C:
+			t.Errorf("stream not closed within %s", timeout)
+			result.Content = content.String()
+			return result
+		}
+	}
+}
+
+// AssertContent reports an error if the stream's content is not want.
+func (r *StreamResult) AssertContent(t testing.TB, want string) {
+	t.Helper()
+	if r.Content != want {
+		t.Errorf("got stream content %q, want %q", r.Content, want)
+	}
+}
+
+// AssertErr reports an error unless the stream's error matches target with
+// errors.Is. A nil target asserts the stream ended without an error.
+func (r *StreamResult) AssertErr(t testing.TB, target error) {
+	t.Helper()
+	switch {
+	case target == nil && r.Err != nil:
+		t.Errorf("got stream error %v, want none", r.Err)
+	case target != nil && !errors.Is(r.Err, target):
+		t.Errorf("got stream error %v, want %v", r.Err, target)
+	}
+}
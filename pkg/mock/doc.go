@@ -12,6 +12,10 @@
 //
 // MockProvider: Implements providers.Provider interface with endpoint mapping
 //
+// RouterAgent: A MockAgent that selects responses by matching the prompt
+//
+// Server: An in-process OpenAI-compatible HTTP server for integration tests
+//
 // # Usage Example
 //
 //	// Create a mock agent with predetermined chat response
@@ -27,6 +31,77 @@
 //	response, err := mockAgent.Chat(context.Background(), "test prompt")
 //	// response contains the predetermined response
 //
+// # Response Builders
+//
+// ChatResponseWith, ToolsResponseWith (with ToolCall), and
+// EmbeddingsResponseWith build complete responses in one call, and
+// ContentChunk, ContentChunks, ToolCallChunk, and FinishChunk build streaming
+// chunks:
+//
+//	mockAgent := mock.NewMockAgent(
+//	    mock.WithToolsResponse(mock.ToolsResponseWith(
+//	        mock.ToolCall("get_weather", `{"city":"Paris"}`),
+//	    ), nil),
+//	    mock.WithStreamChunks(mock.ContentChunks("Hel", "lo"), nil),
+//	)
+//
+// # Scripted Responses
+//
+// WithChatResponses (and the Vision, Tools, and Embeddings variants) return
+// results in call order, repeating the last once exhausted. Mix responses and
+// errors to test retry loops, fallbacks, and multi-turn conversations:
+//
+//	mockAgent := mock.NewMockAgent(
+//	    mock.WithChatResponses(first, second, errors.New("rate limited")),
+//	)
+//
+// # Prompt Routing
+//
+// RouterAgent answers by matching the prompt against rules, so one mock can
+// drive each step of a multi-step orchestration test:
+//
+//	router := mock.NewRouterAgent().
+//	    On(regexp.MustCompile(`^Plan:`), plan).
+//	    OnContains("weather", weatherToolCall).
+//	    Default(fallback)
+//
+// # HTTP Server
+//
+// Server exercises real providers and clients over HTTP. It serves chat
+// completions (including SSE streaming and tools) and embeddings from canned
+// or scripted responses, and captures each request:
+//
+//	srv := mock.NewServer(mock.WithServerChatResponses(resp, mock.HTTPError(503)))
+//	defer srv.Close()
+//
+//	cfg.Provider = &config.ProviderConfig{Name: "ollama", BaseURL: srv.URL}
+//	// ... run the code under test, then inspect srv.Requests()
+//
+// # Failure Injection
+//
+// WithFault (MockAgent) and WithExecuteFault (MockClient) inject errors to
+// exercise retry, circuit breaker, and fallback logic. FailFirst, FailSequence,
+// and FailRandomly build faults; HTTPError produces the *client.HTTPStatusError
+// a real provider failure would:
+//
+//	flaky := mock.NewMockAgent(
+//	    mock.WithChatResponse(resp, nil),
+//	    mock.WithFault(mock.FailFirst(2, mock.HTTPError(503))),
+//	)
+//
+// # Simulated Usage
+//
+// WithUsage (fixed token counts), WithEstimatedUsage (counted from prompt and
+// response text), and WithUsageFunc populate Usage on responses that do not
+// carry it, and on the final chunk of streams, so cost tracking, budgeting,
+// and context-window code can be tested. The New*Agent helpers estimate usage
+// by default. WithPricing sets model pricing for cost calculations:
+//
+//	agent := mock.NewSimpleChatAgent("a", "answer",
+//	    mock.WithUsage(1000, 500),
+//	    mock.WithPricing(&config.PricingConfig{PromptPer1K: 0.01, CompletionPer1K: 0.02}),
+//	)
+//
 // # Streaming Support
 //
 // Streaming methods return pre-populated channels that can be configured
@@ -43,4 +118,42 @@
 //	for chunk := range chunks {
 //	    // Process test chunks
 //	}
+//
+// WithStreamFault (and WithExecuteStreamFault for MockClient) simulates
+// mid-stream failures: the stream sends a number of chunks, then an error
+// chunk or an abrupt close, pausing between chunks and stopping when the
+// context is cancelled:
+//
+//	mockAgent := mock.NewMockAgent(
+//	    mock.WithStreamChunks(chunks, nil),
+//	    mock.WithStreamFault(mock.StreamFault{After: 2, Err: io.ErrUnexpectedEOF}),
+//	)
+//
+// # Concurrency Testing
+//
+// RunConcurrent releases many overlapping calls at once so orchestration
+// code can be stressed against mocks under the race detector, and
+// ConsumeStream drains a stream while checking stream invariants. Both are
+// safe to combine, since ConsumeStream reports with t.Errorf:
+//
+//	errs := mock.RunConcurrent(ctx, 100, 10, func(ctx context.Context, i int) error {
+//	    chunks, err := pipeline.Stream(ctx, "prompt")
+//	    if err != nil {
+//	        return err
+//	    }
+//	    mock.ConsumeStream(t, chunks, 0).AssertErr(t, nil)
+//	    return nil
+//	})
+//
+// Clock is a fake clock for deterministic timing. Setting it on a
+// StreamFault holds each chunk until the test advances the clock:
+//
+//	clock := mock.NewClock(time.Time{})
+//	agent := mock.NewMockAgent(
+//	    mock.WithStreamChunks(chunks, nil),
+//	    mock.WithStreamFault(mock.StreamFault{After: -1, Delay: time.Second, Clock: clock}),
+//	)
+//	// ... start the stream, then:
+//	clock.BlockUntil(ctx, 1)
+//	clock.Advance(time.Second)
 package mock
@@ -0,0 +1,82 @@
+package mock
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"sync"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+)
+
+// Fault decides whether a mock call fails. It receives the 1-based call
+// number across all faultable methods of the mock and returns the error to
+// inject, or nil to let the call proceed with its configured result.
+type Fault func(call int) error
+
+// FailFirst fails the first n calls with err, then lets calls succeed.
+// Use to exercise retry and fallback logic that should recover.
+func FailFirst(n int, err error) Fault {
+	return func(call int) error {
+		if call <= n {
+			return err
+		}
+		return nil
+	}
+}
+
+// FailSequence fails calls with the given errors in order, then lets calls
+// succeed. A nil entry lets that call succeed.
+//
+// Example:
+//
+//	mock.FailSequence(mock.HTTPError(429), mock.HTTPError(503))
+func FailSequence(errs ...error) Fault {
+	return func(call int) error {
+		if call <= len(errs) {
+			return errs[call-1]
+		}
+		return nil
+	}
+}
+
+// FailRandomly fails each call with err at the given probability (0 to 1).
+// The seed makes the failure pattern reproducible across test runs.
+func FailRandomly(probability float64, err error, seed uint64) Fault {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	return func(call int) error {
+		if rng.Float64() < probability {
+			return err
+		}
+		return nil
+	}
+}
+
+// HTTPError returns a *client.HTTPStatusError for the status code, matching
+// what the real client returns for a failed provider response. Codes 429,
+// 502, 503, and 504 are treated as retryable by the client.
+func HTTPError(code int) *client.HTTPStatusError {
+	return &client.HTTPStatusError{
+		StatusCode: code,
+		Status:     http.StatusText(code),
+	}
+}
+
+// faults counts calls and applies a Fault.
+// A nil *faults injects nothing. Thread-safe for concurrent calls.
+type faults struct {
+	mu    sync.Mutex
+	fault Fault
+	calls int
+}
+
+// inject records a call and returns the fault's error for it.
+func (f *faults) inject() error {
+	if f == nil {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.fault(f.calls)
+}
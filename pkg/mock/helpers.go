@@ -1,197 +1,73 @@
 package mock
 
-import (
-	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
-	"github.com/tailored-agentic-units/tau-core/pkg/response"
-)
+import "github.com/tailored-agentic-units/tau-core/pkg/response"
 
 // NewSimpleChatAgent creates a MockAgent configured for simple chat responses.
 // Useful for basic orchestration testing without complex protocol handling.
-func NewSimpleChatAgent(id string, content string) *MockAgent {
-	chatResponse := &response.ChatResponse{
-		Model: "mock-model",
-	}
-	chatResponse.Choices = append(chatResponse.Choices, struct {
-		Index   int              `json:"index"`
-		Message protocol.Message `json:"message"`
-		Delta   *struct {
-			Role    string `json:"role,omitempty"`
-			Content string `json:"content,omitempty"`
-		} `json:"delta,omitempty"`
-		FinishReason string `json:"finish_reason,omitempty"`
-	}{
-		Index:   0,
-		Message: protocol.NewMessage("assistant", content),
-	})
-
-	return NewMockAgent(
+// Like the other response helpers, it reports usage estimated from the prompt
+// and response text; pass WithUsage or WithUsageFunc to override.
+func NewSimpleChatAgent(id string, content string, opts ...MockAgentOption) *MockAgent {
+	return NewMockAgent(append([]MockAgentOption{
 		WithID(id),
-		WithChatResponse(chatResponse, nil),
-	)
+		WithChatResponse(ChatResponseWith(content, nil), nil),
+		WithEstimatedUsage(),
+	}, opts...)...)
 }
 
 // NewStreamingChatAgent creates a MockAgent configured for streaming chat.
 // Returns chunks sequentially when ChatStream is called.
-func NewStreamingChatAgent(id string, chunks []string) *MockAgent {
-	streamChunks := make([]response.StreamingChunk, len(chunks))
-	for i, content := range chunks {
-		chunk := response.StreamingChunk{
-			Model: "mock-model",
-		}
-		chunk.Choices = append(chunk.Choices, struct {
-			Index int `json:"index"`
-			Delta struct {
-				Role    string `json:"role,omitempty"`
-				Content string `json:"content,omitempty"`
-			} `json:"delta"`
-			FinishReason *string `json:"finish_reason"`
-		}{
-			Index: 0,
-			Delta: struct {
-				Role    string `json:"role,omitempty"`
-				Content string `json:"content,omitempty"`
-			}{
-				Content: content,
-			},
-		})
-		streamChunks[i] = chunk
-	}
-
-	return NewMockAgent(
+func NewStreamingChatAgent(id string, chunks []string, opts ...MockAgentOption) *MockAgent {
+	return NewMockAgent(append([]MockAgentOption{
 		WithID(id),
-		WithStreamChunks(streamChunks, nil),
-	)
+		WithStreamChunks(ContentChunks(chunks...), nil),
+		WithEstimatedUsage(),
+	}, opts...)...)
 }
 
 // NewToolsAgent creates a MockAgent configured for tool calling.
 // Returns tool calls in the Tools response.
-func NewToolsAgent(id string, toolCalls []response.ToolCall) *MockAgent {
-	toolsResponse := &response.ToolsResponse{
-		Model: "mock-model",
-	}
-	toolsResponse.Choices = append(toolsResponse.Choices, struct {
-		Index   int `json:"index"`
-		Message struct {
-			Role      string              `json:"role"`
-			Content   string              `json:"content"`
-			ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-		} `json:"message"`
-		FinishReason string `json:"finish_reason,omitempty"`
-	}{
-		Index: 0,
-		Message: struct {
-			Role      string              `json:"role"`
-			Content   string              `json:"content"`
-			ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-		}{
-			Role:      "assistant",
-			Content:   "",
-			ToolCalls: toolCalls,
-		},
-	})
-
-	return NewMockAgent(
+func NewToolsAgent(id string, toolCalls []response.ToolCall, opts ...MockAgentOption) *MockAgent {
+	return NewMockAgent(append([]MockAgentOption{
 		WithID(id),
-		WithToolsResponse(toolsResponse, nil),
-	)
+		WithToolsResponse(ToolsResponseWith(toolCalls...), nil),
+		WithEstimatedUsage(),
+	}, opts...)...)
 }
 
 // NewEmbeddingsAgent creates a MockAgent configured for embeddings generation.
 // Returns the provided embeddings vector.
-func NewEmbeddingsAgent(id string, embedding []float64) *MockAgent {
-	embeddingsResponse := &response.EmbeddingsResponse{
-		Model: "mock-model",
-	}
-	embeddingsResponse.Data = append(embeddingsResponse.Data, struct {
-		Embedding []float64 `json:"embedding"`
-		Index     int       `json:"index"`
-		Object    string    `json:"object"`
-	}{
-		Embedding: embedding,
-		Index:     0,
-		Object:    "embedding",
-	})
-
-	return NewMockAgent(
+func NewEmbeddingsAgent(id string, embedding []float64, opts ...MockAgentOption) *MockAgent {
+	return NewMockAgent(append([]MockAgentOption{
 		WithID(id),
-		WithEmbeddingsResponse(embeddingsResponse, nil),
-	)
+		WithEmbeddingsResponse(EmbeddingsResponseWith(embedding), nil),
+		WithEstimatedUsage(),
+	}, opts...)...)
 }
 
 // NewMultiProtocolAgent creates a MockAgent configured for multiple protocols.
 // Useful for testing agents that handle different protocol types.
-func NewMultiProtocolAgent(id string) *MockAgent {
-	chatResponse := &response.ChatResponse{
-		Model: "mock-model",
-	}
-	chatResponse.Choices = append(chatResponse.Choices, struct {
-		Index   int              `json:"index"`
-		Message protocol.Message `json:"message"`
-		Delta   *struct {
-			Role    string `json:"role,omitempty"`
-			Content string `json:"content,omitempty"`
-		} `json:"delta,omitempty"`
-		FinishReason string `json:"finish_reason,omitempty"`
-	}{
-		Index:   0,
-		Message: protocol.NewMessage("assistant", "Mock chat response"),
-	})
-
-	toolsResponse := &response.ToolsResponse{
-		Model: "mock-model",
-	}
-	toolsResponse.Choices = append(toolsResponse.Choices, struct {
-		Index   int `json:"index"`
-		Message struct {
-			Role      string              `json:"role"`
-			Content   string              `json:"content"`
-			ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-		} `json:"message"`
-		FinishReason string `json:"finish_reason,omitempty"`
-	}{
-		Index: 0,
-		Message: struct {
-			Role      string              `json:"role"`
-			Content   string              `json:"content"`
-			ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-		}{
-			Role:      "assistant",
-			Content:   "",
-			ToolCalls: []response.ToolCall{},
-		},
-	})
-
-	embeddingsResponse := &response.EmbeddingsResponse{
-		Model: "mock-model",
-	}
-	embeddingsResponse.Data = append(embeddingsResponse.Data, struct {
-		Embedding []float64 `json:"embedding"`
-		Index     int       `json:"index"`
-		Object    string    `json:"object"`
-	}{
-		Embedding: []float64{0.1, 0.2, 0.3},
-		Index:     0,
-		Object:    "embedding",
-	})
+func NewMultiProtocolAgent(id string, opts ...MockAgentOption) *MockAgent {
+	chatResponse := ChatResponseWith("Mock chat response", nil)
 
-	return NewMockAgent(
+	return NewMockAgent(append([]MockAgentOption{
 		WithID(id),
 		WithChatResponse(chatResponse, nil),
 		WithVisionResponse(chatResponse, nil),
-		WithToolsResponse(toolsResponse, nil),
-		WithEmbeddingsResponse(embeddingsResponse, nil),
-	)
+		WithToolsResponse(ToolsResponseWith(), nil),
+		WithEmbeddingsResponse(EmbeddingsResponseWith([]float64{0.1, 0.2, 0.3}), nil),
+		WithEstimatedUsage(),
+	}, opts...)...)
 }
 
 // NewFailingAgent creates a MockAgent that returns errors for all operations.
 // Useful for testing error handling in orchestration scenarios.
-func NewFailingAgent(id string, err error) *MockAgent {
-	return NewMockAgent(
+func NewFailingAgent(id string, err error, opts ...MockAgentOption) *MockAgent {
+	return NewMockAgent(append([]MockAgentOption{
 		WithID(id),
 		WithChatResponse(nil, err),
 		WithVisionResponse(nil, err),
 		WithToolsResponse(nil, err),
 		WithEmbeddingsResponse(nil, err),
 		WithStreamChunks(nil, err),
-	)
+	}, opts...)...)
 }
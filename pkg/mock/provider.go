@@ -220,7 +220,7 @@ func (m *MockProvider) ProcessResponse(ctx context.Context, resp *http.Response,
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return response.Parse(proto, body)
+	return response.ParseContext(ctx, proto, body)
 }
 
 // ProcessStreamResponse returns a channel with predetermined chunks.
@@ -0,0 +1,229 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// ErrNoRoute is returned by RouterAgent when no rule or default provides a
+// result of the type a method returns.
+var ErrNoRoute = errors.New("mock: no route for prompt")
+
+// RouterAgent is a MockAgent that selects results by matching the prompt
+// against rules, so one mock can give different answers at each step of a
+// multi-step orchestration test.
+//
+// Results are *response.ChatResponse (Chat, ChatStream, Vision, VisionStream),
+// *response.ToolsResponse (Tools, VisionTools), *response.EmbeddingsResponse
+// (Embed), or an error returned by any method. Rules are checked in
+// registration order, and a rule only applies to methods that return its
+// result type, so the same pattern can route chat and tools calls separately.
+// Unmatched calls use the default result, or fail with ErrNoRoute.
+//
+// Faults configured with WithFault are applied before routing.
+// Rules may be added concurrently with calls.
+type RouterAgent struct {
+	*MockAgent
+
+	mu       sync.RWMutex
+	rules    []route
+	defaults []any
+}
+
+// route pairs a prompt matcher with its result.
+type route struct {
+	match  func(prompt string) bool
+	result any
+}
+
+// NewRouterAgent creates a RouterAgent with no rules.
+// MockAgent options configure the ID, model, and other non-routed behavior.
+//
+// Example:
+//
+//	agent := mock.NewRouterAgent().
+//	    On(regexp.MustCompile(`(?i)^summarize`), summary).
+//	    OnContains("weather", weatherCall).
+//	    Default(fallback)
+func NewRouterAgent(opts ...MockAgentOption) *RouterAgent {
+	return &RouterAgent{MockAgent: NewMockAgent(opts...)}
+}
+
+// On routes prompts matching pattern to result.
+// Panics if result is not a supported response type or error.
+func (r *RouterAgent) On(pattern *regexp.Regexp, result any) *RouterAgent {
+	return r.add(pattern.MatchString, result)
+}
+
+// OnContains routes prompts containing substr to result.
+// Panics if result is not a supported response type or error.
+func (r *RouterAgent) OnContains(substr string, result any) *RouterAgent {
+	return r.add(func(prompt string) bool { return strings.Contains(prompt, substr) }, result)
+}
+
+// Default sets a result for prompts no rule matches. Call once per response
+// type to set defaults for several methods; an error default applies to all.
+// Panics if result is not a supported response type or error.
+func (r *RouterAgent) Default(result any) *RouterAgent {
+	checkRouteResult(result)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.defaults = append(r.defaults, result)
+	return r
+}
+
+func (r *RouterAgent) add(match func(string) bool, result any) *RouterAgent {
+	checkRouteResult(result)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, route{match: match, result: result})
+	return r
+}
+
+// checkRouteResult panics if result is not a routable type, so misconfigured
+// tests fail when the rule is added.
+func checkRouteResult(result any) {
+	switch result.(type) {
+	case *response.ChatResponse, *response.ToolsResponse, *response.EmbeddingsResponse, error:
+	default:
+		panic(fmt.Sprintf("mock: unsupported route result %T", result))
+	}
+}
+
+// resolve returns the first rule result for prompt that is a T or an error,
+// then the first such default.
+func resolve[T any](r *RouterAgent, prompt string) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var zero T
+	accept := func(result any) (T, bool, error) {
+		switch v := result.(type) {
+		case T:
+			return v, true, nil
+		case error:
+			return zero, true, v
+		}
+		return zero, false, nil
+	}
+
+	for _, rule := range r.rules {
+		if !rule.match(prompt) {
+			continue
+		}
+		if v, ok, err := accept(rule.result); ok {
+			return v, err
+		}
+	}
+
+	for _, result := range r.defaults {
+		if v, ok, err := accept(result); ok {
+			return v, err
+		}
+	}
+
+	return zero, fmt.Errorf("%w: %q", ErrNoRoute, prompt)
+}
+
+// Chat returns the chat result routed for the prompt.
+func (r *RouterAgent) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
+	if err := r.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := resolve[*response.ChatResponse](r, prompt)
+	return r.chatUsage(prompt, resp), err
+}
+
+// ChatStream streams the content of the chat result routed for the prompt as
+// a single chunk.
+func (r *RouterAgent) ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	if err := r.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := resolve[*response.ChatResponse](r, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return streamResponse(r.chatUsage(prompt, resp)), nil
+}
+
+// Vision returns the chat result routed for the prompt.
+func (r *RouterAgent) Vision(ctx context.Context, prompt string, images []string, opts ...map[string]any) (*response.ChatResponse, error) {
+	if err := r.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := resolve[*response.ChatResponse](r, prompt)
+	return r.chatUsage(prompt, resp), err
+}
+
+// VisionStream streams the content of the chat result routed for the prompt
+// as a single chunk.
+func (r *RouterAgent) VisionStream(ctx context.Context, prompt string, images []string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	return r.ChatStream(ctx, prompt, opts...)
+}
+
+// Tools returns the tools result routed for the prompt.
+func (r *RouterAgent) Tools(ctx context.Context, prompt string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	if err := r.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := resolve[*response.ToolsResponse](r, prompt)
+	return r.toolsUsage(prompt, resp), err
+}
+
+// VisionTools returns the tools result routed for the prompt.
+func (r *RouterAgent) VisionTools(ctx context.Context, prompt string, images []string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	if err := r.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := resolve[*response.ToolsResponse](r, prompt)
+	return r.toolsUsage(prompt, resp), err
+}
+
+// Embed returns the embeddings result routed for the input.
+func (r *RouterAgent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
+	if err := r.faults.inject(); err != nil {
+		return nil, err
+	}
+	resp, err := resolve[*response.EmbeddingsResponse](r, input)
+	return r.embeddingsUsage(input, resp), err
+}
+
+// streamResponse returns a closed channel holding one chunk with the
+// response's content, finish reason, and usage, or no chunks for a nil response.
+func streamResponse(resp *response.ChatResponse) <-chan *response.StreamingChunk {
+	ch := make(chan *response.StreamingChunk, 1)
+	defer close(ch)
+	if resp == nil {
+		return ch
+	}
+
+	chunk := &response.StreamingChunk{Model: resp.Model, Usage: resp.Usage}
+	for _, choice := range resp.Choices {
+		content, _ := choice.Message.Content.(string)
+		finish := choice.FinishReason
+		if finish == "" {
+			finish = response.FinishReasonStop
+		}
+		chunk.Choices = append(chunk.Choices, response.StreamingChoice{
+			Index:        choice.Index,
+			Delta:        response.StreamingDelta{Role: choice.Message.Role, Content: content},
+			FinishReason: &finish,
+		})
+	}
+
+	ch <- chunk
+	return ch
+}
+
+// Verify RouterAgent implements agent.Agent interface.
+var _ agent.Agent = (*RouterAgent)(nil)
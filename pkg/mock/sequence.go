@@ -0,0 +1,94 @@
+package mock
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// sequence returns scripted results in call order.
+// Each result is a response of type T, an error, or nil. Once exhausted, the last
+// result is repeated for every later call.
+// Thread-safe for concurrent calls.
+type sequence[T any] struct {
+	mu      sync.Mutex
+	results []any
+	next    int
+}
+
+// newSequence creates a sequence from results, panicking if a result is
+// neither a T nor an error so misconfigured tests fail immediately.
+func newSequence[T any](results []any) *sequence[T] {
+	for i, result := range results {
+		switch result.(type) {
+		case T, error, nil:
+		default:
+			var zero T
+			panic(fmt.Sprintf("mock: result %d is %T, want %T or error", i, result, zero))
+		}
+	}
+	return &sequence[T]{results: results}
+}
+
+// pop returns the next scripted result.
+// Returns the zero value and nil error if no results were configured.
+func (s *sequence[T]) pop() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zero T
+	if len(s.results) == 0 {
+		return zero, nil
+	}
+
+	result := s.results[min(s.next, len(s.results)-1)]
+	s.next++
+
+	switch r := result.(type) {
+	case error:
+		return zero, r
+	case T:
+		return r, nil
+	default:
+		return zero, nil
+	}
+}
+
+// WithChatResponses scripts Chat results in call order: the Nth call returns
+// the Nth result, and the last result repeats once the script is exhausted.
+// Each result is a *response.ChatResponse or an error.
+// Overrides WithChatResponse. Panics if a result has any other type.
+//
+// Example:
+//
+//	agent := mock.NewMockAgent(mock.WithChatResponses(first, second, errors.New("rate limited")))
+func WithChatResponses(results ...any) MockAgentOption {
+	return func(m *MockAgent) {
+		m.chatSequence = newSequence[*response.ChatResponse](results)
+	}
+}
+
+// WithVisionResponses scripts Vision results in call order, like WithChatResponses.
+// Each result is a *response.ChatResponse or an error.
+func WithVisionResponses(results ...any) MockAgentOption {
+	return func(m *MockAgent) {
+		m.visionSequence = newSequence[*response.ChatResponse](results)
+	}
+}
+
+// WithToolsResponses scripts Tools and VisionTools results in call order,
+// like WithChatResponses. Each result is a *response.ToolsResponse or an error.
+func WithToolsResponses(results ...any) MockAgentOption {
+	return func(m *MockAgent) {
+		m.toolsSequence = newSequence[*response.ToolsResponse](results)
+	}
+}
+
+// WithEmbeddingsResponses scripts Embed results in call order, like
+// WithChatResponses. Each result is a *response.EmbeddingsResponse or an error.
+func WithEmbeddingsResponses(results ...any) MockAgentOption {
+	return func(m *MockAgent) {
+		m.embeddingsSequence = newSequence[*response.EmbeddingsResponse](results)
+	}
+}
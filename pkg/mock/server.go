@@ -0,0 +1,302 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Server is an in-process OpenAI-compatible HTTP server for integration tests
+// of real providers, clients, and agents. It serves chat completions (with
+// SSE streaming and tools) and embeddings from canned or scripted responses,
+// and captures every request it receives.
+//
+// Any path ending in /chat/completions or /embeddings is served, so the
+// server works as the base URL for both the Ollama and Azure providers.
+// Scripted results are returned in call order like WithChatResponses; an
+// error result is sent as an OpenAI error body with the status code of a
+// *client.HTTPStatusError, or 500 for other errors.
+//
+// Example:
+//
+//	srv := mock.NewServer(mock.WithServerChatResponses(resp))
+//	defer srv.Close()
+//
+//	cfg.Provider = &config.ProviderConfig{Name: "ollama", BaseURL: srv.URL}
+//	a, _ := agent.New(cfg)
+//	a.Chat(ctx, "Hello")
+//	body := srv.LastRequest().Body
+type Server struct {
+	*httptest.Server
+
+	chat       *sequence[*response.ChatResponse]
+	tools      *sequence[*response.ToolsResponse]
+	embeddings *sequence[*response.EmbeddingsResponse]
+	chunks     []*response.StreamingChunk
+
+	mu       sync.Mutex
+	requests []CapturedRequest
+}
+
+// CapturedRequest records a request received by a Server.
+// Body holds the decoded JSON body, or nil if it was not a JSON object.
+type CapturedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   map[string]any
+	Raw    []byte
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithServerChatResponses scripts chat completion results in call order.
+// Each result is a *response.ChatResponse or an error. Without chat results
+// the server replies with "mock response".
+func WithServerChatResponses(results ...any) ServerOption {
+	return func(s *Server) {
+		s.chat = newSequence[*response.ChatResponse](results)
+	}
+}
+
+// WithServerToolsResponses scripts results for chat completions that include
+// tools, in call order. Each result is a *response.ToolsResponse or an error.
+// Without tools results, tools requests use the chat results.
+func WithServerToolsResponses(results ...any) ServerOption {
+	return func(s *Server) {
+		s.tools = newSequence[*response.ToolsResponse](results)
+	}
+}
+
+// WithServerEmbeddingsResponses scripts embeddings results in call order.
+// Each result is a *response.EmbeddingsResponse or an error. Without
+// embeddings results the server returns a zero vector per input.
+func WithServerEmbeddingsResponses(results ...any) ServerOption {
+	return func(s *Server) {
+		s.embeddings = newSequence[*response.EmbeddingsResponse](results)
+	}
+}
+
+// WithServerStreamChunks sets the chunks sent for streaming chat completions.
+// Without chunks, streams send the next chat or tools result as one chunk.
+// A chunk with Error set is sent as an error event and ends the stream.
+func WithServerStreamChunks(chunks ...*response.StreamingChunk) ServerOption {
+	return func(s *Server) {
+		s.chunks = chunks
+	}
+}
+
+// NewServer starts a Server. Call Close when done.
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []CapturedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]CapturedRequest(nil), s.requests...)
+}
+
+// LastRequest returns the most recent request, or nil if none was received.
+func (s *Server) LastRequest() *CapturedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return nil
+	}
+	req := s.requests[len(s.requests)-1]
+	return &req
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	raw, _ := io.ReadAll(r.Body)
+	captured := CapturedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Raw:    raw,
+	}
+	json.Unmarshal(raw, &captured.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, captured)
+	s.mu.Unlock()
+
+	switch {
+	case r.Method != http.MethodPost:
+		writeServerError(w, http.StatusMethodNotAllowed, "method not allowed")
+	case strings.HasSuffix(r.URL.Path, "/chat/completions"):
+		s.serveChat(w, captured.Body)
+	case strings.HasSuffix(r.URL.Path, "/embeddings"):
+		s.serveEmbeddings(w, captured.Body)
+	default:
+		writeServerError(w, http.StatusNotFound, "unknown endpoint "+r.URL.Path)
+	}
+}
+
+func (s *Server) serveChat(w http.ResponseWriter, body map[string]any) {
+	stream, _ := body["stream"].(bool)
+	_, hasTools := body["tools"]
+
+	var result any
+	var err error
+	switch {
+	case hasTools && s.tools != nil:
+		result, err = s.tools.pop()
+	case stream && s.chunks != nil:
+		// Configured chunks are streamed as-is
+	default:
+		result, err = s.nextChat()
+	}
+	if err != nil {
+		writeResultError(w, err)
+		return
+	}
+
+	if !stream {
+		writeServerJSON(w, result)
+		return
+	}
+
+	chunks := s.chunks
+	if result != nil {
+		chunks = []*response.StreamingChunk{resultChunk(result)}
+	}
+
+	ch := make(chan *response.StreamingChunk, len(chunks))
+	for _, chunk := range chunks {
+		ch <- chunk
+	}
+	close(ch)
+	response.StreamSSE(w, ch)
+}
+
+// nextChat returns the next scripted chat result or the default response.
+func (s *Server) nextChat() (*response.ChatResponse, error) {
+	if s.chat != nil {
+		return s.chat.pop()
+	}
+	resp := &response.ChatResponse{Model: "mock-model"}
+	resp.Choices = append(resp.Choices, response.ChatChoice{
+		Message:      protocol.NewMessage("assistant", "mock response"),
+		FinishReason: response.FinishReasonStop,
+	})
+	return resp, nil
+}
+
+func (s *Server) serveEmbeddings(w http.ResponseWriter, body map[string]any) {
+	if s.embeddings != nil {
+		resp, err := s.embeddings.pop()
+		if err != nil {
+			writeResultError(w, err)
+			return
+		}
+		writeServerJSON(w, resp)
+		return
+	}
+
+	inputs := 1
+	if list, ok := body["input"].([]any); ok {
+		inputs = len(list)
+	}
+
+	resp := &response.EmbeddingsResponse{Object: "list", Model: "mock-model"}
+	for i := range inputs {
+		resp.Data = append(resp.Data, response.EmbeddingData{
+			Embedding: []float64{0, 0, 0},
+			Index:     i,
+			Object:    "embedding",
+		})
+	}
+	writeServerJSON(w, resp)
+}
+
+// resultChunk converts a chat or tools response into a single streaming
+// chunk carrying its content, tool calls, finish reason, and usage.
+func resultChunk(result any) *response.StreamingChunk {
+	chunk := &response.StreamingChunk{Object: "chat.completion.chunk"}
+
+	switch resp := result.(type) {
+	case *response.ChatResponse:
+		if resp == nil {
+			return chunk
+		}
+		chunk.Model, chunk.Usage = resp.Model, resp.Usage
+		for _, choice := range resp.Choices {
+			content, _ := choice.Message.Content.(string)
+			chunk.Choices = append(chunk.Choices, streamingChoice(choice.Index, choice.Message.Role, content, nil, choice.FinishReason))
+		}
+	case *response.ToolsResponse:
+		if resp == nil {
+			return chunk
+		}
+		chunk.Model, chunk.Usage = resp.Model, resp.Usage
+		for _, choice := range resp.Choices {
+			chunk.Choices = append(chunk.Choices, streamingChoice(choice.Index, choice.Message.Role, choice.Message.Content, choice.Message.ToolCalls, choice.FinishReason))
+		}
+	}
+
+	return chunk
+}
+
+func streamingChoice(index int, role, content string, calls []response.ToolCall, finish response.FinishReason) response.StreamingChoice {
+	if finish == "" {
+		finish = response.FinishReasonStop
+		if len(calls) > 0 {
+			finish = response.FinishReasonToolCalls
+		}
+	}
+
+	delta := response.StreamingDelta{Role: role, Content: content}
+	for i, call := range calls {
+		delta.ToolCalls = append(delta.ToolCalls, response.ToolCallDelta{
+			Index:    i,
+			ID:       call.ID,
+			Type:     call.Type,
+			Function: call.Function,
+		})
+	}
+
+	return response.StreamingChoice{Index: index, Delta: delta, FinishReason: &finish}
+}
+
+// writeResultError writes a scripted error result, using the status code of
+// a *client.HTTPStatusError or 500 otherwise.
+func writeResultError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var statusErr *client.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		status = statusErr.StatusCode
+	}
+	writeServerError(w, status, err.Error())
+}
+
+func writeServerError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]any{"message": message, "type": "mock_error"},
+	})
+}
+
+func writeServerJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
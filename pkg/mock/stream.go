@@ -0,0 +1,104 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// StreamFault interrupts and paces mock streams to simulate provider failures.
+// After chunks are sent before the stream fails: with Err set, a final chunk
+// carrying Err is sent; otherwise the channel closes abruptly without a
+// finish reason. A negative After sends every configured chunk first.
+// Delay pauses before each chunk, giving tests a window to cancel the
+// context; cancellation closes the stream between chunks. With Clock set,
+// delays are measured on it, so tests release each chunk with Clock.Advance.
+type StreamFault struct {
+	After int
+	Err   error
+	Delay time.Duration
+	Clock *Clock
+}
+
+// after returns a channel that fires once the fault's delay has passed.
+func (f *StreamFault) after() <-chan time.Time {
+	if f.Clock != nil {
+		return f.Clock.After(f.Delay)
+	}
+	return time.After(f.Delay)
+}
+
+// WithStreamFault makes ChatStream and VisionStream deliver chunks from a
+// goroutine that applies the fault and honors context cancellation.
+//
+// Example:
+//
+//	agent := mock.NewMockAgent(
+//	    mock.WithStreamChunks(chunks, nil),
+//	    mock.WithStreamFault(mock.StreamFault{After: 2, Err: io.ErrUnexpectedEOF}),
+//	)
+func WithStreamFault(f StreamFault) MockAgentOption {
+	return func(m *MockAgent) {
+		m.streamFault = &f
+	}
+}
+
+// WithExecuteStreamFault makes ExecuteStream deliver chunks from a goroutine
+// that applies the fault and honors context cancellation.
+func WithExecuteStreamFault(f StreamFault) MockClientOption {
+	return func(m *MockClient) {
+		m.streamFault = &f
+	}
+}
+
+// streamChunks returns a channel delivering chunks. Without a fault the
+// channel is pre-filled and closed; with one, chunks are sent from a
+// goroutine that stops when the context is cancelled.
+func streamChunks(ctx context.Context, chunks []*response.StreamingChunk, fault *StreamFault) <-chan *response.StreamingChunk {
+	if fault == nil {
+		ch := make(chan *response.StreamingChunk, len(chunks))
+		for _, chunk := range chunks {
+			ch <- chunk
+		}
+		close(ch)
+		return ch
+	}
+
+	limit := len(chunks)
+	if fault.After >= 0 {
+		limit = min(fault.After, limit)
+	}
+
+	ch := make(chan *response.StreamingChunk)
+	go func() {
+		defer close(ch)
+
+		send := func(chunk *response.StreamingChunk) bool {
+			if fault.Delay > 0 {
+				select {
+				case <-fault.after():
+				case <-ctx.Done():
+					return false
+				}
+			}
+			select {
+			case ch <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, chunk := range chunks[:limit] {
+			if !send(chunk) {
+				return
+			}
+		}
+
+		if fault.Err != nil {
+			send(&response.StreamingChunk{Error: fault.Err})
+		}
+	}()
+	return ch
+}
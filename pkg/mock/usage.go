@@ -0,0 +1,129 @@
+package mock
+
+import (
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/tokenizer"
+)
+
+// UsageFunc computes simulated token usage from a call's prompt (or
+// embeddings input) and the text of the returned response. Completion is
+// empty for embeddings.
+type UsageFunc func(prompt, completion string) response.TokenUsage
+
+// WithUsage reports fixed token counts on every response that does not
+// already carry usage. Embeddings report prompt tokens only.
+func WithUsage(promptTokens, completionTokens int) MockAgentOption {
+	return WithUsageFunc(func(prompt, completion string) response.TokenUsage {
+		usage := response.TokenUsage{PromptTokens: promptTokens}
+		if completion != "" {
+			usage.CompletionTokens = completionTokens
+		}
+		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+		return usage
+	})
+}
+
+// WithEstimatedUsage reports token counts derived from the prompt and
+// response text with the mock model's tokenizer, on every response that does
+// not already carry usage.
+func WithEstimatedUsage() MockAgentOption {
+	return func(m *MockAgent) {
+		m.usage = func(prompt, completion string) response.TokenUsage {
+			tok := tokenizer.ForModel(m.mockModel.Name)
+			promptTokens, completionTokens := tok.Count(prompt), tok.Count(completion)
+			return response.TokenUsage{
+				PromptTokens:     promptTokens,
+				CompletionTokens: completionTokens,
+				TotalTokens:      promptTokens + completionTokens,
+			}
+		}
+	}
+}
+
+// WithUsageFunc reports usage computed by fn on every response that does not
+// already carry usage. Streams report it on the final chunk.
+func WithUsageFunc(fn UsageFunc) MockAgentOption {
+	return func(m *MockAgent) {
+		m.usage = fn
+	}
+}
+
+// WithPricing sets per-1K token pricing on the mock model so cost tracking
+// can be computed from simulated usage.
+func WithPricing(pricing *config.PricingConfig) MockAgentOption {
+	return func(m *MockAgent) {
+		m.pricing = pricing
+	}
+}
+
+// chatUsage returns a copy of resp with simulated usage, or resp unchanged
+// when simulation is disabled or resp already carries usage.
+func (m *MockAgent) chatUsage(prompt string, resp *response.ChatResponse) *response.ChatResponse {
+	if m.usage == nil || resp == nil || resp.Usage != nil {
+		return resp
+	}
+
+	simulated := *resp
+	usage := m.usage(prompt, resp.Content())
+	simulated.Usage = &usage
+	return &simulated
+}
+
+// toolsUsage returns a copy of resp with simulated usage counting content and
+// tool call names and arguments as completion text.
+func (m *MockAgent) toolsUsage(prompt string, resp *response.ToolsResponse) *response.ToolsResponse {
+	if m.usage == nil || resp == nil || resp.Usage != nil {
+		return resp
+	}
+
+	var completion strings.Builder
+	for _, choice := range resp.Choices {
+		completion.WriteString(choice.Message.Content)
+		for _, call := range choice.Message.ToolCalls {
+			completion.WriteString(call.Function.Name)
+			completion.WriteString(call.Function.Arguments)
+		}
+	}
+
+	simulated := *resp
+	usage := m.usage(prompt, completion.String())
+	simulated.Usage = &usage
+	return &simulated
+}
+
+// embeddingsUsage returns a copy of resp with simulated prompt-only usage.
+func (m *MockAgent) embeddingsUsage(input string, resp *response.EmbeddingsResponse) *response.EmbeddingsResponse {
+	if m.usage == nil || resp == nil || resp.Usage != nil {
+		return resp
+	}
+
+	simulated := *resp
+	usage := m.usage(input, "")
+	simulated.Usage = &usage
+	return &simulated
+}
+
+// streamUsage replaces the final chunk with a copy carrying simulated usage
+// for the streamed content, unless any chunk already carries usage.
+func (m *MockAgent) streamUsage(prompt string, chunks []*response.StreamingChunk) []*response.StreamingChunk {
+	if m.usage == nil || len(chunks) == 0 {
+		return chunks
+	}
+
+	var completion strings.Builder
+	for _, chunk := range chunks {
+		if chunk.Usage != nil {
+			return chunks
+		}
+		completion.WriteString(chunk.Content())
+	}
+
+	last := *chunks[len(chunks)-1]
+	usage := m.usage(prompt, completion.String())
+	last.Usage = &usage
+	chunks[len(chunks)-1] = &last
+	return chunks
+}
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"maps"
+
 	"github.com/tailored-agentic-units/tau-core/pkg/config"
 	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
 )
@@ -19,6 +21,54 @@ type Model struct {
 	// Keys are protocols (Chat, Vision, Tools, Embeddings).
 	// Values are option maps for that protocol (temperature, max_tokens, etc.)
 	Options map[protocol.Protocol]map[string]any
+
+	// Pricing holds per-1K token costs for cost tracking, or nil if not configured.
+	Pricing *config.PricingConfig
+
+	// ContextWindow is the maximum prompt plus output tokens, or 0 if unknown.
+	ContextWindow int
+
+	// MaxOutputTokens is the maximum tokens the model can generate, or 0 if unknown.
+	MaxOutputTokens int
+
+	// Features flags supported features (config.FeatureVision, etc.).
+	// Features not listed are assumed supported.
+	Features map[string]bool
+
+	// Advisory holds the limits (config.LimitContextWindow, etc.) and features
+	// filled in from a preset rather than configured. They are reported but
+	// not enforced against requests.
+	Advisory map[string]bool
+
+	// Formats holds the wire format selected for each protocol by its
+	// capability's "format" setting (see FormatOption). Protocols without
+	// one use the provider's format.
+	Formats map[protocol.Protocol]string
+}
+
+// FormatOption is the capability key that selects the wire format requests
+// for the protocol are marshaled and parsed in, e.g. "openai-chat" or
+// "anthropic" (see providers.LookupCodec). The value "json" and object values
+// are Ollama's JSON output setting instead, and are sent as request options.
+const FormatOption = "format"
+
+// Format returns the wire format selected for a protocol, or an empty string
+// to use the provider's format.
+func (m *Model) Format(p protocol.Protocol) string {
+	return m.Formats[p]
+}
+
+// Supports reports whether the model supports a feature.
+// Returns true unless the feature is explicitly disabled in configuration.
+func (m *Model) Supports(feature string) bool {
+	supported, listed := m.Features[feature]
+	return !listed || supported
+}
+
+// Enforces reports whether a limit or feature was configured rather than
+// filled in from a preset, so requests are checked against it.
+func (m *Model) Enforces(name string) bool {
+	return !m.Advisory[name]
 }
 
 // New creates a Model from a ModelConfig.
@@ -26,13 +76,28 @@ type Model struct {
 // This bridges the gap between JSON configuration structure and runtime domain type.
 func New(cfg *config.ModelConfig) *Model {
 	model := &Model{
-		Name:    cfg.Name,
-		Options: make(map[protocol.Protocol]map[string]any),
+		Name:            cfg.Name,
+		Options:         make(map[protocol.Protocol]map[string]any),
+		Pricing:         cfg.Pricing,
+		ContextWindow:   cfg.ContextWindow,
+		MaxOutputTokens: cfg.MaxOutputTokens,
+		Features:        maps.Clone(cfg.Supports),
+		Advisory:        make(map[string]bool, len(cfg.Advisory)),
+		Formats:         make(map[protocol.Protocol]string),
+	}
+
+	for _, name := range cfg.Advisory {
+		model.Advisory[name] = true
 	}
 
 	// Convert string keys to Protocol constants
 	for protocolName, options := range cfg.Capabilities {
 		p := protocol.Protocol(protocolName)
+		if format, ok := options[FormatOption].(string); ok && format != "json" {
+			model.Formats[p] = format
+			options = maps.Clone(options)
+			delete(options, FormatOption)
+		}
 		model.Options[p] = options
 	}
 
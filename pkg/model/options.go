@@ -0,0 +1,323 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// ErrInvalidOption is the sentinel wrapped by every OptionError.
+var ErrInvalidOption = errors.New("invalid option")
+
+// OptionType identifies the JSON type accepted by an option.
+type OptionType string
+
+const (
+	TypeNumber  OptionType = "number"
+	TypeInteger OptionType = "integer"
+	TypeString  OptionType = "string"
+	TypeBool    OptionType = "bool"
+	TypeArray   OptionType = "array"
+	TypeObject  OptionType = "object"
+)
+
+// OptionSpec describes the accepted values for an option.
+// Types lists the accepted JSON types; an empty list accepts any type.
+// Min and Max bound numeric values. Enum restricts string values.
+type OptionSpec struct {
+	Types []OptionType
+	Min   *float64
+	Max   *float64
+	Enum  []string
+}
+
+// OptionSchema maps option keys to their specifications for a protocol.
+type OptionSchema map[string]OptionSpec
+
+// OptionValidation selects how options are validated against schemas.
+type OptionValidation string
+
+const (
+	// OptionValidationStrict rejects unknown keys and invalid values.
+	OptionValidationStrict OptionValidation = "strict"
+
+	// OptionValidationLenient checks known keys and allows unknown keys through.
+	OptionValidationLenient OptionValidation = "lenient"
+
+	// OptionValidationOff disables option validation.
+	OptionValidationOff OptionValidation = "off"
+)
+
+// IsValid reports whether v is a recognized validation mode.
+func (v OptionValidation) IsValid() bool {
+	switch v {
+	case OptionValidationStrict, OptionValidationLenient, OptionValidationOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// OptionError describes an option that failed validation.
+// Suggestion holds the closest known key when Key is unknown.
+type OptionError struct {
+	Protocol   protocol.Protocol
+	Key        string
+	Reason     string
+	Suggestion string
+}
+
+// Error formats the validation failure, including any suggested key.
+func (e *OptionError) Error() string {
+	msg := fmt.Sprintf("option %q for %s protocol %s", e.Key, e.Protocol, e.Reason)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return msg
+}
+
+// Unwrap returns ErrInvalidOption so errors.Is matches all option failures.
+func (e *OptionError) Unwrap() error {
+	return ErrInvalidOption
+}
+
+func spec(types ...OptionType) OptionSpec {
+	return OptionSpec{Types: types}
+}
+
+func bounded(t OptionType, lo, hi float64) OptionSpec {
+	return OptionSpec{Types: []OptionType{t}, Min: &lo, Max: &hi}
+}
+
+func enum(values ...string) OptionSpec {
+	return OptionSpec{Types: []OptionType{TypeString}, Enum: values}
+}
+
+// generationSchema covers options shared by the chat, vision, and tools protocols
+// across OpenAI-compatible providers, Azure, and Ollama.
+func generationSchema() OptionSchema {
+	return OptionSchema{
+		"temperature":           bounded(TypeNumber, 0, 2),
+		"top_p":                 bounded(TypeNumber, 0, 1),
+		"top_k":                 bounded(TypeInteger, 1, math.MaxInt32),
+		"min_p":                 bounded(TypeNumber, 0, 1),
+		"max_tokens":            bounded(TypeInteger, 1, math.MaxInt32),
+		"max_completion_tokens": bounded(TypeInteger, 1, math.MaxInt32),
+		"n":                     bounded(TypeInteger, 1, 128),
+		"stop":                  spec(TypeString, TypeArray),
+		"presence_penalty":      bounded(TypeNumber, -2, 2),
+		"frequency_penalty":     bounded(TypeNumber, -2, 2),
+		"repeat_penalty":        spec(TypeNumber),
+		"seed":                  spec(TypeInteger),
+		"logprobs":              spec(TypeBool),
+		"top_logprobs":          bounded(TypeInteger, 0, 20),
+		"logit_bias":            spec(TypeObject),
+		"user":                  spec(TypeString),
+		"response_format":       spec(TypeObject),
+		"stream":                spec(TypeBool),
+		"stream_options":        spec(TypeObject),
+		"reasoning_effort":      enum("minimal", "low", "medium", "high"),
+		"service_tier":          spec(TypeString),
+		"metadata":              spec(TypeObject),
+		"store":                 spec(TypeBool),
+		"modalities":            spec(TypeArray),
+		"prediction":            spec(TypeObject),
+		"tool_choice":           spec(TypeString, TypeObject),
+		"parallel_tool_calls":   spec(TypeBool),
+		"data_sources":          spec(TypeArray),
+		"num_ctx":               bounded(TypeInteger, 1, math.MaxInt32),
+		"keep_alive":            spec(TypeString, TypeNumber),
+		"format":                spec(TypeString, TypeObject),
+		"think":                 spec(TypeBool, TypeString),
+		"grammar":               spec(TypeString),
+		"json_schema":           spec(TypeObject),
+		"cache_prompt":          spec(TypeBool),
+		"deferred":              spec(TypeBool),
+		"extra_body":            spec(TypeObject),
+		"options":               spec(TypeObject),
+	}
+}
+
+// schemas holds the registered option schemas by protocol.
+// It is thread-safe for concurrent registration and validation.
+var schemas = struct {
+	byProtocol map[protocol.Protocol]OptionSchema
+	mu         sync.RWMutex
+}{
+	byProtocol: map[protocol.Protocol]OptionSchema{
+		protocol.Chat:   generationSchema(),
+		protocol.Vision: generationSchema(),
+		protocol.Tools:  generationSchema(),
+		protocol.Embeddings: {
+			"encoding_format": enum("float", "base64"),
+			"dimensions":      bounded(TypeInteger, 1, math.MaxInt32),
+			"user":            spec(TypeString),
+			"input_type":      spec(TypeString),
+			"truncate":        spec(TypeBool),
+			"keep_alive":      spec(TypeString, TypeNumber),
+			"extra_body":      spec(TypeObject),
+			"options":         spec(TypeObject),
+		},
+	},
+}
+
+// RegisterOption adds or replaces an option specification for a protocol.
+// Use to allow provider-specific options under strict validation.
+// Thread-safe for concurrent registration.
+func RegisterOption(p protocol.Protocol, key string, s OptionSpec) {
+	schemas.mu.Lock()
+	defer schemas.mu.Unlock()
+
+	if schemas.byProtocol[p] == nil {
+		schemas.byProtocol[p] = make(OptionSchema)
+	}
+	schemas.byProtocol[p][key] = s
+}
+
+// Schema returns a copy of the option schema registered for a protocol.
+func Schema(p protocol.Protocol) OptionSchema {
+	schemas.mu.RLock()
+	defer schemas.mu.RUnlock()
+	return maps.Clone(schemas.byProtocol[p])
+}
+
+// ValidateOptions checks options against the protocol schema.
+// In strict mode unknown keys are rejected with the closest known key as a
+// suggestion; in lenient mode only known keys are checked. Values must match
+// the specified types, ranges, and enumerations.
+// Returns the first *OptionError found, checking keys in sorted order.
+func ValidateOptions(p protocol.Protocol, opts map[string]any, mode OptionValidation) error {
+	if mode == OptionValidationOff {
+		return nil
+	}
+
+	schema := Schema(p)
+
+	for _, key := range slices.Sorted(maps.Keys(opts)) {
+		s, known := schema[key]
+		if !known {
+			if mode == OptionValidationLenient {
+				continue
+			}
+			return &OptionError{
+				Protocol:   p,
+				Key:        key,
+				Reason:     "is not recognized",
+				Suggestion: closestKey(key, schema),
+			}
+		}
+
+		if reason := s.check(opts[key]); reason != "" {
+			return &OptionError{Protocol: p, Key: key, Reason: reason}
+		}
+	}
+
+	return nil
+}
+
+// check returns a description of why value violates the spec, or "" if valid.
+func (s OptionSpec) check(value any) string {
+	if len(s.Types) > 0 && !slices.ContainsFunc(s.Types, func(t OptionType) bool { return matchesType(t, value) }) {
+		names := make([]string, len(s.Types))
+		for i, t := range s.Types {
+			names[i] = string(t)
+		}
+		return fmt.Sprintf("must be %s, got %T", strings.Join(names, " or "), value)
+	}
+
+	if n, ok := toFloat(value); ok {
+		if s.Min != nil && n < *s.Min {
+			return fmt.Sprintf("must be at least %g, got %g", *s.Min, n)
+		}
+		if s.Max != nil && n > *s.Max {
+			return fmt.Sprintf("must be at most %g, got %g", *s.Max, n)
+		}
+	}
+
+	if str, ok := value.(string); ok && len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+		return fmt.Sprintf("must be one of %s, got %q", strings.Join(s.Enum, ", "), str)
+	}
+
+	return ""
+}
+
+func matchesType(t OptionType, value any) bool {
+	switch t {
+	case TypeNumber:
+		_, ok := toFloat(value)
+		return ok
+	case TypeInteger:
+		n, ok := toFloat(value)
+		return ok && n == math.Trunc(n)
+	case TypeString:
+		_, ok := value.(string)
+		return ok
+	case TypeBool:
+		_, ok := value.(bool)
+		return ok
+	case TypeArray:
+		kind := reflect.ValueOf(value).Kind()
+		return kind == reflect.Slice || kind == reflect.Array
+	case TypeObject:
+		kind := reflect.Indirect(reflect.ValueOf(value)).Kind()
+		return kind == reflect.Map || kind == reflect.Struct
+	default:
+		return false
+	}
+}
+
+func toFloat(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// closestKey returns the schema key nearest to key by edit distance,
+// or "" when no key is close enough to be a likely typo.
+func closestKey(key string, schema OptionSchema) string {
+	best, bestDistance := "", max(2, len(key)/3)+1
+	for _, candidate := range slices.Sorted(maps.Keys(schema)) {
+		if d := editDistance(key, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,22 @@
+// Package options provides typed helpers for building request option maps.
+// Agent methods accept options as map[string]any; helpers in this package
+// produce the correct keys and value shapes so callers don't have to remember
+// provider wire names:
+//
+//	opts := options.New(
+//	    options.Set("temperature", 0.2),
+//	    options.Logprobs(5),
+//	    options.Stop("\n\n", "END"),
+//	)
+//	response, err := agent.Chat(ctx, "Pick a number", opts)
+//
+// Structured output helpers set response_format:
+//
+//	opts := options.New(options.JSONSchema("weather", map[string]any{
+//	    "type":       "object",
+//	    "properties": map[string]any{"city": map[string]any{"type": "string"}},
+//	    "required":   []string{"city"},
+//	}))
+//
+// Options are applied in order, so later options override earlier ones.
+package options
@@ -0,0 +1,237 @@
+package options
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// Option sets one or more entries in a request option map.
+type Option func(map[string]any)
+
+// New creates an option map by applying the given options in order.
+func New(opts ...Option) map[string]any {
+	options := make(map[string]any)
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// Set sets an arbitrary option key to the given value.
+func Set(key string, value any) Option {
+	return func(o map[string]any) {
+		o[key] = value
+	}
+}
+
+// From copies every entry of an existing option map.
+// Useful for layering typed helpers over options loaded from configuration.
+func From(source map[string]any) Option {
+	return func(o map[string]any) {
+		maps.Copy(o, source)
+	}
+}
+
+// Choices requests n completions for the prompt.
+// Read the results with ChatResponse.Contents or ChatResponse.Choice.
+func Choices(n int) Option {
+	return func(o map[string]any) {
+		o["n"] = n
+	}
+}
+
+// Logprobs enables token log probabilities in the response.
+// topN sets how many alternative tokens are returned per position (0-20);
+// values less than 1 request log probabilities for generated tokens only.
+func Logprobs(topN int) Option {
+	return func(o map[string]any) {
+		o["logprobs"] = true
+		if topN > 0 {
+			o["top_logprobs"] = min(topN, 20)
+		} else {
+			delete(o, "top_logprobs")
+		}
+	}
+}
+
+// Stop sets the sequences at which generation stops.
+// Sequences are stored under the OpenAI "stop" key; providers with a different
+// wire name (stop_sequences for Anthropic, stopSequences for Gemini) rename it
+// when marshaling, so the same options work across providers.
+// Calling Stop with no sequences removes any previously set stop option.
+func Stop(sequences ...string) Option {
+	return func(o map[string]any) {
+		if len(sequences) == 0 {
+			delete(o, "stop")
+			return
+		}
+		o["stop"] = slices.Clone(sequences)
+	}
+}
+
+// JSONMode constrains output to a valid JSON object using the OpenAI
+// response_format {"type": "json_object"}. Most providers also require the
+// prompt to mention JSON. Requests are rejected before sending when the model
+// disables the json_mode feature.
+func JSONMode() Option {
+	return func(o map[string]any) {
+		o["response_format"] = map[string]any{"type": "json_object"}
+	}
+}
+
+// JSONSchema constrains output to the given JSON Schema using a strict OpenAI
+// json_schema response_format. Name identifies the schema to the model.
+// Providers without response_format support translate it when marshaling
+// (Gemini uses generationConfig). Requests are rejected before sending when
+// the model disables the json_mode or json_schema feature.
+func JSONSchema(name string, schema map[string]any) Option {
+	return func(o map[string]any) {
+		o["response_format"] = map[string]any{
+			"type": "json_schema",
+			"json_schema": map[string]any{
+				"name":   name,
+				"schema": schema,
+				"strict": true,
+			},
+		}
+	}
+}
+
+// Grammar constrains generation to a GBNF grammar, enforced while sampling
+// by the llama.cpp server. It cannot be combined with JSONSchemaConstraint.
+func Grammar(gbnf string) Option {
+	return Set("grammar", gbnf)
+}
+
+// JSONSchemaConstraint constrains generation to JSON matching schema,
+// converted to a grammar by the llama.cpp server. Unlike JSONSchema, which
+// uses response_format, it needs no schema name and works on server versions
+// without response_format support. It cannot be combined with Grammar.
+func JSONSchemaConstraint(schema map[string]any) Option {
+	return Set("json_schema", schema)
+}
+
+// Deferred asks the provider to queue the request and return its ID instead
+// of waiting for the completion (xAI deferred completions). Execute then
+// fails with a *providers.DeferredCompletion carrying the ID, and the
+// completion is fetched with client.FetchDeferred.
+func Deferred() Option {
+	return Set("deferred", true)
+}
+
+// Extra adds vendor-specific fields to the request body without a typed
+// helper or a custom Marshal, such as vLLM guided_json or Groq service_tier.
+// Fields are stored under the extra_body option and merged verbatim into the
+// top level of the marshaled body, replacing any fields of the same name.
+// Repeated calls accumulate fields, later values winning.
+func Extra(fields map[string]any) Option {
+	return func(o map[string]any) {
+		extra, _ := o["extra_body"].(map[string]any)
+		extra = maps.Clone(extra)
+		if extra == nil {
+			extra = make(map[string]any, len(fields))
+		}
+		maps.Copy(extra, fields)
+		o["extra_body"] = extra
+	}
+}
+
+// ToolChoiceAuto lets the model decide whether to call a tool (the default).
+func ToolChoiceAuto() Option {
+	return Set("tool_choice", "auto")
+}
+
+// ToolChoiceNone prevents the model from calling tools.
+func ToolChoiceNone() Option {
+	return Set("tool_choice", "none")
+}
+
+// ToolChoiceRequired requires the model to call at least one tool.
+func ToolChoiceRequired() Option {
+	return Set("tool_choice", "required")
+}
+
+// ToolChoiceFunction forces the model to call the named function.
+// The name must match one of the request's tool definitions; requests naming
+// an unknown tool are rejected before sending.
+func ToolChoiceFunction(name string) Option {
+	return Set("tool_choice", map[string]any{
+		"type":     "function",
+		"function": map[string]any{"name": name},
+	})
+}
+
+// ParallelToolCalls enables or disables multiple tool calls in one response.
+func ParallelToolCalls(enabled bool) Option {
+	return Set("parallel_tool_calls", enabled)
+}
+
+// KeepAlive sets how long a local model server keeps the model loaded after
+// the request (Ollama keep_alive), avoiding reload latency between requests.
+// A negative duration keeps the model loaded indefinitely, and zero unloads it
+// as soon as the request completes. Providers without model residency ignore
+// or reject the option.
+func KeepAlive(d time.Duration) Option {
+	if d < 0 {
+		return Set("keep_alive", -1)
+	}
+	return Set("keep_alive", d.String())
+}
+
+// WebSearchConfig tunes the provider's built-in web search tool. Zero values
+// use the provider defaults; settings a provider has no equivalent for are
+// ignored.
+type WebSearchConfig struct {
+	// ContextSize is how much search context the model receives: "low",
+	// "medium", or "high" (OpenAI search_context_size).
+	ContextSize string
+
+	// MaxUses limits the searches per request (Anthropic max_uses).
+	MaxUses int
+
+	// AllowedDomains restricts results to these domains (Anthropic
+	// allowed_domains).
+	AllowedDomains []string
+}
+
+// WebSearch enables the provider's built-in web search tool, which the model
+// runs server-side before answering: OpenAI web_search_options, Anthropic's
+// web_search tool, or Gemini google_search grounding. Providers that report
+// the searches set each choice's Grounding. Requests are rejected before
+// sending when the model disables the web_search feature.
+func WebSearch(cfg WebSearchConfig) Option {
+	tool := map[string]any{"type": "web_search"}
+	if cfg.ContextSize != "" {
+		tool["search_context_size"] = cfg.ContextSize
+	}
+	if cfg.MaxUses > 0 {
+		tool["max_uses"] = cfg.MaxUses
+	}
+	if len(cfg.AllowedDomains) > 0 {
+		tool["allowed_domains"] = slices.Clone(cfg.AllowedDomains)
+	}
+	return builtinTool(tool)
+}
+
+// FileSearch enables the provider's built-in file search tool over the given
+// vector stores. Only providers with a file search tool accept it; others
+// reject the request when marshaling it.
+func FileSearch(vectorStoreIDs ...string) Option {
+	return builtinTool(map[string]any{
+		"type":             "file_search",
+		"vector_store_ids": slices.Clone(vectorStoreIDs),
+	})
+}
+
+// builtinTool adds a provider-side tool to the builtin_tools option,
+// replacing any existing tool of the same type.
+func builtinTool(tool map[string]any) Option {
+	return func(o map[string]any) {
+		existing, _ := o["builtin_tools"].([]map[string]any)
+		tools := slices.DeleteFunc(slices.Clone(existing), func(t map[string]any) bool {
+			return t["type"] == tool["type"]
+		})
+		o["builtin_tools"] = append(tools, tool)
+	}
+}
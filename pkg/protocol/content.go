@@ -0,0 +1,101 @@
+package protocol
+
+import "strings"
+
+// ContentPart is a single element of structured (multimodal) message content.
+// Type is "text" for text parts and "image_url" for image parts.
+type ContentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+// ImageURL references an image by URL or base64 data URI.
+// Detail optionally controls image resolution ("low", "high", "auto").
+type ImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// TextPart creates a text content part.
+func TextPart(text string) ContentPart {
+	return ContentPart{Type: "text", Text: text}
+}
+
+// ImagePart creates an image content part from a URL or base64 data URI.
+func ImagePart(url string) ContentPart {
+	return ContentPart{Type: "image_url", ImageURL: &ImageURL{URL: url}}
+}
+
+// ImagePartWithDetail creates an image content part with a detail level.
+func ImagePartWithDetail(url, detail string) ContentPart {
+	return ContentPart{Type: "image_url", ImageURL: &ImageURL{URL: url, Detail: detail}}
+}
+
+// NewPartsMessage creates a Message with structured content parts.
+//
+// Example:
+//
+//	msg := protocol.NewPartsMessage("user",
+//	    protocol.TextPart("What is in this image?"),
+//	    protocol.ImagePart("https://example.com/cat.jpg"),
+//	)
+func NewPartsMessage(role string, parts ...ContentPart) Message {
+	return Message{Role: role, Content: parts}
+}
+
+// Text extracts the text of the message content.
+// String content is returned as-is. Structured content, whether built from
+// ContentPart values or decoded from JSON into maps, returns the text of its
+// text parts joined by newlines; non-text parts are skipped.
+// Returns empty string for nil or unrecognized content.
+func (m Message) Text() string {
+	switch v := m.Content.(type) {
+	case string:
+		return v
+	case []ContentPart:
+		texts := make([]string, 0, len(v))
+		for _, part := range v {
+			if part.Type == "text" {
+				texts = append(texts, part.Text)
+			}
+		}
+		return strings.Join(texts, "\n")
+	case []map[string]any:
+		texts := make([]string, 0, len(v))
+		for _, part := range v {
+			if text, ok := partText(part); ok {
+				texts = append(texts, text)
+			}
+		}
+		return strings.Join(texts, "\n")
+	case []any:
+		texts := make([]string, 0, len(v))
+		for _, item := range v {
+			switch part := item.(type) {
+			case map[string]any:
+				if text, ok := partText(part); ok {
+					texts = append(texts, text)
+				}
+			case ContentPart:
+				if part.Type == "text" {
+					texts = append(texts, part.Text)
+				}
+			case string:
+				texts = append(texts, part)
+			}
+		}
+		return strings.Join(texts, "\n")
+	default:
+		return ""
+	}
+}
+
+// partText extracts the text of a map-form content part.
+func partText(part map[string]any) (string, bool) {
+	if partType, _ := part["type"].(string); partType != "text" {
+		return "", false
+	}
+	text, ok := part["text"].(string)
+	return text, ok
+}
@@ -4,9 +4,16 @@ package protocol
 // The Role indicates the message sender (user, assistant, system),
 // and Content can be either a string for text or a structured object
 // for multimodal content (e.g., vision protocol with images).
+// ToolCalls carries the function calls requested by an assistant message,
+// and ToolCallID identifies the call a "tool" role message is the result of.
+// Prefill marks a final, partial assistant message the model should continue
+// (see NewPrefillMessage); it is not serialized.
 type Message struct {
-	Role    string `json:"role"`
-	Content any    `json:"content"`
+	Role       string     `json:"role"`
+	Content    any        `json:"content"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+	Prefill    bool       `json:"-"`
 }
 
 // NewMessage creates a new Message with the specified role and content.
@@ -19,3 +26,48 @@ type Message struct {
 func NewMessage(role string, content any) Message {
 	return Message{Role: role, Content: content}
 }
+
+// NewToolMessage creates a "tool" role Message carrying the result of the
+// tool call with the given ID, to send back after an assistant message that
+// requested it.
+//
+// Example:
+//
+//	messages = append(messages, assistant)
+//	for _, call := range assistant.ToolCalls {
+//	    messages = append(messages, protocol.NewToolMessage(call.ID, run(call)))
+//	}
+func NewToolMessage(callID string, content any) Message {
+	return Message{Role: "tool", Content: content, ToolCallID: callID}
+}
+
+// NewPrefillMessage creates a partial assistant message for the model to
+// continue, such as an opening brace to force JSON output. It must be the
+// final message of a request, and only providers that support prefill
+// accept it (see providers.PrefillSupporter). The response contains only the
+// continuation, not the prefill itself.
+//
+// Example:
+//
+//	messages := []protocol.Message{
+//	    protocol.NewMessage("user", "List three colors as JSON."),
+//	    protocol.NewPrefillMessage("{"),
+//	}
+func NewPrefillMessage(content string) Message {
+	return Message{Role: "assistant", Content: content, Prefill: true}
+}
+
+// ToolCall represents a function call requested by the model.
+// Contains the call ID, type, and function details.
+type ToolCall struct {
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction contains the details of a function to be called.
+// Name specifies the function name, and Arguments contains JSON-encoded parameters.
+type ToolCallFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
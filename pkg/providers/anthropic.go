@@ -0,0 +1,234 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// AnthropicTool is a tool definition in the Anthropic Messages API format.
+type AnthropicTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+// AnthropicTools converts tool definitions to the Anthropic format, where the
+// JSON Schema is carried as input_schema. A definition without parameters
+// gets an empty object schema, which Anthropic requires.
+func AnthropicTools(tools []ToolDefinition) []AnthropicTool {
+	converted := make([]AnthropicTool, len(tools))
+	for i, tool := range tools {
+		schema := tool.Parameters
+		if schema == nil {
+			schema = map[string]any{"type": "object", "properties": map[string]any{}}
+		}
+		converted[i] = AnthropicTool{Name: tool.Name, Description: tool.Description, InputSchema: schema}
+	}
+	return converted
+}
+
+// AnthropicMessage is a message in the Anthropic Messages API format, whose
+// content is a list of blocks.
+type AnthropicMessage struct {
+	Role    string                  `json:"role"`
+	Content []AnthropicContentBlock `json:"content"`
+}
+
+// AnthropicContentBlock is a text, tool_use, or tool_result content block.
+// tool_use blocks carry ID, Name, and Input; tool_result blocks carry
+// ToolUseID and Content.
+type AnthropicContentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+}
+
+// AnthropicMessages converts OpenAI-style messages to the Anthropic format.
+// System and developer messages are returned joined as the top-level system
+// prompt. An assistant message's tool calls become tool_use blocks, with the
+// JSON-encoded arguments as the block input. Tool result messages (see
+// protocol.NewToolMessage) become tool_result blocks in a user message;
+// consecutive results are grouped into one message, as Anthropic expects all
+// results for a turn together. A final prefill message (see
+// protocol.NewPrefillMessage) is sent as a trailing assistant message, which
+// Anthropic continues, with trailing whitespace removed.
+//
+// Only text content is translated. Returns an error for messages with
+// non-text content parts, tool calls with invalid JSON arguments, tool
+// results without a call ID, and unknown roles.
+func AnthropicMessages(messages []protocol.Message) (string, []AnthropicMessage, error) {
+	var (
+		system    []string
+		converted []AnthropicMessage
+	)
+
+	for i, msg := range messages {
+		text, err := textContent(msg)
+		if err != nil {
+			return "", nil, fmt.Errorf("message %d: %w", i, err)
+		}
+
+		switch msg.Role {
+		case "system", "developer":
+			system = append(system, text)
+
+		case "user":
+			converted = append(converted, AnthropicMessage{
+				Role:    "user",
+				Content: []AnthropicContentBlock{{Type: "text", Text: text}},
+			})
+
+		case "assistant":
+			// Anthropic rejects a final assistant message ending in whitespace
+			if msg.Prefill {
+				text = strings.TrimRight(text, " \t\r\n")
+			}
+			var blocks []AnthropicContentBlock
+			if text != "" {
+				blocks = append(blocks, AnthropicContentBlock{Type: "text", Text: text})
+			}
+			for _, call := range msg.ToolCalls {
+				input, err := callArguments(call)
+				if err != nil {
+					return "", nil, fmt.Errorf("message %d: %w", i, err)
+				}
+				blocks = append(blocks, AnthropicContentBlock{
+					Type:  "tool_use",
+					ID:    call.ID,
+					Name:  call.Function.Name,
+					Input: input,
+				})
+			}
+			converted = append(converted, AnthropicMessage{Role: "assistant", Content: blocks})
+
+		case "tool":
+			if msg.ToolCallID == "" {
+				return "", nil, fmt.Errorf("message %d: tool result has no tool call ID", i)
+			}
+			block := AnthropicContentBlock{Type: "tool_result", ToolUseID: msg.ToolCallID, Content: text}
+			if last := len(converted) - 1; last >= 0 && isToolResults(converted[last]) {
+				converted[last].Content = append(converted[last].Content, block)
+				continue
+			}
+			converted = append(converted, AnthropicMessage{Role: "user", Content: []AnthropicContentBlock{block}})
+
+		default:
+			return "", nil, fmt.Errorf("message %d: unknown role %q", i, msg.Role)
+		}
+	}
+
+	return strings.Join(system, "\n\n"), converted, nil
+}
+
+// AnthropicDefaultMaxTokens is the max_tokens sent by MarshalAnthropicTools
+// when the request sets none, since Anthropic requires it.
+const AnthropicDefaultMaxTokens = 4096
+
+// MarshalAnthropicChat marshals chat request data as an Anthropic Messages
+// request body, as MarshalAnthropicTools does for a request without tools.
+func MarshalAnthropicChat(d *ChatData) ([]byte, error) {
+	return MarshalAnthropicTools(&ToolsData{Model: d.Model, Messages: d.Messages, Options: d.Options})
+}
+
+// MarshalAnthropicTools marshals tools request data as an Anthropic Messages
+// request body: system and messages from the messages (see
+// AnthropicMessages), tools from the definitions and built-in tools (see
+// AnthropicBuiltinTools), tool_choice and parallel_tool_calls as the
+// Anthropic tool_choice (see AnthropicToolChoiceOption), and stop as
+// stop_sequences. max_tokens defaults to AnthropicDefaultMaxTokens. Other
+// options are sent as-is.
+// Returns an error for requests with images, which need image content blocks
+// on Anthropic.
+func MarshalAnthropicTools(d *ToolsData) ([]byte, error) {
+	if len(d.Images) > 0 {
+		return nil, errors.New("anthropic tools requests do not support images")
+	}
+
+	system, messages, err := AnthropicMessages(d.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	builtin, options, err := AnthropicBuiltinTools(d.Options)
+	if err != nil {
+		return nil, err
+	}
+	options, err = AnthropicToolChoiceOption(options)
+	if err != nil {
+		return nil, err
+	}
+	options = StopOption(options, StopKeyAnthropic)
+
+	body := make(map[string]any, len(options)+5)
+	maps.Copy(body, options)
+	body["model"] = d.Model
+	body["messages"] = messages
+	if system != "" {
+		body["system"] = system
+	}
+	if _, ok := body["max_tokens"]; !ok {
+		body["max_tokens"] = AnthropicDefaultMaxTokens
+	}
+
+	tools := make([]any, 0, len(d.Tools)+len(builtin))
+	for _, tool := range AnthropicTools(d.Tools) {
+		tools = append(tools, tool)
+	}
+	for _, tool := range builtin {
+		tools = append(tools, tool)
+	}
+	if len(tools) > 0 {
+		body["tools"] = tools
+	}
+	return json.Marshal(body)
+}
+
+// textContent returns a message's text, rejecting structured content with
+// parts other than text.
+func textContent(msg protocol.Message) (string, error) {
+	switch msg.Content.(type) {
+	case nil, string:
+		return msg.Text(), nil
+	}
+
+	data, err := json.Marshal(msg.Content)
+	if err != nil {
+		return "", err
+	}
+	var parts []protocol.ContentPart
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return "", fmt.Errorf("unsupported content %T", msg.Content)
+	}
+	for _, part := range parts {
+		if part.Type != "text" {
+			return "", fmt.Errorf("unsupported content part type %q", part.Type)
+		}
+	}
+	return msg.Text(), nil
+}
+
+// callArguments returns a tool call's arguments as raw JSON, using an empty
+// object when there are none.
+func callArguments(call protocol.ToolCall) (json.RawMessage, error) {
+	if strings.TrimSpace(call.Function.Arguments) == "" {
+		return json.RawMessage("{}"), nil
+	}
+	if !json.Valid([]byte(call.Function.Arguments)) {
+		return nil, fmt.Errorf("tool call %s has invalid JSON arguments", call.ID)
+	}
+	return json.RawMessage(call.Function.Arguments), nil
+}
+
+// isToolResults reports whether msg is a user message of tool_result blocks.
+func isToolResults(msg AnthropicMessage) bool {
+	return msg.Role == "user" && len(msg.Content) > 0 && msg.Content[0].Type == "tool_result"
+}
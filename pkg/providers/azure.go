@@ -7,6 +7,7 @@ import (
 	"io"
 	"maps"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/config"
@@ -14,46 +15,122 @@ import (
 	"github.com/tailored-agentic-units/tau-core/pkg/response"
 )
 
-// AzureProvider implements Provider for Azure OpenAI Service.
+// Azure endpoint types, selected with the "endpoint_type" option.
+const (
+	// AzureEndpointOpenAI routes requests to an Azure OpenAI deployment.
+	AzureEndpointOpenAI = "openai"
+
+	// AzureEndpointServerless routes requests to an Azure AI Foundry
+	// serverless (Models-as-a-Service) endpoint, which serves the Azure AI
+	// Model Inference API without deployments in the path.
+	AzureEndpointServerless = "serverless"
+)
+
+// AzureServerlessAPIVersion is the Model Inference API version used for
+// serverless endpoints when no "api_version" option is configured.
+const AzureServerlessAPIVersion = "2024-05-01-preview"
+
+// AzureProvider implements Provider for Azure OpenAI Service and Azure AI
+// Foundry serverless model endpoints.
 // Supports deployment-based routing and both API key and Entra ID authentication.
 type AzureProvider struct {
 	*BaseProvider
-	deployment string
-	authType   string
-	token      string
-	apiVersion string
+	endpointType    string
+	deployment      string
+	authType        string
+	tokens          *TokenSource
+	apiVersion      string
+	extraParameters string
+	metadata        *MetadataForwarder
 }
 
 // NewAzure creates a new AzureProvider from configuration.
-// Requires "deployment", "auth_type", "token", and "api_version" in options.
-// Returns an error if any required option is missing.
+// Requires "deployment", "auth_type", "api_version", and a credential in options:
+// "token", "token_file", or "token_command" (see TokenSource).
+// System and developer messages follow the role options (see RolePolicy);
+// set "developer_role" for API versions that accept the developer role.
+//
+// Setting "endpoint_type" to AzureEndpointServerless targets an Azure AI
+// Foundry serverless endpoint instead: the base URL is the endpoint's target
+// URI, no deployment is required, and "api_version" defaults to
+// AzureServerlessAPIVersion. The "extra_parameters" option ("pass-through",
+// "drop", or "error") is sent as the extra-parameters header, telling the
+// endpoint how to handle request parameters the Model Inference API does
+// not define.
+// Returns an error if any required option is missing or invalid, or the token cannot be resolved.
 func NewAzure(c *config.ProviderConfig) (Provider, error) {
-	deployment, ok := c.Options["deployment"].(string)
-	if !ok || deployment == "" {
+	endpointType := AzureEndpointOpenAI
+	if t, ok := c.Options["endpoint_type"].(string); ok && t != "" {
+		endpointType = t
+	}
+	if endpointType != AzureEndpointOpenAI && endpointType != AzureEndpointServerless {
+		return nil, fmt.Errorf("endpoint_type must be %q or %q, got %q", AzureEndpointOpenAI, AzureEndpointServerless, endpointType)
+	}
+	serverless := endpointType == AzureEndpointServerless
+
+	deployment, _ := c.Options["deployment"].(string)
+	if deployment == "" && !serverless {
 		return nil, fmt.Errorf("deployment is required for Azure provider")
 	}
 
+	extraParameters, _ := c.Options["extra_parameters"].(string)
+	switch {
+	case extraParameters == "":
+	case !serverless:
+		return nil, fmt.Errorf("extra_parameters requires the %s endpoint type", AzureEndpointServerless)
+	case extraParameters != "pass-through" && extraParameters != "drop" && extraParameters != "error":
+		return nil, fmt.Errorf("extra_parameters must be pass-through, drop, or error, got %q", extraParameters)
+	}
+
 	authType, ok := c.Options["auth_type"].(string)
 	if !ok || authType == "" {
 		return nil, fmt.Errorf("auth_type is required for Azure provider")
 	}
 
-	token, ok := c.Options["token"].(string)
-	if !ok || token == "" {
-		return nil, fmt.Errorf("token is required for Azure provider")
+	tokens, err := NewTokenSource(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve Azure token: %w", err)
+	}
+	if !tokens.Configured() {
+		return nil, fmt.Errorf("token, token_file, or token_command is required for Azure provider")
 	}
 
-	apiVersion, ok := c.Options["api_version"].(string)
-	if !ok || apiVersion == "" {
+	apiVersion, _ := c.Options["api_version"].(string)
+	if apiVersion == "" && serverless {
+		apiVersion = AzureServerlessAPIVersion
+	}
+	if apiVersion == "" {
 		return nil, fmt.Errorf("api_version is required for Azure provider")
 	}
 
+	metadata, err := NewMetadataForwarder(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure metadata options: %w", err)
+	}
+
+	roles, err := NewRolePolicy(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure role options: %w", err)
+	}
+
+	signer, err := NewSigner(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure signing options: %w", err)
+	}
+
+	base := NewBaseProvider(c.Name, strings.TrimSuffix(c.BaseURL, "/"))
+	base.SetRolePolicy(roles)
+	base.SetSigner(signer)
+
 	return &AzureProvider{
-		BaseProvider: NewBaseProvider(c.Name, c.BaseURL),
-		deployment:   deployment,
-		authType:     authType,
-		token:        token,
-		apiVersion:   apiVersion,
+		BaseProvider:    base,
+		endpointType:    endpointType,
+		deployment:      deployment,
+		authType:        authType,
+		tokens:          tokens,
+		apiVersion:      apiVersion,
+		extraParameters: extraParameters,
+		metadata:        metadata,
 	}, nil
 }
 
@@ -61,9 +138,14 @@ func NewAzure(c *config.ProviderConfig) (Provider, error) {
 // Includes deployment name in path and api-version as query parameter.
 // Supports chat, vision, tools (all use /deployments/{deployment}/chat/completions),
 // and embeddings (/deployments/{deployment}/embeddings).
+// Serverless endpoints omit the deployment (/chat/completions and /embeddings);
+// the model is selected by the request body.
 // Returns an error if the protocol is not supported.
 func (p *AzureProvider) Endpoint(proto protocol.Protocol) (string, error) {
 	basePath := fmt.Sprintf("/deployments/%s", p.deployment)
+	if p.endpointType == AzureEndpointServerless {
+		basePath = ""
+	}
 
 	endpoints := map[protocol.Protocol]string{
 		protocol.Chat:       basePath + "/chat/completions",
@@ -80,14 +162,40 @@ func (p *AzureProvider) Endpoint(proto protocol.Protocol) (string, error) {
 	return fmt.Sprintf("%s%s?api-version=%s", p.BaseURL(), endpoint, p.apiVersion), nil
 }
 
+// FilesEndpoint returns the Azure OpenAI files URL, or the URL of a single
+// file when id is not empty. Files are scoped to the resource, not the deployment.
+// Returns an error for serverless endpoints, which have no files API.
+func (p *AzureProvider) FilesEndpoint(id string) (string, error) {
+	if p.endpointType == AzureEndpointServerless {
+		return "", fmt.Errorf("files are not supported by Azure serverless endpoints")
+	}
+
+	path := "/files"
+	if id != "" {
+		path += "/" + url.PathEscape(id)
+	}
+
+	return fmt.Sprintf("%s%s?api-version=%s", p.BaseURL(), path, p.apiVersion), nil
+}
+
 // PrepareRequest prepares a standard (non-streaming) Azure request.
-// Returns an error if the endpoint is invalid.
+// Forwards request metadata per the provider's metadata options.
+// Returns an error if the endpoint is invalid or the token cannot be resolved.
 func (p *AzureProvider) PrepareRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
 	endpoint, err := p.Endpoint(proto)
 	if err != nil {
 		return nil, err
 	}
 
+	if _, err := p.tokens.Token(ctx); err != nil {
+		return nil, fmt.Errorf("failed to resolve Azure token: %w", err)
+	}
+
+	body, headers, err = p.metadata.Apply(ctx, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Request{
 		URL:     endpoint,
 		Headers: headers,
@@ -96,14 +204,23 @@ func (p *AzureProvider) PrepareRequest(ctx context.Context, proto protocol.Proto
 }
 
 // PrepareStreamRequest prepares a streaming Azure request.
-// Adds streaming-specific headers (Accept: text/event-stream, Cache-Control: no-cache).
-// Returns an error if the endpoint is invalid.
+// Forwards request metadata and adds streaming-specific headers (Accept: text/event-stream, Cache-Control: no-cache).
+// Returns an error if the endpoint is invalid or the token cannot be resolved.
 func (p *AzureProvider) PrepareStreamRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
 	endpoint, err := p.Endpoint(proto)
 	if err != nil {
 		return nil, err
 	}
 
+	if _, err := p.tokens.Token(ctx); err != nil {
+		return nil, fmt.Errorf("failed to resolve Azure token: %w", err)
+	}
+
+	body, headers, err = p.metadata.Apply(ctx, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
 	// Clone headers to avoid mutating the original
 	streamHeaders := make(map[string]string)
 	maps.Copy(streamHeaders, headers)
@@ -119,11 +236,13 @@ func (p *AzureProvider) PrepareStreamRequest(ctx context.Context, proto protocol
 
 // ProcessResponse processes a standard Azure HTTP response.
 // Returns an error if the HTTP status is not OK.
-// Uses response.Parse for protocol-aware parsing.
+// Parses the body with the provider's codec (see BaseProvider.Parse).
+// Completions withheld by Azure content filtering return an error wrapping
+// response.ErrContentFiltered.
 func (p *AzureProvider) ProcessResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, NewResponseError(resp.StatusCode, body)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -131,12 +250,22 @@ func (p *AzureProvider) ProcessResponse(ctx context.Context, resp *http.Response
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return response.Parse(proto, body)
+	result, err := p.Parse(ctx, proto, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := response.CheckContentFilter(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
 // ProcessStreamResponse processes a streaming Azure HTTP response with SSE format.
 // Azure uses "data: " prefix for server-sent events.
 // Returns a channel that emits parsed streaming chunks.
+// Chunks for filtered completions carry an Error wrapping response.ErrContentFiltered.
 // The channel is closed when the stream completes or context is cancelled.
 // Returns an error if the HTTP status is not OK.
 func (p *AzureProvider) ProcessStreamResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (<-chan any, error) {
@@ -183,9 +312,17 @@ func (p *AzureProvider) ProcessStreamResponse(ctx context.Context, resp *http.Re
 				return
 			}
 
-			chunk, err := response.ParseStreamChunk(proto, []byte(data))
+			payload := []byte(data)
+			chunk, err := p.ParseStreamChunkContext(ctx, proto, payload)
 			if err != nil {
-				continue
+				if chunk = p.malformedChunk(ctx, proto, payload, err); chunk == nil {
+					continue
+				}
+			}
+
+			// Surface filtered completions as chunk errors
+			if err := response.CheckContentFilter(chunk); err != nil {
+				chunk.Error = err
 			}
 
 			select {
@@ -201,15 +338,24 @@ func (p *AzureProvider) ProcessStreamResponse(ctx context.Context, resp *http.Re
 
 // SetHeaders sets authentication headers on the HTTP request.
 // Supports "api_key" (api-key header) and "bearer" (Authorization: Bearer <token>).
+// File and command tokens are refreshed per the token_refresh option; a
+// failed refresh is returned by PrepareRequest, so requests are not sent with
+// a stale token.
+// Serverless requests also carry the configured extra-parameters header.
 func (p *AzureProvider) SetHeaders(req *http.Request) {
+	if p.extraParameters != "" {
+		req.Header.Set("extra-parameters", p.extraParameters)
+	}
+
+	token, _ := p.tokens.Token(req.Context())
+	if token == "" {
+		return
+	}
+
 	switch p.authType {
 	case "api_key":
-		if p.token != "" {
-			req.Header.Set("api-key", p.token)
-		}
+		req.Header.Set("api-key", token)
 	case "bearer":
-		if p.token != "" {
-			req.Header.Set("Authorization", "Bearer "+p.token)
-		}
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 }
@@ -1,20 +1,28 @@
 package providers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"maps"
+	"strings"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
 )
 
 // BaseProvider provides common functionality for provider implementations.
-// It stores the provider name and base URL, and provides default OpenAI-compatible
-// marshaling for all protocols.
+// It stores the provider name and base URL, and marshals requests and parses
+// responses with the codec for its wire format, OpenAI-compatible by default.
 // Provider implementations typically embed BaseProvider to inherit this functionality.
 type BaseProvider struct {
 	name    string
 	baseURL string
+	roles   RolePolicy
+	format  string
+	signer  Signer
+
+	middlewares []Middleware
 }
 
 // NewBaseProvider creates a new BaseProvider with the given name and base URL.
@@ -37,10 +45,130 @@ func (p *BaseProvider) BaseURL() string {
 	return p.baseURL
 }
 
-// Marshal converts request data to OpenAI-compatible JSON format.
-// This default implementation works for OpenAI, Azure, and Ollama providers.
-// Providers with different wire formats (Anthropic, Google) should override this method.
+// SetRolePolicy sets how Marshal sends system and developer messages.
+// Provider constructors call this with the policy from their options (see
+// NewRolePolicy); by default developer messages are sent as system messages.
+func (p *BaseProvider) SetRolePolicy(policy RolePolicy) {
+	p.roles = policy
+}
+
+// SetFormat selects the wire format used by Marshal, Parse, and
+// ParseStreamChunk (see LookupCodec). The default, FormatOpenAI, works for
+// OpenAI, Azure, and Ollama providers.
+func (p *BaseProvider) SetFormat(format string) {
+	p.format = format
+}
+
+// Format returns the provider's wire format.
+func (p *BaseProvider) Format() string {
+	if p.format == "" {
+		return FormatOpenAI
+	}
+	return p.format
+}
+
+// SupportsPrefill reports whether requests in a wire format, or in the
+// provider's format when format is empty, can end in a prefill message.
+// Only FormatAnthropic and its variants continue a final assistant message.
+func (p *BaseProvider) SupportsPrefill(format string) bool {
+	if format == "" {
+		format = p.Format()
+	}
+	base, _, _ := strings.Cut(format, "-")
+	return base == FormatAnthropic
+}
+
+// Codec returns the codec for a protocol in the provider's wire format.
+// The OpenAI codec uses the provider's role policy.
+// Returns an error if no codec is registered.
+func (p *BaseProvider) Codec(proto protocol.Protocol) (Codec, error) {
+	return p.codec(proto, "")
+}
+
+// codec returns the codec for a protocol in a format, or in the provider's
+// format when format is empty.
+func (p *BaseProvider) codec(proto protocol.Protocol, format string) (Codec, error) {
+	if format == "" {
+		format = p.Format()
+	}
+	codec, err := LookupCodec(p.name, proto, format)
+	if err != nil {
+		return nil, err
+	}
+	if openai, ok := codec.(OpenAICodec); ok {
+		openai.Roles = p.roles
+		return openai, nil
+	}
+	return codec, nil
+}
+
+// Marshal converts request data to the provider's wire format with its codec,
+// or to the format named by the data's Format field when set.
+// In the default OpenAI-compatible format, tools requests carrying images
+// embed them in the last message as for vision, and system and developer
+// messages are rewritten according to the role policy. Fields of the
+// extra_body option are merged into the marshaled body (see ExtraBodyKey).
 func (p *BaseProvider) Marshal(proto protocol.Protocol, data any) ([]byte, error) {
+	codec, err := p.codec(proto, dataFormat(data))
+	if err != nil {
+		return nil, err
+	}
+
+	data, extra, err := splitExtraBody(data)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := codec.Marshal(proto, data)
+	if err != nil {
+		return nil, err
+	}
+	return MergeExtraBody(body, extra)
+}
+
+// Parse parses a response body in the provider's wire format with its codec,
+// or in the format selected by ctx (see WithFormat).
+func (p *BaseProvider) Parse(ctx context.Context, proto protocol.Protocol, body []byte) (any, error) {
+	codec, err := p.codec(proto, FormatFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return codec.Parse(ctx, proto, body)
+}
+
+// ParseStreamChunk parses a streaming event in the provider's wire format
+// with its codec.
+func (p *BaseProvider) ParseStreamChunk(proto protocol.Protocol, data []byte) (*response.StreamingChunk, error) {
+	return p.ParseStreamChunkContext(context.Background(), proto, data)
+}
+
+// ParseStreamChunkContext parses a streaming event like ParseStreamChunk, in
+// the format selected by ctx (see WithFormat) when set.
+func (p *BaseProvider) ParseStreamChunkContext(ctx context.Context, proto protocol.Protocol, data []byte) (*response.StreamingChunk, error) {
+	codec, err := p.codec(proto, FormatFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return codec.ParseStreamChunk(proto, data)
+}
+
+// dataFormat returns the wire format named by request data.
+func dataFormat(data any) string {
+	switch d := data.(type) {
+	case *ChatData:
+		return d.Format
+	case *VisionData:
+		return d.Format
+	case *ToolsData:
+		return d.Format
+	case *EmbeddingsData:
+		return d.Format
+	}
+	return ""
+}
+
+// marshalOpenAI converts request data to OpenAI-compatible JSON.
+func (p *BaseProvider) marshalOpenAI(proto protocol.Protocol, data any) ([]byte, error) {
 	switch proto {
 	case protocol.Chat:
 		return p.marshalChat(data)
@@ -61,10 +189,15 @@ func (p *BaseProvider) marshalChat(data any) ([]byte, error) {
 		return nil, fmt.Errorf("expected *ChatData, got %T", data)
 	}
 
+	options, err := OpenAIBuiltinToolsOption(d.Options)
+	if err != nil {
+		return nil, err
+	}
+
 	combined := make(map[string]any)
 	combined["model"] = d.Model
-	combined["messages"] = d.Messages
-	maps.Copy(combined, d.Options)
+	combined["messages"] = p.roles.Apply(d.Messages)
+	maps.Copy(combined, options)
 	return json.Marshal(combined)
 }
 
@@ -82,9 +215,30 @@ func (p *BaseProvider) marshalVision(data any) ([]byte, error) {
 		return nil, fmt.Errorf("images cannot be empty for vision requests")
 	}
 
-	// Transform the last message to embed images
-	lastIdx := len(d.Messages) - 1
-	message := d.Messages[lastIdx]
+	transformedMessages, err := embedImages(d.Messages, d.Images, d.VisionOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Combine model, messages, and options at root level
+	combined := make(map[string]any)
+	combined["model"] = d.Model
+	combined["messages"] = p.roles.Apply(transformedMessages)
+	options, err := OpenAIBuiltinToolsOption(d.Options)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(combined, options)
+
+	return json.Marshal(combined)
+}
+
+// embedImages returns a copy of messages with the images embedded in the last
+// message as OpenAI image_url content parts following its text.
+// Vision options are copied into each image_url entry.
+func embedImages(messages []protocol.Message, images []string, visionOptions map[string]any) ([]protocol.Message, error) {
+	lastIdx := len(messages) - 1
+	message := messages[lastIdx]
 
 	var textContent string
 	switch v := message.Content.(type) {
@@ -100,14 +254,14 @@ func (p *BaseProvider) marshalVision(data any) ([]byte, error) {
 	}
 
 	// Add each image with embedded options
-	for _, imgURL := range d.Images {
+	for _, imgURL := range images {
 		imageURL := map[string]any{
 			"url": imgURL,
 		}
 
 		// Embed vision_options into image_url map
-		if d.VisionOptions != nil {
-			maps.Copy(imageURL, d.VisionOptions)
+		if visionOptions != nil {
+			maps.Copy(imageURL, visionOptions)
 		}
 
 		content = append(content, map[string]any{
@@ -117,20 +271,13 @@ func (p *BaseProvider) marshalVision(data any) ([]byte, error) {
 	}
 
 	// Create transformed messages
-	transformedMessages := make([]protocol.Message, len(d.Messages))
-	copy(transformedMessages, d.Messages)
-	transformedMessages[lastIdx] = protocol.Message{
+	transformed := make([]protocol.Message, len(messages))
+	copy(transformed, messages)
+	transformed[lastIdx] = protocol.Message{
 		Role:    message.Role,
 		Content: content,
 	}
-
-	// Combine model, messages, and options at root level
-	combined := make(map[string]any)
-	combined["model"] = d.Model
-	combined["messages"] = transformedMessages
-	maps.Copy(combined, d.Options)
-
-	return json.Marshal(combined)
+	return transformed, nil
 }
 
 func (p *BaseProvider) marshalTools(data any) ([]byte, error) {
@@ -139,9 +286,21 @@ func (p *BaseProvider) marshalTools(data any) ([]byte, error) {
 		return nil, fmt.Errorf("expected *ToolsData, got %T", data)
 	}
 
+	messages := d.Messages
+	if len(d.Images) > 0 {
+		if len(messages) == 0 {
+			return nil, fmt.Errorf("messages cannot be empty for vision tools requests")
+		}
+		embedded, err := embedImages(d.Messages, d.Images, d.VisionOptions)
+		if err != nil {
+			return nil, err
+		}
+		messages = embedded
+	}
+
 	combined := make(map[string]any)
 	combined["model"] = d.Model
-	combined["messages"] = d.Messages
+	combined["messages"] = p.roles.Apply(messages)
 
 	// Transform tools to OpenAI format: {"type": "function", "function": {...}}
 	openAITools := make([]map[string]any, len(d.Tools))
@@ -157,7 +316,11 @@ func (p *BaseProvider) marshalTools(data any) ([]byte, error) {
 	}
 	combined["tools"] = openAITools
 
-	maps.Copy(combined, d.Options)
+	options, err := OpenAIBuiltinToolsOption(d.Options)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(combined, options)
 	return json.Marshal(combined)
 }
 
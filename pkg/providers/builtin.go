@@ -0,0 +1,134 @@
+package providers
+
+import (
+	"fmt"
+	"maps"
+)
+
+// BuiltinToolsKey is the option key holding provider-side tools (see
+// options.WebSearch and options.FileSearch): a list of objects whose "type"
+// names the tool. Providers translate the list to their own wire format.
+const BuiltinToolsKey = "builtin_tools"
+
+// Built-in tool types.
+const (
+	BuiltinWebSearch  = "web_search"
+	BuiltinFileSearch = "file_search"
+)
+
+// AnthropicWebSearchType is the versioned type of Anthropic's web search tool.
+const AnthropicWebSearchType = "web_search_20250305"
+
+// BuiltinTools returns the provider-side tools in options, accepting the
+// []map[string]any set by the options helpers and the []any decoded from
+// JSON configuration. Returns an error for malformed entries.
+func BuiltinTools(options map[string]any) ([]map[string]any, error) {
+	switch value := options[BuiltinToolsKey].(type) {
+	case nil:
+		return nil, nil
+	case []map[string]any:
+		for _, tool := range value {
+			if _, ok := tool["type"].(string); !ok {
+				return nil, fmt.Errorf("%s entries must have a type", BuiltinToolsKey)
+			}
+		}
+		return value, nil
+	case []any:
+		tools := make([]map[string]any, len(value))
+		for i, entry := range value {
+			tool, ok := entry.(map[string]any)
+			if _, typed := tool["type"].(string); !ok || !typed {
+				return nil, fmt.Errorf("%s entries must be objects with a type", BuiltinToolsKey)
+			}
+			tools[i] = tool
+		}
+		return tools, nil
+	default:
+		return nil, fmt.Errorf("%s must be a list, got %T", BuiltinToolsKey, value)
+	}
+}
+
+// OpenAIBuiltinToolsOption returns a copy of options with built-in tools
+// translated for the Chat Completions API, where web search is requested
+// with web_search_options. Options are returned unchanged when there are no
+// built-in tools. Returns an error for tools Chat Completions has no
+// equivalent for, such as file_search.
+func OpenAIBuiltinToolsOption(options map[string]any) (map[string]any, error) {
+	tools, err := BuiltinTools(options)
+	if err != nil || options[BuiltinToolsKey] == nil {
+		return options, err
+	}
+
+	translated := maps.Clone(options)
+	delete(translated, BuiltinToolsKey)
+	for _, tool := range tools {
+		switch tool["type"] {
+		case BuiltinWebSearch:
+			search := make(map[string]any)
+			if size, ok := tool["search_context_size"]; ok {
+				search["search_context_size"] = size
+			}
+			translated["web_search_options"] = search
+		default:
+			return nil, fmt.Errorf("built-in tool %q is not supported by the chat completions API", tool["type"])
+		}
+	}
+	return translated, nil
+}
+
+// AnthropicBuiltinTools translates built-in tools to Anthropic server tool
+// definitions, to send in the tools list alongside AnthropicTools, and
+// returns options without the built-in tools key. Web search becomes the
+// web_search tool with its max_uses and allowed_domains settings.
+// Returns an error for tools Anthropic has no equivalent for.
+func AnthropicBuiltinTools(options map[string]any) ([]map[string]any, map[string]any, error) {
+	tools, err := BuiltinTools(options)
+	if err != nil || options[BuiltinToolsKey] == nil {
+		return nil, options, err
+	}
+
+	var server []map[string]any
+	for _, tool := range tools {
+		switch tool["type"] {
+		case BuiltinWebSearch:
+			search := map[string]any{"type": AnthropicWebSearchType, "name": BuiltinWebSearch}
+			for _, key := range []string{"max_uses", "allowed_domains"} {
+				if value, ok := tool[key]; ok {
+					search[key] = value
+				}
+			}
+			server = append(server, search)
+		default:
+			return nil, nil, fmt.Errorf("built-in tool %q is not supported by anthropic", tool["type"])
+		}
+	}
+
+	remaining := maps.Clone(options)
+	delete(remaining, BuiltinToolsKey)
+	return server, remaining, nil
+}
+
+// GeminiBuiltinTools translates built-in tools to Gemini tools and returns
+// options without the built-in tools key. Web search becomes google_search
+// grounding; Gemini has no settings for it, so web search settings are
+// ignored. Returns an error for tools Gemini has no equivalent for.
+func GeminiBuiltinTools(options map[string]any) ([]GeminiTool, map[string]any, error) {
+	tools, err := BuiltinTools(options)
+	if err != nil || options[BuiltinToolsKey] == nil {
+		return nil, options, err
+	}
+
+	var translated []GeminiTool
+	for _, tool := range tools {
+		switch tool["type"] {
+		case BuiltinWebSearch:
+			translated = append(translated, GeminiTool{GoogleSearch: &GeminiGoogleSearch{}})
+		default:
+			return nil, nil, fmt.Errorf("built-in tool %q is not supported by gemini", tool["type"])
+		}
+	}
+
+	remaining := maps.Clone(options)
+	delete(remaining, BuiltinToolsKey)
+	return translated, remaining, nil
+}
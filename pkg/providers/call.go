@@ -0,0 +1,66 @@
+package providers
+
+import "context"
+
+// Priority is a caller-assigned request priority. Providers and gateways
+// that support prioritization map it to their own scheme; others ignore it.
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+)
+
+// CallInfo holds the well-known per-call values carried by a request
+// context. The client assigns a RequestID to every request that does not
+// already have one, so PrepareRequest, PrepareStreamRequest, and SetHeaders
+// (through req.Context()) can always read it with CallInfoFrom. Empty fields
+// are unset.
+type CallInfo struct {
+	RequestID string
+	Tenant    string
+	Priority  Priority
+}
+
+// callInfoKey is the context key for call info.
+type callInfoKey struct{}
+
+// WithCallInfo returns a context carrying call info. Non-empty fields of info
+// override the call info already in ctx.
+func WithCallInfo(ctx context.Context, info CallInfo) context.Context {
+	merged := CallInfoFrom(ctx)
+	if info.RequestID != "" {
+		merged.RequestID = info.RequestID
+	}
+	if info.Tenant != "" {
+		merged.Tenant = info.Tenant
+	}
+	if info.Priority != "" {
+		merged.Priority = info.Priority
+	}
+	return context.WithValue(ctx, callInfoKey{}, merged)
+}
+
+// WithRequestID returns a context carrying a caller-assigned request ID, used
+// instead of a generated one.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return WithCallInfo(ctx, CallInfo{RequestID: id})
+}
+
+// WithTenant returns a context carrying the tenant the request is made for.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return WithCallInfo(ctx, CallInfo{Tenant: tenant})
+}
+
+// WithPriority returns a context carrying the request priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return WithCallInfo(ctx, CallInfo{Priority: priority})
+}
+
+// CallInfoFrom returns the call info carried by ctx, or the zero CallInfo if
+// there is none.
+func CallInfoFrom(ctx context.Context) CallInfo {
+	info, _ := ctx.Value(callInfoKey{}).(CallInfo)
+	return info
+}
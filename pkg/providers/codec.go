@@ -0,0 +1,242 @@
+package providers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Wire format names of the built-in codecs.
+const (
+	FormatOpenAI    = "openai"
+	FormatAnthropic = "anthropic"
+	FormatGemini    = "gemini"
+)
+
+// Codec converts request data to a wire format and parses responses in that
+// format, independently of provider transport (endpoints, authentication,
+// and HTTP handling). Codecs are registered per provider, protocol, and
+// format with RegisterCodec, so alternative wire formats can be developed and
+// tested without a provider.
+type Codec interface {
+	// Marshal converts request data to the wire format. The data parameter
+	// is *ChatData, *VisionData, *ToolsData, or *EmbeddingsData based on
+	// the protocol.
+	Marshal(p protocol.Protocol, data any) ([]byte, error)
+
+	// Parse parses a response body, returning *response.ChatResponse,
+	// *response.ToolsResponse, or *response.EmbeddingsResponse based on
+	// the protocol.
+	Parse(ctx context.Context, p protocol.Protocol, body []byte) (any, error)
+
+	// ParseStreamChunk parses the data of a single streaming event.
+	ParseStreamChunk(p protocol.Protocol, data []byte) (*response.StreamingChunk, error)
+}
+
+// CodecKey identifies a registered codec. An empty Provider matches any
+// provider.
+type CodecKey struct {
+	Provider string
+	Protocol protocol.Protocol
+	Format   string
+}
+
+// codecs maintains the global codec registry.
+var codecs = struct {
+	entries map[CodecKey]Codec
+	mu      sync.RWMutex
+}{
+	entries: make(map[CodecKey]Codec),
+}
+
+// RegisterCodec registers a codec for a provider, protocol, and format,
+// replacing any codec already registered for them. An empty provider
+// registers the codec for every provider without its own.
+// Thread-safe for concurrent registration.
+func RegisterCodec(provider string, p protocol.Protocol, format string, codec Codec) {
+	codecs.mu.Lock()
+	defer codecs.mu.Unlock()
+	codecs.entries[CodecKey{Provider: provider, Protocol: p, Format: format}] = codec
+}
+
+// LookupCodec returns the codec registered for a provider, protocol, and
+// format, falling back to the codec registered for any provider. An empty
+// format selects FormatOpenAI. A format may name a variant of a base format
+// after a hyphen (e.g., "openai-chat"), which uses the base format's codec
+// when no codec is registered for the variant.
+// Returns an error if no codec is registered.
+func LookupCodec(provider string, p protocol.Protocol, format string) (Codec, error) {
+	if format == "" {
+		format = FormatOpenAI
+	}
+
+	codecs.mu.RLock()
+	defer codecs.mu.RUnlock()
+
+	candidates := []string{format}
+	if base, _, ok := strings.Cut(format, "-"); ok {
+		candidates = append(candidates, base)
+	}
+	for _, name := range candidates {
+		if codec, ok := codecs.entries[CodecKey{Provider: provider, Protocol: p, Format: name}]; ok {
+			return codec, nil
+		}
+		if codec, ok := codecs.entries[CodecKey{Protocol: p, Format: name}]; ok {
+			return codec, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s codec registered for protocol %s", format, p)
+}
+
+// formatKey is the context key for a per-request wire format.
+type formatKey struct{}
+
+// WithFormat returns a context selecting the wire format responses are
+// parsed in, instead of the provider's (see BaseProvider.Parse). The client
+// sets it from the request model's format for the protocol.
+func WithFormat(ctx context.Context, format string) context.Context {
+	return context.WithValue(ctx, formatKey{}, format)
+}
+
+// FormatFromContext returns the wire format selected by ctx, or an empty
+// string if none is.
+func FormatFromContext(ctx context.Context) string {
+	format, _ := ctx.Value(formatKey{}).(string)
+	return format
+}
+
+// ListCodecs returns the keys of all registered codecs, sorted by provider,
+// protocol, and format.
+// Thread-safe for concurrent access.
+func ListCodecs() []CodecKey {
+	codecs.mu.RLock()
+	defer codecs.mu.RUnlock()
+
+	keys := make([]CodecKey, 0, len(codecs.entries))
+	for key := range codecs.entries {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b CodecKey) int {
+		return cmp.Or(
+			cmp.Compare(a.Provider, b.Provider),
+			cmp.Compare(a.Protocol, b.Protocol),
+			cmp.Compare(a.Format, b.Format),
+		)
+	})
+	return keys
+}
+
+// OpenAICodec is the OpenAI-compatible wire format used by BaseProvider.
+// System and developer messages are sent according to Roles; BaseProvider
+// sets it to the provider's role policy.
+type OpenAICodec struct {
+	Roles RolePolicy
+}
+
+// Marshal converts request data to OpenAI-compatible JSON.
+func (c OpenAICodec) Marshal(p protocol.Protocol, data any) ([]byte, error) {
+	base := BaseProvider{roles: c.Roles}
+	return base.marshalOpenAI(p, data)
+}
+
+// Parse parses an OpenAI-compatible response in the context parse mode.
+func (c OpenAICodec) Parse(ctx context.Context, p protocol.Protocol, body []byte) (any, error) {
+	return response.ParseContext(ctx, p, body)
+}
+
+// ParseStreamChunk parses an OpenAI-compatible streaming chunk.
+func (c OpenAICodec) ParseStreamChunk(p protocol.Protocol, data []byte) (*response.StreamingChunk, error) {
+	return response.ParseStreamChunk(p, data)
+}
+
+// AnthropicCodec is the Anthropic Messages wire format. The chat and tools
+// protocols are supported, without streaming.
+type AnthropicCodec struct{}
+
+// Marshal converts chat request data with MarshalAnthropicChat and tools
+// request data with MarshalAnthropicTools.
+func (AnthropicCodec) Marshal(p protocol.Protocol, data any) ([]byte, error) {
+	switch p {
+	case protocol.Chat:
+		d, ok := data.(*ChatData)
+		if !ok {
+			return nil, fmt.Errorf("expected *ChatData, got %T", data)
+		}
+		return MarshalAnthropicChat(d)
+	case protocol.Tools:
+		d, ok := data.(*ToolsData)
+		if !ok {
+			return nil, fmt.Errorf("expected *ToolsData, got %T", data)
+		}
+		return MarshalAnthropicTools(d)
+	default:
+		return nil, unsupportedCodec(FormatAnthropic, p)
+	}
+}
+
+// Parse parses a chat response with response.ParseAnthropicChat and a tools
+// response with response.ParseAnthropicTools.
+func (AnthropicCodec) Parse(ctx context.Context, p protocol.Protocol, body []byte) (any, error) {
+	switch p {
+	case protocol.Chat:
+		return response.ParseAnthropicChat(body)
+	case protocol.Tools:
+		return response.ParseAnthropicTools(body)
+	default:
+		return nil, unsupportedCodec(FormatAnthropic, p)
+	}
+}
+
+// ParseStreamChunk returns an error; streaming is not supported.
+func (AnthropicCodec) ParseStreamChunk(p protocol.Protocol, data []byte) (*response.StreamingChunk, error) {
+	return nil, fmt.Errorf("%s codec does not support streaming", FormatAnthropic)
+}
+
+// GeminiCodec is the Gemini generateContent wire format. Only the tools
+// protocol is supported, without streaming.
+type GeminiCodec struct{}
+
+// Marshal converts tools request data with MarshalGeminiTools.
+func (GeminiCodec) Marshal(p protocol.Protocol, data any) ([]byte, error) {
+	if p != protocol.Tools {
+		return nil, unsupportedCodec(FormatGemini, p)
+	}
+	d, ok := data.(*ToolsData)
+	if !ok {
+		return nil, fmt.Errorf("expected *ToolsData, got %T", data)
+	}
+	return MarshalGeminiTools(d)
+}
+
+// Parse parses a tools response with response.ParseGeminiTools.
+func (GeminiCodec) Parse(ctx context.Context, p protocol.Protocol, body []byte) (any, error) {
+	if p != protocol.Tools {
+		return nil, unsupportedCodec(FormatGemini, p)
+	}
+	return response.ParseGeminiTools(body)
+}
+
+// ParseStreamChunk returns an error; streaming is not supported.
+func (GeminiCodec) ParseStreamChunk(p protocol.Protocol, data []byte) (*response.StreamingChunk, error) {
+	return nil, fmt.Errorf("%s codec does not support streaming", FormatGemini)
+}
+
+// unsupportedCodec reports a protocol a codec cannot handle.
+func unsupportedCodec(format string, p protocol.Protocol) error {
+	return fmt.Errorf("%s codec does not support protocol %s", format, p)
+}
+
+func init() {
+	for _, p := range []protocol.Protocol{protocol.Chat, protocol.Vision, protocol.Tools, protocol.Embeddings} {
+		RegisterCodec("", p, FormatOpenAI, OpenAICodec{})
+	}
+	RegisterCodec("", protocol.Chat, FormatAnthropic, AnthropicCodec{})
+	RegisterCodec("", protocol.Tools, FormatAnthropic, AnthropicCodec{})
+	RegisterCodec("", protocol.Tools, FormatGemini, GeminiCodec{})
+}
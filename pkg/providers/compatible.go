@@ -0,0 +1,238 @@
+package providers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// compatibleAPI describes a hosted OpenAI-compatible API: the name used in
+// errors, the base URL used when none is configured, whether it serves
+// embeddings, and the headers it reports rate limits in.
+type compatibleAPI struct {
+	label          string
+	defaultBaseURL string
+	embeddings     bool
+	rateLimits     rateLimitHeaders
+}
+
+// compatibleProvider implements Provider for hosted OpenAI-compatible APIs
+// authenticated with a bearer token. Together, Fireworks, and xAI embed it
+// and add only what differs: base URL, model naming, and rate limit headers.
+type compatibleProvider struct {
+	*BaseProvider
+	api      compatibleAPI
+	tokens   *TokenSource
+	metadata *MetadataForwarder
+}
+
+// newCompatibleProvider creates a compatibleProvider from configuration.
+// Uses the API's default base URL when none is configured.
+// Requires a credential option ("token", "token_file", or "token_command";
+// see TokenSource), sent as a bearer token.
+// System and developer messages follow the role options (see RolePolicy).
+// Returns an error if the credential is missing or cannot be resolved.
+func newCompatibleProvider(c *config.ProviderConfig, api compatibleAPI) (*compatibleProvider, error) {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = api.defaultBaseURL
+	}
+
+	tokens, err := NewTokenSource(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s token: %w", api.label, err)
+	}
+	if !tokens.Configured() {
+		return nil, fmt.Errorf("token, token_file, or token_command is required for %s provider", api.label)
+	}
+
+	metadata, err := NewMetadataForwarder(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata options: %w", api.label, err)
+	}
+
+	roles, err := NewRolePolicy(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s role options: %w", api.label, err)
+	}
+
+	signer, err := NewSigner(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s signing options: %w", api.label, err)
+	}
+
+	base := NewBaseProvider(c.Name, strings.TrimSuffix(baseURL, "/"))
+	base.SetRolePolicy(roles)
+	base.SetSigner(signer)
+
+	return &compatibleProvider{
+		BaseProvider: base,
+		api:          api,
+		tokens:       tokens,
+		metadata:     metadata,
+	}, nil
+}
+
+// Endpoint returns the full endpoint URL for a protocol.
+// Supports chat, vision, tools (all use /chat/completions), and embeddings
+// (/embeddings) when the API serves them.
+// Returns an error if the protocol is not supported.
+func (p *compatibleProvider) Endpoint(proto protocol.Protocol) (string, error) {
+	endpoints := map[protocol.Protocol]string{
+		protocol.Chat:   "/chat/completions",
+		protocol.Vision: "/chat/completions",
+		protocol.Tools:  "/chat/completions",
+	}
+	if p.api.embeddings {
+		endpoints[protocol.Embeddings] = "/embeddings"
+	}
+
+	endpoint, exists := endpoints[proto]
+	if !exists {
+		return "", fmt.Errorf("protocol %s not supported by %s", proto, p.api.label)
+	}
+
+	return p.BaseURL() + endpoint, nil
+}
+
+// PrepareRequest prepares a standard (non-streaming) request.
+// Forwards request metadata per the provider's metadata options.
+// Returns an error if the endpoint is invalid or the token cannot be resolved.
+func (p *compatibleProvider) PrepareRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
+	endpoint, err := p.Endpoint(proto)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.tokens.Token(ctx); err != nil {
+		return nil, fmt.Errorf("failed to resolve %s token: %w", p.api.label, err)
+	}
+
+	body, headers, err = p.metadata.Apply(ctx, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		URL:     endpoint,
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
+// PrepareStreamRequest prepares a streaming request.
+// Forwards request metadata and adds streaming-specific headers (Accept: text/event-stream, Cache-Control: no-cache).
+// Returns an error if the endpoint is invalid or the token cannot be resolved.
+func (p *compatibleProvider) PrepareStreamRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
+	request, err := p.PrepareRequest(ctx, proto, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	streamHeaders := make(map[string]string)
+	maps.Copy(streamHeaders, request.Headers)
+	streamHeaders["Accept"] = "text/event-stream"
+	streamHeaders["Cache-Control"] = "no-cache"
+	request.Headers = streamHeaders
+
+	return request, nil
+}
+
+// ProcessResponse processes a standard HTTP response.
+// Returns an error if the HTTP status is not OK.
+// Parses the body with the provider's codec (see BaseProvider.Parse).
+func (p *compatibleProvider) ProcessResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, NewResponseError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return p.Parse(ctx, proto, body)
+}
+
+// ProcessStreamResponse processes a streaming HTTP response in SSE format
+// with "data: " prefix.
+// Returns a channel that emits parsed streaming chunks.
+// The channel is closed when the stream completes or context is cancelled.
+// Returns an error if the HTTP status is not OK.
+func (p *compatibleProvider) ProcessStreamResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (<-chan any, error) {
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	output := make(chan any)
+
+	go func() {
+		defer close(output)
+		defer resp.Body.Close()
+
+		reader := bufio.NewReader(resp.Body)
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				select {
+				case output <- &response.StreamingChunk{Error: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: ")
+			if !ok {
+				continue
+			}
+
+			if data == "[DONE]" {
+				return
+			}
+
+			payload := []byte(data)
+			chunk, err := p.ParseStreamChunkContext(ctx, proto, payload)
+			if err != nil {
+				if chunk = p.malformedChunk(ctx, proto, payload, err); chunk == nil {
+					continue
+				}
+			}
+
+			select {
+			case output <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return output, nil
+}
+
+// RateLimits maps the API's rate limit headers to RateLimits.
+// Returns false if the API reports none.
+func (p *compatibleProvider) RateLimits(h http.Header) (RateLimits, bool) {
+	return p.api.rateLimits.parse(h)
+}
+
+// SetHeaders sets the bearer token on the HTTP request.
+func (p *compatibleProvider) SetHeaders(req *http.Request) {
+	// Token errors are returned by PrepareRequest
+	if token, _ := p.tokens.Token(req.Context()); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
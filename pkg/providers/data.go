@@ -3,10 +3,13 @@ package providers
 import "github.com/tailored-agentic-units/tau-core/pkg/protocol"
 
 // ChatData contains the data needed to marshal a chat request.
+// Format, when set, selects the wire format instead of the provider's (see
+// BaseProvider.Marshal); the same applies to the other request data types.
 type ChatData struct {
 	Model    string
 	Messages []protocol.Message
 	Options  map[string]any
+	Format   string
 }
 
 // VisionData contains the data needed to marshal a vision request.
@@ -16,14 +19,20 @@ type VisionData struct {
 	Images        []string
 	VisionOptions map[string]any
 	Options       map[string]any
+	Format        string
 }
 
 // ToolsData contains the data needed to marshal a tools request.
+// Images and VisionOptions are set for combined vision and tools requests;
+// the images are embedded in the last message as for VisionData.
 type ToolsData struct {
-	Model    string
-	Messages []protocol.Message
-	Tools    []ToolDefinition
-	Options  map[string]any
+	Model         string
+	Messages      []protocol.Message
+	Tools         []ToolDefinition
+	Images        []string
+	VisionOptions map[string]any
+	Options       map[string]any
+	Format        string
 }
 
 // ToolDefinition represents a provider-agnostic tool (function) definition.
@@ -40,4 +49,5 @@ type EmbeddingsData struct {
 	Model   string
 	Input   any // string or []string for batch embeddings
 	Options map[string]any
+	Format  string
 }
@@ -0,0 +1,26 @@
+package providers
+
+import "fmt"
+
+// DeferredCompletion is returned by ProcessResponse when the provider
+// accepted a deferred request (see options.Deferred) instead of completing it.
+// The completion is fetched later by RequestID, through a DeferredFetcher.
+type DeferredCompletion struct {
+	Provider  string
+	RequestID string
+}
+
+func (d *DeferredCompletion) Error() string {
+	return fmt.Sprintf("%s deferred completion %s pending", d.Provider, d.RequestID)
+}
+
+// DeferredFetcher is implemented by providers that can queue a request and
+// return its completion later.
+type DeferredFetcher interface {
+	Provider
+
+	// DeferredCompletionRequest returns the GET request that fetches the
+	// completion of a deferred request. The response body is processed with
+	// ProcessResponse once the completion is ready.
+	DeferredCompletionRequest(requestID string) (*Request, error)
+}
@@ -65,6 +65,7 @@
 //   - Optional bearer or API key authentication
 //   - Custom authentication header support
 //   - Streaming and non-streaming responses
+//   - Prewarming through the native /api/generate endpoint (see Prewarmer)
 //
 // ## Azure OpenAI Provider
 //
@@ -95,6 +96,103 @@
 //   - API version management
 //   - Server-sent events with "data: " prefix for streaming
 //
+// Azure AI Foundry serverless (Models-as-a-Service) endpoints are selected with
+// the "endpoint_type" option. The base URL is the endpoint's target URI; there
+// is no deployment, and the model is chosen by the request body:
+//
+//	Options: map[string]any{
+//	    "endpoint_type":    "serverless",
+//	    "auth_type":        "bearer",
+//	    "token_file":       "/run/secrets/foundry-key",
+//	    "extra_parameters": "drop", // Optional: pass-through, drop, or error
+//	},
+//
+// ## llama.cpp Provider
+//
+// The llama.cpp provider targets the llama.cpp server's OpenAI-compatible API
+// for fully local inference:
+//
+//	provider, err := providers.Create(&config.ProviderConfig{
+//	    Name:    "llamacpp",
+//	    BaseURL: "http://localhost:8080",
+//	})
+//
+// Features:
+//   - Constrained decoding with a GBNF grammar (options.Grammar) or a JSON
+//     schema (options.JSONSchemaConstraint), one per request
+//   - Optional bearer token matching the server's --api-key
+//   - Mid-stream server errors surfaced as chunks carrying *LlamaCppError
+//
+// ## xAI Provider
+//
+// The xAI provider targets the Grok API for chat, vision, and tools requests,
+// using XAIDefaultBaseURL when no base URL is configured:
+//
+//	provider, err := providers.Create(&config.ProviderConfig{
+//	    Name:    "xai",
+//	    Options: map[string]any{"token_file": "/run/secrets/xai"},
+//	})
+//
+// Features:
+//   - Bearer token authentication (required)
+//   - Deferred completions (options.Deferred): ProcessResponse returns a
+//     *DeferredCompletion, and the provider implements DeferredFetcher
+//
+// ## Together AI and Fireworks Providers
+//
+// The "together" and "fireworks" providers target the hosted OpenAI-compatible
+// APIs, using TogetherDefaultBaseURL and FireworksDefaultBaseURL when no base
+// URL is configured. Both require a bearer token.
+//
+// Features:
+//   - Together model names ("meta-llama/Llama-3.3-70B-Instruct-Turbo") are
+//     sent unchanged; short Fireworks model names are expanded to
+//     accounts/{account}/models/{model} (see FireworksModelName)
+//   - Rate limit headers mapped to RateLimits (see RateLimitReporter)
+//
+// ## Vertex AI Provider
+//
+// The Vertex provider targets Google Cloud Vertex AI publisher models in a
+// project and region:
+//
+//	provider, err := providers.Create(&config.ProviderConfig{
+//	    Name: "vertex",
+//	    Options: map[string]any{
+//	        "project":          "my-project",
+//	        "location":         "europe-west4",                 // Optional: default us-central1
+//	        "credentials_file": "/run/secrets/vertex-sa.json", // Optional: default ADC
+//	    },
+//	})
+//
+// Features:
+//   - OAuth2 access tokens from a service account key or Application Default
+//     Credentials (see GoogleCredentials), cached until shortly before expiry
+//   - Regional or global endpoints (see VertexBaseURL)
+//   - Tools requests in the Gemini format on the model's generateContent
+//     endpoint, sharing the Gemini codec
+//   - Chat and vision requests on Vertex's OpenAI-compatible endpoint
+//
+// ## Gateway Provider
+//
+// The gateway provider covers enterprise LLM gateways that accept the
+// OpenAI-compatible format behind their own URLs, auth header, and payload
+// envelope, configured entirely by the "gateway" option (see GatewayConfig):
+//
+//	"options": {
+//	    "token_file": "/run/secrets/gateway-key",
+//	    "gateway": {
+//	        "endpoints":     {"chat": "/llm/{model}/invoke?tenant={tenant}"},
+//	        "vars":          {"tenant": "acme"},
+//	        "auth_header":   "X-Gateway-Key",
+//	        "envelope":      {"wrap": "payload", "fields": {"app_id": "billing"}},
+//	        "response_path": "data.result"
+//	    }
+//	}
+//
+// The "watsonx" provider is a gateway preconfigured for the IBM watsonx.ai
+// chat API (see WatsonxGateway), requiring "project_id" or "space_id" and an
+// IAM access token.
+//
 // # Base Provider
 //
 // BaseProvider provides common functionality that provider implementations can embed:
@@ -120,6 +218,37 @@
 //   - Base URL storage
 //   - Model instance management
 //
+// # Codecs
+//
+// Wire formats are implemented as a Codec, which marshals request data and
+// parses responses and streaming chunks independently of provider transport.
+// Codecs are registered per provider, protocol, and format; an empty provider
+// registers a codec shared by every provider:
+//
+//	providers.RegisterCodec("", protocol.Chat, "llamacpp", LlamaCppCodec{})
+//
+// BaseProvider's Marshal, Parse, and ParseStreamChunk use the codec for the
+// format selected with SetFormat, FormatOpenAI by default, so the built-in
+// providers parse responses through their codec as well. Built-in codecs are
+// OpenAICodec for every protocol, AnthropicCodec for chat and tools requests
+// (see MarshalAnthropicChat and MarshalAnthropicTools), and GeminiCodec for
+// tools requests (see MarshalGeminiTools). Only the Anthropic format accepts
+// a final prefill message (see BaseProvider.SupportsPrefill).
+//
+// A model's capability can select a format of its own with the "format"
+// setting (see model.FormatOption), so one provider can serve models with
+// different wire formats:
+//
+//	"capabilities": {
+//	  "chat":  {"format": "openai-chat", "temperature": 0.7},
+//	  "tools": {"format": "anthropic"}
+//	}
+//
+// Requests pass the format to Marshal in the data's Format field, and the
+// client selects it for Parse and ParseStreamChunkContext with WithFormat.
+// A format may name a variant after a hyphen, such as "openai-chat", which
+// uses the base format's codec unless one is registered for the variant.
+//
 // # Request and Response Flow
 //
 // Standard request flow:
@@ -156,6 +285,17 @@
 //	    // Handle chunk
 //	}
 //
+// Streaming events that cannot be parsed are skipped by default. In strict
+// parse mode (response.WithParseMode, or client.streaming.parse_mode
+// "strict") each is delivered as a chunk whose Error is a
+// *response.MalformedChunkError carrying the payload, and the stream
+// continues. Either way, WithMalformedChunkHook receives a MalformedChunk for
+// each, so skipped events can be counted:
+//
+//	ctx = providers.WithMalformedChunkHook(ctx, func(m providers.MalformedChunk) {
+//	    malformed.WithLabelValues(m.Provider, string(m.Protocol)).Inc()
+//	})
+//
 // # Request Structure
 //
 // The Request type packages provider-specific request details:
@@ -197,12 +337,137 @@
 //	    "token":     "your-bearer-token",
 //	}
 //
+// Gateways that authenticate requests by signature use a Signer, which the
+// client calls after SetHeaders with the final URL and body. The "signing"
+// option configures the built-in HMAC-SHA256 signer (see NewSigner), and
+// SetSigner installs any other scheme:
+//
+//	Options: map[string]any{
+//	    "signing": map[string]any{"type": "hmac-sha256", "key": "shared-secret"},
+//	}
+//
+//	p.(*providers.GatewayProvider).SetSigner(providers.SignerFunc(func(req *http.Request, body []byte) error {
+//	    return sigv4.Sign(req, body)
+//	}))
+//
+// # Middlewares
+//
+// Use wraps a provider's PrepareRequest, PrepareStreamRequest,
+// ProcessResponse, and ProcessStreamResponse with Middleware decorators, for
+// rewriting bodies or shimming gateways with quirky APIs without writing a
+// provider. The client applies them through Prepare, Process, and
+// ProcessStream; the first middleware added is the outermost:
+//
+//	p.(*providers.OllamaProvider).Use(providers.Middleware{
+//	    Process: func(next providers.ProcessFunc) providers.ProcessFunc {
+//	        return func(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
+//	            resp.Body = unwrapEnvelope(resp.Body)
+//	            return next(ctx, resp, proto)
+//	        }
+//	    },
+//	})
+//
+// # Request Metadata
+//
+// Both built-in providers forward metadata attached with WithMetadata (or
+// agent.WithMetadata) for multi-tenant attribution when configured:
+//
+//	Options: map[string]any{
+//	    "metadata_user":    "user",                               // metadata key sent as the OpenAI "user" field
+//	    "metadata_headers": map[string]any{"tenant": "X-Tenant-ID"}, // metadata key to header name
+//	}
+//
+// Custom providers can reuse MetadataForwarder in PrepareRequest, or read
+// Metadata(req.Context()) in SetHeaders.
+//
+// # Call Info
+//
+// Well-known per-call values travel in the request context as a CallInfo:
+// a request ID, the tenant, and a Priority. Callers attach them with
+// WithRequestID, WithTenant, WithPriority, or WithCallInfo, and the client
+// assigns a request ID to any request without one, shared by its retries.
+// Custom providers read them in PrepareRequest or SetHeaders to customize
+// each call without changing method signatures:
+//
+//	func (p *CustomProvider) SetHeaders(req *http.Request) {
+//	    req.Header.Set("Authorization", "Bearer "+p.apiKey)
+//	    info := providers.CallInfoFrom(req.Context())
+//	    req.Header.Set("X-Request-ID", info.RequestID)
+//	    if info.Priority != "" {
+//	        req.Header.Set("X-Priority", string(info.Priority))
+//	    }
+//	}
+//
+// # System and Developer Messages
+//
+// Requests may carry several system messages and messages with the newer
+// "developer" role. The OpenAI-compatible providers send developer messages
+// as system messages unless the "developer_role" option is set, and merge
+// all of them into one leading system message when "merge_system_messages"
+// is set (see RolePolicy). Custom providers embedding BaseProvider opt in
+// with SetRolePolicy.
+//
+// # Option Wire Names
+//
+// Requests carry stop sequences under the OpenAI "stop" key (see options.Stop).
+// Providers whose wire format uses a different name rename it in Marshal:
+//
+//	opts := providers.StopOption(d.Options, providers.StopKeyAnthropic)
+//
+// Structured output options (options.JSONMode, options.JSONSchema) use the
+// OpenAI response_format; GenerationConfigOption translates them to Gemini's
+// generationConfig. Tool choice options (tool_choice, parallel_tool_calls)
+// are translated by AnthropicToolChoiceOption and GeminiToolConfigOption.
+//
+// Vendor-specific fields without a typed option (options.Extra) are carried
+// under ExtraBodyKey. BaseProvider.Marshal removes the option and merges its
+// fields into the top level of the marshaled body with MergeExtraBody;
+// providers overriding Marshal do the same or handle the option themselves.
+//
+// # Anthropic Tool Use
+//
+// AnthropicMessages converts a conversation to Anthropic content blocks:
+// assistant tool calls become tool_use blocks and tool results (messages
+// created with protocol.NewToolMessage) become tool_result blocks grouped
+// into a single user message. AnthropicTools converts tool definitions, and
+// response.ParseAnthropicTools parses tool_use blocks back into tool calls:
+//
+//	system, messages, err := providers.AnthropicMessages(d.Messages)
+//	body := map[string]any{"system": system, "messages": messages, "tools": providers.AnthropicTools(d.Tools)}
+//
+// # Gemini Function Calling
+//
+// MarshalGeminiTools marshals ToolsData as a Gemini generateContent body, with
+// tool definitions as functionDeclarations, assistant tool calls as
+// functionCall parts, and tool results as functionResponse parts.
+// response.ParseGeminiTools parses functionCall parts back into tool calls,
+// so the same tools loop works against Gemini:
+//
+//	func (p *GeminiProvider) Marshal(proto protocol.Protocol, data any) ([]byte, error) {
+//	    if d, ok := data.(*providers.ToolsData); ok {
+//	        return providers.MarshalGeminiTools(d)
+//	    }
+//	    ...
+//	}
+//
+// # Built-in Tools
+//
+// options.WebSearch and options.FileSearch add provider-side tools under the
+// builtin_tools option (BuiltinToolsKey). The base provider sends web search
+// as web_search_options and rejects file search, which Chat Completions
+// lacks. AnthropicBuiltinTools and GeminiBuiltinTools translate the same
+// option to Anthropic's web_search server tool and Gemini google_search
+// grounding. The response parsers report the searches in each choice's
+// Grounding.
+//
 // # Error Handling
 //
 // Providers return errors for:
 //   - Unsupported protocols: GetEndpoint returns error
 //   - Invalid configuration: NewProvider constructors return error
-//   - HTTP failures: ProcessResponse/ProcessStreamResponse return error with status
+//   - HTTP failures: ProcessResponse returns a *ResponseError whose message
+//     carries the body truncated and redacted (RawBody returns the original);
+//     ProcessStreamResponse returns an error with the status
 //   - Response parsing failures: delegated to capability.ParseResponse
 //
 // # Thread Safety
@@ -218,6 +483,7 @@
 //  2. Implement Provider interface methods
 //  3. Create factory function: func(c *config.ProviderConfig) (Provider, error)
 //  4. Register factory: providers.Register("custom", NewCustomProvider)
+//  5. Verify conformance with providertest.Run (package providers/providertest)
 //
 // Example:
 //
@@ -0,0 +1,42 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+)
+
+// ResponseError is returned by ProcessResponse for non-OK responses.
+// Response bodies can echo prompts and request metadata, so Body, which
+// Error includes, is the body truncated and redacted by the default
+// redaction policy (see redact.Policy.ErrorBody). RawBody returns the
+// original body when it is explicitly needed.
+type ResponseError struct {
+	StatusCode int
+	Body       []byte
+
+	raw []byte
+}
+
+// NewResponseError returns the error for a non-OK response with the given
+// status code and body.
+func NewResponseError(statusCode int, body []byte) *ResponseError {
+	return &ResponseError{
+		StatusCode: statusCode,
+		Body:       redact.Default().ErrorBody(body),
+		raw:        body,
+	}
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// RawBody returns the full, unredacted response body. It may contain
+// sensitive content and should not be logged.
+func (e *ResponseError) RawBody() []byte {
+	if e.raw != nil {
+		return e.raw
+	}
+	return e.Body
+}
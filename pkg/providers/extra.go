@@ -0,0 +1,92 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+// ExtraBodyKey is the option key holding vendor-specific body fields (see
+// options.Extra). Providers remove it before marshaling and merge its
+// entries verbatim into the top level of the marshaled body, replacing any
+// fields of the same name.
+const ExtraBodyKey = "extra_body"
+
+// ExtraBody returns the vendor-specific body fields in options.
+// Returns an error if the extra_body option is not an object.
+func ExtraBody(options map[string]any) (map[string]any, error) {
+	switch value := options[ExtraBodyKey].(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("%s must be an object, got %T", ExtraBodyKey, value)
+	}
+}
+
+// MergeExtraBody merges extra into the top level of a marshaled JSON object
+// body, replacing fields of the same name. The body is returned unchanged
+// when extra is empty.
+// Returns an error if the body is not a JSON object.
+func MergeExtraBody(body []byte, extra map[string]any) ([]byte, error) {
+	if len(extra) == 0 {
+		return body, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return nil, fmt.Errorf("failed to merge %s: request body is not a JSON object", ExtraBodyKey)
+	}
+	maps.Copy(fields, extra)
+	return json.Marshal(fields)
+}
+
+// splitExtraBody returns a copy of request data without the extra_body
+// option, along with the option's fields. Data without the option is
+// returned unchanged.
+func splitExtraBody(data any) (any, map[string]any, error) {
+	var options map[string]any
+	switch d := data.(type) {
+	case *ChatData:
+		options = d.Options
+	case *VisionData:
+		options = d.Options
+	case *ToolsData:
+		options = d.Options
+	case *EmbeddingsData:
+		options = d.Options
+	}
+
+	if _, ok := options[ExtraBodyKey]; !ok {
+		return data, nil, nil
+	}
+
+	extra, err := ExtraBody(options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rest := maps.Clone(options)
+	delete(rest, ExtraBodyKey)
+
+	switch d := data.(type) {
+	case *ChatData:
+		c := *d
+		c.Options = rest
+		data = &c
+	case *VisionData:
+		c := *d
+		c.Options = rest
+		data = &c
+	case *ToolsData:
+		c := *d
+		c.Options = rest
+		data = &c
+	case *EmbeddingsData:
+		c := *d
+		c.Options = rest
+		data = &c
+	}
+	return data, extra, nil
+}
@@ -0,0 +1,17 @@
+package providers
+
+import "errors"
+
+// ErrFilesNotSupported indicates a provider without a files API.
+var ErrFilesNotSupported = errors.New("provider does not support files")
+
+// FileProvider is implemented by providers exposing the OpenAI Files API.
+// Uploaded file IDs can be referenced by requests that accept file inputs.
+// Providers without a files API do not implement this interface.
+type FileProvider interface {
+	Provider
+
+	// FilesEndpoint returns the URL of the files collection, or of a single
+	// file when id is not empty.
+	FilesEndpoint(id string) (string, error)
+}
@@ -0,0 +1,101 @@
+package providers
+
+import (
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// FireworksDefaultBaseURL is the Fireworks AI inference API base URL used
+// when none is configured.
+const FireworksDefaultBaseURL = "https://api.fireworks.ai/inference/v1"
+
+// FireworksDefaultAccount is the account short model names are expanded
+// under when no "account" option is configured.
+const FireworksDefaultAccount = "fireworks"
+
+// fireworksRateLimits are the headers Fireworks reports rate limits in.
+// Fireworks limits prompt and generated tokens separately; the prompt token
+// limit is reported as the token limit.
+var fireworksRateLimits = rateLimitHeaders{
+	limitRequests:     "x-ratelimit-limit-requests",
+	remainingRequests: "x-ratelimit-remaining-requests",
+	limitTokens:       "x-ratelimit-limit-tokens-prompt",
+	remainingTokens:   "x-ratelimit-remaining-tokens-prompt",
+}
+
+// FireworksProvider implements Provider for the Fireworks AI OpenAI-compatible
+// API. Fireworks names models by resource path
+// ("accounts/fireworks/models/llama-v3p1-8b-instruct"); short names are
+// expanded under the provider's account (see FireworksModelName).
+// Rate limits are reported from Fireworks's x-ratelimit-* headers.
+type FireworksProvider struct {
+	*compatibleProvider
+	account string
+}
+
+// FireworksModelName returns the resource path of a model. Names already in
+// the accounts/{account}/models/{model} form are returned unchanged; other
+// names are expanded under account.
+func FireworksModelName(account, name string) string {
+	if strings.HasPrefix(name, "accounts/") {
+		return name
+	}
+	return "accounts/" + account + "/models/" + name
+}
+
+// NewFireworks creates a new FireworksProvider from configuration.
+// Uses FireworksDefaultBaseURL when no base URL is configured, and the
+// "account" option (default FireworksDefaultAccount) to expand short model
+// names.
+// Requires a credential option ("token", "token_file", or "token_command";
+// see TokenSource), sent as a bearer token.
+// System and developer messages follow the role options (see RolePolicy).
+// Returns an error if the credential is missing or cannot be resolved.
+func NewFireworks(c *config.ProviderConfig) (Provider, error) {
+	compatible, err := newCompatibleProvider(c, compatibleAPI{
+		label:          "Fireworks",
+		defaultBaseURL: FireworksDefaultBaseURL,
+		embeddings:     true,
+		rateLimits:     fireworksRateLimits,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	account := FireworksDefaultAccount
+	if a, ok := c.Options["account"].(string); ok && a != "" {
+		account = a
+	}
+
+	return &FireworksProvider{
+		compatibleProvider: compatible,
+		account:            account,
+	}, nil
+}
+
+// Marshal converts request data to the OpenAI-compatible wire format, with
+// the model name expanded by FireworksModelName.
+func (p *FireworksProvider) Marshal(proto protocol.Protocol, data any) ([]byte, error) {
+	switch d := data.(type) {
+	case *ChatData:
+		c := *d
+		c.Model = FireworksModelName(p.account, d.Model)
+		data = &c
+	case *VisionData:
+		c := *d
+		c.Model = FireworksModelName(p.account, d.Model)
+		data = &c
+	case *ToolsData:
+		c := *d
+		c.Model = FireworksModelName(p.account, d.Model)
+		data = &c
+	case *EmbeddingsData:
+		c := *d
+		c.Model = FireworksModelName(p.account, d.Model)
+		data = &c
+	}
+
+	return p.BaseProvider.Marshal(proto, data)
+}
@@ -0,0 +1,428 @@
+package providers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// GatewayConfig describes an enterprise LLM gateway that speaks the
+// OpenAI-compatible wire format behind its own URLs, authentication header,
+// and payload envelope. It is read from the "gateway" provider option.
+//
+// Endpoint templates are paths appended to the base URL, or absolute URLs.
+// They may reference {model}, the request's model (URL-escaped), and any
+// name in Vars, e.g. "/deployments/{model}/chat?api-version={version}".
+type GatewayConfig struct {
+	// Endpoints maps protocol names to endpoint templates. Protocols
+	// without an endpoint are not supported.
+	Endpoints map[string]string `json:"endpoints"`
+
+	// StreamEndpoints maps protocol names to the templates used for
+	// streaming requests, when they differ from Endpoints.
+	StreamEndpoints map[string]string `json:"stream_endpoints,omitempty"`
+
+	// Vars are the values of template variables.
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// AuthHeader is the header the credential is sent in (default
+	// Authorization).
+	AuthHeader string `json:"auth_header,omitempty"`
+
+	// AuthScheme prefixes the credential, separated by a space. Nil uses
+	// "Bearer" for the Authorization header and no scheme otherwise.
+	AuthScheme *string `json:"auth_scheme,omitempty"`
+
+	// Headers are sent with every request.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Envelope reshapes request bodies for the gateway.
+	Envelope GatewayEnvelope `json:"envelope"`
+
+	// ResponsePath is the dot-separated path of the OpenAI-compatible
+	// response within the gateway's response body (and each streaming
+	// event), e.g. "data.result". Empty means the body is the response.
+	ResponsePath string `json:"response_path,omitempty"`
+}
+
+// GatewayEnvelope reshapes an OpenAI-compatible request body. Fields are
+// renamed first, then the body is nested under Wrap, then Fields are added
+// at the top level.
+type GatewayEnvelope struct {
+	// Rename maps body field names to the names the gateway expects, e.g.
+	// {"model": "model_id"}.
+	Rename map[string]string `json:"rename,omitempty"`
+
+	// Wrap nests the body under this key when not empty.
+	Wrap string `json:"wrap,omitempty"`
+
+	// Fields are added to the top level of the body, e.g. a project ID.
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+// GatewayProvider implements Provider for enterprise LLM gateways described
+// entirely by configuration (see GatewayConfig), so a gateway can be used
+// without writing a provider for it.
+type GatewayProvider struct {
+	*BaseProvider
+	gateway  GatewayConfig
+	tokens   *TokenSource
+	metadata *MetadataForwarder
+}
+
+// NewGateway creates a new GatewayProvider from configuration.
+// Requires the "gateway" option, a GatewayConfig or its JSON object form,
+// with at least one endpoint. A credential option ("token", "token_file", or
+// "token_command"; see TokenSource) is sent in the gateway's auth header.
+// System and developer messages follow the role options (see RolePolicy).
+// Returns an error if the gateway option is missing or invalid, or the token
+// cannot be resolved.
+func NewGateway(c *config.ProviderConfig) (Provider, error) {
+	gateway, err := gatewayOption(c.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := NewTokenSource(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve gateway token: %w", err)
+	}
+
+	return newGateway(c, gateway, tokens)
+}
+
+// newGateway creates a GatewayProvider with resolved credentials, for
+// NewGateway and providers preconfigured for a gateway API.
+func newGateway(c *config.ProviderConfig, gateway GatewayConfig, tokens *TokenSource) (*GatewayProvider, error) {
+	if len(gateway.Endpoints) == 0 {
+		return nil, fmt.Errorf("gateway requires at least one endpoint")
+	}
+	for _, endpoints := range []map[string]string{gateway.Endpoints, gateway.StreamEndpoints} {
+		for name := range endpoints {
+			if !protocol.IsValid(name) {
+				return nil, fmt.Errorf("gateway endpoint for unknown protocol %q (valid: %s)", name, protocol.ProtocolStrings())
+			}
+		}
+	}
+
+	metadata, err := NewMetadataForwarder(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata options: %w", c.Name, err)
+	}
+
+	roles, err := NewRolePolicy(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s role options: %w", c.Name, err)
+	}
+
+	signer, err := NewSigner(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s signing options: %w", c.Name, err)
+	}
+
+	base := NewBaseProvider(c.Name, strings.TrimSuffix(c.BaseURL, "/"))
+	base.SetRolePolicy(roles)
+	base.SetSigner(signer)
+
+	return &GatewayProvider{
+		BaseProvider: base,
+		gateway:      gateway,
+		tokens:       tokens,
+		metadata:     metadata,
+	}, nil
+}
+
+// gatewayOption reads the "gateway" option as a GatewayConfig.
+func gatewayOption(options map[string]any) (GatewayConfig, error) {
+	switch v := options["gateway"].(type) {
+	case GatewayConfig:
+		return v, nil
+	case *GatewayConfig:
+		if v != nil {
+			return *v, nil
+		}
+	case map[string]any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return GatewayConfig{}, fmt.Errorf("invalid gateway option: %w", err)
+		}
+		var gateway GatewayConfig
+		if err := json.Unmarshal(data, &gateway); err != nil {
+			return GatewayConfig{}, fmt.Errorf("invalid gateway option: %w", err)
+		}
+		return gateway, nil
+	case nil:
+	default:
+		return GatewayConfig{}, fmt.Errorf("gateway must be an object, got %T", v)
+	}
+	return GatewayConfig{}, fmt.Errorf("gateway option is required for gateway provider")
+}
+
+// Endpoint returns the gateway endpoint URL for a protocol, with variables
+// expanded. {model} is left in place; PrepareRequest expands it.
+// Returns an error if the gateway has no endpoint for the protocol.
+func (p *GatewayProvider) Endpoint(proto protocol.Protocol) (string, error) {
+	return p.endpoint(p.gateway.Endpoints, proto, "{model}")
+}
+
+func (p *GatewayProvider) endpoint(endpoints map[string]string, proto protocol.Protocol, model string) (string, error) {
+	template, exists := endpoints[string(proto)]
+	if !exists {
+		return "", fmt.Errorf("protocol %s not supported by %s", proto, p.Name())
+	}
+
+	replacements := []string{"{model}", model}
+	for name, value := range p.gateway.Vars {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	endpoint := strings.NewReplacer(replacements...).Replace(template)
+
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return endpoint, nil
+	}
+	return p.BaseURL() + endpoint, nil
+}
+
+// PrepareRequest prepares a standard (non-streaming) gateway request: the
+// endpoint is expanded with the request's model, and the body is reshaped
+// by the envelope after request metadata is forwarded.
+// Returns an error if the endpoint is invalid, the body is not a JSON object,
+// or the token cannot be resolved.
+func (p *GatewayProvider) PrepareRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
+	return p.prepare(ctx, p.gateway.Endpoints, proto, body, headers)
+}
+
+// PrepareStreamRequest prepares a streaming gateway request, using the
+// protocol's stream endpoint when configured.
+// Adds streaming-specific headers (Accept: text/event-stream, Cache-Control: no-cache).
+// Returns an error if the endpoint is invalid, the body is not a JSON object,
+// or the token cannot be resolved.
+func (p *GatewayProvider) PrepareStreamRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
+	endpoints := p.gateway.Endpoints
+	if _, ok := p.gateway.StreamEndpoints[string(proto)]; ok {
+		endpoints = p.gateway.StreamEndpoints
+	}
+
+	request, err := p.prepare(ctx, endpoints, proto, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	streamHeaders := make(map[string]string)
+	maps.Copy(streamHeaders, request.Headers)
+	streamHeaders["Accept"] = "text/event-stream"
+	streamHeaders["Cache-Control"] = "no-cache"
+	request.Headers = streamHeaders
+
+	return request, nil
+}
+
+func (p *GatewayProvider) prepare(ctx context.Context, endpoints map[string]string, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	var model string
+	json.Unmarshal(fields["model"], &model)
+
+	endpoint, err := p.endpoint(endpoints, proto, url.PathEscape(model))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.tokens.Token(ctx); err != nil {
+		return nil, fmt.Errorf("failed to resolve gateway token: %w", err)
+	}
+
+	body, headers, err = p.metadata.Apply(ctx, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err = p.envelope(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		URL:     endpoint,
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
+// envelope reshapes a request body per the gateway's envelope.
+func (p *GatewayProvider) envelope(body []byte) ([]byte, error) {
+	env := p.gateway.Envelope
+	if len(env.Rename) == 0 && env.Wrap == "" && len(env.Fields) == 0 {
+		return body, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	for from, to := range env.Rename {
+		if value, ok := fields[from]; ok {
+			delete(fields, from)
+			fields[to] = value
+		}
+	}
+	if env.Wrap != "" {
+		fields = map[string]any{env.Wrap: fields}
+	}
+	maps.Copy(fields, env.Fields)
+
+	return json.Marshal(fields)
+}
+
+// unwrap returns the value at the gateway's response path.
+func (p *GatewayProvider) unwrap(body []byte) ([]byte, error) {
+	if p.gateway.ResponsePath == "" {
+		return body, nil
+	}
+
+	for key := range strings.SplitSeq(p.gateway.ResponsePath, ".") {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, fmt.Errorf("invalid gateway response: %w", err)
+		}
+		value, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("gateway response has no %q", p.gateway.ResponsePath)
+		}
+		body = value
+	}
+	return body, nil
+}
+
+// ProcessResponse processes a standard gateway HTTP response.
+// Returns an error if the HTTP status is not OK.
+// Parses the response at the gateway's response path with the provider's
+// codec (see BaseProvider.Parse).
+func (p *GatewayProvider) ProcessResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, NewResponseError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	body, err = p.unwrap(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Parse(ctx, proto, body)
+}
+
+// ProcessStreamResponse processes a streaming gateway HTTP response in SSE
+// format. The space after "data:" is optional, other SSE fields are ignored,
+// and each event is unwrapped at the gateway's response path.
+// The channel is closed when the stream completes or context is cancelled.
+// Returns an error if the HTTP status is not OK.
+func (p *GatewayProvider) ProcessStreamResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (<-chan any, error) {
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	output := make(chan any)
+
+	go func() {
+		defer close(output)
+		defer resp.Body.Close()
+
+		reader := bufio.NewReader(resp.Body)
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				select {
+				case output <- &response.StreamingChunk{Error: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			data, ok := strings.CutPrefix(strings.TrimSpace(line), "data:")
+			if !ok {
+				continue
+			}
+			data = strings.TrimSpace(data)
+
+			if data == "[DONE]" {
+				return
+			}
+
+			payload := []byte(data)
+			var chunk *response.StreamingChunk
+			event, err := p.unwrap(payload)
+			if err == nil {
+				chunk, err = p.ParseStreamChunkContext(ctx, proto, event)
+			}
+			if err != nil {
+				if chunk = p.malformedChunk(ctx, proto, payload, err); chunk == nil {
+					continue
+				}
+			}
+
+			select {
+			case output <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return output, nil
+}
+
+// SetHeaders sets the gateway's static headers and the credential in its
+// auth header when one is configured. Token errors are returned by
+// PrepareRequest.
+func (p *GatewayProvider) SetHeaders(req *http.Request) {
+	for key, value := range p.gateway.Headers {
+		req.Header.Set(key, value)
+	}
+
+	token, _ := p.tokens.Token(req.Context())
+	if token == "" {
+		return
+	}
+
+	header := p.gateway.AuthHeader
+	if header == "" {
+		header = "Authorization"
+	}
+
+	var scheme string
+	switch {
+	case p.gateway.AuthScheme != nil:
+		scheme = *p.gateway.AuthScheme
+	case http.CanonicalHeaderKey(header) == "Authorization":
+		scheme = "Bearer"
+	}
+
+	if scheme != "" {
+		token = scheme + " " + token
+	}
+	req.Header.Set(header, token)
+}
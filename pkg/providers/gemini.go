@@ -0,0 +1,249 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// GeminiTool groups function declarations in the Gemini tools format, or
+// enables a built-in tool such as google_search.
+type GeminiTool struct {
+	FunctionDeclarations []GeminiFunctionDeclaration `json:"functionDeclarations,omitempty"`
+	GoogleSearch         *GeminiGoogleSearch         `json:"google_search,omitempty"`
+}
+
+// GeminiGoogleSearch enables grounding with Google Search. It has no settings.
+type GeminiGoogleSearch struct{}
+
+// GeminiFunctionDeclaration is a Gemini function (tool) declaration.
+type GeminiFunctionDeclaration struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+// GeminiContent is a turn in the Gemini contents format. Role is "user" or
+// "model".
+type GeminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []GeminiPart `json:"parts"`
+}
+
+// GeminiPart is a text, functionCall, or functionResponse part.
+type GeminiPart struct {
+	Text             string                  `json:"text,omitempty"`
+	FunctionCall     *GeminiFunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *GeminiFunctionResponse `json:"functionResponse,omitempty"`
+}
+
+// GeminiFunctionCall is a function call requested by the model.
+type GeminiFunctionCall struct {
+	ID   string          `json:"id,omitempty"`
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args"`
+}
+
+// GeminiFunctionResponse is the result of a function call. Gemini matches
+// responses to calls by name (and by ID when the call had one).
+type GeminiFunctionResponse struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Response any    `json:"response"`
+}
+
+// GeminiTools converts tool definitions to a single Gemini tool holding one
+// function declaration per definition. Returns nil for no tools.
+func GeminiTools(tools []ToolDefinition) []GeminiTool {
+	if len(tools) == 0 {
+		return nil
+	}
+	declarations := make([]GeminiFunctionDeclaration, len(tools))
+	for i, tool := range tools {
+		declarations[i] = GeminiFunctionDeclaration{Name: tool.Name, Description: tool.Description, Parameters: tool.Parameters}
+	}
+	return []GeminiTool{{FunctionDeclarations: declarations}}
+}
+
+// GeminiContents converts OpenAI-style messages to Gemini contents.
+// System and developer messages are returned as the system instruction (nil
+// if there are none). Assistant messages become "model" turns whose tool
+// calls are functionCall parts. Tool result messages (see
+// protocol.NewToolMessage) become functionResponse parts; since Gemini
+// matches results by function name, each result's name is looked up from the
+// earlier call with the same ID, and consecutive results are grouped into one
+// turn. Results that are JSON objects are sent as the response; other results
+// are wrapped as {"result": ...}.
+//
+// Only text content is translated. Returns an error for messages with
+// non-text content parts, tool calls with invalid JSON arguments, results for
+// unknown calls, and unknown roles.
+func GeminiContents(messages []protocol.Message) (*GeminiContent, []GeminiContent, error) {
+	var (
+		system   []GeminiPart
+		contents []GeminiContent
+		names    = make(map[string]string)
+	)
+
+	for i, msg := range messages {
+		text, err := textContent(msg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("message %d: %w", i, err)
+		}
+
+		switch msg.Role {
+		case "system", "developer":
+			system = append(system, GeminiPart{Text: text})
+
+		case "user":
+			contents = append(contents, GeminiContent{Role: "user", Parts: []GeminiPart{{Text: text}}})
+
+		case "assistant":
+			var parts []GeminiPart
+			if text != "" {
+				parts = append(parts, GeminiPart{Text: text})
+			}
+			for _, call := range msg.ToolCalls {
+				args, err := callArguments(call)
+				if err != nil {
+					return nil, nil, fmt.Errorf("message %d: %w", i, err)
+				}
+				names[call.ID] = call.Function.Name
+				parts = append(parts, GeminiPart{FunctionCall: &GeminiFunctionCall{
+					ID:   geminiCallID(call.ID),
+					Name: call.Function.Name,
+					Args: args,
+				}})
+			}
+			contents = append(contents, GeminiContent{Role: "model", Parts: parts})
+
+		case "tool":
+			name, ok := names[msg.ToolCallID]
+			if !ok {
+				return nil, nil, fmt.Errorf("message %d: tool result for unknown call %q", i, msg.ToolCallID)
+			}
+			part := GeminiPart{FunctionResponse: &GeminiFunctionResponse{
+				ID:       geminiCallID(msg.ToolCallID),
+				Name:     name,
+				Response: functionResponse(text),
+			}}
+			if last := len(contents) - 1; last >= 0 && contents[last].Role == "user" && contents[last].Parts[0].FunctionResponse != nil {
+				contents[last].Parts = append(contents[last].Parts, part)
+				continue
+			}
+			contents = append(contents, GeminiContent{Role: "user", Parts: []GeminiPart{part}})
+
+		default:
+			return nil, nil, fmt.Errorf("message %d: unknown role %q", i, msg.Role)
+		}
+	}
+
+	if len(system) == 0 {
+		return nil, contents, nil
+	}
+	return &GeminiContent{Parts: system}, contents, nil
+}
+
+// MarshalGeminiTools marshals tools request data as a Gemini generateContent
+// request body: contents and systemInstruction from the messages, tools from
+// the definitions and built-in tools (see GeminiBuiltinTools), tool_choice
+// as toolConfig (see GeminiToolConfigOption), response_format as
+// generationConfig (see GenerationConfigOption), and the temperature, top_p,
+// max_tokens, and stop options as their generationConfig equivalents. Other options are sent as-is.
+// Returns an error for requests with images, which need uploaded file
+// references on Gemini.
+func MarshalGeminiTools(d *ToolsData) ([]byte, error) {
+	if len(d.Images) > 0 {
+		return nil, errors.New("gemini tools requests do not support images")
+	}
+
+	system, contents, err := GeminiContents(d.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	builtin, options, err := GeminiBuiltinTools(d.Options)
+	if err != nil {
+		return nil, err
+	}
+	options, err = GeminiToolConfigOption(options)
+	if err != nil {
+		return nil, err
+	}
+	options = geminiGenerationOptions(GenerationConfigOption(options))
+
+	body := make(map[string]any, len(options)+3)
+	maps.Copy(body, options)
+	body["contents"] = contents
+	if system != nil {
+		body["systemInstruction"] = system
+	}
+	if tools := append(GeminiTools(d.Tools), builtin...); tools != nil {
+		body["tools"] = tools
+	}
+	return json.Marshal(body)
+}
+
+// geminiGenerationKeys maps OpenAI sampling options to generationConfig fields.
+var geminiGenerationKeys = map[string]string{
+	"temperature": "temperature",
+	"top_p":       "topP",
+	"max_tokens":  "maxOutputTokens",
+	"stop":        "stopSequences",
+}
+
+// geminiGenerationOptions returns a copy of options with OpenAI sampling
+// options moved into generationConfig, taking precedence over existing
+// generationConfig entries. Options are returned unchanged when none are
+// present.
+func geminiGenerationOptions(options map[string]any) map[string]any {
+	config := make(map[string]any)
+	for key, field := range geminiGenerationKeys {
+		value, ok := options[key]
+		if !ok {
+			continue
+		}
+		if s, ok := value.(string); ok && key == "stop" {
+			value = []string{s}
+		}
+		config[field] = value
+	}
+	if len(config) == 0 {
+		return options
+	}
+
+	translated := maps.Clone(options)
+	for key := range geminiGenerationKeys {
+		delete(translated, key)
+	}
+	if existing, ok := translated["generationConfig"].(map[string]any); ok {
+		merged := maps.Clone(existing)
+		maps.Copy(merged, config)
+		config = merged
+	}
+	translated["generationConfig"] = config
+	return translated
+}
+
+// geminiCallID returns the call ID to send to Gemini. IDs synthesized by
+// response.ParseGeminiTools for calls that had none are not sent back.
+func geminiCallID(id string) string {
+	if strings.HasPrefix(id, response.GeminiCallIDPrefix) {
+		return ""
+	}
+	return id
+}
+
+// functionResponse returns a tool result as a Gemini response object.
+func functionResponse(text string) any {
+	var object map[string]any
+	if err := json.Unmarshal([]byte(text), &object); err == nil && object != nil {
+		return object
+	}
+	return map[string]any{"result": text}
+}
@@ -0,0 +1,310 @@
+package providers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+)
+
+// GoogleCloudScope is the OAuth2 scope requested for Google Cloud APIs.
+const GoogleCloudScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// googleTokenURI is the OAuth2 token endpoint used when credentials do not
+// name one.
+const googleTokenURI = "https://oauth2.googleapis.com/token"
+
+// googleTokenEarlyExpiry is how long before expiry a cached access token is
+// refreshed, so requests never carry a token that expires in flight.
+const googleTokenEarlyExpiry = time.Minute
+
+// GoogleCredentials obtains OAuth2 access tokens for Google Cloud APIs.
+// Credentials are read from the "credentials_json" option (inline JSON) or
+// the "credentials_file" option, and otherwise found through Application
+// Default Credentials: the GOOGLE_APPLICATION_CREDENTIALS file, the gcloud
+// application_default_credentials.json file, or the metadata server on
+// Google Cloud compute.
+//
+// Service account keys are exchanged with a signed JWT assertion, and gcloud
+// user credentials with their refresh token. Access tokens are cached until
+// shortly before they expire.
+type GoogleCredentials struct {
+	kind        string
+	email       string
+	key         *rsa.PrivateKey
+	keyID       string
+	tokenURI    string
+	clientID    string
+	secret      string
+	refresh     string
+	metadataURL string
+	client      *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// googleCredentialsFile is the JSON credentials format written by Google
+// Cloud for service account keys and gcloud user credentials.
+type googleCredentialsFile struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// NewGoogleCredentials creates GoogleCredentials from provider options.
+// Returns an error if an option has the wrong type or the credentials cannot
+// be read or parsed. Tokens are not requested until Token is called.
+func NewGoogleCredentials(options map[string]any) (*GoogleCredentials, error) {
+	inline, err := stringOption(options, "credentials_json")
+	if err != nil {
+		return nil, err
+	}
+	file, err := stringOption(options, "credentials_file")
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	var data []byte
+	switch {
+	case inline != "":
+		data = []byte(inline)
+	case file != "":
+		if data, err = os.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("failed to read credentials_file: %w", err)
+		}
+	default:
+		if data, err = defaultCredentialsFile(); err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return &GoogleCredentials{kind: "metadata", metadataURL: metadataTokenURL(), client: client}, nil
+		}
+	}
+
+	return parseGoogleCredentials(data, client)
+}
+
+// defaultCredentialsFile reads the Application Default Credentials file, if
+// any. Returns nil data when neither GOOGLE_APPLICATION_CREDENTIALS nor the
+// gcloud credentials file exists.
+func defaultCredentialsFile() ([]byte, error) {
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GOOGLE_APPLICATION_CREDENTIALS: %w", err)
+		}
+		return data, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "gcloud", "application_default_credentials.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gcloud credentials: %w", err)
+	}
+	return data, nil
+}
+
+// metadataTokenURL returns the metadata server's token URL for the default
+// service account, honoring the GCE_METADATA_HOST override.
+func metadataTokenURL() string {
+	host := os.Getenv("GCE_METADATA_HOST")
+	if host == "" {
+		host = "metadata.google.internal"
+	}
+	return "http://" + host + "/computeMetadata/v1/instance/service-accounts/default/token"
+}
+
+func parseGoogleCredentials(data []byte, client *http.Client) (*GoogleCredentials, error) {
+	var f googleCredentialsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid Google credentials: %w", err)
+	}
+
+	c := &GoogleCredentials{kind: f.Type, tokenURI: f.TokenURI, client: client}
+	if c.tokenURI == "" {
+		c.tokenURI = googleTokenURI
+	}
+
+	switch f.Type {
+	case "service_account":
+		if f.ClientEmail == "" || f.PrivateKey == "" {
+			return nil, fmt.Errorf("service account credentials require client_email and private_key")
+		}
+		key, err := parseRSAKey(f.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		c.email, c.key, c.keyID = f.ClientEmail, key, f.PrivateKeyID
+	case "authorized_user":
+		if f.ClientID == "" || f.ClientSecret == "" || f.RefreshToken == "" {
+			return nil, fmt.Errorf("user credentials require client_id, client_secret, and refresh_token")
+		}
+		c.clientID, c.secret, c.refresh = f.ClientID, f.ClientSecret, f.RefreshToken
+	default:
+		return nil, fmt.Errorf("unsupported Google credentials type %q", f.Type)
+	}
+	return c, nil
+}
+
+// parseRSAKey parses a PEM-encoded PKCS#8 or PKCS#1 RSA private key.
+func parseRSAKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("invalid service account private_key: no PEM data")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service account private_key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("service account private_key is not an RSA key")
+	}
+	return key, nil
+}
+
+// Token returns a valid access token, requesting a new one when the cached
+// token is missing or about to expire. If a refresh fails, the previous
+// token is returned along with the error.
+// Thread-safe for concurrent access.
+func (c *GoogleCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Until(c.expires) > googleTokenEarlyExpiry {
+		return c.token, nil
+	}
+
+	var (
+		req *http.Request
+		err error
+	)
+	switch c.kind {
+	case "service_account":
+		req, err = c.jwtRequest(ctx)
+	case "authorized_user":
+		req, err = c.formRequest(ctx, url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {c.clientID},
+			"client_secret": {c.secret},
+			"refresh_token": {c.refresh},
+		})
+	default:
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, c.metadataURL, nil)
+		if err == nil {
+			req.Header.Set("Metadata-Flavor", "Google")
+		}
+	}
+	if err != nil {
+		return c.token, err
+	}
+
+	token, expires, err := c.exchange(req)
+	if err != nil {
+		return c.token, err
+	}
+
+	c.token, c.expires = token, expires
+	return c.token, nil
+}
+
+// jwtRequest builds the JWT bearer grant for a service account: an RS256
+// assertion signed with the account's key, scoped to GoogleCloudScope.
+func (c *GoogleCredentials) jwtRequest(ctx context.Context) (*http.Request, error) {
+	now := time.Now()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": c.keyID})
+	claims, _ := json.Marshal(map[string]any{
+		"iss":   c.email,
+		"scope": GoogleCloudScope,
+		"aud":   c.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+
+	encode := base64.RawURLEncoding.EncodeToString
+	unsigned := encode(header) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign service account assertion: %w", err)
+	}
+
+	return c.formRequest(ctx, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {unsigned + "." + encode(signature)},
+	})
+}
+
+func (c *GoogleCredentials) formRequest(ctx context.Context, form url.Values) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, nil
+}
+
+// exchange sends a token request and returns the access token and its expiry.
+func (c *GoogleCredentials) exchange(req *http.Request) (string, time.Time, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to request Google access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read Google token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Token error bodies carry only the OAuth2 error code and description.
+		return "", time.Time{}, fmt.Errorf("Google token request failed with status %d: %s", resp.StatusCode, redact.Default().ErrorBody(body))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid Google token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("Google token response has no access_token")
+	}
+	return result.AccessToken, time.Now().Add(time.Duration(result.ExpiresIn) * time.Second), nil
+}
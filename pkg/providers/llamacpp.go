@@ -0,0 +1,305 @@
+package providers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Constrained decoding option keys accepted by the llama.cpp server. A
+// request may set one or the other, but not both.
+const (
+	LlamaCppGrammarKey    = "grammar"
+	LlamaCppJSONSchemaKey = "json_schema"
+)
+
+// LlamaCppProvider implements Provider for the llama.cpp server's
+// OpenAI-compatible API. Requests may constrain decoding with a GBNF grammar
+// or a JSON schema (see options.Grammar and options.JSONSchemaConstraint),
+// enforced by the server while sampling, for fully local structured output.
+type LlamaCppProvider struct {
+	*BaseProvider
+	tokens   *TokenSource
+	metadata *MetadataForwarder
+}
+
+// NewLlamaCpp creates a new LlamaCppProvider from configuration.
+// Automatically adds /v1 suffix to base URL if not present.
+// A credential option ("token", "token_file", or "token_command"; see
+// TokenSource) is sent as a bearer token, matching the server's --api-key.
+// System and developer messages follow the role options (see RolePolicy).
+// Returns an error if a configured token cannot be resolved.
+func NewLlamaCpp(c *config.ProviderConfig) (Provider, error) {
+	baseURL := c.BaseURL
+	if !strings.HasSuffix(baseURL, "/v1") {
+		baseURL = strings.TrimSuffix(baseURL, "/") + "/v1"
+	}
+
+	tokens, err := NewTokenSource(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve llama.cpp token: %w", err)
+	}
+
+	metadata, err := NewMetadataForwarder(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid llama.cpp metadata options: %w", err)
+	}
+
+	roles, err := NewRolePolicy(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid llama.cpp role options: %w", err)
+	}
+
+	signer, err := NewSigner(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid llama.cpp signing options: %w", err)
+	}
+
+	base := NewBaseProvider(c.Name, baseURL)
+	base.SetRolePolicy(roles)
+	base.SetSigner(signer)
+
+	return &LlamaCppProvider{
+		BaseProvider: base,
+		tokens:       tokens,
+		metadata:     metadata,
+	}, nil
+}
+
+// Endpoint returns the full llama.cpp endpoint URL for a protocol.
+// Supports chat, vision, tools (all use /chat/completions), and embeddings (/embeddings).
+// Returns an error if the protocol is not supported.
+func (p *LlamaCppProvider) Endpoint(proto protocol.Protocol) (string, error) {
+	endpoints := map[protocol.Protocol]string{
+		protocol.Chat:       "/chat/completions",
+		protocol.Vision:     "/chat/completions",
+		protocol.Tools:      "/chat/completions",
+		protocol.Embeddings: "/embeddings",
+	}
+
+	endpoint, exists := endpoints[proto]
+	if !exists {
+		return "", fmt.Errorf("protocol %s not supported by llama.cpp", proto)
+	}
+
+	return p.BaseURL() + endpoint, nil
+}
+
+// Marshal converts request data to the server's wire format.
+// Returns an error if the options set both a grammar and a JSON schema,
+// which the server rejects.
+func (p *LlamaCppProvider) Marshal(proto protocol.Protocol, data any) ([]byte, error) {
+	var options map[string]any
+	switch d := data.(type) {
+	case *ChatData:
+		options = d.Options
+	case *VisionData:
+		options = d.Options
+	case *ToolsData:
+		options = d.Options
+	}
+
+	_, grammar := options[LlamaCppGrammarKey]
+	_, schema := options[LlamaCppJSONSchemaKey]
+	if grammar && schema {
+		return nil, fmt.Errorf("llama.cpp accepts %s or %s, not both", LlamaCppGrammarKey, LlamaCppJSONSchemaKey)
+	}
+
+	return p.BaseProvider.Marshal(proto, data)
+}
+
+// PrepareRequest prepares a standard (non-streaming) llama.cpp request.
+// Forwards request metadata per the provider's metadata options.
+// Returns an error if the endpoint is invalid or the token cannot be resolved.
+func (p *LlamaCppProvider) PrepareRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
+	endpoint, err := p.Endpoint(proto)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.tokens.Token(ctx); err != nil {
+		return nil, fmt.Errorf("failed to resolve llama.cpp token: %w", err)
+	}
+
+	body, headers, err = p.metadata.Apply(ctx, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		URL:     endpoint,
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
+// PrepareStreamRequest prepares a streaming llama.cpp request.
+// Forwards request metadata and adds streaming-specific headers (Accept: text/event-stream, Cache-Control: no-cache).
+// Returns an error if the endpoint is invalid or the token cannot be resolved.
+func (p *LlamaCppProvider) PrepareStreamRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
+	request, err := p.PrepareRequest(ctx, proto, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	streamHeaders := make(map[string]string)
+	maps.Copy(streamHeaders, request.Headers)
+	streamHeaders["Accept"] = "text/event-stream"
+	streamHeaders["Cache-Control"] = "no-cache"
+	request.Headers = streamHeaders
+
+	return request, nil
+}
+
+// ProcessResponse processes a standard llama.cpp HTTP response.
+// Returns an error if the HTTP status is not OK.
+// Parses the body with the provider's codec (see BaseProvider.Parse).
+func (p *LlamaCppProvider) ProcessResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, NewResponseError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return p.Parse(ctx, proto, body)
+}
+
+// ProcessStreamResponse processes a streaming llama.cpp HTTP response.
+// The server's SSE stream differs from OpenAI's in a few ways: the space
+// after "data:" is optional, and failures after the stream has started
+// (e.g., a grammar that cannot be compiled or a context overflow) arrive as
+// an "error:" event or a data event with an "error" object rather than an
+// HTTP status. Both are emitted as a chunk carrying a *LlamaCppError, after
+// which the stream ends.
+// The channel is closed when the stream completes or context is cancelled.
+// Returns an error if the HTTP status is not OK.
+func (p *LlamaCppProvider) ProcessStreamResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (<-chan any, error) {
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	output := make(chan any)
+
+	go func() {
+		defer close(output)
+		defer resp.Body.Close()
+
+		send := func(chunk *response.StreamingChunk) bool {
+			select {
+			case output <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		reader := bufio.NewReader(resp.Body)
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				send(&response.StreamingChunk{Error: err})
+				return
+			}
+
+			line = strings.TrimSpace(line)
+
+			if event, ok := strings.CutPrefix(line, "error:"); ok {
+				send(&response.StreamingChunk{Error: parseLlamaCppError([]byte(strings.TrimSpace(event)))})
+				return
+			}
+
+			data, ok := strings.CutPrefix(line, "data:")
+			if !ok {
+				continue
+			}
+			data = strings.TrimSpace(data)
+
+			if data == "[DONE]" {
+				return
+			}
+
+			if strings.Contains(data, `"error"`) {
+				var envelope struct {
+					Error json.RawMessage `json:"error"`
+				}
+				if json.Unmarshal([]byte(data), &envelope) == nil && len(envelope.Error) > 0 {
+					send(&response.StreamingChunk{Error: parseLlamaCppError(envelope.Error)})
+					return
+				}
+			}
+
+			payload := []byte(data)
+			chunk, err := p.ParseStreamChunkContext(ctx, proto, payload)
+			if err != nil {
+				if chunk = p.malformedChunk(ctx, proto, payload, err); chunk == nil {
+					continue
+				}
+			}
+
+			if !send(chunk) {
+				return
+			}
+		}
+	}()
+
+	return output, nil
+}
+
+// SetHeaders sets the bearer token on the HTTP request when a credential is
+// configured.
+func (p *LlamaCppProvider) SetHeaders(req *http.Request) {
+	// Token errors are returned by PrepareRequest
+	if token, _ := p.tokens.Token(req.Context()); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// LlamaCppError is an error reported by the llama.cpp server mid-stream.
+type LlamaCppError struct {
+	Code    int    `json:"code"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func (e *LlamaCppError) Error() string {
+	if e.Type != "" {
+		return fmt.Sprintf("llama.cpp %s (%d): %s", e.Type, e.Code, e.Message)
+	}
+	return "llama.cpp error: " + e.Message
+}
+
+// parseLlamaCppError decodes a stream error event, falling back to the raw
+// event text as the message.
+func parseLlamaCppError(data []byte) error {
+	e := &LlamaCppError{}
+	if err := json.Unmarshal(data, e); err != nil || e.Message == "" {
+		var message string
+		if json.Unmarshal(data, &message) != nil {
+			message = string(data)
+		}
+		e = &LlamaCppError{Message: message}
+	}
+	if e.Message == "" {
+		return errors.New("llama.cpp stream error")
+	}
+	return e
+}
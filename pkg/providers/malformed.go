@@ -0,0 +1,63 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// MalformedChunk describes a streaming event that could not be parsed.
+// Surfaced reports whether it was delivered as an error chunk (strict parse
+// mode) rather than skipped.
+type MalformedChunk struct {
+	Provider string
+	Protocol protocol.Protocol
+	Payload  []byte
+	Err      error
+	Surfaced bool
+}
+
+// MalformedChunkHook receives each streaming event that could not be parsed.
+// Hooks run synchronously on the stream goroutine and should return quickly.
+type MalformedChunkHook func(MalformedChunk)
+
+type malformedChunkHooksKey struct{}
+
+// WithMalformedChunkHook returns a context whose streams report every event
+// that could not be parsed to hook, whether it was skipped or surfaced.
+// Hooks accumulate.
+//
+//	ctx = providers.WithMalformedChunkHook(ctx, func(m providers.MalformedChunk) {
+//	    malformed.WithLabelValues(m.Provider, string(m.Protocol)).Inc()
+//	})
+func WithMalformedChunkHook(ctx context.Context, hook MalformedChunkHook) context.Context {
+	hooks, _ := ctx.Value(malformedChunkHooksKey{}).([]MalformedChunkHook)
+	hooks = append(hooks[:len(hooks):len(hooks)], hook)
+	return context.WithValue(ctx, malformedChunkHooksKey{}, hooks)
+}
+
+// malformedChunk handles a streaming event that failed to parse with err.
+// The event is reported to the hooks carried by ctx. In strict parse mode
+// (see response.WithParseMode) it returns an error chunk carrying a
+// *response.MalformedChunkError with the payload; otherwise it returns nil
+// and the event is skipped.
+func (p *BaseProvider) malformedChunk(ctx context.Context, proto protocol.Protocol, data []byte, err error) *response.StreamingChunk {
+	strict := response.ParseModeFromContext(ctx) == response.ParseStrict
+
+	hooks, _ := ctx.Value(malformedChunkHooksKey{}).([]MalformedChunkHook)
+	for _, hook := range hooks {
+		hook(MalformedChunk{
+			Provider: p.Name(),
+			Protocol: proto,
+			Payload:  data,
+			Err:      err,
+			Surfaced: strict,
+		})
+	}
+
+	if !strict {
+		return nil
+	}
+	return &response.StreamingChunk{Error: &response.MalformedChunkError{Payload: data, Err: err}}
+}
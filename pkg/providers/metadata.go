@@ -0,0 +1,121 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+// metadataKey is the context key for request metadata.
+type metadataKey struct{}
+
+// WithMetadata returns a context carrying request metadata (e.g., tenant or
+// user IDs) for attribution. Metadata already in ctx is kept, with md values
+// overriding matching keys. The map is copied.
+func WithMetadata(ctx context.Context, md map[string]string) context.Context {
+	merged := Metadata(ctx)
+	if merged == nil {
+		merged = make(map[string]string, len(md))
+	}
+	maps.Copy(merged, md)
+	return context.WithValue(ctx, metadataKey{}, merged)
+}
+
+// Metadata returns a copy of the request metadata carried by ctx,
+// or nil if there is none.
+func Metadata(ctx context.Context) map[string]string {
+	md, _ := ctx.Value(metadataKey{}).(map[string]string)
+	return maps.Clone(md)
+}
+
+// MetadataForwarder forwards request metadata to the provider API according
+// to provider options:
+//
+//   - "metadata_user": metadata key sent as the OpenAI "user" body field
+//   - "metadata_headers": map of metadata key to HTTP header name
+//
+// Example options:
+//
+//	"metadata_user": "user",
+//	"metadata_headers": {"tenant": "X-Tenant-ID"}
+//
+// A nil or unconfigured forwarder sends nothing.
+type MetadataForwarder struct {
+	user    string
+	headers map[string]string
+}
+
+// NewMetadataForwarder creates a MetadataForwarder from provider options.
+// Returns an error if an option has the wrong type.
+func NewMetadataForwarder(options map[string]any) (*MetadataForwarder, error) {
+	user, err := stringOption(options, "metadata_user")
+	if err != nil {
+		return nil, err
+	}
+
+	f := &MetadataForwarder{user: user}
+
+	switch headers := options["metadata_headers"].(type) {
+	case nil:
+	case map[string]string:
+		f.headers = maps.Clone(headers)
+	case map[string]any:
+		f.headers = make(map[string]string, len(headers))
+		for key, value := range headers {
+			name, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("metadata_headers[%s] must be a string, got %T", key, value)
+			}
+			f.headers[key] = name
+		}
+	default:
+		return nil, fmt.Errorf("metadata_headers must be an object, got %T", headers)
+	}
+
+	return f, nil
+}
+
+// Apply returns the body and headers with the metadata in ctx forwarded.
+// The inputs are not modified. A "user" field already in the body is kept.
+// Returns an error if the user field must be added to a body that is not a
+// JSON object.
+func (f *MetadataForwarder) Apply(ctx context.Context, body []byte, headers map[string]string) ([]byte, map[string]string, error) {
+	if f == nil {
+		return body, headers, nil
+	}
+
+	md := Metadata(ctx)
+	if len(md) == 0 {
+		return body, headers, nil
+	}
+
+	if len(f.headers) > 0 {
+		headers = maps.Clone(headers)
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		for key, name := range f.headers {
+			if value, ok := md[key]; ok {
+				headers[name] = value
+			}
+		}
+	}
+
+	if user, ok := md[f.user]; ok && f.user != "" {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, nil, fmt.Errorf("failed to add user field: %w", err)
+		}
+		if _, exists := fields["user"]; !exists {
+			fields["user"], _ = json.Marshal(user)
+			data, err := json.Marshal(fields)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to add user field: %w", err)
+			}
+			body = data
+		}
+	}
+
+	return body, headers, nil
+}
@@ -0,0 +1,120 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// PrepareFunc prepares a provider request, as PrepareRequest and
+// PrepareStreamRequest do.
+type PrepareFunc func(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error)
+
+// ProcessFunc processes a provider response, as ProcessResponse does.
+type ProcessFunc func(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error)
+
+// ProcessStreamFunc processes a streaming provider response, as
+// ProcessStreamResponse does.
+type ProcessStreamFunc func(ctx context.Context, resp *http.Response, proto protocol.Protocol) (<-chan any, error)
+
+// Middleware decorates how a provider prepares requests and processes
+// responses, for body rewriting and compatibility shims for gateways that
+// deviate from the provider's API. Each field wraps the next step and may be
+// nil. Prepare wraps both PrepareRequest and PrepareStreamRequest; IsStream
+// reports which is being called.
+//
+//	p.(*providers.GatewayProvider).Use(providers.Middleware{
+//	    Prepare: func(next providers.PrepareFunc) providers.PrepareFunc {
+//	        return func(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*providers.Request, error) {
+//	            body = bytes.ReplaceAll(body, []byte(`"max_tokens"`), []byte(`"max_new_tokens"`))
+//	            return next(ctx, proto, body, headers)
+//	        }
+//	    },
+//	})
+type Middleware struct {
+	Prepare       func(next PrepareFunc) PrepareFunc
+	Process       func(next ProcessFunc) ProcessFunc
+	ProcessStream func(next ProcessStreamFunc) ProcessStreamFunc
+}
+
+// Decorated is implemented by providers with middlewares. BaseProvider
+// implements it, so every built-in provider accepts middlewares with Use.
+type Decorated interface {
+	Middlewares() []Middleware
+}
+
+// Use adds middlewares to the provider. The first middleware added is the
+// outermost: it sees requests first and responses last.
+// Middlewares should be added before the provider serves requests; Use is
+// not safe to call concurrently with them.
+func (p *BaseProvider) Use(mw ...Middleware) {
+	p.middlewares = append(p.middlewares, mw...)
+}
+
+// Middlewares returns the provider's middlewares in the order they were added.
+func (p *BaseProvider) Middlewares() []Middleware {
+	return p.middlewares
+}
+
+type streamKey struct{}
+
+// IsStream reports whether ctx belongs to a streaming request, so a Prepare
+// middleware can tell PrepareStreamRequest from PrepareRequest.
+func IsStream(ctx context.Context) bool {
+	stream, _ := ctx.Value(streamKey{}).(bool)
+	return stream
+}
+
+// Prepare prepares a request with the provider's PrepareRequest, or
+// PrepareStreamRequest when stream is true, wrapped in its middlewares.
+func Prepare(ctx context.Context, p Provider, proto protocol.Protocol, body []byte, headers map[string]string, stream bool) (*Request, error) {
+	prepare := PrepareFunc(p.PrepareRequest)
+	if stream {
+		prepare = p.PrepareStreamRequest
+	}
+
+	middlewares := middlewaresOf(p)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if wrap := middlewares[i].Prepare; wrap != nil {
+			prepare = wrap(prepare)
+		}
+	}
+	return prepare(context.WithValue(ctx, streamKey{}, stream), proto, body, headers)
+}
+
+// Process processes a response with the provider's ProcessResponse wrapped
+// in its middlewares.
+func Process(ctx context.Context, p Provider, resp *http.Response, proto protocol.Protocol) (any, error) {
+	process := ProcessFunc(p.ProcessResponse)
+
+	middlewares := middlewaresOf(p)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if wrap := middlewares[i].Process; wrap != nil {
+			process = wrap(process)
+		}
+	}
+	return process(ctx, resp, proto)
+}
+
+// ProcessStream processes a streaming response with the provider's
+// ProcessStreamResponse wrapped in its middlewares.
+func ProcessStream(ctx context.Context, p Provider, resp *http.Response, proto protocol.Protocol) (<-chan any, error) {
+	process := ProcessStreamFunc(p.ProcessStreamResponse)
+
+	middlewares := middlewaresOf(p)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if wrap := middlewares[i].ProcessStream; wrap != nil {
+			process = wrap(process)
+		}
+	}
+	return process(context.WithValue(ctx, streamKey{}, true), resp, proto)
+}
+
+// middlewaresOf returns the middlewares of a provider, or nil.
+func middlewaresOf(p Provider) []Middleware {
+	if decorated, ok := p.(Decorated); ok {
+		return decorated.Middlewares()
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package providers
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"maps"
@@ -18,21 +19,52 @@ import (
 // Supports local and remote Ollama instances with optional authentication.
 type OllamaProvider struct {
 	*BaseProvider
-	options map[string]any
+	options  map[string]any
+	tokens   *TokenSource
+	metadata *MetadataForwarder
 }
 
 // NewOllama creates a new OllamaProvider from configuration.
 // Automatically adds /v1 suffix to base URL if not present for OpenAI compatibility.
-// Supports optional authentication via "auth_type" and "token" options.
+// Supports optional authentication via "auth_type" and a credential option:
+// "token", "token_file", or "token_command" (see TokenSource).
+// System and developer messages follow the role options (see RolePolicy).
+// Returns an error if a configured token cannot be resolved.
 func NewOllama(c *config.ProviderConfig) (Provider, error) {
 	baseURL := c.BaseURL
 	if !strings.HasSuffix(baseURL, "/v1") {
 		baseURL = strings.TrimSuffix(baseURL, "/") + "/v1"
 	}
 
+	tokens, err := NewTokenSource(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve Ollama token: %w", err)
+	}
+
+	metadata, err := NewMetadataForwarder(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ollama metadata options: %w", err)
+	}
+
+	roles, err := NewRolePolicy(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ollama role options: %w", err)
+	}
+
+	signer, err := NewSigner(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ollama signing options: %w", err)
+	}
+
+	base := NewBaseProvider(c.Name, baseURL)
+	base.SetRolePolicy(roles)
+	base.SetSigner(signer)
+
 	return &OllamaProvider{
-		BaseProvider: NewBaseProvider(c.Name, baseURL),
+		BaseProvider: base,
 		options:      c.Options,
+		tokens:       tokens,
+		metadata:     metadata,
 	}, nil
 }
 
@@ -56,13 +88,23 @@ func (p *OllamaProvider) Endpoint(proto protocol.Protocol) (string, error) {
 }
 
 // PrepareRequest prepares a standard (non-streaming) Ollama request.
-// Returns an error if the endpoint is invalid.
+// Forwards request metadata per the provider's metadata options.
+// Returns an error if the endpoint is invalid or the token cannot be resolved.
 func (p *OllamaProvider) PrepareRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
 	endpoint, err := p.Endpoint(proto)
 	if err != nil {
 		return nil, err
 	}
 
+	if _, err := p.tokens.Token(ctx); err != nil {
+		return nil, fmt.Errorf("failed to resolve Ollama token: %w", err)
+	}
+
+	body, headers, err = p.metadata.Apply(ctx, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Request{
 		URL:     endpoint,
 		Headers: headers,
@@ -71,14 +113,23 @@ func (p *OllamaProvider) PrepareRequest(ctx context.Context, proto protocol.Prot
 }
 
 // PrepareStreamRequest prepares a streaming Ollama request.
-// Adds streaming-specific headers (Accept: text/event-stream, Cache-Control: no-cache).
-// Returns an error if the endpoint is invalid.
+// Forwards request metadata and adds streaming-specific headers (Accept: text/event-stream, Cache-Control: no-cache).
+// Returns an error if the endpoint is invalid or the token cannot be resolved.
 func (p *OllamaProvider) PrepareStreamRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
 	endpoint, err := p.Endpoint(proto)
 	if err != nil {
 		return nil, err
 	}
 
+	if _, err := p.tokens.Token(ctx); err != nil {
+		return nil, fmt.Errorf("failed to resolve Ollama token: %w", err)
+	}
+
+	body, headers, err = p.metadata.Apply(ctx, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
 	// Clone headers to avoid mutating the original
 	streamHeaders := make(map[string]string)
 	maps.Copy(streamHeaders, headers)
@@ -94,11 +145,11 @@ func (p *OllamaProvider) PrepareStreamRequest(ctx context.Context, proto protoco
 
 // ProcessResponse processes a standard Ollama HTTP response.
 // Returns an error if the HTTP status is not OK.
-// Uses response.Parse for protocol-aware parsing.
+// Parses the body with the provider's codec (see BaseProvider.Parse).
 func (p *OllamaProvider) ProcessResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, NewResponseError(resp.StatusCode, body)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -106,7 +157,7 @@ func (p *OllamaProvider) ProcessResponse(ctx context.Context, resp *http.Respons
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return response.Parse(proto, body)
+	return p.Parse(ctx, proto, body)
 }
 
 // ProcessStreamResponse processes a streaming Ollama HTTP response.
@@ -157,9 +208,12 @@ func (p *OllamaProvider) ProcessStreamResponse(ctx context.Context, resp *http.R
 				line = after
 			}
 
-			chunk, err := response.ParseStreamChunk(proto, []byte(line))
+			payload := []byte(line)
+			chunk, err := p.ParseStreamChunkContext(ctx, proto, payload)
 			if err != nil {
-				continue
+				if chunk = p.malformedChunk(ctx, proto, payload, err); chunk == nil {
+					continue
+				}
 			}
 
 			select {
@@ -173,12 +227,37 @@ func (p *OllamaProvider) ProcessStreamResponse(ctx context.Context, resp *http.R
 	return output, nil
 }
 
+// PrewarmRequest returns a request to Ollama's native generate endpoint with
+// no prompt, which loads the model without generating output. keepAlive is
+// sent as keep_alive when not nil.
+func (p *OllamaProvider) PrewarmRequest(model string, keepAlive any) (*Request, error) {
+	if model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+
+	payload := map[string]any{"model": model}
+	if keepAlive != nil {
+		payload["keep_alive"] = keepAlive
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal prewarm request: %w", err)
+	}
+
+	return &Request{
+		URL:     strings.TrimSuffix(p.BaseURL(), "/v1") + "/api/generate",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    body,
+	}, nil
+}
+
 // SetHeaders sets authentication headers on the HTTP request.
 // Supports "bearer" token (Authorization: Bearer <token>) and "api_key" (custom header).
 // The "auth_header" option allows customizing the API key header name (default: X-API-Key).
 func (p *OllamaProvider) SetHeaders(req *http.Request) {
 	if authType, ok := p.options["auth_type"].(string); ok {
-		if token, ok := p.options["token"].(string); ok && token != "" {
+		// Token errors are returned by PrepareRequest
+		if token, _ := p.tokens.Token(req.Context()); token != "" {
 			switch authType {
 			case "bearer":
 				req.Header.Set("Authorization", "Bearer "+token)
@@ -0,0 +1,185 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// Stop sequence option keys used by provider wire formats.
+// Requests always carry stop sequences under StopKeyOpenAI (as set by
+// options.Stop); providers with a different wire name rename the key in
+// Marshal with StopOption.
+const (
+	StopKeyOpenAI    = "stop"
+	StopKeyAnthropic = "stop_sequences"
+	StopKeyGemini    = "stopSequences"
+)
+
+// StopOption returns a copy of options with the canonical "stop" entry moved
+// to key. A single string value is converted to a one-element []string, since
+// non-OpenAI formats only accept arrays. The original map is not modified, and
+// options are returned unchanged when there is no stop entry or key is "stop".
+func StopOption(options map[string]any, key string) map[string]any {
+	value, ok := options[StopKeyOpenAI]
+	if !ok || key == StopKeyOpenAI {
+		return options
+	}
+
+	renamed := maps.Clone(options)
+	delete(renamed, StopKeyOpenAI)
+	if s, ok := value.(string); ok {
+		value = []string{s}
+	}
+	renamed[key] = value
+	return renamed
+}
+
+// GenerationConfigOption returns a copy of options with an OpenAI
+// response_format translated to Gemini generationConfig fields:
+// json_object sets responseMimeType to "application/json", and json_schema
+// additionally sets responseSchema. Existing generationConfig entries are
+// preserved. Options are returned unchanged when response_format is absent or
+// requests plain text.
+func GenerationConfigOption(options map[string]any) map[string]any {
+	format, ok := options["response_format"].(map[string]any)
+	if !ok {
+		return options
+	}
+
+	config := make(map[string]any)
+	switch format["type"] {
+	case "json_object":
+		config["responseMimeType"] = "application/json"
+	case "json_schema":
+		config["responseMimeType"] = "application/json"
+		if spec, ok := format["json_schema"].(map[string]any); ok && spec["schema"] != nil {
+			config["responseSchema"] = spec["schema"]
+		}
+	default:
+		return options
+	}
+
+	translated := maps.Clone(options)
+	delete(translated, "response_format")
+	if existing, ok := translated["generationConfig"].(map[string]any); ok {
+		merged := maps.Clone(existing)
+		maps.Copy(merged, config)
+		config = merged
+	}
+	translated["generationConfig"] = config
+	return translated
+}
+
+// Tool choice modes accepted in the OpenAI tool_choice option.
+const (
+	ToolChoiceAuto     = "auto"
+	ToolChoiceNone     = "none"
+	ToolChoiceRequired = "required"
+	ToolChoiceFunction = "function"
+)
+
+// ParseToolChoice interprets an OpenAI tool_choice value: one of the mode
+// strings "auto", "none", or "required", or an object naming a function
+// ({"type": "function", "function": {"name": "..."}}). For a named function,
+// mode is ToolChoiceFunction and name holds the function name.
+// Returns an error if the value has neither shape.
+func ParseToolChoice(value any) (mode, name string, err error) {
+	switch v := value.(type) {
+	case string:
+		switch v {
+		case ToolChoiceAuto, ToolChoiceNone, ToolChoiceRequired:
+			return v, "", nil
+		}
+		return "", "", fmt.Errorf("unknown tool_choice mode %q", v)
+	case map[string]any:
+		function, _ := v["function"].(map[string]any)
+		name, _ := function["name"].(string)
+		if v["type"] != ToolChoiceFunction || name == "" {
+			return "", "", errors.New(`tool_choice object must have type "function" and a function name`)
+		}
+		return ToolChoiceFunction, name, nil
+	default:
+		return "", "", fmt.Errorf("tool_choice must be a string or object, got %T", value)
+	}
+}
+
+// AnthropicToolChoiceOption returns a copy of options with tool_choice and
+// parallel_tool_calls translated to the Anthropic tool_choice object:
+// "required" becomes {"type": "any"}, a named function becomes
+// {"type": "tool", "name": ...}, and parallel_tool_calls false sets
+// disable_parallel_tool_use. Options are returned unchanged when neither key
+// is present. Returns an error if tool_choice is invalid.
+func AnthropicToolChoiceOption(options map[string]any) (map[string]any, error) {
+	value, hasChoice := options["tool_choice"]
+	parallel, hasParallel := options["parallel_tool_calls"].(bool)
+	if !hasChoice && !hasParallel {
+		return options, nil
+	}
+
+	choice := map[string]any{"type": ToolChoiceAuto}
+	if hasChoice {
+		mode, name, err := ParseToolChoice(value)
+		if err != nil {
+			return nil, err
+		}
+		switch mode {
+		case ToolChoiceRequired:
+			choice["type"] = "any"
+		case ToolChoiceFunction:
+			choice["type"] = "tool"
+			choice["name"] = name
+		default:
+			choice["type"] = mode
+		}
+	}
+	if hasParallel && !parallel && choice["type"] != ToolChoiceNone {
+		choice["disable_parallel_tool_use"] = true
+	}
+
+	translated := maps.Clone(options)
+	delete(translated, "parallel_tool_calls")
+	translated["tool_choice"] = choice
+	return translated, nil
+}
+
+// GeminiToolConfigOption returns a copy of options with tool_choice translated
+// to a Gemini toolConfig.functionCallingConfig: "auto", "none", and "required"
+// map to the AUTO, NONE, and ANY modes, and a named function maps to ANY with
+// allowedFunctionNames. Gemini has no parallel_tool_calls setting, so the key
+// is dropped. Options are returned unchanged when neither key is present.
+// Returns an error if tool_choice is invalid.
+func GeminiToolConfigOption(options map[string]any) (map[string]any, error) {
+	value, hasChoice := options["tool_choice"]
+	_, hasParallel := options["parallel_tool_calls"]
+	if !hasChoice && !hasParallel {
+		return options, nil
+	}
+
+	translated := maps.Clone(options)
+	delete(translated, "tool_choice")
+	delete(translated, "parallel_tool_calls")
+	if !hasChoice {
+		return translated, nil
+	}
+
+	mode, name, err := ParseToolChoice(value)
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]any{}
+	switch mode {
+	case ToolChoiceAuto:
+		config["mode"] = "AUTO"
+	case ToolChoiceNone:
+		config["mode"] = "NONE"
+	case ToolChoiceRequired:
+		config["mode"] = "ANY"
+	case ToolChoiceFunction:
+		config["mode"] = "ANY"
+		config["allowedFunctionNames"] = []string{name}
+	}
+	translated["toolConfig"] = map[string]any{"functionCallingConfig": config}
+	return translated, nil
+}
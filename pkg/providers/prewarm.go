@@ -0,0 +1,19 @@
+package providers
+
+import "errors"
+
+// ErrPrewarmNotSupported indicates a provider that cannot load a model ahead
+// of requests.
+var ErrPrewarmNotSupported = errors.New("provider does not support prewarming")
+
+// Prewarmer is implemented by providers that can load a model into memory
+// without generating output, such as local model servers that load models on
+// first use. Providers that keep models resident do not implement it.
+type Prewarmer interface {
+	Provider
+
+	// PrewarmRequest returns the request that loads model. A non-nil
+	// keepAlive is the residency hint (see options.KeepAlive) for how long
+	// the model stays loaded afterwards.
+	PrewarmRequest(model string, keepAlive any) (*Request, error)
+}
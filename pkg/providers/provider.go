@@ -27,6 +27,7 @@ type Provider interface {
 
 	// SetHeaders sets provider-specific authentication and custom headers on an HTTP request.
 	// This is called after the request is created but before it is executed.
+	// Per-call values are available with CallInfoFrom(req.Context()).
 	SetHeaders(req *http.Request)
 
 	// Marshal converts request data to provider-specific JSON format.
@@ -37,6 +38,7 @@ type Provider interface {
 
 	// PrepareRequest creates a Request for standard (non-streaming) protocol execution.
 	// Accepts pre-marshaled request body and headers from the request structure.
+	// Per-call values are available with CallInfoFrom(ctx).
 	PrepareRequest(ctx context.Context, p protocol.Protocol, body []byte, headers map[string]string) (*Request, error)
 
 	// PrepareStreamRequest creates a Request for streaming protocol execution.
@@ -44,7 +46,7 @@ type Provider interface {
 	PrepareStreamRequest(ctx context.Context, p protocol.Protocol, body []byte, headers map[string]string) (*Request, error)
 
 	// ProcessResponse processes a standard HTTP response and returns the parsed result.
-	// Uses response.Parse for protocol-aware parsing.
+	// Uses response.ParseContext for protocol-aware parsing in the context parse mode.
 	// Returns an error if the HTTP status is not OK or parsing fails.
 	ProcessResponse(ctx context.Context, resp *http.Response, p protocol.Protocol) (any, error)
 
@@ -66,3 +68,12 @@ type Request struct {
 	// Body is the marshaled request body ready for HTTP transmission.
 	Body []byte
 }
+
+// PrefillSupporter is implemented by providers that can continue a final,
+// partial assistant message (see protocol.NewPrefillMessage). Requests
+// ending in a prefill message are rejected for providers that do not
+// implement it or report false for the request's wire format. An empty
+// format selects the provider's own.
+type PrefillSupporter interface {
+	SupportsPrefill(format string) bool
+}
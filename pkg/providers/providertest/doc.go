@@ -0,0 +1,36 @@
+// Package providertest provides a conformance test harness for
+// providers.Provider implementations, in the manner of net/http/httptest.
+//
+// Run exercises a provider against an in-process test server with a battery
+// of scenarios, each a subtest:
+//
+//   - Name and Endpoint: the provider is named, returns absolute endpoint URLs
+//     for its supported protocols, and rejects every other protocol
+//   - Marshal: request data marshals to a JSON object, and data of the wrong
+//     type is rejected
+//   - PrepareRequest and PrepareStreamRequest: prepared requests carry the
+//     caller's headers without modifying them, and stream requests accept
+//     text/event-stream
+//   - RoundTrip: a request for each protocol is sent to the server, and the
+//     response is processed into the protocol's response type with the
+//     expected answer
+//   - ErrorStatus: error statuses fail both standard and stream processing
+//   - Stream and StreamCancel: streams deliver their content and close when
+//     the response ends, and close promptly when the context is cancelled
+//
+// The server answers in the OpenAI-compatible format by default. Providers
+// with another wire format supply their own bodies carrying the same answer
+// (Content, or a call to ToolName with ToolArguments); GeminiResponse and
+// AnthropicResponse cover the built-in formats.
+//
+// Example:
+//
+//	func TestMyProvider(t *testing.T) {
+//	    providertest.Run(t, providertest.Config{
+//	        New: func(baseURL string) (providers.Provider, error) {
+//	            return NewMyProvider(&config.ProviderConfig{Name: "mine", BaseURL: baseURL})
+//	        },
+//	        Protocols: []protocol.Protocol{protocol.Chat, protocol.Tools},
+//	    })
+//	}
+package providertest
@@ -0,0 +1,470 @@
+package providertest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// The answer every scenario expects: chat and vision responses with Content,
+// tools responses calling ToolName with ToolArguments, and embeddings
+// responses with one embedding. Custom response bodies must carry the same
+// answer in the provider's wire format.
+const (
+	Content       = "Hello from providertest"
+	ToolName      = "providertest_tool"
+	ToolArguments = `{"value":"providertest"}`
+)
+
+// Model is the model requests name when Config.Model is empty.
+const Model = "providertest-model"
+
+// testHeader is a caller header every request carries, which providers must
+// send unchanged.
+const testHeader = "X-Providertest"
+
+// streamTimeout bounds how long a scenario waits for a stream to finish or
+// close after cancellation.
+const streamTimeout = 5 * time.Second
+
+// Config describes the provider under test.
+type Config struct {
+	// New creates the provider, sending requests to baseURL, the URL of a
+	// test server. It is called once per scenario.
+	New func(baseURL string) (providers.Provider, error)
+
+	// Protocols are the protocols the provider supports. Endpoint must
+	// reject every other protocol.
+	Protocols []protocol.Protocol
+
+	// Streaming are the protocols the provider streams. Nil means every
+	// supported protocol that supports streaming; use an empty slice for
+	// providers that do not stream.
+	Streaming []protocol.Protocol
+
+	// Model is the model named in requests (default Model).
+	Model string
+
+	// Response returns a successful response body for a protocol in the
+	// provider's wire format. Nil uses the OpenAI-compatible format.
+	Response func(p protocol.Protocol) []byte
+
+	// StreamResponse returns a successful streaming response body for a
+	// protocol, whose content deltas join to Content. Nil uses
+	// OpenAI-compatible SSE.
+	StreamResponse func(p protocol.Protocol) []byte
+}
+
+// Run runs the conformance scenarios against the provider as subtests:
+// naming and endpoint routing, request marshaling and preparation, a full
+// round trip through a test server for every supported protocol, error
+// statuses, streaming, and stream cancellation.
+func Run(t *testing.T, c Config) {
+	t.Helper()
+	if c.New == nil {
+		t.Fatal("providertest: Config.New is required")
+	}
+	if c.Model == "" {
+		c.Model = Model
+	}
+	if c.Response == nil {
+		c.Response = OpenAIResponse
+	}
+	if c.StreamResponse == nil {
+		c.StreamResponse = OpenAIStreamResponse
+	}
+	if c.Streaming == nil {
+		for _, p := range c.Protocols {
+			if p.SupportsStreaming() {
+				c.Streaming = append(c.Streaming, p)
+			}
+		}
+	}
+
+	s := &suite{Config: c}
+	t.Run("Name", s.name)
+	t.Run("Endpoint", s.endpoint)
+	t.Run("Marshal", s.marshal)
+	t.Run("PrepareRequest", s.prepareRequest)
+	t.Run("PrepareStreamRequest", s.prepareStreamRequest)
+	t.Run("RoundTrip", s.roundTrip)
+	t.Run("ErrorStatus", s.errorStatus)
+	t.Run("Stream", s.stream)
+	t.Run("StreamCancel", s.streamCancel)
+}
+
+type suite struct {
+	Config
+}
+
+// provider creates the provider under test against a server running handler.
+func (s *suite) provider(t *testing.T, handler http.Handler) (providers.Provider, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	p, err := s.New(server.URL)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return p, server
+}
+
+// data returns request data for a protocol.
+func (s *suite) data(p protocol.Protocol) any {
+	messages := []protocol.Message{
+		protocol.NewMessage("system", "You are a conformance test."),
+		protocol.NewMessage("user", "Hello"),
+	}
+
+	switch p {
+	case protocol.Vision:
+		return &providers.VisionData{
+			Model:    s.Model,
+			Messages: messages,
+			Images:   []string{"data:image/png;base64,iVBORw0KGgo="},
+		}
+	case protocol.Tools:
+		return &providers.ToolsData{
+			Model:    s.Model,
+			Messages: messages,
+			Tools: []providers.ToolDefinition{{
+				Name:        ToolName,
+				Description: "A conformance test tool.",
+				Parameters: map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"value": map[string]any{"type": "string"}},
+				},
+			}},
+		}
+	case protocol.Embeddings:
+		return &providers.EmbeddingsData{Model: s.Model, Input: "Hello"}
+	default:
+		return &providers.ChatData{Model: s.Model, Messages: messages}
+	}
+}
+
+// body marshals request data for a protocol.
+func (s *suite) body(t *testing.T, p providers.Provider, proto protocol.Protocol) []byte {
+	t.Helper()
+	body, err := p.Marshal(proto, s.data(proto))
+	if err != nil {
+		t.Fatalf("Marshal(%s) failed: %v", proto, err)
+	}
+	return body
+}
+
+// send prepares a request, sends it to the server, and returns the response.
+func (s *suite) send(t *testing.T, ctx context.Context, p providers.Provider, server *httptest.Server, proto protocol.Protocol, stream bool) *http.Response {
+	t.Helper()
+	prepare := p.PrepareRequest
+	if stream {
+		prepare = p.PrepareStreamRequest
+	}
+
+	request, err := prepare(ctx, proto, s.body(t, p, proto), map[string]string{testHeader: "1"})
+	if err != nil {
+		t.Fatalf("preparing %s request failed: %v", proto, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		t.Fatalf("invalid %s request: %v", proto, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range request.Headers {
+		req.Header.Set(key, value)
+	}
+	p.SetHeaders(req)
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("sending %s request failed: %v", proto, err)
+	}
+	return resp
+}
+
+func (s *suite) name(t *testing.T) {
+	p, _ := s.provider(t, http.NotFoundHandler())
+	if p.Name() == "" {
+		t.Error("Name is empty")
+	}
+}
+
+func (s *suite) endpoint(t *testing.T) {
+	p, _ := s.provider(t, http.NotFoundHandler())
+
+	for _, proto := range protocol.ValidProtocols() {
+		endpoint, err := p.Endpoint(proto)
+		if !slices.Contains(s.Protocols, proto) {
+			if err == nil {
+				t.Errorf("Endpoint(%s) = %q, want an error for an unsupported protocol", proto, endpoint)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Endpoint(%s) failed: %v", proto, err)
+			continue
+		}
+		if u, err := url.Parse(endpoint); err != nil || !u.IsAbs() {
+			t.Errorf("Endpoint(%s) = %q, want an absolute URL", proto, endpoint)
+		}
+	}
+}
+
+func (s *suite) marshal(t *testing.T) {
+	p, _ := s.provider(t, http.NotFoundHandler())
+
+	for _, proto := range s.Protocols {
+		var fields map[string]any
+		if err := json.Unmarshal(s.body(t, p, proto), &fields); err != nil {
+			t.Errorf("Marshal(%s) did not produce a JSON object: %v", proto, err)
+		}
+
+		if _, err := p.Marshal(proto, struct{}{}); err == nil {
+			t.Errorf("Marshal(%s) accepted data of the wrong type", proto)
+		}
+	}
+}
+
+func (s *suite) prepareRequest(t *testing.T) {
+	p, _ := s.provider(t, http.NotFoundHandler())
+
+	for _, proto := range s.Protocols {
+		headers := map[string]string{testHeader: "1"}
+		request, err := p.PrepareRequest(context.Background(), proto, s.body(t, p, proto), headers)
+		if err != nil {
+			t.Errorf("PrepareRequest(%s) failed: %v", proto, err)
+			continue
+		}
+
+		if u, err := url.Parse(request.URL); err != nil || !u.IsAbs() {
+			t.Errorf("PrepareRequest(%s) URL = %q, want an absolute URL", proto, request.URL)
+		}
+		if !json.Valid(request.Body) {
+			t.Errorf("PrepareRequest(%s) body is not valid JSON: %s", proto, request.Body)
+		}
+		if request.Headers[testHeader] != "1" {
+			t.Errorf("PrepareRequest(%s) dropped the caller's headers: %v", proto, request.Headers)
+		}
+		if !maps.Equal(headers, map[string]string{testHeader: "1"}) {
+			t.Errorf("PrepareRequest(%s) modified the caller's headers: %v", proto, headers)
+		}
+	}
+}
+
+func (s *suite) prepareStreamRequest(t *testing.T) {
+	p, _ := s.provider(t, http.NotFoundHandler())
+
+	for _, proto := range s.Streaming {
+		headers := map[string]string{testHeader: "1"}
+		request, err := p.PrepareStreamRequest(context.Background(), proto, s.body(t, p, proto), headers)
+		if err != nil {
+			t.Errorf("PrepareStreamRequest(%s) failed: %v", proto, err)
+			continue
+		}
+
+		if request.Headers["Accept"] != "text/event-stream" {
+			t.Errorf("PrepareStreamRequest(%s) Accept = %q, want text/event-stream", proto, request.Headers["Accept"])
+		}
+		if request.Headers[testHeader] != "1" {
+			t.Errorf("PrepareStreamRequest(%s) dropped the caller's headers: %v", proto, request.Headers)
+		}
+		if !maps.Equal(headers, map[string]string{testHeader: "1"}) {
+			t.Errorf("PrepareStreamRequest(%s) modified the caller's headers: %v", proto, headers)
+		}
+	}
+}
+
+func (s *suite) roundTrip(t *testing.T) {
+	for _, proto := range s.Protocols {
+		t.Run(string(proto), func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				received *http.Request
+			)
+			p, server := s.provider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				received = r
+				mu.Unlock()
+				w.Header().Set("Content-Type", "application/json")
+				w.Write(s.Response(proto))
+			}))
+
+			resp := s.send(t, context.Background(), p, server, proto, false)
+			defer resp.Body.Close()
+
+			result, err := p.ProcessResponse(context.Background(), resp, proto)
+			if err != nil {
+				t.Fatalf("ProcessResponse failed: %v", err)
+			}
+			checkResult(t, proto, result)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if received == nil {
+				t.Fatal("the server received no request")
+			}
+			if received.Header.Get(testHeader) != "1" {
+				t.Error("the caller's headers were not sent")
+			}
+		})
+	}
+}
+
+// checkResult verifies a parsed response carries the expected answer.
+func checkResult(t *testing.T, proto protocol.Protocol, result any) {
+	t.Helper()
+	switch proto {
+	case protocol.Chat, protocol.Vision:
+		resp, ok := result.(*response.ChatResponse)
+		if !ok {
+			t.Fatalf("got %T, want *response.ChatResponse", result)
+		}
+		if resp.Content() != Content {
+			t.Errorf("Content() = %q, want %q", resp.Content(), Content)
+		}
+	case protocol.Tools:
+		resp, ok := result.(*response.ToolsResponse)
+		if !ok {
+			t.Fatalf("got %T, want *response.ToolsResponse", result)
+		}
+		calls := resp.ToolCalls()
+		if len(calls) != 1 || calls[0].Function.Name != ToolName {
+			t.Fatalf("ToolCalls() = %+v, want one call to %s", calls, ToolName)
+		}
+		var got, want any
+		json.Unmarshal([]byte(calls[0].Function.Arguments), &got)
+		json.Unmarshal([]byte(ToolArguments), &want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("tool call arguments = %s, want %s", calls[0].Function.Arguments, ToolArguments)
+		}
+		if resp.FinishReason() != response.FinishReasonToolCalls {
+			t.Errorf("FinishReason() = %q, want %q", resp.FinishReason(), response.FinishReasonToolCalls)
+		}
+	case protocol.Embeddings:
+		resp, ok := result.(*response.EmbeddingsResponse)
+		if !ok {
+			t.Fatalf("got %T, want *response.EmbeddingsResponse", result)
+		}
+		if len(resp.Data) != 1 || len(resp.Data[0].Embedding) == 0 {
+			t.Errorf("got %d embeddings, want one", len(resp.Data))
+		}
+	}
+}
+
+func (s *suite) errorStatus(t *testing.T) {
+	p, server := s.provider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		io.WriteString(w, `{"error": {"message": "providertest failure"}}`)
+	}))
+
+	for _, proto := range s.Protocols {
+		resp := s.send(t, context.Background(), p, server, proto, false)
+		if _, err := p.ProcessResponse(context.Background(), resp, proto); err == nil {
+			t.Errorf("ProcessResponse(%s) accepted status 500", proto)
+		}
+		resp.Body.Close()
+	}
+
+	for _, proto := range s.Streaming {
+		resp := s.send(t, context.Background(), p, server, proto, true)
+		if _, err := p.ProcessStreamResponse(context.Background(), resp, proto); err == nil {
+			t.Errorf("ProcessStreamResponse(%s) accepted status 500", proto)
+		}
+		resp.Body.Close()
+	}
+}
+
+func (s *suite) stream(t *testing.T) {
+	for _, proto := range s.Streaming {
+		t.Run(string(proto), func(t *testing.T) {
+			p, server := s.provider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				w.Write(s.StreamResponse(proto))
+			}))
+
+			ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+			defer cancel()
+
+			chunks, err := p.ProcessStreamResponse(ctx, s.send(t, ctx, p, server, proto, true), proto)
+			if err != nil {
+				t.Fatalf("ProcessStreamResponse failed: %v", err)
+			}
+
+			var content strings.Builder
+			for item := range chunks {
+				chunk, ok := item.(*response.StreamingChunk)
+				if !ok {
+					t.Fatalf("got %T, want *response.StreamingChunk", item)
+				}
+				if chunk.Error != nil {
+					t.Fatalf("stream error: %v", chunk.Error)
+				}
+				content.WriteString(chunk.Content())
+			}
+
+			if ctx.Err() != nil {
+				t.Fatal("the stream did not close when the response ended")
+			}
+			if content.String() != Content {
+				t.Errorf("streamed content = %q, want %q", content.String(), Content)
+			}
+		})
+	}
+}
+
+func (s *suite) streamCancel(t *testing.T) {
+	for _, proto := range s.Streaming {
+		t.Run(string(proto), func(t *testing.T) {
+			// The server sends the first event, then holds the stream open
+			// until the client goes away.
+			p, server := s.provider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				first, _, _ := bytes.Cut(s.StreamResponse(proto), []byte("\n\n"))
+				w.Write(append(first, "\n\n"...))
+				w.(http.Flusher).Flush()
+				<-r.Context().Done()
+			}))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			chunks, err := p.ProcessStreamResponse(ctx, s.send(t, ctx, p, server, proto, true), proto)
+			if err != nil {
+				t.Fatalf("ProcessStreamResponse failed: %v", err)
+			}
+
+			cancel()
+
+			timeout := time.After(streamTimeout)
+			for {
+				select {
+				case _, ok := <-chunks:
+					if !ok {
+						return
+					}
+				case <-timeout:
+					t.Fatal("the stream did not close after the context was cancelled")
+				}
+			}
+		})
+	}
+}
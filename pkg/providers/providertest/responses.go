@@ -0,0 +1,82 @@
+package providertest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// OpenAIResponse returns an OpenAI-compatible response body carrying the
+// expected answer for a protocol.
+func OpenAIResponse(p protocol.Protocol) []byte {
+	switch p {
+	case protocol.Tools:
+		return fmt.Appendf(nil, `{
+			"id": "providertest", "object": "chat.completion", "model": %q,
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "", "tool_calls": [
+				{"id": "call_providertest", "type": "function", "function": {"name": %q, "arguments": %q}}
+			]}, "finish_reason": "tool_calls"}],
+			"usage": {"prompt_tokens": 5, "completion_tokens": 3, "total_tokens": 8}
+		}`, Model, ToolName, ToolArguments)
+	case protocol.Embeddings:
+		return fmt.Appendf(nil, `{
+			"object": "list", "model": %q,
+			"data": [{"object": "embedding", "index": 0, "embedding": [0.1, 0.2, 0.3]}],
+			"usage": {"prompt_tokens": 1, "total_tokens": 1}
+		}`, Model)
+	default:
+		return fmt.Appendf(nil, `{
+			"id": "providertest", "object": "chat.completion", "model": %q,
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": %q}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 5, "completion_tokens": 3, "total_tokens": 8}
+		}`, Model, Content)
+	}
+}
+
+// OpenAIStreamResponse returns an OpenAI-compatible SSE body streaming the
+// expected content in two deltas, followed by [DONE].
+func OpenAIStreamResponse(p protocol.Protocol) []byte {
+	first, second, _ := strings.Cut(Content, " ")
+
+	var b strings.Builder
+	for i, delta := range []string{first + " ", second} {
+		finish := "null"
+		if i == 1 {
+			finish = `"stop"`
+		}
+		fmt.Fprintf(&b, `data: {"id": "providertest", "object": "chat.completion.chunk", "model": %q, "choices": [{"index": 0, "delta": {"content": %q}, "finish_reason": %s}]}`+"\n\n", Model, delta, finish)
+	}
+	b.WriteString("data: [DONE]\n\n")
+	return []byte(b.String())
+}
+
+// GeminiResponse returns a Gemini generateContent response body carrying
+// the expected answer for chat, vision, or tools.
+func GeminiResponse(p protocol.Protocol) []byte {
+	part := fmt.Sprintf(`{"text": %q}`, Content)
+	if p == protocol.Tools {
+		part = fmt.Sprintf(`{"functionCall": {"name": %q, "args": %s}}`, ToolName, ToolArguments)
+	}
+	return fmt.Appendf(nil, `{
+		"responseId": "providertest", "modelVersion": %q,
+		"candidates": [{"index": 0, "content": {"role": "model", "parts": [%s]}, "finishReason": "STOP"}],
+		"usageMetadata": {"promptTokenCount": 5, "candidatesTokenCount": 3, "totalTokenCount": 8}
+	}`, Model, part)
+}
+
+// AnthropicResponse returns an Anthropic Messages response body carrying
+// the expected answer for chat, vision, or tools.
+func AnthropicResponse(p protocol.Protocol) []byte {
+	block, stop := fmt.Sprintf(`{"type": "text", "text": %q}`, Content), "end_turn"
+	if p == protocol.Tools {
+		block = fmt.Sprintf(`{"type": "tool_use", "id": "toolu_providertest", "name": %q, "input": %s}`, ToolName, ToolArguments)
+		stop = "tool_use"
+	}
+	return fmt.Appendf(nil, `{
+		"id": "providertest", "type": "message", "role": "assistant", "model": %q,
+		"content": [%s],
+		"stop_reason": %q,
+		"usage": {"input_tokens": 5, "output_tokens": 3}
+	}`, Model, block, stop)
+}
@@ -0,0 +1,97 @@
+package providers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimits is the rate limit state a provider reports in response headers,
+// mapped from the provider's header format. Fields the provider does not
+// report are zero; ResetRequests and ResetTokens are the time until the
+// corresponding limit resets.
+type RateLimits struct {
+	LimitRequests     int
+	RemainingRequests int
+	ResetRequests     time.Duration
+	LimitTokens       int
+	RemainingTokens   int
+	ResetTokens       time.Duration
+}
+
+// RateLimitReporter is implemented by providers that report rate limits in
+// response headers.
+type RateLimitReporter interface {
+	Provider
+
+	// RateLimits maps the response headers to RateLimits. Returns false if
+	// the headers carry no rate limit information.
+	RateLimits(h http.Header) (RateLimits, bool)
+}
+
+// rateLimitHeaders names the headers a provider reports rate limits in.
+// Empty names are not reported by the provider.
+type rateLimitHeaders struct {
+	limitRequests     string
+	remainingRequests string
+	resetRequests     string
+	limitTokens       string
+	remainingTokens   string
+	resetTokens       string
+}
+
+// parse reads the named headers, skipping malformed values.
+// Returns false if none are present.
+func (n rateLimitHeaders) parse(h http.Header) (RateLimits, bool) {
+	var (
+		limits RateLimits
+		found  bool
+	)
+
+	count := func(key string, dst *int) {
+		if value := headerValue(h, key); value != "" {
+			if v, err := strconv.Atoi(value); err == nil {
+				*dst = v
+				found = true
+			}
+		}
+	}
+	reset := func(key string, dst *time.Duration) {
+		if value := headerValue(h, key); value != "" {
+			if d, ok := parseRateLimitReset(value); ok {
+				*dst = d
+				found = true
+			}
+		}
+	}
+
+	count(n.limitRequests, &limits.LimitRequests)
+	count(n.remainingRequests, &limits.RemainingRequests)
+	reset(n.resetRequests, &limits.ResetRequests)
+	count(n.limitTokens, &limits.LimitTokens)
+	count(n.remainingTokens, &limits.RemainingTokens)
+	reset(n.resetTokens, &limits.ResetTokens)
+
+	return limits, found
+}
+
+// headerValue returns the trimmed value of a header, or "" for an empty name.
+func headerValue(h http.Header, key string) string {
+	if key == "" {
+		return ""
+	}
+	return strings.TrimSpace(h.Get(key))
+}
+
+// parseRateLimitReset accepts a Go duration ("6m0s", "20ms") or a number of
+// seconds ("1", "0.5").
+func parseRateLimitReset(value string) (time.Duration, bool) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return d, true
+	}
+	return 0, false
+}
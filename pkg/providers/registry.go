@@ -63,4 +63,11 @@ func ListProviders() []string {
 func init() {
 	Register("ollama", NewOllama)
 	Register("azure", NewAzure)
+	Register("llamacpp", NewLlamaCpp)
+	Register("xai", NewXAI)
+	Register("together", NewTogether)
+	Register("fireworks", NewFireworks)
+	Register("vertex", NewVertex)
+	Register("gateway", NewGateway)
+	Register("watsonx", NewWatsonx)
 }
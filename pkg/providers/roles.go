@@ -0,0 +1,129 @@
+package providers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// RolePolicy controls how system and developer messages are sent to a
+// provider, according to provider options:
+//
+//   - "developer_role": send "developer" messages with the developer role.
+//     When false (the default), they are sent as system messages, since
+//     most OpenAI-compatible servers and older API versions reject the role.
+//   - "merge_system_messages": merge all system (and downgraded developer)
+//     messages into a single leading system message, for servers that only
+//     honor one. When false (the default), messages are sent as given.
+//
+// Example options:
+//
+//	"developer_role": true,
+//	"merge_system_messages": true
+//
+// The zero value downgrades developer messages and keeps system messages
+// separate.
+type RolePolicy struct {
+	DeveloperRole bool
+	MergeSystem   bool
+}
+
+// NewRolePolicy creates a RolePolicy from provider options. Options may be
+// booleans or the strings "true" and "false", as set by environment
+// variables. Returns an error if an option has another type or value.
+func NewRolePolicy(options map[string]any) (RolePolicy, error) {
+	developer, err := boolOption(options, "developer_role")
+	if err != nil {
+		return RolePolicy{}, err
+	}
+	merge, err := boolOption(options, "merge_system_messages")
+	if err != nil {
+		return RolePolicy{}, err
+	}
+	return RolePolicy{DeveloperRole: developer, MergeSystem: merge}, nil
+}
+
+// Apply returns messages with the policy applied. The input is not modified,
+// and is returned as-is when nothing needs to change.
+//
+// Merged messages keep their order, joined by blank lines, and the merged
+// message takes the developer role only if every merged message had it and
+// the developer role is enabled.
+func (r RolePolicy) Apply(messages []protocol.Message) []protocol.Message {
+	if !r.needsRewrite(messages) {
+		return messages
+	}
+
+	if !r.MergeSystem {
+		rewritten := make([]protocol.Message, len(messages))
+		for i, msg := range messages {
+			if msg.Role == "developer" {
+				msg.Role = "system"
+			}
+			rewritten[i] = msg
+		}
+		return rewritten
+	}
+
+	var (
+		texts []string
+		role  = "developer"
+		rest  = make([]protocol.Message, 0, len(messages))
+	)
+	for _, msg := range messages {
+		switch msg.Role {
+		case "system", "developer":
+			texts = append(texts, msg.Text())
+			if msg.Role == "system" || !r.DeveloperRole {
+				role = "system"
+			}
+		default:
+			rest = append(rest, msg)
+		}
+	}
+
+	merged := protocol.NewMessage(role, strings.Join(texts, "\n\n"))
+	return append([]protocol.Message{merged}, rest...)
+}
+
+// needsRewrite reports whether Apply would change messages.
+func (r RolePolicy) needsRewrite(messages []protocol.Message) bool {
+	instructions := 0
+	for i, msg := range messages {
+		switch msg.Role {
+		case "developer":
+			if !r.DeveloperRole {
+				return true
+			}
+		case "system":
+		default:
+			continue
+		}
+		instructions++
+		if r.MergeSystem && (instructions > 1 || i != 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// boolOption reads an optional boolean option, accepting booleans and their
+// string forms.
+func boolOption(options map[string]any, key string) (bool, error) {
+	switch value := options[key].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return value, nil
+	case string:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("%s must be true or false, got %q", key, value)
+		}
+		return parsed, nil
+	default:
+		return false, fmt.Errorf("%s must be a boolean, got %T", key, value)
+	}
+}
@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+// TogetherDefaultBaseURL is the Together AI API base URL used when none is
+// configured.
+const TogetherDefaultBaseURL = "https://api.together.xyz/v1"
+
+// togetherRateLimits are the headers Together reports rate limits in. The
+// request reset is in seconds.
+var togetherRateLimits = rateLimitHeaders{
+	limitRequests:     "x-ratelimit-limit",
+	remainingRequests: "x-ratelimit-remaining",
+	resetRequests:     "x-ratelimit-reset",
+	limitTokens:       "x-ratelimit-limit-tokens",
+	remainingTokens:   "x-ratelimit-remaining-tokens",
+}
+
+// TogetherProvider implements Provider for the Together AI OpenAI-compatible
+// API. Models are named by organization and model, as listed by Together
+// (e.g., "meta-llama/Llama-3.3-70B-Instruct-Turbo"), and sent unchanged.
+// Rate limits are reported from Together's x-ratelimit-* headers.
+type TogetherProvider struct {
+	*compatibleProvider
+}
+
+// NewTogether creates a new TogetherProvider from configuration.
+// Uses TogetherDefaultBaseURL when no base URL is configured.
+// Requires a credential option ("token", "token_file", or "token_command";
+// see TokenSource), sent as a bearer token.
+// System and developer messages follow the role options (see RolePolicy).
+// Returns an error if the credential is missing or cannot be resolved.
+func NewTogether(c *config.ProviderConfig) (Provider, error) {
+	compatible, err := newCompatibleProvider(c, compatibleAPI{
+		label:          "Together",
+		defaultBaseURL: TogetherDefaultBaseURL,
+		embeddings:     true,
+		rateLimits:     togetherRateLimits,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TogetherProvider{compatibleProvider: compatible}, nil
+}
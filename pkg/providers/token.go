@@ -0,0 +1,149 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource resolves a provider credential from configuration options.
+// Secrets can be given inline ("token"), read from a file ("token_file"),
+// or produced by a command ("token_command", e.g. a secrets manager CLI),
+// in that order of precedence. Resolved secrets are held only by the source,
+// never written back into configuration.
+//
+// When "token_refresh" is set to a duration (e.g., "15m"), file and command
+// tokens are re-resolved once the interval elapses so rotated secrets are
+// picked up without restarting.
+type TokenSource struct {
+	file    string
+	command string
+	refresh time.Duration
+
+	mu       sync.Mutex
+	token    string
+	resolved time.Time
+}
+
+// NewTokenSource creates a TokenSource from provider options and resolves
+// the initial token. Returns an error if an option has the wrong type or the
+// file or command cannot produce a token. A source with no token options
+// configured is valid and yields an empty token.
+func NewTokenSource(options map[string]any) (*TokenSource, error) {
+	s := &TokenSource{}
+
+	token, err := stringOption(options, "token")
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		s.token = token
+		return s, nil
+	}
+
+	if s.file, err = stringOption(options, "token_file"); err != nil {
+		return nil, err
+	}
+	if s.command, err = stringOption(options, "token_command"); err != nil {
+		return nil, err
+	}
+
+	refresh, err := stringOption(options, "token_refresh")
+	if err != nil {
+		return nil, err
+	}
+	if refresh != "" {
+		if s.refresh, err = time.ParseDuration(refresh); err != nil {
+			return nil, fmt.Errorf("invalid token_refresh: %w", err)
+		}
+	}
+
+	if s.file != "" || s.command != "" {
+		if _, err := s.Token(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Configured reports whether the source has a token or a way to obtain one.
+func (s *TokenSource) Configured() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token != "" || s.file != "" || s.command != ""
+}
+
+// Token returns the current token, re-resolving file and command tokens
+// when the refresh interval has elapsed. If a refresh fails, the previous
+// token is returned along with the error.
+// Thread-safe for concurrent access.
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == "" && s.command == "" {
+		return s.token, nil
+	}
+
+	if s.token != "" && (s.refresh <= 0 || time.Since(s.resolved) < s.refresh) {
+		return s.token, nil
+	}
+
+	token, err := s.resolve(ctx)
+	if err != nil {
+		return s.token, err
+	}
+
+	s.token = token
+	s.resolved = time.Now()
+	return s.token, nil
+}
+
+func (s *TokenSource) resolve(ctx context.Context) (string, error) {
+	var token string
+
+	if s.file != "" {
+		data, err := os.ReadFile(s.file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token_file: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	} else {
+		shell, flag := "sh", "-c"
+		if runtime.GOOS == "windows" {
+			shell, flag = "cmd", "/C"
+		}
+
+		out, err := exec.CommandContext(ctx, shell, flag, s.command).Output()
+		if err != nil {
+			// Command output may contain secrets; report only the failure.
+			return "", fmt.Errorf("token_command failed: %w", err)
+		}
+		token = strings.TrimSpace(string(out))
+	}
+
+	if token == "" {
+		return "", fmt.Errorf("resolved token is empty")
+	}
+	return token, nil
+}
+
+// stringOption returns a string option, or an error if it is set to another type.
+func stringOption(options map[string]any, key string) (string, error) {
+	value, exists := options[key]
+	if !exists || value == nil {
+		return "", nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string, got %T", key, value)
+	}
+	return str, nil
+}
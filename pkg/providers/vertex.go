@@ -0,0 +1,410 @@
+package providers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Vertex AI defaults used when the corresponding option is not configured.
+const (
+	VertexDefaultLocation  = "us-central1"
+	VertexDefaultPublisher = "google"
+)
+
+// VertexBaseURL returns the Vertex AI API base URL for a location: the
+// regional endpoint ({location}-aiplatform.googleapis.com), or the global
+// endpoint for "global".
+func VertexBaseURL(location string) string {
+	if location == "global" {
+		return "https://aiplatform.googleapis.com"
+	}
+	return "https://" + location + "-aiplatform.googleapis.com"
+}
+
+// VertexProvider implements Provider for Google Cloud Vertex AI publisher
+// models. Protocols with a registered Gemini codec (tools) are sent to the
+// model's native generateContent endpoint in the Gemini format; other
+// protocols (chat and vision) use Vertex's OpenAI-compatible endpoint, with
+// the model named as {publisher}/{model}.
+type VertexProvider struct {
+	*BaseProvider
+	project     string
+	location    string
+	publisher   string
+	tokens      *TokenSource
+	credentials *GoogleCredentials
+	metadata    *MetadataForwarder
+}
+
+// NewVertex creates a new VertexProvider from configuration.
+// Requires the "project" option. The "location" option selects the region
+// (default VertexDefaultLocation) and "publisher" the model publisher
+// (default VertexDefaultPublisher). Without a base URL, requests go to the
+// location's endpoint (see VertexBaseURL).
+//
+// Requests are authorized with OAuth2 access tokens from a service account
+// or Application Default Credentials (see GoogleCredentials). A credential
+// option ("token", "token_file", or "token_command"; see TokenSource), such
+// as "gcloud auth print-access-token", is used instead when configured.
+// System and developer messages follow the role options (see RolePolicy).
+// Returns an error if the project is missing or the credentials cannot be
+// resolved.
+func NewVertex(c *config.ProviderConfig) (Provider, error) {
+	project, ok := c.Options["project"].(string)
+	if !ok || project == "" {
+		return nil, fmt.Errorf("project is required for Vertex provider")
+	}
+
+	location := VertexDefaultLocation
+	if l, ok := c.Options["location"].(string); ok && l != "" {
+		location = l
+	}
+
+	publisher := VertexDefaultPublisher
+	if p, ok := c.Options["publisher"].(string); ok && p != "" {
+		publisher = p
+	}
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = VertexBaseURL(location)
+	}
+
+	tokens, err := NewTokenSource(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve Vertex token: %w", err)
+	}
+
+	var credentials *GoogleCredentials
+	if !tokens.Configured() {
+		if credentials, err = NewGoogleCredentials(c.Options); err != nil {
+			return nil, fmt.Errorf("failed to load Vertex credentials: %w", err)
+		}
+	}
+
+	metadata, err := NewMetadataForwarder(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Vertex metadata options: %w", err)
+	}
+
+	roles, err := NewRolePolicy(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Vertex role options: %w", err)
+	}
+
+	signer, err := NewSigner(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Vertex signing options: %w", err)
+	}
+
+	base := NewBaseProvider(c.Name, strings.TrimSuffix(baseURL, "/"))
+	base.SetRolePolicy(roles)
+	base.SetSigner(signer)
+
+	return &VertexProvider{
+		BaseProvider: base,
+		project:      project,
+		location:     location,
+		publisher:    publisher,
+		tokens:       tokens,
+		credentials:  credentials,
+		metadata:     metadata,
+	}, nil
+}
+
+// codec returns the codec for a protocol and whether it is the native Gemini
+// format, falling back to the OpenAI-compatible codec.
+func (p *VertexProvider) codec(proto protocol.Protocol) (Codec, bool, error) {
+	if codec, err := LookupCodec(p.Name(), proto, FormatGemini); err == nil {
+		return codec, true, nil
+	}
+	codec, err := p.BaseProvider.Codec(proto)
+	return codec, false, err
+}
+
+// locationPath returns the API path of the provider's project and location.
+func (p *VertexProvider) locationPath() string {
+	return fmt.Sprintf("%s/v1/projects/%s/locations/%s", p.BaseURL(), url.PathEscape(p.project), url.PathEscape(p.location))
+}
+
+// Endpoint returns the full Vertex endpoint URL for a protocol.
+// Chat and vision use the OpenAI-compatible /endpoints/openapi/chat/completions.
+// Tools use the publisher's models collection; PrepareRequest appends the
+// model and the :generateContent method.
+// Returns an error if the protocol is not supported.
+func (p *VertexProvider) Endpoint(proto protocol.Protocol) (string, error) {
+	switch proto {
+	case protocol.Chat, protocol.Vision, protocol.Tools:
+	default:
+		return "", fmt.Errorf("protocol %s not supported by Vertex", proto)
+	}
+
+	if _, native, _ := p.codec(proto); native {
+		return fmt.Sprintf("%s/publishers/%s/models", p.locationPath(), url.PathEscape(p.publisher)), nil
+	}
+	return p.locationPath() + "/endpoints/openapi/chat/completions", nil
+}
+
+// Marshal converts request data to the protocol's wire format. Gemini
+// requests carry the model in the URL rather than the body, so the model is
+// added to the body for PrepareRequest to route on; OpenAI-compatible
+// requests name the model as {publisher}/{model}. Fields of the extra_body
+// option are merged into the marshaled body (see ExtraBodyKey).
+func (p *VertexProvider) Marshal(proto protocol.Protocol, data any) ([]byte, error) {
+	codec, native, err := p.codec(proto)
+	if err != nil {
+		return nil, err
+	}
+
+	data, extra, err := splitExtraBody(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !native {
+		body, err := codec.Marshal(proto, p.publisherModel(data))
+		if err != nil {
+			return nil, err
+		}
+		return MergeExtraBody(body, extra)
+	}
+
+	body, err := codec.Marshal(proto, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("failed to add model to request: %w", err)
+	}
+	maps.Copy(fields, extra)
+	fields["model"] = dataModel(data)
+	return json.Marshal(fields)
+}
+
+// publisherModel returns a copy of request data with the model prefixed by
+// the publisher, unless it already names one.
+func (p *VertexProvider) publisherModel(data any) any {
+	name := func(model string) string {
+		if strings.Contains(model, "/") {
+			return model
+		}
+		return p.publisher + "/" + model
+	}
+
+	switch d := data.(type) {
+	case *ChatData:
+		c := *d
+		c.Model = name(d.Model)
+		return &c
+	case *VisionData:
+		c := *d
+		c.Model = name(d.Model)
+		return &c
+	case *ToolsData:
+		c := *d
+		c.Model = name(d.Model)
+		return &c
+	}
+	return data
+}
+
+// dataModel returns the model named by request data.
+func dataModel(data any) string {
+	switch d := data.(type) {
+	case *ChatData:
+		return d.Model
+	case *VisionData:
+		return d.Model
+	case *ToolsData:
+		return d.Model
+	case *EmbeddingsData:
+		return d.Model
+	}
+	return ""
+}
+
+// Parse parses a response body in the protocol's wire format.
+func (p *VertexProvider) Parse(ctx context.Context, proto protocol.Protocol, body []byte) (any, error) {
+	codec, _, err := p.codec(proto)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Parse(ctx, proto, body)
+}
+
+// ParseStreamChunk parses a streaming event in the protocol's wire format.
+func (p *VertexProvider) ParseStreamChunk(proto protocol.Protocol, data []byte) (*response.StreamingChunk, error) {
+	codec, _, err := p.codec(proto)
+	if err != nil {
+		return nil, err
+	}
+	return codec.ParseStreamChunk(proto, data)
+}
+
+// PrepareRequest prepares a standard (non-streaming) Vertex request.
+// Gemini requests are routed to the model named in the body, which is
+// removed before sending.
+// Forwards request metadata per the provider's metadata options.
+// Returns an error if the endpoint is invalid.
+func (p *VertexProvider) PrepareRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
+	endpoint, err := p.Endpoint(proto)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, native, _ := p.codec(proto); native {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, fmt.Errorf("invalid request body: %w", err)
+		}
+		var model string
+		if err := json.Unmarshal(fields["model"], &model); err != nil || model == "" {
+			return nil, fmt.Errorf("request body does not name a model")
+		}
+		delete(fields, "model")
+		if body, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+		endpoint += "/" + url.PathEscape(model) + ":generateContent"
+	}
+
+	body, headers, err = p.metadata.Apply(ctx, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		URL:     endpoint,
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
+// PrepareStreamRequest prepares a streaming Vertex request.
+// Forwards request metadata and adds streaming-specific headers (Accept: text/event-stream, Cache-Control: no-cache).
+// Returns an error if the endpoint is invalid or the protocol uses the Gemini
+// format, which the Gemini codec cannot stream.
+func (p *VertexProvider) PrepareStreamRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*Request, error) {
+	if _, native, _ := p.codec(proto); native {
+		return nil, fmt.Errorf("streaming %s requests is not supported by Vertex", proto)
+	}
+
+	request, err := p.PrepareRequest(ctx, proto, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	streamHeaders := make(map[string]string)
+	maps.Copy(streamHeaders, request.Headers)
+	streamHeaders["Accept"] = "text/event-stream"
+	streamHeaders["Cache-Control"] = "no-cache"
+	request.Headers = streamHeaders
+
+	return request, nil
+}
+
+// ProcessResponse processes a standard Vertex HTTP response.
+// Returns an error if the HTTP status is not OK.
+// Parses the body in the protocol's wire format (see Parse).
+func (p *VertexProvider) ProcessResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, NewResponseError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return p.Parse(ctx, proto, body)
+}
+
+// ProcessStreamResponse processes a streaming Vertex HTTP response.
+// The OpenAI-compatible endpoint uses SSE format with "data: " prefix.
+// Returns a channel that emits parsed streaming chunks.
+// The channel is closed when the stream completes or context is cancelled.
+// Returns an error if the HTTP status is not OK.
+func (p *VertexProvider) ProcessStreamResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (<-chan any, error) {
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	output := make(chan any)
+
+	go func() {
+		defer close(output)
+		defer resp.Body.Close()
+
+		reader := bufio.NewReader(resp.Body)
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				select {
+				case output <- &response.StreamingChunk{Error: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: ")
+			if !ok {
+				continue
+			}
+
+			if data == "[DONE]" {
+				return
+			}
+
+			payload := []byte(data)
+			chunk, err := p.ParseStreamChunk(proto, payload)
+			if err != nil {
+				if chunk = p.malformedChunk(ctx, proto, payload, err); chunk == nil {
+					continue
+				}
+			}
+
+			select {
+			case output <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return output, nil
+}
+
+// SetHeaders sets the OAuth2 access token on the HTTP request, from the
+// configured credential option or the Google credentials. If a token cannot
+// be obtained, the request is sent without one and fails authorization.
+func (p *VertexProvider) SetHeaders(req *http.Request) {
+	var token string
+	if p.credentials != nil {
+		token, _ = p.credentials.Token(req.Context())
+	} else {
+		token, _ = p.tokens.Token(req.Context())
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
@@ -0,0 +1,77 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+// IBM watsonx.ai defaults used when the corresponding option is not configured.
+const (
+	WatsonxDefaultBaseURL = "https://us-south.ml.cloud.ibm.com"
+	WatsonxDefaultVersion = "2024-05-01"
+)
+
+// WatsonxGateway returns the GatewayConfig for the IBM watsonx.ai chat API:
+// chat, vision, and tools requests go to /ml/v1/text/chat (streaming to
+// /ml/v1/text/chat_stream) at the given API version, with the model sent as
+// model_id and the project or deployment space added to the body. Exactly
+// one of projectID and spaceID should be set.
+func WatsonxGateway(version, projectID, spaceID string) GatewayConfig {
+	fields := map[string]any{}
+	if projectID != "" {
+		fields["project_id"] = projectID
+	}
+	if spaceID != "" {
+		fields["space_id"] = spaceID
+	}
+
+	chat := "/ml/v1/text/chat?version={version}"
+	stream := "/ml/v1/text/chat_stream?version={version}"
+	return GatewayConfig{
+		Endpoints:       map[string]string{"chat": chat, "vision": chat, "tools": chat},
+		StreamEndpoints: map[string]string{"chat": stream, "vision": stream, "tools": stream},
+		Vars:            map[string]string{"version": version},
+		Envelope: GatewayEnvelope{
+			Rename: map[string]string{"model": "model_id"},
+			Fields: fields,
+		},
+	}
+}
+
+// NewWatsonx creates a GatewayProvider for IBM watsonx.ai (see WatsonxGateway).
+// Requires "project_id" or "space_id", and a credential option ("token",
+// "token_file", or "token_command"; see TokenSource) holding an IAM access
+// token, which expires hourly: use token_command with token_refresh to mint
+// fresh tokens. The "version" option sets the API version (default
+// WatsonxDefaultVersion), and the base URL selects the region (default
+// WatsonxDefaultBaseURL).
+// Returns an error if a required option is missing or the token cannot be
+// resolved.
+func NewWatsonx(c *config.ProviderConfig) (Provider, error) {
+	projectID, _ := c.Options["project_id"].(string)
+	spaceID, _ := c.Options["space_id"].(string)
+	if (projectID == "") == (spaceID == "") {
+		return nil, fmt.Errorf("exactly one of project_id or space_id is required for watsonx provider")
+	}
+
+	version := WatsonxDefaultVersion
+	if v, ok := c.Options["version"].(string); ok && v != "" {
+		version = v
+	}
+
+	tokens, err := NewTokenSource(c.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve watsonx token: %w", err)
+	}
+	if !tokens.Configured() {
+		return nil, fmt.Errorf("token, token_file, or token_command is required for watsonx provider")
+	}
+
+	cfg := *c
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = WatsonxDefaultBaseURL
+	}
+
+	return newGateway(&cfg, WatsonxGateway(version, projectID, spaceID), tokens)
+}
@@ -0,0 +1,92 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// XAIDefaultBaseURL is the xAI API base URL used when none is configured.
+const XAIDefaultBaseURL = "https://api.x.ai/v1"
+
+// XAIProvider implements Provider for the xAI (Grok) API, which is
+// OpenAI-compatible for chat, vision, and tools requests. Requests sent with
+// options.Deferred are queued by xAI and fetched later (see
+// DeferredCompletion and DeferredFetcher).
+type XAIProvider struct {
+	*compatibleProvider
+}
+
+// NewXAI creates a new XAIProvider from configuration.
+// Uses XAIDefaultBaseURL when no base URL is configured, and adds the /v1
+// suffix to a configured base URL if not present.
+// Requires a credential option ("token", "token_file", or "token_command";
+// see TokenSource), sent as a bearer token.
+// System and developer messages follow the role options (see RolePolicy).
+// Returns an error if the credential is missing or cannot be resolved.
+func NewXAI(c *config.ProviderConfig) (Provider, error) {
+	cfg := *c
+	if cfg.BaseURL != "" {
+		if baseURL := strings.TrimSuffix(cfg.BaseURL, "/"); !strings.HasSuffix(baseURL, "/v1") {
+			cfg.BaseURL = baseURL + "/v1"
+		}
+	}
+
+	compatible, err := newCompatibleProvider(&cfg, compatibleAPI{
+		label:          "xAI",
+		defaultBaseURL: XAIDefaultBaseURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &XAIProvider{compatibleProvider: compatible}, nil
+}
+
+// ProcessResponse processes a standard xAI HTTP response.
+// Returns an error if the HTTP status is not OK.
+// Parses the body with the provider's codec (see BaseProvider.Parse).
+// A request sent with options.Deferred returns a *DeferredCompletion error
+// carrying the ID to fetch the completion with.
+func (p *XAIProvider) ProcessResponse(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, NewResponseError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var deferred struct {
+		RequestID string          `json:"request_id"`
+		Choices   json.RawMessage `json:"choices"`
+	}
+	if json.Unmarshal(body, &deferred) == nil && deferred.RequestID != "" && deferred.Choices == nil {
+		return nil, &DeferredCompletion{Provider: p.Name(), RequestID: deferred.RequestID}
+	}
+
+	return p.Parse(ctx, proto, body)
+}
+
+// DeferredCompletionRequest returns the request that fetches the completion
+// of a deferred request from /chat/deferred-completion/{request_id}. xAI
+// answers 202 Accepted while the completion is pending.
+func (p *XAIProvider) DeferredCompletionRequest(requestID string) (*Request, error) {
+	if requestID == "" {
+		return nil, fmt.Errorf("request ID is required")
+	}
+
+	return &Request{
+		URL:     p.BaseURL() + "/chat/deferred-completion/" + url.PathEscape(requestID),
+		Headers: map[string]string{},
+	}, nil
+}
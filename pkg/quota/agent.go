@@ -0,0 +1,184 @@
+package quota
+
+import (
+	"context"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// KeyFunc selects the quota key for a call.
+type KeyFunc func(ctx context.Context, a agent.Agent) string
+
+// ByAgent keys quotas by agent ID (default).
+func ByAgent() KeyFunc {
+	return func(ctx context.Context, a agent.Agent) string {
+		return a.ID()
+	}
+}
+
+// ByMetadata keys quotas by a request metadata value set with
+// agent.WithMetadata, such as a tenant ID. Calls without the key share a
+// single quota under the empty key, so unattributed traffic is still limited.
+func ByMetadata(key string) KeyFunc {
+	return func(ctx context.Context, a agent.Agent) string {
+		return agent.Metadata(ctx)[key]
+	}
+}
+
+// Option configures a quota-enforcing agent.
+type Option func(*quotaAgent)
+
+// WithKey sets how calls are keyed (default ByAgent).
+func WithKey(key KeyFunc) Option {
+	return func(a *quotaAgent) {
+		a.key = key
+	}
+}
+
+// quotaAgent decorates an Agent, enforcing a Limiter before every call.
+type quotaAgent struct {
+	agent.Agent
+	limiter *Limiter
+	key     KeyFunc
+}
+
+// Wrap returns an Agent that checks the limiter before every Chat,
+// ChatStream, Vision, VisionStream, Tools, and Embed call and records the
+// tokens each response reports. Calls over quota return an *ExceededError
+// without reaching the provider. Wrap again with a different KeyFunc to
+// enforce per-agent and per-tenant quotas together.
+func Wrap(a agent.Agent, limiter *Limiter, opts ...Option) agent.Agent {
+	limited := &quotaAgent{Agent: a, limiter: limiter, key: ByAgent()}
+	for _, opt := range opts {
+		opt(limited)
+	}
+	return limited
+}
+
+func (a *quotaAgent) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
+	key, err := a.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.Agent.Chat(ctx, prompt, opts...)
+	if err == nil {
+		a.record(key, resp.Usage)
+	}
+	return resp, err
+}
+
+func (a *quotaAgent) ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	key, err := a.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := a.Agent.ChatStream(ctx, prompt, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return a.stream(ctx, key, chunks), nil
+}
+
+func (a *quotaAgent) Vision(ctx context.Context, prompt string, images []string, opts ...map[string]any) (*response.ChatResponse, error) {
+	key, err := a.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.Agent.Vision(ctx, prompt, images, opts...)
+	if err == nil {
+		a.record(key, resp.Usage)
+	}
+	return resp, err
+}
+
+func (a *quotaAgent) VisionStream(ctx context.Context, prompt string, images []string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	key, err := a.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := a.Agent.VisionStream(ctx, prompt, images, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return a.stream(ctx, key, chunks), nil
+}
+
+func (a *quotaAgent) Tools(ctx context.Context, prompt string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	key, err := a.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.Agent.Tools(ctx, prompt, tools, opts...)
+	if err == nil {
+		a.record(key, resp.Usage)
+	}
+	return resp, err
+}
+
+func (a *quotaAgent) VisionTools(ctx context.Context, prompt string, images []string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	key, err := a.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.Agent.VisionTools(ctx, prompt, images, tools, opts...)
+	if err == nil {
+		a.record(key, resp.Usage)
+	}
+	return resp, err
+}
+
+func (a *quotaAgent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
+	key, err := a.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.Agent.Embed(ctx, input, opts...)
+	if err == nil {
+		a.record(key, resp.Usage)
+	}
+	return resp, err
+}
+
+// allow resolves the call's key and checks it against the limiter.
+func (a *quotaAgent) allow(ctx context.Context) (string, error) {
+	key := a.key(ctx, a.Agent)
+	return key, a.limiter.Allow(key)
+}
+
+// record adds reported token usage to the key's quota.
+func (a *quotaAgent) record(key string, usage *response.TokenUsage) {
+	if usage != nil {
+		a.limiter.AddTokens(key, usage.TotalTokens)
+	}
+}
+
+// stream relays chunks to the caller and records the usage reported by the
+// stream before the channel is closed. If ctx is cancelled while the caller is not reading,
+// the remaining chunks are drained so the producer is not blocked.
+func (a *quotaAgent) stream(ctx context.Context, key string, chunks <-chan *response.StreamingChunk) <-chan *response.StreamingChunk {
+	out := make(chan *response.StreamingChunk)
+
+	go func() {
+		defer close(out)
+
+		var usage *response.TokenUsage
+		defer func() { a.record(key, usage) }()
+
+		for chunk := range chunks {
+			if chunk != nil && chunk.Usage != nil {
+				usage = chunk.Usage
+			}
+
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				for range chunks {
+				}
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,22 @@
+// Package quota enforces request and token quotas per agent or per tenant
+// over sliding time windows. A Limiter tracks usage per key; Wrap decorates
+// an agent.Agent so calls over quota fail fast with an *ExceededError that
+// reports when the quota resets.
+//
+// Enforce the agent's configured quota per tenant:
+//
+//	limiter := quota.NewLimiter(cfg.Quota) // {"window": "1m", "max_requests": 60, "max_tokens": 90000}
+//	a = quota.Wrap(a, limiter, quota.WithKey(quota.ByMetadata("tenant")))
+//
+//	ctx = agent.WithMetadata(ctx, map[string]string{"tenant": "acme"})
+//	resp, err := a.Chat(ctx, prompt)
+//
+//	var exceeded *quota.ExceededError
+//	if errors.As(err, &exceeded) {
+//	    w.Header().Set("Retry-After", strconv.Itoa(int(exceeded.RetryAfter().Seconds())+1))
+//	    http.Error(w, err.Error(), http.StatusTooManyRequests)
+//	}
+//
+// Token quotas count the usage reported by responses, so a request is allowed
+// while the window's tokens are below the limit and may overshoot it.
+package quota
@@ -0,0 +1,212 @@
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+// ErrQuotaExceeded is wrapped by every ExceededError.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Limit identifies the quota dimension that was exceeded.
+type Limit string
+
+const (
+	LimitRequests Limit = "requests"
+	LimitTokens   Limit = "tokens"
+)
+
+// ExceededError reports a quota violation for a key.
+// ResetAt is when enough usage leaves the sliding window for the next request
+// to be allowed.
+type ExceededError struct {
+	Key     string
+	Limit   Limit
+	Max     int
+	Used    int
+	Window  time.Duration
+	ResetAt time.Time
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("quota exceeded for %s: %d of %d %s used per %s; resets at %s",
+		e.Key, e.Used, e.Max, e.Limit, e.Window, e.ResetAt.Format(time.RFC3339))
+}
+
+// Unwrap returns ErrQuotaExceeded so callers can use errors.Is.
+func (e *ExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// RetryAfter returns how long until the quota resets, relative to now.
+func (e *ExceededError) RetryAfter() time.Duration {
+	return max(time.Until(e.ResetAt), 0)
+}
+
+// event is a request or token usage entry in a sliding window log.
+type event struct {
+	at     time.Time
+	tokens int
+}
+
+// usage is the sliding window log for one key.
+type usage struct {
+	requests []time.Time
+	tokens   []event
+}
+
+// Limiter enforces request and token quotas per key over a sliding window.
+// Keys are typically agent IDs or tenant identifiers.
+// Thread-safe for concurrent use.
+type Limiter struct {
+	window      time.Duration
+	maxRequests int
+	maxTokens   int
+	now         func() time.Time
+	keys        map[string]*usage
+	mu          sync.Mutex
+}
+
+// LimiterOption configures a Limiter.
+type LimiterOption func(*Limiter)
+
+// WithClock sets the time source, for deterministic tests.
+func WithClock(now func() time.Time) LimiterOption {
+	return func(l *Limiter) {
+		l.now = now
+	}
+}
+
+// NewLimiter creates a Limiter from a quota configuration.
+// A nil config, zero window, or zero limits enforce nothing.
+func NewLimiter(cfg *config.QuotaConfig, opts ...LimiterOption) *Limiter {
+	l := &Limiter{
+		now:  time.Now,
+		keys: make(map[string]*usage),
+	}
+	if cfg != nil {
+		l.window = cfg.Window.ToDuration()
+		l.maxRequests = cfg.MaxRequests
+		l.maxTokens = cfg.MaxTokens
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Allow records a request for key if its quotas permit it.
+// Token quotas block new requests once the tokens reported with AddTokens
+// reach the limit within the window.
+// Returns an *ExceededError if a quota is exhausted.
+func (l *Limiter) Allow(key string) error {
+	if l.window <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	u := l.prune(key, now)
+
+	if l.maxRequests > 0 && len(u.requests) >= l.maxRequests {
+		oldest := u.requests[len(u.requests)-l.maxRequests]
+		return l.exceeded(key, LimitRequests, l.maxRequests, len(u.requests), oldest)
+	}
+
+	if l.maxTokens > 0 {
+		total := 0
+		for _, e := range u.tokens {
+			total += e.tokens
+		}
+		if total >= l.maxTokens {
+			// The quota resets once enough of the oldest usage leaves the window.
+			remaining := total
+			var reset time.Time
+			for _, e := range u.tokens {
+				remaining -= e.tokens
+				reset = e.at
+				if remaining < l.maxTokens {
+					break
+				}
+			}
+			return l.exceeded(key, LimitTokens, l.maxTokens, total, reset)
+		}
+	}
+
+	u.requests = append(u.requests, now)
+	return nil
+}
+
+// AddTokens records tokens consumed by a request for key.
+func (l *Limiter) AddTokens(key string, tokens int) {
+	if l.window <= 0 || tokens <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	u := l.prune(key, now)
+	u.tokens = append(u.tokens, event{at: now, tokens: tokens})
+}
+
+// Usage returns the requests and tokens recorded for key within the window.
+func (l *Limiter) Usage(key string) (requests, tokens int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	u := l.prune(key, l.now())
+	for _, e := range u.tokens {
+		tokens += e.tokens
+	}
+	requests = len(u.requests)
+
+	if requests == 0 && len(u.tokens) == 0 {
+		delete(l.keys, key)
+	}
+	return requests, tokens
+}
+
+// prune drops usage older than the window and returns the key's log.
+// Must be called with l.mu held.
+func (l *Limiter) prune(key string, now time.Time) *usage {
+	u, ok := l.keys[key]
+	if !ok {
+		u = &usage{}
+		l.keys[key] = u
+	}
+
+	cutoff := now.Add(-l.window)
+	i := 0
+	for i < len(u.requests) && !u.requests[i].After(cutoff) {
+		i++
+	}
+	u.requests = u.requests[i:]
+
+	i = 0
+	for i < len(u.tokens) && !u.tokens[i].at.After(cutoff) {
+		i++
+	}
+	u.tokens = u.tokens[i:]
+
+	return u
+}
+
+// exceeded builds an ExceededError that resets when the given usage expires.
+func (l *Limiter) exceeded(key string, limit Limit, maximum, used int, expiring time.Time) *ExceededError {
+	return &ExceededError{
+		Key:     key,
+		Limit:   limit,
+		Max:     maximum,
+		Used:    used,
+		Window:  l.window,
+		ResetAt: expiring.Add(l.window),
+	}
+}
@@ -0,0 +1,29 @@
+// Package redact centralizes redaction of secrets and prompt content, so
+// logs, audit records, request dumps, and error messages apply one policy.
+// A Policy redacts credential-bearing headers, query parameters, and JSON
+// fields by name, replaces regular expression matches in text, and keeps
+// prompt text in full, as a digest, or not at all.
+//
+// Build a policy from configuration and make it the process default:
+//
+//	policy, err := redact.New(redact.Config{
+//	    Keys:     []string{"user_email"},
+//	    Patterns: []string{`\b\d{3}-\d{2}-\d{4}\b`},
+//	    Prompts:  redact.PromptHash,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	redact.SetDefault(policy)
+//
+// The default policy is used by request.Dump and request.RedactHeaders, the
+// client.LogTimings and client.LogRetries hooks, and audit records (unless
+// audit.WithRedaction sets another). Without SetDefault, the built-in policy
+// redacts credential headers and parameters and common API key and bearer
+// token formats, and leaves prompt handling to each consumer.
+//
+// Error wraps an error with a redacted message that still unwraps to the
+// original, so errors.Is and errors.As keep working:
+//
+//	logger.Error("request failed", "error", redact.Default().Error(err))
+package redact
@@ -0,0 +1,309 @@
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Redacted replaces redacted values.
+const Redacted = "[REDACTED]"
+
+// PromptMode controls how prompt text is kept where it is recorded.
+type PromptMode string
+
+const (
+	// PromptFull keeps prompt text, with patterns redacted.
+	PromptFull PromptMode = "full"
+
+	// PromptHash replaces prompt text with its SHA-256 digest.
+	PromptHash PromptMode = "hash"
+
+	// PromptOmit removes prompt text.
+	PromptOmit PromptMode = "omit"
+)
+
+// Config configures a Policy. It can be loaded from JSON.
+//
+// Keys are header, query parameter, and JSON field names whose values are
+// redacted, matched case-insensitively. Patterns are regular expressions
+// whose matches are redacted from text, error messages, and JSON string
+// values. Both extend the built-in defaults, which cover credential-bearing
+// header and parameter names (auth, key, token, secret, and similar) and
+// common API key and bearer token formats. Prompts sets how prompt text is
+// kept; empty leaves each consumer's default (audit records hashes, request
+// dumps keep prompts).
+type Config struct {
+	Keys     []string   `json:"keys,omitempty"`
+	Patterns []string   `json:"patterns,omitempty"`
+	Prompts  PromptMode `json:"prompts,omitempty"`
+}
+
+// Policy redacts secrets and prompt content from logs, audit records,
+// request dumps, and error messages. A nil Policy uses Default.
+// Policies are immutable and safe for concurrent use.
+type Policy struct {
+	keys     map[string]bool
+	patterns []*regexp.Regexp
+	prompts  PromptMode
+}
+
+// secretMarkers identify header and query parameter names that carry
+// credentials.
+var secretMarkers = []string{"auth", "key", "token", "secret", "signature", "sig", "password", "cookie"}
+
+// defaultPatterns match common credential formats in free text.
+var defaultPatterns = []string{
+	`(?i)\bbearer\s+[a-z0-9._~+/=-]+`,
+	`\bsk-[A-Za-z0-9_-]{16,}`,
+	`\bAIza[0-9A-Za-z_-]{35}`,
+}
+
+// promptFields are the JSON request body fields that carry prompt text.
+var promptFields = map[string]bool{"content": true, "text": true, "input": true, "prompt": true, "system": true}
+
+// New creates a Policy from configuration.
+// Returns an error if a pattern does not compile or the prompt mode is unknown.
+func New(cfg Config) (*Policy, error) {
+	switch cfg.Prompts {
+	case "", PromptFull, PromptHash, PromptOmit:
+	default:
+		return nil, fmt.Errorf("invalid prompt mode %q: must be full, hash, or omit", cfg.Prompts)
+	}
+
+	p := &Policy{
+		keys:    make(map[string]bool, len(cfg.Keys)),
+		prompts: cfg.Prompts,
+	}
+	for _, key := range cfg.Keys {
+		p.keys[strings.ToLower(key)] = true
+	}
+	for _, pattern := range append(defaultPatterns, cfg.Patterns...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		p.patterns = append(p.patterns, re)
+	}
+	return p, nil
+}
+
+var builtin, _ = New(Config{})
+
+var current atomic.Pointer[Policy]
+
+// Default returns the process-wide policy, used by request dumps, the client
+// log hooks, and audit records unless they are given one.
+func Default() *Policy {
+	if p := current.Load(); p != nil {
+		return p
+	}
+	return builtin
+}
+
+// SetDefault replaces the process-wide policy. A nil policy restores the
+// built-in defaults.
+func SetDefault(p *Policy) {
+	current.Store(p)
+}
+
+// resolve returns p, or the default policy when p is nil.
+func (p *Policy) resolve() *Policy {
+	if p == nil {
+		return Default()
+	}
+	return p
+}
+
+// Prompts returns the configured prompt mode, or an empty mode when the
+// consumer's default applies.
+func (p *Policy) Prompts() PromptMode {
+	return p.resolve().prompts
+}
+
+// Secret reports whether a header or query parameter name carries a
+// credential: names containing a credential marker and configured keys.
+func (p *Policy) Secret(name string) bool {
+	name = strings.ToLower(name)
+	if p.resolve().keys[name] {
+		return true
+	}
+	for _, marker := range secretMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns s with every pattern match replaced by Redacted.
+func (p *Policy) String(s string) string {
+	for _, re := range p.resolve().patterns {
+		s = re.ReplaceAllLiteralString(s, Redacted)
+	}
+	return s
+}
+
+// Error returns err with its message redacted by String. The returned error
+// unwraps to err, so errors.Is and errors.As still match. Returns err
+// unchanged when nothing is redacted.
+func (p *Policy) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if redacted := p.String(msg); redacted != msg {
+		return &redactedError{msg: redacted, err: err}
+	}
+	return err
+}
+
+// Prompt returns prompt text kept according to mode: redacted by String for
+// PromptFull, a digest for PromptHash, and empty for PromptOmit.
+func (p *Policy) Prompt(text string, mode PromptMode) string {
+	switch mode {
+	case PromptHash:
+		return Digest(text)
+	case PromptOmit:
+		return ""
+	default:
+		return p.String(text)
+	}
+}
+
+// MaxErrorBody is the number of response body bytes ErrorBody keeps.
+const MaxErrorBody = 512
+
+// ErrorBody returns a response body for use in an error message: redacted
+// by String, then truncated to MaxErrorBody bytes with a note of how many
+// bytes were dropped. Response bodies can echo prompts and request metadata,
+// so errors carry this form rather than the full body.
+func (p *Policy) ErrorBody(body []byte) []byte {
+	redacted := p.String(string(body))
+	if len(redacted) <= MaxErrorBody {
+		return []byte(redacted)
+	}
+
+	cut := MaxErrorBody
+	for cut > 0 && !utf8.RuneStart(redacted[cut]) {
+		cut--
+	}
+	return fmt.Appendf(nil, "%s... (%d more bytes)", redacted[:cut], len(redacted)-cut)
+}
+
+// Headers returns the first value of each header, with credential-bearing
+// headers replaced by Redacted and patterns redacted from the rest.
+func (p *Policy) Headers(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		value := Redacted
+		if !p.Secret(key) {
+			value = p.String(header.Get(key))
+		}
+		headers[key] = value
+	}
+	return headers
+}
+
+// URL returns u without user info and with credential-bearing query
+// parameters replaced by Redacted.
+func (p *Policy) URL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+
+	query := redacted.Query()
+	for key := range query {
+		if p.Secret(key) {
+			query.Set(key, Redacted)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+
+	return redacted.String()
+}
+
+// Body returns a request or response body with configured keys redacted at
+// any depth, patterns redacted from string values, and prompt fields
+// (content, text, input, prompt, system) kept per the policy's prompt mode,
+// full by default. Bodies that are not JSON are redacted by String. The body
+// is returned unchanged when nothing is redacted.
+func (p *Policy) Body(body []byte) []byte {
+	p = p.resolve()
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []byte(p.String(string(body)))
+	}
+
+	redacted, changed := p.value(value, false)
+	if !changed {
+		return body
+	}
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		return body
+	}
+	return data
+}
+
+// value redacts a decoded JSON value, reporting whether it changed.
+// Prompt strings are kept per the prompt mode.
+func (p *Policy) value(v any, prompt bool) (any, bool) {
+	switch v := v.(type) {
+	case string:
+		var redacted string
+		if prompt {
+			redacted = p.Prompt(v, p.prompts)
+		} else {
+			redacted = p.String(v)
+		}
+		return redacted, redacted != v
+	case map[string]any:
+		changed := false
+		for key, field := range v {
+			if p.keys[strings.ToLower(key)] {
+				v[key] = Redacted
+				changed = true
+				continue
+			}
+			redacted, c := p.value(field, promptFields[key])
+			v[key] = redacted
+			changed = changed || c
+		}
+		return v, changed
+	case []any:
+		changed := false
+		for i, item := range v {
+			redacted, c := p.value(item, prompt)
+			v[i] = redacted
+			changed = changed || c
+		}
+		return v, changed
+	default:
+		return v, false
+	}
+}
+
+// Digest returns the SHA-256 digest of text as "sha256:" followed by its
+// hex encoding.
+func Digest(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// redactedError carries a redacted message for an error.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+
+func (e *redactedError) Unwrap() error { return e.err }
@@ -0,0 +1,336 @@
+package request
+
+import (
+	"maps"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+// base holds the components shared by every request builder.
+type base struct {
+	protocol protocol.Protocol
+	provider providers.Provider
+	model    *model.Model
+	messages []protocol.Message
+	options  map[string]any
+}
+
+func (b *base) setOption(key string, value any) {
+	if b.options == nil {
+		b.options = make(map[string]any)
+	}
+	b.options[key] = value
+}
+
+func (b *base) setOptions(opts map[string]any) {
+	if b.options == nil {
+		b.options = make(map[string]any, len(opts))
+	}
+	maps.Copy(b.options, opts)
+}
+
+func (b *base) invalid(field, reason string) error {
+	return &ValidationError{Protocol: b.protocol, Field: field, Reason: reason}
+}
+
+// validate checks the provider and model, and the messages when required.
+func (b *base) validate(requireMessages bool) error {
+	if b.provider == nil {
+		return b.invalid("provider", "is required")
+	}
+	if b.model == nil {
+		return b.invalid("model", "is required")
+	}
+	if b.model.Name == "" {
+		return b.invalid("model name", "is required")
+	}
+	if !requireMessages {
+		return nil
+	}
+	if len(b.messages) == 0 {
+		return b.invalid("messages", "must not be empty")
+	}
+	for _, msg := range b.messages {
+		if msg.Role == "" {
+			return b.invalid("messages", "must all have a role")
+		}
+	}
+	return nil
+}
+
+// ChatBuilder assembles a ChatRequest with fluent setters.
+// Created by Chat; required fields are validated by Build.
+type ChatBuilder struct {
+	base
+}
+
+// Chat starts building a chat request for the given provider and model.
+//
+// Example:
+//
+//	req, err := request.Chat(provider, model).
+//	    Message("user", "Hello").
+//	    Option("temperature", 0.7).
+//	    Build()
+func Chat(p providers.Provider, m *model.Model) *ChatBuilder {
+	return &ChatBuilder{base{protocol: protocol.Chat, provider: p, model: m}}
+}
+
+// Messages appends messages to the conversation.
+func (b *ChatBuilder) Messages(messages ...protocol.Message) *ChatBuilder {
+	b.messages = append(b.messages, messages...)
+	return b
+}
+
+// Message appends a single message with the given role and content.
+func (b *ChatBuilder) Message(role string, content any) *ChatBuilder {
+	return b.Messages(protocol.NewMessage(role, content))
+}
+
+// Prefill appends a partial assistant message for the model to continue.
+// It must be the last message added (see protocol.NewPrefillMessage).
+func (b *ChatBuilder) Prefill(content string) *ChatBuilder {
+	return b.Messages(protocol.NewPrefillMessage(content))
+}
+
+// Option sets a single model configuration option.
+func (b *ChatBuilder) Option(key string, value any) *ChatBuilder {
+	b.setOption(key, value)
+	return b
+}
+
+// Options merges model configuration options, overriding existing keys.
+func (b *ChatBuilder) Options(opts map[string]any) *ChatBuilder {
+	b.setOptions(opts)
+	return b
+}
+
+// Build validates the accumulated fields and returns the ChatRequest.
+// Returns a *ValidationError if the provider, model, or messages are missing,
+// or a prefill is not final or not supported by the provider.
+func (b *ChatBuilder) Build() (*ChatRequest, error) {
+	if err := b.validate(true); err != nil {
+		return nil, err
+	}
+	if err := checkPrefill(b.protocol, b.provider, b.model, b.messages, false); err != nil {
+		return nil, err
+	}
+	return NewChat(b.provider, b.model, b.messages, b.options), nil
+}
+
+// VisionBuilder assembles a VisionRequest with fluent setters.
+// Created by Vision; required fields are validated by Build.
+type VisionBuilder struct {
+	base
+	images        []string
+	visionOptions map[string]any
+}
+
+// Vision starts building a vision request for the given provider and model.
+func Vision(p providers.Provider, m *model.Model) *VisionBuilder {
+	return &VisionBuilder{base: base{protocol: protocol.Vision, provider: p, model: m}}
+}
+
+// Messages appends messages to the conversation.
+func (b *VisionBuilder) Messages(messages ...protocol.Message) *VisionBuilder {
+	b.messages = append(b.messages, messages...)
+	return b
+}
+
+// Message appends a single message with the given role and content.
+func (b *VisionBuilder) Message(role string, content any) *VisionBuilder {
+	return b.Messages(protocol.NewMessage(role, content))
+}
+
+// Images appends image URLs or base64 data URIs to analyze.
+func (b *VisionBuilder) Images(images ...string) *VisionBuilder {
+	b.images = append(b.images, images...)
+	return b
+}
+
+// VisionOption sets a vision-specific option (e.g., detail: "high").
+func (b *VisionBuilder) VisionOption(key string, value any) *VisionBuilder {
+	if b.visionOptions == nil {
+		b.visionOptions = make(map[string]any)
+	}
+	b.visionOptions[key] = value
+	return b
+}
+
+// Option sets a single model configuration option.
+func (b *VisionBuilder) Option(key string, value any) *VisionBuilder {
+	b.setOption(key, value)
+	return b
+}
+
+// Options merges model configuration options, overriding existing keys.
+func (b *VisionBuilder) Options(opts map[string]any) *VisionBuilder {
+	b.setOptions(opts)
+	return b
+}
+
+// Build validates the accumulated fields and returns the VisionRequest.
+// Returns a *ValidationError if messages or images are missing, or any image is empty.
+func (b *VisionBuilder) Build() (*VisionRequest, error) {
+	if err := b.validate(true); err != nil {
+		return nil, err
+	}
+	if len(b.images) == 0 {
+		return nil, b.invalid("images", "must not be empty")
+	}
+	for _, image := range b.images {
+		if image == "" {
+			return nil, b.invalid("images", "must not contain empty entries")
+		}
+	}
+	if err := checkPrefill(b.protocol, b.provider, b.model, b.messages, true); err != nil {
+		return nil, err
+	}
+	return NewVision(b.provider, b.model, b.messages, b.images, b.visionOptions, b.options), nil
+}
+
+// ToolsBuilder assembles a ToolsRequest with fluent setters.
+// Created by Tools; required fields are validated by Build.
+type ToolsBuilder struct {
+	base
+	tools         []providers.ToolDefinition
+	images        []string
+	visionOptions map[string]any
+}
+
+// Tools starts building a tools request for the given provider and model.
+func Tools(p providers.Provider, m *model.Model) *ToolsBuilder {
+	return &ToolsBuilder{base: base{protocol: protocol.Tools, provider: p, model: m}}
+}
+
+// Messages appends messages to the conversation.
+func (b *ToolsBuilder) Messages(messages ...protocol.Message) *ToolsBuilder {
+	b.messages = append(b.messages, messages...)
+	return b
+}
+
+// Message appends a single message with the given role and content.
+func (b *ToolsBuilder) Message(role string, content any) *ToolsBuilder {
+	return b.Messages(protocol.NewMessage(role, content))
+}
+
+// Tools appends tool definitions the model can call.
+func (b *ToolsBuilder) Tools(tools ...providers.ToolDefinition) *ToolsBuilder {
+	b.tools = append(b.tools, tools...)
+	return b
+}
+
+// Images appends image URLs or base64 data URIs for the model to analyze
+// alongside the tool definitions.
+func (b *ToolsBuilder) Images(images ...string) *ToolsBuilder {
+	b.images = append(b.images, images...)
+	return b
+}
+
+// VisionOption sets a vision-specific option (e.g., detail: "high") for the images.
+func (b *ToolsBuilder) VisionOption(key string, value any) *ToolsBuilder {
+	if b.visionOptions == nil {
+		b.visionOptions = make(map[string]any)
+	}
+	b.visionOptions[key] = value
+	return b
+}
+
+// Option sets a single model configuration option.
+func (b *ToolsBuilder) Option(key string, value any) *ToolsBuilder {
+	b.setOption(key, value)
+	return b
+}
+
+// Options merges model configuration options, overriding existing keys.
+func (b *ToolsBuilder) Options(opts map[string]any) *ToolsBuilder {
+	b.setOptions(opts)
+	return b
+}
+
+// Build validates the accumulated fields and returns the ToolsRequest.
+// Returns a *ValidationError if messages or tools are missing, a tool has no name,
+// an image is empty, or the tool_choice option is invalid or names an unknown tool.
+func (b *ToolsBuilder) Build() (*ToolsRequest, error) {
+	if err := b.validate(true); err != nil {
+		return nil, err
+	}
+	if len(b.tools) == 0 {
+		return nil, b.invalid("tools", "must not be empty")
+	}
+	for _, tool := range b.tools {
+		if tool.Name == "" {
+			return nil, b.invalid("tools", "must all have a name")
+		}
+	}
+	for _, image := range b.images {
+		if image == "" {
+			return nil, b.invalid("images", "must not contain empty entries")
+		}
+	}
+	if err := checkToolChoice(b.options, b.tools); err != nil {
+		return nil, err
+	}
+	if err := checkPrefill(b.protocol, b.provider, b.model, b.messages, len(b.images) > 0); err != nil {
+		return nil, err
+	}
+	if len(b.images) > 0 {
+		return NewVisionTools(b.provider, b.model, b.messages, b.images, b.visionOptions, b.tools, b.options), nil
+	}
+	return NewTools(b.provider, b.model, b.messages, b.tools, b.options), nil
+}
+
+// EmbeddingsBuilder assembles an EmbeddingsRequest with fluent setters.
+// Created by Embeddings; required fields are validated by Build.
+type EmbeddingsBuilder struct {
+	base
+	input []string
+}
+
+// Embeddings starts building an embeddings request for the given provider and model.
+func Embeddings(p providers.Provider, m *model.Model) *EmbeddingsBuilder {
+	return &EmbeddingsBuilder{base: base{protocol: protocol.Embeddings, provider: p, model: m}}
+}
+
+// Input appends texts to embed. A single text is sent as a string,
+// multiple texts as a batch.
+func (b *EmbeddingsBuilder) Input(texts ...string) *EmbeddingsBuilder {
+	b.input = append(b.input, texts...)
+	return b
+}
+
+// Option sets a single model configuration option.
+func (b *EmbeddingsBuilder) Option(key string, value any) *EmbeddingsBuilder {
+	b.setOption(key, value)
+	return b
+}
+
+// Options merges model configuration options, overriding existing keys.
+func (b *EmbeddingsBuilder) Options(opts map[string]any) *EmbeddingsBuilder {
+	b.setOptions(opts)
+	return b
+}
+
+// Build validates the accumulated fields and returns the EmbeddingsRequest.
+// Returns a *ValidationError if the input is missing or contains empty text.
+func (b *EmbeddingsBuilder) Build() (*EmbeddingsRequest, error) {
+	if err := b.validate(false); err != nil {
+		return nil, err
+	}
+	if len(b.input) == 0 {
+		return nil, b.invalid("input", "must not be empty")
+	}
+	for _, text := range b.input {
+		if text == "" {
+			return nil, b.invalid("input", "must not contain empty text")
+		}
+	}
+
+	var input any = b.input
+	if len(b.input) == 1 {
+		input = b.input[0]
+	}
+	return NewEmbeddings(b.provider, b.model, input, b.options), nil
+}
@@ -46,9 +46,15 @@ func (r *ChatRequest) Marshal() ([]byte, error) {
 		Model:    r.model.Name,
 		Messages: r.messages,
 		Options:  r.options,
+		Format:   r.model.Format(protocol.Chat),
 	})
 }
 
+// Options returns the model configuration options for this request.
+func (r *ChatRequest) Options() map[string]any {
+	return r.options
+}
+
 // Provider returns the provider for this request.
 func (r *ChatRequest) Provider() providers.Provider {
 	return r.provider
@@ -11,4 +11,30 @@
 //	visionReq := request.NewVision(provider, model, messages, images, visionOpts, options)
 //	toolsReq := request.NewTools(provider, model, messages, tools, options)
 //	embeddingsReq := request.NewEmbeddings(provider, model, input, options)
+//
+// Or use the builders, which validate required fields at build time:
+//
+//	chatReq, err := request.Chat(provider, model).
+//	    Message("user", "Hello").
+//	    Option("temperature", 0.7).
+//	    Build()
+//
+// Build returns a *ValidationError (matching ErrInvalidRequest) when a
+// required field such as messages, images, tools, or input is missing.
+//
+// A request may end with a partial assistant message for the model to
+// continue (Prefill, or protocol.NewPrefillMessage). Prefill must be the final
+// message, cannot be combined with images, and is rejected by Build and
+// CheckLimits unless the provider supports it in the request's wire format
+// (see providers.PrefillSupporter). Built-in providers support it in the
+// Anthropic format, selected here by the model's chat capability:
+//
+//	// "chat": {"format": "anthropic"}
+//	chatReq, err := request.Chat(provider, claude).
+//	    Message("user", "List three colors as JSON.").
+//	    Prefill("{").
+//	    Build()
+//
+// Dump captures a request as a redacted, portable Snapshot for bug reports;
+// Replay re-executes a snapshot against a provider with valid credentials.
 package request
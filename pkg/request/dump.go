@@ -0,0 +1,157 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+)
+
+// Redacted replaces secret header and query parameter values in a Snapshot.
+const Redacted = redact.Redacted
+
+// Snapshot is a portable JSON record of a prepared request, produced by Dump.
+// Secret headers and URL query parameters are redacted so snapshots can be
+// attached to bug reports and replayed against a provider with valid credentials.
+// The body is redacted by the default redaction policy (see redact.Default).
+type Snapshot struct {
+	Protocol protocol.Protocol `json:"protocol"`
+	Provider string            `json:"provider"`
+	Model    string            `json:"model"`
+	Method   string            `json:"method"`
+	URL      string            `json:"url"`
+	Headers  map[string]string `json:"headers"`
+	Body     json.RawMessage   `json:"body"`
+}
+
+// Dump captures the request exactly as the client would send it: the
+// provider endpoint, protocol and authentication headers, and marshaled body.
+// Secret values are replaced with Redacted, and the body is redacted by the
+// default redaction policy.
+func Dump(req Request) (*Snapshot, error) {
+	provider := req.Provider()
+	proto := req.Protocol()
+
+	body, err := req.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	providerRequest, err := providers.Prepare(context.Background(), provider, proto, body, req.Headers(), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare request: %w", err)
+	}
+
+	// Build an HTTP request so provider authentication headers are captured.
+	httpReq, err := http.NewRequest(http.MethodPost, providerRequest.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	for key, value := range providerRequest.Headers {
+		httpReq.Header.Set(key, value)
+	}
+	provider.SetHeaders(httpReq)
+
+	return &Snapshot{
+		Protocol: proto,
+		Provider: provider.Name(),
+		Model:    req.Model().Name,
+		Method:   httpReq.Method,
+		URL:      RedactURL(httpReq.URL),
+		Headers:  RedactHeaders(httpReq.Header),
+		Body:     json.RawMessage(redact.Default().Body(providerRequest.Body)),
+	}, nil
+}
+
+// Request rebuilds an executable request from the snapshot.
+// The provider supplies the endpoint and authentication, so a redacted
+// snapshot can be replayed against any environment. The body is sent as dumped.
+func (s *Snapshot) Request(p providers.Provider) Request {
+	headers := make(map[string]string, len(s.Headers))
+	for key, value := range s.Headers {
+		if value != Redacted {
+			headers[key] = value
+		}
+	}
+
+	return &ReplayRequest{
+		protocol: s.Protocol,
+		headers:  headers,
+		body:     s.Body,
+		provider: p,
+		model:    &model.Model{Name: s.Model},
+	}
+}
+
+// Executor executes requests. Satisfied by client.Client.
+type Executor interface {
+	Execute(ctx context.Context, req Request) (any, error)
+}
+
+// Replay re-executes a dumped request through exec using provider p
+// for the endpoint and credentials. Returns the parsed response.
+func Replay(ctx context.Context, exec Executor, s *Snapshot, p providers.Provider) (any, error) {
+	if !protocol.IsValid(string(s.Protocol)) {
+		return nil, fmt.Errorf("invalid snapshot protocol: %q", s.Protocol)
+	}
+	if len(s.Body) == 0 {
+		return nil, fmt.Errorf("snapshot body is empty")
+	}
+	return exec.Execute(ctx, s.Request(p))
+}
+
+// ReplayRequest is a request rebuilt from a Snapshot.
+// Marshal returns the dumped body unchanged.
+type ReplayRequest struct {
+	protocol protocol.Protocol
+	headers  map[string]string
+	body     []byte
+	provider providers.Provider
+	model    *model.Model
+}
+
+// Protocol returns the dumped protocol identifier.
+func (r *ReplayRequest) Protocol() protocol.Protocol {
+	return r.protocol
+}
+
+// Headers returns the dumped non-secret HTTP headers.
+func (r *ReplayRequest) Headers() map[string]string {
+	return maps.Clone(r.headers)
+}
+
+// Marshal returns the dumped request body.
+func (r *ReplayRequest) Marshal() ([]byte, error) {
+	return r.body, nil
+}
+
+// Provider returns the provider used for replay.
+func (r *ReplayRequest) Provider() providers.Provider {
+	return r.provider
+}
+
+// Model returns a model carrying the dumped model name.
+func (r *ReplayRequest) Model() *model.Model {
+	return r.model
+}
+
+// RedactHeaders returns the first value of each header with credential-bearing
+// headers (names containing auth, key, token, secret, and similar markers)
+// replaced by Redacted, using the default redaction policy. Use to log or
+// display outgoing requests safely.
+func RedactHeaders(header http.Header) map[string]string {
+	return redact.Default().Headers(header)
+}
+
+// RedactURL returns u without user info and with credential-bearing query
+// parameters replaced by Redacted, using the default redaction policy.
+func RedactURL(u *url.URL) string {
+	return redact.Default().URL(u)
+}
@@ -46,9 +46,15 @@ func (r *EmbeddingsRequest) Marshal() ([]byte, error) {
 		Model:   r.model.Name,
 		Input:   r.input,
 		Options: r.options,
+		Format:  r.model.Format(protocol.Embeddings),
 	})
 }
 
+// Options returns the model configuration options for this request.
+func (r *EmbeddingsRequest) Options() map[string]any {
+	return r.options
+}
+
 // Provider returns the provider for this request.
 func (r *EmbeddingsRequest) Provider() providers.Provider {
 	return r.provider
@@ -0,0 +1,30 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// ErrInvalidRequest is the sentinel wrapped by every ValidationError.
+// Use errors.Is to detect build-time validation failures.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// ValidationError describes a required request field that is missing or invalid.
+// Returned by the builder Build methods; use errors.As to inspect the field.
+type ValidationError struct {
+	Protocol protocol.Protocol
+	Field    string
+	Reason   string
+}
+
+// Error formats the validation failure with its protocol and field.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s request: %s %s", e.Protocol, e.Field, e.Reason)
+}
+
+// Unwrap returns ErrInvalidRequest so errors.Is matches all validation failures.
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidRequest
+}
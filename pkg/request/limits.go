@@ -0,0 +1,210 @@
+package request
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/tokenizer"
+)
+
+// CheckLimits validates a request against its model metadata.
+// Vision, tools, JSON mode, JSON schema, and web search requests are rejected
+// when the model disables the feature; requested output tokens must not exceed MaxOutputTokens; and
+// the estimated prompt plus requested output must fit the ContextWindow.
+// Limits the model does not declare, and advisory limits and features filled
+// in from a preset, are not checked. Tools requests must also
+// have a valid tool_choice that names one of their tool definitions. A prefill
+// message must be final and supported by the provider.
+// Returns a *ValidationError describing the first violation.
+func CheckLimits(req Request) error {
+	m := req.Model()
+	proto := req.Protocol()
+
+	invalid := func(field, reason string, args ...any) error {
+		return &ValidationError{Protocol: proto, Field: field, Reason: fmt.Sprintf(reason, args...)}
+	}
+
+	// disabled reports whether the model is configured without a feature.
+	disabled := func(feature string) bool {
+		return !m.Supports(feature) && m.Enforces(feature)
+	}
+
+	switch proto {
+	case protocol.Vision:
+		if disabled(config.FeatureVision) {
+			return invalid("model", "%s does not support vision", m.Name)
+		}
+	case protocol.Tools:
+		if disabled(config.FeatureTools) {
+			return invalid("model", "%s does not support tools", m.Name)
+		}
+		if r, ok := req.(*ToolsRequest); ok {
+			if len(r.images) > 0 && disabled(config.FeatureVision) {
+				return invalid("model", "%s does not support vision", m.Name)
+			}
+			if err := checkToolChoice(r.options, r.tools); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := prefillCheck(req); err != nil {
+		return err
+	}
+
+	var opts map[string]any
+	if r, ok := req.(interface{ Options() map[string]any }); ok {
+		opts = r.Options()
+	}
+
+	if requestsJSONMode(opts) && disabled(config.FeatureJSONMode) {
+		return invalid("options", "%s does not support JSON mode", m.Name)
+	}
+
+	if requestsJSONSchema(opts) && disabled(config.FeatureJSONSchema) {
+		return invalid("options", "%s does not support JSON schema output", m.Name)
+	}
+
+	if requestsWebSearch(opts) && disabled(config.FeatureWebSearch) {
+		return invalid("options", "%s does not support web search", m.Name)
+	}
+
+	output := requestedOutputTokens(opts)
+	if m.MaxOutputTokens > 0 && m.Enforces(config.LimitMaxOutputTokens) && output > m.MaxOutputTokens {
+		return invalid("options", "requested %d output tokens exceeds model limit of %d", output, m.MaxOutputTokens)
+	}
+
+	if m.ContextWindow <= 0 || !m.Enforces(config.LimitContextWindow) {
+		return nil
+	}
+
+	if r, ok := req.(*EmbeddingsRequest); ok {
+		return checkEmbeddingsWindow(r, m.ContextWindow)
+	}
+
+	prompt, err := EstimateTokens(req, "")
+	if err != nil {
+		return err
+	}
+	if prompt+output > m.ContextWindow {
+		return invalid("messages", "estimated %d prompt tokens plus %d output tokens exceed context window of %d", prompt, output, m.ContextWindow)
+	}
+
+	return nil
+}
+
+// checkEmbeddingsWindow verifies each input fits the context window individually.
+func checkEmbeddingsWindow(r *EmbeddingsRequest, window int) error {
+	tok := tokenizer.ForModel(r.model.Name)
+
+	var inputs []string
+	switch v := r.input.(type) {
+	case string:
+		inputs = []string{v}
+	case []string:
+		inputs = v
+	}
+
+	for i, text := range inputs {
+		if count := tok.Count(text); count > window {
+			return &ValidationError{
+				Protocol: protocol.Embeddings,
+				Field:    "input",
+				Reason:   fmt.Sprintf("item %d has an estimated %d tokens, exceeding context window of %d", i, count, window),
+			}
+		}
+	}
+	return nil
+}
+
+// checkToolChoice verifies a tool_choice option is well formed and that a
+// named function matches one of the tool definitions.
+func checkToolChoice(opts map[string]any, tools []providers.ToolDefinition) error {
+	value, ok := opts["tool_choice"]
+	if !ok {
+		return nil
+	}
+
+	invalid := func(reason string, args ...any) error {
+		return &ValidationError{Protocol: protocol.Tools, Field: "tool_choice", Reason: fmt.Sprintf(reason, args...)}
+	}
+
+	mode, name, err := providers.ParseToolChoice(value)
+	if err != nil {
+		return invalid("is invalid: %v", err)
+	}
+
+	if mode == providers.ToolChoiceFunction && !slices.ContainsFunc(tools, func(t providers.ToolDefinition) bool { return t.Name == name }) {
+		return invalid("names unknown tool %q", name)
+	}
+
+	return nil
+}
+
+// requestsJSONMode reports whether options ask for JSON output, through an
+// OpenAI response_format, an Ollama format option, or a llama.cpp json_schema
+// constraint.
+func requestsJSONMode(opts map[string]any) bool {
+	if format, ok := opts["response_format"].(map[string]any); ok {
+		switch format["type"] {
+		case "json_object", "json_schema":
+			return true
+		}
+	}
+
+	if requestsJSONSchema(opts) {
+		return true
+	}
+
+	switch format := opts["format"].(type) {
+	case string:
+		return format == "json"
+	case map[string]any:
+		return true
+	}
+
+	return false
+}
+
+// requestsJSONSchema reports whether options constrain output to a JSON schema,
+// through an OpenAI json_schema response_format, an Ollama format schema, or a
+// llama.cpp json_schema constraint.
+func requestsJSONSchema(opts map[string]any) bool {
+	if format, ok := opts["response_format"].(map[string]any); ok && format["type"] == "json_schema" {
+		return true
+	}
+
+	if _, ok := opts["json_schema"].(map[string]any); ok {
+		return true
+	}
+
+	_, ok := opts["format"].(map[string]any)
+	return ok
+}
+
+// requestsWebSearch reports whether options enable the built-in web search
+// tool.
+func requestsWebSearch(opts map[string]any) bool {
+	tools, _ := providers.BuiltinTools(opts)
+	return slices.ContainsFunc(tools, func(tool map[string]any) bool {
+		return tool["type"] == providers.BuiltinWebSearch
+	})
+}
+
+// requestedOutputTokens returns the max_completion_tokens or max_tokens option, or 0.
+func requestedOutputTokens(opts map[string]any) int {
+	for _, key := range []string{"max_completion_tokens", "max_tokens"} {
+		switch v := opts[key].(type) {
+		case int:
+			return v
+		case int64:
+			return int(v)
+		case float64:
+			return int(v)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+package request
+
+import (
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+// checkPrefill verifies that a prefill message is the final assistant
+// message and that the provider supports it in the model's wire format for
+// the protocol. Requests with images embed them in their final message, so
+// they cannot end in a prefill.
+func checkPrefill(proto protocol.Protocol, provider providers.Provider, m *model.Model, messages []protocol.Message, images bool) error {
+	last := len(messages) - 1
+	for i, msg := range messages {
+		if !msg.Prefill {
+			continue
+		}
+
+		invalid := func(reason string) error {
+			return &ValidationError{Protocol: proto, Field: "messages", Reason: reason}
+		}
+
+		switch {
+		case i != last:
+			return invalid("prefill must be the final message")
+		case msg.Role != "assistant":
+			return invalid("prefill must be an assistant message")
+		case images:
+			return invalid("prefill is not supported with images")
+		case !supportsPrefill(provider, m.Format(proto)):
+			return invalid("prefill is not supported by provider " + provider.Name())
+		}
+	}
+	return nil
+}
+
+// supportsPrefill reports whether the provider accepts prefill messages in a
+// wire format, or in its own format when format is empty.
+func supportsPrefill(provider providers.Provider, format string) bool {
+	p, ok := provider.(providers.PrefillSupporter)
+	return ok && p.SupportsPrefill(format)
+}
+
+// prefillCheck checks the messages of chat, vision, and tools requests.
+func prefillCheck(req Request) error {
+	switch r := req.(type) {
+	case *ChatRequest:
+		return checkPrefill(protocol.Chat, r.provider, r.model, r.messages, false)
+	case *VisionRequest:
+		return checkPrefill(protocol.Vision, r.provider, r.model, r.messages, true)
+	case *ToolsRequest:
+		return checkPrefill(protocol.Tools, r.provider, r.model, r.messages, len(r.images) > 0)
+	}
+	return nil
+}
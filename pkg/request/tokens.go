@@ -0,0 +1,84 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/tokenizer"
+)
+
+// Token overheads follow the OpenAI chat format accounting.
+const (
+	// tokensPerMessage covers the role and message delimiters.
+	tokensPerMessage = 3
+
+	// tokensPerReply primes the assistant reply.
+	tokensPerReply = 3
+
+	// tokensPerImage is the base cost of a low-detail image.
+	tokensPerImage = 85
+)
+
+// EstimateTokens estimates the prompt tokens a request will consume.
+// The tokenizer is resolved with tokenizer.ForModel from model, or from the
+// request model name when model is empty. Message text, tool definitions,
+// images, and embedding inputs are counted along with per-message overhead.
+// Use to pre-check context-window fit and budget costs before sending.
+func EstimateTokens(req Request, model string) (int, error) {
+	if model == "" {
+		model = req.Model().Name
+	}
+	tok := tokenizer.ForModel(model)
+
+	switch r := req.(type) {
+	case *ChatRequest:
+		return countMessages(tok, r.messages), nil
+	case *VisionRequest:
+		return countMessages(tok, r.messages) + len(r.images)*tokensPerImage, nil
+	case *ToolsRequest:
+		count := countMessages(tok, r.messages) + len(r.images)*tokensPerImage
+		for _, tool := range r.tools {
+			definition, err := json.Marshal(tool)
+			if err != nil {
+				return 0, fmt.Errorf("failed to marshal tool %s: %w", tool.Name, err)
+			}
+			count += tok.Count(string(definition))
+		}
+		return count, nil
+	case *EmbeddingsRequest:
+		return countInput(tok, r.input)
+	default:
+		body, err := req.Marshal()
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		return tok.Count(string(body)), nil
+	}
+}
+
+func countMessages(tok tokenizer.Tokenizer, messages []protocol.Message) int {
+	count := tokensPerReply
+	for _, msg := range messages {
+		count += tokensPerMessage + tok.Count(msg.Role) + tok.Count(msg.Text())
+		for _, call := range msg.ToolCalls {
+			count += tok.Count(call.Function.Name) + tok.Count(call.Function.Arguments)
+		}
+	}
+	return count
+}
+
+func countInput(tok tokenizer.Tokenizer, input any) (int, error) {
+	switch v := input.(type) {
+	case string:
+		return tok.Count(v), nil
+	case []string:
+		count := 0
+		for _, text := range v {
+			count += tok.Count(text)
+		}
+		return count, nil
+	default:
+		return 0, fmt.Errorf("unsupported embeddings input type %T", input)
+	}
+}
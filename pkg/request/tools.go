@@ -8,12 +8,15 @@ import (
 
 // ToolsRequest represents a tools (function calling) protocol request.
 // Separates tool definitions (protocol input data) from model configuration options.
+// Requests created with NewVisionTools also carry images for the model to analyze.
 type ToolsRequest struct {
-	messages []protocol.Message
-	tools    []providers.ToolDefinition
-	options  map[string]any
-	provider providers.Provider
-	model    *model.Model
+	messages      []protocol.Message
+	tools         []providers.ToolDefinition
+	images        []string       // URLs or base64 data URIs
+	visionOptions map[string]any // Vision-specific options (e.g., detail: "high")
+	options       map[string]any
+	provider      providers.Provider
+	model         *model.Model
 }
 
 // NewTools creates a new ToolsRequest with the given components.
@@ -30,6 +33,23 @@ func NewTools(p providers.Provider, m *model.Model, messages []protocol.Message,
 	}
 }
 
+// NewVisionTools creates a ToolsRequest that also includes images, for agents
+// that analyze images and respond with tool calls (e.g., UI automation).
+// Images are URLs or base64 data URIs embedded in the last message.
+// VisionOptions are vision-specific settings (e.g., detail level).
+// The request uses the Tools protocol and requires a vision-capable model.
+func NewVisionTools(p providers.Provider, m *model.Model, messages []protocol.Message, images []string, visionOpts map[string]any, tools []providers.ToolDefinition, opts map[string]any) *ToolsRequest {
+	return &ToolsRequest{
+		messages:      messages,
+		tools:         tools,
+		images:        images,
+		visionOptions: visionOpts,
+		options:       opts,
+		provider:      p,
+		model:         m,
+	}
+}
+
 // Protocol returns the Tools protocol identifier.
 func (r *ToolsRequest) Protocol() protocol.Protocol {
 	return protocol.Tools
@@ -46,13 +66,26 @@ func (r *ToolsRequest) Headers() map[string]string {
 // Different providers use different tool formats (OpenAI, Anthropic, Google).
 func (r *ToolsRequest) Marshal() ([]byte, error) {
 	return r.provider.Marshal(protocol.Tools, &providers.ToolsData{
-		Model:    r.model.Name,
-		Messages: r.messages,
-		Tools:    r.tools,
-		Options:  r.options,
+		Model:         r.model.Name,
+		Messages:      r.messages,
+		Tools:         r.tools,
+		Images:        r.images,
+		VisionOptions: r.visionOptions,
+		Options:       r.options,
+		Format:        r.model.Format(protocol.Tools),
 	})
 }
 
+// Images returns the images included with the request, or nil for plain tools requests.
+func (r *ToolsRequest) Images() []string {
+	return r.images
+}
+
+// Options returns the model configuration options for this request.
+func (r *ToolsRequest) Options() map[string]any {
+	return r.options
+}
+
 // Provider returns the provider for this request.
 func (r *ToolsRequest) Provider() providers.Provider {
 	return r.provider
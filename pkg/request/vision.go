@@ -53,9 +53,15 @@ func (r *VisionRequest) Marshal() ([]byte, error) {
 		Images:        r.images,
 		VisionOptions: r.visionOptions,
 		Options:       r.options,
+		Format:        r.model.Format(protocol.Vision),
 	})
 }
 
+// Options returns the model configuration options for this request.
+func (r *VisionRequest) Options() map[string]any {
+	return r.options
+}
+
 // Provider returns the provider for this request.
 func (r *VisionRequest) Provider() providers.Provider {
 	return r.provider
@@ -0,0 +1,135 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// anthropicMessage is an Anthropic Messages API response.
+type anthropicMessage struct {
+	ID         string `json:"id"`
+	Model      string `json:"model"`
+	StopReason string `json:"stop_reason"`
+	Content    []struct {
+		Type      string          `json:"type"`
+		Text      string          `json:"text"`
+		ID        string          `json:"id"`
+		Name      string          `json:"name"`
+		Input     json.RawMessage `json:"input"`
+		Content   json.RawMessage `json:"content"`
+		Citations []struct {
+			Type          string `json:"type"`
+			URL           string `json:"url"`
+			Title         string `json:"title"`
+			DocumentTitle string `json:"document_title"`
+			CitedText     string `json:"cited_text"`
+		} `json:"citations"`
+	} `json:"content"`
+	Usage *struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// ParseAnthropicTools parses an Anthropic Messages API response into a
+// ToolsResponse with a single choice. Text blocks are joined into the message
+// content, and tool_use blocks become tool calls whose arguments are the
+// block's JSON input. The stop reason is kept as the finish reason, which
+// normalizes "tool_use" to FinishReasonToolCalls. Web search server tool
+// blocks become the choice's Grounding, and citations on text blocks become
+// the choice's citations, spanning the byte range of the cited block.
+// The original payload is retained and available through Raw.
+func ParseAnthropicTools(body []byte) (*ToolsResponse, error) {
+	n, err := normalizeAnthropic(body)
+	if err != nil {
+		return nil, err
+	}
+	return n.tools(), nil
+}
+
+// ParseAnthropicChat parses an Anthropic Messages API response into a
+// ChatResponse, decoded as by ParseAnthropicTools.
+func ParseAnthropicChat(body []byte) (*ChatResponse, error) {
+	n, err := normalizeAnthropic(body)
+	if err != nil {
+		return nil, err
+	}
+	return n.chat(), nil
+}
+
+// normalizeAnthropic decodes an Anthropic Messages API response.
+func normalizeAnthropic(body []byte) (*normalized, error) {
+	var msg anthropicMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse anthropic response: %w", err)
+	}
+
+	var (
+		text   []string
+		choice = normalizedChoice{finish: FinishReason(msg.StopReason), grounding: &Grounding{}}
+		offset int
+	)
+	for _, block := range msg.Content {
+		switch block.Type {
+		case "server_tool_use":
+			var input struct {
+				Query string `json:"query"`
+			}
+			if block.Name == "web_search" && json.Unmarshal(block.Input, &input) == nil && input.Query != "" {
+				choice.grounding.Queries = append(choice.grounding.Queries, input.Query)
+			}
+		case "web_search_tool_result":
+			// Content is a list of results, or an error object when the
+			// search failed
+			var results []struct {
+				Type  string `json:"type"`
+				URL   string `json:"url"`
+				Title string `json:"title"`
+			}
+			if json.Unmarshal(block.Content, &results) == nil {
+				for _, result := range results {
+					if result.Type == "web_search_result" {
+						choice.grounding.addResult(SearchResult{URL: result.URL, Title: result.Title})
+					}
+				}
+			}
+		case "text":
+			// Citations span the whole text block they are attached to
+			start := offset
+			for _, cited := range block.Citations {
+				citation := Citation{Quote: cited.CitedText, Start: start, End: start + len(block.Text)}
+				if cited.Type == "web_search_result_location" {
+					citation.Type, citation.URL, citation.Title = CitationURL, cited.URL, cited.Title
+				} else {
+					citation.Type, citation.Title = CitationDocument, cited.DocumentTitle
+				}
+				choice.citations = append(choice.citations, citation)
+			}
+			text = append(text, block.Text)
+			offset += len(block.Text)
+		case "tool_use":
+			arguments := string(block.Input)
+			if arguments == "" || arguments == "null" {
+				arguments = "{}"
+			}
+			choice.calls = append(choice.calls, ToolCall{
+				ID:       block.ID,
+				Type:     "function",
+				Function: ToolCallFunction{Name: block.Name, Arguments: arguments},
+			})
+		}
+	}
+	choice.text = strings.Join(text, "")
+
+	n := &normalized{
+		id:      msg.ID,
+		model:   msg.Model,
+		choices: []normalizedChoice{choice},
+		raw:     body,
+	}
+	if msg.Usage != nil {
+		n.usage = normalizedUsage(msg.Usage.InputTokens, msg.Usage.OutputTokens, 0)
+	}
+	return n, nil
+}
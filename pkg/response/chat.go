@@ -10,20 +10,40 @@ import (
 // ChatResponse represents the response from a non-streaming chat protocol request.
 // Contains the model output, metadata, and optional token usage information.
 type ChatResponse struct {
-	ID      string `json:"id,omitempty"`
-	Object  string `json:"object,omitempty"`
-	Created int64  `json:"created,omitempty"`
-	Model   string `json:"model"`
-	Choices []struct {
-		Index   int              `json:"index"`
-		Message protocol.Message `json:"message"`
-		Delta   *struct {
-			Role    string `json:"role,omitempty"`
-			Content string `json:"content,omitempty"`
-		} `json:"delta,omitempty"`
-		FinishReason string `json:"finish_reason,omitempty"`
-	} `json:"choices"`
-	Usage *TokenUsage `json:"usage,omitempty"`
+	ID                string       `json:"id,omitempty"`
+	Object            string       `json:"object,omitempty"`
+	Created           int64        `json:"created,omitempty"`
+	Model             string       `json:"model"`
+	SystemFingerprint string       `json:"system_fingerprint,omitempty"`
+	Choices           []ChatChoice `json:"choices"`
+	Usage             *TokenUsage  `json:"usage,omitempty"`
+
+	// PromptFilterResults is reported by Azure OpenAI.
+	PromptFilterResults []PromptFilterResult `json:"prompt_filter_results,omitempty"`
+
+	raw []byte
+}
+
+// ChatChoice represents a single completion choice in a chat response.
+type ChatChoice struct {
+	Index   int              `json:"index"`
+	Message protocol.Message `json:"message"`
+	Delta   *struct {
+		Role    string `json:"role,omitempty"`
+		Content string `json:"content,omitempty"`
+	} `json:"delta,omitempty"`
+	FinishReason FinishReason `json:"finish_reason,omitempty"`
+	Logprobs     *Logprobs    `json:"logprobs,omitempty"`
+
+	// Grounding is set when a provider-side web search informed the choice.
+	Grounding *Grounding `json:"grounding,omitempty"`
+
+	// Citations link spans of the content to their sources, when the
+	// provider reports them.
+	Citations []Citation `json:"citations,omitempty"`
+
+	// ContentFilterResults is reported by Azure OpenAI.
+	ContentFilterResults ContentFilterResults `json:"content_filter_results,omitempty"`
 }
 
 // Content extracts the text content from the first choice in the response.
@@ -31,23 +51,78 @@ type ChatResponse struct {
 // Returns empty string if there are no choices.
 func (r *ChatResponse) Content() string {
 	if len(r.Choices) > 0 {
-		switch v := r.Choices[0].Message.Content.(type) {
-		case string:
-			return v
-		default:
-			return fmt.Sprintf("%v", v)
+		return r.Choices[0].Content()
+	}
+	return ""
+}
+
+// Contents extracts the text content of every choice in the response.
+// Use when requesting multiple completions (n > 1); order follows Choices.
+func (r *ChatResponse) Contents() []string {
+	contents := make([]string, len(r.Choices))
+	for i := range r.Choices {
+		contents[i] = r.Choices[i].Content()
+	}
+	return contents
+}
+
+// Choice returns the choice with the given index.
+// Looks up by the provider-reported Index rather than slice position.
+// Returns nil if no choice has the index.
+func (r *ChatResponse) Choice(index int) *ChatChoice {
+	for i := range r.Choices {
+		if r.Choices[i].Index == index {
+			return &r.Choices[i]
 		}
 	}
+	return nil
+}
+
+// Content extracts the text content of the choice message.
+// Handles both string content and structured content (e.g., vision responses).
+func (c *ChatChoice) Content() string {
+	return c.Message.Text()
+}
+
+// FinishReason returns the normalized finish reason of the first choice.
+// Returns an empty FinishReason if there are no choices.
+func (r *ChatResponse) FinishReason() FinishReason {
+	if len(r.Choices) > 0 {
+		return r.Choices[0].FinishReason.Normalize()
+	}
 	return ""
 }
 
+// Truncated returns true if the first choice was cut off by the token limit.
+func (r *ChatResponse) Truncated() bool {
+	return r.FinishReason().Truncated()
+}
+
+// Logprobs returns the token log probabilities of the first choice.
+// Returns nil if there are no choices or logprobs were not requested.
+func (r *ChatResponse) Logprobs() *Logprobs {
+	if len(r.Choices) > 0 {
+		return r.Choices[0].Logprobs
+	}
+	return nil
+}
+
+// Raw returns the original JSON payload the response was parsed from.
+// Use to log, persist, or re-parse provider-specific fields not modeled here.
+// Returns nil for responses that were not produced by a parser.
+func (r *ChatResponse) Raw() []byte {
+	return r.raw
+}
+
 // ParseChat parses a chat response from JSON bytes.
+// The original payload is retained and available through Raw.
 // Returns the parsed ChatResponse or an error if parsing fails.
 func ParseChat(body []byte) (*ChatResponse, error) {
 	var response ChatResponse
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse chat response: %w", err)
 	}
+	response.raw = body
 	return &response, nil
 }
 
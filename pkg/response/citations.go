@@ -0,0 +1,157 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// CitationType identifies what a citation points to.
+type CitationType string
+
+const (
+	// CitationURL cites a web page, such as a web search result.
+	CitationURL CitationType = "url"
+
+	// CitationFile cites an uploaded file or retrieved data source.
+	CitationFile CitationType = "file"
+
+	// CitationDocument cites a document supplied in the request.
+	CitationDocument CitationType = "document"
+)
+
+// Citation links a span of a choice's content to its source.
+// Start and End are offsets into the content as reported by the provider;
+// both are zero when the provider gives no span.
+type Citation struct {
+	Type   CitationType `json:"type"`
+	URL    string       `json:"url,omitempty"`
+	Title  string       `json:"title,omitempty"`
+	FileID string       `json:"file_id,omitempty"`
+	Quote  string       `json:"quote,omitempty"`
+	Start  int          `json:"start,omitempty"`
+	End    int          `json:"end,omitempty"`
+}
+
+// Citations returns the citations of the first choice.
+// Returns nil if there are no choices or no citations.
+func (r *ChatResponse) Citations() []Citation {
+	if len(r.Choices) > 0 {
+		return r.Choices[0].Citations
+	}
+	return nil
+}
+
+// Citations returns the citations of the first choice.
+// Returns nil if there are no choices or no citations.
+func (r *ToolsResponse) Citations() []Citation {
+	if len(r.Choices) > 0 {
+		return r.Choices[0].Citations
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a chat choice, collecting citations from the
+// message's OpenAI annotations and Azure On Your Data context.
+func (c *ChatChoice) UnmarshalJSON(data []byte) error {
+	type plain ChatChoice
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	if c.Citations == nil {
+		c.Citations = messageCitations(data)
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a tools choice, collecting citations as for
+// ChatChoice.
+func (c *ToolsChoice) UnmarshalJSON(data []byte) error {
+	type plain ToolsChoice
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	if c.Citations == nil {
+		c.Citations = messageCitations(data)
+	}
+	return nil
+}
+
+// messageCitations extracts citations from a choice's message: OpenAI
+// url_citation and file_citation annotations, and the citations Azure OpenAI
+// On Your Data reports in the message context.
+func messageCitations(choice []byte) []Citation {
+	if !bytes.Contains(choice, []byte(`"annotations"`)) && !bytes.Contains(choice, []byte(`"citations"`)) {
+		return nil
+	}
+
+	var wire struct {
+		Message struct {
+			Annotations []struct {
+				Type        string `json:"type"`
+				Text        string `json:"text"`
+				StartIndex  int    `json:"start_index"`
+				EndIndex    int    `json:"end_index"`
+				URLCitation *struct {
+					URL        string `json:"url"`
+					Title      string `json:"title"`
+					StartIndex int    `json:"start_index"`
+					EndIndex   int    `json:"end_index"`
+				} `json:"url_citation"`
+				FileCitation *struct {
+					FileID string `json:"file_id"`
+					Quote  string `json:"quote"`
+				} `json:"file_citation"`
+			} `json:"annotations"`
+			Context *struct {
+				Citations []struct {
+					Title    string `json:"title"`
+					URL      string `json:"url"`
+					Filepath string `json:"filepath"`
+					Content  string `json:"content"`
+				} `json:"citations"`
+			} `json:"context"`
+		} `json:"message"`
+	}
+	if json.Unmarshal(choice, &wire) != nil {
+		return nil
+	}
+
+	var citations []Citation
+	for _, annotation := range wire.Message.Annotations {
+		switch {
+		case annotation.Type == "url_citation" && annotation.URLCitation != nil:
+			cited := annotation.URLCitation
+			citations = append(citations, Citation{
+				Type:  CitationURL,
+				URL:   cited.URL,
+				Title: cited.Title,
+				Start: cited.StartIndex,
+				End:   cited.EndIndex,
+			})
+		case annotation.Type == "file_citation" && annotation.FileCitation != nil:
+			citations = append(citations, Citation{
+				Type:   CitationFile,
+				FileID: annotation.FileCitation.FileID,
+				Quote:  annotation.FileCitation.Quote,
+				Start:  annotation.StartIndex,
+				End:    annotation.EndIndex,
+			})
+		}
+	}
+
+	if wire.Message.Context != nil {
+		for _, cited := range wire.Message.Context.Citations {
+			title := cited.Title
+			if title == "" {
+				title = cited.Filepath
+			}
+			citations = append(citations, Citation{
+				Type:  CitationFile,
+				URL:   cited.URL,
+				Title: title,
+				Quote: cited.Content,
+			})
+		}
+	}
+	return citations
+}
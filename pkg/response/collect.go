@@ -0,0 +1,156 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// Collect drains a streaming chunk channel and assembles the final ChatResponse.
+// Content deltas are concatenated per choice, tool call fragments are merged by
+// index, and the finish reason and usage reported by the stream are captured.
+// This allows callers to offer streaming and non-streaming output from one code path.
+//
+// Returns an error if a chunk carries an error or the context is cancelled
+// before the channel is closed.
+func Collect(ctx context.Context, chunks <-chan *StreamingChunk) (*ChatResponse, error) {
+	c := newCollector()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stream collection cancelled: %w", ctx.Err())
+		case chunk, ok := <-chunks:
+			if !ok {
+				return c.response(), nil
+			}
+			if chunk == nil {
+				continue
+			}
+			if chunk.Error != nil {
+				return nil, fmt.Errorf("stream error: %w", chunk.Error)
+			}
+			c.add(chunk)
+		}
+	}
+}
+
+// collector accumulates streaming chunks into per-choice state.
+type collector struct {
+	resp    ChatResponse
+	choices map[int]*choiceState
+}
+
+// choiceState tracks the accumulated state of a single streamed choice.
+type choiceState struct {
+	role         string
+	content      strings.Builder
+	toolCalls    map[int]*protocol.ToolCall
+	finishReason FinishReason
+	logprobs     *Logprobs
+}
+
+func newCollector() *collector {
+	return &collector{
+		resp:    ChatResponse{Object: "chat.completion"},
+		choices: make(map[int]*choiceState),
+	}
+}
+
+func (c *collector) add(chunk *StreamingChunk) {
+	if c.resp.ID == "" {
+		c.resp.ID = chunk.ID
+	}
+	if c.resp.Created == 0 {
+		c.resp.Created = chunk.Created
+	}
+	if c.resp.Model == "" {
+		c.resp.Model = chunk.Model
+	}
+	if chunk.Usage != nil {
+		c.resp.Usage = chunk.Usage
+	}
+
+	for _, choice := range chunk.Choices {
+		state, exists := c.choices[choice.Index]
+		if !exists {
+			state = &choiceState{toolCalls: make(map[int]*protocol.ToolCall)}
+			c.choices[choice.Index] = state
+		}
+
+		if choice.Delta.Role != "" {
+			state.role = choice.Delta.Role
+		}
+		state.content.WriteString(choice.Delta.Content)
+
+		for _, fragment := range choice.Delta.ToolCalls {
+			call, exists := state.toolCalls[fragment.Index]
+			if !exists {
+				call = &protocol.ToolCall{}
+				state.toolCalls[fragment.Index] = call
+			}
+			if fragment.ID != "" {
+				call.ID = fragment.ID
+			}
+			if fragment.Type != "" {
+				call.Type = fragment.Type
+			}
+			if fragment.Function.Name != "" {
+				call.Function.Name = fragment.Function.Name
+			}
+			call.Function.Arguments += fragment.Function.Arguments
+		}
+
+		if choice.Logprobs != nil {
+			if state.logprobs == nil {
+				state.logprobs = &Logprobs{}
+			}
+			state.logprobs.Content = append(state.logprobs.Content, choice.Logprobs.Content...)
+			state.logprobs.Refusal = append(state.logprobs.Refusal, choice.Logprobs.Refusal...)
+		}
+
+		if choice.FinishReason != nil && *choice.FinishReason != "" {
+			state.finishReason = *choice.FinishReason
+		}
+	}
+}
+
+func (c *collector) response() *ChatResponse {
+	resp := c.resp
+
+	for _, index := range slices.Sorted(maps.Keys(c.choices)) {
+		state := c.choices[index]
+
+		role := state.role
+		if role == "" {
+			role = "assistant"
+		}
+
+		message := protocol.NewMessage(role, state.content.String())
+
+		if len(state.toolCalls) > 0 {
+			callIndexes := slices.Sorted(maps.Keys(state.toolCalls))
+			message.ToolCalls = make([]protocol.ToolCall, len(callIndexes))
+			for i, callIndex := range callIndexes {
+				call := *state.toolCalls[callIndex]
+				if call.Type == "" {
+					call.Type = "function"
+				}
+				message.ToolCalls[i] = call
+			}
+		}
+
+		resp.Choices = append(resp.Choices, ChatChoice{
+			Index:        index,
+			Message:      message,
+			FinishReason: state.finishReason,
+			Logprobs:     state.logprobs,
+		})
+	}
+
+	return &resp
+}
@@ -1,29 +1,226 @@
 package response
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
 )
 
 // EmbeddingsResponse represents the response from an embeddings protocol request.
 // Contains vector embeddings for the input text along with metadata and token usage.
 type EmbeddingsResponse struct {
-	Object string `json:"object"`
-	Data   []struct {
-		Embedding []float64 `json:"embedding"`
-		Index     int       `json:"index"`
-		Object    string    `json:"object"`
-	}
-	Model string      `json:"model"`
-	Usage *TokenUsage `json:"usage,omitempty"`
+	Object string          `json:"object"`
+	Data   []EmbeddingData `json:"data"`
+	Model  string          `json:"model"`
+	Usage  *TokenUsage     `json:"usage,omitempty"`
+
+	raw []byte
+}
+
+// EmbeddingData is a single embedding vector and the index of its input.
+type EmbeddingData struct {
+	Embedding []float64 `json:"embedding"`
+	Index     int       `json:"index"`
+	Object    string    `json:"object"`
+}
+
+// Raw returns the original JSON payload the response was parsed from.
+// Returns nil for responses that were not produced by a parser.
+func (r *EmbeddingsResponse) Raw() []byte {
+	return r.raw
 }
 
 // ParseEmbeddings parses an embeddings response from JSON bytes.
+// The original payload is retained and available through Raw.
 // Returns the parsed EmbeddingsResponse or an error if parsing fails.
 func ParseEmbeddings(body []byte) (*EmbeddingsResponse, error) {
 	var response EmbeddingsResponse
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse embeddings response: %w", err)
 	}
+	response.raw = body
 	return &response, nil
 }
+
+// UnmarshalJSON decodes an embedding given either as an array of numbers or,
+// for requests with encoding_format "base64", as base64-encoded little-endian
+// float32 values.
+func (d *EmbeddingData) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Embedding json.RawMessage `json:"embedding"`
+		Index     int             `json:"index"`
+		Object    string          `json:"object"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	d.Index, d.Object, d.Embedding = wire.Index, wire.Object, nil
+	if len(wire.Embedding) == 0 || string(wire.Embedding) == "null" {
+		return nil
+	}
+
+	if wire.Embedding[0] == '"' {
+		vector, err := appendBase64(nil, wire.Embedding)
+		if err != nil {
+			return err
+		}
+		d.Embedding = make([]float64, len(vector))
+		for i, value := range vector {
+			d.Embedding[i] = float64(value)
+		}
+		return nil
+	}
+	return json.Unmarshal(wire.Embedding, &d.Embedding)
+}
+
+// DataFloat32 returns the embeddings converted to float32, ordered as Data.
+// The vectors share a single backing array.
+func (r *EmbeddingsResponse) DataFloat32() [][]float32 {
+	size := 0
+	for _, data := range r.Data {
+		size += len(data.Embedding)
+	}
+
+	buf := make([]float32, 0, size)
+	vectors := make([][]float32, len(r.Data))
+	for i, data := range r.Data {
+		start := len(buf)
+		for _, value := range data.Embedding {
+			buf = append(buf, float32(value))
+		}
+		vectors[i] = buf[start:len(buf):len(buf)]
+	}
+	return vectors
+}
+
+// Float32Embeddings is an embeddings response decoded directly into float32
+// vectors, without the intermediate float64 values of EmbeddingsResponse.
+type Float32Embeddings struct {
+	Model string
+	Usage *TokenUsage
+
+	// Vectors holds one embedding per input, ordered by input index.
+	// Each vector is a capped slice of Buffer, so appending to one does not
+	// overwrite the next.
+	Vectors [][]float32
+
+	// Buffer holds every vector, in response order. Pass it to the next
+	// ParseEmbeddingsFloat32 call to reuse its memory.
+	Buffer []float32
+}
+
+// ParseEmbeddingsFloat32 parses an embeddings response directly into float32
+// vectors, for building large indexes where float32 is standard and float64
+// would double memory. Embeddings may be arrays of numbers or base64 strings
+// (encoding_format "base64").
+//
+// The vectors are appended to buf[:0], so passing the previous batch's
+// Buffer reuses its memory; pass nil to allocate. Vectors from an earlier
+// call are overwritten when their buffer is reused.
+// Embeddings without an index are placed in response order.
+// Returns an error if an embedding cannot be decoded or an index is out of
+// range or repeated.
+func ParseEmbeddingsFloat32(body []byte, buf []float32) (*Float32Embeddings, error) {
+	var wire struct {
+		Model string      `json:"model"`
+		Usage *TokenUsage `json:"usage"`
+		Data  []struct {
+			Embedding json.RawMessage `json:"embedding"`
+			Index     *int            `json:"index"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &wire); err != nil {
+		return nil, fmt.Errorf("failed to parse embeddings response: %w", err)
+	}
+
+	buf = buf[:0]
+	bounds := make([][2]int, len(wire.Data))
+	for i, data := range wire.Data {
+		start := len(buf)
+		var err error
+		if len(data.Embedding) > 0 && data.Embedding[0] == '"' {
+			buf, err = appendBase64(buf, data.Embedding)
+		} else {
+			buf, err = appendNumbers(buf, data.Embedding)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse embeddings response: data[%d].embedding: %w", i, err)
+		}
+		bounds[i] = [2]int{start, len(buf)}
+	}
+
+	// Place each vector at its input index
+	vectors := make([][]float32, len(wire.Data))
+	placed := make([]bool, len(wire.Data))
+	for i, data := range wire.Data {
+		index := i
+		if data.Index != nil {
+			index = *data.Index
+		}
+		switch {
+		case index < 0 || index >= len(vectors):
+			return nil, fmt.Errorf("failed to parse embeddings response: data[%d].index %d is out of range", i, index)
+		case placed[index]:
+			return nil, fmt.Errorf("failed to parse embeddings response: data[%d].index %d is repeated", i, index)
+		}
+		placed[index] = true
+		vectors[index] = buf[bounds[i][0]:bounds[i][1]:bounds[i][1]]
+	}
+
+	return &Float32Embeddings{Model: wire.Model, Usage: wire.Usage, Vectors: vectors, Buffer: buf}, nil
+}
+
+// appendBase64 decodes a JSON string of base64-encoded little-endian float32
+// values and appends them to buf.
+func appendBase64(buf []float32, raw json.RawMessage) ([]float32, error) {
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err != nil {
+		return nil, err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 embedding: %w", err)
+	}
+	if len(decoded)%4 != 0 {
+		return nil, fmt.Errorf("base64 embedding has %d bytes, not a multiple of 4", len(decoded))
+	}
+
+	buf = slices.Grow(buf, len(decoded)/4)
+	for i := 0; i < len(decoded); i += 4 {
+		buf = append(buf, math.Float32frombits(binary.LittleEndian.Uint32(decoded[i:])))
+	}
+	return buf, nil
+}
+
+// appendNumbers parses a JSON array of numbers and appends them to buf
+// without allocating an intermediate slice.
+func appendNumbers(buf []float32, raw json.RawMessage) ([]float32, error) {
+	body := bytes.TrimSpace(raw)
+	if len(body) < 2 || body[0] != '[' || body[len(body)-1] != ']' {
+		return nil, fmt.Errorf("expected an array of numbers")
+	}
+	body = bytes.TrimSpace(body[1 : len(body)-1])
+	if len(body) == 0 {
+		return buf, nil
+	}
+
+	for len(body) > 0 {
+		item, rest, more := bytes.Cut(body, []byte(","))
+		if more && len(bytes.TrimSpace(rest)) == 0 {
+			return nil, fmt.Errorf("unexpected trailing comma")
+		}
+		value, err := strconv.ParseFloat(string(bytes.TrimSpace(item)), 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", bytes.TrimSpace(item))
+		}
+		buf = append(buf, float32(value))
+		body = rest
+	}
+	return buf, nil
+}
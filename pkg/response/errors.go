@@ -0,0 +1,7 @@
+package response
+
+import "errors"
+
+// ErrContentFiltered indicates the provider withheld output because it
+// triggered a content filter. Use errors.Is to detect it.
+var ErrContentFiltered = errors.New("content filtered")
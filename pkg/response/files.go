@@ -0,0 +1,30 @@
+package response
+
+// FilePurpose is the intended use of an uploaded file.
+type FilePurpose string
+
+const (
+	FilePurposeAssistants FilePurpose = "assistants"
+	FilePurposeBatch      FilePurpose = "batch"
+	FilePurposeFineTune   FilePurpose = "fine-tune"
+	FilePurposeVision     FilePurpose = "vision"
+	FilePurposeUserData   FilePurpose = "user_data"
+)
+
+// File describes a file stored with a provider's files API.
+// The ID can be referenced by requests that accept file inputs.
+type File struct {
+	ID        string      `json:"id"`
+	Object    string      `json:"object"`
+	Bytes     int64       `json:"bytes"`
+	CreatedAt int64       `json:"created_at"`
+	Filename  string      `json:"filename"`
+	Purpose   FilePurpose `json:"purpose"`
+	Status    string      `json:"status,omitempty"`
+}
+
+// FileList is the response of a files list request.
+type FileList struct {
+	Object string `json:"object"`
+	Data   []File `json:"data"`
+}
@@ -0,0 +1,92 @@
+package response
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ContentFilterResults maps filter categories (hate, self_harm, sexual, violence,
+// jailbreak, protected_material_text, ...) to their evaluation results.
+// Reported by Azure OpenAI for prompts and completions.
+type ContentFilterResults map[string]ContentFilterResult
+
+// ContentFilterResult is the evaluation of a single content filter category.
+// Severity-based categories report Severity; detection-based categories
+// (jailbreak, protected material) report Detected.
+type ContentFilterResult struct {
+	Filtered bool   `json:"filtered"`
+	Severity string `json:"severity,omitempty"`
+	Detected *bool  `json:"detected,omitempty"`
+}
+
+// Filtered returns true if any category caused content to be filtered.
+func (r ContentFilterResults) Filtered() bool {
+	for _, result := range r {
+		if result.Filtered {
+			return true
+		}
+	}
+	return false
+}
+
+// Categories returns the sorted names of the categories that caused filtering.
+func (r ContentFilterResults) Categories() []string {
+	var categories []string
+	for _, name := range slices.Sorted(maps.Keys(r)) {
+		if r[name].Filtered {
+			categories = append(categories, name)
+		}
+	}
+	return categories
+}
+
+// PromptFilterResult contains the content filter evaluation for one prompt.
+type PromptFilterResult struct {
+	PromptIndex          int                  `json:"prompt_index"`
+	ContentFilterResults ContentFilterResults `json:"content_filter_results"`
+}
+
+// CheckContentFilter inspects a parsed response for filtered completions.
+// Returns an error wrapping ErrContentFiltered if any choice finished because
+// of a content filter or reports filtered categories; otherwise returns nil.
+// Accepts *ChatResponse, *ToolsResponse, or *StreamingChunk; other types return nil.
+func CheckContentFilter(result any) error {
+	switch r := result.(type) {
+	case *ChatResponse:
+		for _, choice := range r.Choices {
+			if err := filterError(choice.FinishReason, choice.ContentFilterResults); err != nil {
+				return err
+			}
+		}
+	case *ToolsResponse:
+		for _, choice := range r.Choices {
+			if err := filterError(choice.FinishReason, choice.ContentFilterResults); err != nil {
+				return err
+			}
+		}
+	case *StreamingChunk:
+		for _, choice := range r.Choices {
+			var reason FinishReason
+			if choice.FinishReason != nil {
+				reason = *choice.FinishReason
+			}
+			if err := filterError(reason, choice.ContentFilterResults); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func filterError(reason FinishReason, results ContentFilterResults) error {
+	if reason.Normalize() != FinishReasonContentFilter && !results.Filtered() {
+		return nil
+	}
+
+	if categories := results.Categories(); len(categories) > 0 {
+		return fmt.Errorf("%w: %s", ErrContentFiltered, strings.Join(categories, ", "))
+	}
+	return ErrContentFiltered
+}
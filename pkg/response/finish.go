@@ -0,0 +1,45 @@
+package response
+
+import "strings"
+
+// FinishReason indicates why the model stopped generating output for a choice.
+// Providers report reasons using different vocabularies; use Normalize to map
+// a provider-specific value onto one of the exported constants.
+type FinishReason string
+
+const (
+	// FinishReasonStop indicates the model reached a natural stopping point or stop sequence.
+	FinishReasonStop FinishReason = "stop"
+
+	// FinishReasonLength indicates output was truncated by the token limit.
+	FinishReasonLength FinishReason = "length"
+
+	// FinishReasonToolCalls indicates the model stopped to request tool calls.
+	FinishReasonToolCalls FinishReason = "tool_calls"
+
+	// FinishReasonContentFilter indicates output was withheld by a content filter.
+	FinishReasonContentFilter FinishReason = "content_filter"
+)
+
+// Normalize maps provider-specific finish reasons onto the exported constants.
+// Recognizes OpenAI, Anthropic, and Gemini vocabularies case-insensitively.
+// Unrecognized values are returned lowercased and unchanged otherwise.
+func (f FinishReason) Normalize() FinishReason {
+	switch reason := FinishReason(strings.ToLower(string(f))); reason {
+	case "stop", "end_turn", "stop_sequence", "eos":
+		return FinishReasonStop
+	case "length", "max_tokens", "model_length":
+		return FinishReasonLength
+	case "tool_calls", "tool_use", "function_call":
+		return FinishReasonToolCalls
+	case "content_filter", "safety", "recitation", "blocklist", "prohibited_content", "refusal":
+		return FinishReasonContentFilter
+	default:
+		return reason
+	}
+}
+
+// Truncated returns true if the reason indicates output was cut off by the token limit.
+func (f FinishReason) Truncated() bool {
+	return f.Normalize() == FinishReasonLength
+}
@@ -0,0 +1,168 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GeminiCallIDPrefix prefixes the IDs ParseGeminiTools assigns to function
+// calls that Gemini returned without one.
+const GeminiCallIDPrefix = "gemini_call_"
+
+// geminiResponse is a Gemini generateContent response.
+type geminiResponse struct {
+	ResponseID   string `json:"responseId"`
+	ModelVersion string `json:"modelVersion"`
+	Candidates   []struct {
+		Index   int `json:"index"`
+		Content struct {
+			Parts []struct {
+				Text         string `json:"text"`
+				FunctionCall *struct {
+					ID   string          `json:"id"`
+					Name string          `json:"name"`
+					Args json.RawMessage `json:"args"`
+				} `json:"functionCall"`
+			} `json:"parts"`
+		} `json:"content"`
+		FinishReason      string `json:"finishReason"`
+		GroundingMetadata *struct {
+			WebSearchQueries []string `json:"webSearchQueries"`
+			GroundingChunks  []struct {
+				Web              *geminiSource `json:"web"`
+				RetrievedContext *geminiSource `json:"retrievedContext"`
+			} `json:"groundingChunks"`
+			GroundingSupports []struct {
+				Segment struct {
+					StartIndex int    `json:"startIndex"`
+					EndIndex   int    `json:"endIndex"`
+					Text       string `json:"text"`
+				} `json:"segment"`
+				GroundingChunkIndices []int `json:"groundingChunkIndices"`
+			} `json:"groundingSupports"`
+		} `json:"groundingMetadata"`
+	} `json:"candidates"`
+	UsageMetadata *struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+		TotalTokenCount      int `json:"totalTokenCount"`
+	} `json:"usageMetadata"`
+}
+
+// geminiSource is the web page or retrieved document of a grounding chunk.
+type geminiSource struct {
+	URI   string `json:"uri"`
+	Title string `json:"title"`
+}
+
+// ParseGeminiTools parses a Gemini generateContent response into a
+// ToolsResponse with one choice per candidate. Text parts are joined into the
+// message content, and functionCall parts become tool calls whose arguments
+// are the call's JSON args. Gemini reports STOP when it requests function
+// calls, so candidates with calls finish with FinishReasonToolCalls.
+// Google Search grounding metadata becomes the choice's Grounding, and its
+// grounding supports become citations, one per supporting chunk, spanning
+// the supported segment's byte offsets.
+// Calls without an ID are given one (GeminiCallIDPrefix and the call's
+// position) so tool results can be matched to them; such IDs are not sent
+// back to Gemini.
+// The original payload is retained and available through Raw.
+func ParseGeminiTools(body []byte) (*ToolsResponse, error) {
+	n, err := normalizeGemini(body)
+	if err != nil {
+		return nil, err
+	}
+	return n.tools(), nil
+}
+
+// ParseGeminiChat parses a Gemini generateContent response into a
+// ChatResponse, decoded as by ParseGeminiTools.
+func ParseGeminiChat(body []byte) (*ChatResponse, error) {
+	n, err := normalizeGemini(body)
+	if err != nil {
+		return nil, err
+	}
+	return n.chat(), nil
+}
+
+// normalizeGemini decodes a Gemini generateContent response.
+func normalizeGemini(body []byte) (*normalized, error) {
+	var resp geminiResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse gemini response: %w", err)
+	}
+
+	n := &normalized{
+		id:      resp.ResponseID,
+		model:   resp.ModelVersion,
+		choices: make([]normalizedChoice, len(resp.Candidates)),
+		raw:     body,
+	}
+
+	for i, candidate := range resp.Candidates {
+		choice := normalizedChoice{index: candidate.Index, finish: FinishReason(candidate.FinishReason)}
+
+		var text []string
+		for _, part := range candidate.Content.Parts {
+			if part.FunctionCall == nil {
+				text = append(text, part.Text)
+				continue
+			}
+			id := part.FunctionCall.ID
+			if id == "" {
+				id = GeminiCallIDPrefix + strconv.Itoa(len(choice.calls))
+			}
+			arguments := string(part.FunctionCall.Args)
+			if arguments == "" || arguments == "null" {
+				arguments = "{}"
+			}
+			choice.calls = append(choice.calls, ToolCall{
+				ID:       id,
+				Type:     "function",
+				Function: ToolCallFunction{Name: part.FunctionCall.Name, Arguments: arguments},
+			})
+		}
+		choice.text = strings.Join(text, "")
+
+		if metadata := candidate.GroundingMetadata; metadata != nil {
+			choice.grounding = &Grounding{Queries: metadata.WebSearchQueries}
+			for _, chunk := range metadata.GroundingChunks {
+				if chunk.Web != nil {
+					choice.grounding.addResult(SearchResult{URL: chunk.Web.URI, Title: chunk.Web.Title})
+				}
+			}
+
+			for _, support := range metadata.GroundingSupports {
+				for _, index := range support.GroundingChunkIndices {
+					if index < 0 || index >= len(metadata.GroundingChunks) {
+						continue
+					}
+					citation := Citation{
+						Quote: support.Segment.Text,
+						Start: support.Segment.StartIndex,
+						End:   support.Segment.EndIndex,
+					}
+					chunk := metadata.GroundingChunks[index]
+					switch {
+					case chunk.Web != nil:
+						citation.Type, citation.URL, citation.Title = CitationURL, chunk.Web.URI, chunk.Web.Title
+					case chunk.RetrievedContext != nil:
+						citation.Type, citation.URL, citation.Title = CitationFile, chunk.RetrievedContext.URI, chunk.RetrievedContext.Title
+					default:
+						continue
+					}
+					choice.citations = append(choice.citations, citation)
+				}
+			}
+		}
+
+		n.choices[i] = choice
+	}
+
+	if usage := resp.UsageMetadata; usage != nil {
+		n.usage = normalizedUsage(usage.PromptTokenCount, usage.CandidatesTokenCount, usage.TotalTokenCount)
+	}
+	return n, nil
+}
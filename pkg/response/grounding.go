@@ -0,0 +1,30 @@
+package response
+
+// Grounding describes a provider-side web search run while generating a
+// choice (see options.WebSearch): the queries the model issued and the pages
+// they returned.
+type Grounding struct {
+	Queries []string       `json:"queries,omitempty"`
+	Results []SearchResult `json:"results,omitempty"`
+}
+
+// SearchResult is a web page returned by a provider-side search.
+type SearchResult struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+// addResult appends a result unless its URL was already seen.
+func (g *Grounding) addResult(result SearchResult) {
+	for _, existing := range g.Results {
+		if existing.URL == result.URL {
+			return
+		}
+	}
+	g.Results = append(g.Results, result)
+}
+
+// empty reports whether the grounding holds no queries or results.
+func (g *Grounding) empty() bool {
+	return len(g.Queries) == 0 && len(g.Results) == 0
+}
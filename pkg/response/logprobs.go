@@ -0,0 +1,51 @@
+package response
+
+import "math"
+
+// Logprobs contains token log probability information for a choice.
+// Populated when the request enables the logprobs option.
+type Logprobs struct {
+	Content []TokenLogprob `json:"content"`
+	Refusal []TokenLogprob `json:"refusal,omitempty"`
+}
+
+// TokenLogprob is the log probability of a single generated token.
+// TopLogprobs lists the most likely alternatives at the token position
+// when the request sets top_logprobs.
+type TokenLogprob struct {
+	Token       string       `json:"token"`
+	Logprob     float64      `json:"logprob"`
+	Bytes       []int        `json:"bytes,omitempty"`
+	TopLogprobs []TopLogprob `json:"top_logprobs,omitempty"`
+}
+
+// TopLogprob is a candidate token and its log probability at a token position.
+type TopLogprob struct {
+	Token   string  `json:"token"`
+	Logprob float64 `json:"logprob"`
+	Bytes   []int   `json:"bytes,omitempty"`
+}
+
+// Sum returns the total log probability of the generated content tokens.
+// Useful for ranking candidate completions by sequence likelihood.
+func (l *Logprobs) Sum() float64 {
+	if l == nil {
+		return 0
+	}
+
+	var sum float64
+	for _, token := range l.Content {
+		sum += token.Logprob
+	}
+	return sum
+}
+
+// Perplexity returns the perplexity of the generated content tokens.
+// Lower values indicate higher model confidence.
+// Returns 0 if there are no content tokens.
+func (l *Logprobs) Perplexity() float64 {
+	if l == nil || len(l.Content) == 0 {
+		return 0
+	}
+	return math.Exp(-l.Sum() / float64(len(l.Content)))
+}
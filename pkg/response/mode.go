@@ -0,0 +1,217 @@
+package response
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+// ParseMode controls how strictly response payloads are validated during parsing.
+type ParseMode string
+
+const (
+	// ParseLenient ignores unknown fields and accepts structurally empty responses.
+	// This is the default and is appropriate for production use.
+	ParseLenient ParseMode = "lenient"
+
+	// ParseStrict rejects payloads missing required fields (empty choices or
+	// data, messages without a role or content) and malformed entries with a
+	// detailed ParseError. Fields the response types do not model are ignored,
+	// since providers add them routinely (e.g., "refusal"). Intended for tests
+	// and CI, where provider drift should fail loudly.
+	ParseStrict ParseMode = "strict"
+)
+
+// IsValid returns true if the mode is ParseLenient or ParseStrict.
+func (m ParseMode) IsValid() bool {
+	return m == ParseLenient || m == ParseStrict
+}
+
+var defaultParseMode atomic.Value
+
+func init() {
+	defaultParseMode.Store(ParseLenient)
+}
+
+// SetDefaultParseMode sets the package-level parse mode used when no mode is
+// carried by the context. Invalid modes are ignored.
+// Thread-safe for concurrent access.
+func SetDefaultParseMode(mode ParseMode) {
+	if mode.IsValid() {
+		defaultParseMode.Store(mode)
+	}
+}
+
+// DefaultParseMode returns the package-level parse mode.
+func DefaultParseMode() ParseMode {
+	return defaultParseMode.Load().(ParseMode)
+}
+
+type parseModeKey struct{}
+
+// WithParseMode returns a context that carries the given parse mode.
+// Providers parse responses using the mode carried by the request context.
+func WithParseMode(ctx context.Context, mode ParseMode) context.Context {
+	return context.WithValue(ctx, parseModeKey{}, mode)
+}
+
+// ParseModeFromContext returns the parse mode carried by ctx,
+// falling back to DefaultParseMode when none is set.
+func ParseModeFromContext(ctx context.Context) ParseMode {
+	if mode, ok := ctx.Value(parseModeKey{}).(ParseMode); ok && mode.IsValid() {
+		return mode
+	}
+	return DefaultParseMode()
+}
+
+// ParseError describes why a payload failed strict parsing.
+// Issues lists every problem found so a single run surfaces all of them.
+type ParseError struct {
+	Protocol protocol.Protocol
+	Issues   []string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("strict parse of %s response failed: %s", e.Protocol, strings.Join(e.Issues, "; "))
+}
+
+// ParseWithMode parses a response based on protocol type using the given mode.
+// In strict mode, returns a *ParseError describing missing required fields
+// and malformed entries.
+// Returns an error if mode is not ParseLenient or ParseStrict.
+func ParseWithMode(mode ParseMode, p protocol.Protocol, body []byte) (any, error) {
+	switch mode {
+	case ParseLenient:
+		return Parse(p, body)
+	case ParseStrict:
+	default:
+		return nil, fmt.Errorf("unknown parse mode %q", mode)
+	}
+
+	switch p {
+	case protocol.Chat, protocol.Vision:
+		var resp ChatResponse
+		if err := decodeStrict(p, body, &resp); err != nil {
+			return nil, err
+		}
+		if err := validateChat(p, &resp); err != nil {
+			return nil, err
+		}
+		resp.raw = body
+		return &resp, nil
+	case protocol.Tools:
+		var resp ToolsResponse
+		if err := decodeStrict(p, body, &resp); err != nil {
+			return nil, err
+		}
+		if err := validateTools(&resp); err != nil {
+			return nil, err
+		}
+		resp.raw = body
+		return &resp, nil
+	case protocol.Embeddings:
+		var resp EmbeddingsResponse
+		if err := decodeStrict(p, body, &resp); err != nil {
+			return nil, err
+		}
+		if err := validateEmbeddings(&resp); err != nil {
+			return nil, err
+		}
+		resp.raw = body
+		return &resp, nil
+	default:
+		return nil, fmt.Errorf("unsupported protocol: %s", p)
+	}
+}
+
+// ParseContext parses a response using the parse mode carried by ctx.
+func ParseContext(ctx context.Context, p protocol.Protocol, body []byte) (any, error) {
+	return ParseWithMode(ParseModeFromContext(ctx), p, body)
+}
+
+// decodeStrict decodes body into target, rejecting type mismatches and
+// trailing data.
+func decodeStrict(p protocol.Protocol, body []byte, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+
+	if err := decoder.Decode(target); err != nil {
+		return &ParseError{Protocol: p, Issues: []string{err.Error()}}
+	}
+
+	if decoder.More() {
+		return &ParseError{Protocol: p, Issues: []string{"unexpected data after JSON payload"}}
+	}
+
+	return nil
+}
+
+func validateChat(p protocol.Protocol, resp *ChatResponse) error {
+	var issues []string
+
+	if len(resp.Choices) == 0 {
+		issues = append(issues, "choices is empty")
+	}
+
+	for i, choice := range resp.Choices {
+		if choice.Message.Role == "" {
+			issues = append(issues, fmt.Sprintf("choices[%d].message.role is empty", i))
+		}
+		if choice.Message.Content == nil && len(choice.Message.ToolCalls) == 0 {
+			issues = append(issues, fmt.Sprintf("choices[%d].message has neither content nor tool_calls", i))
+		}
+	}
+
+	return issuesError(p, issues)
+}
+
+func validateTools(resp *ToolsResponse) error {
+	var issues []string
+
+	if len(resp.Choices) == 0 {
+		issues = append(issues, "choices is empty")
+	}
+
+	for i, choice := range resp.Choices {
+		if choice.Message.Role == "" {
+			issues = append(issues, fmt.Sprintf("choices[%d].message.role is empty", i))
+		}
+		for j, call := range choice.Message.ToolCalls {
+			if call.Function.Name == "" {
+				issues = append(issues, fmt.Sprintf("choices[%d].message.tool_calls[%d].function.name is empty", i, j))
+			}
+			if call.Function.Arguments != "" && !json.Valid([]byte(call.Function.Arguments)) {
+				issues = append(issues, fmt.Sprintf("choices[%d].message.tool_calls[%d].function.arguments is not valid JSON", i, j))
+			}
+		}
+	}
+
+	return issuesError(protocol.Tools, issues)
+}
+
+func validateEmbeddings(resp *EmbeddingsResponse) error {
+	var issues []string
+
+	if len(resp.Data) == 0 {
+		issues = append(issues, "data is empty")
+	}
+
+	for i, data := range resp.Data {
+		if len(data.Embedding) == 0 {
+			issues = append(issues, fmt.Sprintf("data[%d].embedding is empty", i))
+		}
+	}
+
+	return issuesError(protocol.Embeddings, issues)
+}
+
+func issuesError(p protocol.Protocol, issues []string) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	return &ParseError{Protocol: p, Issues: issues}
+}
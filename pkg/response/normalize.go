@@ -0,0 +1,101 @@
+package response
+
+import "github.com/tailored-agentic-units/tau-core/pkg/protocol"
+
+// normalized is the provider-neutral form that non-OpenAI parsers decode
+// into before building a ChatResponse or ToolsResponse, so every provider's
+// responses are shaped the same way and answer the same accessors alike.
+type normalized struct {
+	id      string
+	model   string
+	choices []normalizedChoice
+	usage   *TokenUsage
+	raw     []byte
+}
+
+// normalizedChoice is one candidate completion. Finish is the provider's
+// reason, left for FinishReason.Normalize to map, except that a choice with
+// tool calls that reports a plain stop is recorded as FinishReasonToolCalls.
+type normalizedChoice struct {
+	index     int
+	text      string
+	calls     []ToolCall
+	finish    FinishReason
+	grounding *Grounding
+	citations []Citation
+}
+
+// normalizedUsage returns token usage with the total derived from the prompt
+// and completion counts when the provider does not report one.
+func normalizedUsage(prompt, completion, total int) *TokenUsage {
+	if total == 0 {
+		total = prompt + completion
+	}
+	return &TokenUsage{PromptTokens: prompt, CompletionTokens: completion, TotalTokens: total}
+}
+
+// finishReason returns the choice's finish reason, reporting tool calls for
+// choices that stopped to call tools.
+func (c normalizedChoice) finishReason() FinishReason {
+	if len(c.calls) > 0 && c.finish.Normalize() == FinishReasonStop {
+		return FinishReasonToolCalls
+	}
+	return c.finish
+}
+
+// grounded returns the choice's grounding, or nil if it is empty.
+func (c normalizedChoice) grounded() *Grounding {
+	if c.grounding == nil || c.grounding.empty() {
+		return nil
+	}
+	return c.grounding
+}
+
+// tools builds a ToolsResponse.
+func (n *normalized) tools() *ToolsResponse {
+	resp := &ToolsResponse{
+		ID:      n.id,
+		Model:   n.model,
+		Choices: make([]ToolsChoice, len(n.choices)),
+		Usage:   n.usage,
+		raw:     n.raw,
+	}
+	for i, c := range n.choices {
+		resp.Choices[i] = ToolsChoice{
+			Index: c.index,
+			Message: ToolsMessage{
+				Role:      "assistant",
+				Content:   c.text,
+				ToolCalls: c.calls,
+			},
+			FinishReason: c.finishReason(),
+			Grounding:    c.grounded(),
+			Citations:    c.citations,
+		}
+	}
+	return resp
+}
+
+// chat builds a ChatResponse. Tool calls, which chat requests do not
+// normally produce, are kept on the message.
+func (n *normalized) chat() *ChatResponse {
+	resp := &ChatResponse{
+		ID:      n.id,
+		Model:   n.model,
+		Choices: make([]ChatChoice, len(n.choices)),
+		Usage:   n.usage,
+		raw:     n.raw,
+	}
+	for i, c := range n.choices {
+		message := protocol.NewMessage("assistant", c.text)
+		message.ToolCalls = c.calls
+		resp.Choices[i] = ChatChoice{
+			Index:        c.index,
+			Message:      message,
+			FinishReason: c.finishReason(),
+			Grounding:    c.grounded(),
+			Citations:    c.citations,
+		}
+	}
+	return resp
+}
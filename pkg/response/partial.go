@@ -0,0 +1,310 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// JSONAccumulator incrementally collects streamed JSON output and decodes
+// best-effort partial values before the document is complete.
+// Use with JSON-mode or structured output streams to render results progressively.
+//
+// Example:
+//
+//	var acc response.JSONAccumulator
+//	for chunk := range chunks {
+//	    acc.WriteString(chunk.Content())
+//	    var partial Report
+//	    if acc.Partial(&partial) {
+//	        render(partial)
+//	    }
+//	}
+//	var report Report
+//	if err := acc.Final(&report); err != nil {
+//	    log.Fatal(err)
+//	}
+type JSONAccumulator struct {
+	buf strings.Builder
+}
+
+// Write appends streamed bytes to the accumulated document.
+// Implements io.Writer so the accumulator can be a stream.ToWriter target.
+func (a *JSONAccumulator) Write(p []byte) (int, error) {
+	return a.buf.Write(p)
+}
+
+// WriteString appends streamed text to the accumulated document.
+func (a *JSONAccumulator) WriteString(s string) (int, error) {
+	return a.buf.WriteString(s)
+}
+
+// String returns the accumulated raw text.
+func (a *JSONAccumulator) String() string {
+	return a.buf.String()
+}
+
+// Partial decodes the best-effort completion of the accumulated text into target.
+// Incomplete strings are closed, incomplete keys and literals are dropped, and
+// open objects and arrays are closed. Returns false if nothing decodable has
+// arrived yet.
+func (a *JSONAccumulator) Partial(target any) bool {
+	completed, ok := CompleteJSON(a.buf.String())
+	if !ok {
+		return false
+	}
+	return json.Unmarshal([]byte(completed), target) == nil
+}
+
+// Final decodes the complete accumulated document into target.
+// Surrounding markdown code fences are tolerated; the JSON itself must be valid.
+func (a *JSONAccumulator) Final(target any) error {
+	text := trimFences(a.buf.String())
+	if err := json.Unmarshal([]byte(text), target); err != nil {
+		return fmt.Errorf("failed to parse structured output: %w", err)
+	}
+	return nil
+}
+
+// ParsePartialJSON decodes the best-effort completion of a JSON prefix.
+// Returns false if the prefix contains no decodable value.
+func ParsePartialJSON(data string) (any, bool) {
+	completed, ok := CompleteJSON(data)
+	if !ok {
+		return nil, false
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(completed), &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// CompleteJSON returns the longest valid JSON document derivable from a prefix
+// of a streamed object or array. Text before the first '{' or '[' is ignored.
+// Returns false if no valid completion exists yet.
+func CompleteJSON(data string) (string, bool) {
+	start := strings.IndexAny(data, "{[")
+	if start < 0 {
+		return "", false
+	}
+	data = data[start:]
+
+	s := scanJSONPrefix(data)
+
+	// Prefer keeping an in-progress string value.
+	if s.inString {
+		prefix := data[:s.stringTrim]
+		if candidate := prefix + `"` + s.closers(); json.Valid([]byte(candidate)) {
+			return candidate, true
+		}
+	}
+
+	for i := len(s.cuts) - 1; i >= 0; i-- {
+		cut := s.cuts[i]
+		if candidate := data[:cut.pos] + cut.closers; json.Valid([]byte(candidate)) {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// jsonCut is a prefix position that can be completed by closing open containers.
+type jsonCut struct {
+	pos     int
+	closers string
+}
+
+// jsonScan records the structural state of a scanned JSON prefix.
+type jsonScan struct {
+	stack      []byte
+	inString   bool
+	stringTrim int
+	cuts       []jsonCut
+}
+
+func (s *jsonScan) closers() string {
+	closers := make([]byte, 0, len(s.stack))
+	for _, open := range slices.Backward(s.stack) {
+		if open == '{' {
+			closers = append(closers, '}')
+		} else {
+			closers = append(closers, ']')
+		}
+	}
+	return string(closers)
+}
+
+func (s *jsonScan) cut(pos int) {
+	s.cuts = append(s.cuts, jsonCut{pos: pos, closers: s.closers()})
+}
+
+// scanJSONPrefix walks a JSON prefix recording positions where a complete
+// value or container opening ends, and the string state at the end of input.
+func scanJSONPrefix(data string) *jsonScan {
+	s := &jsonScan{}
+	escaped := false
+	unicodeRemaining := 0
+	inLiteral := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if s.inString {
+			switch {
+			case unicodeRemaining > 0:
+				unicodeRemaining--
+			case escaped:
+				escaped = false
+				if c == 'u' {
+					unicodeRemaining = 4
+				}
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				s.inString = false
+				s.cut(i + 1)
+			}
+
+			// Track where an in-progress string can be safely closed,
+			// excluding any incomplete escape sequence.
+			if s.inString && !escaped && unicodeRemaining == 0 {
+				s.stringTrim = i + 1
+			}
+			continue
+		}
+
+		if inLiteral && !isLiteralByte(c) {
+			inLiteral = false
+			s.cut(i)
+		}
+
+		switch c {
+		case '"':
+			s.inString = true
+			s.stringTrim = i + 1
+		case '{', '[':
+			s.stack = append(s.stack, c)
+			s.cut(i + 1)
+		case '}', ']':
+			if len(s.stack) > 0 {
+				s.stack = s.stack[:len(s.stack)-1]
+			}
+			s.cut(i + 1)
+		default:
+			if isLiteralByte(c) {
+				inLiteral = true
+			}
+		}
+	}
+
+	return s
+}
+
+// isLiteralByte reports whether c can appear in a number or true/false/null literal.
+func isLiteralByte(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || c == '-' || c == '+' || c == '.' || c == 'E'
+}
+
+// trimFences removes surrounding whitespace and markdown code fences.
+func trimFences(text string) string {
+	text = strings.TrimSpace(text)
+	if rest, ok := strings.CutPrefix(text, "```"); ok {
+		if newline := strings.IndexByte(rest, '\n'); newline >= 0 {
+			rest = rest[newline+1:]
+		}
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(rest), "```"))
+	}
+	return text
+}
+
+// PartialValue is a decoded snapshot of streamed structured output.
+// Final is true for the last value, which was decoded from the complete
+// document rather than a best-effort completion.
+type PartialValue[T any] struct {
+	Value T
+	Final bool
+	Err   error
+}
+
+// StreamJSON decodes streamed structured output into progressively more
+// complete values of type T. A value is emitted whenever a chunk changes the
+// best-effort decoding, followed by a final validated value when the stream
+// closes. Chunk errors, cancellation, and an invalid final document are
+// reported through Err on the last emitted value; on cancellation Err is
+// ctx.Err(), delivered even if the consumer has stopped reading.
+func StreamJSON[T any](ctx context.Context, chunks <-chan *StreamingChunk) <-chan PartialValue[T] {
+	// One slot of buffer holds the cancellation value for a consumer that is
+	// not receiving when the context ends
+	output := make(chan PartialValue[T], 1)
+
+	go func() {
+		defer close(output)
+
+		var acc JSONAccumulator
+		var last string
+
+		cancelled := func() {
+			// Replace an unread value so the cancellation is always the last
+			select {
+			case <-output:
+			default:
+			}
+			output <- PartialValue[T]{Err: ctx.Err()}
+		}
+
+		emit := func(v PartialValue[T]) bool {
+			select {
+			case output <- v:
+				return true
+			case <-ctx.Done():
+				cancelled()
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				cancelled()
+				return
+			case chunk, ok := <-chunks:
+				if !ok {
+					var value T
+					err := acc.Final(&value)
+					emit(PartialValue[T]{Value: value, Final: true, Err: err})
+					return
+				}
+				if chunk == nil {
+					continue
+				}
+				if chunk.Error != nil {
+					emit(PartialValue[T]{Err: fmt.Errorf("stream error: %w", chunk.Error)})
+					return
+				}
+
+				acc.WriteString(chunk.Content())
+
+				completed, ok := CompleteJSON(acc.String())
+				if !ok || completed == last {
+					continue
+				}
+				last = completed
+
+				var value T
+				if err := json.Unmarshal([]byte(completed), &value); err != nil {
+					continue
+				}
+				if !emit(PartialValue[T]{Value: value}) {
+					return
+				}
+			}
+		}
+	}()
+
+	return output
+}
@@ -0,0 +1,90 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// StreamSSE re-streams chunks to an HTTP client as OpenAI-style server-sent
+// events. Each chunk is written as a "data:" event and flushed immediately,
+// and the stream is terminated with "data: [DONE]" when the channel closes.
+//
+// Heartbeat chunks are written as SSE comments, keeping the client's
+// connection alive while the upstream stream is idle.
+// A chunk error is sent as a final error event ({"error": {"message": ...}})
+// and returned. If the client disconnects, the write error is returned and the
+// remaining chunks are drained in the background so the producer is not blocked;
+// cancel the producer's context to stop it early.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    chunks, err := agent.ChatStream(r.Context(), prompt)
+//	    if err != nil {
+//	        http.Error(w, err.Error(), http.StatusBadGateway)
+//	        return
+//	    }
+//	    response.StreamSSE(w, chunks)
+//	}
+func StreamSSE(w http.ResponseWriter, chunks <-chan *StreamingChunk) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	send := func(data []byte) error {
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return fmt.Errorf("failed to write event: %w", err)
+		}
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return fmt.Errorf("failed to flush event: %w", err)
+		}
+		return nil
+	}
+
+	fail := func(err error) error {
+		go func() {
+			for range chunks {
+			}
+		}()
+		return err
+	}
+
+	for chunk := range chunks {
+		if chunk == nil {
+			continue
+		}
+
+		if chunk.Error != nil {
+			event, _ := json.Marshal(map[string]any{
+				"error": map[string]any{"message": chunk.Error.Error()},
+			})
+			send(event)
+			return fail(fmt.Errorf("stream error: %w", chunk.Error))
+		}
+
+		if chunk.Heartbeat {
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return fail(fmt.Errorf("failed to write heartbeat: %w", err))
+			}
+			if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				return fail(fmt.Errorf("failed to flush heartbeat: %w", err))
+			}
+			continue
+		}
+
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			return fail(fmt.Errorf("failed to marshal chunk: %w", err))
+		}
+
+		if err := send(data); err != nil {
+			return fail(err)
+		}
+	}
+
+	return send([]byte("[DONE]"))
+}
@@ -3,25 +3,61 @@ package response
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // StreamingChunk represents a single chunk from a streaming protocol response.
 // Each chunk contains incremental content in the Delta field and metadata.
 // The Error field can be set during streaming to indicate processing errors.
 type StreamingChunk struct {
-	ID      string `json:"id,omitempty"`
-	Object  string `json:"object,omitempty"`
-	Created int64  `json:"created,omitempty"`
-	Model   string `json:"model"`
-	Choices []struct {
-		Index int `json:"index"`
-		Delta struct {
-			Role    string `json:"role,omitempty"`
-			Content string `json:"content,omitempty"`
-		} `json:"delta"`
-		FinishReason *string `json:"finish_reason"`
-	} `json:"choices"`
-	Error error `json:"-"`
+	ID                string            `json:"id,omitempty"`
+	Object            string            `json:"object,omitempty"`
+	Created           int64             `json:"created,omitempty"`
+	Model             string            `json:"model"`
+	SystemFingerprint string            `json:"system_fingerprint,omitempty"`
+	Choices           []StreamingChoice `json:"choices"`
+	Usage             *TokenUsage       `json:"usage,omitempty"`
+
+	// PromptFilterResults is reported by Azure OpenAI.
+	PromptFilterResults []PromptFilterResult `json:"prompt_filter_results,omitempty"`
+	Error               error                `json:"-"`
+
+	// Heartbeat marks a synthetic chunk emitted while the stream is idle;
+	// it carries no choices. Idle is the time since data was last received.
+	Heartbeat bool          `json:"-"`
+	Idle      time.Duration `json:"-"`
+
+	raw []byte
+}
+
+// StreamingChoice represents a single choice within a streaming chunk.
+// FinishReason is nil until the final chunk for the choice.
+type StreamingChoice struct {
+	Index        int            `json:"index"`
+	Delta        StreamingDelta `json:"delta"`
+	FinishReason *FinishReason  `json:"finish_reason"`
+	Logprobs     *Logprobs      `json:"logprobs,omitempty"`
+
+	// ContentFilterResults is reported by Azure OpenAI.
+	ContentFilterResults ContentFilterResults `json:"content_filter_results,omitempty"`
+}
+
+// StreamingDelta contains the incremental message content for a choice.
+// Tool calls arrive as fragments keyed by index that must be merged across chunks.
+type StreamingDelta struct {
+	Role      string          `json:"role,omitempty"`
+	Content   string          `json:"content,omitempty"`
+	ToolCalls []ToolCallDelta `json:"tool_calls,omitempty"`
+}
+
+// ToolCallDelta represents a fragment of a tool call within a streaming delta.
+// The first fragment for an index carries the ID, type, and function name;
+// subsequent fragments append to the function arguments.
+type ToolCallDelta struct {
+	Index    int              `json:"index"`
+	ID       string           `json:"id,omitempty"`
+	Type     string           `json:"type,omitempty"`
+	Function ToolCallFunction `json:"function"`
 }
 
 // Content extracts the incremental content from the delta in the first choice.
@@ -33,12 +69,41 @@ func (c *StreamingChunk) Content() string {
 	return ""
 }
 
+// Choice returns the choice with the given index, for streams generating
+// several choices (n > 1) whose chunks may carry any of them.
+// Returns nil if the chunk carries no choice with that index.
+func (c *StreamingChunk) Choice(index int) *StreamingChoice {
+	for i := range c.Choices {
+		if c.Choices[i].Index == index {
+			return &c.Choices[i]
+		}
+	}
+	return nil
+}
+
+// FinishReason returns the normalized finish reason of the first choice.
+// Returns an empty FinishReason until the final chunk of the stream.
+func (c *StreamingChunk) FinishReason() FinishReason {
+	if len(c.Choices) > 0 && c.Choices[0].FinishReason != nil {
+		return c.Choices[0].FinishReason.Normalize()
+	}
+	return ""
+}
+
+// Raw returns the original JSON payload the chunk was parsed from.
+// Returns nil for chunks that were not produced by a parser.
+func (c *StreamingChunk) Raw() []byte {
+	return c.raw
+}
+
 // ParseChatStreamChunk parses a streaming chat chunk from JSON bytes.
+// The original payload is retained and available through Raw.
 func ParseChatStreamChunk(data []byte) (*StreamingChunk, error) {
 	var chunk StreamingChunk
 	if err := json.Unmarshal(data, &chunk); err != nil {
 		return nil, fmt.Errorf("failed to parse streaming chunk: %w", err)
 	}
+	chunk.raw = data
 	return &chunk, nil
 }
 
@@ -55,5 +120,22 @@ func ParseToolsStreamChunk(data []byte) (*StreamingChunk, error) {
 	if err := json.Unmarshal(data, &chunk); err != nil {
 		return nil, fmt.Errorf("failed to parse tools streaming chunk: %w", err)
 	}
+	chunk.raw = data
 	return &chunk, nil
 }
+
+// MalformedChunkError is the chunk error for a streaming event that could not
+// be parsed, surfaced in strict parse mode. Payload is the event data as
+// received.
+type MalformedChunkError struct {
+	Payload []byte
+	Err     error
+}
+
+func (e *MalformedChunkError) Error() string {
+	return fmt.Sprintf("malformed stream chunk: %v", e.Err)
+}
+
+func (e *MalformedChunkError) Unwrap() error {
+	return e.Err
+}
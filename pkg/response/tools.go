@@ -3,48 +3,101 @@ package response
 import (
 	"encoding/json"
 	"fmt"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
 )
 
 // ToolsResponse represents the response from a tools (function calling) protocol request.
 // Contains function calls requested by the model along with metadata and token usage.
 type ToolsResponse struct {
-	ID      string `json:"id,omitempty"`
-	Object  string `json:"object,omitempty"`
-	Created int64  `json:"created,omitempty"`
-	Model   string `json:"model"`
-	Choices []struct {
-		Index   int `json:"index"`
-		Message struct {
-			Role      string     `json:"role"`
-			Content   string     `json:"content"`
-			ToolCalls []ToolCall `json:"tool_calls,omitempty"`
-		} `json:"message"`
-		FinishReason string `json:"finish_reason,omitempty"`
-	} `json:"choices"`
-	Usage *TokenUsage `json:"usage,omitempty"`
+	ID                string        `json:"id,omitempty"`
+	Object            string        `json:"object,omitempty"`
+	Created           int64         `json:"created,omitempty"`
+	Model             string        `json:"model"`
+	SystemFingerprint string        `json:"system_fingerprint,omitempty"`
+	Choices           []ToolsChoice `json:"choices"`
+	Usage             *TokenUsage   `json:"usage,omitempty"`
+
+	// PromptFilterResults is reported by Azure OpenAI.
+	PromptFilterResults []PromptFilterResult `json:"prompt_filter_results,omitempty"`
+
+	raw []byte
 }
 
-// ToolCall represents a function call requested by the model.
-// Contains the call ID, type, and function details.
-type ToolCall struct {
-	ID       string           `json:"id"`
-	Type     string           `json:"type"`
-	Function ToolCallFunction `json:"function"`
+// ToolsChoice represents a single completion choice in a tools response.
+type ToolsChoice struct {
+	Index        int          `json:"index"`
+	Message      ToolsMessage `json:"message"`
+	FinishReason FinishReason `json:"finish_reason,omitempty"`
+
+	// Grounding is set when a provider-side web search informed the choice.
+	Grounding *Grounding `json:"grounding,omitempty"`
+
+	// Citations link spans of the content to their sources, when the
+	// provider reports them.
+	Citations []Citation `json:"citations,omitempty"`
+
+	// ContentFilterResults is reported by Azure OpenAI.
+	ContentFilterResults ContentFilterResults `json:"content_filter_results,omitempty"`
+}
+
+// ToolsMessage is the assistant message of a tools response choice.
+// Content may be empty when the model only requests tool calls.
+type ToolsMessage struct {
+	Role      string     `json:"role"`
+	Content   string     `json:"content"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
 }
 
+// Content returns the text content of the first choice, which may
+// accompany tool calls. Returns empty string if there are no choices.
+func (r *ToolsResponse) Content() string {
+	if len(r.Choices) > 0 {
+		return r.Choices[0].Message.Content
+	}
+	return ""
+}
+
+// FinishReason returns the normalized finish reason of the first choice.
+// Returns an empty FinishReason if there are no choices.
+func (r *ToolsResponse) FinishReason() FinishReason {
+	if len(r.Choices) > 0 {
+		return r.Choices[0].FinishReason.Normalize()
+	}
+	return ""
+}
+
+// ToolCalls returns the tool calls requested in the first choice.
+// Returns nil if there are no choices or no tool calls.
+func (r *ToolsResponse) ToolCalls() []ToolCall {
+	if len(r.Choices) > 0 {
+		return r.Choices[0].Message.ToolCalls
+	}
+	return nil
+}
+
+// ToolCall represents a function call requested by the model.
+// Aliased from protocol so tool calls flow between responses and messages.
+type ToolCall = protocol.ToolCall
+
 // ToolCallFunction contains the details of a function to be called.
 // Name specifies the function name, and Arguments contains JSON-encoded parameters.
-type ToolCallFunction struct {
-	Name      string `json:"name"`
-	Arguments string `json:"arguments"`
+type ToolCallFunction = protocol.ToolCallFunction
+
+// Raw returns the original JSON payload the response was parsed from.
+// Returns nil for responses that were not produced by a parser.
+func (r *ToolsResponse) Raw() []byte {
+	return r.raw
 }
 
 // ParseTools parses a tools response from JSON bytes.
+// The original payload is retained and available through Raw.
 // Returns the parsed ToolsResponse or an error if parsing fails.
 func ParseTools(body []byte) (*ToolsResponse, error) {
 	var response ToolsResponse
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse tools response: %w", err)
 	}
+	response.raw = body
 	return &response, nil
 }
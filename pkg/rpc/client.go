@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Client consumes a remote AgentService with the same method signatures as
+// agent.Agent, returning the standard response types. It implements
+// agent.Chatter and agent.Embedder, so code that depends only on those
+// capabilities works with local and remote agents alike.
+type Client struct {
+	service AgentServiceClient
+}
+
+var (
+	_ agent.Chatter  = (*Client)(nil)
+	_ agent.Embedder = (*Client)(nil)
+)
+
+// NewClient creates a Client over an established gRPC connection.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{service: NewAgentServiceClient(conn)}
+}
+
+// Chat executes a chat request on the remote agent.
+func (c *Client) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
+	options, err := firstOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.chat(ctx, &ChatRequest{Prompt: prompt, Options: options})
+}
+
+// ChatMessages executes a chat request for a conversation on the remote
+// agent. The messages are sent as given, including any system message, in
+// place of the agent's system prompt. Message content that is not a string
+// is sent as its JSON encoding.
+func (c *Client) ChatMessages(ctx context.Context, messages []protocol.Message, opts ...map[string]any) (*response.ChatResponse, error) {
+	req, err := conversationRequest(messages, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.chat(ctx, req)
+}
+
+func (c *Client) chat(ctx context.Context, req *ChatRequest) (*response.ChatResponse, error) {
+	resp, err := c.service.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return fromChatResponse(resp), nil
+}
+
+// ChatStream executes a streaming chat request on the remote agent.
+// Receive errors are delivered as a final chunk with Error set.
+func (c *Client) ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	options, err := firstOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.chatStream(ctx, &ChatRequest{Prompt: prompt, Options: options})
+}
+
+// ChatMessagesStream executes a streaming chat request for a conversation on
+// the remote agent, sending messages as ChatMessages does.
+func (c *Client) ChatMessagesStream(ctx context.Context, messages []protocol.Message, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	req, err := conversationRequest(messages, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.chatStream(ctx, req)
+}
+
+func (c *Client) chatStream(ctx context.Context, req *ChatRequest) (<-chan *response.StreamingChunk, error) {
+	stream, err := c.service.ChatStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make(chan *response.StreamingChunk)
+
+	go func() {
+		defer close(output)
+
+		for {
+			chunk, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			next := &response.StreamingChunk{Error: err}
+			if err == nil {
+				next = fromChatChunk(chunk)
+			}
+
+			select {
+			case output <- next:
+			case <-ctx.Done():
+				return
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return output, nil
+}
+
+// Tools executes a tools request on the remote agent.
+func (c *Client) Tools(ctx context.Context, prompt string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	options, err := firstOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make([]*Tool, len(tools))
+	for i, tool := range tools {
+		params, err := toStruct(tool.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameters for tool %s: %w", tool.Name, err)
+		}
+		defs[i] = &Tool{Name: tool.Name, Description: tool.Description, Parameters: params}
+	}
+
+	resp, err := c.service.Tools(ctx, &ToolsRequest{Prompt: prompt, Tools: defs, Options: options})
+	if err != nil {
+		return nil, err
+	}
+	return fromToolsResponse(resp), nil
+}
+
+// Embed executes an embeddings request on the remote agent.
+func (c *Client) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
+	options, err := firstOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.service.Embed(ctx, &EmbedRequest{Input: input, Options: options})
+	if err != nil {
+		return nil, err
+	}
+	return fromEmbedResponse(resp), nil
+}
+
+// conversationRequest builds a ChatRequest carrying messages and options.
+// Returns an error if there are no messages.
+func conversationRequest(messages []protocol.Message, opts []map[string]any) (*ChatRequest, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("messages must not be empty")
+	}
+
+	converted, err := toMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := firstOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ChatRequest{Messages: converted, Options: options}, nil
+}
+
+// firstOptions converts the optional options argument used by agent methods.
+func firstOptions(opts []map[string]any) (*structpb.Struct, error) {
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	return toStruct(opts[0])
+}
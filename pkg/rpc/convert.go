@@ -0,0 +1,207 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// toStruct converts options to a protobuf Struct through JSON, so values such
+// as []string that structpb cannot convert directly are supported.
+// Returns nil for empty options.
+func toStruct(m map[string]any) (*structpb.Struct, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode options: %w", err)
+	}
+
+	s := &structpb.Struct{}
+	if err := s.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to encode options: %w", err)
+	}
+	return s, nil
+}
+
+// fromStruct converts a protobuf Struct to options.
+// Numbers decode as float64, matching JSON configuration.
+func fromStruct(s *structpb.Struct) []map[string]any {
+	if s == nil {
+		return nil
+	}
+	return []map[string]any{s.AsMap()}
+}
+
+// toMessages converts conversation messages, sending non-string content
+// as its JSON encoding.
+func toMessages(messages []protocol.Message) ([]*Message, error) {
+	converted := make([]*Message, len(messages))
+	for i, message := range messages {
+		content, ok := message.Content.(string)
+		if !ok && message.Content != nil {
+			data, err := json.Marshal(message.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode message %d: %w", i, err)
+			}
+			content = string(data)
+		}
+		converted[i] = &Message{Role: message.Role, Content: content}
+	}
+	return converted, nil
+}
+
+func fromMessages(messages []*Message) []protocol.Message {
+	converted := make([]protocol.Message, len(messages))
+	for i, message := range messages {
+		converted[i] = protocol.NewMessage(message.GetRole(), message.GetContent())
+	}
+	return converted
+}
+
+func toUsage(u *response.TokenUsage) *Usage {
+	if u == nil {
+		return nil
+	}
+	return &Usage{
+		PromptTokens:     int32(u.PromptTokens),
+		CompletionTokens: int32(u.CompletionTokens),
+		TotalTokens:      int32(u.TotalTokens),
+	}
+}
+
+func fromUsage(u *Usage) *response.TokenUsage {
+	if u == nil {
+		return nil
+	}
+	return &response.TokenUsage{
+		PromptTokens:     int(u.PromptTokens),
+		CompletionTokens: int(u.CompletionTokens),
+		TotalTokens:      int(u.TotalTokens),
+	}
+}
+
+func toChatResponse(r *response.ChatResponse) *ChatResponse {
+	return &ChatResponse{
+		Id:           r.ID,
+		Model:        r.Model,
+		Content:      r.Content(),
+		FinishReason: string(r.FinishReason()),
+		Usage:        toUsage(r.Usage),
+	}
+}
+
+func fromChatResponse(r *ChatResponse) *response.ChatResponse {
+	return &response.ChatResponse{
+		ID:     r.Id,
+		Object: "chat.completion",
+		Model:  r.Model,
+		Choices: []response.ChatChoice{{
+			Message:      protocol.NewMessage("assistant", r.Content),
+			FinishReason: response.FinishReason(r.FinishReason),
+		}},
+		Usage: fromUsage(r.Usage),
+	}
+}
+
+func toChatChunk(c *response.StreamingChunk) *ChatChunk {
+	return &ChatChunk{
+		Id:           c.ID,
+		Model:        c.Model,
+		Content:      c.Content(),
+		FinishReason: string(c.FinishReason()),
+		Usage:        toUsage(c.Usage),
+	}
+}
+
+func fromChatChunk(c *ChatChunk) *response.StreamingChunk {
+	choice := response.StreamingChoice{
+		Delta: response.StreamingDelta{Content: c.Content},
+	}
+	if c.FinishReason != "" {
+		reason := response.FinishReason(c.FinishReason)
+		choice.FinishReason = &reason
+	}
+
+	return &response.StreamingChunk{
+		ID:      c.Id,
+		Object:  "chat.completion.chunk",
+		Model:   c.Model,
+		Choices: []response.StreamingChoice{choice},
+		Usage:   fromUsage(c.Usage),
+	}
+}
+
+func toToolsResponse(r *response.ToolsResponse) *ToolsResponse {
+	resp := &ToolsResponse{
+		Id:           r.ID,
+		Model:        r.Model,
+		FinishReason: string(r.FinishReason()),
+		Usage:        toUsage(r.Usage),
+	}
+	if len(r.Choices) > 0 {
+		resp.Content = r.Choices[0].Message.Content
+	}
+	for _, call := range r.ToolCalls() {
+		resp.ToolCalls = append(resp.ToolCalls, &ToolCall{
+			Id:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+	return resp
+}
+
+func fromToolsResponse(r *ToolsResponse) *response.ToolsResponse {
+	message := response.ToolsMessage{Role: "assistant", Content: r.Content}
+	for _, call := range r.ToolCalls {
+		message.ToolCalls = append(message.ToolCalls, response.ToolCall{
+			ID:   call.Id,
+			Type: "function",
+			Function: response.ToolCallFunction{
+				Name:      call.Name,
+				Arguments: call.Arguments,
+			},
+		})
+	}
+
+	return &response.ToolsResponse{
+		ID:     r.Id,
+		Object: "chat.completion",
+		Model:  r.Model,
+		Choices: []response.ToolsChoice{{
+			Message:      message,
+			FinishReason: response.FinishReason(r.FinishReason),
+		}},
+		Usage: fromUsage(r.Usage),
+	}
+}
+
+func toEmbedResponse(r *response.EmbeddingsResponse) *EmbedResponse {
+	resp := &EmbedResponse{
+		Model: r.Model,
+		Usage: toUsage(r.Usage),
+	}
+	if len(r.Data) > 0 {
+		resp.Embedding = r.Data[0].Embedding
+	}
+	return resp
+}
+
+func fromEmbedResponse(r *EmbedResponse) *response.EmbeddingsResponse {
+	resp := &response.EmbeddingsResponse{
+		Object: "list",
+		Model:  r.Model,
+		Usage:  fromUsage(r.Usage),
+	}
+	resp.Data = append(resp.Data, response.EmbeddingData{
+		Embedding: r.Embedding,
+		Object:    "embedding",
+	})
+	return resp
+}
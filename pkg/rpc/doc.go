@@ -0,0 +1,47 @@
+// Package rpc provides a gRPC transport for tau-core agents, enabling services
+// written in other languages to consume an agent through the AgentService
+// definition in agent.proto.
+//
+// Serve an agent:
+//
+//	a, err := agent.New(cfg)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	gs := grpc.NewServer()
+//	rpc.RegisterAgentServiceServer(gs, rpc.NewServer(a))
+//	lis, _ := net.Listen("tcp", ":9090")
+//	gs.Serve(lis)
+//
+// Consume a remote agent with the same method signatures as agent.Agent:
+//
+//	conn, err := grpc.NewClient("localhost:9090", grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	c := rpc.NewClient(conn)
+//	resp, err := c.Chat(ctx, "Hello", map[string]any{"temperature": 0.2})
+//
+// Send a conversation, including its own system message, with ChatMessages:
+//
+//	resp, err := c.ChatMessages(ctx, []protocol.Message{
+//	    protocol.NewMessage("system", "Answer briefly."),
+//	    protocol.NewMessage("user", "What is the capital of France?"),
+//	})
+//
+// The package is a separate module so that the gRPC and protobuf
+// dependencies are not required by the root tau-core module.
+//
+// Options are carried as google.protobuf.Struct, so numbers arrive as float64
+// as they do from JSON configuration. Agent errors are returned as gRPC status
+// errors: provider HTTP statuses map to their gRPC equivalents and validation
+// failures map to InvalidArgument.
+//
+// The Go bindings in agent.pb.go and agent_grpc.pb.go are generated from
+// agent.proto; regenerate them from the repository root with:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	  --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//	  pkg/rpc/agent.proto
+package rpc
@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server adapts an agent.Agent to the AgentService gRPC service.
+// Register it with RegisterAgentServiceServer.
+type Server struct {
+	UnimplementedAgentServiceServer
+	agent agent.Agent
+}
+
+// NewServer creates a Server that executes requests with the given agent.
+func NewServer(a agent.Agent) *Server {
+	return &Server{agent: a}
+}
+
+// Chat executes a chat request through the agent. A request carrying
+// messages is sent as that conversation with the agent's client, provider,
+// and model, without the agent's system prompt.
+func (s *Server) Chat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	if len(req.GetMessages()) == 0 {
+		resp, err := s.agent.Chat(ctx, req.GetPrompt(), fromStruct(req.GetOptions())...)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return toChatResponse(resp), nil
+	}
+
+	result, err := s.agent.Client().Execute(ctx, s.conversation(req, false))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp, ok := result.(*response.ChatResponse)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unexpected response type: %T", result)
+	}
+	return toChatResponse(resp), nil
+}
+
+// ChatStream executes a streaming chat request through the agent and sends
+// each chunk to the client. A request carrying messages is streamed as that
+// conversation, as for Chat. A chunk error ends the stream with an error status.
+func (s *Server) ChatStream(req *ChatRequest, stream grpc.ServerStreamingServer[ChatChunk]) error {
+	var (
+		chunks <-chan *response.StreamingChunk
+		err    error
+	)
+	if len(req.GetMessages()) == 0 {
+		chunks, err = s.agent.ChatStream(stream.Context(), req.GetPrompt(), fromStruct(req.GetOptions())...)
+	} else {
+		chunks, err = s.agent.Client().ExecuteStream(stream.Context(), s.conversation(req, true))
+	}
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			return toStatus(chunk.Error)
+		}
+		if chunk.Heartbeat {
+			continue
+		}
+		if err := stream.Send(toChatChunk(chunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tools executes a tools request through the agent.
+func (s *Server) Tools(ctx context.Context, req *ToolsRequest) (*ToolsResponse, error) {
+	tools := make([]agent.Tool, len(req.GetTools()))
+	for i, tool := range req.GetTools() {
+		tools[i] = agent.Tool{
+			Name:        tool.GetName(),
+			Description: tool.GetDescription(),
+			Parameters:  tool.GetParameters().AsMap(),
+		}
+	}
+
+	resp, err := s.agent.Tools(ctx, req.GetPrompt(), tools, fromStruct(req.GetOptions())...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toToolsResponse(resp), nil
+}
+
+// Embed executes an embeddings request through the agent.
+func (s *Server) Embed(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+	resp, err := s.agent.Embed(ctx, req.GetInput(), fromStruct(req.GetOptions())...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toEmbedResponse(resp), nil
+}
+
+// conversation builds a chat request from the messages of req, appending its
+// prompt as a final user message when set. Request options are merged over
+// the model's configured chat options.
+func (s *Server) conversation(req *ChatRequest, stream bool) request.Request {
+	messages := fromMessages(req.GetMessages())
+	if prompt := req.GetPrompt(); prompt != "" {
+		messages = append(messages, protocol.NewMessage("user", prompt))
+	}
+
+	options := maps.Clone(s.agent.Model().Options[protocol.Chat])
+	if options == nil {
+		options = make(map[string]any)
+	}
+	for _, opts := range fromStruct(req.GetOptions()) {
+		maps.Copy(options, opts)
+	}
+	if stream {
+		options["stream"] = true
+	}
+
+	return request.NewChat(s.agent.Provider(), s.agent.Model(), messages, options)
+}
+
+// toStatus maps an agent error to a gRPC status.
+// Provider HTTP status codes map to their gRPC equivalents and validation
+// failures map to InvalidArgument.
+func toStatus(err error) error {
+	var statusErr *client.HTTPStatusError
+	switch {
+	case errors.As(err, &statusErr):
+		return status.Error(httpCode(statusErr.StatusCode), err.Error())
+	case errors.Is(err, request.ErrInvalidRequest), errors.Is(err, model.ErrInvalidOption):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// httpCode maps an HTTP status code to a gRPC code.
+func httpCode(code int) codes.Code {
+	switch code {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if code >= 500 {
+		return codes.Unavailable
+	}
+	return codes.Unknown
+}
@@ -0,0 +1,263 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// recordingAgent captures the options passed to Chat.
+type recordingAgent struct {
+	*mock.MockAgent
+	options map[string]any
+}
+
+func (a *recordingAgent) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
+	if len(opts) > 0 {
+		a.options = opts[0]
+	}
+	return a.MockAgent.Chat(ctx, prompt, opts...)
+}
+
+func newClient(t *testing.T, a agent.Agent) *rpc.Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	rpc.RegisterAgentServiceServer(gs, rpc.NewServer(a))
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return rpc.NewClient(conn)
+}
+
+func TestClient_Chat(t *testing.T) {
+	a := &recordingAgent{MockAgent: mock.NewMockAgent(mock.WithChatResponse(&response.ChatResponse{
+		ID:    "chatcmpl-1",
+		Model: "test-model",
+		Choices: []response.ChatChoice{{
+			Message:      protocol.NewMessage("assistant", "Hello"),
+			FinishReason: response.FinishReasonStop,
+		}},
+		Usage: &response.TokenUsage{PromptTokens: 3, CompletionTokens: 1, TotalTokens: 4},
+	}, nil))}
+
+	c := newClient(t, a)
+
+	resp, err := c.Chat(context.Background(), "Hi", map[string]any{"temperature": 0.2, "stop": []string{"\n"}})
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	if resp.Content() != "Hello" {
+		t.Errorf("got content %q, want %q", resp.Content(), "Hello")
+	}
+
+	if resp.FinishReason() != response.FinishReasonStop {
+		t.Errorf("got finish reason %q, want %q", resp.FinishReason(), response.FinishReasonStop)
+	}
+
+	if resp.Usage == nil || resp.Usage.TotalTokens != 4 {
+		t.Errorf("got usage %+v, want total tokens 4", resp.Usage)
+	}
+
+	if a.options["temperature"] != 0.2 {
+		t.Errorf("got temperature %v, want 0.2", a.options["temperature"])
+	}
+}
+
+func TestClient_ChatStream(t *testing.T) {
+	stop := response.FinishReasonStop
+	chunks := []response.StreamingChunk{
+		{Model: "test-model", Choices: []response.StreamingChoice{{Delta: response.StreamingDelta{Content: "Hel"}}}},
+		{Model: "test-model", Choices: []response.StreamingChoice{{Delta: response.StreamingDelta{Content: "lo"}, FinishReason: &stop}}},
+	}
+
+	c := newClient(t, mock.NewMockAgent(mock.WithStreamChunks(chunks, nil)))
+
+	stream, err := c.ChatStream(context.Background(), "Hi")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	resp, err := response.Collect(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	if resp.Content() != "Hello" {
+		t.Errorf("got content %q, want %q", resp.Content(), "Hello")
+	}
+
+	if resp.FinishReason() != response.FinishReasonStop {
+		t.Errorf("got finish reason %q, want %q", resp.FinishReason(), response.FinishReasonStop)
+	}
+}
+
+func TestClient_Tools(t *testing.T) {
+	resp := &response.ToolsResponse{Model: "test-model"}
+	resp.Choices = append(resp.Choices, response.ToolsChoice{
+		Message: response.ToolsMessage{
+			Role: "assistant",
+			ToolCalls: []response.ToolCall{{
+				ID:       "call_1",
+				Type:     "function",
+				Function: response.ToolCallFunction{Name: "get_weather", Arguments: `{"location":"Boston"}`},
+			}},
+		},
+		FinishReason: response.FinishReasonToolCalls,
+	})
+
+	c := newClient(t, mock.NewMockAgent(mock.WithToolsResponse(resp, nil)))
+
+	got, err := c.Tools(context.Background(), "Weather?", []agent.Tool{{
+		Name:       "get_weather",
+		Parameters: map[string]any{"type": "object", "required": []string{"location"}},
+	}})
+	if err != nil {
+		t.Fatalf("Tools failed: %v", err)
+	}
+
+	calls := got.ToolCalls()
+	if len(calls) != 1 || calls[0].Function.Name != "get_weather" || calls[0].Function.Arguments != `{"location":"Boston"}` {
+		t.Errorf("got tool calls %+v, want get_weather call", calls)
+	}
+}
+
+func TestClient_Embed(t *testing.T) {
+	resp := &response.EmbeddingsResponse{Model: "test-model"}
+	resp.Data = append(resp.Data, struct {
+		Embedding []float64 `json:"embedding"`
+		Index     int       `json:"index"`
+		Object    string    `json:"object"`
+	}{Embedding: []float64{0.1, 0.2, 0.3}})
+
+	c := newClient(t, mock.NewMockAgent(mock.WithEmbeddingsResponse(resp, nil)))
+
+	got, err := c.Embed(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+
+	if len(got.Data) != 1 || len(got.Data[0].Embedding) != 3 {
+		t.Errorf("got data %+v, want one 3-dimension embedding", got.Data)
+	}
+}
+
+func TestClient_ErrorStatus(t *testing.T) {
+	upstream := &client.HTTPStatusError{StatusCode: 429, Status: "429 Too Many Requests"}
+	c := newClient(t, mock.NewMockAgent(mock.WithChatResponse(nil, upstream)))
+
+	_, err := c.Chat(context.Background(), "Hi")
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("got code %v, want %v", status.Code(err), codes.ResourceExhausted)
+	}
+}
+
+// recordingClient captures the request passed to Execute.
+type recordingClient struct {
+	*mock.MockClient
+	req request.Request
+}
+
+func (c *recordingClient) Execute(ctx context.Context, req request.Request) (any, error) {
+	c.req = req
+	return c.MockClient.Execute(ctx, req)
+}
+
+func TestClient_ChatMessages(t *testing.T) {
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: "http://localhost:11434"})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	rc := &recordingClient{MockClient: mock.NewMockClient(mock.WithExecuteResponse(&response.ChatResponse{
+		Model: "test-model",
+		Choices: []response.ChatChoice{{
+			Message:      protocol.NewMessage("assistant", "Paris"),
+			FinishReason: response.FinishReasonStop,
+		}},
+	}, nil))}
+
+	a := mock.NewMockAgent(
+		mock.WithClient(rc),
+		mock.WithProvider(provider),
+		mock.WithModel(model.New(&config.ModelConfig{
+			Name:         "test-model",
+			Capabilities: map[string]map[string]any{"chat": {"temperature": 0.7}},
+		})),
+	)
+
+	c := newClient(t, a)
+
+	messages := []protocol.Message{
+		protocol.NewMessage("system", "Answer briefly."),
+		protocol.NewMessage("user", "What is the capital of France?"),
+	}
+	resp, err := c.ChatMessages(context.Background(), messages, map[string]any{"max_tokens": 10})
+	if err != nil {
+		t.Fatalf("ChatMessages failed: %v", err)
+	}
+
+	if resp.Content() != "Paris" {
+		t.Errorf("got content %q, want %q", resp.Content(), "Paris")
+	}
+
+	if rc.req == nil {
+		t.Fatal("expected the conversation to be executed with the agent's client")
+	}
+
+	body, err := rc.req.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var sent struct {
+		Messages    []protocol.Message `json:"messages"`
+		Temperature float64            `json:"temperature"`
+		MaxTokens   float64            `json:"max_tokens"`
+	}
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if len(sent.Messages) != 2 || sent.Messages[0].Role != "system" || sent.Messages[1].Content != "What is the capital of France?" {
+		t.Errorf("got messages %+v, want the conversation as sent", sent.Messages)
+	}
+
+	if sent.Temperature != 0.7 || sent.MaxTokens != 10 {
+		t.Errorf("got temperature %v and max_tokens %v, want request options merged over model defaults", sent.Temperature, sent.MaxTokens)
+	}
+
+	if _, err := c.ChatMessages(context.Background(), nil); err == nil {
+		t.Error("got nil error, want error for empty messages")
+	}
+}
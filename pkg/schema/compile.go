@@ -0,0 +1,195 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// schemaKeywords hold a single subschema; schemaListKeywords hold a list.
+var (
+	schemaKeywords     = []string{"items", "additionalProperties", "not"}
+	schemaListKeywords = []string{"allOf", "anyOf", "oneOf"}
+	numberKeywords     = []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"}
+	countKeywords      = []string{"minLength", "maxLength", "minItems", "maxItems", "minProperties", "maxProperties"}
+	typeNames          = []string{"object", "array", "string", "number", "integer", "boolean", "null"}
+)
+
+// compile checks a schema node and its subschemas, compiling patterns.
+// Location is the JSON pointer of the node, for errors.
+func (s *Schema) compile(node map[string]any, location string) error {
+	if node == nil {
+		return fmt.Errorf("%s: schema must be an object", location)
+	}
+
+	if ref, ok := node["$ref"]; ok {
+		str, ok := ref.(string)
+		if !ok {
+			return fmt.Errorf("%s/$ref: must be a string", location)
+		}
+		if _, err := s.resolve(str); err != nil {
+			return fmt.Errorf("%s/$ref: %w", location, err)
+		}
+	}
+
+	if err := checkType(node["type"]); err != nil {
+		return fmt.Errorf("%s/type: %w", location, err)
+	}
+
+	for _, key := range numberKeywords {
+		if value, ok := node[key]; ok {
+			if _, isNumber := value.(float64); !isNumber {
+				// Draft 4 boolean exclusive bounds are accepted
+				if _, isBool := value.(bool); !isBool || !strings.HasPrefix(key, "exclusive") {
+					return fmt.Errorf("%s/%s: must be a number", location, key)
+				}
+			}
+		}
+	}
+	if multiple, ok := node["multipleOf"].(float64); ok && multiple <= 0 {
+		return fmt.Errorf("%s/multipleOf: must be positive", location)
+	}
+
+	for _, key := range countKeywords {
+		if value, ok := node[key]; ok {
+			if n, isNumber := value.(float64); !isNumber || n < 0 || n != float64(int(n)) {
+				return fmt.Errorf("%s/%s: must be a non-negative integer", location, key)
+			}
+		}
+	}
+
+	if pattern, ok := node["pattern"]; ok {
+		str, ok := pattern.(string)
+		if !ok {
+			return fmt.Errorf("%s/pattern: must be a string", location)
+		}
+		re, err := regexp.Compile(str)
+		if err != nil {
+			return fmt.Errorf("%s/pattern: %w", location, err)
+		}
+		s.patterns[str] = re
+	}
+
+	if required, ok := node["required"]; ok {
+		list, ok := required.([]any)
+		if !ok {
+			return fmt.Errorf("%s/required: must be an array of strings", location)
+		}
+		for _, name := range list {
+			if _, ok := name.(string); !ok {
+				return fmt.Errorf("%s/required: must be an array of strings", location)
+			}
+		}
+	}
+
+	if enum, ok := node["enum"]; ok {
+		if _, ok := enum.([]any); !ok {
+			return fmt.Errorf("%s/enum: must be an array", location)
+		}
+	}
+
+	if properties, ok := node["properties"]; ok {
+		props, ok := properties.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s/properties: must be an object", location)
+		}
+		for name, sub := range props {
+			if err := s.compileSub(sub, location+"/properties/"+name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range []string{"$defs", "definitions"} {
+		if defs, ok := node[key].(map[string]any); ok {
+			for name, sub := range defs {
+				if err := s.compileSub(sub, location+"/"+key+"/"+name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, key := range schemaKeywords {
+		if sub, ok := node[key]; ok {
+			if err := s.compileSub(sub, location+"/"+key); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range schemaListKeywords {
+		value, ok := node[key]
+		if !ok {
+			continue
+		}
+		list, ok := value.([]any)
+		if !ok || len(list) == 0 {
+			return fmt.Errorf("%s/%s: must be a non-empty array of schemas", location, key)
+		}
+		for i, sub := range list {
+			if err := s.compileSub(sub, location+"/"+key+"/"+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// compileSub compiles a subschema, which may also be a boolean schema.
+func (s *Schema) compileSub(sub any, location string) error {
+	if _, ok := sub.(bool); ok {
+		return nil
+	}
+	node, ok := sub.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: schema must be an object or boolean", location)
+	}
+	return s.compile(node, location)
+}
+
+// resolve returns the subschema a local $ref points to.
+func (s *Schema) resolve(ref string) (any, error) {
+	if ref != "#" && !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("only local references are supported, got %q", ref)
+	}
+
+	var node any = s.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/")[1:] {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("reference %q does not resolve", ref)
+		}
+		if node, ok = object[token]; !ok {
+			return nil, fmt.Errorf("reference %q does not resolve", ref)
+		}
+	}
+	return node, nil
+}
+
+// checkType verifies a type keyword names known types.
+func checkType(value any) error {
+	switch t := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if !slices.Contains(typeNames, t) {
+			return fmt.Errorf("unknown type %q", t)
+		}
+		return nil
+	case []any:
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok || !slices.Contains(typeNames, name) {
+				return fmt.Errorf("unknown type %v", item)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("must be a string or array of strings")
+	}
+}
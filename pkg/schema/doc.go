@@ -0,0 +1,50 @@
+// Package schema validates JSON values against JSON Schema, for checking
+// structured output, tool call arguments, and tool results before they are
+// trusted.
+//
+// A Schema is compiled once and validated against many values. Errors report
+// every violation with a path into the value, such as $.items[2].name:
+//
+//	s, err := schema.New("person", map[string]any{
+//	    "type":     "object",
+//	    "required": []any{"name", "age"},
+//	    "properties": map[string]any{
+//	        "name": map[string]any{"type": "string", "minLength": 1},
+//	        "age":  map[string]any{"type": "integer", "minimum": 0},
+//	    },
+//	})
+//
+//	resp, err := a.Chat(ctx, prompt, options.New(s.Option()))
+//	if err := s.ValidateJSON([]byte(resp.Content())); err != nil {
+//	    var invalid *schema.ValidationError
+//	    if errors.As(err, &invalid) {
+//	        for _, e := range invalid.Errors {
+//	            log.Printf("%s: %s", e.Path, e.Message)
+//	        }
+//	    }
+//	}
+//
+// ValidateToolCall checks a model's tool call arguments against the matching
+// tool definition's parameters.
+//
+// Repair re-asks the model when output is invalid: it calls a generate
+// function again with feedback describing the validation errors, up to a
+// number of retries:
+//
+//	out, err := schema.Repair(ctx, s, 2, func(ctx context.Context, feedback string) (string, error) {
+//	    resp, err := a.Chat(ctx, prompt+feedback, options.New(s.Option()))
+//	    if err != nil {
+//	        return "", err
+//	    }
+//	    return resp.Content(), nil
+//	})
+//
+// # Supported Keywords
+//
+// type, enum, const, properties, required, additionalProperties,
+// minProperties, maxProperties, items, minItems, maxItems, uniqueItems,
+// minLength, maxLength, pattern, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, multipleOf, allOf, anyOf, oneOf, not, and local $ref
+// (#, #/$defs/..., #/definitions/...). Other keywords, including format,
+// are ignored.
+package schema
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is a single schema violation. Path locates the offending value, with
+// $ as the root (e.g., $.items[2].name).
+type Error struct {
+	Path    string
+	Message string
+}
+
+func (e Error) String() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError reports every violation found in a value.
+type ValidationError struct {
+	Schema string
+	Errors []Error
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		lines[i] = err.String()
+	}
+
+	prefix := "schema validation failed"
+	if e.Schema != "" {
+		prefix = fmt.Sprintf("schema %s validation failed", e.Schema)
+	}
+	if len(lines) == 1 {
+		return prefix + ": " + lines[0]
+	}
+	return fmt.Sprintf("%s with %d errors: %s", prefix, len(lines), strings.Join(lines, "; "))
+}
+
+// SyntaxError reports output that is not valid JSON.
+type SyntaxError struct {
+	Schema string
+	Err    error
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Schema != "" {
+		return fmt.Sprintf("schema %s: invalid JSON: %v", e.Schema, e.Err)
+	}
+	return fmt.Sprintf("invalid JSON: %v", e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
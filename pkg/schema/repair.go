@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Generate produces model output. Feedback is empty on the first call; on
+// retries it describes why the previous output was rejected and should be
+// appended to the prompt.
+type Generate func(ctx context.Context, feedback string) (string, error)
+
+// Repair calls generate and validates its output against s, calling it again
+// with feedback up to retries more times while the output is invalid.
+// Returns the valid JSON with any code fence removed. Errors from generate
+// and context cancellation are returned immediately; if every attempt is
+// invalid, the last *ValidationError or *SyntaxError is returned.
+func Repair(ctx context.Context, s *Schema, retries int, generate Generate) (json.RawMessage, error) {
+	var feedback string
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		output, err := generate(ctx, feedback)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.ValidateJSON([]byte(output))
+		if err == nil {
+			return json.RawMessage(StripFence([]byte(output))), nil
+		}
+		if attempt >= retries {
+			return nil, err
+		}
+		feedback = Feedback(err)
+	}
+}
+
+// Feedback formats a validation or syntax error as text to append to a
+// prompt, asking the model to correct its previous response.
+func Feedback(err error) string {
+	var b strings.Builder
+	b.WriteString("\n\nYour previous response was invalid:\n")
+
+	var invalid *ValidationError
+	var syntax *SyntaxError
+	switch {
+	case errors.As(err, &invalid):
+		for _, e := range invalid.Errors {
+			b.WriteString("- " + e.String() + "\n")
+		}
+	case errors.As(err, &syntax):
+		b.WriteString("- " + syntax.Err.Error() + "\n")
+	default:
+		b.WriteString("- " + err.Error() + "\n")
+	}
+
+	b.WriteString("Respond again with only JSON matching the schema.")
+	return b.String()
+}
@@ -0,0 +1,149 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/options"
+)
+
+// Schema is a compiled JSON Schema. It is safe for concurrent use.
+type Schema struct {
+	name     string
+	root     map[string]any
+	patterns map[string]*regexp.Regexp
+}
+
+// New compiles a JSON Schema given as decoded JSON (maps, slices, and
+// scalars). Name identifies the schema in errors and to the model when used
+// as a structured output option.
+// Returns an error if a keyword has the wrong type, a pattern does not
+// compile, or a $ref does not resolve.
+func New(name string, definition map[string]any) (*Schema, error) {
+	root, err := normalize(definition)
+	if err != nil {
+		return nil, fmt.Errorf("schema %s: %w", name, err)
+	}
+
+	s := &Schema{name: name, patterns: make(map[string]*regexp.Regexp)}
+	s.root, _ = root.(map[string]any)
+	if err := s.compile(s.root, "#"); err != nil {
+		return nil, fmt.Errorf("schema %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// Parse compiles a JSON Schema from its JSON encoding.
+func Parse(name string, data []byte) (*Schema, error) {
+	var definition map[string]any
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return nil, fmt.Errorf("schema %s: %w", name, err)
+	}
+	return New(name, definition)
+}
+
+// MustNew is like New but panics if the schema does not compile.
+// Intended for schemas defined as package-level variables.
+func MustNew(name string, definition map[string]any) *Schema {
+	s, err := New(name, definition)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Name returns the schema name.
+func (s *Schema) Name() string {
+	return s.name
+}
+
+// Map returns a copy of the schema definition.
+func (s *Schema) Map() map[string]any {
+	return maps.Clone(s.root)
+}
+
+// Option returns a request option constraining output to the schema (see
+// options.JSONSchema).
+func (s *Schema) Option() options.Option {
+	return options.JSONSchema(s.name, s.Map())
+}
+
+// Validate checks a value against the schema. Values other than decoded JSON
+// are converted through their JSON encoding first.
+// Returns a *ValidationError listing every violation, or nil if the value is
+// valid.
+func (s *Schema) Validate(value any) error {
+	normalized, err := normalize(value)
+	if err != nil {
+		return err
+	}
+
+	v := &validator{schema: s}
+	v.validate(s.root, normalized, "$")
+	if len(v.errs) > 0 {
+		return &ValidationError{Schema: s.name, Errors: v.errs}
+	}
+	return nil
+}
+
+// ValidateJSON parses data and validates it against the schema.
+// Returns a *SyntaxError if data is not valid JSON, otherwise as Validate.
+// A single JSON value surrounded by a Markdown code fence is accepted, since
+// models often add one.
+func (s *Schema) ValidateJSON(data []byte) error {
+	_, err := s.Decode(data)
+	return err
+}
+
+// Decode parses and validates data, returning the decoded value.
+// Errors are as for ValidateJSON.
+func (s *Schema) Decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(StripFence(data)))
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, &SyntaxError{Schema: s.name, Err: err}
+	}
+	if decoder.More() {
+		return nil, &SyntaxError{Schema: s.name, Err: fmt.Errorf("unexpected data after the JSON value")}
+	}
+	if err := s.Validate(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// StripFence returns data without a surrounding Markdown code fence
+// (```json ... ```), or data unchanged if it has none.
+func StripFence(data []byte) []byte {
+	trimmed := bytes.TrimSpace(data)
+	if !bytes.HasPrefix(trimmed, []byte("```")) || !bytes.HasSuffix(trimmed, []byte("```")) || len(trimmed) < 6 {
+		return data
+	}
+	body := trimmed[3 : len(trimmed)-3]
+	if newline := bytes.IndexByte(body, '\n'); newline >= 0 && !strings.ContainsAny(string(body[:newline]), "{[\"") {
+		body = body[newline+1:]
+	}
+	return bytes.TrimSpace(body)
+}
+
+// normalize converts a value to decoded JSON form, so nested Go types such
+// as []string or int become []any and float64.
+func normalize(value any) (any, error) {
+	switch value.(type) {
+	case nil, bool, float64, string:
+		return value, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
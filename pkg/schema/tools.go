@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+// ValidateToolCall checks a tool call's arguments against the parameters of
+// the tool definition with the same name. Empty arguments are treated as an
+// empty object.
+// Returns an error if no tool has the call's name or its parameters do not
+// compile, a *SyntaxError if the arguments are not valid JSON, or a
+// *ValidationError naming the tool if they do not match.
+func ValidateToolCall(call protocol.ToolCall, tools []providers.ToolDefinition) error {
+	for _, tool := range tools {
+		if tool.Name != call.Function.Name {
+			continue
+		}
+		if tool.Parameters == nil {
+			return nil
+		}
+
+		s, err := New(tool.Name, tool.Parameters)
+		if err != nil {
+			return fmt.Errorf("tool %s parameters: %w", tool.Name, err)
+		}
+
+		arguments := call.Function.Arguments
+		if arguments == "" {
+			arguments = "{}"
+		}
+		return s.ValidateJSON([]byte(arguments))
+	}
+	return fmt.Errorf("unknown tool %q", call.Function.Name)
+}
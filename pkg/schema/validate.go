@@ -0,0 +1,321 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDepth bounds $ref expansion so recursive schemas cannot loop forever.
+const maxDepth = 64
+
+// validator collects violations while walking a value.
+type validator struct {
+	schema *Schema
+	errs   []Error
+	depth  int
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.errs = append(v.errs, Error{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// matches reports whether value is valid against sub without recording errors.
+func (v *validator) matches(sub any, value any, path string) bool {
+	trial := &validator{schema: v.schema, depth: v.depth}
+	trial.validateSub(sub, value, path)
+	return len(trial.errs) == 0
+}
+
+// validateSub validates against a subschema, which may be a boolean schema.
+func (v *validator) validateSub(sub any, value any, path string) {
+	switch node := sub.(type) {
+	case bool:
+		if !node {
+			v.fail(path, "is not allowed")
+		}
+	case map[string]any:
+		v.validate(node, value, path)
+	}
+}
+
+// validate checks value against a schema node.
+func (v *validator) validate(node map[string]any, value any, path string) {
+	if ref, ok := node["$ref"].(string); ok {
+		if v.depth >= maxDepth {
+			v.fail(path, "schema references nest too deeply")
+			return
+		}
+		target, _ := v.schema.resolve(ref)
+		v.depth++
+		v.validateSub(target, value, path)
+		v.depth--
+	}
+
+	if t, ok := node["type"]; ok && !matchesType(t, value) {
+		v.fail(path, "expected %s, got %s", typeList(t), typeOf(value))
+		return
+	}
+
+	if enum, ok := node["enum"].([]any); ok && !slices.ContainsFunc(enum, func(item any) bool { return reflect.DeepEqual(item, value) }) {
+		v.fail(path, "must be one of %s", formatValues(enum))
+	}
+	if constant, ok := node["const"]; ok && !reflect.DeepEqual(constant, value) {
+		v.fail(path, "must be %s", formatValue(constant))
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		v.validateObject(node, val, path)
+	case []any:
+		v.validateArray(node, val, path)
+	case string:
+		v.validateString(node, val, path)
+	case float64:
+		v.validateNumber(node, val, path)
+	}
+
+	if all, ok := node["allOf"].([]any); ok {
+		for _, sub := range all {
+			v.validateSub(sub, value, path)
+		}
+	}
+	if anyOf, ok := node["anyOf"].([]any); ok {
+		if !slices.ContainsFunc(anyOf, func(sub any) bool { return v.matches(sub, value, path) }) {
+			v.fail(path, "does not match any of the allowed schemas")
+		}
+	}
+	if oneOf, ok := node["oneOf"].([]any); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if v.matches(sub, value, path) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			v.fail(path, "must match exactly one of the allowed schemas, matched %d", matched)
+		}
+	}
+	if not, ok := node["not"]; ok && v.matches(not, value, path) {
+		v.fail(path, "must not match the excluded schema")
+	}
+}
+
+func (v *validator) validateObject(node map[string]any, object map[string]any, path string) {
+	if required, ok := node["required"].([]any); ok {
+		for _, name := range required {
+			if _, present := object[name.(string)]; !present {
+				v.fail(path, "missing required property %q", name)
+			}
+		}
+	}
+
+	if n, ok := node["minProperties"].(float64); ok && float64(len(object)) < n {
+		v.fail(path, "must have at least %d properties, got %d", int(n), len(object))
+	}
+	if n, ok := node["maxProperties"].(float64); ok && float64(len(object)) > n {
+		v.fail(path, "must have at most %d properties, got %d", int(n), len(object))
+	}
+
+	properties, _ := node["properties"].(map[string]any)
+	additional, hasAdditional := node["additionalProperties"]
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		child := propertyPath(path, name)
+		if sub, ok := properties[name]; ok {
+			v.validateSub(sub, object[name], child)
+			continue
+		}
+		if !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			v.fail(child, "unexpected property")
+			continue
+		}
+		v.validateSub(additional, object[name], child)
+	}
+}
+
+func (v *validator) validateArray(node map[string]any, array []any, path string) {
+	if n, ok := node["minItems"].(float64); ok && float64(len(array)) < n {
+		v.fail(path, "must have at least %d items, got %d", int(n), len(array))
+	}
+	if n, ok := node["maxItems"].(float64); ok && float64(len(array)) > n {
+		v.fail(path, "must have at most %d items, got %d", int(n), len(array))
+	}
+
+	if unique, _ := node["uniqueItems"].(bool); unique {
+		for i := range array {
+			for j := range i {
+				if reflect.DeepEqual(array[i], array[j]) {
+					v.fail(path+"["+strconv.Itoa(i)+"]", "duplicates item %d", j)
+					break
+				}
+			}
+		}
+	}
+
+	if items, ok := node["items"]; ok {
+		for i, item := range array {
+			v.validateSub(items, item, path+"["+strconv.Itoa(i)+"]")
+		}
+	}
+}
+
+func (v *validator) validateString(node map[string]any, str, path string) {
+	length := utf8.RuneCountInString(str)
+	if n, ok := node["minLength"].(float64); ok && float64(length) < n {
+		v.fail(path, "must be at least %d characters, got %d", int(n), length)
+	}
+	if n, ok := node["maxLength"].(float64); ok && float64(length) > n {
+		v.fail(path, "must be at most %d characters, got %d", int(n), length)
+	}
+	if pattern, ok := node["pattern"].(string); ok && !v.schema.patterns[pattern].MatchString(str) {
+		v.fail(path, "must match pattern %q", pattern)
+	}
+}
+
+func (v *validator) validateNumber(node map[string]any, n float64, path string) {
+	minimum, hasMinimum := node["minimum"].(float64)
+	maximum, hasMaximum := node["maximum"].(float64)
+
+	// Draft 4 expresses exclusive bounds as booleans on minimum and maximum
+	exclusiveMin, _ := node["exclusiveMinimum"].(bool)
+	exclusiveMax, _ := node["exclusiveMaximum"].(bool)
+
+	switch {
+	case hasMinimum && exclusiveMin && n <= minimum:
+		v.fail(path, "must be greater than %s, got %s", formatValue(minimum), formatValue(n))
+	case hasMinimum && n < minimum:
+		v.fail(path, "must be at least %s, got %s", formatValue(minimum), formatValue(n))
+	}
+	switch {
+	case hasMaximum && exclusiveMax && n >= maximum:
+		v.fail(path, "must be less than %s, got %s", formatValue(maximum), formatValue(n))
+	case hasMaximum && n > maximum:
+		v.fail(path, "must be at most %s, got %s", formatValue(maximum), formatValue(n))
+	}
+
+	if bound, ok := node["exclusiveMinimum"].(float64); ok && n <= bound {
+		v.fail(path, "must be greater than %s, got %s", formatValue(bound), formatValue(n))
+	}
+	if bound, ok := node["exclusiveMaximum"].(float64); ok && n >= bound {
+		v.fail(path, "must be less than %s, got %s", formatValue(bound), formatValue(n))
+	}
+
+	if multiple, ok := node["multipleOf"].(float64); ok {
+		quotient := n / multiple
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			v.fail(path, "must be a multiple of %s", formatValue(multiple))
+		}
+	}
+}
+
+// matchesType reports whether value has one of the types in t.
+func matchesType(t any, value any) bool {
+	switch types := t.(type) {
+	case string:
+		return isType(types, value)
+	case []any:
+		return slices.ContainsFunc(types, func(name any) bool {
+			s, _ := name.(string)
+			return isType(s, value)
+		})
+	}
+	return true
+}
+
+func isType(name string, value any) bool {
+	switch name {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n) && !math.IsInf(n, 0)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return typeOf(value) == name
+	}
+}
+
+// typeOf returns the JSON type name of a decoded value.
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// typeList formats a type keyword for messages, e.g. "string or null".
+func typeList(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, len(list))
+		for i, name := range list {
+			names[i] = fmt.Sprint(name)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// propertyPath appends a property name to a path, quoting names that are
+// not plain identifiers.
+func propertyPath(path, name string) string {
+	plain := name != ""
+	for i, r := range name {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return path + "." + name
+	}
+	return path + "[" + strconv.Quote(name) + "]"
+}
+
+// formatValue formats a JSON value for messages.
+func formatValue(value any) string {
+	switch val := value.(type) {
+	case string:
+		return strconv.Quote(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// formatValues formats enum values for messages.
+func formatValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = formatValue(value)
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
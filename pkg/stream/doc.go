@@ -0,0 +1,58 @@
+// Package stream provides composable transforms over streaming chunk channels.
+// Each combinator consumes a <-chan *response.StreamingChunk and returns a new
+// channel, so stages can be chained into a pipeline before the final consumer:
+//
+//	chunks, err := agent.ChatStream(ctx, "Tell me a story")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	upper := stream.Map(ctx, chunks, func(c *response.StreamingChunk) *response.StreamingChunk {
+//	    c.Choices[0].Delta.Content = strings.ToUpper(c.Content())
+//	    return c
+//	})
+//	display, archive := stream.Tee(ctx, upper)
+//	go archiveChunks(archive)
+//
+//	if err := stream.ToWriter(ctx, display, os.Stdout); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Demux splits a stream generating several choices (the "n" option) into one
+// channel per choice, each read by its own goroutine:
+//
+//	for i, choice := range stream.Demux(ctx, chunks, 3) {
+//	    go collect(i, choice)
+//	}
+//
+// Writer wraps an io.Writer for displaying streamed text. It never splits a
+// multi-byte character or an ANSI escape sequence across writes, and can hold
+// back partial words (WithWordBuffering):
+//
+//	out := stream.NewWriter(os.Stdout, stream.WithWordBuffering())
+//	err := stream.ToWriter(ctx, chunks, out)
+//	out.Close()
+//
+// Stream is a pull-style alternative to ranging over a channel. Chunks are
+// received only when the caller asks, and Close stops the producer when the
+// caller finishes early:
+//
+//	s, err := stream.Open(ctx, func(ctx context.Context) (<-chan *response.StreamingChunk, error) {
+//	    return agent.ChatStream(ctx, "Tell me a story")
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer s.Close()
+//
+//	for chunk, err := range s.All(ctx) {
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    fmt.Print(chunk.Content())
+//	}
+//
+// Chunks carrying an Error are passed through every stage unchanged so the
+// final consumer observes stream failures. All output channels are closed when
+// the input channel closes or the context is cancelled.
+package stream
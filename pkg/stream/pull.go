@@ -0,0 +1,145 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// ErrClosed is returned by Next after the stream was closed with Close.
+var ErrClosed = errors.New("stream closed")
+
+// Stream is a pull-style reader over a chunk stream. Chunks are received only
+// when the caller asks for them with Next or All, so a slow caller paces the
+// producer instead of buffering the response, and the producer is stopped by
+// Close instead of being left blocked on an abandoned channel.
+//
+// Next and All must not be called concurrently; Close may be called from any
+// goroutine.
+type Stream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	chunks <-chan *response.StreamingChunk
+
+	err    error
+	closed atomic.Bool
+	once   sync.Once
+}
+
+// Open starts a stream by calling open with a context the Stream owns, such
+// as agent.ChatStream or client.ExecuteStream bound to a request, and returns
+// a Stream reading its chunks. The context is derived from ctx and cancelled
+// by Close, which stops the producer.
+// Returns the error from open, if any.
+//
+// Example:
+//
+//	s, err := stream.Open(ctx, func(ctx context.Context) (<-chan *response.StreamingChunk, error) {
+//	    return agent.ChatStream(ctx, "Tell me a story")
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer s.Close()
+//
+//	for chunk, err := range s.All(ctx) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Print(chunk.Content())
+//	}
+func Open(ctx context.Context, open func(ctx context.Context) (<-chan *response.StreamingChunk, error)) (*Stream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	chunks, err := open(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &Stream{ctx: ctx, cancel: cancel, chunks: chunks}, nil
+}
+
+// Next returns the next chunk, waiting until one arrives.
+// Returns io.EOF when the stream ends, the chunk's error for a chunk carrying
+// an Error, ErrClosed after Close, or the stream context's error if it was
+// cancelled. These end the stream, and later calls return the same error.
+// Returns ctx.Err() if ctx is done first; the stream stays open, so Next can
+// be called again.
+func (s *Stream) Next(ctx context.Context) (*response.StreamingChunk, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case chunk, ok := <-s.chunks:
+		switch {
+		case !ok:
+			s.end(s.closedErr())
+		case chunk.Error != nil:
+			s.end(chunk.Error)
+		default:
+			return chunk, nil
+		}
+		return nil, s.err
+	}
+}
+
+// All returns an iterator over the remaining chunks for use with range. An
+// error is yielded once as the final pair; the end of the stream yields
+// nothing. The stream is closed when iteration stops, including when the
+// loop breaks early.
+func (s *Stream) All(ctx context.Context) iter.Seq2[*response.StreamingChunk, error] {
+	return func(yield func(*response.StreamingChunk, error) bool) {
+		defer s.Close()
+
+		for {
+			chunk, err := s.Next(ctx)
+			if err == io.EOF {
+				return
+			}
+			if !yield(chunk, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close stops the stream: its context is cancelled and the remaining chunks
+// are discarded until the producer closes the channel, so no goroutine is
+// left blocked sending to it. Safe to call more than once.
+// Always returns nil.
+func (s *Stream) Close() error {
+	s.once.Do(func() {
+		s.closed.Store(true)
+		s.cancel()
+		for range s.chunks {
+		}
+	})
+	return nil
+}
+
+// closedErr returns the error for a channel that closed: ErrClosed after
+// Close, the context error if the stream context was cancelled, or io.EOF.
+func (s *Stream) closedErr() error {
+	switch {
+	case s.closed.Load():
+		return ErrClosed
+	case s.ctx.Err() != nil:
+		return s.ctx.Err()
+	}
+	return io.EOF
+}
+
+// end records the error that ended the stream and releases its context.
+func (s *Stream) end(err error) {
+	s.err = err
+	s.cancel()
+}
@@ -0,0 +1,263 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Map applies fn to every chunk and emits the result.
+// Error chunks bypass fn; nil chunks are skipped. A nil result from fn drops
+// the chunk.
+func Map(ctx context.Context, in <-chan *response.StreamingChunk, fn func(*response.StreamingChunk) *response.StreamingChunk) <-chan *response.StreamingChunk {
+	output := make(chan *response.StreamingChunk)
+
+	go func() {
+		defer close(output)
+
+		for {
+			chunk, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			if chunk == nil {
+				continue
+			}
+
+			if chunk.Error == nil {
+				chunk = fn(chunk)
+				if chunk == nil {
+					continue
+				}
+			}
+
+			if !send(ctx, output, chunk) {
+				return
+			}
+		}
+	}()
+
+	return output
+}
+
+// Filter emits only the chunks for which keep returns true.
+// Error chunks are always emitted; nil chunks are skipped.
+func Filter(ctx context.Context, in <-chan *response.StreamingChunk, keep func(*response.StreamingChunk) bool) <-chan *response.StreamingChunk {
+	output := make(chan *response.StreamingChunk)
+
+	go func() {
+		defer close(output)
+
+		for {
+			chunk, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			if chunk == nil || (chunk.Error == nil && !keep(chunk)) {
+				continue
+			}
+
+			if !send(ctx, output, chunk) {
+				return
+			}
+		}
+	}()
+
+	return output
+}
+
+// Tee duplicates a stream into two output channels.
+// Both outputs receive every chunk in order, so each must be consumed;
+// a slow consumer applies back-pressure to the other. Use Buffer on an
+// output to decouple consumers running at different speeds. Nil chunks are
+// skipped.
+func Tee(ctx context.Context, in <-chan *response.StreamingChunk) (<-chan *response.StreamingChunk, <-chan *response.StreamingChunk) {
+	first := make(chan *response.StreamingChunk)
+	second := make(chan *response.StreamingChunk)
+
+	go func() {
+		defer close(first)
+		defer close(second)
+
+		for {
+			chunk, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			if chunk == nil {
+				continue
+			}
+
+			// Send to whichever output is ready first, then the other.
+			a, b := first, second
+			for range 2 {
+				select {
+				case a <- chunk:
+					a = nil
+				case b <- chunk:
+					b = nil
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return first, second
+}
+
+// Demux splits a stream generating n choices (the "n" option) into one
+// channel per choice index. Each chunk is delivered to the channel of every
+// choice it carries as a copy holding only that choice, so Content and
+// FinishReason report that choice. Chunks without choices (such as a final
+// usage chunk or heartbeats) and error chunks go to every channel; choices
+// with an index outside 0 to n-1 are dropped, as are nil chunks.
+// A chunk is delivered to its channels one after another, so every channel
+// must be consumed concurrently; use Buffer on an output to decouple consumers
+// running at different speeds. An n less than 1 is treated as 1.
+func Demux(ctx context.Context, in <-chan *response.StreamingChunk, n int) []<-chan *response.StreamingChunk {
+	outputs := make([]chan *response.StreamingChunk, max(n, 1))
+	result := make([]<-chan *response.StreamingChunk, len(outputs))
+	for i := range outputs {
+		outputs[i] = make(chan *response.StreamingChunk)
+		result[i] = outputs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, output := range outputs {
+				close(output)
+			}
+		}()
+
+		for {
+			chunk, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			if chunk == nil {
+				continue
+			}
+
+			if chunk.Error != nil || len(chunk.Choices) == 0 {
+				for _, output := range outputs {
+					if !send(ctx, output, chunk) {
+						return
+					}
+				}
+				continue
+			}
+
+			for _, choice := range chunk.Choices {
+				if choice.Index < 0 || choice.Index >= len(outputs) {
+					continue
+				}
+				split := *chunk
+				split.Choices = []response.StreamingChoice{choice}
+				if !send(ctx, outputs[choice.Index], &split) {
+					return
+				}
+			}
+		}
+	}()
+
+	return result
+}
+
+// Buffer decouples a producer from its consumer with a buffer of the given size.
+// The producer can run up to size chunks ahead of the consumer.
+// A size less than 1 is treated as 1. Nil chunks are skipped.
+func Buffer(ctx context.Context, in <-chan *response.StreamingChunk, size int) <-chan *response.StreamingChunk {
+	output := make(chan *response.StreamingChunk, max(size, 1))
+
+	go func() {
+		defer close(output)
+
+		for {
+			chunk, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			if chunk == nil {
+				continue
+			}
+
+			if !send(ctx, output, chunk) {
+				return
+			}
+		}
+	}()
+
+	return output
+}
+
+// ToWriter writes the content of each chunk to w until the stream closes.
+// If w supports flushing (http.Flusher or bufio.Writer), it is flushed after
+// each write so content reaches the client as it arrives. Nil chunks are
+// skipped.
+// Returns the first chunk error, write error, or context cancellation error.
+func ToWriter(ctx context.Context, in <-chan *response.StreamingChunk, w io.Writer) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stream write cancelled: %w", ctx.Err())
+		case chunk, ok := <-in:
+			if !ok {
+				return nil
+			}
+			if chunk == nil {
+				continue
+			}
+			if chunk.Error != nil {
+				return fmt.Errorf("stream error: %w", chunk.Error)
+			}
+
+			content := chunk.Content()
+			if content == "" {
+				continue
+			}
+
+			if _, err := io.WriteString(w, content); err != nil {
+				return fmt.Errorf("failed to write stream content: %w", err)
+			}
+
+			if err := flush(w); err != nil {
+				return fmt.Errorf("failed to flush stream content: %w", err)
+			}
+		}
+	}
+}
+
+// receive takes the next chunk from in, returning false if in is closed or
+// the context is cancelled first.
+func receive(ctx context.Context, in <-chan *response.StreamingChunk) (*response.StreamingChunk, bool) {
+	select {
+	case chunk, ok := <-in:
+		return chunk, ok
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// send delivers a chunk to output, returning false if the context is cancelled first.
+func send(ctx context.Context, output chan<- *response.StreamingChunk, chunk *response.StreamingChunk) bool {
+	select {
+	case output <- chunk:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// flush flushes w if it supports either flushing convention.
+func flush(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
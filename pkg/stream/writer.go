@@ -0,0 +1,189 @@
+package stream
+
+import (
+	"bytes"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxWordBytes bounds how much text word buffering holds back, so a long run
+// without whitespace (such as a URL or code) still streams.
+const maxWordBytes = 256
+
+// Writer writes streamed text to an underlying writer without splitting a
+// multi-byte UTF-8 character or an ANSI escape sequence across writes, so
+// terminals and TUIs never render a partial character or control code. With
+// word buffering, text is also held back until a word is complete.
+//
+// Incomplete trailing text is held until a later write completes it or
+// Close is called. A Writer is not safe for concurrent use.
+type Writer struct {
+	w         io.Writer
+	words     bool
+	autoFlush bool
+	pending   []byte
+}
+
+// WriterOption configures a Writer.
+type WriterOption func(*Writer)
+
+// WithWordBuffering holds back text until whitespace ends the current word,
+// so words appear whole rather than token by token.
+func WithWordBuffering() WriterOption {
+	return func(w *Writer) {
+		w.words = true
+	}
+}
+
+// WithAutoFlush sets whether the underlying writer is flushed after every
+// write that produces output (default true). Flushing applies to writers
+// supporting http.Flusher or bufio.Writer conventions.
+func WithAutoFlush(enabled bool) WriterOption {
+	return func(w *Writer) {
+		w.autoFlush = enabled
+	}
+}
+
+// NewWriter returns a Writer writing to w.
+func NewWriter(w io.Writer, opts ...WriterOption) *Writer {
+	writer := &Writer{w: w, autoFlush: true}
+	for _, opt := range opts {
+		opt(writer)
+	}
+	return writer
+}
+
+// Write writes the complete text in p and any earlier held-back text,
+// holding back an incomplete trailing character, escape sequence, or word.
+// It reports len(p) on success, since held-back bytes are written later.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+
+	n := w.complete()
+	if n == 0 {
+		return len(p), nil
+	}
+	if err := w.emit(n); err != nil {
+		return 0, err
+	}
+	if w.autoFlush {
+		if err := flush(w.w); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// WriteString is like Write but takes a string.
+func (w *Writer) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush flushes the underlying writer. Held-back text stays buffered until
+// it is complete or Close is called.
+func (w *Writer) Flush() error {
+	return flush(w.w)
+}
+
+// Close writes any held-back text, complete or not, and flushes the
+// underlying writer. Call it when the stream ends. The underlying writer is
+// not closed, and the Writer may be reused afterwards.
+func (w *Writer) Close() error {
+	if err := w.emit(len(w.pending)); err != nil {
+		return err
+	}
+	return flush(w.w)
+}
+
+// Buffered returns the number of held-back bytes.
+func (w *Writer) Buffered() int {
+	return len(w.pending)
+}
+
+// emit writes the first n pending bytes and keeps the rest.
+func (w *Writer) emit(n int) error {
+	if n == 0 {
+		return nil
+	}
+	if _, err := w.w.Write(w.pending[:n]); err != nil {
+		return err
+	}
+	w.pending = append(w.pending[:0], w.pending[n:]...)
+	return nil
+}
+
+// complete returns the length of the pending prefix that can be written.
+func (w *Writer) complete() int {
+	n := min(len(w.pending), incompleteRune(w.pending), incompleteEscape(w.pending))
+	if !w.words || n == 0 {
+		return n
+	}
+
+	// End at the last whitespace so the trailing partial word is held back,
+	// unless that would hold back too much
+	if end := lastSpace(w.pending[:n]); end > 0 {
+		return end
+	}
+	if n > maxWordBytes {
+		return n
+	}
+	return 0
+}
+
+// incompleteRune returns the offset of a trailing incomplete UTF-8 sequence,
+// or len(p) if p ends with a complete character.
+func incompleteRune(p []byte) int {
+	for i := len(p) - 1; i >= max(len(p)-utf8.UTFMax, 0); i-- {
+		if utf8.RuneStart(p[i]) {
+			if !utf8.FullRune(p[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(p)
+}
+
+// incompleteEscape returns the offset of a trailing unterminated ANSI escape
+// sequence, or len(p) if there is none. CSI sequences (ESC [) end with a byte
+// in 0x40-0x7E, OSC sequences (ESC ]) end with BEL or ESC \ (itself a
+// complete two-byte escape), and other escapes are two bytes.
+func incompleteEscape(p []byte) int {
+	start := bytes.LastIndexByte(p, 0x1b)
+	if start < 0 {
+		return len(p)
+	}
+
+	seq := p[start+1:]
+	if len(seq) == 0 {
+		return start
+	}
+	switch seq[0] {
+	case '[':
+		for _, b := range seq[1:] {
+			if b >= 0x40 && b <= 0x7e {
+				return len(p)
+			}
+		}
+		return start
+	case ']':
+		if bytes.IndexByte(seq, 0x07) < 0 {
+			return start
+		}
+	}
+	return len(p)
+}
+
+// lastSpace returns the offset just after the last whitespace character in
+// p, or 0 if there is none.
+func lastSpace(p []byte) int {
+	for i := len(p); i > 0; {
+		r, size := utf8.DecodeLastRune(p[:i])
+		if unicode.IsSpace(r) {
+			return i
+		}
+		i -= size
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package tokenizer
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// BPE is a tiktoken-compatible byte pair encoder.
+// Ranks map byte sequences to token IDs; lower ranks merge first.
+type BPE struct {
+	name    string
+	ranks   map[string]int
+	decoder map[int]string
+}
+
+// NewBPE creates a BPE encoder from mergeable ranks.
+// Returns an error if any single byte is missing from the ranks,
+// since every input must be encodable.
+func NewBPE(name string, ranks map[string]int) (*BPE, error) {
+	for b := range 256 {
+		if _, ok := ranks[string([]byte{byte(b)})]; !ok {
+			return nil, fmt.Errorf("incomplete byte vocabulary for %s: missing byte 0x%02x", name, b)
+		}
+	}
+
+	decoder := make(map[int]string, len(ranks))
+	for token, rank := range ranks {
+		decoder[rank] = token
+	}
+
+	return &BPE{
+		name:    name,
+		ranks:   ranks,
+		decoder: decoder,
+	}, nil
+}
+
+// LoadBPE reads a .tiktoken rank file and creates a BPE encoder.
+// Each line holds a base64-encoded token and its rank separated by a space.
+func LoadBPE(name string, r io.Reader) (*BPE, error) {
+	ranks := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		encoded, rankText, ok := strings.Cut(text, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid rank file line %d: missing rank", line)
+		}
+
+		token, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rank file line %d: %w", line, err)
+		}
+
+		rank, err := strconv.Atoi(rankText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rank file line %d: %w", line, err)
+		}
+
+		ranks[string(token)] = rank
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rank file: %w", err)
+	}
+
+	return NewBPE(name, ranks)
+}
+
+// Name returns the encoding name.
+func (e *BPE) Name() string {
+	return e.name
+}
+
+// Count returns the number of tokens in text.
+func (e *BPE) Count(text string) int {
+	return len(e.Encode(text))
+}
+
+// Encode converts text to token IDs.
+func (e *BPE) Encode(text string) []int {
+	var tokens []int
+	for _, piece := range Split(text) {
+		if rank, ok := e.ranks[piece]; ok {
+			tokens = append(tokens, rank)
+			continue
+		}
+		tokens = e.merge(piece, tokens)
+	}
+	return tokens
+}
+
+// Decode converts token IDs back to text.
+// Unknown token IDs are skipped.
+func (e *BPE) Decode(tokens []int) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteString(e.decoder[token])
+	}
+	return sb.String()
+}
+
+// merge applies byte pair merges to a piece, appending the resulting ranks to tokens.
+func (e *BPE) merge(piece string, tokens []int) []int {
+	// bounds holds the start offset of each part plus the end offset.
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+
+	for len(bounds) > 2 {
+		best, bestRank := -1, 0
+		for i := 0; i+2 < len(bounds); i++ {
+			rank, ok := e.ranks[piece[bounds[i]:bounds[i+2]]]
+			if ok && (best < 0 || rank < bestRank) {
+				best, bestRank = i, rank
+			}
+		}
+		if best < 0 {
+			break
+		}
+		bounds = append(bounds[:best+1], bounds[best+2:]...)
+	}
+
+	for i := 0; i+1 < len(bounds); i++ {
+		tokens = append(tokens, e.ranks[piece[bounds[i]:bounds[i+1]]])
+	}
+	return tokens
+}
@@ -0,0 +1,19 @@
+// Package tokenizer estimates token counts for LLM inputs.
+// It provides a tiktoken-compatible byte pair encoder that loads standard
+// .tiktoken rank files, and a heuristic fallback used when no encoding is
+// registered for a model.
+//
+// Register an encoding once, then resolve tokenizers by model name:
+//
+//	f, _ := os.Open("cl100k_base.tiktoken")
+//	bpe, err := tokenizer.LoadBPE("cl100k_base", f)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	tokenizer.Register(bpe)
+//
+//	count := tokenizer.ForModel("gpt-4").Count("Hello, world")
+//
+// Vocabulary files are not bundled; models without a registered encoding
+// use the Heuristic tokenizer.
+package tokenizer
@@ -0,0 +1,127 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Split pre-tokenizes text into the pieces a BPE encoder merges independently.
+// It reproduces the tiktoken cl100k_base pattern:
+//
+//	(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+
+//
+// The pattern uses lookahead, which the standard regexp package does not
+// support, so it is implemented as a scanner.
+func Split(text string) []string {
+	var pieces []string
+	for i := 0; i < len(text); {
+		n := matchPiece(text[i:])
+		pieces = append(pieces, text[i:i+n])
+		i += n
+	}
+	return pieces
+}
+
+// matchPiece returns the byte length of the piece at the start of s,
+// trying each pattern alternative in order.
+func matchPiece(s string) int {
+	if n := matchContraction(s); n > 0 {
+		return n
+	}
+	if n := matchWord(s); n > 0 {
+		return n
+	}
+	if n := matchRun(s, unicode.IsNumber, 3); n > 0 {
+		return n
+	}
+	if n := matchPunctuation(s); n > 0 {
+		return n
+	}
+	return matchSpace(s)
+}
+
+var contractions = []string{"'s", "'t", "'re", "'ve", "'m", "'ll", "'d"}
+
+func matchContraction(s string) int {
+	for _, c := range contractions {
+		if len(s) >= len(c) && strings.EqualFold(s[:len(c)], c) {
+			return len(c)
+		}
+	}
+	return 0
+}
+
+// matchWord matches [^\r\n\p{L}\p{N}]?\p{L}+.
+func matchWord(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	prefix := 0
+	if r != '\r' && r != '\n' && !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+		prefix = size
+	}
+	letters := matchRun(s[prefix:], unicode.IsLetter, 0)
+	if letters == 0 {
+		return 0
+	}
+	return prefix + letters
+}
+
+// matchPunctuation matches ` ?[^\s\p{L}\p{N}]+[\r\n]*`.
+func matchPunctuation(s string) int {
+	prefix := 0
+	if strings.HasPrefix(s, " ") {
+		prefix = 1
+	}
+	n := matchRun(s[prefix:], isSymbol, 0)
+	if n == 0 {
+		return 0
+	}
+	n += prefix
+	for n < len(s) && (s[n] == '\r' || s[n] == '\n') {
+		n++
+	}
+	return n
+}
+
+// matchSpace matches `\s*[\r\n]+|\s+(?!\S)|\s+`.
+func matchSpace(s string) int {
+	run := matchRun(s, unicode.IsSpace, 0)
+	if run == 0 {
+		// Unreachable for valid pattern coverage; consume one rune.
+		_, size := utf8.DecodeRuneInString(s)
+		return size
+	}
+
+	// \s*[\r\n]+ ends at the last newline in the whitespace run.
+	if last := strings.LastIndexAny(s[:run], "\r\n"); last >= 0 {
+		return last + 1
+	}
+
+	// \s+(?!\S) leaves the final space to prefix the following word.
+	if run < len(s) {
+		_, size := utf8.DecodeLastRuneInString(s[:run])
+		if run-size > 0 {
+			return run - size
+		}
+	}
+	return run
+}
+
+// matchRun returns the byte length of the leading runes satisfying match,
+// limited to limit runes when limit is positive.
+func matchRun(s string, match func(rune) bool, limit int) int {
+	n, count := 0, 0
+	for n < len(s) && (limit <= 0 || count < limit) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !match(r) {
+			break
+		}
+		n += size
+		count++
+	}
+	return n
+}
+
+func isSymbol(r rune) bool {
+	return !unicode.IsSpace(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
@@ -0,0 +1,123 @@
+package tokenizer
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Tokenizer counts the tokens a model would see for a text.
+type Tokenizer interface {
+	// Name returns the encoding identifier (e.g., "cl100k_base").
+	Name() string
+
+	// Count returns the number of tokens in text.
+	Count(text string) int
+}
+
+// Heuristic approximates token counts without a vocabulary.
+// Each pre-tokenized piece counts one token per six ASCII bytes and one
+// token per non-ASCII rune, with a minimum of one token per piece.
+// Counts are typically within 10-20% of BPE encodings for English text.
+type Heuristic struct{}
+
+// Name returns "heuristic".
+func (Heuristic) Name() string {
+	return "heuristic"
+}
+
+// Count returns the approximate number of tokens in text.
+func (Heuristic) Count(text string) int {
+	count := 0
+	for _, piece := range Split(text) {
+		ascii, other := 0, 0
+		for _, r := range piece {
+			if r < utf8.RuneSelf {
+				ascii++
+			} else {
+				other++
+			}
+		}
+		count += max(1, (ascii+5)/6+other)
+	}
+	return count
+}
+
+// registry maintains registered encodings and the model prefix mapping.
+// It is thread-safe for concurrent registration and lookup.
+type registry struct {
+	encodings map[string]Tokenizer
+	models    map[string]string
+	mu        sync.RWMutex
+}
+
+// register is the global tokenizer registry.
+var register = &registry{
+	encodings: make(map[string]Tokenizer),
+	models: map[string]string{
+		"gpt-4o":                 "o200k_base",
+		"gpt-4.1":                "o200k_base",
+		"gpt-5":                  "o200k_base",
+		"o1":                     "o200k_base",
+		"o3":                     "o200k_base",
+		"o4":                     "o200k_base",
+		"gpt-4":                  "cl100k_base",
+		"gpt-3.5-turbo":          "cl100k_base",
+		"text-embedding-3":       "cl100k_base",
+		"text-embedding-ada-002": "cl100k_base",
+	},
+}
+
+// Register registers a tokenizer under its Name.
+// Registering an existing name replaces the previous tokenizer.
+// Thread-safe for concurrent registration.
+func Register(t Tokenizer) {
+	register.mu.Lock()
+	defer register.mu.Unlock()
+	register.encodings[t.Name()] = t
+}
+
+// RegisterModel maps model names starting with prefix to an encoding name.
+// The longest matching prefix wins when resolving a model.
+// Thread-safe for concurrent registration.
+func RegisterModel(prefix, encoding string) {
+	register.mu.Lock()
+	defer register.mu.Unlock()
+	register.models[prefix] = encoding
+}
+
+// Get returns the tokenizer registered under name.
+// Thread-safe for concurrent access.
+func Get(name string) (Tokenizer, bool) {
+	register.mu.RLock()
+	defer register.mu.RUnlock()
+	t, ok := register.encodings[name]
+	return t, ok
+}
+
+// ForModel resolves the tokenizer for a model name.
+// Model names are matched case-insensitively by longest registered prefix;
+// an exact encoding name is also accepted. Returns Heuristic when the model
+// is unknown or its encoding has not been registered.
+// Thread-safe for concurrent access.
+func ForModel(model string) Tokenizer {
+	register.mu.RLock()
+	defer register.mu.RUnlock()
+
+	if t, ok := register.encodings[model]; ok {
+		return t
+	}
+
+	name := strings.ToLower(model)
+	best := ""
+	for prefix := range register.models {
+		if strings.HasPrefix(name, prefix) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+
+	if t, ok := register.encodings[register.models[best]]; ok && best != "" {
+		return t
+	}
+	return Heuristic{}
+}
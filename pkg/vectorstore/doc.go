@@ -0,0 +1,28 @@
+// Package vectorstore provides vector storage and similarity search for
+// retrieval-augmented generation. Memory is an in-memory Store with JSON and
+// gob persistence; external databases (e.g., pgvector, Qdrant) can implement
+// the Store interface.
+//
+// Index documents with an agent's embeddings protocol:
+//
+//	store := vectorstore.NewMemory()
+//	docs := []vectorstore.Document{
+//	    {Content: "Kubernetes schedules containers.", Metadata: map[string]any{"topic": "k8s"}},
+//	    {Content: "Go has goroutines.", Metadata: map[string]any{"topic": "go"}},
+//	}
+//	if err := vectorstore.Index(ctx, a, store, docs); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Search by text with a metadata filter:
+//
+//	results, err := vectorstore.Query(ctx, a, store, "container orchestration", 3, vectorstore.Filter{"topic": "k8s"})
+//	for _, r := range results {
+//	    fmt.Printf("%.3f %s\n", r.Score, r.Content)
+//	}
+//
+// Persist and restore:
+//
+//	store.SaveFile("index.gob")
+//	store, err := vectorstore.LoadFile("index.gob")
+package vectorstore
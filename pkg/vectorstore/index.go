@@ -0,0 +1,56 @@
+package vectorstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+)
+
+// Index embeds the content of documents that have no vector using the agent's
+// embeddings protocol, then adds all documents to the store.
+// Documents are updated in place: missing IDs are generated (UUIDv7) and
+// vectors are filled in, so callers can reference them after indexing.
+// Options are passed to each agent.Embed call.
+func Index(ctx context.Context, a agent.Embedder, store Store, docs []Document, opts ...map[string]any) error {
+	for i := range docs {
+		if docs[i].ID == "" {
+			docs[i].ID = uuid.Must(uuid.NewV7()).String()
+		}
+		if len(docs[i].Vector) > 0 {
+			continue
+		}
+
+		vector, err := embed(ctx, a, docs[i].Content, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to embed document %s: %w", docs[i].ID, err)
+		}
+		docs[i].Vector = vector
+	}
+
+	return store.Add(ctx, docs...)
+}
+
+// Query embeds text using the agent's embeddings protocol and searches the
+// store for the k most similar documents matching the filter.
+func Query(ctx context.Context, a agent.Embedder, store Store, text string, k int, filter Filter, opts ...map[string]any) ([]Result, error) {
+	vector, err := embed(ctx, a, text, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	return store.Search(ctx, vector, k, filter)
+}
+
+// embed returns the first embedding for the input.
+func embed(ctx context.Context, a agent.Embedder, input string, opts ...map[string]any) ([]float64, error) {
+	resp, err := a.Embed(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 || len(resp.Data[0].Embedding) == 0 {
+		return nil, errors.New("embeddings response contains no vectors")
+	}
+	return resp.Data[0].Embedding, nil
+}
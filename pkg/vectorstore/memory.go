@@ -0,0 +1,235 @@
+package vectorstore
+
+import (
+	"cmp"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Format selects the encoding used to persist a Memory store.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatGob  Format = "gob"
+)
+
+// Memory is an in-memory Store that ranks documents by cosine similarity
+// with an exhaustive scan. Suitable for up to tens of thousands of documents.
+// Thread-safe for concurrent use.
+type Memory struct {
+	docs       map[string]Document
+	dimensions int
+	mu         sync.RWMutex
+}
+
+// NewMemory creates an empty in-memory store.
+// The vector dimension is fixed by the first document added.
+func NewMemory() *Memory {
+	return &Memory{docs: make(map[string]Document)}
+}
+
+// Add inserts documents, replacing any existing documents with the same ID.
+// Documents are copied, so callers may reuse their slices and maps.
+// Returns an error without adding anything if any document is invalid.
+func (m *Memory) Add(ctx context.Context, docs ...Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dimensions := m.dimensions
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return ErrMissingID
+		}
+		if len(doc.Vector) == 0 {
+			return fmt.Errorf("%w: %s", ErrMissingVector, doc.ID)
+		}
+		if dimensions == 0 {
+			dimensions = len(doc.Vector)
+		}
+		if len(doc.Vector) != dimensions {
+			return fmt.Errorf("%w: document %s has %d dimensions, want %d", ErrDimensionMismatch, doc.ID, len(doc.Vector), dimensions)
+		}
+	}
+
+	m.dimensions = dimensions
+	for _, doc := range docs {
+		m.docs[doc.ID] = doc.clone()
+	}
+	return nil
+}
+
+// Search returns up to k documents most similar to vector that match the
+// filter, ordered by descending cosine similarity. Ties are ordered by ID.
+func (m *Memory) Search(ctx context.Context, vector []float64, k int, filter Filter) ([]Result, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.dimensions > 0 && len(vector) != m.dimensions {
+		return nil, fmt.Errorf("%w: query has %d dimensions, want %d", ErrDimensionMismatch, len(vector), m.dimensions)
+	}
+
+	var results []Result
+	for _, doc := range m.docs {
+		if !filter.Match(doc.Metadata) {
+			continue
+		}
+		results = append(results, Result{Document: doc.clone(), Score: cosine(vector, doc.Vector)})
+	}
+
+	slices.SortFunc(results, func(a, b Result) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	if k > 0 && len(results) > k {
+		results = results[:k]
+	}
+	return results, nil
+}
+
+// Delete removes documents by ID. Unknown IDs are ignored.
+func (m *Memory) Delete(ctx context.Context, ids ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		delete(m.docs, id)
+	}
+	if len(m.docs) == 0 {
+		m.dimensions = 0
+	}
+	return nil
+}
+
+// Get returns a copy of the document with the given ID.
+func (m *Memory) Get(id string) (Document, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	doc, ok := m.docs[id]
+	if !ok {
+		return Document{}, false
+	}
+	return doc.clone(), true
+}
+
+// Len returns the number of documents in the store.
+func (m *Memory) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.docs)
+}
+
+// snapshot is the persisted form of a Memory store.
+type snapshot struct {
+	Documents []Document `json:"documents"`
+}
+
+// Save writes all documents to w in the given format, ordered by ID.
+// Metadata values persisted as gob must be basic types or registered with gob.Register.
+func (m *Memory) Save(w io.Writer, format Format) error {
+	m.mu.RLock()
+	snap := snapshot{Documents: make([]Document, 0, len(m.docs))}
+	for _, id := range slices.Sorted(maps.Keys(m.docs)) {
+		snap.Documents = append(snap.Documents, m.docs[id])
+	}
+	m.mu.RUnlock()
+
+	switch format {
+	case FormatJSON:
+		if err := json.NewEncoder(w).Encode(snap); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	case FormatGob:
+		if err := gob.NewEncoder(w).Encode(snap); err != nil {
+			return fmt.Errorf("failed to encode gob: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+	return nil
+}
+
+// Load reads documents saved by Save and adds them to the store.
+func (m *Memory) Load(r io.Reader, format Format) error {
+	var snap snapshot
+	switch format {
+	case FormatJSON:
+		if err := json.NewDecoder(r).Decode(&snap); err != nil {
+			return fmt.Errorf("failed to decode JSON: %w", err)
+		}
+	case FormatGob:
+		if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+			return fmt.Errorf("failed to decode gob: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	return m.Add(context.Background(), snap.Documents...)
+}
+
+// SaveFile writes the store to a file, using gob for the ".gob" extension
+// and JSON otherwise.
+func (m *Memory) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if err := m.Save(f, formatFor(path)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadFile creates a store from a file written by SaveFile.
+func LoadFile(path string) (*Memory, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	m := NewMemory()
+	if err := m.Load(f, formatFor(path)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// formatFor selects the persistence format from a file extension.
+func formatFor(path string) Format {
+	if filepath.Ext(path) == ".gob" {
+		return FormatGob
+	}
+	return FormatJSON
+}
+
+// cosine returns the cosine similarity of two equal-length vectors,
+// or zero if either has zero magnitude.
+func cosine(a, b []float64) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
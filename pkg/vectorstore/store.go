@@ -0,0 +1,99 @@
+package vectorstore
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+)
+
+var (
+	// ErrMissingID indicates a document was added without an ID.
+	ErrMissingID = errors.New("document ID is required")
+
+	// ErrMissingVector indicates a document was added without a vector.
+	ErrMissingVector = errors.New("document vector is required")
+
+	// ErrDimensionMismatch indicates a vector whose length differs from the
+	// vectors already in the store.
+	ErrDimensionMismatch = errors.New("vector dimension mismatch")
+)
+
+// Document is a unit of indexed content with its embedding vector.
+// Metadata holds arbitrary attributes that searches can filter on.
+type Document struct {
+	ID       string         `json:"id"`
+	Content  string         `json:"content,omitempty"`
+	Vector   []float64      `json:"vector"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// Result is a document matched by a search with its similarity score.
+// Higher scores are more similar.
+type Result struct {
+	Document
+	Score float64 `json:"score"`
+}
+
+// Filter restricts searches to documents whose metadata has every listed key
+// with an equal value. Numbers compare by value regardless of type, so filters
+// match documents loaded from JSON. A nil or empty filter matches everything.
+type Filter map[string]any
+
+// Match returns true if the metadata satisfies the filter.
+func (f Filter) Match(metadata map[string]any) bool {
+	for key, want := range f {
+		got, ok := metadata[key]
+		if !ok || !equal(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// Store defines the interface for vector stores.
+// Memory is the built-in implementation; external stores (e.g., pgvector,
+// Qdrant) implement this interface to work with Index and Query.
+type Store interface {
+	// Add inserts documents, replacing any existing documents with the same ID.
+	// Returns an error if a document has no ID or vector.
+	Add(ctx context.Context, docs ...Document) error
+
+	// Search returns up to k documents most similar to vector that match the
+	// filter, ordered by descending score. A k of zero or less returns all matches.
+	Search(ctx context.Context, vector []float64, k int, filter Filter) ([]Result, error)
+
+	// Delete removes documents by ID. Unknown IDs are ignored.
+	Delete(ctx context.Context, ids ...string) error
+}
+
+// clone returns a copy of the document with independent vector and metadata.
+func (d Document) clone() Document {
+	d.Vector = append([]float64(nil), d.Vector...)
+	d.Metadata = maps.Clone(d.Metadata)
+	return d
+}
+
+// equal compares metadata values, treating all numeric types as float64.
+func equal(a, b any) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// number converts numeric values to float64.
+func number(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,23 @@
+// Package workflow provides composable primitives for simple multi-step
+// agent pipelines without an external orchestrator.
+//
+// A Step transforms a string input into a string output. Steps compose
+// sequentially with Sequence and concurrently with Parallel:
+//
+//	summarize := workflow.Sequence(
+//	    workflow.Prompt("Summarize the following text:\n\n%s"),
+//	    workflow.Named("summarize", workflow.Chat(writer)),
+//	    workflow.Parallel([]workflow.Step{
+//	        workflow.Sequence(workflow.Prompt("Translate to French:\n\n%s"), workflow.Chat(translator)),
+//	        workflow.Sequence(workflow.Prompt("List the key terms in:\n\n%s"), workflow.Chat(analyst)),
+//	    }, workflow.WithErrorPolicy(workflow.BestEffort)),
+//	)
+//
+//	result, err := summarize.Run(ctx, document)
+//
+// The context passed to Run propagates to every step and agent call.
+// Sequence stops at the first failure, reporting a *StepError that names the
+// failing step. Parallel applies an ErrorPolicy: FailFast cancels the other
+// branches, CollectAll waits and joins every error, and BestEffort keeps the
+// successful outputs.
+package workflow
@@ -0,0 +1,150 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrorPolicy controls how Parallel handles branch failures.
+type ErrorPolicy int
+
+const (
+	// FailFast cancels the remaining branches and returns the first error.
+	FailFast ErrorPolicy = iota
+
+	// CollectAll waits for every branch and returns all errors joined.
+	CollectAll
+
+	// BestEffort joins the outputs of successful branches and only fails
+	// if every branch fails.
+	BestEffort
+)
+
+// ErrAllBranchesFailed is returned under BestEffort when no branch succeeds.
+var ErrAllBranchesFailed = errors.New("all parallel branches failed")
+
+// ParallelOption configures a Parallel step.
+type ParallelOption func(*parallelConfig)
+
+type parallelConfig struct {
+	policy      ErrorPolicy
+	join        func([]string) string
+	concurrency int
+}
+
+// WithErrorPolicy sets how branch failures are handled (default FailFast).
+func WithErrorPolicy(policy ErrorPolicy) ParallelOption {
+	return func(c *parallelConfig) {
+		c.policy = policy
+	}
+}
+
+// WithJoin sets the fan-in function that combines branch outputs, which are
+// given in branch order. The default joins outputs with a blank line.
+func WithJoin(join func([]string) string) ParallelOption {
+	return func(c *parallelConfig) {
+		c.join = join
+	}
+}
+
+// WithConcurrency limits how many branches run at once.
+// Zero or negative runs all branches concurrently.
+func WithConcurrency(n int) ParallelOption {
+	return func(c *parallelConfig) {
+		c.concurrency = n
+	}
+}
+
+// Parallel fans the input out to every branch concurrently and fans the
+// outputs back in with the join function. Each branch receives a context
+// derived from the step's context; under FailFast it is cancelled as soon as
+// any branch fails.
+func Parallel(branches []Step, opts ...ParallelOption) Step {
+	cfg := parallelConfig{
+		policy: FailFast,
+		join: func(outputs []string) string {
+			return strings.Join(outputs, "\n\n")
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context, input string) (string, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		outputs := make([]string, len(branches))
+		errs := make([]error, len(branches))
+
+		limit := cfg.concurrency
+		if limit <= 0 || limit > len(branches) {
+			limit = len(branches)
+		}
+		sem := make(chan struct{}, limit)
+
+		var wg sync.WaitGroup
+		for i, branch := range branches {
+			wg.Go(func() {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					errs[i] = ctx.Err()
+					return
+				}
+
+				output, err := branch.Run(ctx, input)
+				if err != nil {
+					errs[i] = err
+					if cfg.policy == FailFast {
+						cancel()
+					}
+					return
+				}
+				outputs[i] = output
+			})
+		}
+		wg.Wait()
+
+		return cfg.fanIn(outputs, errs)
+	}
+}
+
+// fanIn applies the error policy to branch results and joins the outputs.
+func (c *parallelConfig) fanIn(outputs []string, errs []error) (string, error) {
+	var succeeded []string
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+			continue
+		}
+		succeeded = append(succeeded, outputs[i])
+	}
+
+	switch c.policy {
+	case FailFast:
+		// Report the root cause rather than the cancellations it triggered.
+		for _, err := range failed {
+			if !errors.Is(err, context.Canceled) {
+				return "", err
+			}
+		}
+		if len(failed) > 0 {
+			return "", failed[0]
+		}
+	case CollectAll:
+		if len(failed) > 0 {
+			return "", errors.Join(failed...)
+		}
+	case BestEffort:
+		if len(succeeded) == 0 && len(failed) > 0 {
+			return "", errors.Join(append([]error{ErrAllBranchesFailed}, failed...)...)
+		}
+	}
+
+	return c.join(succeeded), nil
+}
@@ -0,0 +1,98 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+)
+
+// Step is a unit of work in a workflow. It receives the previous step's
+// output and returns its own. Steps should honor context cancellation.
+type Step func(ctx context.Context, input string) (string, error)
+
+// Run executes the step with the given input.
+// Returns the context error without running the step if ctx is already done.
+func (s Step) Run(ctx context.Context, input string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return s(ctx, input)
+}
+
+// StepError identifies the step that failed within a workflow.
+type StepError struct {
+	Name string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %s: %v", e.Name, e.Err)
+}
+
+// Unwrap returns the underlying step error.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Named wraps a step so its errors are reported as a *StepError with the given name.
+func Named(name string, step Step) Step {
+	return func(ctx context.Context, input string) (string, error) {
+		output, err := step.Run(ctx, input)
+		if err != nil {
+			return "", &StepError{Name: name, Err: err}
+		}
+		return output, nil
+	}
+}
+
+// Sequence chains steps so each receives the previous step's output.
+// Stops at the first error or when the context is cancelled.
+// Errors from unnamed steps are reported as a *StepError named by position.
+func Sequence(steps ...Step) Step {
+	return func(ctx context.Context, input string) (string, error) {
+		output := input
+		for i, step := range steps {
+			next, err := step.Run(ctx, output)
+			if err != nil {
+				var named *StepError
+				if errors.As(err, &named) {
+					return "", err
+				}
+				return "", &StepError{Name: fmt.Sprintf("%d", i), Err: err}
+			}
+			output = next
+		}
+		return output, nil
+	}
+}
+
+// Prompt inserts the input into a prompt template, replacing each "%s"
+// (e.g., "Summarize the following:\n\n%s"). The rest of the template is
+// kept verbatim, so it may contain other % characters.
+func Prompt(template string) Step {
+	return func(ctx context.Context, input string) (string, error) {
+		return strings.ReplaceAll(template, "%s", input), nil
+	}
+}
+
+// Transform applies a function to the input that cannot fail.
+func Transform(fn func(string) string) Step {
+	return func(ctx context.Context, input string) (string, error) {
+		return fn(input), nil
+	}
+}
+
+// Chat sends the input as a prompt to the agent and returns the response content.
+// Options are passed to agent.Chat unchanged.
+func Chat(a agent.Chatter, opts ...map[string]any) Step {
+	return func(ctx context.Context, input string) (string, error) {
+		resp, err := a.Chat(ctx, input, opts...)
+		if err != nil {
+			return "", err
+		}
+		return resp.Content(), nil
+	}
+}
@@ -101,15 +101,7 @@ func TestAgent_Chat(t *testing.T) {
 		chatResp := response.ChatResponse{
 			Model: "test-model",
 		}
-		chatResp.Choices = append(chatResp.Choices, struct {
-			Index   int              `json:"index"`
-			Message protocol.Message `json:"message"`
-			Delta   *struct {
-				Role    string `json:"role,omitempty"`
-				Content string `json:"content,omitempty"`
-			} `json:"delta,omitempty"`
-			FinishReason string `json:"finish_reason,omitempty"`
-		}{
+		chatResp.Choices = append(chatResp.Choices, response.ChatChoice{
 			Index:   0,
 			Message: protocol.NewMessage("assistant", "Hello, how can I help you?"),
 		})
@@ -166,15 +158,7 @@ func TestAgent_Vision(t *testing.T) {
 		chatResp := response.ChatResponse{
 			Model: "test-model",
 		}
-		chatResp.Choices = append(chatResp.Choices, struct {
-			Index   int              `json:"index"`
-			Message protocol.Message `json:"message"`
-			Delta   *struct {
-				Role    string `json:"role,omitempty"`
-				Content string `json:"content,omitempty"`
-			} `json:"delta,omitempty"`
-			FinishReason string `json:"finish_reason,omitempty"`
-		}{
+		chatResp.Choices = append(chatResp.Choices, response.ChatChoice{
 			Index:   0,
 			Message: protocol.NewMessage("assistant", "I see a cat in the image."),
 		})
@@ -231,21 +215,9 @@ func TestAgent_Tools(t *testing.T) {
 		toolsResp := response.ToolsResponse{
 			Model: "test-model",
 		}
-		toolsResp.Choices = append(toolsResp.Choices, struct {
-			Index   int `json:"index"`
-			Message struct {
-				Role      string              `json:"role"`
-				Content   string              `json:"content"`
-				ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-			} `json:"message"`
-			FinishReason string `json:"finish_reason,omitempty"`
-		}{
+		toolsResp.Choices = append(toolsResp.Choices, response.ToolsChoice{
 			Index: 0,
-			Message: struct {
-				Role      string              `json:"role"`
-				Content   string              `json:"content"`
-				ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-			}{
+			Message: response.ToolsMessage{
 				Role:    "assistant",
 				Content: "",
 				ToolCalls: []response.ToolCall{
@@ -331,6 +303,88 @@ func TestAgent_Tools(t *testing.T) {
 	}
 }
 
+func TestAgent_VisionTools(t *testing.T) {
+	var body struct {
+		Messages []struct {
+			Content any `json:"content"`
+		} `json:"messages"`
+		Tools []any `json:"tools"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+
+		toolsResp := response.ToolsResponse{Model: "test-model"}
+		toolsResp.Choices = append(toolsResp.Choices, response.ToolsChoice{
+			Message: response.ToolsMessage{
+				Role: "assistant",
+				ToolCalls: []response.ToolCall{
+					{
+						ID:       "call_123",
+						Type:     "function",
+						Function: response.ToolCallFunction{Name: "click", Arguments: `{"x":10,"y":20}`},
+					},
+				},
+			},
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(toolsResp)
+	}))
+	defer server.Close()
+
+	cfg := &config.AgentConfig{
+		Name: "test-agent",
+		Client: &config.ClientConfig{
+			Timeout:            config.Duration(30 * time.Second),
+			ConnectionTimeout:  config.Duration(10 * time.Second),
+			ConnectionPoolSize: 10,
+		},
+		Provider: &config.ProviderConfig{
+			Name:    "ollama",
+			BaseURL: server.URL,
+		},
+		Model: &config.ModelConfig{
+			Name: "test-model",
+			Capabilities: map[string]map[string]any{
+				"tools": {},
+			},
+		},
+	}
+
+	a, err := agent.New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	tools := []agent.Tool{{Name: "click", Description: "Click at a screen position"}}
+	images := []string{"data:image/png;base64,iVBORw0KGgo="}
+
+	resp, err := a.VisionTools(context.Background(), "Click the login button", images, tools, map[string]any{
+		"vision_options": map[string]any{"detail": "low"},
+	})
+	if err != nil {
+		t.Fatalf("VisionTools failed: %v", err)
+	}
+
+	if calls := resp.ToolCalls(); len(calls) != 1 || calls[0].Function.Name != "click" {
+		t.Errorf("got tool calls %v, want click", calls)
+	}
+
+	if len(body.Tools) != 1 {
+		t.Errorf("got %d tools sent, want 1", len(body.Tools))
+	}
+
+	if len(body.Messages) == 0 {
+		t.Fatal("no messages sent")
+	}
+
+	content, ok := body.Messages[len(body.Messages)-1].Content.([]any)
+	if !ok || len(content) != 2 {
+		t.Errorf("got last message content %v, want text and image parts", body.Messages[len(body.Messages)-1].Content)
+	}
+}
+
 func TestAgent_Embed(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		embResp := response.EmbeddingsResponse{
@@ -515,3 +569,236 @@ func TestAgent_Model(t *testing.T) {
 		t.Errorf("got model name %q, want %q", mdl.Name, "test-model")
 	}
 }
+
+func TestAgent_ModelAliases(t *testing.T) {
+	var models []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		models = append(models, r.Host+" "+body.Model)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/embeddings" {
+			w.Write([]byte(`{"object":"list","model":"` + body.Model + `","data":[{"embedding":[0.1],"index":0,"object":"embedding"}]}`))
+			return
+		}
+		w.Write([]byte(`{"model":"` + body.Model + `","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}`))
+	}
+
+	primary := httptest.NewServer(http.HandlerFunc(handler))
+	defer primary.Close()
+	secondary := httptest.NewServer(http.HandlerFunc(handler))
+	defer secondary.Close()
+
+	cfg := &config.AgentConfig{
+		Name:     "test-agent",
+		Client:   &config.ClientConfig{Timeout: config.Duration(30 * time.Second)},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: primary.URL},
+		Model: &config.ModelConfig{
+			Name:         "default-model",
+			Capabilities: map[string]map[string]any{"chat": {}},
+		},
+		Aliases: map[string]*config.AliasConfig{
+			"fast": {Model: &config.ModelConfig{Name: "fast-model"}},
+			"smart": {
+				Provider: &config.ProviderConfig{Name: "ollama", BaseURL: secondary.URL},
+				Model:    &config.ModelConfig{Name: "smart-model"},
+			},
+			"embed": {Model: &config.ModelConfig{Name: "embed-model"}},
+		},
+		Routes: map[string]string{"embeddings": "embed"},
+	}
+
+	a, err := agent.New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := a.Chat(ctx, "Hello"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if _, err := a.Chat(ctx, "Hello", map[string]any{agent.ModelOption: "fast"}); err != nil {
+		t.Fatalf("Chat with fast alias failed: %v", err)
+	}
+	if _, err := a.Chat(ctx, "Hello", map[string]any{agent.ModelOption: "smart"}); err != nil {
+		t.Fatalf("Chat with smart alias failed: %v", err)
+	}
+	if _, err := a.Embed(ctx, "Hello"); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+
+	host := func(url string) string { return url[len("http://"):] }
+	expected := []string{
+		host(primary.URL) + " default-model",
+		host(primary.URL) + " fast-model",
+		host(secondary.URL) + " smart-model",
+		host(primary.URL) + " embed-model",
+	}
+
+	if len(models) != len(expected) {
+		t.Fatalf("got requests %v, want %v", models, expected)
+	}
+	for i := range expected {
+		if models[i] != expected[i] {
+			t.Errorf("request %d: got %q, want %q", i, models[i], expected[i])
+		}
+	}
+
+	if a.Model().Name != "default-model" {
+		t.Errorf("got default model %q, want %q", a.Model().Name, "default-model")
+	}
+
+	if _, err := a.Chat(ctx, "Hello", map[string]any{agent.ModelOption: "missing"}); err == nil {
+		t.Error("expected error for unknown model alias")
+	}
+}
+
+func TestNew_InvalidRoute(t *testing.T) {
+	cfg := &config.AgentConfig{
+		Name:     "test-agent",
+		Client:   &config.ClientConfig{Timeout: config.Duration(30 * time.Second)},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: "http://localhost:11434"},
+		Model:    &config.ModelConfig{Name: "default-model"},
+		Routes:   map[string]string{"embeddings": "missing"},
+	}
+
+	if _, err := agent.New(cfg); err == nil {
+		t.Error("expected error for route to unknown alias")
+	}
+}
+
+func TestAgent_CapabilityFormat(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "msg_1",
+			"model": "test-model",
+			"content": [{"type": "tool_use", "id": "toolu_1", "name": "get_weather", "input": {"city": "Paris"}}],
+			"stop_reason": "tool_use"
+		}`))
+	}))
+	defer server.Close()
+
+	a, err := agent.New(&config.AgentConfig{
+		Name:     "test-agent",
+		Client:   &config.ClientConfig{Timeout: config.Duration(5 * time.Second), ConnectionPoolSize: 1},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: server.URL},
+		Model: &config.ModelConfig{
+			Name: "test-model",
+			Capabilities: map[string]map[string]any{
+				"tools": {"format": "anthropic", "max_tokens": 256},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("agent.New failed: %v", err)
+	}
+
+	resp, err := a.Tools(context.Background(), "Weather in Paris?", []agent.Tool{{
+		Name:       "get_weather",
+		Parameters: map[string]any{"type": "object"},
+	}})
+	if err != nil {
+		t.Fatalf("Tools failed: %v", err)
+	}
+
+	tools, _ := received["tools"].([]any)
+	if len(tools) != 1 || tools[0].(map[string]any)["input_schema"] == nil {
+		t.Errorf("request tools = %v, want the Anthropic format", received["tools"])
+	}
+	if _, ok := received["format"]; ok {
+		t.Error("the wire format should not be sent in the request body")
+	}
+
+	if calls := resp.ToolCalls(); len(calls) != 1 || calls[0].Function.Name != "get_weather" {
+		t.Errorf("got tool calls %+v, want the Anthropic tool_use block parsed", calls)
+	}
+}
+
+func TestAgent_StreamIncludeUsage(t *testing.T) {
+	var received []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		received = append(received, body)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\ndata: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	includeUsage := true
+	a, err := agent.New(&config.AgentConfig{
+		Name: "test-agent",
+		Client: &config.ClientConfig{
+			Timeout:   config.Duration(5 * time.Second),
+			Streaming: config.StreamingConfig{IncludeUsage: &includeUsage},
+		},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: server.URL},
+		Model:    &config.ModelConfig{Name: "test-model"},
+	})
+	if err != nil {
+		t.Fatalf("agent.New failed: %v", err)
+	}
+
+	for _, opts := range [][]map[string]any{nil, {{"stream_options": map[string]any{"include_usage": false}}}} {
+		chunks, err := a.ChatStream(context.Background(), "Hello", opts...)
+		if err != nil {
+			t.Fatalf("ChatStream failed: %v", err)
+		}
+		for range chunks {
+		}
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("got %d requests, want 2", len(received))
+	}
+	if got, _ := received[0]["stream_options"].(map[string]any); got["include_usage"] != true {
+		t.Errorf("got stream_options %v, want include_usage requested", received[0]["stream_options"])
+	}
+	if got, _ := received[1]["stream_options"].(map[string]any); got["include_usage"] != false {
+		t.Errorf("got stream_options %v, want the request's own stream_options kept", received[1]["stream_options"])
+	}
+}
+
+func TestAgent_Supports(t *testing.T) {
+	a, err := agent.New(&config.AgentConfig{
+		Name:     "test-agent",
+		Client:   &config.ClientConfig{Timeout: config.Duration(30 * time.Second)},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: "http://localhost:11434"},
+		Model: &config.ModelConfig{
+			Name:     "default-model",
+			Supports: map[string]bool{config.FeatureVision: false},
+		},
+		Aliases: map[string]*config.AliasConfig{
+			"grok": {
+				Provider: &config.ProviderConfig{Name: "xai", Options: map[string]any{"token": "secret"}},
+				Model:    &config.ModelConfig{Name: "grok-model"},
+			},
+		},
+		Routes: map[string]string{"embeddings": "grok"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	tests := []struct {
+		proto protocol.Protocol
+		want  bool
+	}{
+		{protocol.Chat, true},
+		{protocol.Tools, true},
+		{protocol.Vision, false},     // disabled by the model
+		{protocol.Embeddings, false}, // routed to a provider without embeddings
+		{protocol.Protocol("audio"), false},
+	}
+	for _, tt := range tests {
+		if got := a.Supports(tt.proto); got != tt.want {
+			t.Errorf("Supports(%s) = %v, want %v", tt.proto, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// embedServer returns an agent whose server embeds each input "n" as [n]
+// in reverse response order, failing the requests for which fail returns
+// true.
+func embedServer(t *testing.T, fail func(inputs []string) bool) (agent.Agent, *atomic.Int64) {
+	t.Helper()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var body struct {
+			Input []string `json:"input"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if fail != nil && fail(body.Input) {
+			http.Error(w, `{"error":{"message":"bad request"}}`, http.StatusBadRequest)
+			return
+		}
+
+		resp := response.EmbeddingsResponse{Object: "list", Model: "embed"}
+		for i := len(body.Input) - 1; i >= 0; i-- {
+			n, _ := strconv.Atoi(body.Input[i])
+			resp.Data = append(resp.Data, response.EmbeddingData{Index: i, Embedding: []float64{float64(n)}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	a, err := agent.New(&config.AgentConfig{
+		Name: "test-agent",
+		Client: &config.ClientConfig{
+			Timeout:            config.Duration(30 * time.Second),
+			ConnectionTimeout:  config.Duration(10 * time.Second),
+			ConnectionPoolSize: 10,
+		},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: server.URL},
+		Model:    &config.ModelConfig{Name: "embed"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return a, &requests
+}
+
+func feed(n int) <-chan string {
+	inputs := make(chan string)
+	go func() {
+		defer close(inputs)
+		for i := range n {
+			inputs <- strconv.Itoa(i)
+		}
+	}()
+	return inputs
+}
+
+func TestEmbedAll(t *testing.T) {
+	a, requests := embedServer(t, nil)
+
+	results := agent.EmbedAll(context.Background(), a, feed(10), agent.WithBatchSize(4), agent.WithConcurrency(2))
+
+	seen := make([]bool, 10)
+	for result := range results {
+		if result.Err != nil {
+			t.Fatalf("input %d: %v", result.Index, result.Err)
+		}
+		if result.Input != strconv.Itoa(result.Index) {
+			t.Errorf("result %d has input %q", result.Index, result.Input)
+		}
+		if !slices.Equal(result.Vector, []float64{float64(result.Index)}) {
+			t.Errorf("result %d has vector %v", result.Index, result.Vector)
+		}
+		seen[result.Index] = true
+	}
+
+	if slices.Contains(seen, false) {
+		t.Errorf("missing results: %v", seen)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 batch requests, got %d", got)
+	}
+}
+
+func TestEmbedAll_RetriesFailedBatch(t *testing.T) {
+	var mu sync.Mutex
+	failed := make(map[string]bool)
+	a, requests := embedServer(t, func(inputs []string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if inputs[0] == "0" && !failed["0"] {
+			failed["0"] = true
+			return true
+		}
+		return false
+	})
+
+	results := agent.EmbedAll(context.Background(), a, feed(4), agent.WithBatchSize(2), agent.WithBatchRetries(1, time.Millisecond))
+
+	count := 0
+	for result := range results {
+		if result.Err != nil {
+			t.Errorf("input %d: %v", result.Index, result.Err)
+		}
+		count++
+	}
+	if count != 4 {
+		t.Errorf("expected 4 results, got %d", count)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 requests including the retry, got %d", got)
+	}
+}
+
+func TestEmbedAll_ReportsBatchError(t *testing.T) {
+	a, requests := embedServer(t, func(inputs []string) bool { return inputs[0] == "2" })
+
+	results := agent.EmbedAll(context.Background(), a, feed(4), agent.WithBatchSize(2), agent.WithBatchRetries(1, time.Millisecond))
+
+	var failed []int
+	for result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Index)
+		}
+	}
+	slices.Sort(failed)
+	if !slices.Equal(failed, []int{2, 3}) {
+		t.Errorf("expected inputs 2 and 3 to fail, got %v", failed)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestEmbedAll_Cancel(t *testing.T) {
+	a, _ := embedServer(t, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	inputs := make(chan string)
+	results := agent.EmbedAll(ctx, a, inputs)
+	cancel()
+
+	select {
+	case _, ok := <-results:
+		for ok {
+			_, ok = <-results
+		}
+	case <-time.After(time.Second):
+		t.Fatal("results channel not closed after cancellation")
+	}
+}
@@ -0,0 +1,94 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestWithMetadata(t *testing.T) {
+	ctx := agent.WithMetadata(context.Background(), map[string]string{"tenant": "acme", "user": "u-1"})
+	ctx = agent.WithMetadata(ctx, map[string]string{"user": "u-2"})
+
+	md := agent.Metadata(ctx)
+	if md["tenant"] != "acme" || md["user"] != "u-2" {
+		t.Errorf("got %v, want tenant kept and user overridden", md)
+	}
+
+	md["tenant"] = "changed"
+	if agent.Metadata(ctx)["tenant"] != "acme" {
+		t.Error("Metadata returned shared map; want a copy")
+	}
+
+	if agent.Metadata(context.Background()) != nil {
+		t.Error("expected nil metadata for bare context")
+	}
+}
+
+func TestAgent_MetadataForwarding(t *testing.T) {
+	var body map[string]any
+	var tenant string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		tenant = r.Header.Get("X-Tenant-ID")
+
+		chatResp := response.ChatResponse{Model: "test-model"}
+		chatResp.Choices = append(chatResp.Choices, response.ChatChoice{
+			Message: protocol.NewMessage("assistant", "ok"),
+		})
+		json.NewEncoder(w).Encode(chatResp)
+	}))
+	defer server.Close()
+
+	cfg := &config.AgentConfig{
+		Name: "test-agent",
+		Client: &config.ClientConfig{
+			Timeout:            config.Duration(30 * time.Second),
+			ConnectionTimeout:  config.Duration(10 * time.Second),
+			ConnectionPoolSize: 10,
+		},
+		Provider: &config.ProviderConfig{
+			Name:    "ollama",
+			BaseURL: server.URL,
+			Options: map[string]any{
+				"metadata_user":    "user",
+				"metadata_headers": map[string]any{"tenant": "X-Tenant-ID"},
+			},
+		},
+		Model: &config.ModelConfig{Name: "test-model"},
+	}
+
+	a, err := agent.New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := agent.WithMetadata(context.Background(), map[string]string{"tenant": "acme", "user": "u-42"})
+	if _, err := a.Chat(ctx, "Hello"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	if body["user"] != "u-42" {
+		t.Errorf("got user field %v, want u-42", body["user"])
+	}
+	if tenant != "acme" {
+		t.Errorf("got X-Tenant-ID %q, want acme", tenant)
+	}
+
+	if _, err := a.Chat(context.Background(), "Hello"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if _, ok := body["user"]; ok || tenant != "" {
+		t.Errorf("got user %v tenant %q, want nothing forwarded without metadata", body["user"], tenant)
+	}
+}
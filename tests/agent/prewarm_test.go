@@ -0,0 +1,92 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func TestAgent_Prewarm(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/generate" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		w.Write([]byte(`{"done":true}`))
+	}))
+	defer server.Close()
+
+	a, err := agent.New(&config.AgentConfig{
+		Name:     "prewarm-agent",
+		Client:   &config.ClientConfig{Timeout: config.Duration(10 * time.Second)},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: server.URL},
+		Model: &config.ModelConfig{
+			Name: "llama3.2",
+			Capabilities: map[string]map[string]any{
+				"chat": {"keep_alive": "1h"},
+			},
+		},
+		Aliases: map[string]*config.AliasConfig{
+			"embedder": {Model: &config.ModelConfig{Name: "nomic-embed-text"}},
+		},
+		Routes: map[string]string{"embeddings": "embedder"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if err := a.Prewarm(context.Background()); err != nil {
+		t.Fatalf("Prewarm failed: %v", err)
+	}
+
+	// The chat, vision, and tools routes share one model
+	if len(bodies) != 2 {
+		t.Fatalf("got %d prewarm requests, want 2: %v", len(bodies), bodies)
+	}
+	if bodies[0]["model"] != "llama3.2" || bodies[0]["keep_alive"] != "1h" {
+		t.Errorf("first prewarm = %v, want llama3.2 with keep_alive 1h", bodies[0])
+	}
+	if bodies[1]["model"] != "nomic-embed-text" {
+		t.Errorf("second prewarm = %v, want nomic-embed-text", bodies[1])
+	}
+	if _, ok := bodies[1]["keep_alive"]; ok {
+		t.Errorf("second prewarm = %v, want no keep_alive", bodies[1])
+	}
+}
+
+func TestAgent_Prewarm_NotSupported(t *testing.T) {
+	a, err := agent.New(&config.AgentConfig{
+		Name:   "azure-agent",
+		Client: &config.ClientConfig{Timeout: config.Duration(10 * time.Second)},
+		Provider: &config.ProviderConfig{
+			Name:    "azure",
+			BaseURL: "https://example.openai.azure.com/openai",
+			Options: map[string]any{"deployment": "gpt-4o", "api_version": "2024-10-21", "auth_type": "api_key", "token": "key"},
+		},
+		Model: &config.ModelConfig{Name: "gpt-4o"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if err := a.Prewarm(context.Background()); !errors.Is(err, providers.ErrPrewarmNotSupported) {
+		t.Errorf("got %v, want ErrPrewarmNotSupported", err)
+	}
+}
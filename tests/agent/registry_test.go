@@ -0,0 +1,40 @@
+package agent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+)
+
+func TestRegistry(t *testing.T) {
+	r := agent.NewRegistry()
+
+	second := mock.NewMockAgent(mock.WithID("agent-b"))
+	first := mock.NewMockAgent(mock.WithID("agent-a"))
+
+	for _, a := range []agent.Agent{second, first} {
+		if err := r.Register(a); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	if err := r.Register(mock.NewMockAgent(mock.WithID("agent-a"))); !errors.Is(err, agent.ErrAgentExists) {
+		t.Errorf("got error %v, want %v", err, agent.ErrAgentExists)
+	}
+
+	if got, ok := r.Get("agent-a"); !ok || got != first {
+		t.Error("Get did not return the registered agent")
+	}
+
+	list := r.List()
+	if len(list) != 2 || list[0].ID() != "agent-a" || list[1].ID() != "agent-b" {
+		t.Errorf("got %d agents, want agent-a and agent-b in order", len(list))
+	}
+
+	r.Unregister("agent-a")
+	if _, ok := r.Get("agent-a"); ok {
+		t.Error("agent still registered after Unregister")
+	}
+}
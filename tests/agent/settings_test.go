@@ -0,0 +1,180 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// recordingServer answers chat requests and hands each decoded request body
+// to the returned channel.
+func recordingServer(t *testing.T) (*httptest.Server, <-chan map[string]any) {
+	t.Helper()
+	bodies := make(chan map[string]any, 64)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		select {
+		case bodies <- body:
+		default:
+		}
+
+		resp := response.ChatResponse{Model: "test-model"}
+		resp.Choices = append(resp.Choices, response.ChatChoice{
+			Message: protocol.NewMessage("assistant", "ok"),
+		})
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server, bodies
+}
+
+func newSettingsAgent(t *testing.T, url string) agent.Agent {
+	t.Helper()
+	a, err := agent.New(&config.AgentConfig{
+		Name:         "settings-agent",
+		SystemPrompt: "configured prompt",
+		Client: &config.ClientConfig{
+			Timeout:            config.Duration(30 * time.Second),
+			ConnectionTimeout:  config.Duration(10 * time.Second),
+			ConnectionPoolSize: 10,
+		},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: url},
+		Model: &config.ModelConfig{
+			Name: "test-model",
+			Capabilities: map[string]map[string]any{
+				"chat": {"temperature": 0.7, "max_tokens": 100},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return a
+}
+
+func systemMessage(body map[string]any) (string, bool) {
+	messages, _ := body["messages"].([]any)
+	if len(messages) == 0 {
+		return "", false
+	}
+	first, _ := messages[0].(map[string]any)
+	if first["role"] != "system" {
+		return "", false
+	}
+	content, _ := first["content"].(string)
+	return content, true
+}
+
+func TestAgent_SetSystemPrompt(t *testing.T) {
+	server, bodies := recordingServer(t)
+	a := newSettingsAgent(t, server.URL)
+	ctx := context.Background()
+
+	if _, err := a.Chat(ctx, "hi"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if got, _ := systemMessage(<-bodies); got != "configured prompt" {
+		t.Errorf("system prompt = %q, want %q", got, "configured prompt")
+	}
+
+	a.SetSystemPrompt("updated prompt")
+	if _, err := a.Chat(ctx, "hi"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if got, _ := systemMessage(<-bodies); got != "updated prompt" {
+		t.Errorf("system prompt = %q, want %q", got, "updated prompt")
+	}
+
+	a.SetSystemPrompt("")
+	if _, err := a.Chat(ctx, "hi"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if got, ok := systemMessage(<-bodies); ok {
+		t.Errorf("got system message %q after clearing the prompt", got)
+	}
+}
+
+func TestAgent_SetDefaultOptions(t *testing.T) {
+	server, bodies := recordingServer(t)
+	a := newSettingsAgent(t, server.URL)
+	ctx := context.Background()
+
+	a.SetDefaultOptions(protocol.Chat, map[string]any{"temperature": 0.2})
+	if _, err := a.Chat(ctx, "hi"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	body := <-bodies
+	if body["temperature"] != 0.2 {
+		t.Errorf("temperature = %v, want 0.2", body["temperature"])
+	}
+	if _, ok := body["max_tokens"]; ok {
+		t.Errorf("max_tokens = %v, want the configured options replaced", body["max_tokens"])
+	}
+
+	if _, err := a.Chat(ctx, "hi", map[string]any{"temperature": 0.9}); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if body := <-bodies; body["temperature"] != 0.9 {
+		t.Errorf("temperature = %v, want runtime option 0.9", body["temperature"])
+	}
+
+	a.SetDefaultOptions(protocol.Chat, nil)
+	if _, err := a.Chat(ctx, "hi"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	body = <-bodies
+	if body["temperature"] != 0.7 || body["max_tokens"] != float64(100) {
+		t.Errorf("options = temperature %v, max_tokens %v, want the configured 0.7 and 100", body["temperature"], body["max_tokens"])
+	}
+}
+
+func TestAgent_SetDefaultOptions_CopiesOptions(t *testing.T) {
+	server, bodies := recordingServer(t)
+	a := newSettingsAgent(t, server.URL)
+
+	opts := map[string]any{"temperature": 0.2}
+	a.SetDefaultOptions(protocol.Chat, opts)
+	opts["temperature"] = 0.5
+
+	if _, err := a.Chat(context.Background(), "hi"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if body := <-bodies; body["temperature"] != 0.2 {
+		t.Errorf("temperature = %v, want 0.2 from the options as set", body["temperature"])
+	}
+}
+
+func TestAgent_SettingsConcurrentUse(t *testing.T) {
+	server, _ := recordingServer(t)
+	a := newSettingsAgent(t, server.URL)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 5 {
+				a.SetSystemPrompt(fmt.Sprintf("prompt %d-%d", i, j))
+				a.SetDefaultOptions(protocol.Chat, map[string]any{"temperature": float64(j) / 10})
+			}
+		})
+		wg.Go(func() {
+			for range 5 {
+				if _, err := a.Chat(context.Background(), "hi"); err != nil {
+					t.Errorf("Chat failed: %v", err)
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
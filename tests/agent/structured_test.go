@@ -0,0 +1,136 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/schema"
+)
+
+// structuredAgent returns an agent whose server replies with outputs in turn
+// and records each request's last user message.
+func structuredAgent(t *testing.T, outputs ...string) (agent.Agent, *[]string) {
+	t.Helper()
+
+	var prompts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages       []protocol.Message `json:"messages"`
+			ResponseFormat map[string]any     `json:"response_format"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.ResponseFormat["type"] != "json_schema" {
+			t.Errorf("expected json_schema response_format, got %v", body.ResponseFormat)
+		}
+		prompts = append(prompts, body.Messages[len(body.Messages)-1].Content.(string))
+
+		chatResp := response.ChatResponse{Model: "test-model"}
+		chatResp.Choices = append(chatResp.Choices, response.ChatChoice{
+			Message: protocol.NewMessage("assistant", outputs[min(len(prompts), len(outputs))-1]),
+		})
+		json.NewEncoder(w).Encode(chatResp)
+	}))
+	t.Cleanup(server.Close)
+
+	a, err := agent.New(&config.AgentConfig{
+		Name: "test-agent",
+		Client: &config.ClientConfig{
+			Timeout:            config.Duration(30 * time.Second),
+			ConnectionTimeout:  config.Duration(10 * time.Second),
+			ConnectionPoolSize: 10,
+		},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: server.URL},
+		Model:    &config.ModelConfig{Name: "test-model"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return a, &prompts
+}
+
+var citySchema = schema.MustNew("city", map[string]any{
+	"type":     "object",
+	"required": []any{"name", "population"},
+	"properties": map[string]any{
+		"name":       map[string]any{"type": "string"},
+		"population": map[string]any{"type": "integer"},
+	},
+})
+
+func TestChatStructured_Retry(t *testing.T) {
+	a, prompts := structuredAgent(t, `{"name": "Oslo"}`, `{"name": "Oslo", "population": 700000}`)
+
+	var attempts []agent.StructuredAttempt
+	ctx := agent.WithStructuredHook(context.Background(), func(a agent.StructuredAttempt) {
+		attempts = append(attempts, a)
+	})
+
+	out, err := agent.ChatStructured(ctx, a, "Describe Oslo", citySchema)
+	if err != nil {
+		t.Fatalf("ChatStructured failed: %v", err)
+	}
+	if string(out) != `{"name": "Oslo", "population": 700000}` {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	if len(*prompts) != 2 || (*prompts)[0] != "Describe Oslo" {
+		t.Fatalf("unexpected prompts: %q", *prompts)
+	}
+	if !strings.Contains((*prompts)[1], `missing required property "population"`) {
+		t.Errorf("expected retry prompt to include the error, got %q", (*prompts)[1])
+	}
+
+	if len(attempts) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(attempts))
+	}
+	var invalid *schema.ValidationError
+	if attempts[0].Attempt != 1 || !errors.As(attempts[0].Err, &invalid) {
+		t.Errorf("expected first attempt to be invalid, got %+v", attempts[0])
+	}
+	if attempts[1].Attempt != 2 || attempts[1].Err != nil || attempts[1].Schema != "city" {
+		t.Errorf("expected second attempt to be valid, got %+v", attempts[1])
+	}
+}
+
+func TestChatStructured_Exhausted(t *testing.T) {
+	a, prompts := structuredAgent(t, `not json`)
+
+	_, err := agent.ChatStructured(context.Background(), a, "Describe Oslo", citySchema, map[string]any{
+		agent.StructuredRetriesOption: 1,
+	})
+
+	var syntax *schema.SyntaxError
+	if !errors.As(err, &syntax) {
+		t.Fatalf("expected *schema.SyntaxError, got %T: %v", err, err)
+	}
+	if !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(*prompts) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(*prompts))
+	}
+}
+
+func TestChatStructured_InvalidRetries(t *testing.T) {
+	a, prompts := structuredAgent(t, `{}`)
+
+	_, err := agent.ChatStructured(context.Background(), a, "Describe Oslo", citySchema, map[string]any{
+		agent.StructuredRetriesOption: -1,
+	})
+	if err == nil || !strings.Contains(err.Error(), agent.StructuredRetriesOption) {
+		t.Errorf("expected invalid retries error, got %v", err)
+	}
+	if len(*prompts) != 0 {
+		t.Errorf("expected no requests, got %d", len(*prompts))
+	}
+}
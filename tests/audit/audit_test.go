@@ -0,0 +1,255 @@
+package audit_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/audit"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// recorder is a Sink that keeps records in memory.
+type recorder struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+func (r *recorder) Write(rec audit.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, rec)
+	return nil
+}
+
+func chatAgent() *mock.MockAgent {
+	return mock.NewMockAgent(
+		mock.WithID("agent-1"),
+		mock.WithChatResponse(&response.ChatResponse{
+			Model:   "served-model",
+			Choices: []response.ChatChoice{{Message: protocol.NewMessage("assistant", "Hello there")}},
+			Usage:   &response.TokenUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+		}, nil),
+		mock.WithStreamChunks([]response.StreamingChunk{
+			{Model: "served-model", Choices: []response.StreamingChoice{{Delta: response.StreamingDelta{Content: "Hello "}}}},
+			{Model: "served-model", Choices: []response.StreamingChoice{{Delta: response.StreamingDelta{Content: "there"}}}},
+		}, nil),
+	)
+}
+
+func TestWrap_Chat(t *testing.T) {
+	sink := &recorder{}
+	a := audit.Wrap(chatAgent(), sink)
+
+	resp, err := a.Chat(context.Background(), "Hi")
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if resp.Content() != "Hello there" {
+		t.Errorf("got content %q, want response passed through", resp.Content())
+	}
+
+	if len(sink.records) != 1 {
+		t.Fatalf("got %d records, want 1", len(sink.records))
+	}
+
+	rec := sink.records[0]
+	if rec.AgentID != "agent-1" || rec.Protocol != protocol.Chat || rec.Model != "served-model" {
+		t.Errorf("got record %+v", rec)
+	}
+	if rec.Prompt != "" || rec.PromptHash == "" {
+		t.Errorf("got prompt %q hash %q, want hash only by default", rec.Prompt, rec.PromptHash)
+	}
+	if rec.ResponseDigest == "" || rec.Usage == nil || rec.Usage.TotalTokens != 5 {
+		t.Errorf("got digest %q usage %v", rec.ResponseDigest, rec.Usage)
+	}
+	if rec.Timestamp.IsZero() || rec.Error != "" {
+		t.Errorf("got timestamp %v error %q", rec.Timestamp, rec.Error)
+	}
+
+	resp.Usage.TotalTokens = 99
+	if rec.Usage.TotalTokens != 5 {
+		t.Error("record usage changed with the response; want an independent copy")
+	}
+}
+
+func TestWrap_PromptPolicy(t *testing.T) {
+	tests := []struct {
+		policy   audit.PromptPolicy
+		wantText bool
+		wantHash bool
+	}{
+		{policy: audit.PromptHash, wantHash: true},
+		{policy: audit.PromptFull, wantText: true, wantHash: true},
+		{policy: audit.PromptOmit},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			sink := &recorder{}
+			audit.Wrap(chatAgent(), sink, audit.WithPromptPolicy(tt.policy)).Chat(context.Background(), "secret")
+
+			rec := sink.records[0]
+			if (rec.Prompt == "secret") != tt.wantText {
+				t.Errorf("got prompt %q, want text recorded: %v", rec.Prompt, tt.wantText)
+			}
+			if (rec.PromptHash != "") != tt.wantHash {
+				t.Errorf("got hash %q, want hash recorded: %v", rec.PromptHash, tt.wantHash)
+			}
+		})
+	}
+}
+
+func TestWrap_Error(t *testing.T) {
+	sink := &recorder{}
+	a := audit.Wrap(mock.NewFailingAgent("agent-1", errors.New("provider unavailable")), sink)
+
+	if _, err := a.Embed(context.Background(), "text"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	rec := sink.records[0]
+	if rec.Protocol != protocol.Embeddings || rec.Error != "provider unavailable" || rec.ResponseDigest != "" {
+		t.Errorf("got record %+v", rec)
+	}
+}
+
+func TestWrap_Redaction(t *testing.T) {
+	policy, err := redact.New(redact.Config{
+		Patterns: []string{`acct-\d+`},
+		Prompts:  redact.PromptFull,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	sink := &recorder{}
+	audit.Wrap(chatAgent(), sink, audit.WithRedaction(policy)).Chat(context.Background(), "close acct-1234")
+
+	rec := sink.records[0]
+	if rec.Prompt != "close "+redact.Redacted || rec.PromptHash == "" {
+		t.Errorf("got prompt %q and hash %q, want the redacted full prompt", rec.Prompt, rec.PromptHash)
+	}
+
+	sink = &recorder{}
+	failing := mock.NewFailingAgent("agent-1", errors.New("account acct-1234 is locked"))
+	audit.Wrap(failing, sink, audit.WithRedaction(policy), audit.WithPromptPolicy(audit.PromptOmit)).Chat(context.Background(), "close acct-1234")
+
+	rec = sink.records[0]
+	if rec.Error != "account "+redact.Redacted+" is locked" {
+		t.Errorf("got error %q, want it redacted", rec.Error)
+	}
+	if rec.Prompt != "" || rec.PromptHash != "" {
+		t.Errorf("got prompt %q and hash %q, want WithPromptPolicy to take precedence", rec.Prompt, rec.PromptHash)
+	}
+}
+
+func TestWrap_StreamDigestMatchesChat(t *testing.T) {
+	sink := &recorder{}
+	a := audit.Wrap(chatAgent(), sink)
+
+	a.Chat(context.Background(), "Hi")
+
+	chunks, err := a.ChatStream(context.Background(), "Hi")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+	if _, err := response.Collect(context.Background(), chunks); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if len(sink.records) != 2 {
+		t.Fatalf("got %d records, want 2", len(sink.records))
+	}
+
+	chat, stream := sink.records[0], sink.records[1]
+	if !stream.Stream || stream.Model != "served-model" {
+		t.Errorf("got stream record %+v", stream)
+	}
+	if stream.ResponseDigest != chat.ResponseDigest {
+		t.Errorf("got stream digest %s, want %s", stream.ResponseDigest, chat.ResponseDigest)
+	}
+}
+
+func TestWrap_VisionImages(t *testing.T) {
+	sink := &recorder{}
+	a := mock.NewMockAgent(mock.WithVisionResponse(&response.ChatResponse{}, nil))
+	audit.Wrap(a, sink).Vision(context.Background(), "Describe", []string{"a.png", "b.png"})
+
+	if rec := sink.records[0]; rec.Protocol != protocol.Vision || rec.Images != 2 {
+		t.Errorf("got protocol %s images %d, want vision with 2 images", rec.Protocol, rec.Images)
+	}
+}
+
+func TestWrap_Metadata(t *testing.T) {
+	sink := &recorder{}
+	ctx := agent.WithMetadata(context.Background(), map[string]string{"tenant": "acme"})
+	audit.Wrap(chatAgent(), sink).Chat(ctx, "Hi")
+
+	if got := sink.records[0].Metadata["tenant"]; got != "acme" {
+		t.Errorf("got tenant %q, want acme", got)
+	}
+}
+
+func TestWrap_SinkError(t *testing.T) {
+	sinkErr := errors.New("disk full")
+	var handled error
+
+	a := audit.Wrap(chatAgent(), audit.SinkFunc(func(rec audit.Record) error {
+		return sinkErr
+	}), audit.WithErrorHandler(func(err error) {
+		handled = err
+	}))
+
+	if _, err := a.Chat(context.Background(), "Hi"); err != nil {
+		t.Fatalf("got error %v, want sink failure not to fail the call", err)
+	}
+	if !errors.Is(handled, sinkErr) {
+		t.Errorf("got handled error %v, want %v", handled, sinkErr)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	sink, err := audit.NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink failed: %v", err)
+	}
+	a := audit.Wrap(chatAgent(), sink)
+	a.Chat(context.Background(), "one")
+	a.Chat(context.Background(), "two")
+	sink.Close()
+
+	info, _ := os.Stat(path)
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("got permissions %o, want 0600", perm)
+	}
+
+	f, _ := os.Open(path)
+	defer f.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec audit.Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %d is not a JSON record: %v", lines, err)
+		}
+		lines++
+	}
+	if lines != 2 {
+		t.Errorf("got %d lines, want 2", lines)
+	}
+}
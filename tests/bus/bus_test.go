@@ -0,0 +1,145 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/bus"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+)
+
+func newBus(t *testing.T, ids ...string) *bus.Bus {
+	t.Helper()
+
+	registry := agent.NewRegistry()
+	for _, id := range ids {
+		if err := registry.Register(mock.NewMockAgent(mock.WithID(id))); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	return bus.New(registry)
+}
+
+func TestBus_Request(t *testing.T) {
+	b := newBus(t, "planner", "researcher")
+
+	err := b.Handle("researcher", func(ctx context.Context, msg bus.Message) (any, error) {
+		question, err := bus.Payload[string](msg)
+		if err != nil {
+			return nil, err
+		}
+		return "answer to " + question, nil
+	})
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	request := bus.Message{From: "planner", To: "researcher", Type: "question", Payload: "RAG"}
+	reply, err := b.Request(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	answer, err := bus.Payload[string](reply)
+	if err != nil {
+		t.Fatalf("Payload failed: %v", err)
+	}
+
+	if answer != "answer to RAG" {
+		t.Errorf("got %q, want %q", answer, "answer to RAG")
+	}
+
+	if reply.From != "researcher" || reply.To != "planner" {
+		t.Errorf("got reply from %q to %q, want researcher to planner", reply.From, reply.To)
+	}
+
+	if reply.CorrelationID == "" {
+		t.Error("reply has no correlation ID")
+	}
+}
+
+func TestBus_SendErrors(t *testing.T) {
+	b := newBus(t, "idle")
+
+	tests := []struct {
+		name string
+		to   string
+		want error
+	}{
+		{name: "unknown agent", to: "missing", want: bus.ErrUnknownAgent},
+		{name: "no handler", to: "idle", want: bus.ErrNoHandler},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := b.Send(context.Background(), bus.Message{To: tt.to})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := b.Handle("missing", nil); !errors.Is(err, bus.ErrUnknownAgent) {
+		t.Errorf("got error %v, want %v", err, bus.ErrUnknownAgent)
+	}
+}
+
+func TestBus_Publish(t *testing.T) {
+	b := newBus(t)
+
+	var received []string
+	subscriber := func(name string) bus.Handler {
+		return func(ctx context.Context, msg bus.Message) (any, error) {
+			received = append(received, name+":"+msg.Payload.(string))
+			return nil, nil
+		}
+	}
+
+	unsubscribe := b.Subscribe("status", subscriber("first"))
+	b.Subscribe("status", subscriber("second"))
+	b.Subscribe("other", subscriber("other"))
+
+	delivered, err := b.Publish(context.Background(), bus.Message{Topic: "status", Payload: "ready"})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if delivered != 2 || len(received) != 2 {
+		t.Errorf("got %d delivered, %v received, want 2", delivered, received)
+	}
+
+	unsubscribe()
+	received = nil
+
+	delivered, _ = b.Publish(context.Background(), bus.Message{Topic: "status", Payload: "done"})
+	if delivered != 1 || len(received) != 1 || received[0] != "second:done" {
+		t.Errorf("got %d delivered, %v received, want only second", delivered, received)
+	}
+}
+
+func TestBus_PublishJoinsErrors(t *testing.T) {
+	b := newBus(t)
+	failure := errors.New("subscriber failed")
+
+	b.Subscribe("events", func(ctx context.Context, msg bus.Message) (any, error) { return nil, failure })
+	called := false
+	b.Subscribe("events", func(ctx context.Context, msg bus.Message) (any, error) { called = true; return nil, nil })
+
+	_, err := b.Publish(context.Background(), bus.Message{Topic: "events"})
+	if !errors.Is(err, failure) {
+		t.Errorf("got error %v, want %v", err, failure)
+	}
+
+	if !called {
+		t.Error("remaining subscriber not called after a failure")
+	}
+}
+
+func TestPayload_WrongType(t *testing.T) {
+	_, err := bus.Payload[int](bus.Message{Payload: "text"})
+	if !errors.Is(err, bus.ErrPayloadType) {
+		t.Errorf("got error %v, want %v", err, bus.ErrPayloadType)
+	}
+}
@@ -0,0 +1,282 @@
+package cassette_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/cassette"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func newAgent(t *testing.T, baseURL string, rt http.RoundTripper) agent.Agent {
+	t.Helper()
+
+	a, err := agent.New(&config.AgentConfig{
+		Name: "cassette-agent",
+		Client: &config.ClientConfig{
+			Timeout:            config.Duration(5 * time.Second),
+			ConnectionTimeout:  config.Duration(time.Second),
+			ConnectionPoolSize: 2,
+			Transport:          rt,
+		},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: baseURL},
+		Model:    &config.ModelConfig{Name: "test-model"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return a
+}
+
+func chatResponse(content string) *response.ChatResponse {
+	resp := &response.ChatResponse{Model: "test-model"}
+	resp.Choices = append(resp.Choices, response.ChatChoice{
+		Message: protocol.NewMessage("assistant", content),
+	})
+	return resp
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures", "chat.json")
+
+	srv := mock.NewServer(mock.WithServerChatResponses(chatResponse("first"), chatResponse("second")))
+	baseURL := srv.URL
+
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	a := newAgent(t, baseURL, rec)
+	for _, want := range []string{"first", "second"} {
+		resp, err := a.Chat(context.Background(), "Hello")
+		if err != nil {
+			t.Fatalf("Chat failed: %v", err)
+		}
+		if resp.Content() != want {
+			t.Errorf("got content %q, want %q", resp.Content(), want)
+		}
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	srv.Close()
+
+	replay, err := cassette.New(path, cassette.ModeReplay)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	a = newAgent(t, baseURL, replay)
+	for _, want := range []string{"first", "second"} {
+		resp, err := a.Chat(context.Background(), "Hello")
+		if err != nil {
+			t.Fatalf("replayed Chat failed: %v", err)
+		}
+		if resp.Content() != want {
+			t.Errorf("got replayed content %q, want %q", resp.Content(), want)
+		}
+	}
+
+	_, err = a.Chat(context.Background(), "Hello")
+	if !errors.Is(err, cassette.ErrNoInteraction) {
+		t.Errorf("got error %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestRecorder_ReplayStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stream.json")
+
+	srv := mock.NewServer()
+	baseURL := srv.URL
+
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	collect := func(a agent.Agent) string {
+		t.Helper()
+		chunks, err := a.ChatStream(context.Background(), "Hello")
+		if err != nil {
+			t.Fatalf("ChatStream failed: %v", err)
+		}
+		var content string
+		for chunk := range chunks {
+			if chunk.Error != nil {
+				t.Fatalf("stream error: %v", chunk.Error)
+			}
+			content += chunk.Content()
+		}
+		return content
+	}
+
+	recorded := collect(newAgent(t, baseURL, rec))
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	srv.Close()
+
+	c, err := cassette.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(c.Interactions) != 1 || !strings.Contains(c.Interactions[0].Response.Body, "data: [DONE]") {
+		t.Fatalf("got interactions %+v, want one raw SSE body", c.Interactions)
+	}
+
+	replay, err := cassette.New(path, cassette.ModeReplay)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if got := collect(newAgent(t, baseURL, replay)); got != recorded {
+		t.Errorf("got replayed content %q, want %q", got, recorded)
+	}
+}
+
+func TestRecorder_Sanitization(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret.json")
+
+	srv := mock.NewServer()
+	defer srv.Close()
+
+	redact := []cassette.Option{
+		cassette.WithRedactedHeaders("X-Tenant"),
+		cassette.WithRedactedFields("user"),
+	}
+
+	rec, err := cassette.New(path, cassette.ModeRecord, redact...)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	httpClient := &http.Client{Transport: rec}
+	body := `{"model":"test-model","messages":[],"user":"alice@example.com"}`
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/v1/chat/completions?key=secret-key", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("X-Tenant", "secret-tenant")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	for _, secret := range []string{"secret-key", "secret-token", "secret-tenant", "alice@example.com"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	replay, err := cassette.New(path, cassette.ModeReplay, redact...)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	body = `{"user":"bob@example.com","model":"test-model","messages":[]}`
+	req, _ = http.NewRequest(http.MethodPost, srv.URL+"/v1/chat/completions?key=other-key", strings.NewReader(body))
+	resp, err = (&http.Client{Transport: replay}).Do(req)
+	if err != nil {
+		t.Fatalf("replay with different credentials failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got status %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestRecorder_Policy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+
+	srv := mock.NewServer()
+	defer srv.Close()
+
+	policy, err := redact.New(redact.Config{Keys: []string{"x-tenant", "tenant"}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	rec, err := cassette.New(path, cassette.ModeRecord, cassette.WithPolicy(policy))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/v1/chat/completions?tenant=secret-param&x-goog-api-key=secret-key", strings.NewReader(`{"model":"test-model","messages":[]}`))
+	req.Header.Set("X-Tenant", "secret-tenant")
+	req.Header.Set("Proxy-Authorization", "Basic secret-proxy")
+
+	resp, err := (&http.Client{Transport: rec}).Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	for _, secret := range []string{"secret-param", "secret-key", "secret-tenant", "secret-proxy"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+}
+
+func TestNew_Modes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auto.json")
+
+	if _, err := cassette.New(path, cassette.ModeReplay); err == nil {
+		t.Error("expected error replaying a missing cassette")
+	}
+
+	if _, err := cassette.New(path, "rewind"); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+
+	rec, err := cassette.New(path, cassette.ModeAuto)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if rec.Mode() != cassette.ModeRecord {
+		t.Errorf("got mode %s for missing cassette, want record", rec.Mode())
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	rec, err = cassette.New(path, cassette.ModeAuto)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if rec.Mode() != cassette.ModeReplay {
+		t.Errorf("got mode %s for existing cassette, want replay", rec.Mode())
+	}
+}
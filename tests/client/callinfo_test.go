@@ -0,0 +1,161 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+// callInfoProvider records the call info seen by PrepareRequest,
+// PrepareStreamRequest, and SetHeaders.
+type callInfoProvider struct {
+	providers.Provider
+
+	mu       sync.Mutex
+	prepared []providers.CallInfo
+	headers  []providers.CallInfo
+}
+
+func (p *callInfoProvider) PrepareRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*providers.Request, error) {
+	p.record(&p.prepared, providers.CallInfoFrom(ctx))
+	return p.Provider.PrepareRequest(ctx, proto, body, headers)
+}
+
+func (p *callInfoProvider) PrepareStreamRequest(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*providers.Request, error) {
+	p.record(&p.prepared, providers.CallInfoFrom(ctx))
+	return p.Provider.PrepareStreamRequest(ctx, proto, body, headers)
+}
+
+func (p *callInfoProvider) SetHeaders(req *http.Request) {
+	p.record(&p.headers, providers.CallInfoFrom(req.Context()))
+	p.Provider.SetHeaders(req)
+}
+
+func (p *callInfoProvider) record(infos *[]providers.CallInfo, info providers.CallInfo) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	*infos = append(*infos, info)
+}
+
+func newCallInfoRequest(t *testing.T, baseURL string, options map[string]any) (*callInfoProvider, client.Client, request.Request) {
+	t.Helper()
+
+	ollama, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: baseURL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	provider := &callInfoProvider{Provider: ollama}
+
+	c := client.New(&config.ClientConfig{
+		Timeout:            config.Duration(10 * time.Second),
+		ConnectionPoolSize: 1,
+		Retry: config.RetryConfig{
+			MaxRetries:     2,
+			InitialBackoff: config.Duration(time.Millisecond),
+		},
+	})
+
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, options)
+	return provider, c, req
+}
+
+func TestCallInfo_WithCallInfo(t *testing.T) {
+	ctx := providers.WithTenant(context.Background(), "acme")
+	ctx = providers.WithPriority(ctx, providers.PriorityHigh)
+	ctx = providers.WithCallInfo(ctx, providers.CallInfo{RequestID: "req-1"})
+	ctx = providers.WithCallInfo(ctx, providers.CallInfo{Tenant: "globex"})
+
+	want := providers.CallInfo{RequestID: "req-1", Tenant: "globex", Priority: providers.PriorityHigh}
+	if got := providers.CallInfoFrom(ctx); got != want {
+		t.Errorf("CallInfoFrom = %+v, want %+v", got, want)
+	}
+
+	if got := providers.CallInfoFrom(context.Background()); got != (providers.CallInfo{}) {
+		t.Errorf("CallInfoFrom(empty) = %+v, want zero value", got)
+	}
+}
+
+func TestClient_Execute_CallInfo(t *testing.T) {
+	server := flakyServer(t, 1, http.StatusServiceUnavailable, "")
+	provider, c, req := newCallInfoRequest(t, server.URL, map[string]any{})
+
+	ctx := providers.WithTenant(context.Background(), "acme")
+	ctx = providers.WithPriority(ctx, providers.PriorityLow)
+	if _, err := c.Execute(ctx, req); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if len(provider.prepared) != 2 || len(provider.headers) != 2 {
+		t.Fatalf("got %d prepares and %d SetHeaders calls, want 2 of each", len(provider.prepared), len(provider.headers))
+	}
+	seen := slices.Concat(provider.prepared, provider.headers)
+
+	id := seen[0].RequestID
+	if id == "" {
+		t.Fatal("request ID was not assigned")
+	}
+	for i, info := range seen {
+		if info.RequestID != id {
+			t.Errorf("call %d: request ID = %q, want %q shared by every attempt", i, info.RequestID, id)
+		}
+		if info.Tenant != "acme" || info.Priority != providers.PriorityLow {
+			t.Errorf("call %d: call info = %+v, want tenant acme and low priority", i, info)
+		}
+	}
+
+	if _, err := c.Execute(ctx, req); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if next := provider.prepared[len(provider.prepared)-1].RequestID; next == id || next == "" {
+		t.Errorf("second request ID = %q, want a new ID", next)
+	}
+}
+
+func TestClient_Execute_CallerRequestID(t *testing.T) {
+	server := flakyServer(t, 0, http.StatusOK, "")
+	provider, c, req := newCallInfoRequest(t, server.URL, map[string]any{})
+
+	ctx := providers.WithRequestID(context.Background(), "caller-id")
+	if _, err := c.Execute(ctx, req); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if got := provider.headers[0].RequestID; got != "caller-id" {
+		t.Errorf("request ID = %q, want %q", got, "caller-id")
+	}
+}
+
+func TestClient_ExecuteStream_CallInfo(t *testing.T) {
+	server := pausingServer(t, 0)
+	provider, c, req := newCallInfoRequest(t, server.URL, map[string]any{"stream": true})
+
+	ctx := providers.WithTenant(context.Background(), "acme")
+	chunks, err := c.ExecuteStream(ctx, req)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+	for range chunks {
+	}
+
+	if len(provider.prepared) != 1 || len(provider.headers) != 1 {
+		t.Fatalf("got %d prepares and %d SetHeaders calls, want 1 of each", len(provider.prepared), len(provider.headers))
+	}
+	info := provider.headers[0]
+	if info.RequestID == "" || info.RequestID != provider.prepared[0].RequestID {
+		t.Errorf("request IDs = %q and %q, want the same assigned ID", provider.prepared[0].RequestID, info.RequestID)
+	}
+	if info.Tenant != "acme" {
+		t.Errorf("tenant = %q, want acme", info.Tenant)
+	}
+}
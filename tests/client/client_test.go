@@ -3,8 +3,10 @@ package client_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -37,15 +39,7 @@ func TestClient_Execute_Chat(t *testing.T) {
 		chatResp := response.ChatResponse{
 			Model: "test-model",
 		}
-		chatResp.Choices = append(chatResp.Choices, struct {
-			Index   int              `json:"index"`
-			Message protocol.Message `json:"message"`
-			Delta   *struct {
-				Role    string `json:"role,omitempty"`
-				Content string `json:"content,omitempty"`
-			} `json:"delta,omitempty"`
-			FinishReason string `json:"finish_reason,omitempty"`
-		}{
+		chatResp.Choices = append(chatResp.Choices, response.ChatChoice{
 			Index:   0,
 			Message: protocol.NewMessage("assistant", "Hello, world!"),
 		})
@@ -109,21 +103,9 @@ func TestClient_Execute_Tools(t *testing.T) {
 		toolsResp := response.ToolsResponse{
 			Model: "test-model",
 		}
-		toolsResp.Choices = append(toolsResp.Choices, struct {
-			Index   int `json:"index"`
-			Message struct {
-				Role      string              `json:"role"`
-				Content   string              `json:"content"`
-				ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-			} `json:"message"`
-			FinishReason string `json:"finish_reason,omitempty"`
-		}{
+		toolsResp.Choices = append(toolsResp.Choices, response.ToolsChoice{
 			Index: 0,
-			Message: struct {
-				Role      string              `json:"role"`
-				Content   string              `json:"content"`
-				ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-			}{
+			Message: response.ToolsMessage{
 				Role:    "assistant",
 				Content: "",
 				ToolCalls: []response.ToolCall{
@@ -389,3 +371,177 @@ func TestClient_HTTPClient(t *testing.T) {
 		t.Errorf("got timeout %v, want %v", httpClient.Timeout, 5*time.Second)
 	}
 }
+
+func TestClient_Execute_StrictParseMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"model":"test-model","vendor_extension":{},"choices":[{"index":0,"message":{"content":"Hi","refusal":null}}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{
+		Name:    "ollama",
+		BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	messages := []protocol.Message{protocol.NewMessage("user", "Hello")}
+	req := request.NewChat(provider, mdl, messages, map[string]any{})
+
+	lenient := client.New(&config.ClientConfig{
+		Timeout: config.Duration(30 * time.Second),
+	})
+	if _, err := lenient.Execute(context.Background(), req); err != nil {
+		t.Fatalf("lenient Execute failed: %v", err)
+	}
+
+	strict := client.New(&config.ClientConfig{
+		Timeout:   config.Duration(30 * time.Second),
+		ParseMode: "strict",
+	})
+	_, err = strict.Execute(context.Background(), req)
+
+	var parseErr *response.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("got error %v, want *response.ParseError", err)
+	}
+
+	unknown := client.New(&config.ClientConfig{
+		Timeout:   config.Duration(30 * time.Second),
+		ParseMode: "strcit",
+	})
+	if _, err := unknown.Execute(context.Background(), req); err == nil || !strings.Contains(err.Error(), "unknown parse mode") {
+		t.Errorf("got error %v, want unknown parse mode", err)
+	}
+}
+
+func TestClient_Execute_OptionValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"model":"test-model","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{
+		Name:    "ollama",
+		BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	messages := []protocol.Message{protocol.NewMessage("user", "Hello")}
+	req := request.NewChat(provider, mdl, messages, map[string]any{"tempreture": 0.5})
+
+	strict := client.New(&config.ClientConfig{
+		Timeout:          config.Duration(30 * time.Second),
+		OptionValidation: "strict",
+	})
+	_, err = strict.Execute(context.Background(), req)
+
+	var optionErr *model.OptionError
+	if !errors.As(err, &optionErr) {
+		t.Fatalf("got error %v, want *model.OptionError", err)
+	}
+
+	if optionErr.Suggestion != "temperature" {
+		t.Errorf("got suggestion %q, want %q", optionErr.Suggestion, "temperature")
+	}
+
+	if requests != 0 {
+		t.Errorf("got %d requests, want none sent for invalid options", requests)
+	}
+
+	lenient := client.New(&config.ClientConfig{
+		Timeout:          config.Duration(30 * time.Second),
+		OptionValidation: "lenient",
+	})
+	if _, err := lenient.Execute(context.Background(), req); err != nil {
+		t.Fatalf("lenient Execute failed: %v", err)
+	}
+}
+
+func TestClient_Execute_OptionValidationDefaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"model":"test-model","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{
+		Name:    "ollama",
+		BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	messages := []protocol.Message{protocol.NewMessage("user", "Hello")}
+	req := request.NewChat(provider, mdl, messages, map[string]any{"num_predict": 64})
+
+	unset := client.New(&config.ClientConfig{
+		Timeout: config.Duration(30 * time.Second),
+	})
+	if _, err := unset.Execute(context.Background(), req); err != nil {
+		t.Fatalf("Execute with default validation failed: %v", err)
+	}
+
+	unknown := client.New(&config.ClientConfig{
+		Timeout:          config.Duration(30 * time.Second),
+		OptionValidation: "loose",
+	})
+	if _, err := unknown.Execute(context.Background(), req); err == nil {
+		t.Fatal("got nil error, want error for unknown validation mode")
+	}
+}
+
+func TestClient_Execute_ModelLimits(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"model":"test-model","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{
+		Name:    "ollama",
+		BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	mdl := model.New(&config.ModelConfig{Name: "test-model", MaxOutputTokens: 100})
+	messages := []protocol.Message{protocol.NewMessage("user", "Hello")}
+	req := request.NewChat(provider, mdl, messages, map[string]any{"max_tokens": 500})
+
+	c := client.New(&config.ClientConfig{
+		Timeout:          config.Duration(30 * time.Second),
+		OptionValidation: "strict",
+	})
+	_, err = c.Execute(context.Background(), req)
+
+	if !errors.Is(err, request.ErrInvalidRequest) {
+		t.Fatalf("got error %v, want ErrInvalidRequest", err)
+	}
+
+	if requests != 0 {
+		t.Errorf("got %d requests, want none sent for requests exceeding limits", requests)
+	}
+
+	off := client.New(&config.ClientConfig{
+		Timeout:          config.Duration(30 * time.Second),
+		OptionValidation: "off",
+	})
+	if _, err := off.Execute(context.Background(), req); err != nil {
+		t.Fatalf("Execute with validation off failed: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/options"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestFetchDeferred_XAI(t *testing.T) {
+	var (
+		polls    atomic.Int32
+		deferred atomic.Bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/chat/completions":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			deferred.Store(body["deferred"] == true)
+			w.Write([]byte(`{"request_id":"req-123"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/chat/deferred-completion/req-123":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if polls.Add(1) == 1 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.Write([]byte(`{"id":"req-123","model":"grok-4","choices":[{"index":0,"message":{"role":"assistant","content":"Done"},"finish_reason":"stop"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewXAI(&config.ProviderConfig{
+		Name:    "xai",
+		BaseURL: server.URL,
+		Options: map[string]any{"token": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("NewXAI failed: %v", err)
+	}
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	mdl := model.New(&config.ModelConfig{Name: "grok-4"})
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, options.New(options.Deferred()))
+
+	_, err = c.Execute(context.Background(), req)
+	var pending *providers.DeferredCompletion
+	if !errors.As(err, &pending) {
+		t.Fatalf("Execute returned %v, want *DeferredCompletion", err)
+	}
+	if pending.RequestID != "req-123" {
+		t.Errorf("request ID = %q, want req-123", pending.RequestID)
+	}
+	if !deferred.Load() {
+		t.Error("request body did not set deferred")
+	}
+	if !c.IsHealthy() {
+		t.Error("client marked unhealthy by a deferred completion")
+	}
+
+	_, err = client.FetchDeferred(context.Background(), c, provider, protocol.Chat, pending.RequestID)
+	if !errors.Is(err, client.ErrDeferredPending) {
+		t.Fatalf("first fetch returned %v, want ErrDeferredPending", err)
+	}
+
+	result, err := client.FetchDeferred(context.Background(), c, provider, protocol.Chat, pending.RequestID)
+	if err != nil {
+		t.Fatalf("FetchDeferred failed: %v", err)
+	}
+	if resp := result.(*response.ChatResponse); resp.Content() != "Done" {
+		t.Errorf("content = %q, want Done", resp.Content())
+	}
+}
+
+func TestFetchDeferred_Unsupported(t *testing.T) {
+	ollama, _ := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: "http://localhost:11434"})
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	if _, err := client.FetchDeferred(context.Background(), c, ollama, protocol.Chat, "req-123"); err == nil {
+		t.Error("expected error for provider without deferred completions")
+	}
+}
+
+func TestFetchDeferred_AfterShutdown(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewXAI(&config.ProviderConfig{
+		Name:    "xai",
+		BaseURL: server.URL,
+		Options: map[string]any{"token": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("NewXAI failed: %v", err)
+	}
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if _, err := client.FetchDeferred(context.Background(), c, provider, protocol.Chat, "req-123"); !errors.Is(err, client.ErrClientClosed) {
+		t.Errorf("got %v, want ErrClientClosed", err)
+	}
+	if requests != 0 {
+		t.Errorf("got %d requests, want none sent after Shutdown", requests)
+	}
+}
@@ -0,0 +1,137 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func newFilesClient() client.Client {
+	return client.New(&config.ClientConfig{
+		Timeout:            config.Duration(30 * time.Second),
+		ConnectionTimeout:  config.Duration(10 * time.Second),
+		ConnectionPoolSize: 10,
+	})
+}
+
+func newAzureProvider(t *testing.T, baseURL string) providers.Provider {
+	t.Helper()
+
+	p, err := providers.NewAzure(&config.ProviderConfig{
+		Name:    "azure",
+		BaseURL: baseURL,
+		Options: map[string]any{
+			"deployment":  "gpt-4o",
+			"auth_type":   "api_key",
+			"token":       "test-key",
+			"api_version": "2024-10-21",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAzure failed: %v", err)
+	}
+	return p
+}
+
+func TestUploadFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/files" || r.URL.Query().Get("api-version") != "2024-10-21" {
+			t.Errorf("got %s %s, want POST /files with api-version", r.Method, r.URL)
+		}
+		if r.Header.Get("api-key") != "test-key" {
+			t.Errorf("got api-key %q, want provider authentication", r.Header.Get("api-key"))
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("missing file part: %v", err)
+		}
+		content, _ := io.ReadAll(file)
+
+		json.NewEncoder(w).Encode(response.File{
+			ID:       "file-abc",
+			Object:   "file",
+			Bytes:    int64(len(content)),
+			Filename: header.Filename,
+			Purpose:  response.FilePurpose(r.FormValue("purpose")),
+		})
+	}))
+	defer server.Close()
+
+	file, err := client.UploadFile(context.Background(), newFilesClient(), newAzureProvider(t, server.URL),
+		"data.jsonl", strings.NewReader(`{"a":1}`), response.FilePurposeBatch)
+	if err != nil {
+		t.Fatalf("UploadFile failed: %v", err)
+	}
+
+	if file.ID != "file-abc" || file.Filename != "data.jsonl" || file.Bytes != 7 || file.Purpose != response.FilePurposeBatch {
+		t.Errorf("got %+v", file)
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Query().Get("purpose") != "batch" || r.URL.Query().Get("api-version") == "" {
+			t.Errorf("got %s %s, want GET with purpose and api-version", r.Method, r.URL)
+		}
+		json.NewEncoder(w).Encode(response.FileList{
+			Object: "list",
+			Data:   []response.File{{ID: "file-1"}, {ID: "file-2"}},
+		})
+	}))
+	defer server.Close()
+
+	files, err := client.ListFiles(context.Background(), newFilesClient(), newAzureProvider(t, server.URL), response.FilePurposeBatch)
+	if err != nil {
+		t.Fatalf("ListFiles failed: %v", err)
+	}
+	if len(files) != 2 || files[1].ID != "file-2" {
+		t.Errorf("got %+v", files)
+	}
+}
+
+func TestDeleteFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("got method %s, want DELETE", r.Method)
+		}
+		if r.URL.Path != "/files/file-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":"file-1","object":"file","deleted":true}`))
+	}))
+	defer server.Close()
+
+	c, p := newFilesClient(), newAzureProvider(t, server.URL)
+
+	if err := client.DeleteFile(context.Background(), c, p, "file-1"); err != nil {
+		t.Fatalf("DeleteFile failed: %v", err)
+	}
+
+	err := client.DeleteFile(context.Background(), c, p, "missing")
+	var statusErr *client.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("got error %v, want HTTP 404", err)
+	}
+}
+
+func TestFiles_NotSupported(t *testing.T) {
+	p, _ := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: "http://localhost:11434"})
+
+	_, err := client.ListFiles(context.Background(), newFilesClient(), p, "")
+	if !errors.Is(err, providers.ErrFilesNotSupported) {
+		t.Errorf("got error %v, want ErrFilesNotSupported", err)
+	}
+}
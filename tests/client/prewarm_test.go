@@ -0,0 +1,83 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func TestPrewarm_Ollama(t *testing.T) {
+	var (
+		path, auth string
+		body       map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"model":"llama3.2","done":true,"done_reason":"load"}`))
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{
+		Name:    "ollama",
+		BaseURL: server.URL,
+		Options: map[string]any{"auth_type": "bearer", "token": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	if err := client.Prewarm(context.Background(), c, provider, "llama3.2", "30m0s"); err != nil {
+		t.Fatalf("Prewarm failed: %v", err)
+	}
+
+	if path != "/api/generate" {
+		t.Errorf("path = %q, want /api/generate", path)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want provider authentication", auth)
+	}
+	if body["model"] != "llama3.2" || body["keep_alive"] != "30m0s" {
+		t.Errorf("body = %v, want model and keep_alive", body)
+	}
+	if _, ok := body["prompt"]; ok {
+		t.Errorf("body = %v, want no prompt", body)
+	}
+}
+
+func TestPrewarm_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"model not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	ollama, _ := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: server.URL})
+	err := client.Prewarm(context.Background(), c, ollama, "missing", nil)
+	var statusErr *client.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("got %v, want HTTPStatusError 404", err)
+	}
+
+	azure, err := providers.NewAzure(&config.ProviderConfig{
+		Name:    "azure",
+		BaseURL: server.URL,
+		Options: map[string]any{"deployment": "gpt-4o", "api_version": "2024-10-21", "auth_type": "api_key", "token": "key"},
+	})
+	if err != nil {
+		t.Fatalf("NewAzure failed: %v", err)
+	}
+	if err := client.Prewarm(context.Background(), c, azure, "gpt-4o", nil); !errors.Is(err, providers.ErrPrewarmNotSupported) {
+		t.Errorf("got %v, want ErrPrewarmNotSupported", err)
+	}
+}
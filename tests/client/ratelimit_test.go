@@ -0,0 +1,58 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+func TestClient_Execute_RateLimitHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ratelimit-limit", "60")
+		w.Header().Set("x-ratelimit-remaining", "12")
+		w.Header().Set("x-ratelimit-reset", "0.5")
+		w.Write([]byte(`{"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewTogether(&config.ProviderConfig{
+		Name:    "together",
+		BaseURL: server.URL,
+		Options: map[string]any{"token": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("NewTogether failed: %v", err)
+	}
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+	mdl := model.New(&config.ModelConfig{Name: "m"})
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, nil)
+
+	var events []client.RateLimitEvent
+	ctx := client.WithRateLimitHook(context.Background(), func(e client.RateLimitEvent) {
+		events = append(events, e)
+	})
+	if _, err := c.Execute(ctx, req); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	e := events[0]
+	if e.Provider != "together" || e.Model != "m" || e.StatusCode != http.StatusOK {
+		t.Errorf("event = %+v", e)
+	}
+	want := providers.RateLimits{LimitRequests: 60, RemainingRequests: 12, ResetRequests: 500 * time.Millisecond}
+	if e.Limits != want {
+		t.Errorf("limits = %+v, want %+v", e.Limits, want)
+	}
+}
@@ -0,0 +1,178 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+func TestExecuteRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/models/llama3" {
+			t.Errorf("got %s %s, want POST /v1/models/llama3", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Custom") != "yes" {
+			t.Errorf("got X-Custom %q, want the caller's header", r.Header.Get("X-Custom"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"model":"llama3"}` {
+			t.Errorf("got body %s", body)
+		}
+
+		w.Header().Set("X-Upstream", "ollama")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"details":{}}`))
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	resp, err := client.ExecuteRaw(context.Background(), newFilesClient(), provider, http.MethodPost, "/models/llama3",
+		[]byte(`{"model":"llama3"}`), map[string]string{"X-Custom": "yes"})
+	if err != nil {
+		t.Fatalf("ExecuteRaw failed: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+	if resp.Header.Get("X-Upstream") != "ollama" {
+		t.Errorf("got header %q, want the response headers", resp.Header.Get("X-Upstream"))
+	}
+	if string(resp.Body) != `{"details":{}}` {
+		t.Errorf("got body %s", resp.Body)
+	}
+}
+
+func TestExecuteRaw_ErrorStatusAndAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/deployments" {
+			t.Errorf("got %s %s, want GET /deployments", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("api-key") != "test-key" {
+			t.Errorf("got api-key %q, want provider authentication", r.Header.Get("api-key"))
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	resp, err := client.ExecuteRaw(context.Background(), newFilesClient(), newAzureProvider(t, server.URL), http.MethodGet, "deployments", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteRaw failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestExecuteRaw_AbsoluteURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/elsewhere" {
+			t.Errorf("got path %q, want /elsewhere", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: "http://unused.invalid"})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	resp, err := client.ExecuteRaw(context.Background(), newFilesClient(), provider, http.MethodGet, server.URL+"/elsewhere", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteRaw failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestExecute_SignsRequests(t *testing.T) {
+	var signed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		want := providers.HMACSignature([]byte("secret"), r.Method, r.URL.RequestURI(), r.Header.Get("X-Signature-Timestamp"), body)
+		signed = r.Header.Get("X-Signature") == want
+		w.Write([]byte(`{"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{
+		Name:    "ollama",
+		BaseURL: server.URL,
+		Options: map[string]any{"signing": map[string]any{"type": "hmac-sha256", "key": "secret"}},
+	})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	resp, err := client.ExecuteRaw(context.Background(), newFilesClient(), provider, http.MethodPost, "/chat/completions", []byte(`{}`), nil)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("ExecuteRaw failed: %v", err)
+	}
+	if !signed {
+		t.Error("raw request signature did not verify")
+	}
+
+	signed = false
+	req := request.NewChat(provider, model.New(&config.ModelConfig{Name: "m"}), []protocol.Message{protocol.NewMessage("user", "Hi")}, nil)
+	if _, err := newFilesClient().Execute(context.Background(), req); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !signed {
+		t.Error("protocol request signature did not verify")
+	}
+}
+
+func TestExecute_ProviderMiddlewares(t *testing.T) {
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Shim")
+		w.Write([]byte(`{"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer server.Close()
+
+	p, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	var processed bool
+	p.(*providers.OllamaProvider).Use(providers.Middleware{
+		Prepare: func(next providers.PrepareFunc) providers.PrepareFunc {
+			return func(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*providers.Request, error) {
+				req, err := next(ctx, proto, body, headers)
+				if err == nil {
+					req.Headers["X-Shim"] = "on"
+				}
+				return req, err
+			}
+		},
+		Process: func(next providers.ProcessFunc) providers.ProcessFunc {
+			return func(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
+				processed = true
+				return next(ctx, resp, proto)
+			}
+		},
+	})
+
+	req := request.NewChat(p, model.New(&config.ModelConfig{Name: "m"}), []protocol.Message{protocol.NewMessage("user", "Hi")}, nil)
+	if _, err := newFilesClient().Execute(context.Background(), req); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if gotHeader != "on" || !processed {
+		t.Errorf("got X-Shim %q and processed %v, want both middlewares applied", gotHeader, processed)
+	}
+}
@@ -0,0 +1,200 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+// flakyServer fails the first failures requests with status and the given
+// Retry-After header, then returns a chat completion.
+func flakyServer(t *testing.T, failures int, status int, retryAfter string) *httptest.Server {
+	t.Helper()
+
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= int64(failures) {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"model":"test-model","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func executeWithRetries(t *testing.T, ctx context.Context, baseURL string, retry config.RetryConfig) error {
+	t.Helper()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: baseURL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+
+	c := client.New(&config.ClientConfig{
+		Timeout:            config.Duration(10 * time.Second),
+		ConnectionPoolSize: 1,
+		Retry:              retry,
+	})
+
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{})
+	_, err = c.Execute(ctx, req)
+	return err
+}
+
+func TestClient_Execute_RetryEvents(t *testing.T) {
+	server := flakyServer(t, 2, http.StatusServiceUnavailable, "")
+
+	var events []client.RetryEvent
+	ctx := client.WithRetryHook(context.Background(), func(e client.RetryEvent) {
+		events = append(events, e)
+	})
+
+	err := executeWithRetries(t, ctx, server.URL, config.RetryConfig{
+		MaxRetries:        3,
+		InitialBackoff:    config.Duration(time.Millisecond),
+		MaxBackoff:        config.Duration(10 * time.Millisecond),
+		BackoffMultiplier: 2,
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d retry events, want 2", len(events))
+	}
+	for i, e := range events {
+		if e.Attempt != i+1 {
+			t.Errorf("event %d: got attempt %d, want %d", i, e.Attempt, i+1)
+		}
+		if e.Class != client.ErrorClassServer || e.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("event %d: got class %q status %d", i, e.Class, e.StatusCode)
+		}
+		if e.Provider != "ollama" || e.Protocol != "chat" || e.Model != "test-model" || e.MaxRetries != 3 {
+			t.Errorf("event %d: unexpected request fields %+v", i, e)
+		}
+		if e.Backoff <= 0 || e.RetryAfterHonored {
+			t.Errorf("event %d: got backoff %v honored %t", i, e.Backoff, e.RetryAfterHonored)
+		}
+		if e.Err == nil {
+			t.Errorf("event %d: expected error", i)
+		}
+	}
+}
+
+func TestClient_Execute_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBackoff time.Duration
+		honored    bool
+	}{
+		{name: "honored within max backoff", maxBackoff: 5 * time.Second, honored: true},
+		{name: "exceeds max backoff", maxBackoff: 10 * time.Millisecond, honored: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := flakyServer(t, 1, http.StatusTooManyRequests, "1")
+
+			var event client.RetryEvent
+			ctx := client.WithRetryHook(context.Background(), func(e client.RetryEvent) {
+				event = e
+			})
+
+			start := time.Now()
+			err := executeWithRetries(t, ctx, server.URL, config.RetryConfig{
+				MaxRetries:        1,
+				InitialBackoff:    config.Duration(time.Millisecond),
+				MaxBackoff:        config.Duration(tt.maxBackoff),
+				BackoffMultiplier: 2,
+			})
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+
+			if event.Class != client.ErrorClassRateLimit {
+				t.Errorf("got class %q, want %q", event.Class, client.ErrorClassRateLimit)
+			}
+			if event.RetryAfter != time.Second {
+				t.Errorf("got retry after %v, want 1s", event.RetryAfter)
+			}
+			if event.RetryAfterHonored != tt.honored {
+				t.Errorf("got honored %t, want %t", event.RetryAfterHonored, tt.honored)
+			}
+
+			elapsed := time.Since(start)
+			if tt.honored && (event.Backoff != time.Second || elapsed < time.Second) {
+				t.Errorf("expected a 1s backoff, got %v after %v", event.Backoff, elapsed)
+			}
+			if !tt.honored && event.Backoff > tt.maxBackoff {
+				t.Errorf("got backoff %v above max %v", event.Backoff, tt.maxBackoff)
+			}
+		})
+	}
+}
+
+func TestWithRetryHook_Accumulates(t *testing.T) {
+	server := flakyServer(t, 1, http.StatusBadGateway, "")
+
+	var first, second int
+	ctx := client.WithRetryHook(context.Background(), func(client.RetryEvent) { first++ })
+	ctx = client.WithRetryHook(ctx, func(client.RetryEvent) { second++ })
+
+	err := executeWithRetries(t, ctx, server.URL, config.RetryConfig{
+		MaxRetries:     1,
+		InitialBackoff: config.Duration(time.Millisecond),
+		MaxBackoff:     config.Duration(time.Millisecond),
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if first != 1 || second != 1 {
+		t.Errorf("got hook calls %d and %d, want 1 each", first, second)
+	}
+}
+
+func TestExecute_HTTPStatusErrorSanitized(t *testing.T) {
+	body := `{"error":"invalid key sk-abcdefghijklmnop1234 for prompt ` + strings.Repeat("x", 2000) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	p, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	req := request.NewChat(p, model.New(&config.ModelConfig{Name: "m"}), []protocol.Message{protocol.NewMessage("user", "Hi")}, nil)
+	_, err = newFilesClient().Execute(context.Background(), req)
+
+	var statusErr *client.HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("got %v, want HTTPStatusError", err)
+	}
+	if strings.Contains(err.Error(), "sk-abcdefghijklmnop1234") || len(statusErr.Body) > redact.MaxErrorBody+32 {
+		t.Errorf("error body not sanitized: %d bytes", len(statusErr.Body))
+	}
+	if string(statusErr.RawBody()) != body {
+		t.Error("RawBody does not return the original body")
+	}
+}
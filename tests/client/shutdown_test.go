@@ -0,0 +1,227 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// blockingServer holds each request until release is closed, signalling
+// started when a request arrives.
+func blockingServer(t *testing.T) (server *httptest.Server, started chan struct{}, release chan struct{}) {
+	t.Helper()
+
+	started = make(chan struct{}, 10)
+	release = make(chan struct{})
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"model":"test-model","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, started, release
+}
+
+func newShutdownClient(t *testing.T, baseURL string) (client.Client, request.Request) {
+	t.Helper()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: baseURL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	c := client.New(&config.ClientConfig{
+		Timeout:            config.Duration(10 * time.Second),
+		ConnectionPoolSize: 2,
+	})
+
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{})
+	return c, req
+}
+
+func TestClient_Shutdown_WaitsForInFlight(t *testing.T) {
+	server, started, release := blockingServer(t)
+	c, req := newShutdownClient(t, server.URL)
+
+	executed := make(chan error, 1)
+	go func() {
+		_, err := c.Execute(context.Background(), req)
+		executed <- err
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- c.Shutdown(context.Background())
+	}()
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown returned %v before the in-flight request finished", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Wait for shutdown to begin rejecting requests
+	deadline := time.Now().Add(time.Second)
+	for {
+		_, err := c.Execute(context.Background(), req)
+		if errors.Is(err, client.ErrClientClosed) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected ErrClientClosed after Shutdown, got %v", err)
+		}
+	}
+
+	close(release)
+
+	if err := <-executed; err != nil {
+		t.Errorf("in-flight request failed: %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+}
+
+func TestClient_Shutdown_Deadline(t *testing.T) {
+	server, started, release := blockingServer(t)
+	defer close(release)
+	c, req := newShutdownClient(t, server.URL)
+
+	go c.Execute(context.Background(), req)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := c.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestClient_Shutdown_Idle(t *testing.T) {
+	c, req := newShutdownClient(t, "http://127.0.0.1:0")
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown failed: %v", err)
+	}
+
+	if _, err := c.ExecuteStream(context.Background(), req); !errors.Is(err, client.ErrClientClosed) {
+		t.Errorf("got %v, want ErrClientClosed", err)
+	}
+}
+
+func TestClient_Shutdown_RejectsFiles(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	c := newFilesClient()
+	p := newAzureProvider(t, server.URL)
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if _, err := client.ListFiles(context.Background(), c, p, ""); !errors.Is(err, client.ErrClientClosed) {
+		t.Errorf("ListFiles: got %v, want ErrClientClosed", err)
+	}
+	if _, err := client.UploadFile(context.Background(), c, p, "data.jsonl", strings.NewReader("{}"), response.FilePurposeBatch); !errors.Is(err, client.ErrClientClosed) {
+		t.Errorf("UploadFile: got %v, want ErrClientClosed", err)
+	}
+
+	if requests != 0 {
+		t.Errorf("got %d requests, want none sent after Shutdown", requests)
+	}
+}
+
+func TestClient_Shutdown_WaitsForPrewarm(t *testing.T) {
+	server, started, release := blockingServer(t)
+	c, req := newShutdownClient(t, server.URL)
+
+	prewarmed := make(chan error, 1)
+	go func() {
+		prewarmed <- client.Prewarm(context.Background(), c, req.Provider(), "test-model", nil)
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- c.Shutdown(context.Background())
+	}()
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown returned %v before the prewarm request finished", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := client.Prewarm(context.Background(), c, req.Provider(), "test-model", nil); !errors.Is(err, client.ErrClientClosed) {
+		t.Errorf("got %v, want ErrClientClosed after Shutdown", err)
+	}
+
+	close(release)
+
+	if err := <-prewarmed; err != nil {
+		t.Errorf("in-flight prewarm failed: %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+}
+
+func TestClient_Shutdown_WaitsForExecuteRaw(t *testing.T) {
+	server, started, release := blockingServer(t)
+	c, req := newShutdownClient(t, server.URL)
+
+	executed := make(chan error, 1)
+	go func() {
+		_, err := client.ExecuteRaw(context.Background(), c, req.Provider(), http.MethodGet, "/api/tags", nil, nil)
+		executed <- err
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- c.Shutdown(context.Background())
+	}()
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown returned %v before the raw request finished", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if _, err := client.ExecuteRaw(context.Background(), c, req.Provider(), http.MethodGet, "/api/tags", nil, nil); !errors.Is(err, client.ErrClientClosed) {
+		t.Errorf("got %v, want ErrClientClosed after Shutdown", err)
+	}
+
+	close(release)
+
+	if err := <-executed; err != nil {
+		t.Errorf("in-flight raw request failed: %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+}
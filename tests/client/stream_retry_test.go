@@ -0,0 +1,193 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// slowStartServer handles each call with the handler for its call number,
+// falling back to a complete stream once the handlers run out.
+func slowStartServer(t *testing.T, calls *atomic.Int64, handlers ...func(http.ResponseWriter, *http.Request)) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n := int(calls.Add(1)); n <= len(handlers) {
+			handlers[n-1](w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "%s\n\ndata: [DONE]\n\n", streamChunk)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func unavailable(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "loading model", http.StatusServiceUnavailable)
+}
+
+// silent sends headers but no chunk until the client disconnects.
+func silent(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.(http.Flusher).Flush()
+	<-r.Context().Done()
+}
+
+func openChatStream(t *testing.T, baseURL string, cfg *config.ClientConfig) (<-chan *response.StreamingChunk, error) {
+	t.Helper()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: baseURL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+
+	cfg.Timeout = config.Duration(10 * time.Second)
+	cfg.Retry.InitialBackoff = config.Duration(time.Millisecond)
+	c := client.New(cfg)
+
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{"stream": true})
+	return c.ExecuteStream(context.Background(), req)
+}
+
+func drainContent(chunks <-chan *response.StreamingChunk) (content string, errs []error) {
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			errs = append(errs, chunk.Error)
+		}
+		content += chunk.Content()
+	}
+	return content, errs
+}
+
+func TestClient_ExecuteStream_RetriesBeforeFirstChunk(t *testing.T) {
+	var calls atomic.Int64
+	server := slowStartServer(t, &calls, unavailable, unavailable)
+
+	streamRetry := true
+	chunks, err := openChatStream(t, server.URL, &config.ClientConfig{
+		StreamRetry: &streamRetry,
+		Retry:       config.RetryConfig{MaxRetries: 3},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	content, errs := drainContent(chunks)
+	if content != "hi" || len(errs) > 0 {
+		t.Errorf("got content %q, errors %v, want %q", content, errs, "hi")
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server called %d times, want 3", got)
+	}
+}
+
+func TestClient_ExecuteStream_NoRetryByDefault(t *testing.T) {
+	var calls atomic.Int64
+	server := slowStartServer(t, &calls, unavailable)
+
+	_, err := openChatStream(t, server.URL, &config.ClientConfig{
+		Retry: config.RetryConfig{MaxRetries: 3},
+	})
+
+	var httpErr *client.HTTPStatusError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected HTTPStatusError 503, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server called %d times, want 1", got)
+	}
+}
+
+func TestClient_ExecuteStream_FirstByteTimeout(t *testing.T) {
+	var calls atomic.Int64
+	server := slowStartServer(t, &calls, silent)
+
+	_, err := openChatStream(t, server.URL, &config.ClientConfig{
+		StreamFirstByteTimeout: config.Duration(50 * time.Millisecond),
+	})
+	if !errors.Is(err, client.ErrStreamFirstByteTimeout) {
+		t.Fatalf("expected ErrStreamFirstByteTimeout, got %v", err)
+	}
+}
+
+func TestClient_ExecuteStream_FirstByteTimeoutRetried(t *testing.T) {
+	var calls atomic.Int64
+	server := slowStartServer(t, &calls, silent)
+
+	var events []client.RetryEvent
+	streamRetry := true
+	cfg := &config.ClientConfig{
+		StreamFirstByteTimeout: config.Duration(50 * time.Millisecond),
+		StreamRetry:            &streamRetry,
+		Retry:                  config.RetryConfig{MaxRetries: 1},
+	}
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	cfg.Timeout = config.Duration(10 * time.Second)
+	cfg.Retry.InitialBackoff = config.Duration(time.Millisecond)
+	c := client.New(cfg)
+
+	ctx := client.WithRetryHook(context.Background(), func(e client.RetryEvent) { events = append(events, e) })
+	req := request.NewChat(provider, model.New(&config.ModelConfig{Name: "test-model"}), []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{"stream": true})
+	chunks, err := c.ExecuteStream(ctx, req)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	if content, errs := drainContent(chunks); content != "hi" || len(errs) > 0 {
+		t.Errorf("got content %q, errors %v, want %q", content, errs, "hi")
+	}
+	if len(events) != 1 || events[0].Class != client.ErrorClassFirstByte {
+		t.Errorf("got retry events %+v, want one first_byte retry", events)
+	}
+}
+
+func TestClient_ExecuteStream_NoRetryAfterFirstChunk(t *testing.T) {
+	var calls atomic.Int64
+	server := slowStartServer(t, &calls, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "%s\n\n", streamChunk)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	streamRetry := true
+	chunks, err := openChatStream(t, server.URL, &config.ClientConfig{
+		StreamRetry:            &streamRetry,
+		StreamFirstByteTimeout: config.Duration(time.Second),
+		Streaming:              config.StreamingConfig{IdleTimeout: config.Duration(100 * time.Millisecond)},
+		Retry:                  config.RetryConfig{MaxRetries: 3},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	content, errs := drainContent(chunks)
+	if content != "hi" {
+		t.Errorf("got content %q, want the first chunk only", content)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], client.ErrStreamStalled) {
+		t.Errorf("got errors %v, want a single stall error", errs)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server called %d times, want 1", got)
+	}
+}
@@ -0,0 +1,424 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+const streamChunk = `data: {"model":"test-model","choices":[{"index":0,"delta":{"content":"hi"}}]}`
+
+// pausingServer streams a chunk, pauses, and then either finishes the stream
+// or, if pause is negative, hangs until the client disconnects.
+func pausingServer(t *testing.T, pause time.Duration) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "%s\n\n", streamChunk)
+		w.(http.Flusher).Flush()
+
+		if pause < 0 {
+			<-r.Context().Done()
+			return
+		}
+
+		time.Sleep(pause)
+		fmt.Fprintf(w, "%s\n\ndata: [DONE]\n\n", streamChunk)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func streamChat(t *testing.T, baseURL string, cfg *config.ClientConfig) []*response.StreamingChunk {
+	t.Helper()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: baseURL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+
+	cfg.Timeout = config.Duration(10 * time.Second)
+	c := client.New(cfg)
+
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{"stream": true})
+	chunks, err := c.ExecuteStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	var received []*response.StreamingChunk
+	for chunk := range chunks {
+		received = append(received, chunk)
+	}
+	return received
+}
+
+func TestClient_ExecuteStream_Stalled(t *testing.T) {
+	server := pausingServer(t, -1)
+
+	start := time.Now()
+	chunks := streamChat(t, server.URL, &config.ClientConfig{
+		Streaming: config.StreamingConfig{IdleTimeout: config.Duration(100 * time.Millisecond)},
+	})
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("stalled stream was not aborted (took %v)", elapsed)
+	}
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want content chunk and stall error", len(chunks))
+	}
+	if chunks[0].Content() != "hi" {
+		t.Errorf("got content %q, want %q", chunks[0].Content(), "hi")
+	}
+	if !errors.Is(chunks[1].Error, client.ErrStreamStalled) {
+		t.Errorf("got error %v, want ErrStreamStalled", chunks[1].Error)
+	}
+}
+
+func TestClient_ExecuteStream_Heartbeats(t *testing.T) {
+	server := pausingServer(t, 300*time.Millisecond)
+
+	chunks := streamChat(t, server.URL, &config.ClientConfig{
+		Streaming: config.StreamingConfig{
+			IdleTimeout: config.Duration(5 * time.Second),
+			Heartbeat:   config.Duration(50 * time.Millisecond),
+		},
+	})
+
+	var content string
+	heartbeats := 0
+	for _, chunk := range chunks {
+		if chunk.Error != nil {
+			t.Fatalf("unexpected stream error: %v", chunk.Error)
+		}
+		if chunk.Heartbeat {
+			heartbeats++
+			if chunk.Idle < 50*time.Millisecond {
+				t.Errorf("heartbeat idle %v below interval", chunk.Idle)
+			}
+			continue
+		}
+		content += chunk.Content()
+	}
+
+	if heartbeats == 0 {
+		t.Error("expected heartbeats while the stream was idle")
+	}
+	if content != "hihi" {
+		t.Errorf("got content %q, want %q", content, "hihi")
+	}
+}
+
+func TestClient_ExecuteStream_NoMonitoringByDefault(t *testing.T) {
+	server := pausingServer(t, 150*time.Millisecond)
+
+	chunks := streamChat(t, server.URL, &config.ClientConfig{})
+	for _, chunk := range chunks {
+		if chunk.Heartbeat || chunk.Error != nil {
+			t.Fatalf("unexpected chunk %+v", chunk)
+		}
+	}
+	if len(chunks) != 2 {
+		t.Errorf("got %d chunks, want 2", len(chunks))
+	}
+}
+
+// endlessServer streams chunks until the client disconnects, closing
+// disconnected when it does.
+func endlessServer(t *testing.T, disconnected chan<- struct{}) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(disconnected)
+		w.Header().Set("Content-Type", "text/event-stream")
+		for {
+			if _, err := fmt.Fprintf(w, "%s\n\n", streamChunk); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// waitGoroutines waits for the goroutine count to fall back to at most want.
+func waitGoroutines(t *testing.T, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > want {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d goroutines, want at most %d", runtime.NumGoroutine(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClient_ExecuteStream_Abandoned(t *testing.T) {
+	disconnected := make(chan struct{})
+	server := endlessServer(t, disconnected)
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	c := client.New(&config.ClientConfig{
+		Timeout:           config.Duration(10 * time.Second),
+		StreamSendTimeout: config.Duration(50 * time.Millisecond),
+	})
+
+	baseline := runtime.NumGoroutine()
+
+	timings := make(chan client.Timing, 1)
+	ctx := client.WithTimingHook(context.Background(), func(timing client.Timing) {
+		timings <- timing
+	})
+
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{"stream": true})
+	chunks, err := c.ExecuteStream(ctx, req)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	// Read one chunk, then abandon the stream without cancelling ctx
+	if chunk := <-chunks; chunk == nil || chunk.Content() != "hi" {
+		t.Fatalf("got first chunk %+v, want content hi", chunk)
+	}
+
+	select {
+	case <-disconnected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("abandoned stream kept its connection open")
+	}
+
+	select {
+	case timing := <-timings:
+		if !errors.Is(timing.Err, client.ErrStreamAbandoned) {
+			t.Errorf("got timing error %v, want ErrStreamAbandoned", timing.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no timing reported for the abandoned stream")
+	}
+
+	for range chunks {
+	}
+	c.HTTPClient().CloseIdleConnections()
+	waitGoroutines(t, baseline)
+}
+
+func TestClient_ExecuteStream_SlowConsumer(t *testing.T) {
+	server := pausingServer(t, 10*time.Millisecond)
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	c := client.New(&config.ClientConfig{
+		Timeout:           config.Duration(10 * time.Second),
+		StreamSendTimeout: config.Duration(time.Second),
+	})
+
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{"stream": true})
+	chunks, err := c.ExecuteStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	// A consumer slower than the producer but within the timeout gets every chunk
+	var content string
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			t.Fatalf("unexpected stream error: %v", chunk.Error)
+		}
+		content += chunk.Content()
+		time.Sleep(50 * time.Millisecond)
+	}
+	if content != "hihi" {
+		t.Errorf("got content %q, want %q", content, "hihi")
+	}
+}
+
+func newStreamRequest(t *testing.T, baseURL string) request.Request {
+	t.Helper()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: baseURL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	return request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{"stream": true})
+}
+
+func TestClient_ExecuteStream_CancelReleasesStream(t *testing.T) {
+	disconnected := make(chan struct{})
+	server := endlessServer(t, disconnected)
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	baseline := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, end := client.WithStreamEnd(ctx)
+	chunks, err := c.ExecuteStream(ctx, newStreamRequest(t, server.URL))
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+	<-chunks
+	cancel()
+
+	closed := make(chan struct{})
+	go func() {
+		for range chunks {
+		}
+		close(closed)
+	}()
+
+	for name, ch := range map[string]<-chan struct{}{
+		"chunk channel": closed,
+		"end":           end.Done(),
+		"connection":    disconnected,
+	} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatalf("%s not released within a second of cancelling", name)
+		}
+	}
+
+	if !errors.Is(end.Err(), context.Canceled) {
+		t.Errorf("got end error %v, want context.Canceled", end.Err())
+	}
+	c.HTTPClient().CloseIdleConnections()
+	waitGoroutines(t, baseline)
+}
+
+func TestClient_ExecuteStream_EndCompleted(t *testing.T) {
+	server := pausingServer(t, 0)
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	ctx, end := client.WithStreamEnd(context.Background())
+	if end.Err() != nil {
+		t.Errorf("got error %v before the stream started, want nil", end.Err())
+	}
+
+	chunks, err := c.ExecuteStream(ctx, newStreamRequest(t, server.URL))
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+	for range chunks {
+	}
+
+	select {
+	case <-end.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after the stream completed")
+	}
+	if end.Err() != nil {
+		t.Errorf("got end error %v, want nil", end.Err())
+	}
+}
+
+func TestClient_ExecuteStream_EndStalled(t *testing.T) {
+	server := pausingServer(t, -1)
+	c := client.New(&config.ClientConfig{
+		Timeout:   config.Duration(10 * time.Second),
+		Streaming: config.StreamingConfig{IdleTimeout: config.Duration(100 * time.Millisecond)},
+	})
+
+	ctx, end := client.WithStreamEnd(context.Background())
+	chunks, err := c.ExecuteStream(ctx, newStreamRequest(t, server.URL))
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+	for range chunks {
+	}
+
+	<-end.Done()
+	if !errors.Is(end.Err(), client.ErrStreamStalled) {
+		t.Errorf("got end error %v, want ErrStreamStalled", end.Err())
+	}
+}
+
+func TestClient_ExecuteStream_EndNotStarted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	ctx, end := client.WithStreamEnd(context.Background())
+	_, err := c.ExecuteStream(ctx, newStreamRequest(t, server.URL))
+	if err == nil {
+		t.Fatal("expected ExecuteStream to fail")
+	}
+
+	select {
+	case <-end.Done():
+	default:
+		t.Fatal("Done not closed after ExecuteStream failed")
+	}
+	var statusErr *client.HTTPStatusError
+	if !errors.As(end.Err(), &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got end error %v, want the 503 status error", end.Err())
+	}
+}
+
+func TestClient_ExecuteStream_StreamingConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "%s\n\ndata: {\"choices\":[\n\n%s\n\ndata: [DONE]\n\n", streamChunk, streamChunk)
+	}))
+	t.Cleanup(server.Close)
+
+	c := client.New(&config.ClientConfig{
+		Timeout:   config.Duration(10 * time.Second),
+		Streaming: config.StreamingConfig{BufferSize: 3, ParseMode: "strict"},
+	})
+
+	chunks, err := c.ExecuteStream(context.Background(), newStreamRequest(t, server.URL))
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	// The buffer lets the whole stream arrive before anything is read
+	deadline := time.Now().Add(2 * time.Second)
+	for len(chunks) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if cap(chunks) != 3 || len(chunks) != 3 {
+		t.Fatalf("got %d of %d buffered chunks, want 3 of 3", len(chunks), cap(chunks))
+	}
+
+	var received []*response.StreamingChunk
+	for chunk := range chunks {
+		received = append(received, chunk)
+	}
+
+	var malformed *response.MalformedChunkError
+	if len(received) != 3 || !errors.As(received[1].Error, &malformed) {
+		t.Errorf("got %d chunks with middle error %v, want the malformed chunk surfaced in strict mode", len(received), received[1].Error)
+	}
+}
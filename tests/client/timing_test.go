@@ -0,0 +1,158 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+// timingRecorder collects the timings reported to its hook.
+type timingRecorder struct {
+	mu      sync.Mutex
+	timings []client.Timing
+}
+
+func (r *timingRecorder) hook(t client.Timing) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timings = append(r.timings, t)
+}
+
+func (r *timingRecorder) all() []client.Timing {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.timings
+}
+
+func TestClient_Execute_TimingHook(t *testing.T) {
+	server := flakyServer(t, 1, http.StatusServiceUnavailable, "")
+
+	var recorder timingRecorder
+	ctx := client.WithTimingHook(context.Background(), recorder.hook)
+
+	err := executeWithRetries(t, ctx, server.URL, config.RetryConfig{
+		MaxRetries:     2,
+		InitialBackoff: config.Duration(time.Millisecond),
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	timings := recorder.all()
+	if len(timings) != 2 {
+		t.Fatalf("got %d timings, want 2", len(timings))
+	}
+
+	first, second := timings[0], timings[1]
+	if first.Attempt != 1 || second.Attempt != 2 {
+		t.Errorf("attempts = %d, %d, want 1, 2", first.Attempt, second.Attempt)
+	}
+	if first.StatusCode != http.StatusServiceUnavailable || first.Err == nil {
+		t.Errorf("first attempt: status %d, err %v, want 503 and an error", first.StatusCode, first.Err)
+	}
+	if second.StatusCode != http.StatusOK || second.Err != nil {
+		t.Errorf("second attempt: status %d, err %v, want 200 and no error", second.StatusCode, second.Err)
+	}
+	if !second.ReusedConn {
+		t.Error("second attempt should reuse the pooled connection")
+	}
+
+	for _, timing := range timings {
+		if timing.Provider != "ollama" || timing.Protocol != "chat" || timing.Model != "test-model" {
+			t.Errorf("got %s/%s/%s, want ollama/chat/test-model", timing.Provider, timing.Protocol, timing.Model)
+		}
+		if timing.Stream {
+			t.Error("Stream should be false for Execute")
+		}
+		if timing.TTFB <= 0 || timing.Total < timing.TTFB || timing.Wait > timing.TTFB {
+			t.Errorf("inconsistent phases: wait %v, ttfb %v, total %v", timing.Wait, timing.TTFB, timing.Total)
+		}
+	}
+}
+
+func TestClient_Execute_NoTimingHook(t *testing.T) {
+	server := flakyServer(t, 0, http.StatusOK, "")
+
+	if err := executeWithRetries(t, context.Background(), server.URL, config.RetryConfig{}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+}
+
+func TestClient_ExecuteStream_TimingHook(t *testing.T) {
+	const pause = 50 * time.Millisecond
+	server := pausingServer(t, pause)
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	var recorder timingRecorder
+	ctx := client.WithTimingHook(context.Background(), recorder.hook)
+
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{"stream": true})
+	chunks, err := c.ExecuteStream(ctx, req)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+	for range chunks {
+	}
+
+	timings := recorder.all()
+	if len(timings) != 1 {
+		t.Fatalf("got %d timings, want 1", len(timings))
+	}
+
+	timing := timings[0]
+	if !timing.Stream || timing.Attempt != 1 || timing.StatusCode != http.StatusOK {
+		t.Errorf("got stream %t, attempt %d, status %d, want true, 1, 200", timing.Stream, timing.Attempt, timing.StatusCode)
+	}
+	if timing.TTFB <= 0 || timing.TTFB >= pause {
+		t.Errorf("TTFB = %v, want the first chunk before the %v pause", timing.TTFB, pause)
+	}
+	if timing.Total < pause {
+		t.Errorf("Total = %v, want the whole stream (at least %v)", timing.Total, pause)
+	}
+}
+
+func TestClient_ExecuteStream_TimingHookOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	mdl := model.New(&config.ModelConfig{Name: "test-model"})
+	c := client.New(&config.ClientConfig{Timeout: config.Duration(10 * time.Second)})
+
+	var recorder timingRecorder
+	ctx := client.WithTimingHook(context.Background(), recorder.hook)
+
+	req := request.NewChat(provider, mdl, []protocol.Message{protocol.NewMessage("user", "Hello")}, map[string]any{"stream": true})
+	if _, err := c.ExecuteStream(ctx, req); err == nil {
+		t.Fatal("expected ExecuteStream to fail")
+	}
+
+	timings := recorder.all()
+	if len(timings) != 1 {
+		t.Fatalf("got %d timings, want 1", len(timings))
+	}
+	if timings[0].StatusCode != http.StatusServiceUnavailable || timings[0].Err == nil {
+		t.Errorf("got status %d, err %v, want 503 and an error", timings[0].StatusCode, timings[0].Err)
+	}
+}
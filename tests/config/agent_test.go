@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
 )
 
 func TestAgentConfig_Unmarshal(t *testing.T) {
@@ -434,3 +436,280 @@ func TestLoadAgentConfig_MergesWithDefaults(t *testing.T) {
 		t.Fatal("model is nil")
 	}
 }
+
+func TestLoadAgentConfig_Extends(t *testing.T) {
+	tempDir := t.TempDir()
+
+	base := `{
+		"name": "base-agent",
+		"system_prompt": "Base prompt",
+		"provider": {
+			"name": "azure",
+			"base_url": "https://dev.openai.azure.com/openai",
+			"options": {"deployment": "gpt-4o", "token": "dev-token"}
+		},
+		"model": {"name": "gpt-4o"}
+	}`
+	prod := `{
+		"extends": "base.json",
+		"provider": {
+			"base_url": "https://prod.openai.azure.com/openai",
+			"options": {"token": "prod-token"}
+		}
+	}`
+
+	if err := os.WriteFile(filepath.Join(tempDir, "base.json"), []byte(base), 0644); err != nil {
+		t.Fatalf("failed to write base config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "prod.json"), []byte(prod), 0644); err != nil {
+		t.Fatalf("failed to write prod config: %v", err)
+	}
+
+	cfg, err := config.LoadAgentConfig(filepath.Join(tempDir, "prod.json"))
+	if err != nil {
+		t.Fatalf("LoadAgentConfig failed: %v", err)
+	}
+
+	if cfg.Name != "base-agent" {
+		t.Errorf("got name %q, want %q", cfg.Name, "base-agent")
+	}
+
+	if cfg.Provider.BaseURL != "https://prod.openai.azure.com/openai" {
+		t.Errorf("got base_url %q, want prod URL", cfg.Provider.BaseURL)
+	}
+
+	if cfg.Provider.Options["token"] != "prod-token" {
+		t.Errorf("got token %v, want %q", cfg.Provider.Options["token"], "prod-token")
+	}
+
+	if cfg.Provider.Options["deployment"] != "gpt-4o" {
+		t.Errorf("got deployment %v, want %q", cfg.Provider.Options["deployment"], "gpt-4o")
+	}
+
+	if cfg.Extends != "" {
+		t.Errorf("got extends %q, want empty after resolution", cfg.Extends)
+	}
+}
+
+func TestLoadAgentConfig_Overlays(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"base.json":  `{"name": "agent", "model": {"name": "llama3.2:3b"}}`,
+		"dev.json":   `{"provider": {"base_url": "http://dev:11434"}}`,
+		"local.json": `{"provider": {"base_url": "http://localhost:11435"}, "system_prompt": "Local"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cfg, err := config.LoadAgentConfig(
+		filepath.Join(tempDir, "base.json"),
+		filepath.Join(tempDir, "dev.json"),
+		filepath.Join(tempDir, "local.json"),
+	)
+	if err != nil {
+		t.Fatalf("LoadAgentConfig failed: %v", err)
+	}
+
+	if cfg.Provider.BaseURL != "http://localhost:11435" {
+		t.Errorf("got base_url %q, want last overlay", cfg.Provider.BaseURL)
+	}
+
+	if cfg.Model.Name != "llama3.2:3b" {
+		t.Errorf("got model %q, want %q", cfg.Model.Name, "llama3.2:3b")
+	}
+
+	if cfg.SystemPrompt != "Local" {
+		t.Errorf("got system prompt %q, want %q", cfg.SystemPrompt, "Local")
+	}
+}
+
+func TestLoadAgentConfig_CircularExtends(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tempDir, "a.json"), []byte(`{"extends": "b.json"}`), 0644); err != nil {
+		t.Fatalf("failed to write a.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "b.json"), []byte(`{"extends": "a.json"}`), 0644); err != nil {
+		t.Fatalf("failed to write b.json: %v", err)
+	}
+
+	if _, err := config.LoadAgentConfig(filepath.Join(tempDir, "a.json")); err == nil {
+		t.Error("expected error for circular extends")
+	}
+}
+
+func TestSaveAgentConfig_RoundTrip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "saved.json")
+
+	original := config.DefaultAgentConfig()
+	original.Name = "saved-agent"
+	original.Client.Timeout = config.Duration(45 * time.Second)
+	original.Provider.Options["auth_type"] = "bearer"
+	original.Model.Name = "llama3.2:3b"
+	original.Model.Capabilities["chat"] = map[string]any{"temperature": 0.3}
+
+	if err := config.SaveAgentConfig(filename, &original); err != nil {
+		t.Fatalf("SaveAgentConfig failed: %v", err)
+	}
+
+	loaded, err := config.LoadAgentConfig(filename)
+	if err != nil {
+		t.Fatalf("LoadAgentConfig failed: %v", err)
+	}
+
+	if loaded.Name != "saved-agent" || loaded.Model.Name != "llama3.2:3b" {
+		t.Errorf("got %+v, want saved values", loaded)
+	}
+
+	if loaded.Client.Timeout.ToDuration() != 45*time.Second {
+		t.Errorf("got timeout %v, want 45s", loaded.Client.Timeout.ToDuration())
+	}
+
+	if loaded.Model.Capabilities["chat"]["temperature"] != 0.3 {
+		t.Errorf("got capabilities %v", loaded.Model.Capabilities)
+	}
+
+	if !loaded.Client.Retry.JitterEnabled() {
+		t.Error("got jitter false, want true")
+	}
+}
+
+func TestAgentConfig_Redacted(t *testing.T) {
+	cfg := config.DefaultAgentConfig()
+	cfg.Provider.Options = map[string]any{
+		"token":         "secret-token",
+		"token_file":    "/run/secrets/llm",
+		"token_command": "vault read -field=key secret/llm",
+		"client_secret": "entra-secret",
+		"cookie":        "session=abc",
+		"auth_type":     "bearer",
+		"deployment":    "gpt-4o",
+	}
+
+	redacted := cfg.Redacted()
+
+	for _, key := range []string{"token", "token_command", "client_secret", "cookie"} {
+		if redacted.Provider.Options[key] != config.RedactedValue {
+			t.Errorf("got %s %v, want redacted", key, redacted.Provider.Options[key])
+		}
+	}
+
+	for _, key := range []string{"token_file", "auth_type", "deployment"} {
+		if redacted.Provider.Options[key] != cfg.Provider.Options[key] {
+			t.Errorf("got %s %v, want %v", key, redacted.Provider.Options[key], cfg.Provider.Options[key])
+		}
+	}
+
+	if cfg.Provider.Options["token"] != "secret-token" {
+		t.Error("Redacted modified the original config")
+	}
+
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if strings.Contains(string(data), "secret-token") {
+		t.Errorf("redacted output leaks token: %s", data)
+	}
+}
+
+func TestAgentConfig_Redacted_PolicyKeys(t *testing.T) {
+	policy, err := redact.New(redact.Config{Keys: []string{"tenant_id"}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	redact.SetDefault(policy)
+	defer redact.SetDefault(nil)
+
+	cfg := config.DefaultAgentConfig()
+	cfg.Provider.Options = map[string]any{"tenant_id": "acme-tenant"}
+
+	if got := cfg.Redacted().Provider.Options["tenant_id"]; got != config.RedactedValue {
+		t.Errorf("got tenant_id %v, want redacted", got)
+	}
+}
+
+func TestAgentConfig_Merge_Aliases(t *testing.T) {
+	base := config.DefaultAgentConfig()
+	base.Aliases = map[string]*config.AliasConfig{
+		"fast": {Model: &config.ModelConfig{Name: "llama3.2:3b"}},
+	}
+	base.Routes = map[string]string{"embeddings": "embed"}
+
+	base.Merge(&config.AgentConfig{
+		Aliases: map[string]*config.AliasConfig{
+			"fast":  {Model: &config.ModelConfig{Name: "llama3.2:1b"}},
+			"smart": {Model: &config.ModelConfig{Name: "gpt-4o"}},
+		},
+		Routes: map[string]string{"vision": "smart"},
+	})
+
+	if base.Aliases["fast"].Model.Name != "llama3.2:1b" {
+		t.Errorf("got fast model %q, want %q", base.Aliases["fast"].Model.Name, "llama3.2:1b")
+	}
+
+	if base.Aliases["smart"] == nil {
+		t.Error("smart alias missing after merge")
+	}
+
+	if base.Routes["embeddings"] != "embed" || base.Routes["vision"] != "smart" {
+		t.Errorf("got routes %v, want embeddings and vision routes", base.Routes)
+	}
+}
+
+func TestAgentConfig_Merge_AliasLeavesSourceUntouched(t *testing.T) {
+	first := &config.AgentConfig{
+		Aliases: map[string]*config.AliasConfig{
+			"smart": {
+				Provider: &config.ProviderConfig{Name: "azure", Options: map[string]any{"deployment": "gpt-4o"}},
+				Model:    &config.ModelConfig{Name: "gpt-4o"},
+			},
+		},
+	}
+
+	base := config.DefaultAgentConfig()
+	base.Merge(first)
+	base.Merge(&config.AgentConfig{
+		Aliases: map[string]*config.AliasConfig{
+			"smart": {
+				Provider: &config.ProviderConfig{Options: map[string]any{"deployment": "gpt-4.1"}},
+				Model:    &config.ModelConfig{Name: "gpt-4.1"},
+			},
+		},
+	})
+
+	if base.Aliases["smart"].Model.Name != "gpt-4.1" {
+		t.Errorf("got merged model %q, want %q", base.Aliases["smart"].Model.Name, "gpt-4.1")
+	}
+
+	source := first.Aliases["smart"]
+	if source.Model.Name != "gpt-4o" || source.Provider.Options["deployment"] != "gpt-4o" {
+		t.Errorf("got source alias model %q deployment %v, want the source left untouched",
+			source.Model.Name, source.Provider.Options["deployment"])
+	}
+}
+
+func TestAgentConfig_Redacted_AliasProvider(t *testing.T) {
+	cfg := config.DefaultAgentConfig()
+	cfg.Aliases = map[string]*config.AliasConfig{
+		"smart": {
+			Provider: &config.ProviderConfig{Name: "azure", Options: map[string]any{"token": "alias-secret"}},
+			Model:    &config.ModelConfig{Name: "gpt-4o"},
+		},
+	}
+
+	redacted := cfg.Redacted()
+
+	if redacted.Aliases["smart"].Provider.Options["token"] != config.RedactedValue {
+		t.Errorf("got alias token %v, want redacted", redacted.Aliases["smart"].Provider.Options["token"])
+	}
+
+	if cfg.Aliases["smart"].Provider.Options["token"] != "alias-secret" {
+		t.Error("Redacted modified the original alias config")
+	}
+}
@@ -47,7 +47,7 @@ func TestClientConfig_Unmarshal(t *testing.T) {
 		t.Errorf("got backoff_multiplier %v, want 2.0", cfg.Retry.BackoffMultiplier)
 	}
 
-	if !cfg.Retry.Jitter {
+	if !cfg.Retry.JitterEnabled() {
 		t.Error("got jitter false, want true")
 	}
 
@@ -87,7 +87,7 @@ func TestClientConfig_Defaults(t *testing.T) {
 		t.Errorf("got backoff_multiplier %v, want 2.0", cfg.Retry.BackoffMultiplier)
 	}
 
-	if !cfg.Retry.Jitter {
+	if !cfg.Retry.JitterEnabled() {
 		t.Error("got jitter false, want true")
 	}
 
@@ -119,7 +119,7 @@ func TestRetryConfig_Defaults(t *testing.T) {
 		t.Errorf("got backoff_multiplier %v, want 2.0", cfg.BackoffMultiplier)
 	}
 
-	if !cfg.Jitter {
+	if !cfg.JitterEnabled() {
 		t.Error("got jitter false, want true")
 	}
 }
@@ -217,6 +217,30 @@ func TestClientConfig_Merge(t *testing.T) {
 				ConnectionTimeout: config.Duration(90 * time.Second),
 			},
 		},
+		{
+			name: "merge parse_mode",
+			base: &config.ClientConfig{
+				ParseMode: "lenient",
+			},
+			source: &config.ClientConfig{
+				ParseMode: "strict",
+			},
+			expected: &config.ClientConfig{
+				ParseMode: "strict",
+			},
+		},
+		{
+			name: "merge option_validation",
+			base: &config.ClientConfig{
+				OptionValidation: "strict",
+			},
+			source: &config.ClientConfig{
+				OptionValidation: "lenient",
+			},
+			expected: &config.ClientConfig{
+				OptionValidation: "lenient",
+			},
+		},
 		{
 			name: "zero values preserve base",
 			base: &config.ClientConfig{
@@ -259,6 +283,72 @@ func TestClientConfig_Merge(t *testing.T) {
 			if tt.base.ConnectionTimeout != tt.expected.ConnectionTimeout {
 				t.Errorf("got connection_timeout %v, want %v", tt.base.ConnectionTimeout, tt.expected.ConnectionTimeout)
 			}
+
+			if tt.base.ParseMode != tt.expected.ParseMode {
+				t.Errorf("got parse_mode %q, want %q", tt.base.ParseMode, tt.expected.ParseMode)
+			}
+
+			if tt.base.OptionValidation != tt.expected.OptionValidation {
+				t.Errorf("got option_validation %q, want %q", tt.base.OptionValidation, tt.expected.OptionValidation)
+			}
+		})
+	}
+}
+
+func TestClientConfig_Merge_Jitter(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected bool
+	}{
+		{name: "omitted jitter preserves base", source: `{"timeout": "10s"}`, expected: true},
+		{name: "retry without jitter preserves base", source: `{"retry": {"max_retries": 5}}`, expected: true},
+		{name: "explicit false disables", source: `{"retry": {"jitter": false}}`, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := config.DefaultClientConfig()
+
+			var source config.ClientConfig
+			if err := json.Unmarshal([]byte(tt.source), &source); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+
+			base.Merge(&source)
+
+			if base.Retry.JitterEnabled() != tt.expected {
+				t.Errorf("got jitter %v, want %v", base.Retry.JitterEnabled(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestClientConfig_Merge_JitterInCode(t *testing.T) {
+	disabled := false
+	enabled := true
+
+	tests := []struct {
+		name     string
+		base     bool
+		source   config.RetryConfig
+		expected bool
+	}{
+		{name: "explicit false disables", base: true, source: config.RetryConfig{Jitter: &disabled}, expected: false},
+		{name: "explicit true enables", base: false, source: config.RetryConfig{Jitter: &enabled}, expected: true},
+		{name: "nil jitter preserves base", base: true, source: config.RetryConfig{MaxRetries: 5}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := config.DefaultClientConfig()
+			base.Retry.Jitter = &tt.base
+
+			base.Merge(&config.ClientConfig{Retry: tt.source})
+
+			if base.Retry.JitterEnabled() != tt.expected {
+				t.Errorf("got jitter %v, want %v", base.Retry.JitterEnabled(), tt.expected)
+			}
 		})
 	}
 }
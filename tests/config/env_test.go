@@ -0,0 +1,158 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("TAU_NAME", "env-agent")
+	t.Setenv("TAU_PROVIDER_NAME", "azure")
+	t.Setenv("TAU_PROVIDER_BASE_URL", "https://env.openai.azure.com/openai")
+	t.Setenv("TAU_PROVIDER_OPTIONS_TOKEN", "env-token")
+	t.Setenv("TAU_PROVIDER_OPTIONS_HEADERS", `{"X-Team":"core"}`)
+	t.Setenv("TAU_MODEL_NAME", "gpt-4o")
+	t.Setenv("TAU_MODEL_CAPABILITIES_CHAT", `{"temperature":0.2}`)
+	t.Setenv("TAU_CLIENT_TIMEOUT", "45s")
+	t.Setenv("TAU_CLIENT_RETRY_MAX_RETRIES", "5")
+
+	cfg, err := config.FromEnv("TAU")
+	if err != nil {
+		t.Fatalf("FromEnv failed: %v", err)
+	}
+
+	if cfg.Name != "env-agent" {
+		t.Errorf("got name %q, want %q", cfg.Name, "env-agent")
+	}
+
+	if cfg.Provider == nil || cfg.Provider.Name != "azure" || cfg.Provider.BaseURL != "https://env.openai.azure.com/openai" {
+		t.Fatalf("got provider %+v", cfg.Provider)
+	}
+
+	if cfg.Provider.Options["token"] != "env-token" {
+		t.Errorf("got token %v, want %q", cfg.Provider.Options["token"], "env-token")
+	}
+
+	if headers, ok := cfg.Provider.Options["headers"].(map[string]any); !ok || headers["X-Team"] != "core" {
+		t.Errorf("got headers %v, want decoded JSON object", cfg.Provider.Options["headers"])
+	}
+
+	if cfg.Model == nil || cfg.Model.Capabilities["chat"]["temperature"] != 0.2 {
+		t.Errorf("got model %+v", cfg.Model)
+	}
+
+	if cfg.Client == nil || cfg.Client.Timeout.ToDuration() != 45*time.Second || cfg.Client.Retry.MaxRetries != 5 {
+		t.Errorf("got client %+v", cfg.Client)
+	}
+}
+
+func TestFromEnv_MergeOverFile(t *testing.T) {
+	t.Setenv("TAU_CLIENT_TIMEOUT", "10s")
+
+	cfg := config.DefaultAgentConfig()
+	cfg.Merge(&config.AgentConfig{
+		Name:     "file-agent",
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: "http://file:11434"},
+	})
+
+	env, err := config.FromEnv("TAU")
+	if err != nil {
+		t.Fatalf("FromEnv failed: %v", err)
+	}
+	cfg.Merge(env)
+
+	if cfg.Name != "file-agent" {
+		t.Errorf("got name %q, want file value preserved", cfg.Name)
+	}
+
+	if cfg.Provider.BaseURL != "http://file:11434" {
+		t.Errorf("got base_url %q, want file value preserved", cfg.Provider.BaseURL)
+	}
+
+	if cfg.Client.Timeout.ToDuration() != 10*time.Second {
+		t.Errorf("got timeout %v, want 10s", cfg.Client.Timeout.ToDuration())
+	}
+
+	if !cfg.Client.Retry.JitterEnabled() {
+		t.Error("got jitter false, want default preserved when not set in env")
+	}
+}
+
+func TestFromEnv_Unset(t *testing.T) {
+	cfg, err := config.FromEnv("TAU_TEST_UNSET")
+	if err != nil {
+		t.Fatalf("FromEnv failed: %v", err)
+	}
+
+	if cfg.Provider != nil || cfg.Model != nil || cfg.Client != nil {
+		t.Errorf("got %+v, want empty config", cfg)
+	}
+}
+
+func TestFromEnv_InvalidValues(t *testing.T) {
+	t.Setenv("TAU_CLIENT_TIMEOUT", "soon")
+	t.Setenv("TAU_CLIENT_CONNECTION_POOL_SIZE", "many")
+
+	if _, err := config.FromEnv("TAU"); err == nil {
+		t.Error("expected error for invalid values")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.json")
+	overlay := filepath.Join(dir, "prod.json")
+	os.WriteFile(base, []byte(`{"name": "base", "provider": {"name": "ollama", "base_url": "http://localhost:11434"}, "model": {"name": "llama3"}}`), 0600)
+	os.WriteFile(overlay, []byte(`{"model": {"name": "llama3.1"}}`), 0600)
+	t.Setenv("TAU_LOAD_NAME", "env-agent")
+
+	cfg, err := config.Load("TAU_LOAD", base, overlay)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Name != "env-agent" || cfg.Model.Name != "llama3.1" || cfg.Provider.Name != "ollama" {
+		t.Errorf("got name %q model %q provider %q, want overlay and environment merged over base", cfg.Name, cfg.Model.Name, cfg.Provider.Name)
+	}
+
+	t.Chdir(dir)
+	cfg, err = config.Load("TAU_LOAD")
+	if err != nil {
+		t.Fatalf("Load without files failed: %v", err)
+	}
+	if cfg.Name != "env-agent" {
+		t.Errorf("got name %q, want environment merged over defaults", cfg.Name)
+	}
+}
+
+func TestLoad_KeepsRewriteWarnings(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(file, []byte(`{
+		"provider": {"name": "ollama", "base_url": "http://localhost:11434"},
+		"model": {"name": "gpt-4-vision-preview"},
+		"deprecated_models": "rewrite"
+	}`), 0600)
+
+	cfg, err := config.Load("TAU_LOAD_REWRITE", file)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	warnings := cfg.Deprecations()
+	if cfg.Model.Name != "gpt-4o" || len(warnings) != 1 || !warnings[0].Rewritten {
+		t.Errorf("got model %q, warnings %v, want the rewrite and its warning", cfg.Model.Name, warnings)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	var files config.Files
+	files.Set("base.json, prod.json")
+	files.Set("local.json")
+
+	if got := files.String(); got != "base.json,prod.json,local.json" {
+		t.Errorf("got %q, want files in order", got)
+	}
+}
@@ -0,0 +1,185 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+func TestLookupModel(t *testing.T) {
+	tests := []struct {
+		name        string
+		model       string
+		found       bool
+		deprecated  bool
+		replacement string
+	}{
+		{name: "current model", model: "gpt-4o", found: true},
+		{name: "deprecated model", model: "gpt-4-vision-preview", found: true, deprecated: true, replacement: "gpt-4o"},
+		{name: "case insensitive", model: "GPT-4-Vision-Preview", found: true, deprecated: true, replacement: "gpt-4o"},
+		{name: "ollama tag", model: "llama2:7b", found: true, deprecated: true, replacement: "llama3.2"},
+		{name: "unknown", model: "custom-model", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := config.LookupModel(tt.model)
+			if ok != tt.found {
+				t.Fatalf("got found %v, want %v", ok, tt.found)
+			}
+
+			if info.Deprecated != tt.deprecated {
+				t.Errorf("got deprecated %v, want %v", info.Deprecated, tt.deprecated)
+			}
+
+			if info.Replacement != tt.replacement {
+				t.Errorf("got replacement %q, want %q", info.Replacement, tt.replacement)
+			}
+		})
+	}
+}
+
+func TestRegisterModel(t *testing.T) {
+	config.RegisterModel(config.ModelInfo{Name: "test-legacy", Deprecated: true, Replacement: "test-current"})
+
+	info, ok := config.LookupModel("test-legacy")
+	if !ok || info.Replacement != "test-current" {
+		t.Errorf("got %+v, want registered test-legacy", info)
+	}
+
+	found := false
+	for _, known := range config.KnownModels() {
+		if known.Name == "test-legacy" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("KnownModels missing registered model")
+	}
+}
+
+func TestAgentConfig_ResolveDeprecations(t *testing.T) {
+	newConfig := func(policy config.DeprecationPolicy) *config.AgentConfig {
+		cfg := config.DefaultAgentConfig()
+		cfg.Model.Name = "gpt-4-vision-preview"
+		cfg.Aliases = map[string]*config.AliasConfig{
+			"old": {Model: &config.ModelConfig{Name: "o1-mini"}},
+		}
+		cfg.DeprecatedModels = policy
+		return &cfg
+	}
+
+	t.Run("warn", func(t *testing.T) {
+		cfg := newConfig("")
+		if err := cfg.ResolveDeprecations(); err != nil {
+			t.Fatalf("ResolveDeprecations failed: %v", err)
+		}
+
+		warnings := cfg.Deprecations()
+		if len(warnings) != 2 {
+			t.Fatalf("got %d warnings, want 2", len(warnings))
+		}
+
+		if cfg.Model.Name != "gpt-4-vision-preview" {
+			t.Errorf("got model %q, want unchanged", cfg.Model.Name)
+		}
+
+		if warnings[1].Alias != "old" || !strings.Contains(warnings[1].String(), `use "o4-mini"`) {
+			t.Errorf("got alias warning %q", warnings[1])
+		}
+	})
+
+	t.Run("rewrite", func(t *testing.T) {
+		cfg := newConfig(config.DeprecationRewrite)
+		if err := cfg.ResolveDeprecations(); err != nil {
+			t.Fatalf("ResolveDeprecations failed: %v", err)
+		}
+
+		if cfg.Model.Name != "gpt-4o" {
+			t.Errorf("got model %q, want %q", cfg.Model.Name, "gpt-4o")
+		}
+
+		if cfg.Aliases["old"].Model.Name != "o4-mini" {
+			t.Errorf("got alias model %q, want %q", cfg.Aliases["old"].Model.Name, "o4-mini")
+		}
+
+		if warnings := cfg.Deprecations(); len(warnings) != 2 || !warnings[0].Rewritten {
+			t.Errorf("got warnings %v, want rewritten warnings", warnings)
+		}
+	})
+
+	t.Run("resolve after override", func(t *testing.T) {
+		cfg := newConfig(config.DeprecationRewrite)
+		if err := cfg.ResolveDeprecations(); err != nil {
+			t.Fatalf("ResolveDeprecations failed: %v", err)
+		}
+
+		cfg.Model.Name = "gpt-4-32k"
+		if err := cfg.ResolveDeprecations(); err != nil {
+			t.Fatalf("ResolveDeprecations failed: %v", err)
+		}
+
+		warnings := cfg.Deprecations()
+		if len(warnings) != 2 {
+			t.Fatalf("got warnings %v, want the kept alias rewrite and the override", warnings)
+		}
+		if warnings[0].Alias != "old" || warnings[1].Model != "gpt-4-32k" || cfg.Model.Name != "gpt-4o" {
+			t.Errorf("got warnings %v, model %q", warnings, cfg.Model.Name)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		cfg := newConfig(config.DeprecationError)
+		if err := cfg.ResolveDeprecations(); !errors.Is(err, config.ErrDeprecatedModel) {
+			t.Errorf("got error %v, want ErrDeprecatedModel", err)
+		}
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		cfg := newConfig(config.DeprecationIgnore)
+		if err := cfg.ResolveDeprecations(); err != nil {
+			t.Fatalf("ResolveDeprecations failed: %v", err)
+		}
+
+		if len(cfg.Deprecations()) != 0 {
+			t.Errorf("got %d warnings, want none", len(cfg.Deprecations()))
+		}
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		cfg := newConfig("sometimes")
+		if err := cfg.ResolveDeprecations(); err == nil {
+			t.Error("expected error for invalid policy")
+		}
+	})
+}
+
+func TestLoadAgentConfig_RewritesDeprecatedModel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{
+		"name": "legacy-agent",
+		"deprecated_models": "rewrite",
+		"provider": {"name": "azure", "base_url": "https://example.openai.azure.com/openai"},
+		"model": {"name": "gpt-4-vision-preview"}
+	}`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	cfg, err := config.LoadAgentConfig(path)
+	if err != nil {
+		t.Fatalf("LoadAgentConfig failed: %v", err)
+	}
+
+	if cfg.Model.Name != "gpt-4o" {
+		t.Errorf("got model %q, want %q", cfg.Model.Name, "gpt-4o")
+	}
+
+	if cfg.Model.ContextWindow != 128000 {
+		t.Errorf("got context window %d, want gpt-4o preset applied", cfg.Model.ContextWindow)
+	}
+}
@@ -247,3 +247,28 @@ func TestModelConfig_Merge(t *testing.T) {
 		})
 	}
 }
+
+func TestModelConfig_Merge_Limits(t *testing.T) {
+	base := &config.ModelConfig{
+		ContextWindow:   8192,
+		MaxOutputTokens: 2048,
+		Supports:        map[string]bool{config.FeatureVision: false, config.FeatureTools: true},
+	}
+
+	base.Merge(&config.ModelConfig{
+		ContextWindow: 128000,
+		Supports:      map[string]bool{config.FeatureVision: true},
+	})
+
+	if base.ContextWindow != 128000 {
+		t.Errorf("got context window %d, want 128000", base.ContextWindow)
+	}
+
+	if base.MaxOutputTokens != 2048 {
+		t.Errorf("got max output tokens %d, want 2048", base.MaxOutputTokens)
+	}
+
+	if !base.Supports[config.FeatureVision] || !base.Supports[config.FeatureTools] {
+		t.Errorf("got supports %v, want vision and tools enabled", base.Supports)
+	}
+}
@@ -0,0 +1,172 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+func TestLookupPreset(t *testing.T) {
+	config.RegisterPreset(config.AnyProvider, "test-preset", &config.ModelConfig{
+		Capabilities: map[string]map[string]any{"chat": {"max_tokens": 100}},
+	})
+	config.RegisterPreset(config.AnyProvider, "test-preset-large", &config.ModelConfig{
+		Capabilities: map[string]map[string]any{"chat": {"max_tokens": 200}},
+	})
+	config.RegisterPreset("test-provider", "test-preset", &config.ModelConfig{
+		Capabilities: map[string]map[string]any{"chat": {"max_tokens": 300}},
+	})
+
+	tests := []struct {
+		name      string
+		provider  string
+		model     string
+		maxTokens int
+		found     bool
+	}{
+		{name: "any provider", provider: "ollama", model: "test-preset-small", maxTokens: 100, found: true},
+		{name: "longest prefix", provider: "ollama", model: "Test-Preset-Large-v2", maxTokens: 200, found: true},
+		{name: "provider specific wins", provider: "test-provider", model: "test-preset-large", maxTokens: 300, found: true},
+		{name: "no match", provider: "ollama", model: "unknown-model", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preset, ok := config.LookupPreset(tt.provider, tt.model)
+			if ok != tt.found {
+				t.Fatalf("got found %v, want %v", ok, tt.found)
+			}
+			if !ok {
+				return
+			}
+			if preset.Capabilities["chat"]["max_tokens"] != tt.maxTokens {
+				t.Errorf("got max_tokens %v, want %d", preset.Capabilities["chat"]["max_tokens"], tt.maxTokens)
+			}
+		})
+	}
+}
+
+func TestLookupPreset_ReturnsCopy(t *testing.T) {
+	preset, ok := config.LookupPreset("azure", "gpt-4o")
+	if !ok {
+		t.Fatal("expected gpt-4o preset")
+	}
+	preset.Capabilities["chat"]["format"] = "anthropic"
+
+	again, _ := config.LookupPreset("azure", "gpt-4o")
+	if again.Capabilities["chat"]["format"] == "anthropic" {
+		t.Error("modifying a looked-up preset changed the registry")
+	}
+}
+
+func TestLoadAgentConfig_AppliesPreset(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.json")
+	content := `{
+		"provider": {"name": "azure", "base_url": "https://test.openai.azure.com/openai"},
+		"model": {
+			"name": "gpt-4o",
+			"capabilities": {"chat": {"max_tokens": 1024, "temperature": 0.2}}
+		}
+	}`
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := config.LoadAgentConfig(filename)
+	if err != nil {
+		t.Fatalf("LoadAgentConfig failed: %v", err)
+	}
+
+	chat := cfg.Model.Capabilities["chat"]
+	if chat["max_tokens"] != float64(1024) || chat["temperature"] != 0.2 {
+		t.Errorf("got chat %v, want config values to override preset", chat)
+	}
+
+	for _, protocol := range []string{"chat", "vision", "tools"} {
+		if cfg.Model.Capabilities[protocol]["format"] != "openai" {
+			t.Errorf("got %s capability %v, want openai format from preset", protocol, cfg.Model.Capabilities[protocol])
+		}
+	}
+
+	if _, ok := cfg.Model.Capabilities["vision"]["max_tokens"]; ok {
+		t.Error("preset added max_tokens to an unconfigured capability")
+	}
+}
+
+func TestLookupPreset_Limits(t *testing.T) {
+	preset, ok := config.LookupPreset("azure", "gpt-4o-mini")
+	if !ok {
+		t.Fatal("expected gpt-4o preset")
+	}
+
+	if preset.ContextWindow != 128000 || preset.MaxOutputTokens != 16384 {
+		t.Errorf("got limits %d/%d, want 128000/16384", preset.ContextWindow, preset.MaxOutputTokens)
+	}
+
+	preset, ok = config.LookupPreset("ollama", "llava:13b")
+	if !ok {
+		t.Fatal("expected llava preset")
+	}
+
+	if supported, listed := preset.Supports[config.FeatureTools]; !listed || supported {
+		t.Errorf("got supports %v, want tools disabled", preset.Supports)
+	}
+}
+
+func TestAgentConfig_ApplyPreset_Aliases(t *testing.T) {
+	cfg := config.DefaultAgentConfig()
+	cfg.Provider.Name = "ollama"
+	cfg.Aliases = map[string]*config.AliasConfig{
+		"smart": {
+			Provider: &config.ProviderConfig{Name: "azure"},
+			Model:    &config.ModelConfig{Name: "gpt-4o"},
+		},
+		"embed": {Model: &config.ModelConfig{Name: "nomic-embed-text"}},
+	}
+
+	cfg.ApplyPreset()
+
+	if cfg.Aliases["smart"].Model.ContextWindow != 128000 {
+		t.Errorf("got smart context window %d, want 128000", cfg.Aliases["smart"].Model.ContextWindow)
+	}
+
+	if cfg.Aliases["embed"].Model.Capabilities["embeddings"]["format"] != "openai" {
+		t.Error("expected embed alias to receive the ollama embeddings preset")
+	}
+}
+
+func TestAgentConfig_ApplyPreset_Advisory(t *testing.T) {
+	cfg := config.DefaultAgentConfig()
+	cfg.Provider.Name = "ollama"
+	cfg.Model = &config.ModelConfig{
+		Name:     "llama3.2:latest",
+		Supports: map[string]bool{config.FeatureTools: true},
+	}
+
+	cfg.ApplyPreset()
+
+	if !slices.Contains(cfg.Model.Advisory, config.FeatureVision) {
+		t.Errorf("got advisory %v, want preset vision flag marked advisory", cfg.Model.Advisory)
+	}
+
+	if slices.Contains(cfg.Model.Advisory, config.FeatureTools) {
+		t.Errorf("got advisory %v, want configured tools flag enforced", cfg.Model.Advisory)
+	}
+
+	cfg = config.DefaultAgentConfig()
+	cfg.Provider.Name = "azure"
+	cfg.Model = &config.ModelConfig{Name: "gpt-4o", ContextWindow: 64000}
+
+	cfg.ApplyPreset()
+
+	if slices.Contains(cfg.Model.Advisory, config.LimitContextWindow) {
+		t.Errorf("got advisory %v, want configured context window enforced", cfg.Model.Advisory)
+	}
+
+	if !slices.Contains(cfg.Model.Advisory, config.LimitMaxOutputTokens) {
+		t.Errorf("got advisory %v, want preset output limit marked advisory", cfg.Model.Advisory)
+	}
+}
@@ -0,0 +1,63 @@
+package config_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+func TestPricingConfig_Cost(t *testing.T) {
+	pricing := &config.PricingConfig{PromptPer1K: 0.0025, CompletionPer1K: 0.01}
+
+	got := pricing.Cost(2000, 500)
+	if math.Abs(got-0.01) > 1e-9 {
+		t.Errorf("got cost %v, want 0.01", got)
+	}
+}
+
+func TestAgentConfig_PricingAndQuota(t *testing.T) {
+	data := `{
+		"name": "metered-agent",
+		"model": {
+			"name": "gpt-4o",
+			"pricing": {"prompt_per_1k": 0.0025, "completion_per_1k": 0.01, "currency": "USD"}
+		},
+		"quota": {"window": "1m", "max_requests": 60, "max_tokens": 90000}
+	}`
+
+	var cfg config.AgentConfig
+	if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if cfg.Model.Pricing == nil || cfg.Model.Pricing.CompletionPer1K != 0.01 || cfg.Model.Pricing.Currency != "USD" {
+		t.Errorf("got pricing %+v", cfg.Model.Pricing)
+	}
+
+	if cfg.Quota == nil || cfg.Quota.Window.ToDuration() != time.Minute || cfg.Quota.MaxRequests != 60 || cfg.Quota.MaxTokens != 90000 {
+		t.Errorf("got quota %+v", cfg.Quota)
+	}
+}
+
+func TestAgentConfig_Merge_PricingAndQuota(t *testing.T) {
+	base := config.DefaultAgentConfig()
+	base.Merge(&config.AgentConfig{
+		Model: &config.ModelConfig{Pricing: &config.PricingConfig{PromptPer1K: 1, CompletionPer1K: 2}},
+		Quota: &config.QuotaConfig{Window: config.Duration(time.Minute), MaxRequests: 10},
+	})
+	base.Merge(&config.AgentConfig{
+		Model: &config.ModelConfig{Pricing: &config.PricingConfig{CompletionPer1K: 3}},
+		Quota: &config.QuotaConfig{MaxTokens: 500},
+	})
+
+	if base.Model.Pricing.PromptPer1K != 1 || base.Model.Pricing.CompletionPer1K != 3 {
+		t.Errorf("got pricing %+v", base.Model.Pricing)
+	}
+
+	if base.Quota.MaxRequests != 10 || base.Quota.MaxTokens != 500 || base.Quota.Window.ToDuration() != time.Minute {
+		t.Errorf("got quota %+v", base.Quota)
+	}
+}
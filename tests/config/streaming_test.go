@@ -0,0 +1,193 @@
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+func TestStreamingConfig_Unmarshal(t *testing.T) {
+	jsonData := `{
+		"timeout": "30s",
+		"streaming": {
+			"buffer_size": 16,
+			"idle_timeout": "20s",
+			"heartbeat": "5s",
+			"include_usage": true,
+			"parse_mode": "strict"
+		}
+	}`
+
+	var cfg config.ClientConfig
+	if err := json.Unmarshal([]byte(jsonData), &cfg); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	want := config.StreamingConfig{
+		BufferSize:  16,
+		IdleTimeout: config.Duration(20 * time.Second),
+		Heartbeat:   config.Duration(5 * time.Second),
+		ParseMode:   "strict",
+	}
+	got := cfg.Streaming
+	if !got.IncludeUsageEnabled() {
+		t.Error("got include_usage disabled, want enabled")
+	}
+	got.IncludeUsage = nil
+	if got != want {
+		t.Errorf("got streaming %+v, want %+v", got, want)
+	}
+}
+
+func TestStreamingConfig_OmittedWhenUnset(t *testing.T) {
+	data, err := json.Marshal(config.DefaultClientConfig())
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if strings.Contains(string(data), "streaming") {
+		t.Errorf("got %s, want no streaming block", data)
+	}
+}
+
+func TestClientConfig_ResolvedStreaming(t *testing.T) {
+	cfg := &config.ClientConfig{
+		ParseMode:         "strict",
+		StreamIdleTimeout: config.Duration(10 * time.Second),
+		StreamHeartbeat:   config.Duration(2 * time.Second),
+		Streaming: config.StreamingConfig{
+			IdleTimeout: config.Duration(30 * time.Second),
+		},
+	}
+
+	got := cfg.ResolvedStreaming()
+	if got.IdleTimeout.ToDuration() != 30*time.Second {
+		t.Errorf("got idle timeout %v, want the streaming block's 30s", got.IdleTimeout.ToDuration())
+	}
+	if got.Heartbeat.ToDuration() != 2*time.Second {
+		t.Errorf("got heartbeat %v, want the deprecated field's 2s", got.Heartbeat.ToDuration())
+	}
+	if got.ParseMode != "" {
+		t.Errorf("got parse mode %q, want the client parse mode left out of streaming", got.ParseMode)
+	}
+}
+
+func TestClientConfig_Merge_Streaming(t *testing.T) {
+	enabled := true
+	cfg := config.DefaultClientConfig()
+	cfg.Streaming.BufferSize = 4
+	cfg.Streaming.ParseMode = "lenient"
+
+	cfg.Merge(&config.ClientConfig{Streaming: config.StreamingConfig{
+		IncludeUsage: &enabled,
+		ParseMode:    "strict",
+	}})
+
+	if cfg.Streaming.BufferSize != 4 {
+		t.Errorf("got buffer size %d, want 4 kept", cfg.Streaming.BufferSize)
+	}
+	if !cfg.Streaming.IncludeUsageEnabled() || cfg.Streaming.ParseMode != "strict" {
+		t.Errorf("got streaming %+v, want include_usage and strict merged", cfg.Streaming)
+	}
+}
+
+func TestClientConfig_Merge_DisablesStreamingFlags(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	cfg := config.DefaultClientConfig()
+	cfg.Merge(&config.ClientConfig{
+		StreamRetry: &enabled,
+		Streaming:   config.StreamingConfig{IncludeUsage: &enabled},
+	})
+
+	cfg.Merge(&config.ClientConfig{})
+	if !cfg.StreamRetryEnabled() || !cfg.Streaming.IncludeUsageEnabled() {
+		t.Fatal("unset overlay disabled stream_retry or include_usage")
+	}
+
+	cfg.Merge(&config.ClientConfig{
+		StreamRetry: &disabled,
+		Streaming:   config.StreamingConfig{IncludeUsage: &disabled},
+	})
+	if cfg.StreamRetryEnabled() {
+		t.Error("got stream_retry enabled, want overlay false to disable it")
+	}
+	if cfg.Streaming.IncludeUsageEnabled() {
+		t.Error("got include_usage enabled, want overlay false to disable it")
+	}
+}
+
+func TestClientConfig_Merge_DeprecatedStreamFields(t *testing.T) {
+	cfg := config.DefaultClientConfig()
+	cfg.Merge(&config.ClientConfig{
+		StreamIdleTimeout: config.Duration(10 * time.Second),
+		StreamHeartbeat:   config.Duration(2 * time.Second),
+		Streaming: config.StreamingConfig{
+			Heartbeat: config.Duration(5 * time.Second),
+		},
+	})
+
+	if cfg.StreamIdleTimeout != 0 || cfg.StreamHeartbeat != 0 {
+		t.Errorf("got deprecated fields %v/%v, want them moved into streaming", cfg.StreamIdleTimeout, cfg.StreamHeartbeat)
+	}
+	if cfg.Streaming.IdleTimeout.ToDuration() != 10*time.Second {
+		t.Errorf("got idle timeout %v, want 10s from the deprecated field", cfg.Streaming.IdleTimeout.ToDuration())
+	}
+	if cfg.Streaming.Heartbeat.ToDuration() != 5*time.Second {
+		t.Errorf("got heartbeat %v, want the streaming block's 5s", cfg.Streaming.Heartbeat.ToDuration())
+	}
+}
+
+func TestFromEnv_Streaming(t *testing.T) {
+	t.Setenv("TAU_CLIENT_STREAMING_BUFFER_SIZE", "8")
+	t.Setenv("TAU_CLIENT_STREAMING_INCLUDE_USAGE", "true")
+	t.Setenv("TAU_CLIENT_STREAMING_PARSE_MODE", "strict")
+	t.Setenv("TAU_CLIENT_STREAM_IDLE_TIMEOUT", "10s")
+	t.Setenv("TAU_CLIENT_STREAM_HEARTBEAT", "2s")
+	t.Setenv("TAU_CLIENT_STREAMING_HEARTBEAT", "5s")
+
+	cfg, err := config.FromEnv("TAU")
+	if err != nil {
+		t.Fatalf("FromEnv failed: %v", err)
+	}
+
+	if cfg.Client == nil {
+		t.Fatal("expected client config from streaming variables")
+	}
+	streaming := cfg.Client.Streaming
+	if streaming.BufferSize != 8 || !streaming.IncludeUsageEnabled() || streaming.ParseMode != "strict" {
+		t.Errorf("got streaming %+v", streaming)
+	}
+	if streaming.IdleTimeout.ToDuration() != 10*time.Second || streaming.Heartbeat.ToDuration() != 5*time.Second {
+		t.Errorf("got idle timeout %v and heartbeat %v, want 10s and 5s",
+			streaming.IdleTimeout.ToDuration(), streaming.Heartbeat.ToDuration())
+	}
+}
+
+func TestLoad_EnvDisablesStreamingFlags(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(file, []byte(`{
+		"client": {"stream_retry": true, "streaming": {"include_usage": true}},
+		"provider": {"name": "ollama", "base_url": "http://localhost:11434"},
+		"model": {"name": "llama3"}
+	}`), 0600)
+	t.Setenv("TAU_LOAD_STREAMING_CLIENT_STREAM_RETRY", "false")
+	t.Setenv("TAU_LOAD_STREAMING_CLIENT_STREAMING_INCLUDE_USAGE", "false")
+
+	cfg, err := config.Load("TAU_LOAD_STREAMING", file)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Client.StreamRetryEnabled() {
+		t.Error("got stream_retry enabled, want env false to disable it")
+	}
+	if cfg.Client.Streaming.IncludeUsageEnabled() {
+		t.Error("got include_usage enabled, want env false to disable it")
+	}
+}
@@ -0,0 +1,170 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+)
+
+func validConfig() *config.AgentConfig {
+	cfg := config.DefaultAgentConfig()
+	cfg.Model.Name = "llama3.2:3b"
+	return &cfg
+}
+
+// validationFields returns the field of every ValidationError joined in err.
+func validationFields(t *testing.T, err error) []string {
+	t.Helper()
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected joined errors, got %T: %v", err, err)
+	}
+
+	var fields []string
+	for _, e := range joined.Unwrap() {
+		var verr *config.ValidationError
+		if !errors.As(e, &verr) {
+			t.Fatalf("expected *config.ValidationError, got %T", e)
+		}
+		fields = append(fields, verr.Field)
+	}
+	return fields
+}
+
+func TestValidate_Valid(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*config.AgentConfig)
+		fields []string
+	}{
+		{
+			name:   "missing model name",
+			modify: func(c *config.AgentConfig) { c.Model.Name = "" },
+			fields: []string{"model.name"},
+		},
+		{
+			name:   "missing provider",
+			modify: func(c *config.AgentConfig) { c.Provider = nil },
+			fields: []string{"provider"},
+		},
+		{
+			name:   "base url without scheme",
+			modify: func(c *config.AgentConfig) { c.Provider.BaseURL = "localhost:11434" },
+			fields: []string{"provider.base_url"},
+		},
+		{
+			name: "client ranges",
+			modify: func(c *config.AgentConfig) {
+				c.Client.Timeout = 0
+				c.Client.Retry.BackoffMultiplier = 0.5
+				c.Client.OptionValidation = "loose"
+			},
+			fields: []string{"client.timeout", "client.retry.backoff_multiplier", "client.option_validation"},
+		},
+		{
+			name: "streaming ranges",
+			modify: func(c *config.AgentConfig) {
+				c.Client.Streaming.BufferSize = -1
+				c.Client.Streaming.ParseMode = "loose"
+			},
+			fields: []string{"client.streaming.buffer_size", "client.streaming.parse_mode"},
+		},
+		{
+			name: "unknown capability protocol",
+			modify: func(c *config.AgentConfig) {
+				c.Model.Capabilities["completion"] = map[string]any{}
+			},
+			fields: []string{"model.capabilities.completion"},
+		},
+		{
+			name: "route to undefined alias",
+			modify: func(c *config.AgentConfig) {
+				c.Routes = map[string]string{"embeddings": "embed"}
+			},
+			fields: []string{"routes.embeddings"},
+		},
+		{
+			name: "alias without model",
+			modify: func(c *config.AgentConfig) {
+				c.Aliases = map[string]*config.AliasConfig{"fast": {}}
+			},
+			fields: []string{"aliases.fast.model"},
+		},
+		{
+			name: "max output exceeds context window",
+			modify: func(c *config.AgentConfig) {
+				c.Model.ContextWindow = 4096
+				c.Model.MaxOutputTokens = 8192
+			},
+			fields: []string{"model.max_output_tokens"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if err == nil {
+				t.Fatal("expected validation error")
+			}
+
+			fields := validationFields(t, err)
+			if len(fields) != len(tt.fields) {
+				t.Fatalf("got fields %v, want %v", fields, tt.fields)
+			}
+			for i := range fields {
+				if fields[i] != tt.fields[i] {
+					t.Errorf("field %d: got %q, want %q", i, fields[i], tt.fields[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadAgentConfig_Comments(t *testing.T) {
+	data := `{
+  // agent name
+  "name": "commented", /* inline */
+  "provider": {
+    "name": "ollama",
+    "base_url": "http://localhost:11434" // trailing
+  },
+  /*
+   * block comment
+   */
+  "model": {"name": "llama3.2:3b"},
+  "system_prompt": "keep // this and /* this */"
+}`
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadAgentConfig(path)
+	if err != nil {
+		t.Fatalf("failed to load commented config: %v", err)
+	}
+
+	if cfg.Name != "commented" {
+		t.Errorf("got name %q, want %q", cfg.Name, "commented")
+	}
+	if want := "keep // this and /* this */"; cfg.SystemPrompt != want {
+		t.Errorf("got system prompt %q, want %q", cfg.SystemPrompt, want)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+}
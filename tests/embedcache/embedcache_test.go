@@ -0,0 +1,210 @@
+package embedcache_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/embedcache"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// countingAgent counts Embed calls that reach the underlying agent.
+type countingAgent struct {
+	agent.Agent
+	calls atomic.Int64
+}
+
+func (a *countingAgent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
+	a.calls.Add(1)
+	return a.Agent.Embed(ctx, input, opts...)
+}
+
+func newCountingAgent() *countingAgent {
+	return &countingAgent{Agent: mock.NewEmbeddingsAgent("embedder", []float64{0.1, 0.2, 0.3})}
+}
+
+func TestWrap_CachesByInput(t *testing.T) {
+	inner := newCountingAgent()
+	a := embedcache.Wrap(inner, embedcache.NewMemory(0))
+	ctx := context.Background()
+
+	first, err := a.Embed(ctx, "hello")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	second, err := a.Embed(ctx, "hello")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+
+	if got := inner.calls.Load(); got != 1 {
+		t.Errorf("got %d provider calls, want 1", got)
+	}
+	if !slices.Equal(first.Data[0].Embedding, second.Data[0].Embedding) {
+		t.Errorf("cached vector %v differs from %v", second.Data[0].Embedding, first.Data[0].Embedding)
+	}
+	if second.Usage != nil {
+		t.Errorf("expected no usage on cache hit, got %+v", second.Usage)
+	}
+
+	if _, err := a.Embed(ctx, "world"); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if _, err := a.Embed(ctx, "hello", map[string]any{"dimensions": 2}); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if got := inner.calls.Load(); got != 3 {
+		t.Errorf("got %d provider calls, want 3 (new input and new options miss)", got)
+	}
+}
+
+func TestWrap_KeysFollowDefaultOptions(t *testing.T) {
+	inner := newCountingAgent()
+	a := embedcache.Wrap(inner, embedcache.NewMemory(0))
+	ctx := context.Background()
+
+	if _, err := a.Embed(ctx, "hello"); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+
+	a.SetDefaultOptions(protocol.Embeddings, map[string]any{"dimensions": 256})
+	if _, err := a.Embed(ctx, "hello"); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if got := inner.calls.Load(); got != 2 {
+		t.Errorf("got %d provider calls, want 2 (new default options miss)", got)
+	}
+
+	a.SetDefaultOptions(protocol.Embeddings, nil)
+	if _, err := a.Embed(ctx, "hello"); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if got := inner.calls.Load(); got != 2 {
+		t.Errorf("got %d provider calls, want 2 (restored defaults hit)", got)
+	}
+}
+
+func TestWrap_ErrorsAreNotCached(t *testing.T) {
+	store := embedcache.NewMemory(0)
+	failing := mock.NewFailingAgent("embedder", errors.New("provider down"))
+	a := embedcache.Wrap(failing, store)
+
+	if _, err := a.Embed(context.Background(), "hello"); err == nil {
+		t.Fatal("expected error")
+	}
+	if store.Len() != 0 {
+		t.Errorf("expected empty cache, got %d entries", store.Len())
+	}
+}
+
+func TestKey(t *testing.T) {
+	base := embedcache.Key("model", "input", map[string]any{"a": 1, "b": 2})
+
+	if got := embedcache.Key("model", "input", map[string]any{"b": 2, "a": 1}); got != base {
+		t.Error("expected equal options to produce equal keys")
+	}
+	if embedcache.Key("other", "input", nil) == embedcache.Key("model", "input", nil) {
+		t.Error("expected different models to produce different keys")
+	}
+	if embedcache.Key("model", "input", nil) == base {
+		t.Error("expected different options to produce different keys")
+	}
+}
+
+func TestMemory_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := embedcache.NewMemory(2)
+
+	store.Put(ctx, "a", []float64{1})
+	store.Put(ctx, "b", []float64{2})
+	store.Get(ctx, "a")
+	store.Put(ctx, "c", []float64{3})
+
+	if _, ok, _ := store.Get(ctx, "b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := store.Get(ctx, key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+	if store.Len() != 2 {
+		t.Errorf("got %d entries, want 2", store.Len())
+	}
+}
+
+func TestDisk_PersistsAcrossStores(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	key := embedcache.Key("model", "hello", nil)
+	vector := []float64{0.5, -1.25, 3e-8}
+
+	store, err := embedcache.NewDisk(dir)
+	if err != nil {
+		t.Fatalf("NewDisk failed: %v", err)
+	}
+	if err := store.Put(ctx, key, vector); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	reopened, err := embedcache.NewDisk(dir)
+	if err != nil {
+		t.Fatalf("NewDisk failed: %v", err)
+	}
+	got, ok, err := reopened.Get(ctx, key)
+	if err != nil || !ok {
+		t.Fatalf("Get = %v, %v; want cached vector", ok, err)
+	}
+	if !slices.Equal(got, vector) {
+		t.Errorf("got %v, want %v", got, vector)
+	}
+
+	if _, ok, err := reopened.Get(ctx, embedcache.Key("model", "missing", nil)); ok || err != nil {
+		t.Errorf("Get missing = %v, %v; want miss", ok, err)
+	}
+
+	if err := store.Put(ctx, "../escape", vector); err == nil {
+		t.Error("expected error for unsafe key")
+	}
+}
+
+func TestWrap_StoreErrorsFallThrough(t *testing.T) {
+	dir := t.TempDir()
+	store, err := embedcache.NewDisk(dir)
+	if err != nil {
+		t.Fatalf("NewDisk failed: %v", err)
+	}
+
+	inner := newCountingAgent()
+	var reported []error
+	a := embedcache.Wrap(inner, store, embedcache.WithErrorHandler(func(err error) {
+		reported = append(reported, err)
+	}))
+
+	// Corrupt the entry the call will look up
+	key := embedcache.Key(inner.Model().Name, "hello", inner.Model().Options["embeddings"])
+	if err := os.MkdirAll(filepath.Join(dir, key[:2]), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, key[:2], key), []byte("bad"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.Embed(context.Background(), "hello"); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if inner.calls.Load() != 1 {
+		t.Errorf("expected corrupt entry to be treated as a miss")
+	}
+	if len(reported) != 1 {
+		t.Errorf("got %d reported errors, want 1", len(reported))
+	}
+}
@@ -0,0 +1,185 @@
+package eval_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/eval"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+var cases = []eval.Case{
+	{Name: "capital", Prompt: "Capital of France?", Expected: "Paris"},
+	{Name: "math", Prompt: "2+2?", Expected: "4"},
+}
+
+func chatAgent(id, content string, usage *response.TokenUsage) agent.Agent {
+	return mock.NewMockAgent(mock.WithID(id), mock.WithChatResponse(&response.ChatResponse{
+		Choices: []response.ChatChoice{{Message: protocol.NewMessage("assistant", content)}},
+		Usage:   usage,
+	}, nil))
+}
+
+func TestRun(t *testing.T) {
+	agents := []agent.Agent{
+		chatAgent("a", "Paris", &response.TokenUsage{PromptTokens: 5, CompletionTokens: 1, TotalTokens: 6}),
+		mock.NewFailingAgent("b", errors.New("provider unavailable")),
+	}
+
+	report, err := eval.Run(context.Background(), cases, agents, eval.WithScorer(eval.ExactMatch()), eval.WithConcurrency(4))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(report.Results) != 4 {
+		t.Fatalf("got %d results, want 4", len(report.Results))
+	}
+
+	order := []string{"capital/a", "capital/b", "math/a", "math/b"}
+	for i, result := range report.Results {
+		if got := result.Case + "/" + result.Agent; got != order[i] {
+			t.Errorf("result %d: got %s, want %s", i, got, order[i])
+		}
+	}
+
+	first := report.Results[0]
+	if first.Output != "Paris" || first.Score == nil || *first.Score != 1 {
+		t.Errorf("got output %q score %v, want Paris scored 1", first.Output, first.Score)
+	}
+	if first.Model != "mock-model" || first.Usage == nil {
+		t.Errorf("got model %q usage %v, want recorded metadata", first.Model, first.Usage)
+	}
+	if report.Results[2].Score == nil || *report.Results[2].Score != 0 {
+		t.Errorf("got score %v for wrong answer, want 0", report.Results[2].Score)
+	}
+	if report.Results[1].Error == "" || report.Results[1].Score != nil {
+		t.Errorf("got error %q score %v, want recorded failure without score", report.Results[1].Error, report.Results[1].Score)
+	}
+
+	summaries := report.Summaries()
+	if len(summaries) != 2 {
+		t.Fatalf("got %d summaries, want 2", len(summaries))
+	}
+	a, b := summaries[0], summaries[1]
+	if a.Agent != "a" || a.Cases != 2 || a.Errors != 0 || a.TotalTokens != 12 || a.Scored != 2 || a.MeanScore != 0.5 {
+		t.Errorf("got summary %+v for agent a", a)
+	}
+	if b.Agent != "b" || b.Errors != 2 || b.Scored != 0 {
+		t.Errorf("got summary %+v for agent b", b)
+	}
+}
+
+func TestRun_DryRun(t *testing.T) {
+	agents := []agent.Agent{mock.NewFailingAgent("live", errors.New("should not be called"))}
+
+	standIn := func(a agent.Agent, c eval.Case) agent.Agent {
+		return mock.NewSimpleChatAgent(a.ID(), c.Expected)
+	}
+
+	report, err := eval.Run(context.Background(), cases, agents, eval.WithDryRun(standIn), eval.WithScorer(eval.ExactMatch()))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	for _, result := range report.Results {
+		if result.Error != "" || result.Score == nil || *result.Score != 1 {
+			t.Errorf("case %s: got error %q score %v, want expected answer scored 1", result.Case, result.Error, result.Score)
+		}
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := eval.Run(ctx, cases, []agent.Agent{chatAgent("a", "Paris", nil)})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+	for _, result := range report.Results {
+		if result.Agent == "" {
+			t.Errorf("got unfilled result %+v, want only completed results", result)
+		}
+	}
+	if summaries := report.Summaries(); len(summaries) > 1 {
+		t.Errorf("got %d summaries, want at most the one real agent", len(summaries))
+	}
+}
+
+func TestScorers(t *testing.T) {
+	c := eval.Case{Prompt: "Capital of France?", Expected: "Paris"}
+
+	tests := []struct {
+		name   string
+		scorer eval.Scorer
+		output string
+		want   float64
+	}{
+		{name: "exact match", scorer: eval.ExactMatch(), output: " Paris\n", want: 1},
+		{name: "exact mismatch", scorer: eval.ExactMatch(), output: "It is Paris.", want: 0},
+		{name: "contains", scorer: eval.Contains(), output: "It is paris.", want: 1},
+		{name: "contains mismatch", scorer: eval.Contains(), output: "Lyon", want: 0},
+		{name: "judge", scorer: eval.Judge(mock.NewSimpleChatAgent("judge", "Score: 8"), ""), output: "Paris", want: 0.8},
+		{name: "judge clamps", scorer: eval.Judge(mock.NewSimpleChatAgent("judge", "15"), ""), output: "Paris", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.scorer(context.Background(), c, tt.output)
+			if err != nil {
+				t.Fatalf("scorer failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJudge_NoRating(t *testing.T) {
+	scorer := eval.Judge(mock.NewSimpleChatAgent("judge", "Looks good"), "")
+	if _, err := scorer(context.Background(), cases[0], "Paris"); err == nil {
+		t.Error("expected error for reply without rating")
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	report, _ := eval.Run(context.Background(), cases, []agent.Agent{chatAgent("a", "Paris", nil)}, eval.WithScorer(eval.ExactMatch()))
+
+	var jsonOut bytes.Buffer
+	if err := report.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded struct {
+		Results   []eval.Result  `json:"results"`
+		Summaries []eval.Summary `json:"summaries"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded.Results) != 2 || len(decoded.Summaries) != 1 {
+		t.Errorf("got %d results and %d summaries, want 2 and 1", len(decoded.Results), len(decoded.Summaries))
+	}
+
+	var csvOut bytes.Buffer
+	if err := report.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&csvOut).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want header and 2 results", len(rows))
+	}
+	if rows[0][0] != "case" || rows[1][0] != "capital" || rows[1][8] != "1" || rows[2][8] != "0" {
+		t.Errorf("got rows %v", rows)
+	}
+}
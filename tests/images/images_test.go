@@ -0,0 +1,185 @@
+package images_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/images"
+)
+
+func pngBytes(t *testing.T, width, height int, c color.Color) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDataURI_RoundTrip(t *testing.T) {
+	data := pngBytes(t, 2, 2, color.Black)
+	img := &images.Image{Data: data, MIMEType: "image/png"}
+
+	uri := img.DataURI()
+	if !strings.HasPrefix(uri, "data:image/png;base64,") {
+		t.Fatalf("got %q, want PNG data URI", uri[:30])
+	}
+
+	parsed, err := images.ParseDataURI(uri)
+	if err != nil {
+		t.Fatalf("ParseDataURI failed: %v", err)
+	}
+	if parsed.MIMEType != "image/png" || !bytes.Equal(parsed.Data, data) {
+		t.Errorf("got %s with %d bytes, want original image", parsed.MIMEType, len(parsed.Data))
+	}
+
+	for _, invalid := range []string{"image/png;base64,AAAA", "data:image/png;base64", "data:text/plain,hello", "data:image/png;base64,!!"} {
+		if _, err := images.ParseDataURI(invalid); err == nil {
+			t.Errorf("ParseDataURI(%q): expected error", invalid)
+		}
+	}
+}
+
+func TestLoad_Sources(t *testing.T) {
+	data := pngBytes(t, 4, 4, color.White)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "image.png")
+	os.WriteFile(path, data, 0644)
+
+	for _, source := range []string{server.URL + "/image.png", path, (&images.Image{Data: data, MIMEType: "image/png"}).DataURI()} {
+		img, err := images.Load(context.Background(), source)
+		if err != nil {
+			t.Fatalf("Load(%.40s) failed: %v", source, err)
+		}
+		if img.MIMEType != "image/png" || !bytes.Equal(img.Data, data) {
+			t.Errorf("Load(%.40s): got %s with %d bytes", source, img.MIMEType, len(img.Data))
+		}
+	}
+
+	if _, err := images.Load(context.Background(), server.URL+"/missing.png"); err == nil {
+		t.Error("expected error for failed download")
+	}
+}
+
+func TestLoad_Validation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	os.WriteFile(path, []byte("plain text, not an image"), 0644)
+
+	if _, err := images.Load(context.Background(), path); !errors.Is(err, images.ErrNotImage) {
+		t.Errorf("got error %v, want ErrNotImage", err)
+	}
+
+	data := pngBytes(t, 16, 16, color.White)
+	if _, err := images.FromBytes(data, images.WithMaxBytes(10)); !errors.Is(err, images.ErrTooLarge) {
+		t.Errorf("got error %v, want ErrTooLarge", err)
+	}
+}
+
+func TestFromBytes_Downscale(t *testing.T) {
+	data := pngBytes(t, 400, 200, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+
+	img, err := images.FromBytes(data, images.WithMaxDimension(100))
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+
+	decoded, format, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if format != "png" || img.MIMEType != "image/png" {
+		t.Errorf("got format %s (%s), want png", format, img.MIMEType)
+	}
+	if b := decoded.Bounds(); b.Dx() != 100 || b.Dy() != 50 {
+		t.Errorf("got %dx%d, want 100x50", b.Dx(), b.Dy())
+	}
+
+	r, g, b, _ := decoded.At(10, 10).RGBA()
+	if r>>8 != 200 || g>>8 != 100 || b>>8 != 50 {
+		t.Errorf("got color (%d, %d, %d), want (200, 100, 50) preserved", r>>8, g>>8, b>>8)
+	}
+
+	small, _ := images.FromBytes(data, images.WithMaxDimension(1000))
+	if !bytes.Equal(small.Data, data) {
+		t.Error("expected image within limits to be unchanged")
+	}
+}
+
+func TestFromBytes_ConvertToJPEG(t *testing.T) {
+	data := pngBytes(t, 8, 8, color.NRGBA{})
+
+	img, err := images.FromBytes(data, images.WithFormat(images.FormatJPEG))
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+	if img.MIMEType != "image/jpeg" {
+		t.Fatalf("got %s, want image/jpeg", img.MIMEType)
+	}
+
+	decoded, err := jpeg.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		t.Fatalf("failed to decode JPEG: %v", err)
+	}
+	if r, _, _, _ := decoded.At(4, 4).RGBA(); r>>8 < 250 {
+		t.Errorf("got red %d, want transparent pixels flattened to white", r>>8)
+	}
+}
+
+func TestFromBytes_UnsupportedFormat(t *testing.T) {
+	// RIFF/WEBP header is detected as image/webp but has no stdlib decoder.
+	webp := append([]byte("RIFF\x00\x00\x00\x00WEBPVP8 "), make([]byte, 32)...)
+
+	img, err := images.FromBytes(webp)
+	if err != nil || img.MIMEType != "image/webp" {
+		t.Fatalf("got %v, %v; want WebP passed through unchanged", img, err)
+	}
+
+	if _, err := images.FromBytes(webp, images.WithMaxDimension(100)); !errors.Is(err, images.ErrUnsupportedFormat) {
+		t.Errorf("got error %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.png")
+	os.WriteFile(path, pngBytes(t, 2, 2, color.Black), 0644)
+
+	uris, err := images.Prepare(context.Background(), []string{path, path})
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if len(uris) != 2 || !strings.HasPrefix(uris[0], "data:image/png;base64,") {
+		t.Errorf("got %d URIs, want 2 PNG data URIs", len(uris))
+	}
+
+	if _, err := images.Prepare(context.Background(), []string{filepath.Join(t.TempDir(), "missing.png")}); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
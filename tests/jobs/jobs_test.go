@@ -0,0 +1,405 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/jobs"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// blockingClient blocks Execute until release is closed or the context ends,
+// tracking the peak number of concurrent executions.
+type blockingClient struct {
+	client.Client
+	release chan struct{}
+	started chan struct{}
+	active  atomic.Int32
+	peak    atomic.Int32
+}
+
+func newBlockingClient() *blockingClient {
+	return &blockingClient{
+		Client:  mock.NewMockClient(),
+		release: make(chan struct{}),
+		started: make(chan struct{}, 100),
+	}
+}
+
+func (c *blockingClient) Execute(ctx context.Context, req request.Request) (any, error) {
+	n := c.active.Add(1)
+	defer c.active.Add(-1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	c.started <- struct{}{}
+
+	select {
+	case <-c.release:
+		return "done", nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func chatRequest() request.Request {
+	return request.NewChat(
+		mock.NewMockProvider(),
+		mock.NewMockAgent().Model(),
+		[]protocol.Message{protocol.NewMessage("user", "Describe this image")},
+		nil,
+	)
+}
+
+func TestQueue_SubmitAndResult(t *testing.T) {
+	want := &response.ChatResponse{Model: "mock-model"}
+	q := jobs.New(mock.NewMockClient(mock.WithExecuteResponse(want, nil)))
+	defer q.Close()
+
+	id, err := q.Submit(context.Background(), chatRequest())
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	got, err := q.Wait(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("got result %v, want %v", got, want)
+	}
+
+	status, err := q.Status(id)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.State != jobs.StateSucceeded {
+		t.Errorf("got state %s, want %s", status.State, jobs.StateSucceeded)
+	}
+	if status.Started.IsZero() || status.Finished.Before(status.Started) {
+		t.Errorf("got started %v, finished %v, want ordered timestamps", status.Started, status.Finished)
+	}
+}
+
+func TestQueue_Failed(t *testing.T) {
+	cause := errors.New("provider unavailable")
+	q := jobs.New(mock.NewMockClient(mock.WithExecuteResponse(nil, cause)))
+	defer q.Close()
+
+	id, _ := q.Submit(context.Background(), chatRequest())
+	if _, err := q.Wait(context.Background(), id); !errors.Is(err, cause) {
+		t.Fatalf("got error %v, want %v", err, cause)
+	}
+
+	status, _ := q.Status(id)
+	if status.State != jobs.StateFailed {
+		t.Errorf("got state %s, want %s", status.State, jobs.StateFailed)
+	}
+	if !errors.Is(status.Err, cause) {
+		t.Errorf("got status error %v, want %v", status.Err, cause)
+	}
+}
+
+func TestQueue_NotFinished(t *testing.T) {
+	c := newBlockingClient()
+	q := jobs.New(c)
+	defer q.Close()
+
+	id, _ := q.Submit(context.Background(), chatRequest())
+	<-c.started
+
+	if status, _ := q.Status(id); status.State != jobs.StateRunning {
+		t.Errorf("got state %s, want %s", status.State, jobs.StateRunning)
+	}
+	if _, err := q.Result(id); !errors.Is(err, jobs.ErrNotFinished) {
+		t.Errorf("got error %v, want ErrNotFinished", err)
+	}
+
+	close(c.release)
+	if _, err := q.Wait(context.Background(), id); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+}
+
+func TestQueue_UnknownJob(t *testing.T) {
+	q := jobs.New(mock.NewMockClient())
+	defer q.Close()
+
+	if _, err := q.Status("missing"); !errors.Is(err, jobs.ErrUnknownJob) {
+		t.Errorf("Status: got error %v, want ErrUnknownJob", err)
+	}
+	if _, err := q.Result("missing"); !errors.Is(err, jobs.ErrUnknownJob) {
+		t.Errorf("Result: got error %v, want ErrUnknownJob", err)
+	}
+	if err := q.Cancel("missing"); !errors.Is(err, jobs.ErrUnknownJob) {
+		t.Errorf("Cancel: got error %v, want ErrUnknownJob", err)
+	}
+}
+
+func TestQueue_WorkersLimitConcurrency(t *testing.T) {
+	c := newBlockingClient()
+	q := jobs.New(c, jobs.WithWorkers(2))
+
+	ids := make([]string, 5)
+	for i := range ids {
+		id, err := q.Submit(context.Background(), chatRequest())
+		if err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+		ids[i] = id
+	}
+
+	<-c.started
+	<-c.started
+	close(c.release)
+	q.Close()
+
+	if peak := c.peak.Load(); peak != 2 {
+		t.Errorf("got peak concurrency %d, want 2", peak)
+	}
+	for _, id := range ids {
+		if status, _ := q.Status(id); status.State != jobs.StateSucceeded {
+			t.Errorf("job %s: got state %s, want %s", id, status.State, jobs.StateSucceeded)
+		}
+	}
+}
+
+func TestQueue_QueueFull(t *testing.T) {
+	c := newBlockingClient()
+	q := jobs.New(c, jobs.WithWorkers(1), jobs.WithQueueSize(1))
+	defer q.Close()
+	defer close(c.release)
+
+	q.Submit(context.Background(), chatRequest())
+	<-c.started
+	if _, err := q.Submit(context.Background(), chatRequest()); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	if _, err := q.Submit(context.Background(), chatRequest()); !errors.Is(err, jobs.ErrQueueFull) {
+		t.Errorf("got error %v, want ErrQueueFull", err)
+	}
+}
+
+func TestQueue_Cancel(t *testing.T) {
+	c := newBlockingClient()
+	q := jobs.New(c, jobs.WithWorkers(1))
+	defer q.Close()
+
+	running, _ := q.Submit(context.Background(), chatRequest())
+	pending, _ := q.Submit(context.Background(), chatRequest())
+	<-c.started
+
+	q.Cancel(pending)
+	q.Cancel(running)
+
+	for _, id := range []string{running, pending} {
+		if _, err := q.Wait(context.Background(), id); !errors.Is(err, jobs.ErrCancelled) {
+			t.Errorf("got error %v, want ErrCancelled", err)
+		}
+		if status, _ := q.Status(id); status.State != jobs.StateCancelled {
+			t.Errorf("got state %s, want %s", status.State, jobs.StateCancelled)
+		}
+	}
+}
+
+func TestQueue_SubmitContextDetached(t *testing.T) {
+	q := jobs.New(mock.NewMockClient(mock.WithExecuteResponse("done", nil)))
+	defer q.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	id, _ := q.Submit(ctx, chatRequest())
+	cancel()
+
+	if _, err := q.Wait(context.Background(), id); err != nil {
+		t.Errorf("got error %v, want job to outlive the submitting context", err)
+	}
+}
+
+func TestQueue_Timeout(t *testing.T) {
+	q := jobs.New(newBlockingClient(), jobs.WithTimeout(10*time.Millisecond))
+	defer q.Close()
+
+	id, _ := q.Submit(context.Background(), chatRequest())
+	if _, err := q.Wait(context.Background(), id); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+
+	if status, _ := q.Status(id); status.State != jobs.StateFailed {
+		t.Errorf("got state %s, want %s", status.State, jobs.StateFailed)
+	}
+}
+
+func TestQueue_Callbacks(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) jobs.Callback {
+		return func(status jobs.Status, result any) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, name+":"+string(status.State)+":"+result.(string))
+		}
+	}
+
+	q := jobs.New(
+		mock.NewMockClient(mock.WithExecuteResponse("ok", nil)),
+		jobs.WithCallback(record("queue")),
+	)
+
+	q.Submit(context.Background(), chatRequest(), record("job"))
+	q.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"queue:succeeded:ok", "job:succeeded:ok"}
+	if len(calls) != len(want) {
+		t.Fatalf("got calls %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d: got %q, want %q", i, calls[i], want[i])
+		}
+	}
+}
+
+func TestQueue_Remove(t *testing.T) {
+	q := jobs.New(mock.NewMockClient(mock.WithExecuteResponse("ok", nil)))
+	defer q.Close()
+
+	id, _ := q.Submit(context.Background(), chatRequest())
+	q.Wait(context.Background(), id)
+
+	if err := q.Remove(id); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := q.Status(id); !errors.Is(err, jobs.ErrUnknownJob) {
+		t.Errorf("got error %v, want ErrUnknownJob", err)
+	}
+}
+
+func TestQueue_Retention(t *testing.T) {
+	q := jobs.New(mock.NewMockClient(mock.WithExecuteResponse("ok", nil)), jobs.WithRetention(time.Millisecond))
+	defer q.Close()
+
+	id, _ := q.Submit(context.Background(), chatRequest())
+	q.Wait(context.Background(), id)
+	time.Sleep(5 * time.Millisecond)
+
+	q.Submit(context.Background(), chatRequest())
+	if _, err := q.Status(id); !errors.Is(err, jobs.ErrUnknownJob) {
+		t.Errorf("got error %v, want expired job to be removed", err)
+	}
+}
+
+func TestQueue_SubmitAfterClose(t *testing.T) {
+	q := jobs.New(mock.NewMockClient())
+	q.Close()
+
+	if _, err := q.Submit(context.Background(), chatRequest()); !errors.Is(err, jobs.ErrClosed) {
+		t.Errorf("got error %v, want ErrClosed", err)
+	}
+}
+
+func TestQueue_CancelPendingReportsCancelled(t *testing.T) {
+	c := newBlockingClient()
+	q := jobs.New(c, jobs.WithWorkers(1))
+	defer q.Close()
+	defer close(c.release)
+
+	q.Submit(context.Background(), chatRequest())
+	<-c.started
+	pending, _ := q.Submit(context.Background(), chatRequest())
+
+	q.Cancel(pending)
+
+	if status, _ := q.Status(pending); status.State != jobs.StateCancelled {
+		t.Errorf("got state %s, want %s before a worker dequeues the job", status.State, jobs.StateCancelled)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := q.Wait(ctx, pending); !errors.Is(err, jobs.ErrCancelled) {
+		t.Errorf("got error %v, want ErrCancelled without waiting for a worker", err)
+	}
+}
+
+func TestQueue_RemoveUnfinished(t *testing.T) {
+	c := newBlockingClient()
+	q := jobs.New(c, jobs.WithWorkers(1))
+
+	var cancelled atomic.Int32
+	running, _ := q.Submit(context.Background(), chatRequest(), func(status jobs.Status, result any) {
+		if status.State == jobs.StateCancelled {
+			cancelled.Add(1)
+		}
+	})
+	pending, _ := q.Submit(context.Background(), chatRequest())
+	<-c.started
+
+	for _, id := range []string{running, pending} {
+		if err := q.Remove(id); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if _, err := q.Status(id); !errors.Is(err, jobs.ErrUnknownJob) {
+			t.Errorf("got error %v, want ErrUnknownJob after removing an unfinished job", err)
+		}
+	}
+
+	q.Close()
+
+	if n := cancelled.Load(); n != 1 {
+		t.Errorf("got %d cancelled callbacks, want 1", n)
+	}
+}
+
+func TestQueue_CancelPendingFreesCapacity(t *testing.T) {
+	c := newBlockingClient()
+	q := jobs.New(c, jobs.WithWorkers(1), jobs.WithQueueSize(1))
+	defer q.Close()
+	defer close(c.release)
+
+	q.Submit(context.Background(), chatRequest())
+	<-c.started
+
+	for range 3 {
+		pending, err := q.Submit(context.Background(), chatRequest())
+		if err != nil {
+			t.Fatalf("got error %v, want capacity freed by the cancelled job", err)
+		}
+		q.Cancel(pending)
+	}
+}
+
+func TestQueue_WaitDuringRemove(t *testing.T) {
+	c := newBlockingClient()
+	q := jobs.New(c, jobs.WithWorkers(1))
+	defer q.Close()
+
+	id, _ := q.Submit(context.Background(), chatRequest())
+	<-c.started
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := q.Wait(context.Background(), id)
+		errs <- err
+	}()
+
+	// Let Wait look up the job before it is removed
+	time.Sleep(20 * time.Millisecond)
+	q.Remove(id)
+
+	if err := <-errs; !errors.Is(err, jobs.ErrCancelled) {
+		t.Errorf("got error %v, want ErrCancelled", err)
+	}
+}
@@ -2,6 +2,7 @@ package mock_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/mock"
@@ -27,15 +28,7 @@ func TestMockAgent_Chat(t *testing.T) {
 	expectedResponse := &response.ChatResponse{
 		Model: "test-model",
 	}
-	expectedResponse.Choices = append(expectedResponse.Choices, struct {
-		Index   int              `json:"index"`
-		Message protocol.Message `json:"message"`
-		Delta   *struct {
-			Role    string `json:"role,omitempty"`
-			Content string `json:"content,omitempty"`
-		} `json:"delta,omitempty"`
-		FinishReason string `json:"finish_reason,omitempty"`
-	}{
+	expectedResponse.Choices = append(expectedResponse.Choices, response.ChatChoice{
 		Index:   0,
 		Message: protocol.NewMessage("assistant", "Hello"),
 	})
@@ -60,15 +53,7 @@ func TestMockAgent_Vision(t *testing.T) {
 	expectedResponse := &response.ChatResponse{
 		Model: "test-model",
 	}
-	expectedResponse.Choices = append(expectedResponse.Choices, struct {
-		Index   int              `json:"index"`
-		Message protocol.Message `json:"message"`
-		Delta   *struct {
-			Role    string `json:"role,omitempty"`
-			Content string `json:"content,omitempty"`
-		} `json:"delta,omitempty"`
-		FinishReason string `json:"finish_reason,omitempty"`
-	}{
+	expectedResponse.Choices = append(expectedResponse.Choices, response.ChatChoice{
 		Index:   0,
 		Message: protocol.NewMessage("assistant", "I see an image"),
 	})
@@ -93,21 +78,9 @@ func TestMockAgent_Tools(t *testing.T) {
 	expectedResponse := &response.ToolsResponse{
 		Model: "test-model",
 	}
-	expectedResponse.Choices = append(expectedResponse.Choices, struct {
-		Index   int `json:"index"`
-		Message struct {
-			Role      string              `json:"role"`
-			Content   string              `json:"content"`
-			ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-		} `json:"message"`
-		FinishReason string `json:"finish_reason,omitempty"`
-	}{
+	expectedResponse.Choices = append(expectedResponse.Choices, response.ToolsChoice{
 		Index: 0,
-		Message: struct {
-			Role      string              `json:"role"`
-			Content   string              `json:"content"`
-			ToolCalls []response.ToolCall `json:"tool_calls,omitempty"`
-		}{
+		Message: response.ToolsMessage{
 			Role:    "assistant",
 			Content: "",
 			ToolCalls: []response.ToolCall{
@@ -205,3 +178,72 @@ func TestNewStreamingChatAgent(t *testing.T) {
 		t.Errorf("got content %q, want %q", content, "Hello, world!")
 	}
 }
+
+func TestMockAgent_ChatResponses(t *testing.T) {
+	first := &response.ChatResponse{Model: "first"}
+	second := &response.ChatResponse{Model: "second"}
+	failure := errors.New("rate limited")
+
+	agent := mock.NewMockAgent(mock.WithChatResponses(first, second, failure))
+
+	tests := []struct {
+		want    *response.ChatResponse
+		wantErr error
+	}{
+		{want: first},
+		{want: second},
+		{wantErr: failure},
+		{wantErr: failure},
+	}
+
+	for i, tt := range tests {
+		resp, err := agent.Chat(context.Background(), "test")
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("call %d: got error %v, want %v", i+1, err, tt.wantErr)
+		}
+		if resp != tt.want {
+			t.Errorf("call %d: got response %v, want %v", i+1, resp, tt.want)
+		}
+	}
+}
+
+func TestMockAgent_ToolsResponses(t *testing.T) {
+	calling := &response.ToolsResponse{Model: "calling"}
+	done := &response.ToolsResponse{Model: "done"}
+
+	agent := mock.NewMockAgent(mock.WithToolsResponses(calling, done))
+
+	for _, want := range []*response.ToolsResponse{calling, done, done} {
+		resp, err := agent.Tools(context.Background(), "test", nil)
+		if err != nil {
+			t.Fatalf("Tools failed: %v", err)
+		}
+		if resp != want {
+			t.Errorf("got response %q, want %q", resp.Model, want.Model)
+		}
+	}
+}
+
+func TestWithChatResponses_InvalidType(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for invalid result type")
+		}
+	}()
+
+	mock.NewMockAgent(mock.WithChatResponses("not a response"))
+}
+
+func TestMockAgent_Supports(t *testing.T) {
+	agent := mock.NewMockAgent(mock.WithUnsupported(protocol.Vision))
+
+	if !agent.Supports(protocol.Chat) {
+		t.Error("chat should be supported by default")
+	}
+	if agent.Supports(protocol.Vision) {
+		t.Error("vision should be unsupported")
+	}
+	if agent.Supports(protocol.Protocol("audio")) {
+		t.Error("invalid protocols should be unsupported")
+	}
+}
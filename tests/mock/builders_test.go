@@ -0,0 +1,82 @@
+package mock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestChatResponseWith(t *testing.T) {
+	usage := &response.TokenUsage{PromptTokens: 2, CompletionTokens: 1, TotalTokens: 3}
+	resp := mock.ChatResponseWith("hello", usage)
+
+	if resp.Content() != "hello" {
+		t.Errorf("got content %q, want hello", resp.Content())
+	}
+	if resp.FinishReason() != response.FinishReasonStop {
+		t.Errorf("got finish reason %q, want stop", resp.FinishReason())
+	}
+	if resp.Usage != usage {
+		t.Error("expected usage to be set")
+	}
+}
+
+func TestToolsResponseWith(t *testing.T) {
+	resp := mock.ToolsResponseWith(
+		mock.ToolCall("get_weather", `{"city":"Paris"}`),
+		mock.ToolCall("get_time", `{}`),
+	)
+
+	calls := resp.ToolCalls()
+	if len(calls) != 2 {
+		t.Fatalf("got %d tool calls, want 2", len(calls))
+	}
+	if calls[0].ID != "call_get_weather" || calls[0].Type != "function" || calls[0].Function.Arguments != `{"city":"Paris"}` {
+		t.Errorf("got tool call %+v", calls[0])
+	}
+	if resp.FinishReason() != response.FinishReasonToolCalls {
+		t.Errorf("got finish reason %q, want tool_calls", resp.FinishReason())
+	}
+
+	if empty := mock.ToolsResponseWith(); empty.FinishReason() != response.FinishReasonStop {
+		t.Errorf("got finish reason %q without calls, want stop", empty.FinishReason())
+	}
+}
+
+func TestEmbeddingsResponseWith(t *testing.T) {
+	resp := mock.EmbeddingsResponseWith([]float64{0.1}, []float64{0.2})
+
+	if len(resp.Data) != 2 || resp.Data[1].Index != 1 || resp.Data[1].Embedding[0] != 0.2 {
+		t.Errorf("got data %+v, want two indexed vectors", resp.Data)
+	}
+}
+
+func TestChunkBuilders(t *testing.T) {
+	call := mock.ToolCall("lookup", `{"q":"go"}`)
+	chunks := append(mock.ContentChunks("Hel", "lo"),
+		mock.ToolCallChunk(0, call),
+		mock.FinishChunk(response.FinishReasonToolCalls, &response.TokenUsage{TotalTokens: 7}),
+	)
+
+	agent := mock.NewMockAgent(mock.WithStreamChunks(chunks, nil))
+	stream, err := agent.ChatStream(context.Background(), "Hi")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	resp, err := response.Collect(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if resp.Content() != "Hello" {
+		t.Errorf("got content %q, want Hello", resp.Content())
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens != 7 {
+		t.Errorf("got usage %+v, want total 7", resp.Usage)
+	}
+	if resp.FinishReason() != response.FinishReasonToolCalls {
+		t.Errorf("got finish reason %q, want tool_calls", resp.FinishReason())
+	}
+}
@@ -43,14 +43,7 @@ func TestMockClient_ExecuteStream(t *testing.T) {
 	chunk := &response.StreamingChunk{
 		Model: "test-model",
 	}
-	chunk.Choices = make([]struct {
-		Index int `json:"index"`
-		Delta struct {
-			Role    string `json:"role,omitempty"`
-			Content string `json:"content,omitempty"`
-		} `json:"delta"`
-		FinishReason *string `json:"finish_reason"`
-	}, 1)
+	chunk.Choices = make([]response.StreamingChoice, 1)
 	chunk.Choices[0].Delta.Content = "Hello"
 
 	chunks := []*response.StreamingChunk{chunk}
@@ -0,0 +1,93 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+)
+
+func TestClock_Advance(t *testing.T) {
+	clock := mock.NewClock(time.Time{})
+	start := clock.Now()
+
+	late := clock.After(2 * time.Second)
+	early := clock.After(time.Second)
+
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case <-early:
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+
+	clock.Advance(2 * time.Second)
+	if got := <-early; !got.Equal(start.Add(time.Second)) {
+		t.Errorf("got early fire time %v, want %v", got, start.Add(time.Second))
+	}
+	if got := <-late; !got.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("got late fire time %v, want %v", got, start.Add(2*time.Second))
+	}
+	if clock.Since(start) != 2500*time.Millisecond || clock.Waiters() != 0 {
+		t.Errorf("got elapsed %s and %d waiters", clock.Since(start), clock.Waiters())
+	}
+}
+
+func TestClock_BlockUntilAndSleep(t *testing.T) {
+	clock := mock.NewClock(time.Time{})
+	ctx := context.Background()
+
+	done := make(chan error)
+	go func() { done <- clock.Sleep(ctx, time.Minute) }()
+
+	if err := clock.BlockUntil(ctx, 1); err != nil {
+		t.Fatalf("BlockUntil failed: %v", err)
+	}
+	clock.Advance(time.Minute)
+	if err := <-done; err != nil {
+		t.Errorf("Sleep returned %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := clock.Sleep(cancelled, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+	if clock.Waiters() != 0 {
+		t.Errorf("got %d waiters, want the cancelled sleep removed", clock.Waiters())
+	}
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := clock.BlockUntil(short, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestStreamFault_Clock(t *testing.T) {
+	clock := mock.NewClock(time.Time{})
+	agent := mock.NewMockAgent(
+		mock.WithStreamChunks(contentChunks("a", "b"), nil),
+		mock.WithStreamFault(mock.StreamFault{After: -1, Delay: time.Hour, Clock: clock}),
+	)
+
+	chunks, err := agent.ChatStream(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	for _, want := range []string{"a", "b"} {
+		if err := clock.BlockUntil(context.Background(), 1); err != nil {
+			t.Fatalf("BlockUntil failed: %v", err)
+		}
+		clock.Advance(time.Hour)
+		if chunk := <-chunks; chunk.Content() != want {
+			t.Errorf("got %q, want %q", chunk.Content(), want)
+		}
+	}
+	if _, ok := <-chunks; ok {
+		t.Error("expected the stream to close")
+	}
+}
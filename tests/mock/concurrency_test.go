@@ -0,0 +1,133 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// recordingTB captures errors reported by helpers under test.
+type recordingTB struct {
+	testing.TB
+	mu     sync.Mutex
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRunConcurrent(t *testing.T) {
+	agent := mock.NewMockAgent(
+		mock.WithChatResponse(mock.ChatResponseWith("ok", nil), nil),
+		mock.WithFault(mock.FailFirst(3, mock.HTTPError(503))),
+	)
+
+	var active, peak atomic.Int32
+	errs := mock.RunConcurrent(context.Background(), 50, 8, func(ctx context.Context, i int) error {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		_, err := agent.Chat(ctx, fmt.Sprintf("call %d", i))
+		return err
+	})
+
+	if len(errs) != 50 {
+		t.Fatalf("got %d errors, want one per call", len(errs))
+	}
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed != 3 {
+		t.Errorf("got %d failed calls, want 3", failed)
+	}
+	if peak.Load() > 8 {
+		t.Errorf("got %d concurrent calls, want at most 8 workers", peak.Load())
+	}
+}
+
+func TestRunConcurrent_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls atomic.Int32
+	errs := mock.RunConcurrent(ctx, 5, 0, func(ctx context.Context, i int) error {
+		calls.Add(1)
+		return nil
+	})
+
+	if calls.Load() != 0 {
+		t.Errorf("got %d calls, want none after cancellation", calls.Load())
+	}
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("call %d: got %v, want context.Canceled", i, err)
+		}
+	}
+}
+
+func TestConsumeStream(t *testing.T) {
+	agent := mock.NewStreamingChatAgent("a", []string{"Hel", "lo"})
+
+	errs := mock.RunConcurrent(context.Background(), 20, 0, func(ctx context.Context, i int) error {
+		chunks, err := agent.ChatStream(ctx, "Hi")
+		if err != nil {
+			return err
+		}
+		result := mock.ConsumeStream(t, chunks, 0)
+		result.AssertContent(t, "Hello")
+		result.AssertErr(t, nil)
+		if result.Usage == nil {
+			return errors.New("expected estimated usage on the final chunk")
+		}
+		return nil
+	})
+	if err := errors.Join(errs...); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConsumeStream_Violations(t *testing.T) {
+	ch := make(chan *response.StreamingChunk, 3)
+	ch <- &response.StreamingChunk{Error: io.ErrUnexpectedEOF}
+	ch <- nil
+	ch <- &response.StreamingChunk{}
+	close(ch)
+
+	rec := &recordingTB{TB: t}
+	result := mock.ConsumeStream(rec, ch, 0)
+	result.AssertErr(rec, io.ErrUnexpectedEOF)
+
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[0], "nil chunk") || !strings.Contains(rec.errors[1], "after error chunk") {
+		t.Errorf("got reported errors %q", rec.errors)
+	}
+
+	open := make(chan *response.StreamingChunk)
+	rec = &recordingTB{TB: t}
+	mock.ConsumeStream(rec, open, 10*time.Millisecond)
+	mock.ConsumeStream(rec, ch, 0).AssertContent(rec, "unexpected")
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[0], "not closed") {
+		t.Errorf("got reported errors %q", rec.errors)
+	}
+}
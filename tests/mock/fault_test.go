@@ -0,0 +1,102 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestWithFault_FailFirst(t *testing.T) {
+	expected := &response.ChatResponse{Model: "test-model"}
+	agent := mock.NewMockAgent(
+		mock.WithChatResponse(expected, nil),
+		mock.WithFault(mock.FailFirst(2, mock.HTTPError(503))),
+	)
+
+	for call := 1; call <= 2; call++ {
+		_, err := agent.Chat(context.Background(), "test")
+
+		var statusErr *client.HTTPStatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != 503 {
+			t.Fatalf("call %d: got error %v, want HTTP 503", call, err)
+		}
+	}
+
+	resp, err := agent.Chat(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("call 3: Chat failed: %v", err)
+	}
+	if resp != expected {
+		t.Error("call 3: returned different response than configured")
+	}
+}
+
+func TestWithFault_CountsAcrossMethods(t *testing.T) {
+	failure := errors.New("unavailable")
+	agent := mock.NewMockAgent(
+		mock.WithFault(mock.FailSequence(nil, failure)),
+		mock.WithStreamChunks(nil, nil),
+	)
+
+	if _, err := agent.ChatStream(context.Background(), "test"); err != nil {
+		t.Fatalf("call 1: ChatStream failed: %v", err)
+	}
+
+	if _, err := agent.Embed(context.Background(), "test"); !errors.Is(err, failure) {
+		t.Errorf("call 2: got error %v, want %v", err, failure)
+	}
+}
+
+func TestFailRandomly(t *testing.T) {
+	failure := errors.New("flaky")
+
+	count := func(seed uint64) int {
+		fault := mock.FailRandomly(0.5, failure, seed)
+		failures := 0
+		for call := 1; call <= 1000; call++ {
+			if fault(call) != nil {
+				failures++
+			}
+		}
+		return failures
+	}
+
+	failures := count(42)
+	if failures < 400 || failures > 600 {
+		t.Errorf("got %d failures in 1000 calls, want about 500", failures)
+	}
+
+	if again := count(42); again != failures {
+		t.Errorf("got %d failures with the same seed, want %d", again, failures)
+	}
+
+	never := mock.FailRandomly(0, failure, 1)
+	for call := 1; call <= 100; call++ {
+		if never(call) != nil {
+			t.Fatal("probability 0 injected a failure")
+		}
+	}
+}
+
+func TestWithExecuteFault(t *testing.T) {
+	c := mock.NewMockClient(
+		mock.WithExecuteResponse("ok", nil),
+		mock.WithExecuteFault(mock.FailFirst(1, mock.HTTPError(429))),
+	)
+
+	if _, err := c.Execute(context.Background(), nil); err == nil {
+		t.Fatal("call 1: expected injected error, got nil")
+	}
+
+	result, err := c.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("call 2: Execute failed: %v", err)
+	}
+	if result != "ok" {
+		t.Errorf("call 2: got %v, want ok", result)
+	}
+}
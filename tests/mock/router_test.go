@@ -0,0 +1,103 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func chatResponse(content string) *response.ChatResponse {
+	resp := &response.ChatResponse{Model: "mock-model"}
+	resp.Choices = append(resp.Choices, response.ChatChoice{
+		Message: protocol.NewMessage("assistant", content),
+	})
+	return resp
+}
+
+func TestRouterAgent_Chat(t *testing.T) {
+	failure := errors.New("overloaded")
+
+	agent := mock.NewRouterAgent(mock.WithID("router")).
+		On(regexp.MustCompile(`(?i)^summarize`), chatResponse("summary")).
+		OnContains("weather", &response.ToolsResponse{Model: "tools"}).
+		OnContains("weather", chatResponse("sunny")).
+		OnContains("fail", failure).
+		Default(chatResponse("fallback"))
+
+	tests := []struct {
+		prompt  string
+		want    string
+		wantErr error
+	}{
+		{prompt: "Summarize this report", want: "summary"},
+		{prompt: "What's the weather?", want: "sunny"},
+		{prompt: "please fail", wantErr: failure},
+		{prompt: "Hello", want: "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prompt, func(t *testing.T) {
+			resp, err := agent.Chat(context.Background(), tt.prompt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && resp.Content() != tt.want {
+				t.Errorf("got content %q, want %q", resp.Content(), tt.want)
+			}
+		})
+	}
+
+	if agent.ID() != "router" {
+		t.Errorf("got ID %q, want router", agent.ID())
+	}
+}
+
+func TestRouterAgent_Tools(t *testing.T) {
+	weather := &response.ToolsResponse{Model: "weather"}
+
+	agent := mock.NewRouterAgent().
+		OnContains("weather", chatResponse("sunny")).
+		OnContains("weather", weather)
+
+	resp, err := agent.Tools(context.Background(), "What's the weather?", nil)
+	if err != nil {
+		t.Fatalf("Tools failed: %v", err)
+	}
+	if resp != weather {
+		t.Errorf("got response %q, want weather", resp.Model)
+	}
+
+	if _, err := agent.Tools(context.Background(), "Hello", nil); !errors.Is(err, mock.ErrNoRoute) {
+		t.Errorf("got error %v, want ErrNoRoute", err)
+	}
+}
+
+func TestRouterAgent_ChatStream(t *testing.T) {
+	agent := mock.NewRouterAgent().Default(chatResponse("streamed"))
+
+	chunks, err := agent.ChatStream(context.Background(), "Hello")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	var content string
+	var finish response.FinishReason
+	for chunk := range chunks {
+		content += chunk.Content()
+		if reason := chunk.FinishReason(); reason != "" {
+			finish = reason
+		}
+	}
+
+	if content != "streamed" {
+		t.Errorf("got content %q, want streamed", content)
+	}
+	if finish != response.FinishReasonStop {
+		t.Errorf("got finish reason %q, want stop", finish)
+	}
+}
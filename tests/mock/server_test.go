@@ -0,0 +1,166 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/client"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func newServerAgent(t *testing.T, srv *mock.Server) agent.Agent {
+	t.Helper()
+
+	a, err := agent.New(&config.AgentConfig{
+		Name: "server-agent",
+		Client: &config.ClientConfig{
+			Timeout:            config.Duration(5 * time.Second),
+			ConnectionTimeout:  config.Duration(time.Second),
+			ConnectionPoolSize: 2,
+		},
+		Provider: &config.ProviderConfig{Name: "ollama", BaseURL: srv.URL},
+		Model:    &config.ModelConfig{Name: "test-model"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return a
+}
+
+func TestServer_Chat(t *testing.T) {
+	srv := mock.NewServer(mock.WithServerChatResponses(chatResponse("first"), chatResponse("second")))
+	defer srv.Close()
+
+	a := newServerAgent(t, srv)
+
+	for _, want := range []string{"first", "second"} {
+		resp, err := a.Chat(context.Background(), "Hello", map[string]any{"temperature": 0.2})
+		if err != nil {
+			t.Fatalf("Chat failed: %v", err)
+		}
+		if resp.Content() != want {
+			t.Errorf("got content %q, want %q", resp.Content(), want)
+		}
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+
+	last := srv.LastRequest()
+	if last.Path != "/v1/chat/completions" {
+		t.Errorf("got path %q, want /v1/chat/completions", last.Path)
+	}
+	if last.Body["model"] != "test-model" || last.Body["temperature"] != 0.2 {
+		t.Errorf("got body %v, want model and temperature", last.Body)
+	}
+}
+
+func TestServer_ChatStream(t *testing.T) {
+	srv := mock.NewServer()
+	defer srv.Close()
+
+	a := newServerAgent(t, srv)
+
+	chunks, err := a.ChatStream(context.Background(), "Hello")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	var content string
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			t.Fatalf("stream error: %v", chunk.Error)
+		}
+		content += chunk.Content()
+	}
+
+	if content != "mock response" {
+		t.Errorf("got content %q, want mock response", content)
+	}
+
+	if srv.LastRequest().Body["stream"] != true {
+		t.Error("stream flag not captured")
+	}
+}
+
+func TestServer_Tools(t *testing.T) {
+	tools := &response.ToolsResponse{Model: "test-model"}
+	tools.Choices = append(tools.Choices, response.ToolsChoice{
+		Message: response.ToolsMessage{
+			Role: "assistant",
+			ToolCalls: []response.ToolCall{{
+				ID:       "call_1",
+				Type:     "function",
+				Function: response.ToolCallFunction{Name: "get_weather", Arguments: `{"city":"Boston"}`},
+			}},
+		},
+		FinishReason: response.FinishReasonToolCalls,
+	})
+
+	srv := mock.NewServer(mock.WithServerToolsResponses(tools))
+	defer srv.Close()
+
+	a := newServerAgent(t, srv)
+
+	resp, err := a.Tools(context.Background(), "Weather in Boston?", []agent.Tool{{Name: "get_weather"}})
+	if err != nil {
+		t.Fatalf("Tools failed: %v", err)
+	}
+
+	if calls := resp.ToolCalls(); len(calls) != 1 || calls[0].Function.Name != "get_weather" {
+		t.Errorf("got tool calls %v, want get_weather", calls)
+	}
+
+	if _, ok := srv.LastRequest().Body["tools"]; !ok {
+		t.Error("tools not captured in request body")
+	}
+}
+
+func TestServer_Embeddings(t *testing.T) {
+	srv := mock.NewServer()
+	defer srv.Close()
+
+	a := newServerAgent(t, srv)
+
+	resp, err := a.Embed(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+
+	if len(resp.Data) != 1 || len(resp.Data[0].Embedding) == 0 {
+		t.Errorf("got data %v, want one embedding", resp.Data)
+	}
+
+	if srv.LastRequest().Path != "/v1/embeddings" {
+		t.Errorf("got path %q, want /v1/embeddings", srv.LastRequest().Path)
+	}
+}
+
+func TestServer_ErrorStatus(t *testing.T) {
+	srv := mock.NewServer(mock.WithServerChatResponses(mock.HTTPError(429), chatResponse("recovered")))
+	defer srv.Close()
+
+	a := newServerAgent(t, srv)
+
+	_, err := a.Chat(context.Background(), "Hello")
+
+	var statusErr *client.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 429 {
+		t.Fatalf("got error %v, want HTTP 429", err)
+	}
+
+	resp, err := a.Chat(context.Background(), "Hello")
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if resp.Content() != "recovered" {
+		t.Errorf("got content %q, want recovered", resp.Content())
+	}
+}
@@ -0,0 +1,125 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func contentChunks(contents ...string) []response.StreamingChunk {
+	chunks := make([]response.StreamingChunk, len(contents))
+	for i, content := range contents {
+		chunks[i].Choices = []response.StreamingChoice{{Delta: response.StreamingDelta{Content: content}}}
+	}
+	return chunks
+}
+
+func TestWithStreamFault_ErrorChunk(t *testing.T) {
+	agent := mock.NewMockAgent(
+		mock.WithStreamChunks(contentChunks("a", "b", "c"), nil),
+		mock.WithStreamFault(mock.StreamFault{After: 2, Err: io.ErrUnexpectedEOF}),
+	)
+
+	chunks, err := agent.ChatStream(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	var content string
+	var streamErr error
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			streamErr = chunk.Error
+			continue
+		}
+		content += chunk.Content()
+	}
+
+	if content != "ab" {
+		t.Errorf("got content %q, want ab", content)
+	}
+	if !errors.Is(streamErr, io.ErrUnexpectedEOF) {
+		t.Errorf("got stream error %v, want %v", streamErr, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestWithStreamFault_AbruptClose(t *testing.T) {
+	agent := mock.NewMockAgent(
+		mock.WithStreamChunks(contentChunks("a", "b", "c"), nil),
+		mock.WithStreamFault(mock.StreamFault{After: 1}),
+	)
+
+	chunks, err := agent.VisionStream(context.Background(), "test", nil)
+	if err != nil {
+		t.Fatalf("VisionStream failed: %v", err)
+	}
+
+	count := 0
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			t.Fatalf("unexpected error chunk: %v", chunk.Error)
+		}
+		count++
+	}
+
+	if count != 1 {
+		t.Errorf("got %d chunks, want 1", count)
+	}
+}
+
+func TestWithStreamFault_Cancellation(t *testing.T) {
+	agent := mock.NewMockAgent(
+		mock.WithStreamChunks(contentChunks("a", "b", "c"), nil),
+		mock.WithStreamFault(mock.StreamFault{After: -1, Delay: 10 * time.Millisecond}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks, err := agent.ChatStream(ctx, "test")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	<-chunks
+	cancel()
+
+	remaining := 0
+	for range chunks {
+		remaining++
+	}
+
+	if remaining > 1 {
+		t.Errorf("got %d chunks after cancellation, want at most 1", remaining)
+	}
+}
+
+func TestWithExecuteStreamFault(t *testing.T) {
+	failure := errors.New("connection reset")
+	first := &response.StreamingChunk{Model: "test-model"}
+
+	c := mock.NewMockClient(
+		mock.WithStreamResponse([]*response.StreamingChunk{first, {Model: "test-model"}}, nil),
+		mock.WithExecuteStreamFault(mock.StreamFault{After: 1, Err: failure}),
+	)
+
+	chunks, err := c.ExecuteStream(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	if chunk := <-chunks; chunk != first {
+		t.Errorf("got chunk %v, want first configured chunk", chunk)
+	}
+
+	if chunk := <-chunks; chunk == nil || !errors.Is(chunk.Error, failure) {
+		t.Errorf("got chunk %v, want error chunk", chunk)
+	}
+
+	if _, ok := <-chunks; ok {
+		t.Error("stream not closed after error chunk")
+	}
+}
@@ -0,0 +1,141 @@
+package mock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestWithUsage_Fixed(t *testing.T) {
+	resp := chatResponse("answer")
+	agent := mock.NewMockAgent(
+		mock.WithChatResponse(resp, nil),
+		mock.WithEmbeddingsResponse(&response.EmbeddingsResponse{Model: "mock-model"}, nil),
+		mock.WithUsage(10, 5),
+	)
+
+	for range 2 {
+		got, err := agent.Chat(context.Background(), "Hello")
+		if err != nil {
+			t.Fatalf("Chat failed: %v", err)
+		}
+		if got.Usage == nil || *got.Usage != (response.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}) {
+			t.Errorf("got usage %+v, want 10/5/15", got.Usage)
+		}
+	}
+
+	if resp.Usage != nil {
+		t.Error("simulated usage modified the configured response")
+	}
+
+	embed, err := agent.Embed(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if embed.Usage == nil || *embed.Usage != (response.TokenUsage{PromptTokens: 10, TotalTokens: 10}) {
+		t.Errorf("got embeddings usage %+v, want prompt tokens only", embed.Usage)
+	}
+}
+
+func TestWithEstimatedUsage(t *testing.T) {
+	agent := mock.NewSimpleChatAgent("a", "A short answer.")
+
+	short, err := agent.Chat(context.Background(), "Hi")
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	long, err := agent.Chat(context.Background(), "Explain the history of distributed consensus algorithms in detail.")
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	if short.Usage == nil || long.Usage == nil {
+		t.Fatalf("got usage %v and %v, want estimated usage", short.Usage, long.Usage)
+	}
+	if long.Usage.PromptTokens <= short.Usage.PromptTokens {
+		t.Errorf("got prompt tokens %d for long prompt, want more than %d", long.Usage.PromptTokens, short.Usage.PromptTokens)
+	}
+	if short.Usage.CompletionTokens == 0 || short.Usage.CompletionTokens != long.Usage.CompletionTokens {
+		t.Errorf("got completion tokens %d and %d, want equal and non-zero", short.Usage.CompletionTokens, long.Usage.CompletionTokens)
+	}
+	if short.Usage.TotalTokens != short.Usage.PromptTokens+short.Usage.CompletionTokens {
+		t.Errorf("got total %d, want prompt + completion", short.Usage.TotalTokens)
+	}
+}
+
+func TestWithUsage_KeepsConfiguredUsage(t *testing.T) {
+	resp := chatResponse("answer")
+	resp.Usage = &response.TokenUsage{PromptTokens: 1, CompletionTokens: 1, TotalTokens: 2}
+
+	agent := mock.NewMockAgent(mock.WithChatResponse(resp, nil), mock.WithUsage(10, 5))
+
+	got, err := agent.Chat(context.Background(), "Hello")
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if got.Usage.TotalTokens != 2 {
+		t.Errorf("got total %d, want configured usage", got.Usage.TotalTokens)
+	}
+}
+
+func TestWithUsage_Stream(t *testing.T) {
+	agent := mock.NewStreamingChatAgent("a", []string{"Hello", " world"}, mock.WithUsage(4, 2))
+
+	chunks, err := agent.ChatStream(context.Background(), "Hi")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	var usage *response.TokenUsage
+	count := 0
+	for chunk := range chunks {
+		count++
+		if chunk.Usage != nil {
+			if count != 2 {
+				t.Errorf("got usage on chunk %d, want final chunk", count)
+			}
+			usage = chunk.Usage
+		}
+	}
+
+	if usage == nil || usage.TotalTokens != 6 {
+		t.Errorf("got stream usage %+v, want total 6", usage)
+	}
+}
+
+func TestWithPricing(t *testing.T) {
+	agent := mock.NewSimpleChatAgent("a", "answer",
+		mock.WithUsage(1000, 500),
+		mock.WithPricing(&config.PricingConfig{PromptPer1K: 0.01, CompletionPer1K: 0.02}),
+	)
+
+	resp, err := agent.Chat(context.Background(), "Hello")
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	pricing := agent.Model().Pricing
+	if pricing == nil {
+		t.Fatal("expected model pricing")
+	}
+	if cost := pricing.Cost(resp.Usage.PromptTokens, resp.Usage.CompletionTokens); cost != 0.02 {
+		t.Errorf("got cost %v, want 0.02", cost)
+	}
+}
+
+func TestRouterAgent_Usage(t *testing.T) {
+	agent := mock.NewRouterAgent(mock.WithUsage(3, 2)).Default(chatResponse("routed"))
+
+	chunks, err := agent.ChatStream(context.Background(), "Hello")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+	for chunk := range chunks {
+		if chunk.Usage == nil || chunk.Usage.TotalTokens != 5 {
+			t.Errorf("got usage %+v, want total 5", chunk.Usage)
+		}
+	}
+}
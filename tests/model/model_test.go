@@ -0,0 +1,73 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+func TestNew_Limits(t *testing.T) {
+	m := model.New(&config.ModelConfig{
+		Name:            "test-model",
+		ContextWindow:   128000,
+		MaxOutputTokens: 16384,
+	})
+
+	if m.ContextWindow != 128000 {
+		t.Errorf("got context window %d, want 128000", m.ContextWindow)
+	}
+
+	if m.MaxOutputTokens != 16384 {
+		t.Errorf("got max output tokens %d, want 16384", m.MaxOutputTokens)
+	}
+}
+
+func TestModel_Supports(t *testing.T) {
+	m := model.New(&config.ModelConfig{
+		Name:     "test-model",
+		Supports: map[string]bool{config.FeatureVision: false, config.FeatureTools: true},
+	})
+
+	if m.Supports(config.FeatureVision) {
+		t.Error("expected vision to be unsupported")
+	}
+
+	if !m.Supports(config.FeatureTools) {
+		t.Error("expected tools to be supported")
+	}
+
+	if !m.Supports(config.FeatureJSONMode) {
+		t.Error("expected unlisted feature to be supported")
+	}
+}
+
+func TestNew_Format(t *testing.T) {
+	capabilities := map[string]map[string]any{
+		"chat":  {"format": "openai-chat", "temperature": 0.7},
+		"tools": {"format": "json"},
+	}
+	m := model.New(&config.ModelConfig{Name: "test-model", Capabilities: capabilities})
+
+	if got := m.Format(protocol.Chat); got != "openai-chat" {
+		t.Errorf("chat format = %q, want openai-chat", got)
+	}
+	if _, ok := m.Options[protocol.Chat][model.FormatOption]; ok {
+		t.Error("the wire format should not be sent as a request option")
+	}
+	if m.Options[protocol.Chat]["temperature"] != 0.7 {
+		t.Errorf("chat options = %v, want temperature kept", m.Options[protocol.Chat])
+	}
+	if _, ok := capabilities["chat"][model.FormatOption]; !ok {
+		t.Error("New should not modify the configuration")
+	}
+
+	// "json" is Ollama's JSON output option, not a wire format
+	if got := m.Format(protocol.Tools); got != "" {
+		t.Errorf("tools format = %q, want none", got)
+	}
+	if m.Options[protocol.Tools][model.FormatOption] != "json" {
+		t.Errorf("tools options = %v, want format json kept", m.Options[protocol.Tools])
+	}
+}
@@ -0,0 +1,133 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+func TestValidateOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol protocol.Protocol
+		options  map[string]any
+		mode     model.OptionValidation
+		key      string
+	}{
+		{
+			name:     "valid chat options",
+			protocol: protocol.Chat,
+			options:  map[string]any{"temperature": 0.7, "max_tokens": 100, "stop": []string{"\n"}, "stream": true},
+			mode:     model.OptionValidationStrict,
+		},
+		{
+			name:     "json numbers accepted as integers",
+			protocol: protocol.Chat,
+			options:  map[string]any{"max_tokens": float64(4096)},
+			mode:     model.OptionValidationStrict,
+		},
+		{
+			name:     "unknown key",
+			protocol: protocol.Chat,
+			options:  map[string]any{"tempreture": 0.7},
+			mode:     model.OptionValidationStrict,
+			key:      "tempreture",
+		},
+		{
+			name:     "unknown key allowed in lenient mode",
+			protocol: protocol.Chat,
+			options:  map[string]any{"custom_extension": true},
+			mode:     model.OptionValidationLenient,
+		},
+		{
+			name:     "wrong type",
+			protocol: protocol.Chat,
+			options:  map[string]any{"temperature": "hot"},
+			mode:     model.OptionValidationLenient,
+			key:      "temperature",
+		},
+		{
+			name:     "out of range",
+			protocol: protocol.Chat,
+			options:  map[string]any{"top_p": 1.5},
+			mode:     model.OptionValidationStrict,
+			key:      "top_p",
+		},
+		{
+			name:     "fractional integer",
+			protocol: protocol.Tools,
+			options:  map[string]any{"max_tokens": 10.5},
+			mode:     model.OptionValidationStrict,
+			key:      "max_tokens",
+		},
+		{
+			name:     "enum violation",
+			protocol: protocol.Embeddings,
+			options:  map[string]any{"encoding_format": "int8"},
+			mode:     model.OptionValidationStrict,
+			key:      "encoding_format",
+		},
+		{
+			name:     "chat option invalid for embeddings",
+			protocol: protocol.Embeddings,
+			options:  map[string]any{"temperature": 0.5},
+			mode:     model.OptionValidationStrict,
+			key:      "temperature",
+		},
+		{
+			name:     "validation off",
+			protocol: protocol.Chat,
+			options:  map[string]any{"tempreture": "anything"},
+			mode:     model.OptionValidationOff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := model.ValidateOptions(tt.protocol, tt.options, tt.mode)
+
+			if tt.key == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, model.ErrInvalidOption) {
+				t.Fatalf("got error %v, want ErrInvalidOption", err)
+			}
+
+			var optionErr *model.OptionError
+			if !errors.As(err, &optionErr) {
+				t.Fatalf("got error %T, want *model.OptionError", err)
+			}
+
+			if optionErr.Key != tt.key {
+				t.Errorf("got key %q, want %q", optionErr.Key, tt.key)
+			}
+		})
+	}
+}
+
+func TestValidateOptions_Suggestion(t *testing.T) {
+	err := model.ValidateOptions(protocol.Chat, map[string]any{"tempreture": 0.7}, model.OptionValidationStrict)
+
+	expected := `option "tempreture" for chat protocol is not recognized (did you mean "temperature"?)`
+	if err == nil || err.Error() != expected {
+		t.Errorf("got error %v, want %q", err, expected)
+	}
+}
+
+func TestRegisterOption(t *testing.T) {
+	model.RegisterOption(protocol.Chat, "test_vendor_flag", model.OptionSpec{Types: []model.OptionType{model.TypeBool}})
+
+	if err := model.ValidateOptions(protocol.Chat, map[string]any{"test_vendor_flag": true}, model.OptionValidationStrict); err != nil {
+		t.Errorf("registered option rejected: %v", err)
+	}
+
+	if _, ok := model.Schema(protocol.Chat)["test_vendor_flag"]; !ok {
+		t.Error("Schema does not include registered option")
+	}
+}
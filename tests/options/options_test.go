@@ -0,0 +1,247 @@
+package options_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/options"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+func TestNew_Empty(t *testing.T) {
+	opts := options.New()
+
+	if opts == nil {
+		t.Fatal("New returned nil map")
+	}
+
+	if len(opts) != 0 {
+		t.Errorf("got %d options, want 0", len(opts))
+	}
+}
+
+func TestNew_AppliesInOrder(t *testing.T) {
+	opts := options.New(
+		options.From(map[string]any{"temperature": 0.7, "max_tokens": 100}),
+		options.Set("temperature", 0.2),
+	)
+
+	if opts["temperature"] != 0.2 {
+		t.Errorf("got temperature %v, want 0.2", opts["temperature"])
+	}
+
+	if opts["max_tokens"] != 100 {
+		t.Errorf("got max_tokens %v, want 100", opts["max_tokens"])
+	}
+}
+
+func TestLogprobs(t *testing.T) {
+	tests := []struct {
+		name        string
+		topN        int
+		expectedTop any
+	}{
+		{name: "without alternatives", topN: 0, expectedTop: nil},
+		{name: "with alternatives", topN: 5, expectedTop: 5},
+		{name: "clamped", topN: 50, expectedTop: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options.New(options.Logprobs(tt.topN))
+
+			if opts["logprobs"] != true {
+				t.Errorf("got logprobs %v, want true", opts["logprobs"])
+			}
+
+			if opts["top_logprobs"] != tt.expectedTop {
+				t.Errorf("got top_logprobs %v, want %v", opts["top_logprobs"], tt.expectedTop)
+			}
+		})
+	}
+}
+
+func TestChoices(t *testing.T) {
+	opts := options.New(options.Choices(3))
+
+	if opts["n"] != 3 {
+		t.Errorf("got n %v, want 3", opts["n"])
+	}
+}
+
+func TestStop(t *testing.T) {
+	opts := options.New(options.Stop("\n\n", "END"))
+
+	stop, ok := opts["stop"].([]string)
+	if !ok {
+		t.Fatalf("got stop %T, want []string", opts["stop"])
+	}
+
+	if len(stop) != 2 || stop[0] != "\n\n" || stop[1] != "END" {
+		t.Errorf("got stop %q, want [\"\\n\\n\" \"END\"]", stop)
+	}
+}
+
+func TestStop_Empty(t *testing.T) {
+	opts := options.New(
+		options.Stop("END"),
+		options.Stop(),
+	)
+
+	if _, ok := opts["stop"]; ok {
+		t.Errorf("got stop %v, want removed", opts["stop"])
+	}
+}
+
+func TestJSONMode(t *testing.T) {
+	opts := options.New(options.JSONMode())
+
+	format, ok := opts["response_format"].(map[string]any)
+	if !ok {
+		t.Fatalf("got response_format %T, want map[string]any", opts["response_format"])
+	}
+
+	if format["type"] != "json_object" {
+		t.Errorf("got type %v, want json_object", format["type"])
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema := map[string]any{"type": "object"}
+	opts := options.New(options.JSONSchema("reply", schema))
+
+	format, ok := opts["response_format"].(map[string]any)
+	if !ok {
+		t.Fatalf("got response_format %T, want map[string]any", opts["response_format"])
+	}
+
+	if format["type"] != "json_schema" {
+		t.Errorf("got type %v, want json_schema", format["type"])
+	}
+
+	spec, ok := format["json_schema"].(map[string]any)
+	if !ok {
+		t.Fatalf("got json_schema %T, want map[string]any", format["json_schema"])
+	}
+
+	if spec["name"] != "reply" {
+		t.Errorf("got name %v, want reply", spec["name"])
+	}
+
+	if spec["strict"] != true {
+		t.Errorf("got strict %v, want true", spec["strict"])
+	}
+
+	if got, ok := spec["schema"].(map[string]any); !ok || got["type"] != "object" {
+		t.Errorf("got schema %v, want %v", spec["schema"], schema)
+	}
+}
+
+func TestToolChoice(t *testing.T) {
+	tests := []struct {
+		name   string
+		option options.Option
+		want   string
+	}{
+		{name: "auto", option: options.ToolChoiceAuto(), want: "auto"},
+		{name: "none", option: options.ToolChoiceNone(), want: "none"},
+		{name: "required", option: options.ToolChoiceRequired(), want: "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options.New(tt.option)
+			if opts["tool_choice"] != tt.want {
+				t.Errorf("got tool_choice %v, want %s", opts["tool_choice"], tt.want)
+			}
+		})
+	}
+}
+
+func TestToolChoiceFunction(t *testing.T) {
+	opts := options.New(
+		options.ToolChoiceFunction("get_weather"),
+		options.ParallelToolCalls(false),
+	)
+
+	choice, ok := opts["tool_choice"].(map[string]any)
+	if !ok {
+		t.Fatalf("got tool_choice %T, want map[string]any", opts["tool_choice"])
+	}
+
+	function, _ := choice["function"].(map[string]any)
+	if choice["type"] != "function" || function["name"] != "get_weather" {
+		t.Errorf("got tool_choice %v, want function get_weather", choice)
+	}
+
+	if opts["parallel_tool_calls"] != false {
+		t.Errorf("got parallel_tool_calls %v, want false", opts["parallel_tool_calls"])
+	}
+}
+
+func TestWebSearch(t *testing.T) {
+	opts := options.New(
+		options.WebSearch(options.WebSearchConfig{MaxUses: 1}),
+		options.FileSearch("vs_1"),
+		options.WebSearch(options.WebSearchConfig{ContextSize: "high", AllowedDomains: []string{"go.dev"}}),
+	)
+
+	tools, ok := opts["builtin_tools"].([]map[string]any)
+	if !ok || len(tools) != 2 {
+		t.Fatalf("expected 2 builtin tools, got %v", opts["builtin_tools"])
+	}
+	if tools[0]["type"] != "file_search" || !slices.Equal(tools[0]["vector_store_ids"].([]string), []string{"vs_1"}) {
+		t.Errorf("unexpected file search tool: %v", tools[0])
+	}
+
+	search := tools[1]
+	if search["type"] != "web_search" || search["search_context_size"] != "high" {
+		t.Errorf("unexpected web search tool: %v", search)
+	}
+	if _, ok := search["max_uses"]; ok {
+		t.Error("expected the later web search to replace the earlier one")
+	}
+}
+
+func TestKeepAlive(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want any
+	}{
+		{30 * time.Minute, "30m0s"},
+		{0, "0s"},
+		{-time.Second, -1},
+	}
+
+	for _, tt := range tests {
+		opts := options.New(options.KeepAlive(tt.d))
+		if opts["keep_alive"] != tt.want {
+			t.Errorf("KeepAlive(%s) = %v, want %v", tt.d, opts["keep_alive"], tt.want)
+		}
+	}
+
+	if err := model.ValidateOptions(protocol.Chat, options.New(options.KeepAlive(time.Hour)), model.OptionValidationStrict); err != nil {
+		t.Errorf("keep_alive should pass strict validation: %v", err)
+	}
+}
+
+func TestExtra(t *testing.T) {
+	opts := options.New(
+		options.Extra(map[string]any{"guided_json": map[string]any{"type": "object"}, "service_tier": "auto"}),
+		options.Extra(map[string]any{"service_tier": "flex"}),
+	)
+
+	extra, ok := opts["extra_body"].(map[string]any)
+	if !ok {
+		t.Fatalf("extra_body = %T, want map[string]any", opts["extra_body"])
+	}
+	if extra["service_tier"] != "flex" || extra["guided_json"] == nil {
+		t.Errorf("unexpected extra_body: %v", extra)
+	}
+
+	if err := model.ValidateOptions(protocol.Chat, opts, model.OptionValidationStrict); err != nil {
+		t.Errorf("extra_body should pass strict validation: %v", err)
+	}
+}
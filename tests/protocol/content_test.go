@@ -0,0 +1,76 @@
+package protocol_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+)
+
+func TestMessage_Text(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  any
+		expected string
+	}{
+		{name: "string", content: "Hello", expected: "Hello"},
+		{name: "nil", content: nil, expected: ""},
+		{
+			name: "content parts",
+			content: []protocol.ContentPart{
+				protocol.TextPart("Describe"),
+				protocol.ImagePart("https://example.com/a.png"),
+				protocol.TextPart("briefly"),
+			},
+			expected: "Describe\nbriefly",
+		},
+		{
+			name: "map parts",
+			content: []map[string]any{
+				{"type": "text", "text": "What is this?"},
+				{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/a.png"}},
+			},
+			expected: "What is this?",
+		},
+		{name: "unrecognized", content: 42, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := protocol.NewMessage("user", tt.content)
+			if got := msg.Text(); got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMessage_Text_DecodedJSON(t *testing.T) {
+	data := `{"role":"user","content":[{"type":"text","text":"first"},{"type":"image_url","image_url":{"url":"x"}},{"type":"text","text":"second"}]}`
+
+	var msg protocol.Message
+	if err := json.Unmarshal([]byte(data), &msg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if msg.Text() != "first\nsecond" {
+		t.Errorf("got %q, want %q", msg.Text(), "first\nsecond")
+	}
+}
+
+func TestNewPartsMessage_Marshal(t *testing.T) {
+	msg := protocol.NewPartsMessage("user",
+		protocol.TextPart("What is in this image?"),
+		protocol.ImagePartWithDetail("data:image/png;base64,AAAA", "high"),
+	)
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	expected := `{"role":"user","content":[{"type":"text","text":"What is in this image?"},{"type":"image_url","image_url":{"url":"data:image/png;base64,AAAA","detail":"high"}}]}`
+	if string(data) != expected {
+		t.Errorf("got %s, want %s", data, expected)
+	}
+}
@@ -0,0 +1,162 @@
+package providers_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+const anthropicToolUse = `{
+  "id": "msg_01",
+  "type": "message",
+  "role": "assistant",
+  "model": "claude-test",
+  "content": [
+    {"type": "text", "text": "Checking both cities."},
+    {"type": "tool_use", "id": "toolu_01", "name": "get_weather", "input": {"city": "Paris"}},
+    {"type": "tool_use", "id": "toolu_02", "name": "get_weather", "input": {"city": "Oslo"}}
+  ],
+  "stop_reason": "tool_use",
+  "usage": {"input_tokens": 40, "output_tokens": 25}
+}`
+
+func TestAnthropicMessages_ToolRoundTrip(t *testing.T) {
+	resp, err := response.ParseAnthropicTools([]byte(anthropicToolUse))
+	if err != nil {
+		t.Fatalf("ParseAnthropicTools failed: %v", err)
+	}
+
+	assistant := protocol.Message{
+		Role:      "assistant",
+		Content:   resp.Choices[0].Message.Content,
+		ToolCalls: resp.ToolCalls(),
+	}
+	messages := []protocol.Message{
+		protocol.NewMessage("system", "Be brief."),
+		protocol.NewMessage("user", "Weather in Paris and Oslo?"),
+		assistant,
+		protocol.NewToolMessage("toolu_01", "18C, sunny"),
+		protocol.NewToolMessage("toolu_02", "4C, snow"),
+	}
+
+	system, converted, err := providers.AnthropicMessages(messages)
+	if err != nil {
+		t.Fatalf("AnthropicMessages failed: %v", err)
+	}
+
+	if system != "Be brief." {
+		t.Errorf("got system %q, want %q", system, "Be brief.")
+	}
+	if len(converted) != 3 {
+		t.Fatalf("got %d messages, want 3 (user, assistant, grouped tool results)", len(converted))
+	}
+
+	blocks := converted[1].Content
+	if converted[1].Role != "assistant" || len(blocks) != 3 {
+		t.Fatalf("got assistant message %+v, want text and two tool_use blocks", converted[1])
+	}
+	if blocks[0].Type != "text" || blocks[0].Text != "Checking both cities." {
+		t.Errorf("got first block %+v, want the text", blocks[0])
+	}
+	if blocks[1].Type != "tool_use" || blocks[1].ID != "toolu_01" || blocks[1].Name != "get_weather" {
+		t.Errorf("got tool_use block %+v", blocks[1])
+	}
+	var input map[string]string
+	if err := json.Unmarshal(blocks[1].Input, &input); err != nil || input["city"] != "Paris" {
+		t.Errorf("got input %s, want the original tool_use input", blocks[1].Input)
+	}
+
+	results := converted[2]
+	if results.Role != "user" || len(results.Content) != 2 {
+		t.Fatalf("got %+v, want one user message with both tool results", results)
+	}
+	for i, want := range []struct{ id, content string }{{"toolu_01", "18C, sunny"}, {"toolu_02", "4C, snow"}} {
+		block := results.Content[i]
+		if block.Type != "tool_result" || block.ToolUseID != want.id || block.Content != want.content {
+			t.Errorf("result %d: got %+v, want tool_result for %s", i, block, want.id)
+		}
+	}
+
+	data, err := json.Marshal(converted[2])
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), `"input"`) || strings.Contains(string(data), `"text"`) {
+		t.Errorf("tool_result blocks should omit unrelated fields: %s", data)
+	}
+}
+
+func TestAnthropicMessages_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		message protocol.Message
+		want    string
+	}{
+		{
+			name:    "tool result without call ID",
+			message: protocol.NewToolMessage("", "done"),
+			want:    "no tool call ID",
+		},
+		{
+			name: "invalid arguments",
+			message: protocol.Message{Role: "assistant", ToolCalls: []protocol.ToolCall{{
+				ID: "call_1", Type: "function",
+				Function: protocol.ToolCallFunction{Name: "f", Arguments: "{not json"},
+			}}},
+			want: "invalid JSON arguments",
+		},
+		{
+			name:    "image content",
+			message: protocol.NewPartsMessage("user", protocol.ImagePart("https://example.com/cat.jpg")),
+			want:    "unsupported content part",
+		},
+		{
+			name:    "unknown role",
+			message: protocol.NewMessage("narrator", "Meanwhile..."),
+			want:    "unknown role",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := providers.AnthropicMessages([]protocol.Message{tt.message})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnthropicTools(t *testing.T) {
+	schema := map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}}
+	tools := providers.AnthropicTools([]providers.ToolDefinition{
+		{Name: "get_weather", Description: "Current weather", Parameters: schema},
+		{Name: "now"},
+	})
+
+	if tools[0].Name != "get_weather" || tools[0].InputSchema["properties"] == nil {
+		t.Errorf("got %+v, want the schema as input_schema", tools[0])
+	}
+	if tools[1].InputSchema["type"] != "object" {
+		t.Errorf("got %+v, want an empty object schema", tools[1])
+	}
+}
+
+func TestAnthropicMessages_Prefill(t *testing.T) {
+	_, converted, err := providers.AnthropicMessages([]protocol.Message{
+		protocol.NewMessage("user", "List three colors as JSON."),
+		protocol.NewPrefillMessage("{\n"),
+	})
+	if err != nil {
+		t.Fatalf("AnthropicMessages failed: %v", err)
+	}
+
+	last := converted[len(converted)-1]
+	if last.Role != "assistant" || last.Content[0].Text != "{" {
+		t.Errorf("got %+v, want a trailing assistant message without trailing whitespace", last)
+	}
+}
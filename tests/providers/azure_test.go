@@ -2,11 +2,17 @@ package providers_test
 
 import (
 	"context"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/tailored-agentic-units/tau-core/pkg/config"
 	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
 	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
 )
 
 func TestNewAzure(t *testing.T) {
@@ -272,3 +278,210 @@ func TestAzure_PrepareStreamRequest(t *testing.T) {
 		t.Errorf("got Cache-Control header %q, want %q", request.Headers["Cache-Control"], "no-cache")
 	}
 }
+
+func newTestAzure(t *testing.T) providers.Provider {
+	t.Helper()
+
+	provider, err := providers.NewAzure(&config.ProviderConfig{
+		Name:    "azure",
+		BaseURL: "https://my-resource.openai.azure.com",
+		Options: map[string]any{
+			"deployment":  "gpt-4-deployment",
+			"auth_type":   "api_key",
+			"token":       "test-key",
+			"api_version": "2024-02-01",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAzure failed: %v", err)
+	}
+	return provider
+}
+
+func TestAzure_ProcessResponse_ContentFilterAnnotations(t *testing.T) {
+	provider := newTestAzure(t)
+
+	body := `{
+		"model": "gpt-4",
+		"prompt_filter_results": [{
+			"prompt_index": 0,
+			"content_filter_results": {
+				"hate": {"filtered": false, "severity": "safe"},
+				"jailbreak": {"filtered": false, "detected": false}
+			}
+		}],
+		"choices": [{
+			"index": 0,
+			"message": {"role": "assistant", "content": "Hello"},
+			"finish_reason": "stop",
+			"content_filter_results": {
+				"violence": {"filtered": false, "severity": "low"}
+			}
+		}]
+	}`
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	result, err := provider.ProcessResponse(context.Background(), resp, protocol.Chat)
+	if err != nil {
+		t.Fatalf("ProcessResponse failed: %v", err)
+	}
+
+	chat := result.(*response.ChatResponse)
+
+	if len(chat.PromptFilterResults) != 1 {
+		t.Fatalf("got %d prompt filter results, want 1", len(chat.PromptFilterResults))
+	}
+
+	jailbreak := chat.PromptFilterResults[0].ContentFilterResults["jailbreak"]
+	if jailbreak.Detected == nil || *jailbreak.Detected {
+		t.Errorf("got jailbreak %+v, want detected false", jailbreak)
+	}
+
+	violence := chat.Choices[0].ContentFilterResults["violence"]
+	if violence.Severity != "low" {
+		t.Errorf("got severity %q, want %q", violence.Severity, "low")
+	}
+}
+
+func TestAzure_ProcessResponse_ContentFiltered(t *testing.T) {
+	provider := newTestAzure(t)
+
+	body := `{
+		"model": "gpt-4",
+		"choices": [{
+			"index": 0,
+			"message": {"role": "assistant", "content": null},
+			"finish_reason": "content_filter",
+			"content_filter_results": {
+				"hate": {"filtered": false, "severity": "safe"},
+				"violence": {"filtered": true, "severity": "high"}
+			}
+		}]
+	}`
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	_, err := provider.ProcessResponse(context.Background(), resp, protocol.Chat)
+	if !errors.Is(err, response.ErrContentFiltered) {
+		t.Fatalf("got error %v, want ErrContentFiltered", err)
+	}
+
+	if !strings.Contains(err.Error(), "violence") {
+		t.Errorf("error %q does not name the filtered category", err)
+	}
+}
+
+func TestAzure_ProcessStreamResponse_ContentFiltered(t *testing.T) {
+	provider := newTestAzure(t)
+
+	stream := "data: {\"model\":\"gpt-4\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\n" +
+		"data: {\"model\":\"gpt-4\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"content_filter\",\"content_filter_results\":{\"sexual\":{\"filtered\":true,\"severity\":\"medium\"}}}]}\n\n" +
+		"data: [DONE]\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(stream)),
+	}
+
+	chunks, err := provider.ProcessStreamResponse(context.Background(), resp, protocol.Chat)
+	if err != nil {
+		t.Fatalf("ProcessStreamResponse failed: %v", err)
+	}
+
+	var received []*response.StreamingChunk
+	for data := range chunks {
+		received = append(received, data.(*response.StreamingChunk))
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(received))
+	}
+
+	if received[0].Error != nil {
+		t.Errorf("unexpected error on first chunk: %v", received[0].Error)
+	}
+
+	if !errors.Is(received[1].Error, response.ErrContentFiltered) {
+		t.Errorf("got error %v, want ErrContentFiltered", received[1].Error)
+	}
+}
+
+func TestAzure_Serverless(t *testing.T) {
+	provider, err := providers.NewAzure(&config.ProviderConfig{
+		Name:    "azure",
+		BaseURL: "https://mistral-large.eastus2.models.ai.azure.com/",
+		Options: map[string]any{
+			"endpoint_type":    providers.AzureEndpointServerless,
+			"auth_type":        "bearer",
+			"token":            "test-key",
+			"extra_parameters": "drop",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAzure failed: %v", err)
+	}
+
+	tests := []struct {
+		protocol protocol.Protocol
+		expected string
+	}{
+		{protocol.Chat, "https://mistral-large.eastus2.models.ai.azure.com/chat/completions?api-version=" + providers.AzureServerlessAPIVersion},
+		{protocol.Tools, "https://mistral-large.eastus2.models.ai.azure.com/chat/completions?api-version=" + providers.AzureServerlessAPIVersion},
+		{protocol.Embeddings, "https://mistral-large.eastus2.models.ai.azure.com/embeddings?api-version=" + providers.AzureServerlessAPIVersion},
+	}
+	for _, tt := range tests {
+		endpoint, err := provider.Endpoint(tt.protocol)
+		if err != nil {
+			t.Fatalf("Endpoint(%s) failed: %v", tt.protocol, err)
+		}
+		if endpoint != tt.expected {
+			t.Errorf("Endpoint(%s) = %q, want %q", tt.protocol, endpoint, tt.expected)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "https://mistral-large.eastus2.models.ai.azure.com/chat/completions", nil)
+	provider.SetHeaders(req)
+	if got := req.Header.Get("extra-parameters"); got != "drop" {
+		t.Errorf("extra-parameters = %q, want drop", got)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer test-key" {
+		t.Errorf("Authorization = %q, want bearer token", got)
+	}
+
+	if _, err := provider.(providers.FileProvider).FilesEndpoint(""); err == nil {
+		t.Error("expected error for files on a serverless endpoint")
+	}
+}
+
+func TestNewAzure_ServerlessOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+	}{
+		{"unknown endpoint type", map[string]any{"endpoint_type": "dedicated"}},
+		{"invalid extra parameters", map[string]any{"endpoint_type": providers.AzureEndpointServerless, "extra_parameters": "ignore"}},
+		{"extra parameters on openai endpoint", map[string]any{"deployment": "gpt-4", "api_version": "2024-02-01", "extra_parameters": "drop"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := map[string]any{"auth_type": "api_key", "token": "test-key"}
+			maps.Copy(options, tt.options)
+			_, err := providers.NewAzure(&config.ProviderConfig{
+				Name:    "azure",
+				BaseURL: "https://endpoint.models.ai.azure.com",
+				Options: options,
+			})
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -162,6 +162,60 @@ func TestBaseProvider_Marshal_Tools(t *testing.T) {
 	}
 }
 
+func TestBaseProvider_Marshal_VisionTools(t *testing.T) {
+	provider := providers.NewBaseProvider("test", "https://api.test.com")
+
+	toolsData := &providers.ToolsData{
+		Model: "gpt-4o",
+		Messages: []protocol.Message{
+			protocol.NewMessage("system", "You operate a browser."),
+			protocol.NewMessage("user", "Click the login button"),
+		},
+		Tools:         []providers.ToolDefinition{{Name: "click", Description: "Click at a position"}},
+		Images:        []string{"data:image/png;base64,iVBORw0KGgo="},
+		VisionOptions: map[string]any{"detail": "high"},
+	}
+
+	body, err := provider.Marshal(protocol.Tools, toolsData)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var result struct {
+		Messages []struct {
+			Role    string `json:"role"`
+			Content any    `json:"content"`
+		} `json:"messages"`
+		Tools []any `json:"tools"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("Failed to unmarshal result: %v", err)
+	}
+
+	if len(result.Tools) != 1 {
+		t.Errorf("got %d tools, want 1", len(result.Tools))
+	}
+
+	if len(result.Messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(result.Messages))
+	}
+
+	if result.Messages[0].Content != "You operate a browser." {
+		t.Errorf("system message was modified: %v", result.Messages[0].Content)
+	}
+
+	content, ok := result.Messages[1].Content.([]any)
+	if !ok || len(content) != 2 {
+		t.Fatalf("got content %v, want text and image parts", result.Messages[1].Content)
+	}
+
+	image, _ := content[1].(map[string]any)
+	imageURL, _ := image["image_url"].(map[string]any)
+	if image["type"] != "image_url" || imageURL["detail"] != "high" {
+		t.Errorf("got image part %v, want image_url with detail high", image)
+	}
+}
+
 func TestBaseProvider_Marshal_Embeddings(t *testing.T) {
 	provider := providers.NewBaseProvider("test", "https://api.test.com")
 
@@ -198,3 +252,54 @@ func TestBaseProvider_Marshal_UnsupportedProtocol(t *testing.T) {
 		t.Error("expected error for unsupported protocol, got nil")
 	}
 }
+
+func TestBaseProvider_Marshal_ExtraBody(t *testing.T) {
+	provider := providers.NewBaseProvider("test", "https://api.test.com")
+
+	options := map[string]any{
+		"temperature": 0.7,
+		providers.ExtraBodyKey: map[string]any{
+			"guided_json": map[string]any{"type": "object"},
+			"temperature": 0.1,
+		},
+	}
+	body, err := provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model:    "gpt-4",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Hello")},
+		Options:  options,
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("Failed to unmarshal result: %v", err)
+	}
+
+	if _, ok := result[providers.ExtraBodyKey]; ok {
+		t.Error("extra_body was sent as an option")
+	}
+	if result["guided_json"] == nil {
+		t.Errorf("guided_json missing from body: %s", body)
+	}
+	if result["temperature"] != 0.1 {
+		t.Errorf("got temperature %v, want the extra_body value 0.1", result["temperature"])
+	}
+	if _, ok := options[providers.ExtraBodyKey]; !ok {
+		t.Error("Marshal modified the request options")
+	}
+}
+
+func TestBaseProvider_Marshal_ExtraBodyInvalid(t *testing.T) {
+	provider := providers.NewBaseProvider("test", "https://api.test.com")
+
+	_, err := provider.Marshal(protocol.Embeddings, &providers.EmbeddingsData{
+		Model:   "text-embedding-3-small",
+		Input:   "Hello",
+		Options: map[string]any{providers.ExtraBodyKey: "guided_json"},
+	})
+	if err == nil {
+		t.Error("expected error for a non-object extra_body")
+	}
+}
@@ -0,0 +1,125 @@
+package providers_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/options"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func TestBuiltinTools_FromJSON(t *testing.T) {
+	var opts map[string]any
+	json.Unmarshal([]byte(`{"builtin_tools": [{"type": "web_search", "max_uses": 2}]}`), &opts)
+
+	tools, err := providers.BuiltinTools(opts)
+	if err != nil {
+		t.Fatalf("BuiltinTools failed: %v", err)
+	}
+	if len(tools) != 1 || tools[0]["type"] != providers.BuiltinWebSearch {
+		t.Errorf("got %v", tools)
+	}
+
+	for _, invalid := range []any{"web_search", []any{"web_search"}, []any{map[string]any{}}} {
+		if _, err := providers.BuiltinTools(map[string]any{providers.BuiltinToolsKey: invalid}); err == nil {
+			t.Errorf("%v: expected error", invalid)
+		}
+	}
+}
+
+func TestBaseProvider_Marshal_WebSearch(t *testing.T) {
+	provider := providers.NewBaseProvider("test", "https://api.test.com")
+
+	body, err := provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model:    "gpt-4o-search-preview",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Latest Go release?")},
+		Options:  options.New(options.WebSearch(options.WebSearchConfig{ContextSize: "low", MaxUses: 3})),
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var result map[string]any
+	json.Unmarshal(body, &result)
+	if _, ok := result[providers.BuiltinToolsKey]; ok {
+		t.Error("builtin_tools should not be sent")
+	}
+	search, _ := result["web_search_options"].(map[string]any)
+	if search["search_context_size"] != "low" || len(search) != 1 {
+		t.Errorf("got web_search_options %v, want only search_context_size", result["web_search_options"])
+	}
+
+	_, err = provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model:    "gpt-4o",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Search my files")},
+		Options:  options.New(options.FileSearch("vs_1")),
+	})
+	if err == nil {
+		t.Error("expected file_search to be rejected for chat completions")
+	}
+}
+
+func TestAnthropicBuiltinTools(t *testing.T) {
+	opts := options.New(
+		options.Set("temperature", 0.5),
+		options.WebSearch(options.WebSearchConfig{MaxUses: 3, AllowedDomains: []string{"go.dev"}, ContextSize: "high"}),
+	)
+
+	tools, remaining, err := providers.AnthropicBuiltinTools(opts)
+	if err != nil {
+		t.Fatalf("AnthropicBuiltinTools failed: %v", err)
+	}
+
+	if len(tools) != 1 {
+		t.Fatalf("expected 1 tool, got %v", tools)
+	}
+	tool := tools[0]
+	if tool["type"] != providers.AnthropicWebSearchType || tool["name"] != "web_search" || tool["max_uses"] != 3 {
+		t.Errorf("unexpected tool %v", tool)
+	}
+	if _, ok := tool["search_context_size"]; ok {
+		t.Error("search_context_size has no Anthropic equivalent")
+	}
+	if _, ok := remaining[providers.BuiltinToolsKey]; ok || remaining["temperature"] != 0.5 {
+		t.Errorf("unexpected remaining options %v", remaining)
+	}
+	if _, ok := opts[providers.BuiltinToolsKey]; !ok {
+		t.Error("original options should not be modified")
+	}
+
+	if _, _, err := providers.AnthropicBuiltinTools(options.New(options.FileSearch("vs_1"))); err == nil {
+		t.Error("expected file_search to be rejected")
+	}
+}
+
+func TestMarshalGeminiTools_WebSearch(t *testing.T) {
+	data, err := providers.MarshalGeminiTools(&providers.ToolsData{
+		Model:    "gemini-test",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Who won yesterday?")},
+		Tools:    []providers.ToolDefinition{{Name: "lookup", Parameters: map[string]any{"type": "object"}}},
+		Options:  options.New(options.WebSearch(options.WebSearchConfig{})),
+	})
+	if err != nil {
+		t.Fatalf("MarshalGeminiTools failed: %v", err)
+	}
+
+	var body struct {
+		Tools   []map[string]any `json:"tools"`
+		Builtin any              `json:"builtin_tools"`
+	}
+	json.Unmarshal(data, &body)
+
+	if len(body.Tools) != 2 {
+		t.Fatalf("expected function and google_search tools, got %v", body.Tools)
+	}
+	if _, ok := body.Tools[0]["functionDeclarations"]; !ok {
+		t.Errorf("expected function declarations first, got %v", body.Tools[0])
+	}
+	if _, ok := body.Tools[1]["google_search"]; !ok || len(body.Tools[1]) != 1 {
+		t.Errorf("expected a google_search tool, got %v", body.Tools[1])
+	}
+	if body.Builtin != nil {
+		t.Error("builtin_tools should not be sent")
+	}
+}
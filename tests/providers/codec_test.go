@@ -0,0 +1,303 @@
+package providers_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// stubCodec marshals every request as a fixed body and parses every response
+// as a chat response with the body as content.
+type stubCodec struct {
+	body string
+}
+
+func (c stubCodec) Marshal(p protocol.Protocol, data any) ([]byte, error) {
+	return []byte(c.body), nil
+}
+
+func (c stubCodec) Parse(ctx context.Context, p protocol.Protocol, body []byte) (any, error) {
+	return &response.ChatResponse{Choices: []response.ChatChoice{{Message: protocol.NewMessage("assistant", string(body))}}}, nil
+}
+
+func (c stubCodec) ParseStreamChunk(p protocol.Protocol, data []byte) (*response.StreamingChunk, error) {
+	return &response.StreamingChunk{Choices: []response.StreamingChoice{{Delta: response.StreamingDelta{Content: string(data)}}}}, nil
+}
+
+func TestLookupCodec_BuiltIn(t *testing.T) {
+	tests := []struct {
+		proto  protocol.Protocol
+		format string
+		want   providers.Codec
+	}{
+		{protocol.Chat, "", providers.OpenAICodec{}},
+		{protocol.Embeddings, providers.FormatOpenAI, providers.OpenAICodec{}},
+		{protocol.Chat, providers.FormatAnthropic, providers.AnthropicCodec{}},
+		{protocol.Tools, providers.FormatAnthropic, providers.AnthropicCodec{}},
+		{protocol.Tools, providers.FormatGemini, providers.GeminiCodec{}},
+	}
+
+	for _, tt := range tests {
+		codec, err := providers.LookupCodec("ollama", tt.proto, tt.format)
+		if err != nil {
+			t.Errorf("%s/%s: LookupCodec failed: %v", tt.proto, tt.format, err)
+			continue
+		}
+		if codec != tt.want {
+			t.Errorf("%s/%s: got %T, want %T", tt.proto, tt.format, codec, tt.want)
+		}
+	}
+
+	if _, err := providers.LookupCodec("ollama", protocol.Chat, providers.FormatGemini); err == nil {
+		t.Error("expected error for unregistered gemini chat codec")
+	}
+
+	keys := providers.ListCodecs()
+	if !slices.Contains(keys, providers.CodecKey{Protocol: protocol.Tools, Format: providers.FormatAnthropic}) {
+		t.Errorf("ListCodecs missing anthropic tools codec: %v", keys)
+	}
+}
+
+func TestRegisterCodec_ProviderSpecific(t *testing.T) {
+	providers.RegisterCodec("codec-test", protocol.Chat, providers.FormatOpenAI, stubCodec{body: "custom"})
+
+	codec, err := providers.LookupCodec("codec-test", protocol.Chat, providers.FormatOpenAI)
+	if err != nil {
+		t.Fatalf("LookupCodec failed: %v", err)
+	}
+	if _, ok := codec.(stubCodec); !ok {
+		t.Errorf("got %T, want the provider's codec", codec)
+	}
+
+	// Other providers and protocols keep the shared codec
+	if codec, _ := providers.LookupCodec("other", protocol.Chat, providers.FormatOpenAI); codec != (providers.OpenAICodec{}) {
+		t.Errorf("other provider got %T, want OpenAICodec", codec)
+	}
+	if codec, _ := providers.LookupCodec("codec-test", protocol.Vision, providers.FormatOpenAI); codec != (providers.OpenAICodec{}) {
+		t.Errorf("vision got %T, want OpenAICodec", codec)
+	}
+
+	provider := providers.NewBaseProvider("codec-test", "https://api.test.com")
+	body, err := provider.Marshal(protocol.Chat, &providers.ChatData{Model: "m"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(body) != "custom" {
+		t.Errorf("Marshal = %s, want the registered codec's output", body)
+	}
+
+	result, err := provider.Parse(context.Background(), protocol.Chat, []byte("parsed"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if resp := result.(*response.ChatResponse); resp.Content() != "parsed" {
+		t.Errorf("Parse content = %q, want %q", resp.Content(), "parsed")
+	}
+}
+
+func TestBaseProvider_SetFormat(t *testing.T) {
+	provider := providers.NewBaseProvider("test", "https://api.test.com")
+	if provider.Format() != providers.FormatOpenAI {
+		t.Errorf("default format = %q, want %q", provider.Format(), providers.FormatOpenAI)
+	}
+
+	provider.SetFormat(providers.FormatAnthropic)
+	data := &providers.ToolsData{
+		Model: "claude-sonnet",
+		Messages: []protocol.Message{
+			protocol.NewMessage("system", "Be brief."),
+			protocol.NewMessage("user", "Weather in Paris?"),
+		},
+		Tools:   []providers.ToolDefinition{{Name: "get_weather", Description: "Get weather"}},
+		Options: map[string]any{"stop": "END", "tool_choice": "required"},
+	}
+
+	body, err := provider.Marshal(protocol.Tools, data)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var result map[string]any
+	json.Unmarshal(body, &result)
+	if result["system"] != "Be brief." || result["model"] != "claude-sonnet" {
+		t.Errorf("system = %v, model = %v", result["system"], result["model"])
+	}
+	if result["max_tokens"] != float64(providers.AnthropicDefaultMaxTokens) {
+		t.Errorf("max_tokens = %v, want default %d", result["max_tokens"], providers.AnthropicDefaultMaxTokens)
+	}
+	if stops, _ := result["stop_sequences"].([]any); len(stops) != 1 || stops[0] != "END" {
+		t.Errorf("stop_sequences = %v, want [END]", result["stop_sequences"])
+	}
+	if choice, _ := result["tool_choice"].(map[string]any); choice["type"] != "any" {
+		t.Errorf("tool_choice = %v, want type any", result["tool_choice"])
+	}
+	tools, _ := result["tools"].([]any)
+	if len(tools) != 1 || tools[0].(map[string]any)["input_schema"] == nil {
+		t.Errorf("tools = %v, want one tool with input_schema", result["tools"])
+	}
+
+	if _, err := provider.Marshal(protocol.Vision, &providers.VisionData{}); err == nil || !strings.Contains(err.Error(), "anthropic") {
+		t.Errorf("vision in anthropic format: got %v, want unregistered codec error", err)
+	}
+
+	parsed, err := provider.Parse(context.Background(), protocol.Tools, []byte(`{
+		"model": "claude-sonnet",
+		"stop_reason": "tool_use",
+		"content": [{"type": "tool_use", "id": "toolu_1", "name": "get_weather", "input": {"city": "Paris"}}]
+	}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	resp := parsed.(*response.ToolsResponse)
+	if calls := resp.Choices[0].Message.ToolCalls; len(calls) != 1 || calls[0].Function.Name != "get_weather" {
+		t.Errorf("tool calls = %+v", calls)
+	}
+}
+
+func TestOpenAICodec_MatchesBaseProvider(t *testing.T) {
+	data := &providers.ChatData{
+		Model:    "m",
+		Messages: []protocol.Message{protocol.NewMessage("developer", "Be brief.")},
+	}
+
+	provider := providers.NewBaseProvider("test", "https://api.test.com")
+	viaProvider, err := provider.Marshal(protocol.Chat, data)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	viaCodec, err := providers.OpenAICodec{}.Marshal(protocol.Chat, data)
+	if err != nil {
+		t.Fatalf("OpenAICodec.Marshal failed: %v", err)
+	}
+	if string(viaProvider) != string(viaCodec) {
+		t.Errorf("provider body %s differs from codec body %s", viaProvider, viaCodec)
+	}
+	if !strings.Contains(string(viaCodec), `"role":"system"`) {
+		t.Errorf("developer message should be sent as system by default: %s", viaCodec)
+	}
+}
+
+func TestLookupCodec_FormatVariant(t *testing.T) {
+	codec, err := providers.LookupCodec("ollama", protocol.Chat, "openai-chat")
+	if err != nil {
+		t.Fatalf("LookupCodec failed: %v", err)
+	}
+	if codec != (providers.OpenAICodec{}) {
+		t.Errorf("got %T, want the base format's OpenAICodec", codec)
+	}
+
+	providers.RegisterCodec("", protocol.Chat, "openai-variant", stubCodec{body: "variant"})
+	if codec, _ := providers.LookupCodec("ollama", protocol.Chat, "openai-variant"); codec != (stubCodec{body: "variant"}) {
+		t.Errorf("got %T, want the codec registered for the variant", codec)
+	}
+
+	if _, err := providers.LookupCodec("ollama", protocol.Chat, "unknown-chat"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestAnthropicCodec_Chat(t *testing.T) {
+	provider := providers.NewBaseProvider("test", "https://api.test.com")
+	provider.SetFormat(providers.FormatAnthropic)
+
+	body, err := provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model: "claude-sonnet",
+		Messages: []protocol.Message{
+			protocol.NewMessage("system", "Answer in JSON."),
+			protocol.NewMessage("user", "List three colors."),
+			protocol.NewPrefillMessage("{ "),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var payload struct {
+		System   string                       `json:"system"`
+		Messages []providers.AnthropicMessage `json:"messages"`
+		Tools    []any                        `json:"tools"`
+	}
+	json.Unmarshal(body, &payload)
+	if payload.System != "Answer in JSON." || len(payload.Messages) != 2 || payload.Tools != nil {
+		t.Fatalf("Marshal = %s, want the system prompt, two messages, and no tools", body)
+	}
+	if last := payload.Messages[1]; last.Role != "assistant" || last.Content[0].Text != "{" {
+		t.Errorf("final message = %+v, want the trimmed prefill", last)
+	}
+
+	result, err := provider.Parse(context.Background(), protocol.Chat, []byte(`{"model":"claude-sonnet","content":[{"type":"text","text":"\"colors\": []}"}],"stop_reason":"end_turn"}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if resp, ok := result.(*response.ChatResponse); !ok || resp.Content() != `"colors": []}` {
+		t.Errorf("Parse = %#v, want a ChatResponse with the continuation", result)
+	}
+}
+
+func TestBaseProvider_SupportsPrefill(t *testing.T) {
+	provider := providers.NewBaseProvider("test", "https://api.test.com")
+
+	tests := []struct {
+		format string
+		want   bool
+	}{
+		{"", false},
+		{providers.FormatOpenAI, false},
+		{providers.FormatGemini, false},
+		{providers.FormatAnthropic, true},
+		{"anthropic-beta", true},
+	}
+	for _, tt := range tests {
+		if got := provider.SupportsPrefill(tt.format); got != tt.want {
+			t.Errorf("SupportsPrefill(%q) = %v, want %v", tt.format, got, tt.want)
+		}
+	}
+
+	provider.SetFormat(providers.FormatAnthropic)
+	if !provider.SupportsPrefill("") {
+		t.Error("SupportsPrefill(\"\") = false, want the provider's Anthropic format")
+	}
+}
+
+func TestBaseProvider_RequestFormat(t *testing.T) {
+	provider := providers.NewBaseProvider("test", "https://api.test.com")
+
+	body, err := provider.Marshal(protocol.Tools, &providers.ToolsData{
+		Model:    "m",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Hi")},
+		Tools:    []providers.ToolDefinition{{Name: "lookup", Parameters: map[string]any{"type": "object"}}},
+		Format:   providers.FormatAnthropic,
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(body), `"input_schema"`) {
+		t.Errorf("Marshal = %s, want the Anthropic format", body)
+	}
+
+	ctx := providers.WithFormat(context.Background(), providers.FormatAnthropic)
+	if got := providers.FormatFromContext(ctx); got != providers.FormatAnthropic {
+		t.Errorf("FormatFromContext = %q, want %q", got, providers.FormatAnthropic)
+	}
+
+	result, err := provider.Parse(ctx, protocol.Tools, []byte(`{
+		"content": [{"type": "tool_use", "id": "toolu_1", "name": "lookup", "input": {}}],
+		"stop_reason": "tool_use"
+	}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if calls := result.(*response.ToolsResponse).ToolCalls(); len(calls) != 1 || calls[0].Function.Name != "lookup" {
+		t.Errorf("got tool calls %+v, want the Anthropic tool_use block", calls)
+	}
+
+	if provider.Format() != providers.FormatOpenAI {
+		t.Errorf("provider format = %q, want it unchanged", provider.Format())
+	}
+}
@@ -0,0 +1,48 @@
+package providers_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/redact"
+)
+
+func TestResponseError(t *testing.T) {
+	p := newMiddlewareOllama(t)
+
+	body := `{"error":"model not found","echo":"` + strings.Repeat("prompt ", 200) + `","auth":"Bearer abc.def"}`
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	_, err := p.ProcessResponse(context.Background(), resp, protocol.Chat)
+
+	var respErr *providers.ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("got %v, want ResponseError", err)
+	}
+	if respErr.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %d, want 404", respErr.StatusCode)
+	}
+	if !strings.HasPrefix(err.Error(), "request failed with status 404: ") {
+		t.Errorf("got message %q", err.Error())
+	}
+	if len(respErr.Body) > redact.MaxErrorBody+32 || !strings.Contains(string(respErr.Body), "more bytes") {
+		t.Errorf("got %d body bytes, want the body truncated", len(respErr.Body))
+	}
+	if string(respErr.RawBody()) != body {
+		t.Error("RawBody does not return the original body")
+	}
+
+	short := providers.NewResponseError(http.StatusUnauthorized, []byte(`{"auth":"Bearer abc.def"}`))
+	if strings.Contains(short.Error(), "abc.def") {
+		t.Errorf("got message %q, want the token redacted", short.Error())
+	}
+}
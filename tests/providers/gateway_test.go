@@ -0,0 +1,202 @@
+package providers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// gatewayOptions is a gateway config in the JSON form read from config files.
+func gatewayOptions(t *testing.T) map[string]any {
+	t.Helper()
+	var gateway map[string]any
+	err := json.Unmarshal([]byte(`{
+		"endpoints": {"chat": "/llm/{model}/invoke?tenant={tenant}"},
+		"stream_endpoints": {"chat": "/llm/{model}/stream?tenant={tenant}"},
+		"vars": {"tenant": "acme"},
+		"auth_header": "X-Gateway-Key",
+		"headers": {"X-Client": "tau"},
+		"envelope": {"rename": {"messages": "conversation"}, "wrap": "payload", "fields": {"app_id": "billing"}},
+		"response_path": "data.result"
+	}`), &gateway)
+	if err != nil {
+		t.Fatalf("invalid gateway fixture: %v", err)
+	}
+	return map[string]any{"gateway": gateway, "token": "secret"}
+}
+
+func TestGateway_PrepareRequest(t *testing.T) {
+	provider, err := providers.Create(&config.ProviderConfig{
+		Name:    "gateway",
+		BaseURL: "https://llm.corp.example.com/",
+		Options: gatewayOptions(t),
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	body, err := provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model:    "gpt-4o",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Hello")},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	req, err := provider.PrepareRequest(context.Background(), protocol.Chat, body, map[string]string{})
+	if err != nil {
+		t.Fatalf("PrepareRequest failed: %v", err)
+	}
+	if want := "https://llm.corp.example.com/llm/gpt-4o/invoke?tenant=acme"; req.URL != want {
+		t.Errorf("URL = %q, want %q", req.URL, want)
+	}
+
+	var sent map[string]any
+	json.Unmarshal(req.Body, &sent)
+	payload, _ := sent["payload"].(map[string]any)
+	if sent["app_id"] != "billing" || payload["model"] != "gpt-4o" || payload["conversation"] == nil || payload["messages"] != nil {
+		t.Errorf("body = %s, want renamed messages wrapped in payload with app_id", req.Body)
+	}
+
+	stream, err := provider.PrepareStreamRequest(context.Background(), protocol.Chat, body, map[string]string{})
+	if err != nil {
+		t.Fatalf("PrepareStreamRequest failed: %v", err)
+	}
+	if want := "https://llm.corp.example.com/llm/gpt-4o/stream?tenant=acme"; stream.URL != want {
+		t.Errorf("stream URL = %q, want %q", stream.URL, want)
+	}
+
+	if _, err := provider.Endpoint(protocol.Tools); err == nil {
+		t.Error("expected error for a protocol without an endpoint")
+	}
+
+	httpReq, _ := http.NewRequest(http.MethodPost, req.URL, nil)
+	provider.SetHeaders(httpReq)
+	if got := httpReq.Header.Get("X-Gateway-Key"); got != "secret" {
+		t.Errorf("X-Gateway-Key = %q, want the raw token", got)
+	}
+	if got := httpReq.Header.Get("X-Client"); got != "tau" {
+		t.Errorf("X-Client = %q, want tau", got)
+	}
+	if got := httpReq.Header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization = %q, want none", got)
+	}
+}
+
+func TestGateway_ResponsePath(t *testing.T) {
+	provider, err := providers.NewGateway(&config.ProviderConfig{
+		Name:    "gateway",
+		BaseURL: "https://llm.corp.example.com",
+		Options: gatewayOptions(t),
+	})
+	if err != nil {
+		t.Fatalf("NewGateway failed: %v", err)
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"data":{"result":{"model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}}}`)),
+	}
+	result, err := provider.ProcessResponse(context.Background(), resp, protocol.Chat)
+	if err != nil {
+		t.Fatalf("ProcessResponse failed: %v", err)
+	}
+	if got := result.(*response.ChatResponse).Content(); got != "Hi" {
+		t.Errorf("content = %q, want Hi", got)
+	}
+
+	resp = &http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader("event: message\n" +
+			"data:{\"data\":{\"result\":{\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}}}\n\n" +
+			"data: {\"data\":{\"result\":{\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"}}]}}}\n\n" +
+			"data: [DONE]\n\n")),
+	}
+	chunks, err := provider.ProcessStreamResponse(context.Background(), resp, protocol.Chat)
+	if err != nil {
+		t.Fatalf("ProcessStreamResponse failed: %v", err)
+	}
+	var content strings.Builder
+	for chunk := range chunks {
+		content.WriteString(chunk.(*response.StreamingChunk).Content())
+	}
+	if content.String() != "Hello" {
+		t.Errorf("streamed content = %q, want Hello", content.String())
+	}
+}
+
+func TestNewGateway_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+	}{
+		{"missing gateway", map[string]any{}},
+		{"no endpoints", map[string]any{"gateway": map[string]any{}}},
+		{"unknown protocol", map[string]any{"gateway": map[string]any{"endpoints": map[string]any{"completions": "/v1/completions"}}}},
+		{"wrong type", map[string]any{"gateway": "https://llm.corp.example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := providers.NewGateway(&config.ProviderConfig{Name: "gateway", Options: tt.options}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestWatsonx(t *testing.T) {
+	provider, err := providers.Create(&config.ProviderConfig{
+		Name:    "watsonx",
+		Options: map[string]any{"project_id": "proj-1", "token": "iam-token"},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	body, err := provider.Marshal(protocol.Tools, &providers.ToolsData{
+		Model:    "ibm/granite-3-8b-instruct",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Weather in Paris?")},
+		Tools:    []providers.ToolDefinition{{Name: "get_weather", Description: "Get weather"}},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	req, err := provider.PrepareRequest(context.Background(), protocol.Tools, body, map[string]string{})
+	if err != nil {
+		t.Fatalf("PrepareRequest failed: %v", err)
+	}
+	if want := providers.WatsonxDefaultBaseURL + "/ml/v1/text/chat?version=" + providers.WatsonxDefaultVersion; req.URL != want {
+		t.Errorf("URL = %q, want %q", req.URL, want)
+	}
+
+	var sent map[string]any
+	json.Unmarshal(req.Body, &sent)
+	if sent["model_id"] != "ibm/granite-3-8b-instruct" || sent["project_id"] != "proj-1" || sent["model"] != nil {
+		t.Errorf("body = %s, want model_id and project_id", req.Body)
+	}
+
+	httpReq, _ := http.NewRequest(http.MethodPost, req.URL, nil)
+	provider.SetHeaders(httpReq)
+	if got := httpReq.Header.Get("Authorization"); got != "Bearer iam-token" {
+		t.Errorf("Authorization = %q, want bearer IAM token", got)
+	}
+
+	for _, options := range []map[string]any{
+		{"token": "iam-token"},
+		{"token": "iam-token", "project_id": "proj-1", "space_id": "space-1"},
+		{"project_id": "proj-1"},
+	} {
+		if _, err := providers.NewWatsonx(&config.ProviderConfig{Name: "watsonx", Options: options}); err == nil {
+			t.Errorf("options %v: expected error", options)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package providers_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+const geminiFunctionCall = `{
+  "candidates": [{
+    "content": {"role": "model", "parts": [
+      {"functionCall": {"name": "get_weather", "args": {"city": "Paris"}}},
+      {"functionCall": {"name": "get_time", "args": {"zone": "CET"}}}
+    ]},
+    "finishReason": "STOP",
+    "index": 0
+  }],
+  "usageMetadata": {"promptTokenCount": 30, "candidatesTokenCount": 10, "totalTokenCount": 40},
+  "modelVersion": "gemini-test"
+}`
+
+func TestGeminiContents_ToolRoundTrip(t *testing.T) {
+	resp, err := response.ParseGeminiTools([]byte(geminiFunctionCall))
+	if err != nil {
+		t.Fatalf("ParseGeminiTools failed: %v", err)
+	}
+
+	calls := resp.ToolCalls()
+	messages := []protocol.Message{
+		protocol.NewMessage("system", "Be brief."),
+		protocol.NewMessage("user", "Weather and time in Paris?"),
+		{Role: "assistant", ToolCalls: calls},
+		protocol.NewToolMessage(calls[0].ID, `{"temp": 18}`),
+		protocol.NewToolMessage(calls[1].ID, "14:00"),
+	}
+
+	system, contents, err := providers.GeminiContents(messages)
+	if err != nil {
+		t.Fatalf("GeminiContents failed: %v", err)
+	}
+
+	if system == nil || len(system.Parts) != 1 || system.Parts[0].Text != "Be brief." {
+		t.Errorf("got system instruction %+v", system)
+	}
+	if len(contents) != 3 {
+		t.Fatalf("got %d contents, want 3 (user, model, grouped responses)", len(contents))
+	}
+
+	model := contents[1]
+	if model.Role != "model" || len(model.Parts) != 2 {
+		t.Fatalf("got %+v, want a model turn with two function calls", model)
+	}
+	call := model.Parts[0].FunctionCall
+	if call == nil || call.Name != "get_weather" || call.ID != "" {
+		t.Errorf("got function call %+v, want get_weather without the synthesized ID", call)
+	}
+	var args map[string]string
+	if err := json.Unmarshal(call.Args, &args); err != nil || args["city"] != "Paris" {
+		t.Errorf("got args %s, want the original args", call.Args)
+	}
+
+	results := contents[2]
+	if results.Role != "user" || len(results.Parts) != 2 {
+		t.Fatalf("got %+v, want one user turn with both function responses", results)
+	}
+	first, second := results.Parts[0].FunctionResponse, results.Parts[1].FunctionResponse
+	if first == nil || first.Name != "get_weather" {
+		t.Fatalf("got %+v, want a get_weather response", first)
+	}
+	if got, _ := first.Response.(map[string]any); got["temp"] != float64(18) {
+		t.Errorf("got response %v, want the JSON object result", first.Response)
+	}
+	if second == nil || second.Name != "get_time" {
+		t.Fatalf("got %+v, want a get_time response", second)
+	}
+	if got, _ := second.Response.(map[string]any); got["result"] != "14:00" {
+		t.Errorf("got response %v, want the text wrapped as result", second.Response)
+	}
+}
+
+func TestGeminiContents_UnknownCall(t *testing.T) {
+	_, _, err := providers.GeminiContents([]protocol.Message{protocol.NewToolMessage("call_9", "done")})
+	if err == nil || !strings.Contains(err.Error(), "unknown call") {
+		t.Errorf("got error %v, want an unknown call error", err)
+	}
+}
+
+func TestMarshalGeminiTools(t *testing.T) {
+	data, err := providers.MarshalGeminiTools(&providers.ToolsData{
+		Model:    "gemini-test",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Weather in Paris?")},
+		Tools: []providers.ToolDefinition{{
+			Name:        "get_weather",
+			Description: "Current weather",
+			Parameters:  map[string]any{"type": "object"},
+		}},
+		Options: map[string]any{
+			"tool_choice": "required",
+			"temperature": 0.2,
+			"max_tokens":  256,
+			"stop":        "END",
+		},
+	})
+	if err != nil {
+		t.Fatalf("MarshalGeminiTools failed: %v", err)
+	}
+
+	var body struct {
+		Contents []providers.GeminiContent `json:"contents"`
+		Tools    []providers.GeminiTool    `json:"tools"`
+		Config   map[string]any            `json:"generationConfig"`
+		Tool     struct {
+			Calling map[string]any `json:"functionCallingConfig"`
+		} `json:"toolConfig"`
+		Temperature any `json:"temperature"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if len(body.Contents) != 1 || body.Contents[0].Parts[0].Text != "Weather in Paris?" {
+		t.Errorf("got contents %+v", body.Contents)
+	}
+	if len(body.Tools) != 1 || body.Tools[0].FunctionDeclarations[0].Name != "get_weather" {
+		t.Errorf("got tools %+v, want one functionDeclarations entry", body.Tools)
+	}
+	if body.Tool.Calling["mode"] != "ANY" {
+		t.Errorf("got toolConfig %v, want mode ANY", body.Tool.Calling)
+	}
+	if body.Config["temperature"] != 0.2 || body.Config["maxOutputTokens"] != float64(256) {
+		t.Errorf("got generationConfig %v, want temperature and maxOutputTokens", body.Config)
+	}
+	if stops, _ := body.Config["stopSequences"].([]any); len(stops) != 1 || stops[0] != "END" {
+		t.Errorf("got stopSequences %v, want [END]", body.Config["stopSequences"])
+	}
+	if body.Temperature != nil {
+		t.Error("temperature should be moved into generationConfig")
+	}
+}
+
+func TestMarshalGeminiTools_Images(t *testing.T) {
+	_, err := providers.MarshalGeminiTools(&providers.ToolsData{
+		Messages: []protocol.Message{protocol.NewMessage("user", "What is this?")},
+		Images:   []string{"https://example.com/cat.jpg"},
+	})
+	if err == nil {
+		t.Error("expected an error for images")
+	}
+}
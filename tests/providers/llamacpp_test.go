@@ -0,0 +1,193 @@
+package providers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/options"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func newTestLlamaCpp(t *testing.T, opts map[string]any) providers.Provider {
+	t.Helper()
+	provider, err := providers.Create(&config.ProviderConfig{
+		Name:    "llamacpp",
+		BaseURL: "http://localhost:8080",
+		Options: opts,
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	return provider
+}
+
+func TestLlamaCpp_Endpoint(t *testing.T) {
+	provider := newTestLlamaCpp(t, nil)
+
+	tests := []struct {
+		proto protocol.Protocol
+		want  string
+	}{
+		{protocol.Chat, "http://localhost:8080/v1/chat/completions"},
+		{protocol.Tools, "http://localhost:8080/v1/chat/completions"},
+		{protocol.Embeddings, "http://localhost:8080/v1/embeddings"},
+	}
+	for _, tt := range tests {
+		got, err := provider.Endpoint(tt.proto)
+		if err != nil {
+			t.Fatalf("Endpoint(%s) failed: %v", tt.proto, err)
+		}
+		if got != tt.want {
+			t.Errorf("Endpoint(%s) = %q, want %q", tt.proto, got, tt.want)
+		}
+	}
+}
+
+func TestLlamaCpp_Marshal_Constraints(t *testing.T) {
+	provider := newTestLlamaCpp(t, nil)
+	messages := []protocol.Message{protocol.NewMessage("user", "Answer yes or no")}
+
+	body, err := provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model:    "qwen2.5",
+		Messages: messages,
+		Options:  options.New(options.Grammar(`root ::= "yes" | "no"`)),
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result map[string]any
+	json.Unmarshal(body, &result)
+	if result["grammar"] != `root ::= "yes" | "no"` {
+		t.Errorf("grammar = %v", result["grammar"])
+	}
+
+	schema := map[string]any{"type": "object"}
+	body, err = provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model:    "qwen2.5",
+		Messages: messages,
+		Options:  options.New(options.JSONSchemaConstraint(schema)),
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	json.Unmarshal(body, &result)
+	if constraint, _ := result["json_schema"].(map[string]any); constraint["type"] != "object" {
+		t.Errorf("json_schema = %v", result["json_schema"])
+	}
+
+	_, err = provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model:    "qwen2.5",
+		Messages: messages,
+		Options:  options.New(options.Grammar("root ::= \"x\""), options.JSONSchemaConstraint(schema)),
+	})
+	if err == nil {
+		t.Error("expected error for grammar combined with json_schema")
+	}
+}
+
+func TestLlamaCpp_SetHeaders(t *testing.T) {
+	provider := newTestLlamaCpp(t, map[string]any{"token": "secret"})
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost:8080/v1/chat/completions", nil)
+	provider.SetHeaders(req)
+	if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+	}
+
+	provider = newTestLlamaCpp(t, nil)
+	req, _ = http.NewRequest(http.MethodPost, "http://localhost:8080/v1/chat/completions", nil)
+	provider.SetHeaders(req)
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization = %q, want none without a token", got)
+	}
+}
+
+func streamLlamaCpp(t *testing.T, stream string) []*response.StreamingChunk {
+	t.Helper()
+	provider := newTestLlamaCpp(t, nil)
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(stream)),
+	}
+
+	chunks, err := provider.ProcessStreamResponse(context.Background(), resp, protocol.Chat)
+	if err != nil {
+		t.Fatalf("ProcessStreamResponse failed: %v", err)
+	}
+
+	var received []*response.StreamingChunk
+	for data := range chunks {
+		received = append(received, data.(*response.StreamingChunk))
+	}
+	return received
+}
+
+func TestLlamaCpp_ProcessStreamResponse(t *testing.T) {
+	stream := "data: {\"model\":\"qwen2.5\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}\n\n" +
+		"data:{\"model\":\"qwen2.5\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"}}]}\n\n" +
+		"data: {\"model\":\"qwen2.5\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}],\"timings\":{\"predicted_n\":2}}\n\n" +
+		"data: [DONE]\n\n"
+
+	received := streamLlamaCpp(t, stream)
+	if len(received) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(received))
+	}
+
+	var content strings.Builder
+	for _, chunk := range received {
+		if chunk.Error != nil {
+			t.Fatalf("unexpected chunk error: %v", chunk.Error)
+		}
+		content.WriteString(chunk.Content())
+	}
+	if content.String() != "Hello" {
+		t.Errorf("content = %q, want %q", content.String(), "Hello")
+	}
+}
+
+func TestLlamaCpp_ProcessStreamResponse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		message string
+	}{
+		{
+			name:    "error event",
+			event:   "error: {\"code\":400,\"message\":\"failed to parse grammar\",\"type\":\"invalid_request_error\"}\n\n",
+			message: "failed to parse grammar",
+		},
+		{
+			name:    "data error object",
+			event:   "data: {\"error\":{\"code\":500,\"message\":\"context size exceeded\",\"type\":\"server_error\"}}\n\n",
+			message: "context size exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := "data: {\"model\":\"qwen2.5\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\n" +
+				tt.event +
+				"data: {\"model\":\"qwen2.5\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"ignored\"}}]}\n\n"
+
+			received := streamLlamaCpp(t, stream)
+			if len(received) != 2 {
+				t.Fatalf("got %d chunks, want 2 (the stream ends at the error)", len(received))
+			}
+
+			var llamaErr *providers.LlamaCppError
+			if !errors.As(received[1].Error, &llamaErr) {
+				t.Fatalf("got error %v, want *LlamaCppError", received[1].Error)
+			}
+			if llamaErr.Message != tt.message {
+				t.Errorf("message = %q, want %q", llamaErr.Message, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package providers_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+const malformedStream = "data: {\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hello\"}}]}\n\n" +
+	"data: {\"model\":\"m\",\"choices\":[{\n\n" +
+	"data: {\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\" there\"}}]}\n\n" +
+	"data: [DONE]\n\n"
+
+// streamMalformed processes malformedStream with Ollama under ctx, returning
+// the chunks and the malformed events reported to the hook.
+func streamMalformed(t *testing.T, ctx context.Context) ([]*response.StreamingChunk, []providers.MalformedChunk) {
+	t.Helper()
+
+	provider, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: "http://localhost:11434"})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	var reported []providers.MalformedChunk
+	ctx = providers.WithMalformedChunkHook(ctx, func(m providers.MalformedChunk) {
+		reported = append(reported, m)
+	})
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(malformedStream)),
+	}
+	chunks, err := provider.ProcessStreamResponse(ctx, resp, protocol.Chat)
+	if err != nil {
+		t.Fatalf("ProcessStreamResponse failed: %v", err)
+	}
+
+	var received []*response.StreamingChunk
+	for data := range chunks {
+		received = append(received, data.(*response.StreamingChunk))
+	}
+	return received, reported
+}
+
+func TestMalformedChunk_LenientSkips(t *testing.T) {
+	chunks, reported := streamMalformed(t, context.Background())
+
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+	for _, chunk := range chunks {
+		if chunk.Error != nil {
+			t.Errorf("unexpected chunk error: %v", chunk.Error)
+		}
+	}
+
+	if len(reported) != 1 {
+		t.Fatalf("got %d reported events, want 1", len(reported))
+	}
+	if reported[0].Surfaced {
+		t.Error("skipped event reported as surfaced")
+	}
+	if reported[0].Provider != "ollama" || reported[0].Protocol != protocol.Chat || reported[0].Err == nil {
+		t.Errorf("got event %+v", reported[0])
+	}
+}
+
+func TestMalformedChunk_StrictSurfaces(t *testing.T) {
+	ctx := response.WithParseMode(context.Background(), response.ParseStrict)
+	chunks, reported := streamMalformed(t, ctx)
+
+	if len(chunks) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(chunks))
+	}
+	if chunks[0].Content() != "Hello" || chunks[2].Content() != " there" {
+		t.Errorf("got contents %q and %q around the error", chunks[0].Content(), chunks[2].Content())
+	}
+
+	var malformed *response.MalformedChunkError
+	if !errors.As(chunks[1].Error, &malformed) {
+		t.Fatalf("got error %v, want a MalformedChunkError", chunks[1].Error)
+	}
+	if want := `{"model":"m","choices":[{`; string(malformed.Payload) != want {
+		t.Errorf("got payload %q, want %q", malformed.Payload, want)
+	}
+	if malformed.Err == nil {
+		t.Error("MalformedChunkError should wrap the parse error")
+	}
+
+	if len(reported) != 1 || !reported[0].Surfaced {
+		t.Errorf("got reported events %+v, want one surfaced event", reported)
+	}
+}
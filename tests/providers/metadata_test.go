@@ -0,0 +1,92 @@
+package providers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func TestMetadataForwarder_Apply(t *testing.T) {
+	ctx := providers.WithMetadata(context.Background(), map[string]string{"tenant": "acme", "user": "u-1"})
+
+	tests := []struct {
+		name       string
+		options    map[string]any
+		body       string
+		wantUser   any
+		wantHeader string
+	}{
+		{
+			name:    "unconfigured",
+			options: nil,
+			body:    `{"model":"m"}`,
+		},
+		{
+			name:       "user and headers",
+			options:    map[string]any{"metadata_user": "user", "metadata_headers": map[string]any{"tenant": "X-Tenant-ID"}},
+			body:       `{"model":"m"}`,
+			wantUser:   "u-1",
+			wantHeader: "acme",
+		},
+		{
+			name:     "existing user kept",
+			options:  map[string]any{"metadata_user": "tenant"},
+			body:     `{"model":"m","user":"explicit"}`,
+			wantUser: "explicit",
+		},
+		{
+			name:       "string header map",
+			options:    map[string]any{"metadata_headers": map[string]string{"tenant": "X-Tenant-ID"}},
+			body:       `{"model":"m"}`,
+			wantHeader: "acme",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := providers.NewMetadataForwarder(tt.options)
+			if err != nil {
+				t.Fatalf("NewMetadataForwarder failed: %v", err)
+			}
+
+			original := map[string]string{"Content-Type": "application/json"}
+			body, headers, err := f.Apply(ctx, []byte(tt.body), original)
+			if err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+
+			var fields map[string]any
+			json.Unmarshal(body, &fields)
+			if fields["user"] != tt.wantUser {
+				t.Errorf("got user %v, want %v", fields["user"], tt.wantUser)
+			}
+			if headers["X-Tenant-ID"] != tt.wantHeader {
+				t.Errorf("got header %q, want %q", headers["X-Tenant-ID"], tt.wantHeader)
+			}
+			if _, ok := original["X-Tenant-ID"]; ok {
+				t.Error("Apply modified the input headers")
+			}
+		})
+	}
+}
+
+func TestNewMetadataForwarder_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+	}{
+		{name: "user not string", options: map[string]any{"metadata_user": 1}},
+		{name: "headers not object", options: map[string]any{"metadata_headers": "X-Tenant"}},
+		{name: "header name not string", options: map[string]any{"metadata_headers": map[string]any{"tenant": 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := providers.NewMetadataForwarder(tt.options); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
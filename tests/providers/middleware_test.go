@@ -0,0 +1,159 @@
+package providers_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// tracing returns a middleware that records name in calls around each step.
+func tracing(name string, calls *[]string) providers.Middleware {
+	return providers.Middleware{
+		Prepare: func(next providers.PrepareFunc) providers.PrepareFunc {
+			return func(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*providers.Request, error) {
+				*calls = append(*calls, name+" prepare")
+				req, err := next(ctx, proto, body, headers)
+				*calls = append(*calls, name+" prepared")
+				return req, err
+			}
+		},
+	}
+}
+
+func newMiddlewareOllama(t *testing.T) *providers.OllamaProvider {
+	t.Helper()
+
+	p, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: "http://localhost:11434"})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	return p.(*providers.OllamaProvider)
+}
+
+func TestMiddleware_PrepareOrder(t *testing.T) {
+	p := newMiddlewareOllama(t)
+
+	var calls []string
+	p.Use(tracing("outer", &calls), tracing("inner", &calls), providers.Middleware{})
+
+	if _, err := providers.Prepare(context.Background(), p, protocol.Chat, []byte(`{}`), nil, false); err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+
+	want := []string{"outer prepare", "inner prepare", "inner prepared", "outer prepared"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %v, want %v", calls, want)
+	}
+}
+
+func TestMiddleware_PrepareRewrite(t *testing.T) {
+	p := newMiddlewareOllama(t)
+
+	var streams []bool
+	p.Use(providers.Middleware{
+		Prepare: func(next providers.PrepareFunc) providers.PrepareFunc {
+			return func(ctx context.Context, proto protocol.Protocol, body []byte, headers map[string]string) (*providers.Request, error) {
+				streams = append(streams, providers.IsStream(ctx))
+				return next(ctx, proto, []byte(strings.ReplaceAll(string(body), "max_tokens", "num_predict")), headers)
+			}
+		},
+	})
+
+	req, err := providers.Prepare(context.Background(), p, protocol.Chat, []byte(`{"max_tokens":5}`), nil, false)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if string(req.Body) != `{"num_predict":5}` {
+		t.Errorf("got body %s, want the rewritten body", req.Body)
+	}
+
+	streamReq, err := providers.Prepare(context.Background(), p, protocol.Chat, []byte(`{}`), nil, true)
+	if err != nil {
+		t.Fatalf("Prepare stream failed: %v", err)
+	}
+	if streamReq.Headers["Accept"] != "text/event-stream" {
+		t.Errorf("got Accept %q, want the streaming request", streamReq.Headers["Accept"])
+	}
+	if !reflect.DeepEqual(streams, []bool{false, true}) {
+		t.Errorf("got IsStream %v, want [false true]", streams)
+	}
+}
+
+func TestMiddleware_Process(t *testing.T) {
+	p := newMiddlewareOllama(t)
+
+	// Unwrap a gateway envelope before the provider parses the response
+	p.Use(providers.Middleware{
+		Process: func(next providers.ProcessFunc) providers.ProcessFunc {
+			return func(ctx context.Context, resp *http.Response, proto protocol.Protocol) (any, error) {
+				body, _ := io.ReadAll(resp.Body)
+				inner := strings.TrimSuffix(strings.TrimPrefix(string(body), `{"result":`), "}")
+				resp.Body = io.NopCloser(strings.NewReader(inner))
+				return next(ctx, resp, proto)
+			}
+		},
+	})
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"result":{"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}}`)),
+	}
+	result, err := providers.Process(context.Background(), p, resp, protocol.Chat)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if chat, ok := result.(*response.ChatResponse); !ok || chat.Content() != "Hi" {
+		t.Errorf("got %+v, want the unwrapped chat response", result)
+	}
+}
+
+func TestMiddleware_ProcessStream(t *testing.T) {
+	p := newMiddlewareOllama(t)
+
+	p.Use(providers.Middleware{
+		ProcessStream: func(next providers.ProcessStreamFunc) providers.ProcessStreamFunc {
+			return func(ctx context.Context, resp *http.Response, proto protocol.Protocol) (<-chan any, error) {
+				chunks, err := next(ctx, resp, proto)
+				if err != nil {
+					return nil, err
+				}
+				out := make(chan any)
+				go func() {
+					defer close(out)
+					for data := range chunks {
+						if chunk, ok := data.(*response.StreamingChunk); ok && len(chunk.Choices) > 0 {
+							chunk.Choices[0].Delta.Content = strings.ToUpper(chunk.Choices[0].Delta.Content)
+						}
+						out <- data
+					}
+				}()
+				return out, nil
+			}
+		},
+	})
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"hi\"}}]}\n\ndata: [DONE]\n\n")),
+	}
+	chunks, err := providers.ProcessStream(context.Background(), p, resp, protocol.Chat)
+	if err != nil {
+		t.Fatalf("ProcessStream failed: %v", err)
+	}
+
+	var content string
+	for data := range chunks {
+		content += data.(*response.StreamingChunk).Content()
+	}
+	if content != "HI" {
+		t.Errorf("got content %q, want %q", content, "HI")
+	}
+}
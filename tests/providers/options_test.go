@@ -0,0 +1,254 @@
+package providers_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func TestStopOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		key     string
+		want    []string
+	}{
+		{
+			name:    "anthropic array",
+			options: map[string]any{"stop": []string{"END", "STOP"}},
+			key:     providers.StopKeyAnthropic,
+			want:    []string{"END", "STOP"},
+		},
+		{
+			name:    "gemini string",
+			options: map[string]any{"stop": "END"},
+			key:     providers.StopKeyGemini,
+			want:    []string{"END"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := providers.StopOption(tt.options, tt.key)
+
+			if _, ok := result["stop"]; ok {
+				t.Error("stop key was not removed")
+			}
+
+			got, ok := result[tt.key].([]string)
+			if !ok || !slices.Equal(got, tt.want) {
+				t.Errorf("got %s %v, want %v", tt.key, result[tt.key], tt.want)
+			}
+
+			if _, ok := tt.options["stop"]; !ok {
+				t.Error("original options were modified")
+			}
+		})
+	}
+}
+
+func TestStopOption_Unchanged(t *testing.T) {
+	options := map[string]any{"stop": "END", "temperature": 0.5}
+
+	result := providers.StopOption(options, providers.StopKeyOpenAI)
+	if result["stop"] != "END" {
+		t.Errorf("got stop %v, want END", result["stop"])
+	}
+
+	without := providers.StopOption(map[string]any{"temperature": 0.5}, providers.StopKeyAnthropic)
+	if _, ok := without[providers.StopKeyAnthropic]; ok {
+		t.Error("stop_sequences set without a stop option")
+	}
+}
+
+func TestGenerationConfigOption(t *testing.T) {
+	schema := map[string]any{"type": "object"}
+
+	tests := []struct {
+		name       string
+		format     map[string]any
+		wantSchema bool
+	}{
+		{
+			name:   "json object",
+			format: map[string]any{"type": "json_object"},
+		},
+		{
+			name: "json schema",
+			format: map[string]any{
+				"type":        "json_schema",
+				"json_schema": map[string]any{"name": "reply", "schema": schema},
+			},
+			wantSchema: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := map[string]any{
+				"response_format":  tt.format,
+				"generationConfig": map[string]any{"temperature": 0.2},
+			}
+
+			result := providers.GenerationConfigOption(options)
+
+			if _, ok := result["response_format"]; ok {
+				t.Error("response_format was not removed")
+			}
+
+			config, ok := result["generationConfig"].(map[string]any)
+			if !ok {
+				t.Fatalf("got generationConfig %T, want map[string]any", result["generationConfig"])
+			}
+
+			if config["responseMimeType"] != "application/json" {
+				t.Errorf("got responseMimeType %v, want application/json", config["responseMimeType"])
+			}
+
+			if config["temperature"] != 0.2 {
+				t.Errorf("got temperature %v, want existing value preserved", config["temperature"])
+			}
+
+			if _, ok := config["responseSchema"]; ok != tt.wantSchema {
+				t.Errorf("got responseSchema present %v, want %v", ok, tt.wantSchema)
+			}
+
+			if _, ok := options["response_format"]; !ok {
+				t.Error("original options were modified")
+			}
+		})
+	}
+}
+
+func TestGenerationConfigOption_Text(t *testing.T) {
+	options := map[string]any{"response_format": map[string]any{"type": "text"}}
+
+	result := providers.GenerationConfigOption(options)
+	if _, ok := result["generationConfig"]; ok {
+		t.Error("generationConfig set for text response format")
+	}
+}
+
+func TestParseToolChoice(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		wantMode string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "auto", value: "auto", wantMode: providers.ToolChoiceAuto},
+		{name: "required", value: "required", wantMode: providers.ToolChoiceRequired},
+		{
+			name:     "function",
+			value:    map[string]any{"type": "function", "function": map[string]any{"name": "get_weather"}},
+			wantMode: providers.ToolChoiceFunction,
+			wantName: "get_weather",
+		},
+		{name: "unknown mode", value: "always", wantErr: true},
+		{name: "function without name", value: map[string]any{"type": "function"}, wantErr: true},
+		{name: "wrong type", value: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, name, err := providers.ParseToolChoice(tt.value)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseToolChoice failed: %v", err)
+			}
+
+			if mode != tt.wantMode || name != tt.wantName {
+				t.Errorf("got (%q, %q), want (%q, %q)", mode, name, tt.wantMode, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestAnthropicToolChoiceOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		want    map[string]any
+	}{
+		{
+			name:    "required",
+			options: map[string]any{"tool_choice": "required"},
+			want:    map[string]any{"type": "any"},
+		},
+		{
+			name: "function without parallel calls",
+			options: map[string]any{
+				"tool_choice":         map[string]any{"type": "function", "function": map[string]any{"name": "get_weather"}},
+				"parallel_tool_calls": false,
+			},
+			want: map[string]any{"type": "tool", "name": "get_weather", "disable_parallel_tool_use": true},
+		},
+		{
+			name:    "parallel calls only",
+			options: map[string]any{"parallel_tool_calls": false},
+			want:    map[string]any{"type": "auto", "disable_parallel_tool_use": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := providers.AnthropicToolChoiceOption(tt.options)
+			if err != nil {
+				t.Fatalf("AnthropicToolChoiceOption failed: %v", err)
+			}
+
+			if _, ok := result["parallel_tool_calls"]; ok {
+				t.Error("parallel_tool_calls was not removed")
+			}
+
+			choice, ok := result["tool_choice"].(map[string]any)
+			if !ok || !maps.Equal(choice, tt.want) {
+				t.Errorf("got tool_choice %v, want %v", result["tool_choice"], tt.want)
+			}
+		})
+	}
+}
+
+func TestGeminiToolConfigOption(t *testing.T) {
+	result, err := providers.GeminiToolConfigOption(map[string]any{
+		"tool_choice":         map[string]any{"type": "function", "function": map[string]any{"name": "get_weather"}},
+		"parallel_tool_calls": true,
+	})
+	if err != nil {
+		t.Fatalf("GeminiToolConfigOption failed: %v", err)
+	}
+
+	for _, key := range []string{"tool_choice", "parallel_tool_calls"} {
+		if _, ok := result[key]; ok {
+			t.Errorf("%s was not removed", key)
+		}
+	}
+
+	toolConfig, _ := result["toolConfig"].(map[string]any)
+	config, ok := toolConfig["functionCallingConfig"].(map[string]any)
+	if !ok {
+		t.Fatalf("got toolConfig %v, want functionCallingConfig", result["toolConfig"])
+	}
+
+	if config["mode"] != "ANY" {
+		t.Errorf("got mode %v, want ANY", config["mode"])
+	}
+
+	if names, _ := config["allowedFunctionNames"].([]string); !slices.Equal(names, []string{"get_weather"}) {
+		t.Errorf("got allowedFunctionNames %v, want [get_weather]", config["allowedFunctionNames"])
+	}
+
+	if _, err := providers.GeminiToolConfigOption(map[string]any{"tool_choice": "always"}); err == nil {
+		t.Error("expected error for invalid tool_choice, got nil")
+	}
+}
@@ -0,0 +1,130 @@
+package providers_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func roles(messages []protocol.Message) []string {
+	out := make([]string, len(messages))
+	for i, msg := range messages {
+		out[i] = msg.Role
+	}
+	return out
+}
+
+func TestRolePolicy_Apply(t *testing.T) {
+	messages := []protocol.Message{
+		protocol.NewMessage("system", "You are terse."),
+		protocol.NewMessage("developer", "Answer in French."),
+		protocol.NewMessage("user", "Hello"),
+		protocol.NewMessage("system", "Never use emoji."),
+	}
+
+	tests := []struct {
+		name   string
+		policy providers.RolePolicy
+		roles  []string
+		first  string
+	}{
+		{
+			name:   "downgrade developer",
+			policy: providers.RolePolicy{},
+			roles:  []string{"system", "system", "user", "system"},
+			first:  "You are terse.",
+		},
+		{
+			name:   "native",
+			policy: providers.RolePolicy{DeveloperRole: true},
+			roles:  []string{"system", "developer", "user", "system"},
+			first:  "You are terse.",
+		},
+		{
+			name:   "merge",
+			policy: providers.RolePolicy{MergeSystem: true},
+			roles:  []string{"system", "user"},
+			first:  "You are terse.\n\nAnswer in French.\n\nNever use emoji.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.policy.Apply(messages)
+			if got := roles(result); !slices.Equal(got, tt.roles) {
+				t.Errorf("got roles %v, want %v", got, tt.roles)
+			}
+			if result[0].Text() != tt.first {
+				t.Errorf("got first message %q, want %q", result[0].Text(), tt.first)
+			}
+		})
+	}
+
+	if messages[1].Role != "developer" {
+		t.Error("Apply modified its input")
+	}
+}
+
+func TestRolePolicy_MergeKeepsDeveloper(t *testing.T) {
+	policy := providers.RolePolicy{DeveloperRole: true, MergeSystem: true}
+	result := policy.Apply([]protocol.Message{
+		protocol.NewMessage("developer", "One."),
+		protocol.NewMessage("developer", "Two."),
+		protocol.NewMessage("user", "Hi"),
+	})
+
+	if got := roles(result); !slices.Equal(got, []string{"developer", "user"}) {
+		t.Errorf("got roles %v, want [developer user]", got)
+	}
+}
+
+func TestNewRolePolicy(t *testing.T) {
+	policy, err := providers.NewRolePolicy(map[string]any{"developer_role": true, "merge_system_messages": "true"})
+	if err != nil {
+		t.Fatalf("NewRolePolicy failed: %v", err)
+	}
+	if !policy.DeveloperRole || !policy.MergeSystem {
+		t.Errorf("got %+v, want both enabled", policy)
+	}
+
+	if _, err := providers.NewRolePolicy(map[string]any{"developer_role": 1}); err == nil {
+		t.Error("expected an error for a non-boolean option")
+	}
+}
+
+func TestOllama_RoleOptions(t *testing.T) {
+	provider, err := providers.NewOllama(&config.ProviderConfig{
+		Name:    "ollama",
+		BaseURL: "http://localhost:11434",
+		Options: map[string]any{"merge_system_messages": true},
+	})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	body, err := provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model: "llama3",
+		Messages: []protocol.Message{
+			protocol.NewMessage("developer", "Be brief."),
+			protocol.NewMessage("system", "Use metric units."),
+			protocol.NewMessage("user", "Hi"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var result struct {
+		Messages []protocol.Message `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got := roles(result.Messages); !slices.Equal(got, []string{"system", "user"}) {
+		t.Errorf("got roles %v, want [system user]", got)
+	}
+}
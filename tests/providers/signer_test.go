@@ -0,0 +1,116 @@
+package providers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func TestNewSigner_Unset(t *testing.T) {
+	signer, err := providers.NewSigner(map[string]any{})
+	if err != nil || signer != nil {
+		t.Errorf("got %v, %v, want no signer", signer, err)
+	}
+}
+
+func TestNewSigner_HMAC(t *testing.T) {
+	signer, err := providers.NewSigner(map[string]any{
+		"signing": map[string]any{"type": "hmac-sha256", "key": "secret", "header": "X-Sig"},
+	})
+	if err != nil {
+		t.Fatalf("NewSigner failed: %v", err)
+	}
+
+	body := []byte(`{"model":"m"}`)
+	req, _ := http.NewRequest(http.MethodPost, "https://gateway.example.com/llm/chat?tenant=a", nil)
+	if err := signer.Sign(req, body); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	timestamp := req.Header.Get("X-Signature-Timestamp")
+	if timestamp == "" {
+		t.Fatal("missing timestamp header")
+	}
+	want := providers.HMACSignature([]byte("secret"), http.MethodPost, "/llm/chat?tenant=a", timestamp, body)
+	if got := req.Header.Get("X-Sig"); got != want {
+		t.Errorf("got signature %q, want %q", got, want)
+	}
+
+	if other := providers.HMACSignature([]byte("secret"), http.MethodPost, "/llm/chat?tenant=a", timestamp, []byte("{}")); other == want {
+		t.Error("signature does not cover the body")
+	}
+}
+
+func TestNewSigner_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		signing any
+		want    string
+	}{
+		{"not an object", "hmac", "must be an object"},
+		{"unsupported type", map[string]any{"type": "rsa", "key": "k"}, "unsupported signing type"},
+		{"missing key", map[string]any{"type": "hmac-sha256"}, "key is required"},
+		{"wrong header type", map[string]any{"type": "hmac-sha256", "key": "k", "header": 1}, "header must be a string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := providers.NewSigner(map[string]any{"signing": tt.signing})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSign_ProviderOptions(t *testing.T) {
+	p, err := providers.Create(&config.ProviderConfig{
+		Name:    "ollama",
+		BaseURL: "http://localhost:11434",
+		Options: map[string]any{"signing": map[string]any{"type": "hmac-sha256", "key": "secret"}},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost:11434/v1/chat/completions", nil)
+	p.SetHeaders(req)
+	if err := providers.Sign(p, req, []byte("{}")); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if req.Header.Get("X-Signature") == "" {
+		t.Error("request was not signed")
+	}
+
+	_, err = providers.Create(&config.ProviderConfig{
+		Name:    "xai",
+		Options: map[string]any{"token": "t", "signing": map[string]any{"type": "hmac-sha256"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "invalid xAI signing options") {
+		t.Errorf("got error %v, want invalid xAI signing options", err)
+	}
+}
+
+func TestSign_SetSigner(t *testing.T) {
+	p, err := providers.NewOllama(&config.ProviderConfig{Name: "ollama", BaseURL: "http://localhost:11434"})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost:11434/v1/chat/completions", nil)
+	if err := providers.Sign(p, req, nil); err != nil {
+		t.Fatalf("Sign without a signer failed: %v", err)
+	}
+
+	signErr := errors.New("no credentials")
+	p.(*providers.OllamaProvider).SetSigner(providers.SignerFunc(func(req *http.Request, body []byte) error {
+		return signErr
+	}))
+	if err := providers.Sign(p, req, nil); !errors.Is(err, signErr) {
+		t.Errorf("got %v, want %v", err, signErr)
+	}
+}
@@ -0,0 +1,150 @@
+package providers_test
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func TestNewTogether(t *testing.T) {
+	provider, err := providers.Create(&config.ProviderConfig{
+		Name:    "together",
+		Options: map[string]any{"token": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	endpoint, err := provider.Endpoint(protocol.Embeddings)
+	if err != nil {
+		t.Fatalf("Endpoint failed: %v", err)
+	}
+	if want := providers.TogetherDefaultBaseURL + "/embeddings"; endpoint != want {
+		t.Errorf("Endpoint = %q, want %q", endpoint, want)
+	}
+
+	body, err := provider.Marshal(protocol.Chat, &providers.ChatData{Model: "meta-llama/Llama-3.3-70B-Instruct-Turbo"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result map[string]any
+	json.Unmarshal(body, &result)
+	if result["model"] != "meta-llama/Llama-3.3-70B-Instruct-Turbo" {
+		t.Errorf("model = %v, want the name unchanged", result["model"])
+	}
+
+	if _, err := providers.NewTogether(&config.ProviderConfig{Name: "together"}); err == nil {
+		t.Error("expected error without a token")
+	}
+}
+
+func TestNewFireworks_ModelNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		model   string
+		want    string
+	}{
+		{"short name", nil, "llama-v3p1-8b-instruct", "accounts/fireworks/models/llama-v3p1-8b-instruct"},
+		{"account option", map[string]any{"account": "acme"}, "tuned-model", "accounts/acme/models/tuned-model"},
+		{"resource path", nil, "accounts/acme/models/tuned-model", "accounts/acme/models/tuned-model"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := map[string]any{"token": "secret"}
+			maps.Copy(options, tt.options)
+			provider, err := providers.Create(&config.ProviderConfig{Name: "fireworks", Options: options})
+			if err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+
+			data := &providers.ChatData{Model: tt.model}
+			body, err := provider.Marshal(protocol.Chat, data)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var result map[string]any
+			json.Unmarshal(body, &result)
+			if result["model"] != tt.want {
+				t.Errorf("model = %v, want %q", result["model"], tt.want)
+			}
+			if data.Model != tt.model {
+				t.Errorf("request data modified: model = %q", data.Model)
+			}
+		})
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	tests := []struct {
+		provider string
+		headers  map[string]string
+		want     providers.RateLimits
+	}{
+		{
+			provider: "together",
+			headers: map[string]string{
+				"X-Ratelimit-Limit":            "60",
+				"X-Ratelimit-Remaining":        "59",
+				"X-Ratelimit-Reset":            "1",
+				"X-Ratelimit-Limit-Tokens":     "180000",
+				"X-Ratelimit-Remaining-Tokens": "179000",
+			},
+			want: providers.RateLimits{
+				LimitRequests:     60,
+				RemainingRequests: 59,
+				ResetRequests:     time.Second,
+				LimitTokens:       180000,
+				RemainingTokens:   179000,
+			},
+		},
+		{
+			provider: "fireworks",
+			headers: map[string]string{
+				"X-Ratelimit-Limit-Requests":          "600",
+				"X-Ratelimit-Remaining-Requests":      "598",
+				"X-Ratelimit-Limit-Tokens-Prompt":     "60000",
+				"X-Ratelimit-Remaining-Tokens-Prompt": "59500",
+			},
+			want: providers.RateLimits{
+				LimitRequests:     600,
+				RemainingRequests: 598,
+				LimitTokens:       60000,
+				RemainingTokens:   59500,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			provider, err := providers.Create(&config.ProviderConfig{Name: tt.provider, Options: map[string]any{"token": "secret"}})
+			if err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			reporter, ok := provider.(providers.RateLimitReporter)
+			if !ok {
+				t.Fatalf("%s does not implement RateLimitReporter", tt.provider)
+			}
+
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			got, ok := reporter.RateLimits(h)
+			if !ok || got != tt.want {
+				t.Errorf("RateLimits = %+v, %v, want %+v", got, ok, tt.want)
+			}
+
+			if _, ok := reporter.RateLimits(http.Header{}); ok {
+				t.Error("RateLimits reported limits without headers")
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func TestTokenSource_Inline(t *testing.T) {
+	source, err := providers.NewTokenSource(map[string]any{"token": "inline", "token_file": "/does/not/exist"})
+	if err != nil {
+		t.Fatalf("NewTokenSource failed: %v", err)
+	}
+
+	token, err := source.Token(context.Background())
+	if err != nil || token != "inline" {
+		t.Errorf("got token %q, err %v, want inline token to take precedence", token, err)
+	}
+}
+
+func TestTokenSource_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("file-secret\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+
+	source, err := providers.NewTokenSource(map[string]any{"token_file": path})
+	if err != nil {
+		t.Fatalf("NewTokenSource failed: %v", err)
+	}
+
+	token, _ := source.Token(context.Background())
+	if token != "file-secret" {
+		t.Errorf("got token %q, want %q", token, "file-secret")
+	}
+}
+
+func TestTokenSource_Refresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+
+	source, err := providers.NewTokenSource(map[string]any{
+		"token_file":    path,
+		"token_refresh": "10ms",
+	})
+	if err != nil {
+		t.Fatalf("NewTokenSource failed: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("rotated"), 0600); err != nil {
+		t.Fatalf("failed to rotate token file: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	token, err := source.Token(context.Background())
+	if err != nil || token != "rotated" {
+		t.Errorf("got token %q, err %v, want rotated token", token, err)
+	}
+
+	// Failed refreshes keep the last good token.
+	os.Remove(path)
+	time.Sleep(20 * time.Millisecond)
+
+	token, err = source.Token(context.Background())
+	if err == nil {
+		t.Error("expected refresh error for missing file")
+	}
+	if token != "rotated" {
+		t.Errorf("got token %q, want last good token", token)
+	}
+}
+
+func TestTokenSource_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("token_command test uses a POSIX shell")
+	}
+
+	source, err := providers.NewTokenSource(map[string]any{"token_command": "echo command-secret"})
+	if err != nil {
+		t.Fatalf("NewTokenSource failed: %v", err)
+	}
+
+	token, _ := source.Token(context.Background())
+	if token != "command-secret" {
+		t.Errorf("got token %q, want %q", token, "command-secret")
+	}
+}
+
+func TestTokenSource_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+	}{
+		{name: "missing file", options: map[string]any{"token_file": "/does/not/exist"}},
+		{name: "failing command", options: map[string]any{"token_command": "exit 1"}},
+		{name: "empty command output", options: map[string]any{"token_command": "true"}},
+		{name: "wrong type", options: map[string]any{"token_file": 42}},
+		{name: "invalid refresh", options: map[string]any{"token": "", "token_file": "x", "token_refresh": "often"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := providers.NewTokenSource(tt.options); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestNewAzure_TokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "azure-key")
+	if err := os.WriteFile(path, []byte("file-key"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+
+	cfg := &config.ProviderConfig{
+		Name:    "azure",
+		BaseURL: "https://test.openai.azure.com/openai",
+		Options: map[string]any{
+			"deployment":  "gpt-4",
+			"auth_type":   "api_key",
+			"token_file":  path,
+			"api_version": "2024-02-01",
+		},
+	}
+
+	provider, err := providers.NewAzure(cfg)
+	if err != nil {
+		t.Fatalf("NewAzure failed: %v", err)
+	}
+
+	req, _ := http.NewRequest("POST", "https://test.openai.azure.com", nil)
+	provider.SetHeaders(req)
+
+	if req.Header.Get("api-key") != "file-key" {
+		t.Errorf("got api-key %q, want %q", req.Header.Get("api-key"), "file-key")
+	}
+
+	if _, exists := cfg.Options["token"]; exists {
+		t.Error("resolved token written back into config options")
+	}
+}
+
+func TestPrepareRequest_TokenRefreshError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	options := map[string]any{"token_file": path, "token_refresh": "10ms", "auth_type": "bearer"}
+
+	tests := []struct {
+		name   string
+		create func(*config.ProviderConfig) (providers.Provider, error)
+	}{
+		{name: "ollama", create: providers.NewOllama},
+		{name: "llamacpp", create: providers.NewLlamaCpp},
+		{name: "together", create: providers.NewTogether},
+	}
+
+	created := make([]providers.Provider, len(tests))
+	for i, tt := range tests {
+		provider, err := tt.create(&config.ProviderConfig{Name: tt.name, BaseURL: "http://localhost:8080", Options: options})
+		if err != nil {
+			t.Fatalf("creating %s failed: %v", tt.name, err)
+		}
+		created[i] = provider
+	}
+
+	os.Remove(path)
+	time.Sleep(20 * time.Millisecond)
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := created[i].PrepareRequest(context.Background(), protocol.Chat, []byte(`{}`), map[string]string{}); err == nil {
+				t.Error("expected token refresh error from PrepareRequest")
+			}
+			if _, err := created[i].PrepareStreamRequest(context.Background(), protocol.Chat, []byte(`{}`), map[string]string{}); err == nil {
+				t.Error("expected token refresh error from PrepareStreamRequest")
+			}
+		})
+	}
+}
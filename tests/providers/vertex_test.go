@@ -0,0 +1,275 @@
+package providers_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func newTestVertex(t *testing.T, options map[string]any) providers.Provider {
+	t.Helper()
+	opts := map[string]any{"project": "my-project", "token": "access-token"}
+	maps.Copy(opts, options)
+	provider, err := providers.Create(&config.ProviderConfig{Name: "vertex", Options: opts})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	return provider
+}
+
+func TestNewVertex_MissingProject(t *testing.T) {
+	if _, err := providers.NewVertex(&config.ProviderConfig{Name: "vertex", Options: map[string]any{"token": "t"}}); err == nil {
+		t.Error("expected error without a project")
+	}
+}
+
+func TestVertex_Endpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  map[string]any
+		proto    protocol.Protocol
+		expected string
+	}{
+		{
+			name:     "default region chat",
+			proto:    protocol.Chat,
+			expected: "https://us-central1-aiplatform.googleapis.com/v1/projects/my-project/locations/us-central1/endpoints/openapi/chat/completions",
+		},
+		{
+			name:     "regional tools",
+			options:  map[string]any{"location": "europe-west4"},
+			proto:    protocol.Tools,
+			expected: "https://europe-west4-aiplatform.googleapis.com/v1/projects/my-project/locations/europe-west4/publishers/google/models",
+		},
+		{
+			name:     "global publisher",
+			options:  map[string]any{"location": "global", "publisher": "anthropic"},
+			proto:    protocol.Tools,
+			expected: "https://aiplatform.googleapis.com/v1/projects/my-project/locations/global/publishers/anthropic/models",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, err := newTestVertex(t, tt.options).Endpoint(tt.proto)
+			if err != nil {
+				t.Fatalf("Endpoint failed: %v", err)
+			}
+			if endpoint != tt.expected {
+				t.Errorf("Endpoint = %q, want %q", endpoint, tt.expected)
+			}
+		})
+	}
+
+	if _, err := newTestVertex(t, nil).Endpoint(protocol.Embeddings); err == nil {
+		t.Error("expected error for embeddings")
+	}
+}
+
+func TestVertex_Chat_OpenAICompatible(t *testing.T) {
+	provider := newTestVertex(t, nil)
+
+	body, err := provider.Marshal(protocol.Chat, &providers.ChatData{
+		Model:    "gemini-2.0-flash",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Hello")},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var result map[string]any
+	json.Unmarshal(body, &result)
+	if result["model"] != "google/gemini-2.0-flash" {
+		t.Errorf("model = %v, want google/gemini-2.0-flash", result["model"])
+	}
+
+	if _, err := provider.PrepareStreamRequest(context.Background(), protocol.Chat, body, map[string]string{}); err != nil {
+		t.Errorf("PrepareStreamRequest failed: %v", err)
+	}
+}
+
+func TestVertex_Tools_Gemini(t *testing.T) {
+	provider := newTestVertex(t, nil)
+
+	body, err := provider.Marshal(protocol.Tools, &providers.ToolsData{
+		Model:    "gemini-2.0-flash",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Weather in Paris?")},
+		Tools:    []providers.ToolDefinition{{Name: "get_weather", Description: "Get weather"}},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	req, err := provider.PrepareRequest(context.Background(), protocol.Tools, body, map[string]string{})
+	if err != nil {
+		t.Fatalf("PrepareRequest failed: %v", err)
+	}
+	want := "https://us-central1-aiplatform.googleapis.com/v1/projects/my-project/locations/us-central1/publishers/google/models/gemini-2.0-flash:generateContent"
+	if req.URL != want {
+		t.Errorf("URL = %q, want %q", req.URL, want)
+	}
+
+	var sent map[string]any
+	json.Unmarshal(req.Body, &sent)
+	if _, ok := sent["model"]; ok {
+		t.Errorf("body still names the model: %s", req.Body)
+	}
+	if sent["contents"] == nil || sent["tools"] == nil {
+		t.Errorf("body = %s, want Gemini contents and tools", req.Body)
+	}
+
+	if _, err := provider.PrepareStreamRequest(context.Background(), protocol.Tools, body, map[string]string{}); err == nil {
+		t.Error("expected error streaming Gemini tools requests")
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`{
+			"candidates": [{"content": {"role": "model", "parts": [{"functionCall": {"name": "get_weather", "args": {"city": "Paris"}}}]}, "finishReason": "STOP"}]
+		}`)),
+	}
+	result, err := provider.ProcessResponse(context.Background(), resp, protocol.Tools)
+	if err != nil {
+		t.Fatalf("ProcessResponse failed: %v", err)
+	}
+	if calls := result.(*response.ToolsResponse).Choices[0].Message.ToolCalls; len(calls) != 1 || calls[0].Function.Name != "get_weather" {
+		t.Errorf("tool calls = %+v", calls)
+	}
+}
+
+func TestVertex_ExtraBody(t *testing.T) {
+	provider := newTestVertex(t, nil)
+
+	body, err := provider.Marshal(protocol.Tools, &providers.ToolsData{
+		Model:    "gemini-2.0-flash",
+		Messages: []protocol.Message{protocol.NewMessage("user", "Weather in Paris?")},
+		Tools:    []providers.ToolDefinition{{Name: "get_weather", Description: "Get weather"}},
+		Options: map[string]any{providers.ExtraBodyKey: map[string]any{
+			"labels": map[string]any{"team": "search"},
+			"model":  "ignored",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	req, err := provider.PrepareRequest(context.Background(), protocol.Tools, body, map[string]string{})
+	if err != nil {
+		t.Fatalf("PrepareRequest failed: %v", err)
+	}
+	if !strings.Contains(req.URL, "/models/gemini-2.0-flash:generateContent") {
+		t.Errorf("URL = %q, want the request model", req.URL)
+	}
+
+	var sent map[string]any
+	json.Unmarshal(req.Body, &sent)
+	if sent["labels"] == nil {
+		t.Errorf("body = %s, want the extra_body labels", req.Body)
+	}
+}
+
+func TestVertex_ServiceAccount(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	var exchanges atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges.Add(1)
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			http.Error(w, `{"error":"unsupported_grant_type"}`, http.StatusBadRequest)
+			return
+		}
+
+		parts := strings.Split(r.Form.Get("assertion"), ".")
+		if len(parts) != 3 {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+
+		var claims map[string]any
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		json.Unmarshal(payload, &claims)
+		if claims["iss"] != "svc@my-project.iam.gserviceaccount.com" || claims["scope"] != providers.GoogleCloudScope {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"access_token":"ya29.service","expires_in":3600,"token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	credentials, _ := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "svc@my-project.iam.gserviceaccount.com",
+		"private_key":    string(pemKey),
+		"private_key_id": "key-1",
+		"token_uri":      server.URL,
+	})
+	provider, err := providers.NewVertex(&config.ProviderConfig{
+		Name:    "vertex",
+		Options: map[string]any{"project": "my-project", "credentials_json": string(credentials)},
+	})
+	if err != nil {
+		t.Fatalf("NewVertex failed: %v", err)
+	}
+
+	for range 2 {
+		req, _ := http.NewRequest(http.MethodPost, "https://us-central1-aiplatform.googleapis.com", nil)
+		provider.SetHeaders(req)
+		if got := req.Header.Get("Authorization"); got != "Bearer ya29.service" {
+			t.Errorf("Authorization = %q, want the exchanged access token", got)
+		}
+	}
+	if n := exchanges.Load(); n != 1 {
+		t.Errorf("token exchanged %d times, want 1 (cached)", n)
+	}
+}
+
+func TestNewGoogleCredentials_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials string
+	}{
+		{"malformed json", "{"},
+		{"unknown type", `{"type":"external_account"}`},
+		{"service account without key", `{"type":"service_account","client_email":"svc@example.com"}`},
+		{"user without refresh token", `{"type":"authorized_user","client_id":"id","client_secret":"secret"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := providers.NewGoogleCredentials(map[string]any{"credentials_json": tt.credentials}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+)
+
+func TestNewXAI(t *testing.T) {
+	provider, err := providers.Create(&config.ProviderConfig{
+		Name:    "xai",
+		Options: map[string]any{"token": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if provider.BaseURL() != providers.XAIDefaultBaseURL {
+		t.Errorf("BaseURL = %q, want default %q", provider.BaseURL(), providers.XAIDefaultBaseURL)
+	}
+
+	if _, err := providers.NewXAI(&config.ProviderConfig{Name: "xai"}); err == nil {
+		t.Error("expected error without a token")
+	}
+}
+
+func TestXAI_Endpoint(t *testing.T) {
+	provider, _ := providers.NewXAI(&config.ProviderConfig{
+		Name:    "xai",
+		BaseURL: "https://gateway.example.com/",
+		Options: map[string]any{"token": "secret"},
+	})
+
+	for _, proto := range []protocol.Protocol{protocol.Chat, protocol.Vision, protocol.Tools} {
+		got, err := provider.Endpoint(proto)
+		if err != nil {
+			t.Fatalf("Endpoint(%s) failed: %v", proto, err)
+		}
+		if want := "https://gateway.example.com/v1/chat/completions"; got != want {
+			t.Errorf("Endpoint(%s) = %q, want %q", proto, got, want)
+		}
+	}
+
+	if _, err := provider.Endpoint(protocol.Embeddings); err == nil {
+		t.Error("expected error for embeddings")
+	}
+}
+
+func TestXAI_SetHeaders(t *testing.T) {
+	provider, _ := providers.NewXAI(&config.ProviderConfig{
+		Name:    "xai",
+		Options: map[string]any{"token": "secret"},
+	})
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, providers.XAIDefaultBaseURL+"/chat/completions", nil)
+	provider.SetHeaders(req)
+	if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+	}
+}
+
+func TestXAI_DeferredCompletionRequest(t *testing.T) {
+	provider, _ := providers.NewXAI(&config.ProviderConfig{
+		Name:    "xai",
+		Options: map[string]any{"token": "secret"},
+	})
+
+	fetcher, ok := provider.(providers.DeferredFetcher)
+	if !ok {
+		t.Fatal("xAI provider does not implement DeferredFetcher")
+	}
+
+	req, err := fetcher.DeferredCompletionRequest("req-123")
+	if err != nil {
+		t.Fatalf("DeferredCompletionRequest failed: %v", err)
+	}
+	if want := providers.XAIDefaultBaseURL + "/chat/deferred-completion/req-123"; req.URL != want {
+		t.Errorf("URL = %q, want %q", req.URL, want)
+	}
+
+	if _, err := fetcher.DeferredCompletionRequest(""); err == nil {
+		t.Error("expected error for empty request ID")
+	}
+}
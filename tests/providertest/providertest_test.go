@@ -0,0 +1,117 @@
+package providertest_test
+
+import (
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers/providertest"
+)
+
+var allProtocols = []protocol.Protocol{protocol.Chat, protocol.Vision, protocol.Tools, protocol.Embeddings}
+
+// newProvider returns a Config.New that creates a built-in provider.
+func newProvider(name string, options map[string]any) func(string) (providers.Provider, error) {
+	return func(baseURL string) (providers.Provider, error) {
+		return providers.Create(&config.ProviderConfig{Name: name, BaseURL: baseURL, Options: options})
+	}
+}
+
+func TestBuiltinProviders(t *testing.T) {
+	tests := []struct {
+		name   string
+		config providertest.Config
+	}{
+		{
+			name: "ollama",
+			config: providertest.Config{
+				New:       newProvider("ollama", nil),
+				Protocols: allProtocols,
+			},
+		},
+		{
+			name: "azure",
+			config: providertest.Config{
+				New: newProvider("azure", map[string]any{
+					"deployment":  "providertest",
+					"auth_type":   "api_key",
+					"token":       "secret",
+					"api_version": "2024-02-01",
+				}),
+				Protocols: allProtocols,
+			},
+		},
+		{
+			name: "llamacpp",
+			config: providertest.Config{
+				New:       newProvider("llamacpp", nil),
+				Protocols: allProtocols,
+			},
+		},
+		{
+			name: "xai",
+			config: providertest.Config{
+				New:       newProvider("xai", map[string]any{"token": "secret"}),
+				Protocols: []protocol.Protocol{protocol.Chat, protocol.Vision, protocol.Tools},
+			},
+		},
+		{
+			name: "together",
+			config: providertest.Config{
+				New:       newProvider("together", map[string]any{"token": "secret"}),
+				Protocols: allProtocols,
+			},
+		},
+		{
+			name: "fireworks",
+			config: providertest.Config{
+				New:       newProvider("fireworks", map[string]any{"token": "secret"}),
+				Protocols: allProtocols,
+			},
+		},
+		{
+			name: "vertex",
+			config: providertest.Config{
+				New:       newProvider("vertex", map[string]any{"project": "providertest", "token": "secret"}),
+				Protocols: []protocol.Protocol{protocol.Chat, protocol.Vision, protocol.Tools},
+				Streaming: []protocol.Protocol{protocol.Chat, protocol.Vision},
+				Response: func(p protocol.Protocol) []byte {
+					if p == protocol.Tools {
+						return providertest.GeminiResponse(p)
+					}
+					return providertest.OpenAIResponse(p)
+				},
+			},
+		},
+		{
+			name: "gateway",
+			config: providertest.Config{
+				New: newProvider("gateway", map[string]any{
+					"token": "secret",
+					"gateway": map[string]any{
+						"endpoints": map[string]any{
+							"chat":  "/llm/{model}/chat",
+							"tools": "/llm/{model}/chat",
+						},
+						"envelope":      map[string]any{"wrap": "request"},
+						"response_path": "result",
+					},
+				}),
+				Protocols: []protocol.Protocol{protocol.Chat, protocol.Tools},
+				Response: func(p protocol.Protocol) []byte {
+					return append(append([]byte(`{"result": `), providertest.OpenAIResponse(p)...), '}')
+				},
+				StreamResponse: func(p protocol.Protocol) []byte {
+					return []byte(`data: {"result": {"choices": [{"index": 0, "delta": {"content": "` + providertest.Content + `"}}]}}` + "\n\n")
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providertest.Run(t, tt.config)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package quota_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/agent"
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/quota"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// clock is a manually advanced time source.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newClock() *clock {
+	return &clock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestLimiter_Requests(t *testing.T) {
+	c := newClock()
+	l := quota.NewLimiter(&config.QuotaConfig{
+		Window:      config.Duration(time.Minute),
+		MaxRequests: 2,
+	}, quota.WithClock(c.Now))
+
+	start := c.Now()
+	for range 2 {
+		if err := l.Allow("a"); err != nil {
+			t.Fatalf("Allow failed: %v", err)
+		}
+		c.Advance(10 * time.Second)
+	}
+
+	err := l.Allow("a")
+	var exceeded *quota.ExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("got error %v, want *quota.ExceededError", err)
+	}
+	if !errors.Is(err, quota.ErrQuotaExceeded) {
+		t.Error("expected error to wrap ErrQuotaExceeded")
+	}
+	if exceeded.Key != "a" || exceeded.Limit != quota.LimitRequests || exceeded.Max != 2 || exceeded.Used != 2 {
+		t.Errorf("got %+v", exceeded)
+	}
+	if want := start.Add(time.Minute); !exceeded.ResetAt.Equal(want) {
+		t.Errorf("got reset %v, want %v", exceeded.ResetAt, want)
+	}
+
+	if err := l.Allow("b"); err != nil {
+		t.Errorf("got error %v for independent key", err)
+	}
+
+	c.Advance(41 * time.Second)
+	if err := l.Allow("a"); err != nil {
+		t.Errorf("got error %v after oldest request left the window", err)
+	}
+}
+
+func TestLimiter_Tokens(t *testing.T) {
+	c := newClock()
+	l := quota.NewLimiter(&config.QuotaConfig{
+		Window:    config.Duration(time.Minute),
+		MaxTokens: 100,
+	}, quota.WithClock(c.Now))
+
+	start := c.Now()
+	l.Allow("a")
+	l.AddTokens("a", 70)
+	c.Advance(20 * time.Second)
+	l.Allow("a")
+	l.AddTokens("a", 40)
+
+	err := l.Allow("a")
+	var exceeded *quota.ExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("got error %v, want *quota.ExceededError", err)
+	}
+	if exceeded.Limit != quota.LimitTokens || exceeded.Used != 110 {
+		t.Errorf("got %+v", exceeded)
+	}
+	if want := start.Add(time.Minute); !exceeded.ResetAt.Equal(want) {
+		t.Errorf("got reset %v, want %v", exceeded.ResetAt, want)
+	}
+
+	requests, tokens := l.Usage("a")
+	if requests != 2 || tokens != 110 {
+		t.Errorf("got usage %d requests %d tokens, want 2 and 110", requests, tokens)
+	}
+
+	c.Advance(41 * time.Second)
+	if err := l.Allow("a"); err != nil {
+		t.Errorf("got error %v after oldest tokens left the window", err)
+	}
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	for _, cfg := range []*config.QuotaConfig{nil, {MaxRequests: 1}} {
+		l := quota.NewLimiter(cfg)
+		for range 5 {
+			if err := l.Allow("a"); err != nil {
+				t.Fatalf("got error %v, want no enforcement for %+v", err, cfg)
+			}
+		}
+	}
+}
+
+func chatAgent(id string, tokens int) agent.Agent {
+	return mock.NewMockAgent(mock.WithID(id), mock.WithChatResponse(&response.ChatResponse{
+		Choices: []response.ChatChoice{{Message: protocol.NewMessage("assistant", "ok")}},
+		Usage:   &response.TokenUsage{TotalTokens: tokens},
+	}, nil))
+}
+
+func TestWrap_ByAgent(t *testing.T) {
+	l := quota.NewLimiter(&config.QuotaConfig{Window: config.Duration(time.Minute), MaxTokens: 50})
+	a := quota.Wrap(chatAgent("agent-1", 30), l)
+
+	for range 2 {
+		if _, err := a.Chat(context.Background(), "hi"); err != nil {
+			t.Fatalf("Chat failed: %v", err)
+		}
+	}
+
+	if _, err := a.Chat(context.Background(), "hi"); !errors.Is(err, quota.ErrQuotaExceeded) {
+		t.Errorf("got error %v, want ErrQuotaExceeded", err)
+	}
+
+	if _, tokens := l.Usage("agent-1"); tokens != 60 {
+		t.Errorf("got %d tokens recorded, want 60", tokens)
+	}
+}
+
+func TestWrap_ByMetadata(t *testing.T) {
+	l := quota.NewLimiter(&config.QuotaConfig{Window: config.Duration(time.Minute), MaxRequests: 1})
+	a := quota.Wrap(chatAgent("agent-1", 0), l, quota.WithKey(quota.ByMetadata("tenant")))
+
+	acme := agent.WithMetadata(context.Background(), map[string]string{"tenant": "acme"})
+	globex := agent.WithMetadata(context.Background(), map[string]string{"tenant": "globex"})
+
+	if _, err := a.Chat(acme, "hi"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if _, err := a.Chat(globex, "hi"); err != nil {
+		t.Fatalf("got error %v for a different tenant", err)
+	}
+
+	_, err := a.Chat(acme, "hi")
+	var exceeded *quota.ExceededError
+	if !errors.As(err, &exceeded) || exceeded.Key != "acme" {
+		t.Errorf("got error %v, want acme quota exceeded", err)
+	}
+}
+
+func TestWrap_StreamRecordsUsage(t *testing.T) {
+	l := quota.NewLimiter(&config.QuotaConfig{Window: config.Duration(time.Minute), MaxTokens: 1000})
+	a := quota.Wrap(mock.NewMockAgent(mock.WithID("agent-1"), mock.WithStreamChunks([]response.StreamingChunk{
+		{Choices: []response.StreamingChoice{{Delta: response.StreamingDelta{Content: "ok"}}}},
+		{Usage: &response.TokenUsage{TotalTokens: 12}},
+	}, nil)), l)
+
+	chunks, err := a.ChatStream(context.Background(), "hi")
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+	for range chunks {
+	}
+
+	if _, tokens := l.Usage("agent-1"); tokens != 12 {
+		t.Errorf("got %d tokens recorded, want 12", tokens)
+	}
+}
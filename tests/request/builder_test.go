@@ -0,0 +1,231 @@
+package request_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/options"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+func newProvider(t *testing.T) providers.Provider {
+	t.Helper()
+
+	p, err := providers.NewOllama(&config.ProviderConfig{
+		Name:    "ollama",
+		BaseURL: "http://localhost:11434",
+	})
+	if err != nil {
+		t.Fatalf("NewOllama failed: %v", err)
+	}
+	return p
+}
+
+func TestChatBuilder(t *testing.T) {
+	m := &model.Model{Name: "llama3.1:8b"}
+
+	req, err := request.Chat(newProvider(t), m).
+		Message("system", "Be brief.").
+		Messages(protocol.NewMessage("user", "Hello")).
+		Option("temperature", 0.7).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	body, err := req.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if payload["model"] != "llama3.1:8b" {
+		t.Errorf("got model %v, want %q", payload["model"], "llama3.1:8b")
+	}
+
+	if payload["temperature"] != 0.7 {
+		t.Errorf("got temperature %v, want 0.7", payload["temperature"])
+	}
+
+	if messages, ok := payload["messages"].([]any); !ok || len(messages) != 2 {
+		t.Errorf("got messages %v, want 2 messages", payload["messages"])
+	}
+}
+
+func TestBuilders_Validation(t *testing.T) {
+	p := newProvider(t)
+	m := &model.Model{Name: "llava"}
+
+	tests := []struct {
+		name     string
+		build    func() error
+		protocol protocol.Protocol
+		field    string
+	}{
+		{
+			name: "chat without messages",
+			build: func() error {
+				_, err := request.Chat(p, m).Build()
+				return err
+			},
+			protocol: protocol.Chat,
+			field:    "messages",
+		},
+		{
+			name: "chat without provider",
+			build: func() error {
+				_, err := request.Chat(nil, m).Message("user", "hi").Build()
+				return err
+			},
+			protocol: protocol.Chat,
+			field:    "provider",
+		},
+		{
+			name: "chat with unnamed model",
+			build: func() error {
+				_, err := request.Chat(p, &model.Model{}).Message("user", "hi").Build()
+				return err
+			},
+			protocol: protocol.Chat,
+			field:    "model name",
+		},
+		{
+			name: "vision without images",
+			build: func() error {
+				_, err := request.Vision(p, m).Message("user", "Describe").Build()
+				return err
+			},
+			protocol: protocol.Vision,
+			field:    "images",
+		},
+		{
+			name: "tools without tools",
+			build: func() error {
+				_, err := request.Tools(p, m).Message("user", "Weather?").Build()
+				return err
+			},
+			protocol: protocol.Tools,
+			field:    "tools",
+		},
+		{
+			name: "tools with unnamed tool",
+			build: func() error {
+				_, err := request.Tools(p, m).
+					Message("user", "Weather?").
+					Tools(providers.ToolDefinition{Description: "missing name"}).
+					Build()
+				return err
+			},
+			protocol: protocol.Tools,
+			field:    "tools",
+		},
+		{
+			name: "tools choosing unknown function",
+			build: func() error {
+				_, err := request.Tools(p, m).
+					Message("user", "Weather?").
+					Tools(providers.ToolDefinition{Name: "get_weather"}).
+					Options(options.New(options.ToolChoiceFunction("get_time"))).
+					Build()
+				return err
+			},
+			protocol: protocol.Tools,
+			field:    "tool_choice",
+		},
+		{
+			name: "tools with invalid choice",
+			build: func() error {
+				_, err := request.Tools(p, m).
+					Message("user", "Weather?").
+					Tools(providers.ToolDefinition{Name: "get_weather"}).
+					Option("tool_choice", "always").
+					Build()
+				return err
+			},
+			protocol: protocol.Tools,
+			field:    "tool_choice",
+		},
+		{
+			name: "embeddings without input",
+			build: func() error {
+				_, err := request.Embeddings(p, m).Build()
+				return err
+			},
+			protocol: protocol.Embeddings,
+			field:    "input",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.build()
+			if !errors.Is(err, request.ErrInvalidRequest) {
+				t.Fatalf("got error %v, want ErrInvalidRequest", err)
+			}
+
+			var validationErr *request.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("got error %T, want *request.ValidationError", err)
+			}
+
+			if validationErr.Protocol != tt.protocol {
+				t.Errorf("got protocol %q, want %q", validationErr.Protocol, tt.protocol)
+			}
+
+			if validationErr.Field != tt.field {
+				t.Errorf("got field %q, want %q", validationErr.Field, tt.field)
+			}
+		})
+	}
+}
+
+func TestVisionBuilder(t *testing.T) {
+	req, err := request.Vision(newProvider(t), &model.Model{Name: "llava"}).
+		Message("user", "Describe this image").
+		Images("https://example.com/cat.png").
+		VisionOption("detail", "high").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if req.Protocol() != protocol.Vision {
+		t.Errorf("got protocol %q, want %q", req.Protocol(), protocol.Vision)
+	}
+
+	if _, err := req.Marshal(); err != nil {
+		t.Errorf("Marshal failed: %v", err)
+	}
+}
+
+func TestEmbeddingsBuilder_SingleInput(t *testing.T) {
+	req, err := request.Embeddings(newProvider(t), &model.Model{Name: "nomic-embed-text"}).
+		Input("hello").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	body, err := req.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if payload["input"] != "hello" {
+		t.Errorf("got input %v, want %q", payload["input"], "hello")
+	}
+}
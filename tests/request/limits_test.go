@@ -0,0 +1,181 @@
+package request_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/config"
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/options"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+func TestCheckLimits(t *testing.T) {
+	p := newProvider(t)
+	messages := []protocol.Message{protocol.NewMessage("user", "Hello there")}
+	tools := []providers.ToolDefinition{{Name: "get_weather", Description: "Get the weather"}}
+
+	limited := model.New(&config.ModelConfig{
+		Name:            "limited",
+		ContextWindow:   50,
+		MaxOutputTokens: 20,
+		Supports: map[string]bool{
+			config.FeatureVision:   false,
+			config.FeatureTools:    false,
+			config.FeatureJSONMode: false,
+		},
+	})
+	blind := model.New(&config.ModelConfig{
+		Name:     "blind",
+		Supports: map[string]bool{config.FeatureVision: false},
+	})
+	noSchema := model.New(&config.ModelConfig{
+		Name:     "no-schema",
+		Supports: map[string]bool{config.FeatureJSONSchema: false},
+	})
+	noSearch := model.New(&config.ModelConfig{
+		Name:     "no-search",
+		Supports: map[string]bool{config.FeatureWebSearch: false},
+	})
+	open := model.New(&config.ModelConfig{Name: "open"})
+	guessed := model.New(&config.ModelConfig{
+		Name:            "guessed",
+		ContextWindow:   50,
+		MaxOutputTokens: 20,
+		Supports:        map[string]bool{config.FeatureVision: false},
+		Advisory:        []string{config.LimitContextWindow, config.LimitMaxOutputTokens, config.FeatureVision},
+	})
+
+	tests := []struct {
+		name  string
+		req   request.Request
+		field string
+	}{
+		{
+			name: "within limits",
+			req:  request.NewChat(p, limited, messages, map[string]any{"max_tokens": 10}),
+		},
+		{
+			name:  "vision unsupported",
+			req:   request.NewVision(p, limited, messages, []string{"a.png"}, nil, nil),
+			field: "model",
+		},
+		{
+			name:  "tools unsupported",
+			req:   request.NewTools(p, limited, messages, tools, nil),
+			field: "model",
+		},
+		{
+			name:  "json mode unsupported",
+			req:   request.NewChat(p, limited, messages, map[string]any{"response_format": map[string]any{"type": "json_object"}}),
+			field: "options",
+		},
+		{
+			name:  "ollama json format unsupported",
+			req:   request.NewChat(p, limited, messages, map[string]any{"format": "json"}),
+			field: "options",
+		},
+		{
+			name: "json mode without schema support",
+			req:  request.NewChat(p, noSchema, messages, options.New(options.JSONMode())),
+		},
+		{
+			name:  "json schema unsupported",
+			req:   request.NewChat(p, noSchema, messages, options.New(options.JSONSchema("reply", map[string]any{"type": "object"}))),
+			field: "options",
+		},
+		{
+			name:  "json schema constraint unsupported",
+			req:   request.NewChat(p, noSchema, messages, options.New(options.JSONSchemaConstraint(map[string]any{"type": "object"}))),
+			field: "options",
+		},
+		{
+			name: "web search supported",
+			req:  request.NewChat(p, open, messages, options.New(options.WebSearch(options.WebSearchConfig{}))),
+		},
+		{
+			name:  "web search unsupported",
+			req:   request.NewChat(p, noSearch, messages, options.New(options.WebSearch(options.WebSearchConfig{}))),
+			field: "options",
+		},
+		{
+			name:  "output exceeds limit",
+			req:   request.NewChat(p, limited, messages, map[string]any{"max_tokens": 100}),
+			field: "options",
+		},
+		{
+			name:  "prompt exceeds context window",
+			req:   request.NewChat(p, limited, []protocol.Message{protocol.NewMessage("user", strings.Repeat("word ", 100))}, nil),
+			field: "messages",
+		},
+		{
+			name:  "prompt plus output exceeds context window",
+			req:   request.NewChat(p, limited, []protocol.Message{protocol.NewMessage("user", strings.Repeat("word ", 30))}, map[string]any{"max_tokens": 20}),
+			field: "messages",
+		},
+		{
+			name:  "embeddings input exceeds context window",
+			req:   request.NewEmbeddings(p, limited, []string{"short", strings.Repeat("word ", 100)}, nil),
+			field: "input",
+		},
+		{
+			name: "vision tools supported",
+			req:  request.NewVisionTools(p, open, messages, []string{"a.png"}, nil, tools, nil),
+		},
+		{
+			name:  "vision tools without vision",
+			req:   request.NewVisionTools(p, blind, messages, []string{"a.png"}, nil, tools, nil),
+			field: "model",
+		},
+		{
+			name: "tool choice names defined tool",
+			req:  request.NewTools(p, open, messages, tools, options.New(options.ToolChoiceFunction("get_weather"))),
+		},
+		{
+			name:  "tool choice names unknown tool",
+			req:   request.NewTools(p, open, messages, tools, options.New(options.ToolChoiceFunction("get_time"))),
+			field: "tool_choice",
+		},
+		{
+			name: "advisory vision not checked",
+			req:  request.NewVision(p, guessed, messages, []string{"a.png"}, nil, nil),
+		},
+		{
+			name: "advisory limits not checked",
+			req:  request.NewChat(p, guessed, []protocol.Message{protocol.NewMessage("user", strings.Repeat("word ", 100))}, map[string]any{"max_tokens": 100}),
+		},
+		{
+			name: "undeclared limits not checked",
+			req:  request.NewVision(p, open, messages, []string{"a.png"}, nil, map[string]any{"max_tokens": 1000000}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := request.CheckLimits(tt.req)
+
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("CheckLimits failed: %v", err)
+				}
+				return
+			}
+
+			var validationErr *request.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("got error %v, want *request.ValidationError", err)
+			}
+
+			if validationErr.Field != tt.field {
+				t.Errorf("got field %q, want %q", validationErr.Field, tt.field)
+			}
+
+			if !errors.Is(err, request.ErrInvalidRequest) {
+				t.Errorf("got error %v, want ErrInvalidRequest", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package request_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+)
+
+// anthropicModel returns a model whose requests use the Anthropic wire
+// format, which supports prefill.
+func anthropicModel() *model.Model {
+	return &model.Model{
+		Name: "claude-sonnet",
+		Formats: map[protocol.Protocol]string{
+			protocol.Chat:   providers.FormatAnthropic,
+			protocol.Vision: providers.FormatAnthropic,
+			protocol.Tools:  providers.FormatAnthropic,
+		},
+	}
+}
+
+func TestChatBuilder_Prefill(t *testing.T) {
+	req, err := request.Chat(newProvider(t), anthropicModel()).
+		Message("system", "Answer in JSON.").
+		Message("user", "List three colors.").
+		Prefill("{").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if err := request.CheckLimits(req); err != nil {
+		t.Fatalf("CheckLimits failed: %v", err)
+	}
+
+	body, err := req.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var payload struct {
+		System   string `json:"system"`
+		Messages []struct {
+			Role    string `json:"role"`
+			Content []struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if payload.System != "Answer in JSON." || len(payload.Messages) != 2 {
+		t.Fatalf("got %s, want an Anthropic body with the system prompt and two messages", body)
+	}
+	last := payload.Messages[1]
+	if last.Role != "assistant" || len(last.Content) != 1 || last.Content[0].Text != "{" {
+		t.Errorf("got final message %+v, want the assistant prefill", last)
+	}
+	if strings.Contains(string(body), "prefill") {
+		t.Error("the prefill marker should not be serialized")
+	}
+}
+
+func TestPrefill_Validation(t *testing.T) {
+	p := newProvider(t)
+
+	tests := []struct {
+		name   string
+		model  *model.Model
+		build  func(*model.Model) (request.Request, error)
+		reason string
+	}{
+		{
+			name:  "unsupported format",
+			model: &model.Model{Name: "llama3.1:8b"},
+			build: func(m *model.Model) (request.Request, error) {
+				return request.Chat(p, m).Message("user", "Hi").Prefill("Sure").Build()
+			},
+			reason: "not supported by provider ollama",
+		},
+		{
+			name:  "not final",
+			model: anthropicModel(),
+			build: func(m *model.Model) (request.Request, error) {
+				return request.Chat(p, m).Prefill("Sure").Message("user", "Hi").Build()
+			},
+			reason: "must be the final message",
+		},
+		{
+			name:  "vision",
+			model: anthropicModel(),
+			build: func(m *model.Model) (request.Request, error) {
+				return request.Vision(p, m).
+					Messages(protocol.NewMessage("user", "Describe"), protocol.NewPrefillMessage("It shows")).
+					Images("https://example.com/cat.jpg").
+					Build()
+			},
+			reason: "not supported with images",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build(tt.model)
+
+			var validation *request.ValidationError
+			if !errors.As(err, &validation) || !strings.Contains(validation.Reason, tt.reason) {
+				t.Errorf("got error %v, want a validation error containing %q", err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestCheckLimits_Prefill(t *testing.T) {
+	messages := []protocol.Message{
+		protocol.NewMessage("user", "Hi"),
+		protocol.NewPrefillMessage("Sure"),
+	}
+
+	err := request.CheckLimits(request.NewChat(newProvider(t), &model.Model{Name: "llama3.1:8b"}, messages, nil))
+	if !errors.Is(err, request.ErrInvalidRequest) {
+		t.Errorf("got %v, want a validation error for the OpenAI format", err)
+	}
+
+	if err := request.CheckLimits(request.NewChat(newProvider(t), anthropicModel(), messages, nil)); err != nil {
+		t.Errorf("CheckLimits failed for the Anthropic format: %v", err)
+	}
+}
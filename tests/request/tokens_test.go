@@ -0,0 +1,73 @@
+package request_test
+
+import (
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/model"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/request"
+	"github.com/tailored-agentic-units/tau-core/pkg/tokenizer"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	p := newProvider(t)
+	m := &model.Model{Name: "llama3.1:8b"}
+	messages := []protocol.Message{protocol.NewMessage("user", "Hello there")}
+	h := tokenizer.Heuristic{}
+
+	// Reply priming, message overhead, role, and content.
+	chatTokens := 3 + 3 + h.Count("user") + h.Count("Hello there")
+
+	tests := []struct {
+		name     string
+		req      request.Request
+		expected int
+	}{
+		{
+			name:     "chat",
+			req:      request.NewChat(p, m, messages, nil),
+			expected: chatTokens,
+		},
+		{
+			name:     "vision adds image cost",
+			req:      request.NewVision(p, m, messages, []string{"a.png", "b.png"}, nil, nil),
+			expected: chatTokens + 2*85,
+		},
+		{
+			name:     "embeddings batch",
+			req:      request.NewEmbeddings(p, m, []string{"one", "two"}, nil),
+			expected: h.Count("one") + h.Count("two"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := request.EstimateTokens(tt.req, "")
+			if err != nil {
+				t.Fatalf("EstimateTokens failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("got %d tokens, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEstimateTokens_ToolsExceedChat(t *testing.T) {
+	p := newProvider(t)
+	m := &model.Model{Name: "llama3.1:8b"}
+	messages := []protocol.Message{protocol.NewMessage("user", "Weather in Boston?")}
+
+	chat, _ := request.EstimateTokens(request.NewChat(p, m, messages, nil), "")
+	tools, err := request.EstimateTokens(request.NewTools(p, m, messages, []providers.ToolDefinition{
+		{Name: "get_weather", Description: "Get the weather", Parameters: map[string]any{"type": "object"}},
+	}, nil), "")
+	if err != nil {
+		t.Fatalf("EstimateTokens failed: %v", err)
+	}
+
+	if tools <= chat {
+		t.Errorf("got tools estimate %d, want more than chat estimate %d", tools, chat)
+	}
+}
@@ -0,0 +1,90 @@
+package response_test
+
+import (
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestParseAnthropicTools(t *testing.T) {
+	body := []byte(`{
+		"id": "msg_01",
+		"model": "claude-test",
+		"content": [
+			{"type": "text", "text": "Let me check."},
+			{"type": "tool_use", "id": "toolu_01", "name": "get_weather", "input": {"city": "Paris"}}
+		],
+		"stop_reason": "tool_use",
+		"usage": {"input_tokens": 12, "output_tokens": 8}
+	}`)
+
+	resp, err := response.ParseAnthropicTools(body)
+	if err != nil {
+		t.Fatalf("ParseAnthropicTools failed: %v", err)
+	}
+
+	if resp.FinishReason() != response.FinishReasonToolCalls {
+		t.Errorf("got finish reason %q, want %q", resp.FinishReason(), response.FinishReasonToolCalls)
+	}
+	if got := resp.Choices[0].Message.Content; got != "Let me check." {
+		t.Errorf("got content %q", got)
+	}
+
+	calls := resp.ToolCalls()
+	if len(calls) != 1 {
+		t.Fatalf("got %d tool calls, want 1", len(calls))
+	}
+	if calls[0].ID != "toolu_01" || calls[0].Type != "function" || calls[0].Function.Name != "get_weather" {
+		t.Errorf("got tool call %+v", calls[0])
+	}
+	if calls[0].Function.Arguments != `{"city": "Paris"}` {
+		t.Errorf("got arguments %s, want the tool_use input", calls[0].Function.Arguments)
+	}
+
+	if resp.Usage == nil || resp.Usage.PromptTokens != 12 || resp.Usage.CompletionTokens != 8 || resp.Usage.TotalTokens != 20 {
+		t.Errorf("got usage %+v, want 12/8/20", resp.Usage)
+	}
+	if string(resp.Raw()) != string(body) {
+		t.Error("Raw should return the original payload")
+	}
+}
+
+func TestParseAnthropicTools_Invalid(t *testing.T) {
+	if _, err := response.ParseAnthropicTools([]byte(`{"content": "nope"`)); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestParseAnthropicTools_WebSearch(t *testing.T) {
+	body := `{
+		"id": "msg_1",
+		"model": "claude-test",
+		"stop_reason": "end_turn",
+		"content": [
+			{"type": "server_tool_use", "id": "srvtoolu_1", "name": "web_search", "input": {"query": "go 1.25 release"}},
+			{"type": "web_search_tool_result", "tool_use_id": "srvtoolu_1", "content": [
+				{"type": "web_search_result", "url": "https://go.dev/doc/go1.25", "title": "Go 1.25 Release Notes", "encrypted_content": "..."}
+			]},
+			{"type": "text", "text": "Go 1.25 was released in August."}
+		]
+	}`
+
+	resp, err := response.ParseAnthropicTools([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseAnthropicTools failed: %v", err)
+	}
+
+	choice := resp.Choices[0]
+	if len(choice.Message.ToolCalls) != 0 {
+		t.Errorf("server tool use should not be a tool call: %v", choice.Message.ToolCalls)
+	}
+	if choice.Message.Content != "Go 1.25 was released in August." {
+		t.Errorf("got content %q", choice.Message.Content)
+	}
+	if choice.Grounding == nil || len(choice.Grounding.Queries) != 1 || choice.Grounding.Queries[0] != "go 1.25 release" {
+		t.Fatalf("got grounding %+v", choice.Grounding)
+	}
+	if len(choice.Grounding.Results) != 1 || choice.Grounding.Results[0].URL != "https://go.dev/doc/go1.25" {
+		t.Errorf("got results %v", choice.Grounding.Results)
+	}
+}
@@ -0,0 +1,139 @@
+package response_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestChatResponse_Citations_OpenAI(t *testing.T) {
+	body := `{
+		"model": "gpt-4o-search-preview",
+		"choices": [{
+			"index": 0,
+			"message": {
+				"role": "assistant",
+				"content": "Go 1.25 shipped in August.",
+				"annotations": [
+					{"type": "url_citation", "url_citation": {"start_index": 0, "end_index": 26, "url": "https://go.dev/doc/go1.25", "title": "Go 1.25 Release Notes"}},
+					{"type": "file_citation", "text": "【4:0†source】", "start_index": 26, "end_index": 38, "file_citation": {"file_id": "file-abc", "quote": "released in August"}}
+				]
+			},
+			"finish_reason": "stop"
+		}]
+	}`
+
+	resp, err := response.ParseChat([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseChat failed: %v", err)
+	}
+
+	want := []response.Citation{
+		{Type: response.CitationURL, URL: "https://go.dev/doc/go1.25", Title: "Go 1.25 Release Notes", End: 26},
+		{Type: response.CitationFile, FileID: "file-abc", Quote: "released in August", Start: 26, End: 38},
+	}
+	if got := resp.Citations(); !slices.Equal(got, want) {
+		t.Errorf("got citations %+v\nwant %+v", got, want)
+	}
+	if resp.Content() != "Go 1.25 shipped in August." {
+		t.Errorf("got content %q", resp.Content())
+	}
+}
+
+func TestChatResponse_Citations_AzureOnYourData(t *testing.T) {
+	body := `{
+		"model": "gpt-4o",
+		"choices": [{
+			"message": {
+				"role": "assistant",
+				"content": "The policy allows 20 days [doc1].",
+				"context": {"citations": [{"content": "Employees receive 20 days...", "title": "", "filepath": "handbook.pdf", "url": "https://files.test/handbook.pdf"}]}
+			}
+		}]
+	}`
+
+	resp, err := response.ParseChat([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseChat failed: %v", err)
+	}
+
+	want := []response.Citation{{Type: response.CitationFile, URL: "https://files.test/handbook.pdf", Title: "handbook.pdf", Quote: "Employees receive 20 days..."}}
+	if got := resp.Citations(); !slices.Equal(got, want) {
+		t.Errorf("got citations %+v, want %+v", got, want)
+	}
+}
+
+func TestChatResponse_Citations_None(t *testing.T) {
+	resp, err := response.ParseChat([]byte(`{"model": "m", "choices": [{"message": {"role": "assistant", "content": "hi"}}]}`))
+	if err != nil {
+		t.Fatalf("ParseChat failed: %v", err)
+	}
+	if resp.Citations() != nil {
+		t.Errorf("expected no citations, got %v", resp.Citations())
+	}
+	if (&response.ChatResponse{}).Citations() != nil {
+		t.Error("expected nil citations without choices")
+	}
+}
+
+func TestParseGeminiTools_Citations(t *testing.T) {
+	body := `{
+		"candidates": [{
+			"content": {"role": "model", "parts": [{"text": "Spain won the final."}]},
+			"finishReason": "STOP",
+			"groundingMetadata": {
+				"groundingChunks": [
+					{"web": {"uri": "https://example.com/a", "title": "example.com"}},
+					{"retrievedContext": {"uri": "gs://bucket/report.pdf", "title": "report.pdf"}}
+				],
+				"groundingSupports": [
+					{"segment": {"startIndex": 0, "endIndex": 20, "text": "Spain won the final."}, "groundingChunkIndices": [0, 1, 7]}
+				]
+			}
+		}]
+	}`
+
+	resp, err := response.ParseGeminiTools([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseGeminiTools failed: %v", err)
+	}
+
+	want := []response.Citation{
+		{Type: response.CitationURL, URL: "https://example.com/a", Title: "example.com", Quote: "Spain won the final.", End: 20},
+		{Type: response.CitationFile, URL: "gs://bucket/report.pdf", Title: "report.pdf", Quote: "Spain won the final.", End: 20},
+	}
+	if got := resp.Citations(); !slices.Equal(got, want) {
+		t.Errorf("got citations %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseAnthropicTools_Citations(t *testing.T) {
+	body := `{
+		"id": "msg_1",
+		"model": "claude-test",
+		"stop_reason": "end_turn",
+		"content": [
+			{"type": "text", "text": "Per the docs, "},
+			{"type": "text", "text": "Go 1.25 shipped in August.", "citations": [
+				{"type": "web_search_result_location", "url": "https://go.dev/doc/go1.25", "title": "Go 1.25 Release Notes", "cited_text": "Go 1.25 is released", "encrypted_index": "x"}
+			]},
+			{"type": "text", "text": " It adds wg.Go.", "citations": [
+				{"type": "char_location", "document_index": 0, "document_title": "Notes", "cited_text": "WaitGroup.Go", "start_char_index": 0, "end_char_index": 12}
+			]}
+		]
+	}`
+
+	resp, err := response.ParseAnthropicTools([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseAnthropicTools failed: %v", err)
+	}
+
+	want := []response.Citation{
+		{Type: response.CitationURL, URL: "https://go.dev/doc/go1.25", Title: "Go 1.25 Release Notes", Quote: "Go 1.25 is released", Start: 14, End: 40},
+		{Type: response.CitationDocument, Title: "Notes", Quote: "WaitGroup.Go", Start: 40, End: 55},
+	}
+	if got := resp.Citations(); !slices.Equal(got, want) {
+		t.Errorf("got citations %+v\nwant %+v", got, want)
+	}
+}
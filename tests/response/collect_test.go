@@ -0,0 +1,124 @@
+package response_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func streamOf(t *testing.T, payloads ...string) <-chan *response.StreamingChunk {
+	t.Helper()
+
+	ch := make(chan *response.StreamingChunk, len(payloads))
+	for _, payload := range payloads {
+		var chunk response.StreamingChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		ch <- &chunk
+	}
+	close(ch)
+	return ch
+}
+
+func TestCollect_Content(t *testing.T) {
+	stream := streamOf(t,
+		`{"id":"chatcmpl-1","model":"gpt-4","created":1,"choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"}}]}`,
+		`{"id":"chatcmpl-1","model":"gpt-4","choices":[{"index":0,"delta":{"content":"lo"}}]}`,
+		`{"id":"chatcmpl-1","model":"gpt-4","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}`,
+		`{"id":"chatcmpl-1","model":"gpt-4","choices":[],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`,
+	)
+
+	resp, err := response.Collect(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	if resp.ID != "chatcmpl-1" {
+		t.Errorf("got ID %q, want %q", resp.ID, "chatcmpl-1")
+	}
+
+	if resp.Model != "gpt-4" {
+		t.Errorf("got model %q, want %q", resp.Model, "gpt-4")
+	}
+
+	if resp.Content() != "Hello" {
+		t.Errorf("got content %q, want %q", resp.Content(), "Hello")
+	}
+
+	if resp.Choices[0].Message.Role != "assistant" {
+		t.Errorf("got role %q, want %q", resp.Choices[0].Message.Role, "assistant")
+	}
+
+	if resp.Choices[0].FinishReason != "stop" {
+		t.Errorf("got finish reason %q, want %q", resp.Choices[0].FinishReason, "stop")
+	}
+
+	if resp.Usage == nil || resp.Usage.TotalTokens != 5 {
+		t.Errorf("got usage %+v, want total tokens 5", resp.Usage)
+	}
+}
+
+func TestCollect_ToolCallFragments(t *testing.T) {
+	stream := streamOf(t,
+		`{"model":"gpt-4","choices":[{"index":0,"delta":{"role":"assistant","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}}]}`,
+		`{"model":"gpt-4","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"location\":"}}]}}]}`,
+		`{"model":"gpt-4","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Boston\"}"}}]}}]}`,
+		`{"model":"gpt-4","choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","function":{"name":"get_time","arguments":"{}"}}]}}]}`,
+		`{"model":"gpt-4","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+	)
+
+	resp, err := response.Collect(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	calls := resp.Choices[0].Message.ToolCalls
+	if len(calls) != 2 {
+		t.Fatalf("got %d tool calls, want 2", len(calls))
+	}
+
+	if calls[0].ID != "call_1" || calls[0].Function.Name != "get_weather" {
+		t.Errorf("got first call %+v, want call_1 get_weather", calls[0])
+	}
+
+	if calls[0].Function.Arguments != `{"location":"Boston"}` {
+		t.Errorf("got arguments %q, want %q", calls[0].Function.Arguments, `{"location":"Boston"}`)
+	}
+
+	if calls[1].Type != "function" {
+		t.Errorf("got type %q, want %q", calls[1].Type, "function")
+	}
+
+	if resp.Choices[0].FinishReason != "tool_calls" {
+		t.Errorf("got finish reason %q, want %q", resp.Choices[0].FinishReason, "tool_calls")
+	}
+}
+
+func TestCollect_ChunkError(t *testing.T) {
+	streamErr := errors.New("connection reset")
+
+	ch := make(chan *response.StreamingChunk, 1)
+	ch <- &response.StreamingChunk{Error: streamErr}
+	close(ch)
+
+	_, err := response.Collect(context.Background(), ch)
+	if !errors.Is(err, streamErr) {
+		t.Errorf("got error %v, want %v", err, streamErr)
+	}
+}
+
+func TestCollect_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ch := make(chan *response.StreamingChunk)
+
+	_, err := response.Collect(ctx, ch)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,130 @@
+package response_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// base64Vector encodes values as base64 little-endian float32, as returned
+// for encoding_format "base64".
+func base64Vector(values ...float32) string {
+	data := make([]byte, 0, len(values)*4)
+	for _, value := range values {
+		data = binary.LittleEndian.AppendUint32(data, math.Float32bits(value))
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func TestParseEmbeddings_Base64(t *testing.T) {
+	body := `{"object":"list","model":"embed","data":[{"object":"embedding","index":0,"embedding":"` + base64Vector(0.5, -1.25) + `"}]}`
+
+	resp, err := response.ParseEmbeddings([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseEmbeddings failed: %v", err)
+	}
+	if got := resp.Data[0].Embedding; !slices.Equal(got, []float64{0.5, -1.25}) {
+		t.Errorf("unexpected embedding: %v", got)
+	}
+}
+
+func TestEmbeddingsResponse_DataFloat32(t *testing.T) {
+	resp := &response.EmbeddingsResponse{Data: []response.EmbeddingData{
+		{Embedding: []float64{0.1, 0.2}},
+		{Embedding: []float64{0.3}},
+	}}
+
+	vectors := resp.DataFloat32()
+	if len(vectors) != 2 || !slices.Equal(vectors[0], []float32{0.1, 0.2}) || !slices.Equal(vectors[1], []float32{0.3}) {
+		t.Fatalf("unexpected vectors: %v", vectors)
+	}
+
+	// Appending to one vector must not overwrite the next
+	_ = append(vectors[0], 9)
+	if vectors[1][0] != 0.3 {
+		t.Errorf("vectors share capacity: %v", vectors)
+	}
+}
+
+func TestParseEmbeddingsFloat32(t *testing.T) {
+	body := `{"model":"embed","usage":{"prompt_tokens":4,"total_tokens":4},"data":[
+		{"index":1,"embedding":[0.25, -1e-3, 3]},
+		{"index":0,"embedding":"` + base64Vector(1.5, 2.5, 3.5) + `"}
+	]}`
+
+	result, err := response.ParseEmbeddingsFloat32([]byte(body), nil)
+	if err != nil {
+		t.Fatalf("ParseEmbeddingsFloat32 failed: %v", err)
+	}
+
+	if result.Model != "embed" || result.Usage == nil || result.Usage.PromptTokens != 4 {
+		t.Errorf("unexpected metadata: %+v", result)
+	}
+	if !slices.Equal(result.Vectors[0], []float32{1.5, 2.5, 3.5}) {
+		t.Errorf("vector 0: got %v", result.Vectors[0])
+	}
+	if !slices.Equal(result.Vectors[1], []float32{0.25, -1e-3, 3}) {
+		t.Errorf("vector 1: got %v", result.Vectors[1])
+	}
+}
+
+func TestParseEmbeddingsFloat32_ReusesBuffer(t *testing.T) {
+	first, err := response.ParseEmbeddingsFloat32([]byte(`{"data":[{"index":0,"embedding":[1,2]},{"index":1,"embedding":[3,4]}]}`), nil)
+	if err != nil {
+		t.Fatalf("ParseEmbeddingsFloat32 failed: %v", err)
+	}
+	if len(first.Buffer) != 4 || cap(first.Vectors[0]) != 2 {
+		t.Fatalf("got buffer %v and vector capacity %d, want the full buffer and capped vectors", first.Buffer, cap(first.Vectors[0]))
+	}
+
+	// The next batch, in reverse response order, fits the first batch's buffer
+	second, err := response.ParseEmbeddingsFloat32([]byte(`{"data":[{"index":1,"embedding":[7,8]},{"index":0,"embedding":[5,6]}]}`), first.Buffer)
+	if err != nil {
+		t.Fatalf("ParseEmbeddingsFloat32 failed: %v", err)
+	}
+
+	if &second.Buffer[0] != &first.Buffer[0] {
+		t.Error("expected the second batch to reuse the first batch's buffer")
+	}
+	if &second.Vectors[1][0] != &first.Buffer[0] || &second.Vectors[0][0] != &first.Buffer[2] {
+		t.Error("expected the second batch's vectors to share the first batch's memory")
+	}
+	if !slices.Equal(second.Vectors[0], []float32{5, 6}) || !slices.Equal(second.Vectors[1], []float32{7, 8}) {
+		t.Errorf("got vectors %v, want [[5 6] [7 8]]", second.Vectors)
+	}
+}
+
+func TestParseEmbeddingsFloat32_MissingIndex(t *testing.T) {
+	result, err := response.ParseEmbeddingsFloat32([]byte(`{"data":[{"embedding":[1]},{"embedding":[2]}]}`), nil)
+	if err != nil {
+		t.Fatalf("ParseEmbeddingsFloat32 failed: %v", err)
+	}
+	if !slices.Equal(result.Vectors[0], []float32{1}) || !slices.Equal(result.Vectors[1], []float32{2}) {
+		t.Errorf("got vectors %v, want response order", result.Vectors)
+	}
+}
+
+func TestParseEmbeddingsFloat32_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not json":       `{`,
+		"not an array":   `{"data":[{"embedding":{}}]}`,
+		"bad number":     `{"data":[{"embedding":[1,"x"]}]}`,
+		"trailing comma": `{"data":[{"embedding":[1,]}]}`,
+		"bad base64":     `{"data":[{"embedding":"!!"}]}`,
+		"short base64":   `{"data":[{"embedding":"AAA="}]}`,
+		"repeated index": `{"data":[{"index":0,"embedding":[1]},{"index":0,"embedding":[2]}]}`,
+		"index range":    `{"data":[{"index":0,"embedding":[1]},{"index":2,"embedding":[2]}]}`,
+	}
+
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := response.ParseEmbeddingsFloat32([]byte(body), nil); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
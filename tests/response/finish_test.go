@@ -0,0 +1,113 @@
+package response_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestFinishReason_Normalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      response.FinishReason
+		expected response.FinishReason
+	}{
+		{name: "openai stop", raw: "stop", expected: response.FinishReasonStop},
+		{name: "anthropic end_turn", raw: "end_turn", expected: response.FinishReasonStop},
+		{name: "gemini uppercase", raw: "STOP", expected: response.FinishReasonStop},
+		{name: "openai length", raw: "length", expected: response.FinishReasonLength},
+		{name: "anthropic max_tokens", raw: "max_tokens", expected: response.FinishReasonLength},
+		{name: "openai tool_calls", raw: "tool_calls", expected: response.FinishReasonToolCalls},
+		{name: "anthropic tool_use", raw: "tool_use", expected: response.FinishReasonToolCalls},
+		{name: "openai content_filter", raw: "content_filter", expected: response.FinishReasonContentFilter},
+		{name: "gemini safety", raw: "SAFETY", expected: response.FinishReasonContentFilter},
+		{name: "unknown", raw: "Other", expected: "other"},
+		{name: "empty", raw: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.raw.Normalize(); got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChatResponse_FinishReason(t *testing.T) {
+	jsonData := `{
+		"model": "gpt-4",
+		"choices": [{
+			"index": 0,
+			"message": {"role": "assistant", "content": "Once upon a"},
+			"finish_reason": "length"
+		}]
+	}`
+
+	var resp response.ChatResponse
+	if err := json.Unmarshal([]byte(jsonData), &resp); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if resp.FinishReason() != response.FinishReasonLength {
+		t.Errorf("got finish reason %q, want %q", resp.FinishReason(), response.FinishReasonLength)
+	}
+
+	if !resp.Truncated() {
+		t.Error("expected response to be truncated")
+	}
+}
+
+func TestChatResponse_FinishReason_EmptyChoices(t *testing.T) {
+	resp := response.ChatResponse{}
+
+	if resp.FinishReason() != "" {
+		t.Errorf("got finish reason %q, want empty", resp.FinishReason())
+	}
+
+	if resp.Truncated() {
+		t.Error("expected response not to be truncated")
+	}
+}
+
+func TestToolsResponse_FinishReason(t *testing.T) {
+	jsonData := `{
+		"model": "gpt-4",
+		"choices": [{
+			"index": 0,
+			"message": {
+				"role": "assistant",
+				"content": "",
+				"tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "f", "arguments": "{}"}}]
+			},
+			"finish_reason": "tool_calls"
+		}]
+	}`
+
+	var resp response.ToolsResponse
+	if err := json.Unmarshal([]byte(jsonData), &resp); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if resp.FinishReason() != response.FinishReasonToolCalls {
+		t.Errorf("got finish reason %q, want %q", resp.FinishReason(), response.FinishReasonToolCalls)
+	}
+
+	if len(resp.ToolCalls()) != 1 {
+		t.Errorf("got %d tool calls, want 1", len(resp.ToolCalls()))
+	}
+}
+
+func TestStreamingChunk_FinishReason(t *testing.T) {
+	jsonData := `{"model": "gpt-4", "choices": [{"index": 0, "delta": {}, "finish_reason": "stop"}]}`
+
+	var chunk response.StreamingChunk
+	if err := json.Unmarshal([]byte(jsonData), &chunk); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if chunk.FinishReason() != response.FinishReasonStop {
+		t.Errorf("got finish reason %q, want %q", chunk.FinishReason(), response.FinishReasonStop)
+	}
+}
@@ -0,0 +1,103 @@
+package response_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestParseGeminiTools(t *testing.T) {
+	body := []byte(`{
+		"responseId": "resp-1",
+		"modelVersion": "gemini-test",
+		"candidates": [{
+			"index": 0,
+			"content": {"role": "model", "parts": [
+				{"text": "Checking."},
+				{"functionCall": {"id": "fc-1", "name": "get_weather", "args": {"city": "Paris"}}},
+				{"functionCall": {"name": "get_time"}}
+			]},
+			"finishReason": "STOP"
+		}],
+		"usageMetadata": {"promptTokenCount": 12, "candidatesTokenCount": 8, "totalTokenCount": 20}
+	}`)
+
+	resp, err := response.ParseGeminiTools(body)
+	if err != nil {
+		t.Fatalf("ParseGeminiTools failed: %v", err)
+	}
+
+	if resp.ID != "resp-1" || resp.Model != "gemini-test" {
+		t.Errorf("got id %q, model %q", resp.ID, resp.Model)
+	}
+	if resp.FinishReason() != response.FinishReasonToolCalls {
+		t.Errorf("got finish reason %q, want %q", resp.FinishReason(), response.FinishReasonToolCalls)
+	}
+	if got := resp.Choices[0].Message.Content; got != "Checking." {
+		t.Errorf("got content %q", got)
+	}
+
+	calls := resp.ToolCalls()
+	if len(calls) != 2 {
+		t.Fatalf("got %d tool calls, want 2", len(calls))
+	}
+	if calls[0].ID != "fc-1" || calls[0].Function.Name != "get_weather" || calls[0].Function.Arguments != `{"city": "Paris"}` {
+		t.Errorf("got first call %+v", calls[0])
+	}
+	if !strings.HasPrefix(calls[1].ID, response.GeminiCallIDPrefix) || calls[1].Function.Arguments != "{}" {
+		t.Errorf("got second call %+v, want a synthesized ID and empty arguments", calls[1])
+	}
+
+	if resp.Usage == nil || resp.Usage.TotalTokens != 20 || resp.Usage.CompletionTokens != 8 {
+		t.Errorf("got usage %+v, want 12/8/20", resp.Usage)
+	}
+}
+
+func TestParseGeminiTools_TextOnly(t *testing.T) {
+	resp, err := response.ParseGeminiTools([]byte(`{"candidates": [{"content": {"parts": [{"text": "Hi"}]}, "finishReason": "STOP"}]}`))
+	if err != nil {
+		t.Fatalf("ParseGeminiTools failed: %v", err)
+	}
+	if resp.FinishReason() != response.FinishReasonStop || len(resp.ToolCalls()) != 0 {
+		t.Errorf("got finish reason %q and %d calls, want stop and none", resp.FinishReason(), len(resp.ToolCalls()))
+	}
+}
+
+func TestParseGeminiTools_Grounding(t *testing.T) {
+	body := `{
+		"candidates": [{
+			"content": {"role": "model", "parts": [{"text": "Spain won."}]},
+			"finishReason": "STOP",
+			"groundingMetadata": {
+				"webSearchQueries": ["euro final result"],
+				"groundingChunks": [
+					{"web": {"uri": "https://example.com/a", "title": "example.com"}},
+					{"web": {"uri": "https://example.com/a", "title": "example.com"}},
+					{"web": {"uri": "https://news.test/b", "title": "news.test"}}
+				]
+			}
+		}]
+	}`
+
+	resp, err := response.ParseGeminiTools([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseGeminiTools failed: %v", err)
+	}
+
+	grounding := resp.Choices[0].Grounding
+	if grounding == nil {
+		t.Fatal("expected grounding")
+	}
+	if len(grounding.Queries) != 1 || grounding.Queries[0] != "euro final result" {
+		t.Errorf("got queries %v", grounding.Queries)
+	}
+	want := []response.SearchResult{
+		{URL: "https://example.com/a", Title: "example.com"},
+		{URL: "https://news.test/b", Title: "news.test"},
+	}
+	if !slices.Equal(grounding.Results, want) {
+		t.Errorf("got results %v, want %v", grounding.Results, want)
+	}
+}
@@ -0,0 +1,90 @@
+package response_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestChatResponse_Logprobs(t *testing.T) {
+	jsonData := `{
+		"model": "gpt-4",
+		"choices": [{
+			"index": 0,
+			"message": {"role": "assistant", "content": "Hi there"},
+			"logprobs": {
+				"content": [
+					{"token": "Hi", "logprob": -0.5, "bytes": [72, 105], "top_logprobs": [
+						{"token": "Hi", "logprob": -0.5},
+						{"token": "Hello", "logprob": -1.2}
+					]},
+					{"token": " there", "logprob": -0.25, "top_logprobs": []}
+				]
+			},
+			"finish_reason": "stop"
+		}]
+	}`
+
+	var resp response.ChatResponse
+	if err := json.Unmarshal([]byte(jsonData), &resp); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	logprobs := resp.Logprobs()
+	if logprobs == nil {
+		t.Fatal("logprobs is nil")
+	}
+
+	if len(logprobs.Content) != 2 {
+		t.Fatalf("got %d tokens, want 2", len(logprobs.Content))
+	}
+
+	if len(logprobs.Content[0].TopLogprobs) != 2 {
+		t.Errorf("got %d top logprobs, want 2", len(logprobs.Content[0].TopLogprobs))
+	}
+
+	if logprobs.Sum() != -0.75 {
+		t.Errorf("got sum %v, want -0.75", logprobs.Sum())
+	}
+
+	expected := math.Exp(0.375)
+	if math.Abs(logprobs.Perplexity()-expected) > 1e-9 {
+		t.Errorf("got perplexity %v, want %v", logprobs.Perplexity(), expected)
+	}
+}
+
+func TestChatResponse_Logprobs_NotRequested(t *testing.T) {
+	jsonData := `{"model": "gpt-4", "choices": [{"index": 0, "message": {"role": "assistant", "content": "Hi"}}]}`
+
+	var resp response.ChatResponse
+	if err := json.Unmarshal([]byte(jsonData), &resp); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if resp.Logprobs() != nil {
+		t.Error("expected nil logprobs")
+	}
+
+	if resp.Logprobs().Sum() != 0 {
+		t.Error("expected zero sum for nil logprobs")
+	}
+}
+
+func TestCollect_Logprobs(t *testing.T) {
+	stream := streamOf(t,
+		`{"model":"gpt-4","choices":[{"index":0,"delta":{"content":"Hi"},"logprobs":{"content":[{"token":"Hi","logprob":-0.5}]}}]}`,
+		`{"model":"gpt-4","choices":[{"index":0,"delta":{"content":" there"},"logprobs":{"content":[{"token":" there","logprob":-0.25}]}}]}`,
+	)
+
+	resp, err := response.Collect(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	if resp.Logprobs() == nil || len(resp.Logprobs().Content) != 2 {
+		t.Fatalf("got logprobs %+v, want 2 tokens", resp.Logprobs())
+	}
+}
@@ -0,0 +1,126 @@
+package response_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestParseWithMode_Lenient(t *testing.T) {
+	body := `{"model":"gpt-4","unknown_field":true,"choices":[]}`
+
+	if _, err := response.ParseWithMode(response.ParseLenient, protocol.Chat, []byte(body)); err != nil {
+		t.Errorf("lenient parse failed: %v", err)
+	}
+}
+
+func TestParseWithMode_Strict(t *testing.T) {
+	tests := []struct {
+		name    string
+		proto   protocol.Protocol
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "valid chat",
+			proto:   protocol.Chat,
+			body:    `{"model":"gpt-4","system_fingerprint":"fp_1","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"},"finish_reason":"stop"}]}`,
+			wantErr: "",
+		},
+		{
+			name:    "unmodeled fields",
+			proto:   protocol.Chat,
+			body:    `{"model":"gpt-4","service_tier":"default","choices":[{"index":0,"message":{"role":"assistant","content":"Hi","refusal":null,"annotations":[]}}]}`,
+			wantErr: "",
+		},
+		{
+			name:    "empty choices",
+			proto:   protocol.Chat,
+			body:    `{"model":"gpt-4","choices":[]}`,
+			wantErr: "choices is empty",
+		},
+		{
+			name:    "schema mismatch",
+			proto:   protocol.Chat,
+			body:    `{"model":"gpt-4","choices":"none"}`,
+			wantErr: "cannot unmarshal",
+		},
+		{
+			name:    "invalid tool arguments",
+			proto:   protocol.Tools,
+			body:    `{"model":"gpt-4","choices":[{"index":0,"message":{"role":"assistant","content":"","tool_calls":[{"id":"c","type":"function","function":{"name":"f","arguments":"{bad"}}]}}]}`,
+			wantErr: "arguments is not valid JSON",
+		},
+		{
+			name:    "empty embedding",
+			proto:   protocol.Embeddings,
+			body:    `{"object":"list","model":"embed","data":[{"embedding":[],"index":0,"object":"embedding"}]}`,
+			wantErr: "data[0].embedding is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := response.ParseWithMode(response.ParseStrict, tt.proto, []byte(tt.body))
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var parseErr *response.ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("got error %v, want *ParseError", err)
+			}
+
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseWithMode_UnknownMode(t *testing.T) {
+	body := `{"model":"gpt-4","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}`
+
+	if _, err := response.ParseWithMode("strcit", protocol.Chat, []byte(body)); err == nil {
+		t.Error("expected error for unknown parse mode")
+	}
+}
+
+func TestParseContext_UsesContextMode(t *testing.T) {
+	body := `{"model":"gpt-4","choices":[]}`
+
+	if _, err := response.ParseContext(context.Background(), protocol.Chat, []byte(body)); err != nil {
+		t.Errorf("default mode parse failed: %v", err)
+	}
+
+	ctx := response.WithParseMode(context.Background(), response.ParseStrict)
+	if _, err := response.ParseContext(ctx, protocol.Chat, []byte(body)); err == nil {
+		t.Error("expected strict parse error from context mode")
+	}
+}
+
+func TestSetDefaultParseMode(t *testing.T) {
+	t.Cleanup(func() { response.SetDefaultParseMode(response.ParseLenient) })
+
+	response.SetDefaultParseMode(response.ParseStrict)
+	if response.DefaultParseMode() != response.ParseStrict {
+		t.Errorf("got default %q, want %q", response.DefaultParseMode(), response.ParseStrict)
+	}
+
+	response.SetDefaultParseMode("bogus")
+	if response.DefaultParseMode() != response.ParseStrict {
+		t.Error("invalid mode should be ignored")
+	}
+
+	if response.ParseModeFromContext(context.Background()) != response.ParseStrict {
+		t.Error("context without mode should use package default")
+	}
+}
@@ -0,0 +1,168 @@
+package response_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+// Equivalent payloads in each provider's wire format must answer the
+// response accessors identically.
+
+var equivalentChat = map[string][]byte{
+	"openai": []byte(`{
+		"id": "1", "model": "m",
+		"choices": [{"index": 0, "message": {"role": "assistant", "content": "Hello there."}, "finish_reason": "length"}],
+		"usage": {"prompt_tokens": 5, "completion_tokens": 3, "total_tokens": 8}
+	}`),
+	"anthropic": []byte(`{
+		"id": "1", "model": "m",
+		"content": [{"type": "text", "text": "Hello "}, {"type": "text", "text": "there."}],
+		"stop_reason": "max_tokens",
+		"usage": {"input_tokens": 5, "output_tokens": 3}
+	}`),
+	"gemini": []byte(`{
+		"responseId": "1", "modelVersion": "m",
+		"candidates": [{"index": 0, "content": {"role": "model", "parts": [{"text": "Hello "}, {"text": "there."}]}, "finishReason": "MAX_TOKENS"}],
+		"usageMetadata": {"promptTokenCount": 5, "candidatesTokenCount": 3, "totalTokenCount": 8}
+	}`),
+}
+
+var equivalentTools = map[string][]byte{
+	"openai": []byte(`{
+		"id": "1", "model": "m",
+		"choices": [{"index": 0, "message": {"role": "assistant", "content": "Checking.", "tool_calls": [
+			{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}}
+		]}, "finish_reason": "tool_calls"}],
+		"usage": {"prompt_tokens": 5, "completion_tokens": 3, "total_tokens": 8}
+	}`),
+	"anthropic": []byte(`{
+		"id": "1", "model": "m",
+		"content": [
+			{"type": "text", "text": "Checking."},
+			{"type": "tool_use", "id": "call_1", "name": "get_weather", "input": {"city": "Paris"}}
+		],
+		"stop_reason": "tool_use",
+		"usage": {"input_tokens": 5, "output_tokens": 3}
+	}`),
+	"gemini": []byte(`{
+		"responseId": "1", "modelVersion": "m",
+		"candidates": [{"index": 0, "content": {"role": "model", "parts": [
+			{"text": "Checking."},
+			{"functionCall": {"id": "call_1", "name": "get_weather", "args": {"city": "Paris"}}}
+		]}, "finishReason": "STOP"}],
+		"usageMetadata": {"promptTokenCount": 5, "candidatesTokenCount": 3, "totalTokenCount": 8}
+	}`),
+}
+
+func TestNormalize_ChatConformance(t *testing.T) {
+	parsers := map[string]func([]byte) (*response.ChatResponse, error){
+		"openai":    response.ParseChat,
+		"anthropic": response.ParseAnthropicChat,
+		"gemini":    response.ParseGeminiChat,
+	}
+
+	for name, parse := range parsers {
+		t.Run(name, func(t *testing.T) {
+			body := equivalentChat[name]
+			resp, err := parse(body)
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+
+			if resp.ID != "1" || resp.Model != "m" {
+				t.Errorf("got id %q model %q, want 1 and m", resp.ID, resp.Model)
+			}
+			if got := resp.Content(); got != "Hello there." {
+				t.Errorf("got content %q, want %q", got, "Hello there.")
+			}
+			if got := resp.Contents(); !reflect.DeepEqual(got, []string{"Hello there."}) {
+				t.Errorf("got contents %q", got)
+			}
+			if got := resp.FinishReason(); got != response.FinishReasonLength {
+				t.Errorf("got finish reason %q, want %q", got, response.FinishReasonLength)
+			}
+			if !resp.Truncated() {
+				t.Error("response should be truncated")
+			}
+			if resp.Choice(0) == nil || resp.Choice(0).Message.Role != "assistant" {
+				t.Errorf("got choice %+v, want an assistant choice at index 0", resp.Choice(0))
+			}
+			wantUsage := &response.TokenUsage{PromptTokens: 5, CompletionTokens: 3, TotalTokens: 8}
+			if !reflect.DeepEqual(resp.Usage, wantUsage) {
+				t.Errorf("got usage %+v, want %+v", resp.Usage, wantUsage)
+			}
+			if len(resp.Citations()) != 0 {
+				t.Errorf("got citations %+v, want none", resp.Citations())
+			}
+			if string(resp.Raw()) != string(body) {
+				t.Error("Raw should return the original payload")
+			}
+		})
+	}
+}
+
+func TestNormalize_ToolsConformance(t *testing.T) {
+	parsers := map[string]func([]byte) (*response.ToolsResponse, error){
+		"openai":    response.ParseTools,
+		"anthropic": response.ParseAnthropicTools,
+		"gemini":    response.ParseGeminiTools,
+	}
+
+	for name, parse := range parsers {
+		t.Run(name, func(t *testing.T) {
+			body := equivalentTools[name]
+			resp, err := parse(body)
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+
+			if got := resp.Content(); got != "Checking." {
+				t.Errorf("got content %q, want %q", got, "Checking.")
+			}
+			if got := resp.FinishReason(); got != response.FinishReasonToolCalls {
+				t.Errorf("got finish reason %q, want %q", got, response.FinishReasonToolCalls)
+			}
+
+			calls := resp.ToolCalls()
+			if len(calls) != 1 {
+				t.Fatalf("got %d tool calls, want 1", len(calls))
+			}
+			if calls[0].ID != "call_1" || calls[0].Type != "function" || calls[0].Function.Name != "get_weather" {
+				t.Errorf("got tool call %+v", calls[0])
+			}
+			var args map[string]any
+			if err := json.Unmarshal([]byte(calls[0].Function.Arguments), &args); err != nil || args["city"] != "Paris" {
+				t.Errorf("got arguments %s, want city Paris", calls[0].Function.Arguments)
+			}
+
+			wantUsage := &response.TokenUsage{PromptTokens: 5, CompletionTokens: 3, TotalTokens: 8}
+			if !reflect.DeepEqual(resp.Usage, wantUsage) {
+				t.Errorf("got usage %+v, want %+v", resp.Usage, wantUsage)
+			}
+			if string(resp.Raw()) != string(body) {
+				t.Error("Raw should return the original payload")
+			}
+		})
+	}
+}
+
+func TestNormalize_GeminiUsageTotal(t *testing.T) {
+	body := []byte(`{
+		"candidates": [{"content": {"parts": [{"text": "Hi"}]}, "finishReason": "STOP"}],
+		"usageMetadata": {"promptTokenCount": 4, "candidatesTokenCount": 2}
+	}`)
+
+	resp, err := response.ParseGeminiChat(body)
+	if err != nil {
+		t.Fatalf("ParseGeminiChat failed: %v", err)
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens != 6 {
+		t.Errorf("got usage %+v, want a total of 6", resp.Usage)
+	}
+	if resp.FinishReason() != response.FinishReasonStop {
+		t.Errorf("got finish reason %q, want %q", resp.FinishReason(), response.FinishReasonStop)
+	}
+}
@@ -0,0 +1,165 @@
+package response_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestCompleteJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		ok       bool
+	}{
+		{name: "no json yet", input: "Sure, ", ok: false},
+		{name: "open object", input: `{`, expected: `{}`, ok: true},
+		{name: "partial key", input: `{"na`, expected: `{}`, ok: true},
+		{name: "key without value", input: `{"name":`, expected: `{}`, ok: true},
+		{name: "partial string value", input: `{"name": "Ad`, expected: `{"name": "Ad"}`, ok: true},
+		{name: "trailing comma", input: `{"name": "Ada",`, expected: `{"name": "Ada"}`, ok: true},
+		{name: "partial number dropped", input: `{"a": "x", "age": 3`, expected: `{"a": "x"}`, ok: true},
+		{name: "partial literal dropped", input: `{"ok": tr`, expected: `{}`, ok: true},
+		{name: "nested array", input: `{"tags": ["a", "b`, expected: `{"tags": ["a", "b"]}`, ok: true},
+		{name: "incomplete escape", input: `{"s": "line\`, expected: `{"s": "line"}`, ok: true},
+		{name: "incomplete unicode", input: `{"s": "x\u00`, expected: `{"s": "x"}`, ok: true},
+		{name: "leading prose", input: "Here you go:\n```json\n{\"a\": 1}", expected: `{"a": 1}`, ok: true},
+		{name: "complete", input: `{"a": [1, 2]}`, expected: `{"a": [1, 2]}`, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := response.CompleteJSON(tt.input)
+			if ok != tt.ok {
+				t.Fatalf("got ok %v, want %v", ok, tt.ok)
+			}
+			if got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePartialJSON(t *testing.T) {
+	value, ok := response.ParsePartialJSON(`{"items": [{"id": 1}, {"id"`)
+	if !ok {
+		t.Fatal("ParsePartialJSON returned false")
+	}
+
+	expected := map[string]any{"items": []any{map[string]any{"id": float64(1)}, map[string]any{}}}
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("got %v, want %v", value, expected)
+	}
+}
+
+type report struct {
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+}
+
+func TestJSONAccumulator(t *testing.T) {
+	var acc response.JSONAccumulator
+
+	acc.WriteString(`{"title": "Quarterly`)
+
+	var partial report
+	if !acc.Partial(&partial) {
+		t.Fatal("Partial returned false")
+	}
+	if partial.Title != "Quarterly" {
+		t.Errorf("got partial title %q, want %q", partial.Title, "Quarterly")
+	}
+
+	acc.WriteString(` Review", "tags": ["finance"]}`)
+
+	var final report
+	if err := acc.Final(&final); err != nil {
+		t.Fatalf("Final failed: %v", err)
+	}
+	if final.Title != "Quarterly Review" || len(final.Tags) != 1 {
+		t.Errorf("got final %+v", final)
+	}
+}
+
+func TestJSONAccumulator_FinalInvalid(t *testing.T) {
+	var acc response.JSONAccumulator
+	acc.WriteString(`{"title": "unterminated`)
+
+	var final report
+	if err := acc.Final(&final); err == nil {
+		t.Error("expected error for incomplete document")
+	}
+}
+
+func TestStreamJSON(t *testing.T) {
+	chunks := source(`{"title": "Q`, `3 Review", "tags": [`, `"finance"]}`)
+
+	var values []response.PartialValue[report]
+	for value := range response.StreamJSON[report](context.Background(), chunks) {
+		values = append(values, value)
+	}
+
+	if len(values) < 2 {
+		t.Fatalf("got %d values, want at least 2", len(values))
+	}
+
+	if values[0].Value.Title != "Q" {
+		t.Errorf("got first title %q, want %q", values[0].Value.Title, "Q")
+	}
+
+	last := values[len(values)-1]
+	if !last.Final || last.Err != nil {
+		t.Fatalf("got last %+v, want final without error", last)
+	}
+
+	if last.Value.Title != "Q3 Review" || len(last.Value.Tags) != 1 {
+		t.Errorf("got final value %+v", last.Value)
+	}
+}
+
+func TestStreamJSON_ChunkError(t *testing.T) {
+	streamErr := errors.New("stream failed")
+	ch := make(chan *response.StreamingChunk, 1)
+	ch <- &response.StreamingChunk{Error: streamErr}
+	close(ch)
+
+	var last response.PartialValue[report]
+	for value := range response.StreamJSON[report](context.Background(), ch) {
+		last = value
+	}
+
+	if !errors.Is(last.Err, streamErr) {
+		t.Errorf("got error %v, want %v", last.Err, streamErr)
+	}
+}
+
+func TestStreamJSON_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var last response.PartialValue[report]
+	for value := range response.StreamJSON[report](ctx, make(chan *response.StreamingChunk)) {
+		last = value
+	}
+
+	if !errors.Is(last.Err, context.Canceled) {
+		t.Errorf("got error %v, want %v", last.Err, context.Canceled)
+	}
+}
+
+func source(contents ...string) <-chan *response.StreamingChunk {
+	ch := make(chan *response.StreamingChunk, len(contents))
+	for _, content := range contents {
+		chunk := &response.StreamingChunk{}
+		chunk.Choices = append(chunk.Choices, response.StreamingChoice{
+			Delta: response.StreamingDelta{Content: content},
+		})
+		ch <- chunk
+	}
+	close(ch)
+	return ch
+}
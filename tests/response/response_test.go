@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
 	"github.com/tailored-agentic-units/tau-core/pkg/response"
 )
 
@@ -137,6 +138,31 @@ func TestStreamingChunk_Content_EmptyChoices(t *testing.T) {
 	}
 }
 
+func TestStreamingChunk_Choice(t *testing.T) {
+	jsonData := `{
+		"model": "gpt-4",
+		"choices": [
+			{"index": 1, "delta": {"content": "second"}, "finish_reason": null},
+			{"index": 0, "delta": {"content": "first"}, "finish_reason": "stop"}
+		]
+	}`
+
+	var chunk response.StreamingChunk
+	if err := json.Unmarshal([]byte(jsonData), &chunk); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if choice := chunk.Choice(0); choice == nil || choice.Delta.Content != "first" {
+		t.Errorf("got choice 0 %+v, want content %q", choice, "first")
+	}
+	if choice := chunk.Choice(1); choice == nil || choice.Delta.Content != "second" {
+		t.Errorf("got choice 1 %+v, want content %q", choice, "second")
+	}
+	if choice := chunk.Choice(2); choice != nil {
+		t.Errorf("got choice 2 %+v, want nil", choice)
+	}
+}
+
 func TestStreamingChunk_Unmarshal(t *testing.T) {
 	jsonData := `{
 		"id": "chatcmpl-123",
@@ -293,3 +319,92 @@ func TestParseChat_InvalidJSON(t *testing.T) {
 		t.Error("expected error for invalid JSON, got nil")
 	}
 }
+
+func TestChatResponse_Contents_MultipleChoices(t *testing.T) {
+	jsonData := `{
+		"model": "gpt-4",
+		"choices": [
+			{"index": 0, "message": {"role": "assistant", "content": "first"}},
+			{"index": 1, "message": {"role": "assistant", "content": "second"}},
+			{"index": 2, "message": {"role": "assistant", "content": null}}
+		]
+	}`
+
+	var resp response.ChatResponse
+	if err := json.Unmarshal([]byte(jsonData), &resp); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	contents := resp.Contents()
+	if len(contents) != 3 {
+		t.Fatalf("got %d contents, want 3", len(contents))
+	}
+
+	if contents[0] != "first" || contents[1] != "second" || contents[2] != "" {
+		t.Errorf("got contents %q, want [first second \"\"]", contents)
+	}
+
+	choice := resp.Choice(1)
+	if choice == nil {
+		t.Fatal("Choice(1) returned nil")
+	}
+
+	if choice.Content() != "second" {
+		t.Errorf("got choice content %q, want %q", choice.Content(), "second")
+	}
+
+	if resp.Choice(5) != nil {
+		t.Error("expected nil for missing choice index")
+	}
+}
+
+func TestParse_RetainsRawBody(t *testing.T) {
+	tests := []struct {
+		name  string
+		proto protocol.Protocol
+		body  string
+	}{
+		{name: "chat", proto: protocol.Chat, body: `{"model":"gpt-4","choices":[],"x_vendor":{"region":"eu"}}`},
+		{name: "tools", proto: protocol.Tools, body: `{"model":"gpt-4","choices":[],"x_vendor":true}`},
+		{name: "embeddings", proto: protocol.Embeddings, body: `{"object":"list","data":[],"model":"embed","x_vendor":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := response.Parse(tt.proto, []byte(tt.body))
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			raw, ok := result.(interface{ Raw() []byte })
+			if !ok {
+				t.Fatalf("result %T does not expose Raw", result)
+			}
+
+			if string(raw.Raw()) != tt.body {
+				t.Errorf("got raw %q, want %q", raw.Raw(), tt.body)
+			}
+		})
+	}
+}
+
+func TestParseStreamChunk_RetainsRawBody(t *testing.T) {
+	data := `{"model":"gpt-4","choices":[{"index":0,"delta":{"content":"Hi"}}]}`
+
+	chunk, err := response.ParseStreamChunk(protocol.Chat, []byte(data))
+	if err != nil {
+		t.Fatalf("ParseStreamChunk failed: %v", err)
+	}
+
+	if string(chunk.Raw()) != data {
+		t.Errorf("got raw %q, want %q", chunk.Raw(), data)
+	}
+}
+
+func TestChatResponse_Raw_Constructed(t *testing.T) {
+	resp := response.ChatResponse{Model: "gpt-4"}
+
+	if resp.Raw() != nil {
+		t.Error("expected nil raw for constructed response")
+	}
+}
@@ -0,0 +1,123 @@
+package response_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+)
+
+func TestStreamSSE(t *testing.T) {
+	stream := streamOf(t,
+		`{"id":"chatcmpl-1","model":"gpt-4","choices":[{"index":0,"delta":{"content":"Hel"}}]}`,
+		`{"id":"chatcmpl-1","model":"gpt-4","choices":[{"index":0,"delta":{"content":"lo"}}]}`,
+	)
+
+	recorder := httptest.NewRecorder()
+	if err := response.StreamSSE(recorder, stream); err != nil {
+		t.Fatalf("StreamSSE failed: %v", err)
+	}
+
+	if got := recorder.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("got content type %q, want %q", got, "text/event-stream")
+	}
+
+	events := strings.Split(strings.TrimSuffix(recorder.Body.String(), "\n\n"), "\n\n")
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3: %q", len(events), recorder.Body.String())
+	}
+
+	if !strings.HasPrefix(events[0], `data: {"id":"chatcmpl-1"`) || !strings.Contains(events[0], `"content":"Hel"`) {
+		t.Errorf("got first event %q", events[0])
+	}
+
+	if events[2] != "data: [DONE]" {
+		t.Errorf("got last event %q, want %q", events[2], "data: [DONE]")
+	}
+
+	if !recorder.Flushed {
+		t.Error("expected events to be flushed")
+	}
+}
+
+func TestStreamSSE_ChunkError(t *testing.T) {
+	streamErr := errors.New("upstream failed")
+	ch := make(chan *response.StreamingChunk, 1)
+	ch <- &response.StreamingChunk{Error: streamErr}
+	close(ch)
+
+	recorder := httptest.NewRecorder()
+	err := response.StreamSSE(recorder, ch)
+	if !errors.Is(err, streamErr) {
+		t.Errorf("got error %v, want %v", err, streamErr)
+	}
+
+	body := recorder.Body.String()
+	if !strings.Contains(body, `"message":"upstream failed"`) {
+		t.Errorf("got body %q, want error event", body)
+	}
+
+	if strings.Contains(body, "[DONE]") {
+		t.Error("expected no [DONE] event after an error")
+	}
+}
+
+// disconnectedWriter simulates a client that has gone away.
+type disconnectedWriter struct {
+	header http.Header
+}
+
+func (w *disconnectedWriter) Header() http.Header       { return w.header }
+func (w *disconnectedWriter) WriteHeader(int)           {}
+func (w *disconnectedWriter) Write([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func TestStreamSSE_ClientDisconnect(t *testing.T) {
+	ch := make(chan *response.StreamingChunk)
+	produced := make(chan struct{})
+
+	go func() {
+		defer close(produced)
+		defer close(ch)
+		for range 3 {
+			chunk := &response.StreamingChunk{}
+			chunk.Choices = append(chunk.Choices, response.StreamingChoice{
+				Delta: response.StreamingDelta{Content: "x"},
+			})
+			ch <- chunk
+		}
+	}()
+
+	if err := response.StreamSSE(&disconnectedWriter{header: make(http.Header)}, ch); err == nil {
+		t.Fatal("expected error for disconnected client")
+	}
+
+	select {
+	case <-produced:
+	case <-time.After(time.Second):
+		t.Fatal("producer blocked after client disconnect")
+	}
+}
+
+func TestStreamSSE_Heartbeat(t *testing.T) {
+	chunks := make(chan *response.StreamingChunk, 2)
+	chunks <- &response.StreamingChunk{Heartbeat: true, Idle: time.Second}
+	chunks <- &response.StreamingChunk{Model: "gpt-4"}
+	close(chunks)
+
+	recorder := httptest.NewRecorder()
+	if err := response.StreamSSE(recorder, chunks); err != nil {
+		t.Fatalf("StreamSSE failed: %v", err)
+	}
+
+	events := strings.Split(strings.TrimSuffix(recorder.Body.String(), "\n\n"), "\n\n")
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3: %q", len(events), recorder.Body.String())
+	}
+	if events[0] != ": heartbeat" {
+		t.Errorf("got first event %q, want heartbeat comment", events[0])
+	}
+}
@@ -0,0 +1,293 @@
+package schema_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/providers"
+	"github.com/tailored-agentic-units/tau-core/pkg/schema"
+)
+
+func personSchema(t *testing.T) *schema.Schema {
+	t.Helper()
+
+	s, err := schema.New("person", map[string]any{
+		"type":                 "object",
+		"required":             []string{"name", "age"},
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string", "minLength": 1},
+			"age":  map[string]any{"type": "integer", "minimum": 0},
+			"tags": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string", "enum": []string{"a", "b"}},
+				"uniqueItems": true,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return s
+}
+
+// errorStrings returns the "path: message" strings of a *ValidationError.
+func errorStrings(t *testing.T, err error) []string {
+	t.Helper()
+
+	var invalid *schema.ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected *schema.ValidationError, got %T: %v", err, err)
+	}
+	var out []string
+	for _, e := range invalid.Errors {
+		out = append(out, e.String())
+	}
+	return out
+}
+
+func TestNew_InvalidSchemas(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition map[string]any
+		want       string
+	}{
+		{"unknown type", map[string]any{"type": "strnig"}, "strnig"},
+		{"bad minimum", map[string]any{"minimum": "0"}, "minimum"},
+		{"bad pattern", map[string]any{"pattern": "("}, "pattern"},
+		{"unresolved ref", map[string]any{"$ref": "#/$defs/missing"}, "#/$defs/missing"},
+		{"bad required", map[string]any{"required": []any{1}}, "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schema.New("test", tt.definition)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	s := personSchema(t)
+
+	if err := s.ValidateJSON([]byte(`{"name": "Ada", "age": 36, "tags": ["a", "b"]}`)); err != nil {
+		t.Errorf("expected valid, got %v", err)
+	}
+
+	value := struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}{"Ada", 36}
+	if err := s.Validate(value); err != nil {
+		t.Errorf("expected Go value to validate, got %v", err)
+	}
+}
+
+func TestValidate_ErrorPaths(t *testing.T) {
+	s := personSchema(t)
+
+	err := s.ValidateJSON([]byte(`{"name": "", "age": 1.5, "tags": ["a", "c", "a"], "extra-field": true}`))
+	got := errorStrings(t, err)
+	want := []string{
+		`$.age: expected integer, got number`,
+		`$["extra-field"]: unexpected property`,
+		`$.name: must be at least 1 characters, got 0`,
+		`$.tags[2]: duplicates item 0`,
+		`$.tags[1]: must be one of ["a", "b"]`,
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("errors:\n got %q\nwant %q", got, want)
+	}
+	if !strings.HasPrefix(err.Error(), "schema person validation failed with 5 errors: ") {
+		t.Errorf("unexpected message: %v", err)
+	}
+}
+
+func TestValidate_MissingRequired(t *testing.T) {
+	err := personSchema(t).ValidateJSON([]byte(`{"name": "Ada"}`))
+	got := errorStrings(t, err)
+	if !slices.Equal(got, []string{`$: missing required property "age"`}) {
+		t.Errorf("unexpected errors: %q", got)
+	}
+}
+
+func TestValidate_Refs(t *testing.T) {
+	s, err := schema.New("tree", map[string]any{
+		"$ref": "#/$defs/node",
+		"$defs": map[string]any{
+			"node": map[string]any{
+				"type":     "object",
+				"required": []any{"value"},
+				"properties": map[string]any{
+					"value":    map[string]any{"type": "number", "exclusiveMinimum": 0},
+					"children": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/node"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if err := s.ValidateJSON([]byte(`{"value": 1, "children": [{"value": 2, "children": []}]}`)); err != nil {
+		t.Errorf("expected valid, got %v", err)
+	}
+
+	got := errorStrings(t, s.ValidateJSON([]byte(`{"value": 1, "children": [{"value": 0}, {}]}`)))
+	want := []string{
+		`$.children[0].value: must be greater than 0, got 0`,
+		`$.children[1]: missing required property "value"`,
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("errors:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestValidate_Combinators(t *testing.T) {
+	s, err := schema.New("value", map[string]any{
+		"anyOf": []any{
+			map[string]any{"type": "string"},
+			map[string]any{"type": "integer"},
+		},
+		"oneOf": []any{
+			map[string]any{"type": "number", "multipleOf": 2},
+			map[string]any{"type": "number", "multipleOf": 3},
+			map[string]any{"type": "string"},
+		},
+		"not": map[string]any{"const": "forbidden"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	for _, valid := range []string{`"ok"`, `4`, `9`} {
+		if err := s.ValidateJSON([]byte(valid)); err != nil {
+			t.Errorf("%s: expected valid, got %v", valid, err)
+		}
+	}
+
+	tests := map[string]string{
+		`true`:        "$: does not match any of the allowed schemas",
+		`6`:           "$: must match exactly one of the allowed schemas, matched 2",
+		`"forbidden"`: "$: must not match the excluded schema",
+	}
+	for input, want := range tests {
+		got := errorStrings(t, s.ValidateJSON([]byte(input)))
+		if !slices.Contains(got, want) {
+			t.Errorf("%s: expected %q, got %q", input, want, got)
+		}
+	}
+}
+
+func TestValidateJSON_Fence(t *testing.T) {
+	s := personSchema(t)
+
+	fenced := "```json\n{\"name\": \"Ada\", \"age\": 36}\n```"
+	if err := s.ValidateJSON([]byte(fenced)); err != nil {
+		t.Errorf("expected fenced JSON to validate, got %v", err)
+	}
+}
+
+func TestValidateJSON_SyntaxError(t *testing.T) {
+	s := personSchema(t)
+
+	for _, input := range []string{`{"name": `, `{"name": "Ada", "age": 1} trailing`} {
+		err := s.ValidateJSON([]byte(input))
+		var syntax *schema.SyntaxError
+		if !errors.As(err, &syntax) {
+			t.Errorf("%s: expected *schema.SyntaxError, got %T: %v", input, err, err)
+		}
+	}
+}
+
+func TestValidateToolCall(t *testing.T) {
+	tools := []providers.ToolDefinition{{
+		Name: "get_weather",
+		Parameters: map[string]any{
+			"type":     "object",
+			"required": []any{"location"},
+			"properties": map[string]any{
+				"location": map[string]any{"type": "string"},
+			},
+		},
+	}}
+
+	call := func(name, arguments string) protocol.ToolCall {
+		return protocol.ToolCall{ID: "call_1", Type: "function", Function: protocol.ToolCallFunction{Name: name, Arguments: arguments}}
+	}
+
+	if err := schema.ValidateToolCall(call("get_weather", `{"location": "Boston"}`), tools); err != nil {
+		t.Errorf("expected valid call, got %v", err)
+	}
+
+	got := errorStrings(t, schema.ValidateToolCall(call("get_weather", ""), tools))
+	if !slices.Equal(got, []string{`$: missing required property "location"`}) {
+		t.Errorf("unexpected errors: %q", got)
+	}
+
+	err := schema.ValidateToolCall(call("get_time", `{}`), tools)
+	if err == nil || !strings.Contains(err.Error(), `unknown tool "get_time"`) {
+		t.Errorf("expected unknown tool error, got %v", err)
+	}
+}
+
+func TestRepair(t *testing.T) {
+	s := personSchema(t)
+
+	var feedbacks []string
+	outputs := []string{`{"name": "Ada"}`, "```json\n{\"name\": \"Ada\", \"age\": 36}\n```"}
+	out, err := schema.Repair(context.Background(), s, 2, func(ctx context.Context, feedback string) (string, error) {
+		feedbacks = append(feedbacks, feedback)
+		return outputs[len(feedbacks)-1], nil
+	})
+	if err != nil {
+		t.Fatalf("Repair failed: %v", err)
+	}
+
+	if string(out) != `{"name": "Ada", "age": 36}` {
+		t.Errorf("unexpected output: %s", out)
+	}
+	if len(feedbacks) != 2 || feedbacks[0] != "" {
+		t.Fatalf("expected empty feedback then a retry, got %q", feedbacks)
+	}
+	if !strings.Contains(feedbacks[1], `$: missing required property "age"`) {
+		t.Errorf("expected feedback to include the error path, got %q", feedbacks[1])
+	}
+}
+
+func TestRepair_Exhausted(t *testing.T) {
+	s := personSchema(t)
+
+	calls := 0
+	_, err := schema.Repair(context.Background(), s, 2, func(ctx context.Context, feedback string) (string, error) {
+		calls++
+		return `not json`, nil
+	})
+
+	var syntax *schema.SyntaxError
+	if !errors.As(err, &syntax) {
+		t.Errorf("expected *schema.SyntaxError, got %T: %v", err, err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRepair_GenerateError(t *testing.T) {
+	boom := errors.New("boom")
+	_, err := schema.Repair(context.Background(), personSchema(t), 2, func(ctx context.Context, feedback string) (string, error) {
+		return "", boom
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("expected generate error, got %v", err)
+	}
+}
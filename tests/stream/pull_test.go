@@ -0,0 +1,218 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/stream"
+)
+
+// producer returns an open func whose goroutine sends chunks with the given
+// contents, stops when its context is cancelled, and closes stopped on exit.
+func producer(stopped chan<- struct{}, contents ...string) func(context.Context) (<-chan *response.StreamingChunk, error) {
+	return func(ctx context.Context) (<-chan *response.StreamingChunk, error) {
+		out := make(chan *response.StreamingChunk)
+		go func() {
+			defer close(stopped)
+			defer close(out)
+			for _, content := range contents {
+				chunk := &response.StreamingChunk{}
+				chunk.Choices = append(chunk.Choices, response.StreamingChoice{
+					Delta: response.StreamingDelta{Content: content},
+				})
+				select {
+				case out <- chunk:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return out, nil
+	}
+}
+
+func TestStream_Next(t *testing.T) {
+	ctx := context.Background()
+	stopped := make(chan struct{})
+
+	s, err := stream.Open(ctx, producer(stopped, "a", "b"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer s.Close()
+
+	var got []string
+	for {
+		chunk, err := s.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		got = append(got, chunk.Content())
+	}
+
+	if strings.Join(got, "") != "ab" {
+		t.Errorf("got %q, want %q", got, []string{"a", "b"})
+	}
+	if _, err := s.Next(ctx); err != io.EOF {
+		t.Errorf("got %v after the end, want io.EOF", err)
+	}
+}
+
+func TestStream_NextErrorChunk(t *testing.T) {
+	streamErr := errors.New("stream failed")
+	in := make(chan *response.StreamingChunk, 2)
+	in <- &response.StreamingChunk{Error: streamErr}
+	in <- &response.StreamingChunk{}
+	close(in)
+
+	s, err := stream.Open(context.Background(), func(context.Context) (<-chan *response.StreamingChunk, error) {
+		return in, nil
+	})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer s.Close()
+
+	for range 2 {
+		if _, err := s.Next(context.Background()); !errors.Is(err, streamErr) {
+			t.Errorf("got %v, want %v", err, streamErr)
+		}
+	}
+}
+
+func TestStream_NextContext(t *testing.T) {
+	never := make(chan *response.StreamingChunk)
+	s, err := stream.Open(context.Background(), func(context.Context) (<-chan *response.StreamingChunk, error) {
+		return never, nil
+	})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := s.Next(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	go func() {
+		never <- &response.StreamingChunk{Model: "late"}
+		close(never)
+	}()
+	chunk, err := s.Next(context.Background())
+	if err != nil || chunk.Model != "late" {
+		t.Errorf("got %v, %v, want the late chunk after a timed out Next", chunk, err)
+	}
+	s.Close()
+}
+
+func TestStream_OpenError(t *testing.T) {
+	openErr := errors.New("open failed")
+	_, err := stream.Open(context.Background(), func(context.Context) (<-chan *response.StreamingChunk, error) {
+		return nil, openErr
+	})
+	if !errors.Is(err, openErr) {
+		t.Errorf("got %v, want %v", err, openErr)
+	}
+}
+
+func TestStream_All(t *testing.T) {
+	ctx := context.Background()
+	stopped := make(chan struct{})
+
+	s, err := stream.Open(ctx, producer(stopped, "a", "b", "c"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	var got strings.Builder
+	for chunk, err := range s.All(ctx) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got.WriteString(chunk.Content())
+	}
+
+	if got.String() != "abc" {
+		t.Errorf("got %q, want %q", got.String(), "abc")
+	}
+}
+
+func TestStream_AllBreakStopsProducer(t *testing.T) {
+	ctx := context.Background()
+	stopped := make(chan struct{})
+
+	s, err := stream.Open(ctx, producer(stopped, "a", "b", "c"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	for range s.All(ctx) {
+		break
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("producer still running after the loop ended")
+	}
+	if _, err := s.Next(ctx); !errors.Is(err, stream.ErrClosed) {
+		t.Errorf("got %v after the loop ended, want ErrClosed", err)
+	}
+}
+
+func TestStream_AllYieldsError(t *testing.T) {
+	streamErr := errors.New("stream failed")
+	in := make(chan *response.StreamingChunk, 2)
+	in <- &response.StreamingChunk{}
+	in <- &response.StreamingChunk{Error: streamErr}
+	close(in)
+
+	s, err := stream.Open(context.Background(), func(context.Context) (<-chan *response.StreamingChunk, error) {
+		return in, nil
+	})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	var chunks int
+	var got error
+	for chunk, err := range s.All(context.Background()) {
+		if err != nil {
+			got = err
+			continue
+		}
+		if chunk != nil {
+			chunks++
+		}
+	}
+
+	if chunks != 1 || !errors.Is(got, streamErr) {
+		t.Errorf("got %d chunks and error %v, want 1 chunk and %v", chunks, got, streamErr)
+	}
+}
+
+func TestStream_CloseIdempotent(t *testing.T) {
+	stopped := make(chan struct{})
+	s, err := stream.Open(context.Background(), producer(stopped, "a", "b"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("second Close failed: %v", err)
+	}
+	<-stopped
+}
@@ -0,0 +1,367 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/stream"
+)
+
+func source(contents ...string) <-chan *response.StreamingChunk {
+	ch := make(chan *response.StreamingChunk, len(contents))
+	for _, content := range contents {
+		chunk := &response.StreamingChunk{Model: "test-model"}
+		chunk.Choices = append(chunk.Choices, response.StreamingChoice{
+			Delta: response.StreamingDelta{Content: content},
+		})
+		ch <- chunk
+	}
+	close(ch)
+	return ch
+}
+
+// withNils returns a closed channel of chunks with the given contents, each
+// preceded by a nil chunk and the last followed by one.
+func withNils(contents ...string) <-chan *response.StreamingChunk {
+	ch := make(chan *response.StreamingChunk, 2*len(contents)+1)
+	for chunk := range source(contents...) {
+		ch <- nil
+		ch <- chunk
+	}
+	ch <- nil
+	close(ch)
+	return ch
+}
+
+func drain(ch <-chan *response.StreamingChunk) []string {
+	var contents []string
+	for chunk := range ch {
+		contents = append(contents, chunk.Content())
+	}
+	return contents
+}
+
+func TestMap(t *testing.T) {
+	ctx := context.Background()
+
+	out := stream.Map(ctx, source("a", "b"), func(c *response.StreamingChunk) *response.StreamingChunk {
+		c.Choices[0].Delta.Content = strings.ToUpper(c.Content())
+		return c
+	})
+
+	got := strings.Join(drain(out), "")
+	if got != "AB" {
+		t.Errorf("got %q, want %q", got, "AB")
+	}
+}
+
+func TestMap_ErrorChunkPassesThrough(t *testing.T) {
+	streamErr := errors.New("stream failed")
+	in := make(chan *response.StreamingChunk, 1)
+	in <- &response.StreamingChunk{Error: streamErr}
+	close(in)
+
+	called := false
+	out := stream.Map(context.Background(), in, func(c *response.StreamingChunk) *response.StreamingChunk {
+		called = true
+		return c
+	})
+
+	chunk := <-out
+	if chunk.Error != streamErr {
+		t.Errorf("got error %v, want %v", chunk.Error, streamErr)
+	}
+
+	if called {
+		t.Error("map function called for error chunk")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	out := stream.Filter(context.Background(), source("keep", "", "this"), func(c *response.StreamingChunk) bool {
+		return c.Content() != ""
+	})
+
+	got := drain(out)
+	if len(got) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(got))
+	}
+}
+
+func TestMapFilter_SkipNilChunks(t *testing.T) {
+	in := make(chan *response.StreamingChunk, 3)
+	in <- nil
+	for chunk := range source("a") {
+		in <- chunk
+	}
+	in <- nil
+	close(in)
+
+	mapped := stream.Map(context.Background(), in, func(c *response.StreamingChunk) *response.StreamingChunk {
+		return c
+	})
+	out := stream.Filter(context.Background(), mapped, func(c *response.StreamingChunk) bool {
+		return true
+	})
+
+	got := drain(out)
+	if len(got) != 1 || got[0] != "a" {
+		t.Errorf("got %q, want only the non-nil chunk", got)
+	}
+}
+
+func TestMapFilter_StopOnCancelWithoutInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan *response.StreamingChunk)
+
+	mapped := stream.Map(ctx, in, func(c *response.StreamingChunk) *response.StreamingChunk {
+		return c
+	})
+	filtered := stream.Filter(ctx, in, func(c *response.StreamingChunk) bool {
+		return true
+	})
+	cancel()
+
+	// Both outputs close although the input never does.
+	for range mapped {
+	}
+	for range filtered {
+	}
+}
+
+func TestTee(t *testing.T) {
+	first, second := stream.Tee(context.Background(), source("x", "y", "z"))
+
+	var wg sync.WaitGroup
+	var a, b []string
+	wg.Go(func() { a = drain(first) })
+	wg.Go(func() { b = drain(second) })
+	wg.Wait()
+
+	if strings.Join(a, "") != "xyz" {
+		t.Errorf("got first %q, want %q", strings.Join(a, ""), "xyz")
+	}
+
+	if strings.Join(b, "") != "xyz" {
+		t.Errorf("got second %q, want %q", strings.Join(b, ""), "xyz")
+	}
+}
+
+// choices returns a chunk carrying the given contents as choices, keyed by
+// choice index.
+func choices(contents map[int]string) *response.StreamingChunk {
+	chunk := &response.StreamingChunk{Model: "test-model"}
+	for index, content := range contents {
+		chunk.Choices = append(chunk.Choices, response.StreamingChoice{
+			Index: index,
+			Delta: response.StreamingDelta{Content: content},
+		})
+	}
+	return chunk
+}
+
+func TestTee_SkipNilChunks(t *testing.T) {
+	first, second := stream.Tee(context.Background(), withNils("x", "y"))
+
+	var got [2]string
+	var wg sync.WaitGroup
+	wg.Go(func() { got[0] = strings.Join(drain(first), "") })
+	wg.Go(func() { got[1] = strings.Join(drain(second), "") })
+	wg.Wait()
+
+	if got[0] != "xy" || got[1] != "xy" {
+		t.Errorf("got %q, want both outputs %q", got, "xy")
+	}
+}
+
+func TestTee_StopOnCancelWithoutInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan *response.StreamingChunk)
+
+	first, second := stream.Tee(ctx, in)
+	cancel()
+
+	// Both outputs close although the input never does.
+	for range first {
+	}
+	for range second {
+	}
+}
+
+func TestDemux(t *testing.T) {
+	streamErr := errors.New("stream failed")
+	in := make(chan *response.StreamingChunk, 5)
+	in <- choices(map[int]string{0: "a", 1: "x"})
+	in <- choices(map[int]string{1: "y"})
+	in <- choices(map[int]string{0: "b", 5: "dropped"})
+	in <- &response.StreamingChunk{Usage: &response.TokenUsage{TotalTokens: 4}}
+	in <- &response.StreamingChunk{Error: streamErr}
+	close(in)
+
+	outputs := stream.Demux(context.Background(), in, 2)
+	if len(outputs) != 2 {
+		t.Fatalf("got %d outputs, want 2", len(outputs))
+	}
+
+	type result struct {
+		content string
+		usage   bool
+		err     error
+	}
+	results := make([]result, len(outputs))
+
+	var wg sync.WaitGroup
+	for i, out := range outputs {
+		wg.Go(func() {
+			for chunk := range out {
+				switch {
+				case chunk.Error != nil:
+					results[i].err = chunk.Error
+				case chunk.Usage != nil:
+					results[i].usage = true
+				default:
+					if len(chunk.Choices) != 1 || chunk.Choices[0].Index != i {
+						t.Errorf("output %d got choices %+v", i, chunk.Choices)
+					}
+					results[i].content += chunk.Content()
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	for i, want := range []string{"ab", "xy"} {
+		if results[i].content != want {
+			t.Errorf("output %d got content %q, want %q", i, results[i].content, want)
+		}
+		if !results[i].usage {
+			t.Errorf("output %d missed the usage chunk", i)
+		}
+		if !errors.Is(results[i].err, streamErr) {
+			t.Errorf("output %d got error %v, want %v", i, results[i].err, streamErr)
+		}
+	}
+}
+
+func TestDemux_SkipNilChunks(t *testing.T) {
+	in := make(chan *response.StreamingChunk, 3)
+	in <- nil
+	in <- choices(map[int]string{0: "a", 1: "x"})
+	in <- nil
+	close(in)
+
+	outputs := stream.Demux(context.Background(), in, 2)
+
+	var wg sync.WaitGroup
+	got := make([]string, len(outputs))
+	for i, out := range outputs {
+		wg.Go(func() {
+			got[i] = strings.Join(drain(out), "")
+		})
+	}
+	wg.Wait()
+
+	if got[0] != "a" || got[1] != "x" {
+		t.Errorf("got %q, want [a x]", got)
+	}
+}
+
+func TestDemux_StopOnCancelWithoutInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan *response.StreamingChunk)
+
+	outputs := stream.Demux(ctx, in, 2)
+	cancel()
+
+	// Every output closes although the input never does.
+	for _, out := range outputs {
+		for range out {
+		}
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	out := stream.Buffer(context.Background(), source("a", "b", "c"), 3)
+
+	got := strings.Join(drain(out), "")
+	if got != "abc" {
+		t.Errorf("got %q, want %q", got, "abc")
+	}
+}
+
+func TestBuffer_SkipNilChunks(t *testing.T) {
+	out := stream.Buffer(context.Background(), withNils("a", "b"), 4)
+
+	got := strings.Join(drain(out), "")
+	if got != "ab" {
+		t.Errorf("got %q, want %q", got, "ab")
+	}
+}
+
+func TestBuffer_StopOnCancelWithoutInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan *response.StreamingChunk)
+
+	out := stream.Buffer(ctx, in, 2)
+	cancel()
+
+	// The output closes although the input never does.
+	for range out {
+	}
+}
+
+func TestToWriter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	if err := stream.ToWriter(context.Background(), source("Hello", ", ", "world"), recorder); err != nil {
+		t.Fatalf("ToWriter failed: %v", err)
+	}
+
+	if recorder.Body.String() != "Hello, world" {
+		t.Errorf("got %q, want %q", recorder.Body.String(), "Hello, world")
+	}
+
+	if !recorder.Flushed {
+		t.Error("expected writer to be flushed")
+	}
+}
+
+func TestToWriter_ChunkError(t *testing.T) {
+	streamErr := errors.New("stream failed")
+	in := make(chan *response.StreamingChunk, 1)
+	in <- &response.StreamingChunk{Error: streamErr}
+	close(in)
+
+	var sb strings.Builder
+	err := stream.ToWriter(context.Background(), in, &sb)
+	if !errors.Is(err, streamErr) {
+		t.Errorf("got error %v, want %v", err, streamErr)
+	}
+}
+
+func TestToWriter_SkipNilChunks(t *testing.T) {
+	var sb strings.Builder
+	if err := stream.ToWriter(context.Background(), withNils("Hello", " world"), &sb); err != nil {
+		t.Fatalf("ToWriter failed: %v", err)
+	}
+
+	if sb.String() != "Hello world" {
+		t.Errorf("got %q, want %q", sb.String(), "Hello world")
+	}
+}
+
+func TestToWriter_StopOnCancelWithoutInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var sb strings.Builder
+	err := stream.ToWriter(ctx, make(chan *response.StreamingChunk), &sb)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
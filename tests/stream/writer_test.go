@@ -0,0 +1,138 @@
+package stream_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/stream"
+)
+
+// recorder records each write to the underlying writer and counts flushes.
+type recorder struct {
+	writes  []string
+	flushes int
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	r.writes = append(r.writes, string(p))
+	return len(p), nil
+}
+
+func (r *recorder) Flush() {
+	r.flushes++
+}
+
+func writeAll(t *testing.T, w *stream.Writer, chunks ...string) {
+	t.Helper()
+	for _, chunk := range chunks {
+		n, err := w.WriteString(chunk)
+		if err != nil || n != len(chunk) {
+			t.Fatalf("WriteString(%q) = %d, %v", chunk, n, err)
+		}
+	}
+}
+
+func TestWriter_RuneSafe(t *testing.T) {
+	rec := &recorder{}
+	w := stream.NewWriter(rec)
+
+	euro := "€" // 3 bytes
+	writeAll(t, w, "price: "+euro[:1], euro[1:2], euro[2:]+"5")
+
+	if len(rec.writes) != 2 || rec.writes[0] != "price: " || rec.writes[1] != euro+"5" {
+		t.Errorf("got writes %q", rec.writes)
+	}
+	if rec.flushes != 2 {
+		t.Errorf("expected a flush per write, got %d", rec.flushes)
+	}
+}
+
+func TestWriter_ANSISafe(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		want   []string
+	}{
+		{
+			name:   "csi",
+			chunks: []string{"red: \x1b[3", "1mhot\x1b[0m"},
+			want:   []string{"red: ", "\x1b[31mhot\x1b[0m"},
+		},
+		{
+			name:   "lone escape",
+			chunks: []string{"a\x1b", "[1mb"},
+			want:   []string{"a", "\x1b[1mb"},
+		},
+		{
+			name:   "osc hyperlink",
+			chunks: []string{"\x1b]8;;https://go.dev", "\x07Go\x1b]8;;\x07"},
+			want:   []string{"\x1b]8;;https://go.dev\x07Go\x1b]8;;\x07"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			w := stream.NewWriter(rec)
+			writeAll(t, w, tt.chunks...)
+
+			if strings.Join(rec.writes, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got writes %q, want %q", rec.writes, tt.want)
+			}
+			if w.Buffered() != 0 {
+				t.Errorf("expected nothing buffered, got %d bytes", w.Buffered())
+			}
+		})
+	}
+}
+
+func TestWriter_WordBuffering(t *testing.T) {
+	rec := &recorder{}
+	w := stream.NewWriter(rec, stream.WithWordBuffering(), stream.WithAutoFlush(false))
+
+	writeAll(t, w, "Hel", "lo wo", "rld")
+	if strings.Join(rec.writes, "") != "Hello " {
+		t.Errorf("got writes %q before close", rec.writes)
+	}
+	if w.Buffered() != len("world") {
+		t.Errorf("expected the partial word to be held, got %d bytes", w.Buffered())
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if strings.Join(rec.writes, "") != "Hello world" {
+		t.Errorf("got %q after close", strings.Join(rec.writes, ""))
+	}
+	if rec.flushes != 1 {
+		t.Errorf("expected only the close to flush, got %d", rec.flushes)
+	}
+}
+
+func TestWriter_WordBufferingLongRun(t *testing.T) {
+	var buf bytes.Buffer
+	w := stream.NewWriter(&buf, stream.WithWordBuffering())
+
+	long := strings.Repeat("x", 300)
+	writeAll(t, w, long)
+	if buf.String() != long {
+		t.Errorf("expected a long run without whitespace to be written, got %d bytes", buf.Len())
+	}
+}
+
+func TestWriter_CloseWritesIncomplete(t *testing.T) {
+	var buf bytes.Buffer
+	w := stream.NewWriter(&buf)
+
+	writeAll(t, w, "done\x1b[")
+	if buf.String() != "done" {
+		t.Fatalf("got %q before close", buf.String())
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if buf.String() != "done\x1b[" {
+		t.Errorf("got %q after close", buf.String())
+	}
+}
@@ -0,0 +1,148 @@
+package tokenizer_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/tokenizer"
+)
+
+// testRanks builds a byte-level vocabulary with merges for "hello".
+func testRanks() map[string]int {
+	ranks := make(map[string]int)
+	for b := range 256 {
+		ranks[string([]byte{byte(b)})] = b
+	}
+	ranks["he"] = 256
+	ranks["ll"] = 257
+	ranks["hell"] = 258
+	ranks["hello"] = 259
+	ranks[" w"] = 260
+	return ranks
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "words", input: "Hello world", expected: []string{"Hello", " world"}},
+		{name: "contraction", input: "I'm here", expected: []string{"I", "'m", " here"}},
+		{name: "numbers", input: "12345", expected: []string{"123", "45"}},
+		{name: "punctuation", input: "Hi, there!", expected: []string{"Hi", ",", " there", "!"}},
+		{name: "double space", input: "a  b", expected: []string{"a", " ", " b"}},
+		{name: "newlines", input: "a\n\nb", expected: []string{"a", "\n\n", "b"}},
+		{name: "trailing space", input: "end  ", expected: []string{"end", "  "}},
+		{name: "unicode", input: "café naïve", expected: []string{"café", " naïve"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tokenizer.Split(tt.input)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+			if strings.Join(got, "") != tt.input {
+				t.Errorf("pieces do not reassemble input")
+			}
+		})
+	}
+}
+
+func TestBPE_EncodeDecode(t *testing.T) {
+	bpe, err := tokenizer.NewBPE("test", testRanks())
+	if err != nil {
+		t.Fatalf("NewBPE failed: %v", err)
+	}
+
+	tokens := bpe.Encode("hello world")
+	expected := []int{259, 260, 'o', 'r', 'l', 'd'}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("got tokens %v, want %v", tokens, expected)
+	}
+
+	if got := bpe.Decode(tokens); got != "hello world" {
+		t.Errorf("got decoded %q, want %q", got, "hello world")
+	}
+
+	if bpe.Count("hell") != 1 {
+		t.Errorf("got count %d, want 1", bpe.Count("hell"))
+	}
+}
+
+func TestNewBPE_IncompleteVocabulary(t *testing.T) {
+	_, err := tokenizer.NewBPE("partial", map[string]int{"a": 0})
+	if err == nil {
+		t.Error("expected error for incomplete byte vocabulary")
+	}
+}
+
+func TestLoadBPE(t *testing.T) {
+	var sb strings.Builder
+	for token, rank := range testRanks() {
+		fmt.Fprintf(&sb, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), rank)
+	}
+
+	bpe, err := tokenizer.LoadBPE("loaded", strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("LoadBPE failed: %v", err)
+	}
+
+	if bpe.Name() != "loaded" {
+		t.Errorf("got name %q, want %q", bpe.Name(), "loaded")
+	}
+
+	if bpe.Count("hello") != 1 {
+		t.Errorf("got count %d, want 1", bpe.Count("hello"))
+	}
+}
+
+func TestLoadBPE_InvalidLine(t *testing.T) {
+	_, err := tokenizer.LoadBPE("bad", strings.NewReader("aGVsbG8=\n"))
+	if err == nil {
+		t.Error("expected error for line without rank")
+	}
+}
+
+func TestHeuristic(t *testing.T) {
+	h := tokenizer.Heuristic{}
+
+	if h.Count("") != 0 {
+		t.Errorf("got count %d for empty text, want 0", h.Count(""))
+	}
+
+	// "The quick brown fox" splits into four short pieces.
+	if got := h.Count("The quick brown fox"); got != 4 {
+		t.Errorf("got count %d, want 4", got)
+	}
+}
+
+func TestForModel(t *testing.T) {
+	bpe, err := tokenizer.NewBPE("test_registry_base", testRanks())
+	if err != nil {
+		t.Fatalf("NewBPE failed: %v", err)
+	}
+
+	tokenizer.Register(bpe)
+	tokenizer.RegisterModel("test-model", "test_registry_base")
+
+	if got := tokenizer.ForModel("Test-Model-Large"); got.Name() != "test_registry_base" {
+		t.Errorf("got tokenizer %q, want %q", got.Name(), "test_registry_base")
+	}
+
+	if got := tokenizer.ForModel("test_registry_base"); got.Name() != "test_registry_base" {
+		t.Errorf("got tokenizer %q for encoding name, want %q", got.Name(), "test_registry_base")
+	}
+
+	if got := tokenizer.ForModel("unknown-model"); got.Name() != "heuristic" {
+		t.Errorf("got tokenizer %q, want heuristic", got.Name())
+	}
+
+	if _, ok := tokenizer.Get("test_registry_base"); !ok {
+		t.Error("Get did not find registered tokenizer")
+	}
+}
@@ -0,0 +1,204 @@
+package vectorstore_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/vectorstore"
+)
+
+func seed(t *testing.T) *vectorstore.Memory {
+	t.Helper()
+
+	store := vectorstore.NewMemory()
+	err := store.Add(context.Background(),
+		vectorstore.Document{ID: "k8s", Content: "Kubernetes", Vector: []float64{1, 0, 0}, Metadata: map[string]any{"topic": "ops", "year": 2014}},
+		vectorstore.Document{ID: "docker", Content: "Docker", Vector: []float64{0.9, 0.1, 0}, Metadata: map[string]any{"topic": "ops", "year": 2013}},
+		vectorstore.Document{ID: "go", Content: "Go", Vector: []float64{0, 1, 0}, Metadata: map[string]any{"topic": "lang", "year": 2009}},
+	)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	return store
+}
+
+func ids(results []vectorstore.Result) []string {
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = r.ID
+	}
+	return out
+}
+
+func TestMemory_Search(t *testing.T) {
+	store := seed(t)
+
+	tests := []struct {
+		name   string
+		k      int
+		filter vectorstore.Filter
+		want   []string
+	}{
+		{name: "top k", k: 2, want: []string{"k8s", "docker"}},
+		{name: "all", k: 0, want: []string{"k8s", "docker", "go"}},
+		{name: "filter", k: 5, filter: vectorstore.Filter{"topic": "lang"}, want: []string{"go"}},
+		{name: "numeric filter", k: 5, filter: vectorstore.Filter{"year": 2013.0}, want: []string{"docker"}},
+		{name: "no match", k: 5, filter: vectorstore.Filter{"topic": "ml"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.Search(context.Background(), []float64{1, 0, 0}, tt.k, tt.filter)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+
+			got := ids(results)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemory_Search_Scores(t *testing.T) {
+	store := seed(t)
+
+	results, _ := store.Search(context.Background(), []float64{1, 0, 0}, 3, nil)
+	if results[0].Score != 1 {
+		t.Errorf("got score %v for identical vector, want 1", results[0].Score)
+	}
+	if results[2].Score != 0 {
+		t.Errorf("got score %v for orthogonal vector, want 0", results[2].Score)
+	}
+}
+
+func TestMemory_Add_Validation(t *testing.T) {
+	store := seed(t)
+
+	tests := []struct {
+		name string
+		doc  vectorstore.Document
+		want error
+	}{
+		{name: "missing ID", doc: vectorstore.Document{Vector: []float64{1, 0, 0}}, want: vectorstore.ErrMissingID},
+		{name: "missing vector", doc: vectorstore.Document{ID: "x"}, want: vectorstore.ErrMissingVector},
+		{name: "dimension mismatch", doc: vectorstore.Document{ID: "x", Vector: []float64{1, 0}}, want: vectorstore.ErrDimensionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := store.Add(context.Background(), tt.doc); !errors.Is(err, tt.want) {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := store.Search(context.Background(), []float64{1, 0}, 1, nil); !errors.Is(err, vectorstore.ErrDimensionMismatch) {
+		t.Errorf("Search: got error %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestMemory_AddReplacesAndDelete(t *testing.T) {
+	store := seed(t)
+
+	store.Add(context.Background(), vectorstore.Document{ID: "go", Content: "Golang", Vector: []float64{0, 0, 1}})
+	if doc, _ := store.Get("go"); doc.Content != "Golang" {
+		t.Errorf("got content %q, want replaced document", doc.Content)
+	}
+	if store.Len() != 3 {
+		t.Errorf("got %d documents, want 3", store.Len())
+	}
+
+	store.Delete(context.Background(), "go", "missing")
+	if _, ok := store.Get("go"); ok {
+		t.Error("expected deleted document to be gone")
+	}
+	if store.Len() != 2 {
+		t.Errorf("got %d documents, want 2", store.Len())
+	}
+}
+
+func TestMemory_Persistence(t *testing.T) {
+	for _, file := range []string{"index.json", "index.gob"} {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), file)
+			if err := seed(t).SaveFile(path); err != nil {
+				t.Fatalf("SaveFile failed: %v", err)
+			}
+
+			store, err := vectorstore.LoadFile(path)
+			if err != nil {
+				t.Fatalf("LoadFile failed: %v", err)
+			}
+
+			if store.Len() != 3 {
+				t.Fatalf("got %d documents, want 3", store.Len())
+			}
+
+			results, _ := store.Search(context.Background(), []float64{1, 0, 0}, 5, vectorstore.Filter{"year": 2014})
+			if got := ids(results); len(got) != 1 || got[0] != "k8s" {
+				t.Errorf("got %v, want [k8s]", got)
+			}
+		})
+	}
+}
+
+// embedAgent returns a vector derived from the input's keywords.
+type embedAgent struct {
+	*mock.MockAgent
+	calls int
+}
+
+func (a *embedAgent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
+	a.calls++
+	vector := []float64{0, 0}
+	if strings.Contains(input, "container") {
+		vector[0] = 1
+	}
+	if strings.Contains(input, "language") {
+		vector[1] = 1
+	}
+
+	resp := &response.EmbeddingsResponse{}
+	resp.Data = append(resp.Data, struct {
+		Embedding []float64 `json:"embedding"`
+		Index     int       `json:"index"`
+		Object    string    `json:"object"`
+	}{Embedding: vector})
+	return resp, nil
+}
+
+func TestIndexAndQuery(t *testing.T) {
+	a := &embedAgent{MockAgent: mock.NewMockAgent()}
+	store := vectorstore.NewMemory()
+
+	docs := []vectorstore.Document{
+		{Content: "container runtime"},
+		{ID: "go", Content: "programming language"},
+		{ID: "pre", Content: "ignored", Vector: []float64{1, 1}},
+	}
+	if err := vectorstore.Index(context.Background(), a, store, docs); err != nil {
+		t.Fatalf("Index failed: %v", err)
+	}
+
+	if docs[0].ID == "" {
+		t.Error("expected generated ID")
+	}
+	if a.calls != 2 {
+		t.Errorf("got %d embed calls, want 2 (pre-embedded document skipped)", a.calls)
+	}
+
+	results, err := vectorstore.Query(context.Background(), a, store, "a language", 1, nil)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "go" {
+		t.Errorf("got %v, want [go]", ids(results))
+	}
+}
@@ -0,0 +1,265 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tailored-agentic-units/tau-core/pkg/mock"
+	"github.com/tailored-agentic-units/tau-core/pkg/protocol"
+	"github.com/tailored-agentic-units/tau-core/pkg/response"
+	"github.com/tailored-agentic-units/tau-core/pkg/workflow"
+)
+
+func chatResponse(content string) *response.ChatResponse {
+	return &response.ChatResponse{
+		Choices: []response.ChatChoice{{Message: protocol.NewMessage("assistant", content)}},
+	}
+}
+
+func fail(err error) workflow.Step {
+	return func(ctx context.Context, input string) (string, error) {
+		return "", err
+	}
+}
+
+func TestSequence(t *testing.T) {
+	a := mock.NewMockAgent(mock.WithChatResponse(chatResponse("a summary"), nil))
+
+	step := workflow.Sequence(
+		workflow.Prompt("Summarize: %s"),
+		workflow.Chat(a),
+		workflow.Transform(strings.ToUpper),
+	)
+
+	got, err := step.Run(context.Background(), "long text")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if got != "A SUMMARY" {
+		t.Errorf("got %q, want %q", got, "A SUMMARY")
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		input    string
+		want     string
+	}{
+		{name: "placeholder", template: "Summarize: %s", input: "text", want: "Summarize: text"},
+		{name: "literal percent", template: "Cut it by 50% or more: %s", input: "text", want: "Cut it by 50% or more: text"},
+		{name: "verbs in input", template: "Echo: %s", input: "100%d", want: "Echo: 100%d"},
+		{name: "repeated placeholder", template: "%s, then %s", input: "again", want: "again, then again"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := workflow.Prompt(tt.template).Run(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSequence_StepError(t *testing.T) {
+	cause := errors.New("model unavailable")
+
+	tests := []struct {
+		name string
+		step workflow.Step
+		want string
+	}{
+		{
+			name: "named step",
+			step: workflow.Sequence(workflow.Prompt("%s"), workflow.Named("draft", fail(cause))),
+			want: "draft",
+		},
+		{
+			name: "wrapped named step",
+			step: workflow.Sequence(workflow.Prompt("%s"), func(ctx context.Context, input string) (string, error) {
+				_, err := workflow.Named("draft", fail(cause)).Run(ctx, input)
+				return "", fmt.Errorf("retries exhausted: %w", err)
+			}),
+			want: "draft",
+		},
+		{
+			name: "unnamed step uses position",
+			step: workflow.Sequence(workflow.Prompt("%s"), fail(cause)),
+			want: "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.step.Run(context.Background(), "input")
+
+			var stepErr *workflow.StepError
+			if !errors.As(err, &stepErr) {
+				t.Fatalf("got error %v, want *workflow.StepError", err)
+			}
+
+			if stepErr.Name != tt.want {
+				t.Errorf("got step name %q, want %q", stepErr.Name, tt.want)
+			}
+
+			if !errors.Is(err, cause) {
+				t.Errorf("got error %v, want %v", err, cause)
+			}
+		})
+	}
+}
+
+func TestSequence_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ran := false
+	step := workflow.Sequence(
+		workflow.Transform(func(s string) string { cancel(); return s }),
+		workflow.Transform(func(s string) string { ran = true; return s }),
+	)
+
+	_, err := step.Run(ctx, "input")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+
+	if ran {
+		t.Error("step ran after context cancellation")
+	}
+}
+
+func TestParallel(t *testing.T) {
+	step := workflow.Parallel([]workflow.Step{
+		workflow.Prompt("first: %s"),
+		workflow.Prompt("second: %s"),
+	}, workflow.WithJoin(func(outputs []string) string {
+		return strings.Join(outputs, " | ")
+	}))
+
+	got, err := step.Run(context.Background(), "x")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if got != "first: x | second: x" {
+		t.Errorf("got %q, want %q", got, "first: x | second: x")
+	}
+}
+
+func TestParallel_ErrorPolicies(t *testing.T) {
+	first := errors.New("first failed")
+	second := errors.New("second failed")
+
+	t.Run("fail fast cancels branches", func(t *testing.T) {
+		slow := func(ctx context.Context, input string) (string, error) {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(5 * time.Second):
+				return "slow", nil
+			}
+		}
+
+		start := time.Now()
+		_, err := workflow.Parallel([]workflow.Step{slow, fail(first)}).Run(context.Background(), "x")
+		if !errors.Is(err, first) {
+			t.Errorf("got error %v, want %v", err, first)
+		}
+
+		if time.Since(start) > time.Second {
+			t.Error("fail fast did not cancel the slow branch")
+		}
+	})
+
+	t.Run("collect all", func(t *testing.T) {
+		step := workflow.Parallel([]workflow.Step{fail(first), workflow.Prompt("%s"), fail(second)},
+			workflow.WithErrorPolicy(workflow.CollectAll))
+
+		_, err := step.Run(context.Background(), "x")
+		if !errors.Is(err, first) || !errors.Is(err, second) {
+			t.Errorf("got error %v, want both branch errors", err)
+		}
+	})
+
+	t.Run("best effort keeps successes", func(t *testing.T) {
+		step := workflow.Parallel([]workflow.Step{fail(first), workflow.Prompt("ok %s")},
+			workflow.WithErrorPolicy(workflow.BestEffort))
+
+		got, err := step.Run(context.Background(), "x")
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+
+		if got != "ok x" {
+			t.Errorf("got %q, want %q", got, "ok x")
+		}
+	})
+
+	t.Run("best effort all failed", func(t *testing.T) {
+		step := workflow.Parallel([]workflow.Step{fail(first), fail(second)},
+			workflow.WithErrorPolicy(workflow.BestEffort))
+
+		_, err := step.Run(context.Background(), "x")
+		if !errors.Is(err, workflow.ErrAllBranchesFailed) {
+			t.Errorf("got error %v, want %v", err, workflow.ErrAllBranchesFailed)
+		}
+	})
+}
+
+func TestParallel_Concurrency(t *testing.T) {
+	var running, peak atomic.Int32
+
+	branch := func(ctx context.Context, input string) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := peak.Load()
+			if n <= current || peak.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return input, nil
+	}
+
+	branches := []workflow.Step{branch, branch, branch, branch}
+	if _, err := workflow.Parallel(branches, workflow.WithConcurrency(2)).Run(context.Background(), "x"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if peak.Load() > 2 {
+		t.Errorf("got peak concurrency %d, want at most 2", peak.Load())
+	}
+}
+
+// echoChatter implements only agent.Chatter.
+type echoChatter struct{}
+
+func (echoChatter) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
+	return chatResponse("echo: " + prompt), nil
+}
+
+func (echoChatter) ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	return nil, errors.New("not streamed")
+}
+
+func TestChat_Chatter(t *testing.T) {
+	out, err := workflow.Chat(echoChatter{})(context.Background(), "hi")
+	if err != nil {
+		t.Fatalf("Chat step failed: %v", err)
+	}
+	if out != "echo: hi" {
+		t.Errorf("got %q, want %q", out, "echo: hi")
+	}
+}